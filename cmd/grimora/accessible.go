@@ -0,0 +1,23 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/tui"
+)
+
+const accessibleFlag = "--accessible"
+
+// forceAccessible is set by --accessible and turns on accessible mode
+// whatever the config says.
+var forceAccessible bool
+
+// programOptions runs the TUI in the alternate screen, except in accessible
+// mode: there it draws inline, so the lines it prints for screen readers
+// land in the terminal's scrollback. Call it after tui.ApplyConfig.
+func programOptions() []tea.ProgramOption {
+	if tui.Accessible() {
+		return nil
+	}
+	return []tea.ProgramOption{tea.WithAltScreen()}
+}
@@ -0,0 +1,7 @@
+package main
+
+const lowBandwidthFlag = "--low-bandwidth"
+
+// forceLowBandwidth is set by --low-bandwidth and turns on low-bandwidth
+// mode whatever the config says.
+var forceLowBandwidth bool
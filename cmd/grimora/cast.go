@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/atotto/clipboard"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+const castUsage = "usage: grimora cast <spell-id> [--copy] [--print]"
+
+// runCast implements `grimora cast <spell-id> [--copy] [--print]`, recording
+// that the caller used a spell. --copy also puts the spell on the
+// clipboard and --print writes it to stdout, so fetching a spell and
+// counting the use is one step.
+func runCast(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("cast", flag.ContinueOnError)
+	copyText := fs.Bool("copy", false, "copy the spell text to the clipboard")
+	printText := fs.Bool("print", false, "print the spell text to stdout")
+	ids, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(ids) != 1 {
+		return errors.New(castUsage)
+	}
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	// With --print, stdout carries only the spell so it can be piped.
+	status := io.Writer(os.Stdout)
+	if *printText {
+		status = os.Stderr
+	}
+	return castSpell(context.Background(), c, ids[0], *copyText, *printText, os.Stdout, status)
+}
+
+// castSpell records a cast of spell id and reports the new counts on
+// status. The spell is fetched first when its text is wanted, so a bad ID
+// fails before anything is recorded.
+func castSpell(ctx context.Context, c client.API, id string, copyText, printText bool, out, status io.Writer) error {
+	var spell *domain.Spell
+	if copyText || printText {
+		var err error
+		if spell, err = c.GetSpell(ctx, id); err != nil {
+			return fmt.Errorf("get spell %s: %w", id, err)
+		}
+	}
+	cast, err := c.CastSpell(ctx, id)
+	if err != nil {
+		return fmt.Errorf("cast spell %s: %w", id, err)
+	}
+	if printText {
+		fmt.Fprintln(out, spell.Text)
+	}
+	if copyText {
+		if err := clipboard.WriteAll(spell.Text); err != nil {
+			// No clipboard (e.g. headless SSH); --print still works there.
+			fmt.Fprintln(status, "clipboard unavailable, use --print to get the text")
+		} else {
+			fmt.Fprintln(status, "Copied the spell")
+		}
+	}
+	fmt.Fprintf(status, "Cast recorded · this spell has been cast %d times · you've cast %d\n", cast.Casts, cast.SpellsCast)
+	return nil
+}
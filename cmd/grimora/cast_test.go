@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestCastSpell(t *testing.T) {
+	spell := domain.Spell{ID: uuid.New(), Text: "bisect before you guess", Casts: 4}
+	f := &clienttest.Fake{Spells: []domain.Spell{spell}, ForgeStats: &domain.ForgeStats{SpellsCast: 2}}
+
+	var out, status bytes.Buffer
+	if err := castSpell(context.Background(), f, spell.ID.String(), false, true, &out, &status); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "bisect before you guess\n" {
+		t.Errorf("stdout = %q, want only the spell text", out.String())
+	}
+	if !strings.Contains(status.String(), "cast 5 times · you've cast 3") {
+		t.Errorf("status = %q", status.String())
+	}
+}
+
+func TestCastSpellUnknown(t *testing.T) {
+	f := &clienttest.Fake{}
+	var out bytes.Buffer
+	if err := castSpell(context.Background(), f, uuid.NewString(), false, true, &out, &out); err == nil {
+		t.Fatal("expected an error for an unknown spell")
+	}
+	if f.Count("CastSpell") != 0 {
+		t.Error("expected nothing recorded when the spell can't be fetched")
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// ciStatusAliases maps the spellings CI systems commonly use onto the
+// canonical statuses.
+var ciStatusAliases = map[string]string{
+	"pass":     domain.CIStatusSuccess,
+	"passed":   domain.CIStatusSuccess,
+	"ok":       domain.CIStatusSuccess,
+	"fail":     domain.CIStatusFailure,
+	"failed":   domain.CIStatusFailure,
+	"canceled": domain.CIStatusCancelled,
+}
+
+// runCI dispatches `grimora ci <subcommand>`.
+func runCI(apiURL string, args []string) error {
+	if len(args) == 0 || args[0] != "notify" {
+		return errors.New("usage: grimora ci notify --room <slug> --status <success|failure|cancelled> --title <title>")
+	}
+	a, room, err := parseCINotify(args[1:], os.Getenv)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	// In CI the token comes from a secret via GRIMORA_TOKEN, which readToken
+	// already prefers over the stored session.
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	if _, err := c.PostAnnouncement(context.Background(), room, a); err != nil {
+		return fmt.Errorf("post build result: %w", err)
+	}
+	fmt.Printf("  %s✓%s posted %s to #%s\n", ansiGreen, ansiReset, a.Fields["status"], room)
+	return nil
+}
+
+// parseCINotify turns `ci notify` flags into a build-result announcement.
+// Anything not given on the command line is filled in from the GitHub
+// Actions environment when it's available, so a workflow step only needs
+// --room, --status and --title.
+func parseCINotify(args []string, getenv func(string) string) (client.Announcement, string, error) {
+	fs := flag.NewFlagSet("ci notify", flag.ContinueOnError)
+	room := fs.String("room", "", "room slug to post to (required)")
+	status := fs.String("status", "", "build result: success, failure or cancelled (required)")
+	title := fs.String("title", "", "what was built, e.g. \"api v1.2\" (required)")
+	body := fs.String("body", "", "optional detail line")
+	link := fs.String("url", "", "link to the run (default: the GitHub Actions run)")
+	repo := fs.String("repo", getenv("GITHUB_REPOSITORY"), "repository")
+	ref := fs.String("ref", getenv("GITHUB_REF_NAME"), "branch or tag")
+	commit := fs.String("commit", getenv("GITHUB_SHA"), "commit SHA")
+	if err := fs.Parse(args); err != nil {
+		return client.Announcement{}, "", err
+	}
+
+	slug := strings.TrimPrefix(strings.TrimSpace(*room), "#")
+	if slug == "" {
+		return client.Announcement{}, "", errors.New("--room is required")
+	}
+	st := strings.ToLower(strings.TrimSpace(*status))
+	if alias, ok := ciStatusAliases[st]; ok {
+		st = alias
+	}
+	if !domain.ValidCIStatus(st) {
+		return client.Announcement{}, "", fmt.Errorf("--status must be success, failure or cancelled (got %q)", *status)
+	}
+	if strings.TrimSpace(*title) == "" {
+		return client.Announcement{}, "", errors.New("--title is required")
+	}
+
+	runURL := *link
+	if runURL == "" && getenv("GITHUB_RUN_ID") != "" {
+		server := getenv("GITHUB_SERVER_URL")
+		if server == "" {
+			server = "https://github.com"
+		}
+		runURL = server + "/" + getenv("GITHUB_REPOSITORY") + "/actions/runs/" + getenv("GITHUB_RUN_ID")
+	}
+
+	fields := map[string]string{"status": st}
+	if *repo != "" {
+		fields["repo"] = *repo
+	}
+	if *ref != "" {
+		fields["ref"] = *ref
+	}
+	if *commit != "" {
+		fields["commit"] = shortSHA(*commit)
+	}
+	return client.Announcement{
+		Kind:   domain.MessageKindCI,
+		Title:  strings.TrimSpace(*title),
+		Body:   strings.TrimSpace(*body),
+		URL:    runURL,
+		Fields: fields,
+	}, slug, nil
+}
+
+// shortSHA abbreviates a full commit hash to the usual seven characters.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
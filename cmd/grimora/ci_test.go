@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func fakeEnv(vars map[string]string) func(string) string {
+	return func(k string) string { return vars[k] }
+}
+
+func TestParseCINotify(t *testing.T) {
+	a, room, err := parseCINotify([]string{"--room", "#build-club", "--status", "passed", "--title", "api v1.2"}, fakeEnv(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if room != "build-club" {
+		t.Errorf("room = %q, want build-club", room)
+	}
+	if a.Kind != domain.MessageKindCI || a.Title != "api v1.2" {
+		t.Errorf("announcement = %+v", a)
+	}
+	if a.Fields["status"] != domain.CIStatusSuccess {
+		t.Errorf("status = %q, want success", a.Fields["status"])
+	}
+	if a.URL != "" {
+		t.Errorf("URL = %q, want empty outside Actions", a.URL)
+	}
+}
+
+func TestParseCINotifyGitHubActionsEnv(t *testing.T) {
+	env := fakeEnv(map[string]string{
+		"GITHUB_REPOSITORY": "me/api",
+		"GITHUB_REF_NAME":   "main",
+		"GITHUB_SHA":        "0123456789abcdef",
+		"GITHUB_RUN_ID":     "42",
+	})
+	a, _, err := parseCINotify([]string{"--room", "build-club", "--status", "failure", "--title", "api"}, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.URL != "https://github.com/me/api/actions/runs/42" {
+		t.Errorf("URL = %q", a.URL)
+	}
+	if a.Fields["repo"] != "me/api" || a.Fields["ref"] != "main" || a.Fields["commit"] != "0123456" {
+		t.Errorf("fields = %v", a.Fields)
+	}
+
+	// Explicit flags win over the environment.
+	a, _, err = parseCINotify([]string{"--room", "r", "--status", "success", "--title", "t", "--ref", "v1.2", "--url", "https://ci.example/1"}, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Fields["ref"] != "v1.2" || a.URL != "https://ci.example/1" {
+		t.Errorf("flags should override env: ref=%q url=%q", a.Fields["ref"], a.URL)
+	}
+}
+
+func TestParseCINotifyValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"missing room", []string{"--status", "success", "--title", "t"}},
+		{"missing title", []string{"--room", "r", "--status", "success"}},
+		{"unknown status", []string{"--room", "r", "--status", "flaky", "--title", "t"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := parseCINotify(tt.args, fakeEnv(nil)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,481 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/appdir"
+	"github.com/naveenspark/grimora/internal/tui"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// Flag arguments, for completing what follows a flag. A flag with no arg is
+// a switch; one with choices completes from those.
+const (
+	argText  = "text"
+	argFile  = "file"
+	argDir   = "dir"
+	argTags  = "tags"  // spell tags, from the API via completeHidden
+	argRooms = "rooms" // room slugs, from the API via completeHidden
+)
+
+// completeHidden is the hidden command the completion scripts run for tags
+// and room slugs: `grimora __complete tags`.
+const completeHidden = "__complete"
+
+type completionFlag struct {
+	name    string // as typed, without dashes
+	desc    string
+	arg     string
+	choices []string
+}
+
+type completionCommand struct {
+	name  string
+	desc  string
+	subs  []string // positional subcommands, e.g. invites list
+	flags []completionFlag
+}
+
+// completionGlobals are the flags accepted before any command.
+var completionGlobals = []completionFlag{
+	{name: "debug", desc: "log requests and UI events"},
+	{name: "accessible", desc: "screen reader friendly output"},
+	{name: "low-bandwidth", desc: "poll less, fetch less, animate nothing"},
+	{name: "demo", desc: "try the TUI on a sample community"},
+	{name: "metrics-addr", desc: "serve Prometheus metrics on this address", arg: argText},
+	{name: "view", desc: "open the TUI on this tab", choices: tui.TabNames()},
+	{name: "version", desc: "show version"},
+}
+
+// completionCommands lists every subcommand and its flags. Keep it in step
+// with run and printHelp; TestCompletionCoversCommands checks the commands.
+var completionCommands = []completionCommand{
+	{name: "login", desc: "Authenticate with GitHub"},
+	{name: "logout", desc: "Clear your session"},
+	{name: "open", desc: "Open the TUI on a grimora.ai link"},
+	{name: "tui", desc: "Open the TUI on a tab", flags: []completionFlag{
+		{name: "view", desc: "tab to open on", choices: tui.TabNames()},
+		{name: "tag", desc: "Grimoire tags", arg: argTags},
+		{name: "search", desc: "Grimoire search", arg: argText},
+	}},
+	{name: "update", desc: "Update grimora", flags: []completionFlag{
+		{name: "channel", desc: "release channel", choices: []string{"stable", "beta", "nightly"}},
+		{name: "rollback", desc: "restore the binary the last update replaced"},
+	}},
+	{name: "invites", desc: "List, copy or revoke invites", subs: []string{"list", "copy", "revoke"}},
+	{name: "leaderboard", desc: "Print standings", flags: []completionFlag{
+		{name: "guild", desc: "only rank magicians in this guild", choices: guildIDs()},
+		{name: "city", desc: "only rank magicians in this city", arg: argText},
+		{name: "limit", desc: "number of rows to show", arg: argText},
+		{name: "json", desc: "print JSON instead of a table"},
+	}},
+	{name: "stream", desc: "Print the activity stream", flags: []completionFlag{
+		{name: "follow", desc: "keep printing new events"},
+		{name: "kind", desc: "only these kinds", choices: domain.StreamKinds},
+		{name: "following", desc: "only magicians you follow"},
+		{name: "limit", desc: "number of recent events to print first", arg: argText},
+		{name: "json", desc: "print events as NDJSON"},
+	}},
+	{name: "ci", desc: "Post a build result card", subs: []string{"notify"}, flags: []completionFlag{
+		{name: "room", desc: "room slug to post to", arg: argRooms},
+		{name: "status", desc: "build result", choices: []string{"success", "failure", "cancelled"}},
+		{name: "title", desc: "what was built", arg: argText},
+		{name: "body", desc: "optional detail line", arg: argText},
+		{name: "url", desc: "link to the run", arg: argText},
+		{name: "repo", desc: "repository", arg: argText},
+		{name: "ref", desc: "branch or tag", arg: argText},
+		{name: "commit", desc: "commit SHA", arg: argText},
+	}},
+	{name: "spellbook", desc: "Print a collection", flags: []completionFlag{
+		{name: "collection", desc: `"saved" or a guild chest`, arg: argText},
+		{name: "format", desc: "output format", choices: []string{"md", "html", "pdf"}},
+		{name: "out", desc: "write to this file", arg: argFile},
+	}},
+	{name: "spells", desc: "Write spells to files, publish them or make a feed", subs: []string{"pull", "publish", "feed"}, flags: []completionFlag{
+		{name: "tag", desc: "spells with any of these tags", arg: argTags},
+		{name: "out", desc: "directory (pull) or file (feed) to write", arg: argFile},
+		{name: "gist", desc: "publish as a gist"},
+		{name: "public", desc: "make the gist public"},
+		{name: "repo", desc: "commit into owner/name[/dir]", arg: argText},
+		{name: "min-potency", desc: "only spells at least this potent", arg: argText},
+		{name: "sort", desc: "feed order", choices: []string{"new", "top"}},
+		{name: "limit", desc: "most spells in the feed", arg: argText},
+		{name: "format", desc: "feed format", choices: []string{"atom", "json"}},
+	}},
+	{name: "cast", desc: "Record using a spell", flags: []completionFlag{
+		{name: "copy", desc: "copy the spell text to the clipboard"},
+		{name: "print", desc: "print the spell text to stdout"},
+	}},
+	{name: "draft", desc: "Read a shared draft or suggest an edit", subs: []string{"suggest"}, flags: []completionFlag{
+		{name: "replace", desc: "the text to change", arg: argText},
+		{name: "with", desc: "what to change it to", arg: argText},
+		{name: "context", desc: "edit the context instead of the spell text"},
+		{name: "note", desc: "why, shown to the owner", arg: argText},
+	}},
+	{name: "forge", desc: "Submit a spell from a file or stdin", flags: []completionFlag{
+		{name: "file", desc: "read the spell from this file", arg: argFile},
+		{name: "tag", desc: "the spell's tag", arg: argTags},
+		{name: "stack", desc: "comma-separated tools it's for", arg: argText},
+		{name: "model", desc: "the model it was written for", arg: argText},
+		{name: "context", desc: "a note on when to use it", arg: argText},
+		{name: "json", desc: "print the created spell as JSON"},
+		{name: "watch", desc: "forge drafts in this directory as they're saved", arg: argDir},
+		{name: "yes", desc: "with --watch, forge without asking"},
+		{name: "interval", desc: "with --watch, how often to look for saves", arg: argText},
+	}},
+	{name: "journal", desc: "Search everything you've posted", subs: []string{"grep", "path"}, flags: []completionFlag{
+		{name: "i", desc: "ignore case"},
+		{name: "kind", desc: "only entries of this kind", choices: []string{"room", "dm", "spell", "project"}},
+		{name: "since", desc: "only entries on or after this date", arg: argText},
+		{name: "json", desc: "print matching entries as NDJSON"},
+	}},
+	{name: "tour", desc: "Practice in a private sandbox room"},
+	{name: "profile", desc: "Show or set your availability", flags: []completionFlag{
+		{name: "timezone", desc: "IANA time zone", arg: argText},
+		{name: "active-hours", desc: "local hours you're usually around", arg: argText},
+	}},
+	{name: "completion", desc: "Print a shell completion script", subs: []string{"bash", "zsh", "fish"}},
+	{name: "terms", desc: "Terms of Service"},
+	{name: "privacy", desc: "Privacy Policy"},
+	{name: "faq", desc: "Frequently Asked Questions"},
+	{name: "version", desc: "Show version"},
+	{name: "help", desc: "Show help"},
+}
+
+func guildIDs() []string {
+	ids := make([]string, 0, len(domain.Guilds))
+	for id := range domain.Guilds {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// dash returns the flag as typed: -i for one letter, --name otherwise.
+func (f completionFlag) dash() string {
+	if len(f.name) == 1 {
+		return "-" + f.name
+	}
+	return "--" + f.name
+}
+
+// runCompletion implements `grimora completion bash|zsh|fish`.
+func runCompletion(args []string, w io.Writer) error {
+	if len(args) != 1 {
+		return errors.New("usage: grimora completion bash|zsh|fish")
+	}
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(w)
+	case "zsh":
+		writeZshCompletion(w)
+	case "fish":
+		writeFishCompletion(w)
+	default:
+		return fmt.Errorf("unknown shell %q (want bash, zsh or fish)", args[0])
+	}
+	return nil
+}
+
+func writeBashCompletion(w io.Writer) {
+	var names []string
+	for _, c := range completionCommands {
+		names = append(names, c.name)
+	}
+	for _, f := range completionGlobals {
+		names = append(names, f.dash())
+	}
+
+	fmt.Fprintf(w, `# bash completion for grimora. Load it with:
+#   source <(grimora completion bash)
+_grimora() {
+    local cur prev cmd i
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        case "${COMP_WORDS[i]}" in
+        -*) ;;
+        *) cmd="${COMP_WORDS[i]}"; break ;;
+        esac
+    done
+    if [[ $prev == --metrics-addr ]]; then
+        return
+    fi
+    if [[ -z $cmd ]]; then
+        COMPREPLY=($(compgen -W %q -- "$cur"))
+        return
+    fi
+    case "$cmd $prev" in
+`, strings.Join(names, " "))
+	for _, c := range completionCommands {
+		for _, f := range c.flags {
+			var reply string
+			switch {
+			case len(f.choices) > 0:
+				reply = fmt.Sprintf(`COMPREPLY=($(compgen -W %q -- "$cur"))`, strings.Join(f.choices, " "))
+			case f.arg == argFile:
+				reply = `COMPREPLY=($(compgen -f -- "$cur"))`
+			case f.arg == argDir:
+				reply = `COMPREPLY=($(compgen -d -- "$cur"))`
+			case f.arg == argTags, f.arg == argRooms:
+				reply = fmt.Sprintf(`COMPREPLY=($(compgen -W "$(grimora %s %s 2>/dev/null)" -- "$cur"))`, completeHidden, f.arg)
+			case f.arg == argText:
+				reply = ":" // free text: nothing to offer
+			default:
+				continue
+			}
+			fmt.Fprintf(w, "    %q)\n        %s\n        return ;;\n", c.name+" "+f.dash(), reply)
+		}
+	}
+	fmt.Fprint(w, "    esac\n    case \"$cmd\" in\n")
+	for _, c := range completionCommands {
+		words := slices.Clone(c.subs)
+		for _, f := range c.flags {
+			words = append(words, f.dash())
+		}
+		if len(words) > 0 {
+			fmt.Fprintf(w, "    %s) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", c.name, strings.Join(words, " "))
+		}
+	}
+	fmt.Fprint(w, "    esac\n}\ncomplete -F _grimora grimora\n")
+}
+
+// zshQuote escapes s for a single-quoted zsh word inside _arguments specs.
+func zshQuote(s string) string {
+	r := strings.NewReplacer(`'`, `'\''`, "[", `\[`, "]", `\]`, ":", `\:`)
+	return r.Replace(s)
+}
+
+// zshSpec is the _arguments spec for f.
+func zshSpec(f completionFlag) string {
+	spec := f.dash() + "[" + zshQuote(f.desc) + "]"
+	switch {
+	case len(f.choices) > 0:
+		spec += ":" + f.name + ":(" + strings.Join(f.choices, " ") + ")"
+	case f.arg == argFile:
+		spec += ":file:_files"
+	case f.arg == argDir:
+		spec += ":directory:_files -/"
+	case f.arg == argTags, f.arg == argRooms:
+		spec += ":" + f.arg + ":_grimora_api " + f.arg
+	case f.arg == argText:
+		spec += ":" + f.name + ": "
+	}
+	return "'" + spec + "'"
+}
+
+func writeZshCompletion(w io.Writer) {
+	fmt.Fprint(w, `#compdef grimora
+# zsh completion for grimora. Load it with:
+#   source <(grimora completion zsh)
+# or save it as _grimora somewhere on your $fpath.
+
+_grimora_api() {
+    local -a values
+    values=(${(f)"$(grimora `+completeHidden+` $1 2>/dev/null)"})
+    compadd -a values
+}
+
+_grimora() {
+    local -a commands
+    commands=(
+`)
+	for _, c := range completionCommands {
+		fmt.Fprintf(w, "        '%s:%s'\n", c.name, zshQuote(c.desc))
+	}
+	fmt.Fprint(w, "    )\n    local curcontext=\"$curcontext\" state line\n    _arguments -C \\\n")
+	for _, f := range completionGlobals {
+		fmt.Fprintf(w, "        %s \\\n", zshSpec(f))
+	}
+	fmt.Fprint(w, `        '1: :->command' \
+        '*:: :->args'
+    case $state in
+    command)
+        _describe 'command' commands ;;
+    args)
+        case $words[1] in
+`)
+	for _, c := range completionCommands {
+		if len(c.subs) == 0 && len(c.flags) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "        %s)\n            _arguments", c.name)
+		if len(c.subs) > 0 {
+			fmt.Fprintf(w, " \\\n                '1:command:(%s)'", strings.Join(c.subs, " "))
+		}
+		for _, f := range c.flags {
+			fmt.Fprintf(w, " \\\n                %s", zshSpec(f))
+		}
+		fmt.Fprint(w, " ;;\n")
+	}
+	fmt.Fprint(w, `        esac ;;
+    esac
+}
+
+if [[ $zsh_eval_context[-1] == loadautofunc ]]; then
+    _grimora "$@"
+else
+    compdef _grimora grimora
+fi
+`)
+}
+
+// fishQuote single-quotes s for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func writeFishCompletion(w io.Writer) {
+	fmt.Fprint(w, "# fish completion for grimora. Load it with:\n#   grimora completion fish | source\n")
+	fmt.Fprint(w, "complete -c grimora -f\n")
+	for _, f := range completionGlobals {
+		fmt.Fprintf(w, "complete -c grimora -n __fish_use_subcommand -l %s -d %s", f.name, fishQuote(f.desc))
+		if f.arg != "" {
+			fmt.Fprint(w, " -x")
+		}
+		fmt.Fprintln(w)
+	}
+	for _, c := range completionCommands {
+		fmt.Fprintf(w, "complete -c grimora -n __fish_use_subcommand -a %s -d %s\n", c.name, fishQuote(c.desc))
+	}
+	for _, c := range completionCommands {
+		cond := fishQuote("__fish_seen_subcommand_from " + c.name)
+		if len(c.subs) > 0 {
+			fmt.Fprintf(w, "complete -c grimora -n %s -a %s\n", cond, fishQuote(strings.Join(c.subs, " ")))
+		}
+		for _, f := range c.flags {
+			opt := "-l " + f.name
+			if len(f.name) == 1 {
+				opt = "-o " + f.name
+			}
+			fmt.Fprintf(w, "complete -c grimora -n %s %s -d %s", cond, opt, fishQuote(f.desc))
+			switch {
+			case len(f.choices) > 0:
+				fmt.Fprintf(w, " -x -a %s", fishQuote(strings.Join(f.choices, " ")))
+			case f.arg == argFile, f.arg == argDir:
+				fmt.Fprint(w, " -r -F")
+			case f.arg == argTags, f.arg == argRooms:
+				fmt.Fprintf(w, " -x -a %s", fishQuote("(grimora "+completeHidden+" "+f.arg+" 2>/dev/null)"))
+			case f.arg == argText:
+				fmt.Fprint(w, " -x")
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// completionCacheTTL is how long fetched tags and rooms are offered before
+// the next completion asks the API again.
+const completionCacheTTL = time.Hour
+
+// completionFetchTimeout bounds the API call a completion may make, so a
+// slow network never hangs the shell.
+const completionFetchTimeout = 2 * time.Second
+
+// completionCache is ~/.grimora/completion.json.
+type completionCache struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Tags      []string  `json:"tags,omitempty"`
+	Rooms     []string  `json:"rooms,omitempty"`
+}
+
+func completionCachePath() (string, error) {
+	return appdir.Path("completion.json")
+}
+
+func loadCompletionCache(path string) completionCache {
+	var cache completionCache
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &cache) //nolint:errcheck // a bad cache is refetched
+	}
+	return cache
+}
+
+func saveCompletionCache(path string, cache completionCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// completionSource fetches what the cache holds.
+type completionSource interface {
+	TagStats(ctx context.Context) ([]domain.TagStat, error)
+	ListRooms(ctx context.Context) ([]domain.Room, error)
+}
+
+// refreshCompletionCache fetches tags and room slugs. Tags fall back to the
+// built-in list if the API has none.
+func refreshCompletionCache(ctx context.Context, src completionSource, now time.Time) (completionCache, error) {
+	cache := completionCache{FetchedAt: now}
+	stats, err := src.TagStats(ctx)
+	if err != nil {
+		return cache, err
+	}
+	for _, s := range stats {
+		cache.Tags = append(cache.Tags, s.Tag)
+	}
+	if len(cache.Tags) == 0 {
+		cache.Tags = slices.Clone(domain.ValidTags)
+	}
+	rooms, err := src.ListRooms(ctx)
+	if err != nil {
+		return cache, err
+	}
+	for _, r := range rooms {
+		cache.Rooms = append(cache.Rooms, r.Slug)
+	}
+	return cache, nil
+}
+
+// runCompleteHidden implements `grimora __complete tags|rooms` for the
+// completion scripts: one value per line from the cache, refreshed from the
+// API when it's stale and there's a login. Failures print what's cached, or
+// for tags the built-in list, and never an error.
+func runCompleteHidden(apiURL string, args []string, w io.Writer) error {
+	if len(args) != 1 || (args[0] != argTags && args[0] != argRooms) {
+		return nil
+	}
+	path, err := completionCachePath()
+	if err != nil {
+		return nil
+	}
+	cache := loadCompletionCache(path)
+	now := time.Now()
+	if now.Sub(cache.FetchedAt) > completionCacheTTL {
+		if c, err := authedClient(apiURL); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), completionFetchTimeout)
+			fresh, err := refreshCompletionCache(ctx, c, now)
+			cancel()
+			if err == nil {
+				cache = fresh
+				saveCompletionCache(path, cache) //nolint:errcheck // best effort; refetched next time
+			}
+		}
+	}
+	values := cache.Rooms
+	if args[0] == argTags {
+		values = cache.Tags
+		if len(values) == 0 {
+			values = domain.ValidTags
+		}
+	}
+	for _, v := range values {
+		fmt.Fprintln(w, v) //nolint:errcheck
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// TestCompletionCoversCommands checks every command run dispatches on is
+// offered for completion.
+func TestCompletionCoversCommands(t *testing.T) {
+	src, err := os.ReadFile("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := string(src)
+	body = body[strings.Index(body, "func run() error"):]
+	body = body[:strings.Index(body, "token := readToken()")]
+	known := map[string]bool{}
+	for _, c := range completionCommands {
+		known[c.name] = true
+	}
+	for _, m := range regexp.MustCompile(`"([a-z][a-z-]*)"`).FindAllStringSubmatch(body, -1) {
+		if strings.HasPrefix(m[1], "GRIMORA") {
+			continue
+		}
+		if !known[m[1]] {
+			t.Errorf("command %q has no completion", m[1])
+		}
+	}
+}
+
+func TestCompletionScripts(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		var buf bytes.Buffer
+		if err := runCompletion([]string{shell}, &buf); err != nil {
+			t.Fatalf("completion %s: %v", shell, err)
+		}
+		script := buf.String()
+		for _, c := range completionCommands {
+			if !strings.Contains(script, c.name) {
+				t.Errorf("%s script is missing command %q", shell, c.name)
+			}
+			for _, f := range c.flags {
+				if !strings.Contains(script, f.name) {
+					t.Errorf("%s script is missing %s flag %q", shell, c.name, f.name)
+				}
+			}
+		}
+		if !strings.Contains(script, completeHidden) || !strings.Contains(script, argRooms) {
+			t.Errorf("%s script doesn't complete rooms from the API", shell)
+		}
+	}
+	if err := runCompletion([]string{"powershell"}, &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an unknown shell")
+	}
+}
+
+func TestRefreshCompletionCache(t *testing.T) {
+	f := &clienttest.Fake{
+		Spells: []domain.Spell{{Tag: "cli"}, {Tag: "cli"}, {Tag: "agents"}},
+		Rooms:  []domain.Room{{Slug: "the-hall"}, {Slug: "rust"}},
+	}
+	now := time.Now()
+	cache, err := refreshCompletionCache(context.Background(), f, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(cache.Tags, ",") != "cli,agents" || strings.Join(cache.Rooms, ",") != "the-hall,rust" || !cache.FetchedAt.Equal(now) {
+		t.Errorf("cache = %+v", cache)
+	}
+}
+
+func TestCompleteHiddenUsesFreshCache(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".grimora", "completion.json")
+	if err := saveCompletionCache(path, completionCache{FetchedAt: time.Now(), Tags: []string{"cli"}, Rooms: []string{"rust"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	// A fresh cache is used as is, without the API.
+	if err := runCompleteHidden("http://unused.invalid", []string{"rooms"}, &buf); err != nil || buf.String() != "rust\n" {
+		t.Errorf("rooms = %q, %v", buf.String(), err)
+	}
+	buf.Reset()
+	runCompleteHidden("http://unused.invalid", []string{"tags"}, &buf) //nolint:errcheck
+	if buf.String() != "cli\n" {
+		t.Errorf("tags = %q", buf.String())
+	}
+}
+
+func TestCompleteHiddenSignedOutFallsBack(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var buf bytes.Buffer
+	if err := runCompleteHidden("http://unused.invalid", []string{"tags"}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), domain.ValidTags[0]) {
+		t.Errorf("tags without a login or cache = %q, want the built-in tags", buf.String())
+	}
+}
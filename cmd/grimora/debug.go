@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	glog "github.com/naveenspark/grimora/internal/log"
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+const debugFlag = "--debug"
+
+// startDebugLog turns on file logging when --debug or GRIMORA_DEBUG asks for
+// it, returning the observer that traces API requests and a closer for the
+// log file. With debugging off both are nil.
+func startDebugLog(debug bool) (client.RequestObserver, io.Closer, error) {
+	if !debug && !glog.EnabledByEnv() {
+		return nil, nil, nil
+	}
+	path, err := glog.Path()
+	if err != nil {
+		return nil, nil, err
+	}
+	closer, err := glog.Setup(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	glog.Info("grimora starting", "version", version, "args", os.Args[1:])
+	fmt.Fprintf(os.Stderr, "debug: logging to %s\n", path)
+	return glog.Request, closer, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/mockapi"
+	"github.com/naveenspark/grimora/internal/tui"
+)
+
+const demoFlag = "--demo"
+
+// demoMode is set by --demo: the TUI talks to a made-up community served
+// from memory instead of the real API, with no login needed.
+var demoMode bool
+
+// checkDemoArgs refuses --demo with a subcommand. The demo community only
+// backs the TUI; a subcommand would reach the real API with the real login.
+// `open`, `tui` and flags like --view still start the TUI, so they're fine.
+func checkDemoArgs(args []string) error {
+	if len(args) == 0 || args[0] == "open" || args[0] == "tui" || strings.HasPrefix(args[0], "-") {
+		return nil
+	}
+	return fmt.Errorf("%s only runs the TUI, so it can't be used with `grimora %s`", demoFlag, args[0])
+}
+
+// startDemo serves the demo community on a free local port. The caller
+// closes it once the TUI exits.
+func startDemo() (*mockapi.Server, error) {
+	srv, err := mockapi.Start("127.0.0.1:0", mockapi.Demo())
+	if err != nil {
+		return nil, fmt.Errorf("start demo: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "demo: serving a sample community on %s\n", srv.URL)
+	return srv, nil
+}
+
+// runDemoTUI runs app without the magician's saved session, drafts or
+// journal, and without saving anything back: nothing from the demo should
+// leak into their real one.
+func runDemoTUI(app tui.App, abandon context.CancelFunc) error {
+	p := tea.NewProgram(app, programOptions()...)
+	stop := handleShutdown(p, abandon)
+	defer stop()
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("tui error: %w", err)
+	}
+	return nil
+}
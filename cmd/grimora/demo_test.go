@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestStartDemoSignsIn(t *testing.T) {
+	srv, err := startDemo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close() //nolint:errcheck
+	check := checkStartup(newClient(srv.URL, "demo"), 0)
+	if err := <-check.pending; err != nil {
+		t.Errorf("signing in to the demo: %v", err)
+	}
+}
+
+func TestCheckDemoArgs(t *testing.T) {
+	for _, args := range [][]string{nil, {"tui", "--view", "board"}, {"open", "https://grimora.ai/spells/x"}, {"--view", "grimoire"}} {
+		if err := checkDemoArgs(args); err != nil {
+			t.Errorf("checkDemoArgs(%q) = %v, want the TUI to start", args, err)
+		}
+	}
+	for _, args := range [][]string{{"cast", "x"}, {"leaderboard"}, {"ci", "notify"}, {"login"}} {
+		if err := checkDemoArgs(args); err == nil {
+			t.Errorf("checkDemoArgs(%q) = nil, want it refused before it reaches the real API", args)
+		}
+	}
+}
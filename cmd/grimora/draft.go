@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+const draftUsage = `usage: grimora draft <link>
+       grimora draft suggest <link> --replace <text> --with <text> [--context] [--note <why>]`
+
+// runDraft implements `grimora draft`, the collaborator's side of a shared
+// spell draft: read it from its share link, then suggest edits the owner
+// reviews in the spell form.
+func runDraft(apiURL string, args []string) error {
+	suggest := len(args) > 0 && args[0] == "suggest"
+	if suggest {
+		args = args[1:]
+	}
+	fs := flag.NewFlagSet("draft suggest", flag.ContinueOnError)
+	original := fs.String("replace", "", "the text to change, exactly as it appears in the draft")
+	replacement := fs.String("with", "", "what to change it to")
+	inContext := fs.Bool("context", false, "edit the context instead of the spell text")
+	note := fs.String("note", "", "why, shown to the owner")
+	refs, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(refs) != 1 {
+		return errors.New(draftUsage)
+	}
+	id, err := parseDraftRef(refs[0])
+	if err != nil {
+		return err
+	}
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	if !suggest {
+		return showDraft(context.Background(), c, id, os.Stdout)
+	}
+	field := "text"
+	if *inContext {
+		field = "context"
+	}
+	return suggestDraftEdit(context.Background(), c, id, field, *original, *replacement, *note, os.Stdout)
+}
+
+// parseDraftRef takes a draft's ID from its share link, or the bare ID.
+func parseDraftRef(ref string) (string, error) {
+	s := strings.TrimSpace(ref)
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		s = s[:i]
+	}
+	s = path.Base(strings.TrimSuffix(s, "/"))
+	if uuid.Validate(s) != nil {
+		return "", fmt.Errorf("not a draft link: %q", ref)
+	}
+	return s, nil
+}
+
+// showDraft prints a shared draft so a collaborator can pick what to
+// suggest.
+func showDraft(ctx context.Context, c client.API, id string, out io.Writer) error {
+	d, err := c.GetSpellDraft(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get draft %s: %w", id, err)
+	}
+	fmt.Fprintln(out, d.Text)
+	if d.Context != "" {
+		fmt.Fprintf(out, "\n%sContext:%s %s\n", ansiSlate, ansiReset, d.Context)
+	}
+	if n := len(d.Pending()); n > 0 {
+		fmt.Fprintf(out, "\n%s%d suggestion(s) waiting for the owner%s\n", ansiSlate, n, ansiReset)
+	}
+	return nil
+}
+
+// suggestDraftEdit proposes replacing original with replacement in one
+// field of the draft. The draft is fetched first, so a span that isn't in
+// it fails here rather than reaching the owner as a suggestion that can't
+// be applied.
+func suggestDraftEdit(ctx context.Context, c client.API, id, field, original, replacement, note string, out io.Writer) error {
+	if original == "" {
+		return errors.New("--replace is required: the text to change, exactly as it appears in the draft")
+	}
+	if original == replacement {
+		return errors.New("--with must differ from --replace")
+	}
+	d, err := c.GetSpellDraft(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get draft %s: %w", id, err)
+	}
+	current := d.Text
+	if field == "context" {
+		current = d.Context
+	}
+	if !strings.Contains(current, original) {
+		return fmt.Errorf("%q isn't in the draft's %s", original, field)
+	}
+	if _, err := c.SuggestDraftEdit(ctx, id, field, original, replacement, note); err != nil {
+		return fmt.Errorf("suggest edit: %w", err)
+	}
+	fmt.Fprintf(out, "  %s✓%s suggested · the owner will see it next time they check the draft\n", ansiGreen, ansiReset)
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestParseDraftRef(t *testing.T) {
+	id := uuid.NewString()
+	for _, ref := range []string{id, "grimora.ai/drafts/" + id, "https://grimora.ai/d/" + id + "/?from=dm"} {
+		if got, err := parseDraftRef(ref); err != nil || got != id {
+			t.Errorf("parseDraftRef(%q) = %q, %v", ref, got, err)
+		}
+	}
+	if _, err := parseDraftRef("https://grimora.ai/drafts/"); err == nil {
+		t.Error("expected an error for a link without an ID")
+	}
+}
+
+func TestSuggestDraftEdit(t *testing.T) {
+	d := domain.SpellDraft{ID: uuid.New(), Text: "Always bisect first.", Context: "for regressions"}
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "grace"}, Drafts: []domain.SpellDraft{d}}
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	if err := suggestDraftEdit(ctx, f, d.ID.String(), "text", "Always", "Usually", "too strong", &out); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := f.GetSpellDraft(ctx, d.ID.String())
+	if p := got.Pending(); len(p) != 1 || p[0].AuthorLogin != "grace" || p[0].Replacement != "Usually" {
+		t.Errorf("pending = %+v", p)
+	}
+
+	// The span is checked against the field being edited.
+	if err := suggestDraftEdit(ctx, f, d.ID.String(), "context", "Always", "Usually", "", &out); err == nil {
+		t.Error("expected an error for text that isn't in the context")
+	}
+	if n := f.Count("SuggestDraftEdit"); n != 1 {
+		t.Errorf("SuggestDraftEdit called %d times, want only the valid suggestion sent", n)
+	}
+}
+
+func TestShowDraft(t *testing.T) {
+	d := domain.SpellDraft{ID: uuid.New(), Text: "Always bisect first.", Context: "for regressions"}
+	f := &clienttest.Fake{Drafts: []domain.SpellDraft{d}}
+	var out bytes.Buffer
+	if err := showDraft(context.Background(), f, d.ID.String(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Always bisect first.") || !strings.Contains(out.String(), "for regressions") {
+		t.Errorf("output = %q", out.String())
+	}
+}
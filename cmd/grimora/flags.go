@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// globalValueFlags are the global flags that take their value as the next
+// argument, so scanning past them skips that value too.
+var globalValueFlags = []string{viewFlag, "--tag", "--search", metricsAddrFlag}
+
+// extractGlobalFlag pulls flag out of the global flags leading args,
+// returning whether it was there and the remaining arguments for subcommand
+// dispatch. The global flags end at the first argument that isn't a flag,
+// so a subcommand's own arguments are left alone.
+func extractGlobalFlag(args []string, flag string) (bool, []string) {
+	found := false
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == flag:
+			found = true
+		case !strings.HasPrefix(a, "-"):
+			return found, append(rest, args[i:]...)
+		default:
+			rest = append(rest, a)
+			if isGlobalValueFlag(a) && i+1 < len(args) {
+				i++
+				rest = append(rest, args[i])
+			}
+		}
+	}
+	return found, rest
+}
+
+// extractGlobalValue pulls flag and its value, as "flag value" or
+// "flag=value", out of the global flags leading args, like
+// extractGlobalFlag. The value is empty when flag isn't there.
+func extractGlobalValue(args []string, flag string) (string, []string, error) {
+	var value string
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == flag:
+			if i+1 >= len(args) {
+				return "", nil, fmt.Errorf("%s needs a value", flag)
+			}
+			i++
+			value = args[i]
+		case strings.HasPrefix(a, flag+"="):
+			value = strings.TrimPrefix(a, flag+"=")
+		case !strings.HasPrefix(a, "-"):
+			return value, append(rest, args[i:]...), nil
+		default:
+			rest = append(rest, a)
+			if isGlobalValueFlag(a) && i+1 < len(args) {
+				i++
+				rest = append(rest, args[i])
+			}
+		}
+	}
+	return value, rest, nil
+}
+
+// isGlobalValueFlag reports whether a is a global flag whose value is the
+// next argument.
+func isGlobalValueFlag(a string) bool {
+	for _, f := range globalValueFlags {
+		if a == f || a == "-"+strings.TrimPrefix(f, "--") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractGlobalFlag(t *testing.T) {
+	for _, tt := range []struct {
+		args  []string
+		flag  string
+		found bool
+		rest  []string
+	}{
+		{[]string{"--debug", "invites", "copy"}, debugFlag, true, []string{"invites", "copy"}},
+		{[]string{"--demo", "--accessible"}, demoFlag, true, []string{"--accessible"}},
+		{[]string{"--view", "grimoire", "--low-bandwidth"}, lowBandwidthFlag, true, []string{"--view", "grimoire"}},
+		{[]string{"--tag=go", "--accessible"}, accessibleFlag, true, []string{"--tag=go"}},
+		{[]string{"--metrics-addr", ":9090", "--debug"}, debugFlag, true, []string{"--metrics-addr", ":9090"}},
+		// A subcommand's own arguments are its business.
+		{[]string{"tour", "--accessible"}, accessibleFlag, false, []string{"tour", "--accessible"}},
+		{[]string{"leaderboard", "--json"}, debugFlag, false, []string{"leaderboard", "--json"}},
+	} {
+		found, rest := extractGlobalFlag(tt.args, tt.flag)
+		if found != tt.found || !slices.Equal(rest, tt.rest) {
+			t.Errorf("extractGlobalFlag(%q, %s) = %v, %q, want %v, %q", tt.args, tt.flag, found, rest, tt.found, tt.rest)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+const forgeUsage = "usage: grimora forge --tag tag [--file path | < path] [--stack a,b] [--model m] [--context text] [--json]\n       grimora forge --watch dir [--yes] [--interval 1s] [--tag tag] [--stack a,b] [--model m] [--context text]"
+
+// runForge implements `grimora forge`, submitting a spell to the Forge
+// without opening the TUI. The text comes from --file, or from stdin when
+// it's piped in, so editors and scripts can forge what they have open.
+// With --watch it forges a directory of drafts as they're saved instead.
+func runForge(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("forge", flag.ContinueOnError)
+	file := fs.String("file", "", `read the spell from this file ("-" for stdin)`)
+	tag := fs.String("tag", "", "the spell's tag, e.g. debugging")
+	stack := fs.String("stack", "", "comma-separated tools it's for, e.g. go,postgres")
+	model := fs.String("model", "", "the model it was written for")
+	spellContext := fs.String("context", "", "a note on when to use it")
+	asJSON := fs.Bool("json", false, "print the created spell as JSON")
+	watch := fs.String("watch", "", "forge the drafts in this directory as they're saved")
+	yes := fs.Bool("yes", false, "with --watch, forge without asking first")
+	interval := fs.Duration("interval", time.Second, "with --watch, how often to look for saves")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New(forgeUsage)
+	}
+	if *watch != "" {
+		if *file != "" || *asJSON {
+			return errors.New("--watch forges its own files and prints as it goes; drop --file and --json")
+		}
+		// Frontmatter in each draft beats these; they fill in what it lacks.
+		defaults := client.CreateSpellRequest{Tag: *tag, Model: *model, Context: *spellContext}
+		for t := range strings.SplitSeq(*stack, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				defaults.Stack = append(defaults.Stack, t)
+			}
+		}
+		return runForgeWatch(apiURL, *watch, defaults, *yes, *interval)
+	}
+
+	path := *file
+	if path == "" {
+		if stdinIsTerminal() {
+			return errors.New(forgeUsage)
+		}
+		path = "-"
+	}
+	text, err := readSpellText(path, os.Stdin)
+	if err != nil {
+		return err
+	}
+	req, err := forgeRequest(text, *tag, *stack, *model, *spellContext)
+	if err != nil {
+		return err
+	}
+
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	spell, err := forgeSpell(context.Background(), c, openJournal(), req)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(spell)
+	}
+	printForged(os.Stdout, spell)
+	return nil
+}
+
+// stdinIsTerminal reports whether stdin is a terminal rather than a pipe or
+// file, i.e. nobody is feeding grimora a spell.
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// readSpellText reads the spell at path, or from stdin for "-".
+func readSpellText(path string, stdin io.Reader) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("read stdin: %w", err)
+		}
+		return string(data), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read spell: %w", err)
+	}
+	return string(data), nil
+}
+
+// forgeRequest builds the request for a new spell, checking locally what
+// the API would reject anyway so scripts fail before a round trip.
+func forgeRequest(text, tag, stack, model, spellContext string) (client.CreateSpellRequest, error) {
+	text = strings.TrimSpace(text)
+	if problem := domain.SpellLengthProblem(text); problem != "" {
+		return client.CreateSpellRequest{}, fmt.Errorf("spell is %s", problem)
+	}
+	tag = domain.NormalizeTag(tag)
+	if tag == "" {
+		return client.CreateSpellRequest{}, errors.New("--tag is required, e.g. --tag debugging")
+	}
+	if !domain.WellFormedTag(tag) {
+		return client.CreateSpellRequest{}, fmt.Errorf("--tag %q: use 2-%d lowercase letters, digits or hyphens", tag, domain.MaxTagLen)
+	}
+	var tools []string
+	for t := range strings.SplitSeq(stack, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tools = append(tools, t)
+		}
+	}
+	return client.CreateSpellRequest{
+		Text:    text,
+		Tag:     tag,
+		Model:   strings.TrimSpace(model),
+		Stack:   tools,
+		Context: strings.TrimSpace(spellContext),
+	}, nil
+}
+
+// forgeSpell submits req and records the new spell in j, like the create
+// form does. Field errors from the API are spelled out, since there's no form
+// to mark them on.
+func forgeSpell(ctx context.Context, c client.API, j *journal.Journal, req client.CreateSpellRequest) (*domain.Spell, error) {
+	spell, err := c.CreateSpell(ctx, req)
+	if err != nil {
+		if fields := client.FieldErrors(err); len(fields) > 0 {
+			msgs := make([]string, len(fields))
+			for i, fe := range fields {
+				why := fe.Message
+				if why == "" {
+					why = fe.Code
+				}
+				msgs[i] = fe.Field + ": " + why
+			}
+			return nil, fmt.Errorf("forge rejected the spell: %s", strings.Join(msgs, "; "))
+		}
+		return nil, fmt.Errorf("forge spell: %w", err)
+	}
+	recordPost(j, journal.Entry{Kind: journal.KindSpell, ID: spell.ID.String(), Where: req.Tag, Text: req.Text})
+	return spell, nil
+}
+
+// printForged reports the new spell's ID and where the Grimoire's verdict
+// stands.
+func printForged(w io.Writer, s *domain.Spell) {
+	fmt.Fprintf(w, "Forged %s\n", s.ID)
+	switch s.Status {
+	case "published":
+		fmt.Fprintf(w, "The Grimoire accepted it · potency %d\n", s.Potency)
+		if s.Voice != "" {
+			fmt.Fprintf(w, "  %q\n", s.Voice)
+		}
+	case "removed":
+		fmt.Fprintln(w, "The Grimoire rejected it")
+	default:
+		fmt.Fprintln(w, "The Grimoire is still reading it")
+	}
+	fmt.Fprintln(w, domain.SpellPermalink(s.ID.String()))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+const testSpellText = "Reproduce the bug in a failing test before touching the code."
+
+func TestForgeRequest(t *testing.T) {
+	req, err := forgeRequest("\n"+testSpellText+"\n\n", "Debugging", "go, postgres,,", " gpt ", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Text != testSpellText || req.Tag != "debugging" || req.Model != "gpt" {
+		t.Errorf("forgeRequest() = %+v", req)
+	}
+	if !slices.Equal(req.Stack, []string{"go", "postgres"}) {
+		t.Errorf("Stack = %q", req.Stack)
+	}
+}
+
+func TestForgeRequestRejects(t *testing.T) {
+	tests := []struct {
+		name, text, tag, want string
+	}{
+		{"short", "too short", "debugging", "too short"},
+		{"no tag", testSpellText, "", "--tag is required"},
+		{"bad tag", testSpellText, "c++", "--tag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := forgeRequest(tt.text, tt.tag, "", "", "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("forgeRequest() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSpellText(t *testing.T) {
+	got, err := readSpellText("-", strings.NewReader(testSpellText))
+	if err != nil || got != testSpellText {
+		t.Errorf("readSpellText(stdin) = %q, %v", got, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "prompt.md")
+	if err := os.WriteFile(path, []byte(testSpellText), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := readSpellText(path, nil); err != nil || got != testSpellText {
+		t.Errorf("readSpellText(file) = %q, %v", got, err)
+	}
+	if _, err := readSpellText(filepath.Join(t.TempDir(), "missing.md"), nil); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestForgeSpell(t *testing.T) {
+	f := &clienttest.Fake{}
+	req := client.CreateSpellRequest{Text: testSpellText, Tag: "debugging", Stack: []string{"go"}}
+	path := filepath.Join(t.TempDir(), "journal.ndjson")
+	spell, err := forgeSpell(context.Background(), f, journal.Open(path), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Spells) != 1 || f.Spells[0].ID != spell.ID || f.Spells[0].Text != testSpellText {
+		t.Errorf("server has %+v", f.Spells)
+	}
+	entries, err := journal.Search(path, func(journal.Entry) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Kind != journal.KindSpell || entries[0].ID != spell.ID.String() || entries[0].Where != "debugging" {
+		t.Errorf("journal = %+v, want the forged spell", entries)
+	}
+}
+
+func TestForgeSpellFieldErrors(t *testing.T) {
+	f := &clienttest.Fake{Fail: map[string]error{"CreateSpell": &client.HTTPError{
+		StatusCode: 422,
+		Fields:     []client.FieldError{{Field: "tag", Message: "unknown tag"}, {Field: "text", Code: client.CodeTooLong}},
+	}}}
+	_, err := forgeSpell(context.Background(), f, nil, client.CreateSpellRequest{})
+	if err == nil || !strings.Contains(err.Error(), "tag: unknown tag; text: "+client.CodeTooLong) {
+		t.Errorf("forgeSpell() error = %v", err)
+	}
+}
+
+func TestPrintForged(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		spell domain.Spell
+		want  string
+	}{
+		{domain.Spell{ID: id, Status: "published", Potency: 3, Voice: "A method, not a mood."}, "accepted it · potency 3\n  \"A method, not a mood.\"\n"},
+		{domain.Spell{ID: id, Status: "removed"}, "rejected it\n"},
+		{domain.Spell{ID: id, Status: "pending"}, "still reading it\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		printForged(&out, &tt.spell)
+		got := out.String()
+		if !strings.HasPrefix(got, "Forged "+id.String()+"\n") || !strings.Contains(got, tt.want) ||
+			!strings.HasSuffix(got, "/spells/"+id.String()+"\n") {
+			t.Errorf("printForged(%s) = %q", tt.spell.Status, got)
+		}
+	}
+}
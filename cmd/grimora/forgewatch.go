@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/export"
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+// spellMapFile sits in a watched directory and remembers which spell each
+// draft became, so saving the draft again updates that spell instead of
+// forging a duplicate.
+const spellMapFile = ".grimora-spells.json"
+
+// spellMapping is what spellMapFile records for one draft.
+type spellMapping struct {
+	ID  string `json:"id"`
+	Sum string `json:"sum"` // sha256 of the draft as last forged
+}
+
+// forgeWatcher forges the drafts in a directory as they're saved.
+type forgeWatcher struct {
+	dir      string
+	c        client.API
+	defaults client.CreateSpellRequest // for drafts without their own frontmatter
+	yes      bool                      // forge without asking
+	in       *bufio.Reader
+	out      io.Writer
+	answers  <-chan string // lines read from in, once confirm first asks
+
+	mapping map[string]spellMapping // by file name
+	seen    map[string]string       // sum of each draft when last looked at
+	primed  bool                    // the first scan has run
+
+	journal *journal.Journal // new spells are recorded here
+}
+
+// runForgeWatch implements `grimora forge --watch dir`, polling dir every
+// interval until interrupted.
+func runForgeWatch(apiURL, dir string, defaults client.CreateSpellRequest, yes bool, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+	if info, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("watch: %w", err)
+	} else if !info.IsDir() {
+		return fmt.Errorf("watch: %s is not a directory", dir)
+	}
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	w, err := newForgeWatcher(dir, c, defaults, yes, os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	w.journal = openJournal()
+	fmt.Fprintf(os.Stdout, "Watching %s for spell drafts · ctrl+c to stop\n", dir)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.scan(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func newForgeWatcher(dir string, c client.API, defaults client.CreateSpellRequest, yes bool, in io.Reader, out io.Writer) (*forgeWatcher, error) {
+	w := &forgeWatcher{
+		dir:      dir,
+		c:        c,
+		defaults: defaults,
+		yes:      yes,
+		in:       bufio.NewReader(in),
+		out:      out,
+		mapping:  make(map[string]spellMapping),
+		seen:     make(map[string]string),
+	}
+	data, err := os.ReadFile(filepath.Join(dir, spellMapFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return w, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", spellMapFile, err)
+	}
+	if err := json.Unmarshal(data, &w.mapping); err != nil {
+		return nil, fmt.Errorf("read %s: %w", spellMapFile, err)
+	}
+	return w, nil
+}
+
+// isDraft reports whether name in a watched directory is a spell draft:
+// markdown or plain text, not hidden and not an editor's swap file.
+func isDraft(name string) bool {
+	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+		return false
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".md" || ext == ".txt"
+}
+
+// scan looks over the drafts once and offers to forge each one saved since
+// the last scan. On the first scan, drafts that haven't changed since they
+// were last forged are left alone, and so are drafts never forged at all:
+// they're only offered once they're saved.
+func (w *forgeWatcher) scan(ctx context.Context) error {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return fmt.Errorf("watch: %w", err)
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !isDraft(name) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(w.dir, name))
+		if err != nil {
+			// Most likely removed or mid-save; the next scan will see.
+			continue
+		}
+		raw := sha256.Sum256(data)
+		sum := hex.EncodeToString(raw[:])
+		if w.seen[name] == sum {
+			continue
+		}
+		w.seen[name] = sum
+		m, mapped := w.mapping[name]
+		if m.Sum == sum || (!w.primed && !mapped) {
+			continue
+		}
+		w.forge(ctx, name, string(data), sum)
+	}
+	w.primed = true
+	return nil
+}
+
+// forge offers to forge the draft name, or to update the spell it became,
+// and records the result. Problems with one draft are reported and leave
+// it for the next save.
+func (w *forgeWatcher) forge(ctx context.Context, name, data, sum string) {
+	draft, err := export.ReadSpellFile(data)
+	if err != nil {
+		fmt.Fprintf(w.out, "%s: %v\n", name, err)
+		return
+	}
+	tag, stack, model, spellContext := draft.Tag, draft.Stack, draft.Model, draft.Context
+	if tag == "" {
+		tag = w.defaults.Tag
+	}
+	if len(stack) == 0 {
+		stack = w.defaults.Stack
+	}
+	if model == "" {
+		model = w.defaults.Model
+	}
+	if spellContext == "" {
+		spellContext = w.defaults.Context
+	}
+	req, err := forgeRequest(draft.Text, tag, strings.Join(stack, ","), model, spellContext)
+	if err != nil {
+		fmt.Fprintf(w.out, "%s: %v\n", name, err)
+		return
+	}
+
+	// A draft pulled with `grimora spells pull` names its spell in the
+	// frontmatter; one forged here is in the mapping.
+	id := w.mapping[name].ID
+	if id == "" && draft.ID != uuid.Nil {
+		id = draft.ID.String()
+	}
+	question := "Forge a new spell from " + name + "?"
+	if id != "" {
+		question = "Update spell " + id + " from " + name + "?"
+	}
+	if !w.confirm(ctx, question) {
+		return
+	}
+
+	if id != "" {
+		spell, err := w.c.UpdateSpell(ctx, id, req)
+		switch {
+		case err == nil:
+			w.remember(name, spell.ID.String(), sum)
+			fmt.Fprintf(w.out, "Updated %s · the Grimoire is reading it again\n", spell.ID)
+			return
+		case client.IsForbidden(err):
+			fmt.Fprintf(w.out, "%s: spell %s isn't yours to edit; drop its id to forge your own\n", name, id)
+			return
+		case !client.IsNotFound(err):
+			fmt.Fprintf(w.out, "%s: update spell: %v\n", name, err)
+			return
+		}
+		// The spell is gone, so the draft starts over as a new one.
+		fmt.Fprintf(w.out, "%s: spell %s no longer exists, forging it anew\n", name, id)
+	}
+	spell, err := forgeSpell(ctx, w.c, w.journal, req)
+	if err != nil {
+		fmt.Fprintf(w.out, "%s: %v\n", name, err)
+		return
+	}
+	w.remember(name, spell.ID.String(), sum)
+	printForged(w.out, spell)
+}
+
+// confirm asks question unless --yes was given. Anything but yes, including
+// stdin running out or ctx ending while it waits, is a no.
+func (w *forgeWatcher) confirm(ctx context.Context, question string) bool {
+	if w.yes {
+		return true
+	}
+	if w.answers == nil {
+		w.answers = readLines(w.in)
+	}
+	fmt.Fprint(w.out, question+" [y/N] ")
+	var line string
+	select {
+	case line = <-w.answers:
+	case <-ctx.Done():
+		fmt.Fprintln(w.out)
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
+
+// readLines reads in line by line in the background, so a prompt can stop
+// waiting without leaving a read behind that would take the next answer.
+// The channel is closed when in runs out.
+func readLines(in *bufio.Reader) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		for {
+			line, err := in.ReadString('\n')
+			if line != "" {
+				lines <- line
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return lines
+}
+
+// remember records that name became spell id and saves the mapping. A
+// mapping that can't be saved is reported but doesn't stop the watch.
+func (w *forgeWatcher) remember(name, id, sum string) {
+	w.mapping[name] = spellMapping{ID: id, Sum: sum}
+	data, err := json.MarshalIndent(w.mapping, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(w.dir, spellMapFile), append(data, '\n'), 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(w.out, "save %s: %v\n", spellMapFile, err)
+	}
+}
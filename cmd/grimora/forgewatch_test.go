@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func writeDraft(t *testing.T, dir, name, text string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestForgeWatcher(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	f := &clienttest.Fake{Me: &domain.Magician{ID: uuid.New(), GitHubLogin: "me"}}
+	writeDraft(t, dir, "duck.md", testSpellText)
+	writeDraft(t, dir, "notes.json", "{}")
+
+	var out bytes.Buffer
+	in := strings.NewReader("y\ny\nn\n")
+	w, err := newForgeWatcher(dir, f, client.CreateSpellRequest{Tag: "debugging"}, false, in, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Calls()) != 0 {
+		t.Fatalf("calls = %v, want drafts left alone until they're saved", f.Calls())
+	}
+
+	writeDraft(t, dir, "duck.md", testSpellText+" Then fix it.")
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Spells) != 1 || f.Spells[0].Tag != "debugging" || !strings.Contains(out.String(), "Forge a new spell from duck.md? [y/N]") {
+		t.Fatalf("spells = %+v, output:\n%s", f.Spells, out.String())
+	}
+	id := f.Spells[0].ID.String()
+
+	writeDraft(t, dir, "duck.md", "---\ntag: testing\n---\n"+testSpellText)
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Spells) != 1 || f.Spells[0].Tag != "testing" || f.Count("UpdateSpell") != 1 {
+		t.Fatalf("spells = %+v, want %s updated in place", f.Spells, id)
+	}
+	if !strings.Contains(out.String(), "Update spell "+id+" from duck.md?") {
+		t.Errorf("output:\n%s", out.String())
+	}
+
+	writeDraft(t, dir, "duck.md", testSpellText+" Declined.")
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if f.Count("UpdateSpell") != 1 {
+		t.Error("n should leave the spell alone")
+	}
+
+	// A fresh watch remembers the mapping, and the declined save is still
+	// waiting to be forged.
+	out.Reset()
+	w, err = newForgeWatcher(dir, f, client.CreateSpellRequest{Tag: "debugging"}, true, nil, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if f.Count("UpdateSpell") != 2 || !strings.HasSuffix(f.Spells[0].Text, "Declined.") || strings.Contains(out.String(), "[y/N]") {
+		t.Errorf("spells = %+v, want the pending save applied without asking, output:\n%s", f.Spells, out.String())
+	}
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if f.Count("UpdateSpell") != 2 {
+		t.Error("an unchanged draft shouldn't be forged again")
+	}
+}
+
+func TestForgeWatcherLostSpell(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	me := uuid.New()
+	theirs := domain.Spell{ID: uuid.New(), MagicianID: uuid.New(), Text: testSpellText, Tag: "debugging"}
+	f := &clienttest.Fake{Me: &domain.Magician{ID: me}, Spells: []domain.Spell{theirs}}
+
+	var out bytes.Buffer
+	w, err := newForgeWatcher(dir, f, client.CreateSpellRequest{}, true, nil, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Someone else's spell, pulled to disk, can't be edited.
+	writeDraft(t, dir, "theirs.md", "---\nid: "+theirs.ID.String()+"\ntag: debugging\n---\n"+testSpellText+" Mine now.")
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Spells) != 1 || !strings.Contains(out.String(), "isn't yours to edit") {
+		t.Fatalf("spells = %+v, output:\n%s", f.Spells, out.String())
+	}
+
+	// A spell that's gone is forged anew.
+	gone := uuid.New().String()
+	writeDraft(t, dir, "gone.md", "---\nid: "+gone+"\ntag: debugging\n---\n"+testSpellText)
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Spells) != 2 || w.mapping["gone.md"].ID != f.Spells[1].ID.String() {
+		t.Errorf("mapping = %+v, want gone.md mapped to its new spell", w.mapping)
+	}
+
+	writeDraft(t, dir, "untagged.md", testSpellText)
+	if err := w.scan(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "untagged.md: --tag is required") {
+		t.Errorf("output:\n%s", out.String())
+	}
+}
+
+func TestForgeWatcherConfirmStopsOnCancel(t *testing.T) {
+	in, stdin := io.Pipe()
+	defer stdin.Close() //nolint:errcheck
+	var out bytes.Buffer
+	w, err := newForgeWatcher(t.TempDir(), &clienttest.Fake{}, client.CreateSpellRequest{}, false, in, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if w.confirm(ctx, "Forge?") {
+		t.Error("a prompt nobody answers should be a no")
+	}
+
+	// The answer typed next goes to the next prompt.
+	go stdin.Write([]byte("y\n")) //nolint:errcheck
+	if !w.confirm(context.Background(), "Forge?") {
+		t.Error("y should be a yes")
+	}
+}
+
+func TestIsDraft(t *testing.T) {
+	for name, want := range map[string]bool{
+		"duck.md": true, "notes.TXT": true, ".grimora-spells.json": false,
+		".duck.md.swp": false, "duck.md~": false, "image.png": false,
+	} {
+		if got := isDraft(name); got != want {
+			t.Errorf("isDraft(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
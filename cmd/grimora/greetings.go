@@ -69,13 +69,35 @@ func printHelp() {
 	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	commands := []struct{ cmd, desc string }{
 		{"grimora", "Enter the Hall (interactive TUI)"},
+		{"grimora open <link>", "Open the TUI on a grimora.ai message, spell or magician"},
+		{"grimora --view <tab>", "Open the TUI on a tab (--tag, --search for the Grimoire)"},
 		{"grimora login", "Authenticate with GitHub"},
 		{"grimora logout", "Clear your session"},
-		{"grimora update", "Check for updates"},
+		{"grimora update", "Update (--channel stable|beta|nightly, --rollback)"},
+		{"grimora invites", "List invites (copy [code], revoke <code>)"},
+		{"grimora leaderboard", "Print standings (--guild, --city, --limit, --json)"},
+		{"grimora stream", "Print the activity stream (--follow, --kind, --following, --json)"},
+		{"grimora spellbook", "Print a collection (--collection, --format md|html|pdf, --out)"},
+		{"grimora spells pull", "Write spells to ./prompts/<slug>.md (--tag, --out dir)"},
+		{"grimora spells publish", "Publish spells to GitHub (--gist, --public, --repo)"},
+		{"grimora spells feed", "Write recent spells as an Atom or JSON feed (--tag, --min-potency, --out)"},
+		{"grimora cast <id>", "Record using a spell (--copy, --print)"},
+		{"grimora draft <link>", "Read a shared draft (suggest <link> --replace, --with, --note)"},
+		{"grimora forge", "Submit a spell from --file or stdin (--tag, --stack, --json, --watch dir)"},
+		{"grimora journal grep", "Search everything you've posted (-i, --kind, --since)"},
+		{"grimora tour", "Practice chatting in a private sandbox room"},
+		{"grimora profile", "Show or set your time zone (--timezone, --active-hours)"},
+		{"grimora ci notify", "Post a build result card (--room, --status, --title)"},
+		{"grimora completion", "Print a shell completion script (bash|zsh|fish)"},
 		{"grimora terms", "Terms of Service"},
 		{"grimora privacy", "Privacy Policy"},
 		{"grimora faq", "Frequently Asked Questions"},
 		{"grimora --version", "Show version"},
+		{"--metrics-addr <a>", "Serve Prometheus metrics on <a> (e.g. :9090)"},
+		{"--debug", "Log requests and UI events to ~/.grimora/logs"},
+		{"--accessible", "Screen reader friendly: no animation or box drawing"},
+		{"--low-bandwidth", "For slow links: poll less, fetch less, no animation"},
+		{"--demo", "Try the TUI on a sample community, no login needed"},
 		{"grimora help", "You are here"},
 	}
 
@@ -108,5 +130,9 @@ func printGrimoireGreeting() {
 		Foreground(lipgloss.Color("245")).
 		Render("To enter: grimora login")
 
-	fmt.Printf("\n%s\n\n%s\n%s\n\n%s\n\n", title, quote, attrib, hint)
+	practice := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245")).
+		Render("To practice first: grimora tour")
+
+	fmt.Printf("\n%s\n\n%s\n%s\n\n%s\n%s\n\n", title, quote, attrib, hint, practice)
 }
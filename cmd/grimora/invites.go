@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/atotto/clipboard"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// errNotLoggedIn is returned by commands that need a session when there is none.
+var errNotLoggedIn = errors.New("not logged in — run: grimora login")
+
+// authedClient returns an API client for the stored session.
+func authedClient(apiURL string) (*client.Client, error) {
+	token := readToken()
+	if token == "" {
+		return nil, errNotLoggedIn
+	}
+	return newClient(apiURL, token), nil
+}
+
+// runInvites dispatches `grimora invites [list|copy|revoke]`.
+func runInvites(apiURL string, args []string) error {
+	sub := "list"
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch sub {
+	case "list", "ls":
+		invites, err := c.ListInvites(ctx)
+		if err != nil {
+			return fmt.Errorf("list invites: %w", err)
+		}
+		// Progress is best-effort; older servers don't report it.
+		progress, _ := c.GetInviteProgress(ctx) //nolint:errcheck // optional
+		printInvites(os.Stdout, invites, progress)
+		return nil
+
+	case "copy":
+		invites, err := c.ListInvites(ctx)
+		if err != nil {
+			return fmt.Errorf("list invites: %w", err)
+		}
+		code := ""
+		if len(args) > 0 {
+			code = args[0]
+		}
+		inv, err := pickInvite(invites, code)
+		if err != nil {
+			return err
+		}
+		if err := clipboard.WriteAll(inv.JoinURL()); err != nil {
+			// No clipboard (e.g. headless SSH) — the link is still printed below.
+			fmt.Println(ansiSlate + "clipboard unavailable, copy it manually:" + ansiReset)
+			fmt.Println(inv.JoinURL())
+			return nil
+		}
+		fmt.Printf("Copied %s%s%s\n", ansiEmerald, inv.JoinURL(), ansiReset)
+		return nil
+
+	case "revoke":
+		if len(args) == 0 {
+			return errors.New("usage: grimora invites revoke <code>")
+		}
+		code := args[0]
+		invites, err := c.ListInvites(ctx)
+		if err != nil {
+			return fmt.Errorf("list invites: %w", err)
+		}
+		inv, err := findInvite(invites, code)
+		if err != nil {
+			return err
+		}
+		if inv.Claimed() {
+			return fmt.Errorf("invite %s was already claimed and can't be revoked", code)
+		}
+		if err := c.RevokeInvite(ctx, code); err != nil {
+			return fmt.Errorf("revoke invite: %w", err)
+		}
+		fmt.Printf("Revoked %s\n", code)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown invites command %q (want list, copy or revoke)", sub)
+	}
+}
+
+// findInvite returns the invite with the given code.
+func findInvite(invites []domain.Invite, code string) (domain.Invite, error) {
+	for _, inv := range invites {
+		if inv.Code == code {
+			return inv, nil
+		}
+	}
+	return domain.Invite{}, fmt.Errorf("no invite with code %q", code)
+}
+
+// pickInvite returns the invite with the given code, or the first unclaimed
+// one when code is empty.
+func pickInvite(invites []domain.Invite, code string) (domain.Invite, error) {
+	if code != "" {
+		return findInvite(invites, code)
+	}
+	for _, inv := range invites {
+		if !inv.Claimed() {
+			return inv, nil
+		}
+	}
+	return domain.Invite{}, errors.New("no unclaimed invites left")
+}
+
+// printInvites writes the invite table and, if known, progress toward the next code.
+func printInvites(w io.Writer, invites []domain.Invite, progress *domain.InviteProgress) {
+	if len(invites) == 0 {
+		fmt.Fprintln(w, "No invite codes yet.")
+	}
+	for _, inv := range invites {
+		if !inv.Claimed() {
+			fmt.Fprintf(w, "  %s%-12s%s %s\n", ansiEmerald, inv.Code, ansiReset, inv.JoinURL())
+			continue
+		}
+		status := "claimed"
+		if inv.UsedByLogin != "" {
+			status += " by @" + inv.UsedByLogin
+		}
+		if inv.UsedAt != nil {
+			status += " on " + inv.UsedAt.Format("Jan 2, 2006")
+		}
+		fmt.Fprintf(w, "  %s%-12s %s%s\n", ansiSlate, inv.Code, status, ansiReset)
+	}
+	if progress != nil && progress.SpellsRequired > 0 {
+		fmt.Fprintf(w, "\n  %d/%d spells toward your next invite\n", progress.SpellsForged, progress.SpellsRequired)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func testInvites() []domain.Invite {
+	used := uuid.New()
+	at := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	return []domain.Invite{
+		{Code: "CLAIMED1", UsedBy: &used, UsedByLogin: "alice", UsedAt: &at},
+		{Code: "OPEN1"},
+		{Code: "OPEN2"},
+	}
+}
+
+func TestPickInvite(t *testing.T) {
+	inv, err := pickInvite(testInvites(), "")
+	if err != nil || inv.Code != "OPEN1" {
+		t.Errorf("pickInvite(\"\") = %q, %v; want OPEN1", inv.Code, err)
+	}
+	inv, err = pickInvite(testInvites(), "OPEN2")
+	if err != nil || inv.Code != "OPEN2" {
+		t.Errorf("pickInvite(OPEN2) = %q, %v", inv.Code, err)
+	}
+	if _, err := pickInvite(testInvites(), "NOPE"); err == nil {
+		t.Error("expected error for unknown code")
+	}
+	if _, err := pickInvite(testInvites()[:1], ""); err == nil {
+		t.Error("expected error when every invite is claimed")
+	}
+}
+
+func TestPrintInvites(t *testing.T) {
+	var buf bytes.Buffer
+	printInvites(&buf, testInvites(), &domain.InviteProgress{SpellsForged: 3, SpellsRequired: 10})
+	out := buf.String()
+	for _, want := range []string{"claimed by @alice on Mar 14, 2026", "grimora.ai/join/OPEN1", "3/10 spells"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunInvitesRequiresLogin(t *testing.T) {
+	t.Setenv("GRIMORA_TOKEN", "")
+	t.Setenv("HOME", t.TempDir())
+	if err := runInvites("http://unused.invalid", []string{"list"}); err != errNotLoggedIn {
+		t.Errorf("expected errNotLoggedIn, got %v", err)
+	}
+}
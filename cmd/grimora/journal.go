@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/journal"
+	glog "github.com/naveenspark/grimora/internal/log"
+)
+
+// journalKinds are the --kind values `grimora journal grep` accepts.
+var journalKinds = []string{journal.KindRoom, journal.KindDM, journal.KindSpell, journal.KindProject}
+
+// openJournal returns the journal commands record what they post in, or nil
+// when there's no home directory to keep it in.
+func openJournal() *journal.Journal {
+	path, err := journal.Path()
+	if err != nil {
+		return nil
+	}
+	return journal.Open(path)
+}
+
+// recordPost appends e to j. As in the TUI, a failure is only logged: the
+// post itself already went through.
+func recordPost(j *journal.Journal, e journal.Entry) {
+	if err := j.Append(e); err != nil {
+		glog.Warn("journal append failed", "err", err)
+	}
+}
+
+// runJournal dispatches `grimora journal <command>`.
+func runJournal(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: grimora journal grep <pattern> [-i] [--kind k] [--since yyyy-mm-dd] [--json]")
+	}
+	switch args[0] {
+	case "grep":
+		return runJournalGrep(args[1:])
+	case "path":
+		path, err := journal.Path()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	}
+	return fmt.Errorf("unknown journal command %q (want grep or path)", args[0])
+}
+
+// runJournalGrep implements `grimora journal grep <pattern>`, searching the
+// local journal of everything sent from this machine.
+func runJournalGrep(args []string) error {
+	fs := flag.NewFlagSet("journal grep", flag.ContinueOnError)
+	ignoreCase := fs.Bool("i", false, "ignore case")
+	kind := fs.String("kind", "", "only entries of this kind: room, dm, spell or project")
+	since := fs.String("since", "", "only entries on or after this date (yyyy-mm-dd)")
+	asJSON := fs.Bool("json", false, "print matching entries as NDJSON")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: grimora journal grep <pattern> [-i] [--kind k] [--since yyyy-mm-dd] [--json]")
+	}
+	if *kind != "" && !slices.Contains(journalKinds, *kind) {
+		return fmt.Errorf("unknown kind %q (want %s)", *kind, strings.Join(journalKinds, ", "))
+	}
+	var from time.Time
+	if *since != "" {
+		if from, err = time.ParseInLocation(time.DateOnly, *since, time.Local); err != nil {
+			return fmt.Errorf("--since: want a date like 2026-01-31")
+		}
+	}
+	pattern := positional[0]
+	if *ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("bad pattern: %w", err)
+	}
+
+	path, err := journal.Path()
+	if err != nil {
+		return err
+	}
+	entries, err := journal.Search(path, func(e journal.Entry) bool {
+		return (*kind == "" || e.Kind == *kind) && !e.Time.Before(from) && re.MatchString(e.Text)
+	})
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	printJournal(os.Stdout, entries, re, colorEnabled(os.Stdout))
+	return nil
+}
+
+// journalWhere labels where an entry was posted: #room, @login, the spell's
+// tag or the workshop.
+func journalWhere(e journal.Entry) string {
+	switch e.Kind {
+	case journal.KindRoom:
+		return "#" + e.Where
+	case journal.KindDM:
+		return "@" + e.Where
+	case journal.KindSpell:
+		return "spell:" + e.Where
+	case journal.KindProject:
+		if e.Where != "" {
+			return "workshop:" + e.Where
+		}
+		return "workshop"
+	}
+	return e.Kind
+}
+
+// printJournal writes one block per entry: local time and where it was
+// posted, then the text with matches highlighted and continuation lines
+// indented.
+func printJournal(w io.Writer, entries []journal.Entry, re *regexp.Regexp, color bool) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No matches.")
+		return
+	}
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+	for _, e := range entries {
+		text := e.Text
+		if color {
+			text = re.ReplaceAllStringFunc(text, func(m string) string { return paint(ansiGold, m) })
+		}
+		stamp := paint(ansiSlate, e.Time.Local().Format("2006-01-02 15:04"))
+		fmt.Fprintf(w, "%s  %s  %s\n", stamp, paint(ansiBold, journalWhere(e)), strings.ReplaceAll(text, "\n", "\n    "))
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/journal"
+)
+
+func TestPrintJournal(t *testing.T) {
+	at := time.Date(2026, 10, 1, 9, 5, 0, 0, time.Local)
+	entries := []journal.Entry{
+		{Time: at, Kind: journal.KindRoom, Where: "the-hall", Text: "anyone tried sqlc?"},
+		{Time: at, Kind: journal.KindDM, Where: "ada", Text: "sqlc tip:\nuse named params"},
+		{Time: at, Kind: journal.KindSpell, Where: "data", Text: "Generate sqlc queries"},
+	}
+	var buf bytes.Buffer
+	printJournal(&buf, entries, regexp.MustCompile("sqlc"), false)
+	want := "2026-10-01 09:05  #the-hall  anyone tried sqlc?\n" +
+		"2026-10-01 09:05  @ada  sqlc tip:\n    use named params\n" +
+		"2026-10-01 09:05  spell:data  Generate sqlc queries\n"
+	if got := buf.String(); got != want {
+		t.Errorf("printJournal() =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	printJournal(&buf, entries[:1], regexp.MustCompile("sqlc"), true)
+	if !ansiRe.MatchString(buf.String()) || !bytes.Contains(buf.Bytes(), []byte(ansiGold+"sqlc"+ansiReset)) {
+		t.Errorf("expected highlighted match, got %q", buf.String())
+	}
+
+	buf.Reset()
+	printJournal(&buf, nil, regexp.MustCompile("x"), false)
+	if buf.String() != "No matches.\n" {
+		t.Errorf("got %q", buf.String())
+	}
+}
+
+func TestRunJournalGrep(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path, err := journal.Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := journal.Open(path)
+	j.Append(journal.Entry{Kind: journal.KindRoom, Where: "the-hall", Text: "hello"}) //nolint:errcheck
+
+	if err := runJournalGrep([]string{"hello", "--kind", "dm"}); err != nil {
+		t.Errorf("runJournalGrep() error: %v", err)
+	}
+	if err := runJournalGrep([]string{"hello", "--kind", "tweet"}); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+	if err := runJournalGrep([]string{"hello", "--since", "yesterday"}); err == nil {
+		t.Error("expected error for bad date")
+	}
+	if err := runJournalGrep([]string{"("}); err == nil {
+		t.Error("expected error for bad pattern")
+	}
+	if err := runJournalGrep(nil); err == nil {
+		t.Error("expected usage error without a pattern")
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// maxLeaderboardLimit mirrors the API's page size cap.
+const maxLeaderboardLimit = 100
+
+// runLeaderboard implements `grimora leaderboard [--guild g] [--city c] [--limit n] [--json]`.
+// The leaderboard is public, so a stored session is used if present but not required.
+func runLeaderboard(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	guild := fs.String("guild", "", "only rank magicians in this guild")
+	city := fs.String("city", "", "only rank magicians in this city")
+	limit := fs.Int("limit", 20, "number of rows to show (1-100)")
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	g := strings.ToLower(*guild)
+	if g != "" && !domain.ValidGuildID(g) {
+		return fmt.Errorf("unknown guild %q", *guild)
+	}
+	if *limit < 1 || *limit > maxLeaderboardLimit {
+		return fmt.Errorf("--limit must be between 1 and %d", maxLeaderboardLimit)
+	}
+
+	c := newClient(apiURL, readToken())
+	entries, err := c.GetLeaderboard(context.Background(), g, *city, *limit, 0)
+	if err != nil {
+		return fmt.Errorf("get leaderboard: %w", err)
+	}
+
+	if *asJSON {
+		return writeLeaderboardJSON(os.Stdout, entries)
+	}
+	printLeaderboard(os.Stdout, entries, colorEnabled(os.Stdout))
+	return nil
+}
+
+// writeLeaderboardJSON writes entries as an indented JSON array ([] when empty).
+func writeLeaderboardJSON(w io.Writer, entries []domain.LeaderboardEntry) error {
+	if entries == nil {
+		entries = []domain.LeaderboardEntry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// printLeaderboard writes an aligned table. Widths are measured on the plain
+// text so ANSI colors never skew the columns.
+func printLeaderboard(w io.Writer, entries []domain.LeaderboardEntry, color bool) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No magicians ranked yet.")
+		return
+	}
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+
+	header := []string{"RANK", "MAGICIAN", "GUILD", "CITY", "SPELLS", "POTENCY"}
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = []string{
+			"#" + strconv.Itoa(e.Rank),
+			"@" + e.Login,
+			e.GuildID,
+			e.City,
+			strconv.Itoa(e.SpellsForged),
+			"P" + strconv.Itoa(e.TotalPotency),
+		}
+	}
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = len(h)
+	}
+	for _, r := range rows {
+		for i, cell := range r {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+	// Numeric columns are right-aligned.
+	numeric := []bool{true, false, false, false, true, true}
+	pad := func(s string, i int) string {
+		gap := strings.Repeat(" ", widths[i]-len([]rune(s)))
+		if numeric[i] {
+			return gap + s
+		}
+		return s + gap
+	}
+
+	var hdr []string
+	for i, h := range header {
+		hdr = append(hdr, pad(h, i))
+	}
+	fmt.Fprintln(w, "  "+paint(ansiSlate, strings.Join(hdr, "  ")))
+	for i, r := range rows {
+		cells := make([]string, len(r))
+		for j, cell := range r {
+			cells[j] = pad(cell, j)
+		}
+		cells[0] = paint(ansiSlate, cells[0])
+		cells[1] = paint(ansiBold, cells[1])
+		cells[5] = paint(ansiGold, cells[5])
+		if entries[i].Rank <= 3 {
+			cells[0] = paint(ansiEmerald, pad(r[0], 0))
+		}
+		fmt.Fprintln(w, "  "+strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+}
+
+// colorEnabled reports whether f is a terminal and NO_COLOR is unset, so
+// piping into Slack or CI logs yields plain text.
+func colorEnabled(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+func testLeaderboard() []domain.LeaderboardEntry {
+	return []domain.LeaderboardEntry{
+		{Rank: 1, Login: "alice", GuildID: "loomari", City: "Berlin", SpellsForged: 42, TotalPotency: 120},
+		{Rank: 2, Login: "bobthebuilder", GuildID: "cipher", SpellsForged: 7, TotalPotency: 9},
+	}
+}
+
+func TestPrintLeaderboardAligned(t *testing.T) {
+	var buf bytes.Buffer
+	printLeaderboard(&buf, testLeaderboard(), false)
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+	if strings.Contains(buf.String(), "\033[") {
+		t.Error("expected no ANSI codes with color off")
+	}
+	// Every row's guild column starts at the same offset as the header's.
+	col := strings.Index(lines[0], "GUILD")
+	for _, l := range lines[1:] {
+		if !strings.HasPrefix(l[col:], "loomari") && !strings.HasPrefix(l[col:], "cipher") {
+			t.Errorf("guild column misaligned in %q", l)
+		}
+	}
+	if !strings.Contains(lines[1], "@alice") || !strings.Contains(lines[1], "P120") {
+		t.Errorf("unexpected first row %q", lines[1])
+	}
+}
+
+func TestPrintLeaderboardColorKeepsAlignment(t *testing.T) {
+	var plain, colored bytes.Buffer
+	printLeaderboard(&plain, testLeaderboard(), false)
+	printLeaderboard(&colored, testLeaderboard(), true)
+	stripped := ansiRe.ReplaceAllString(colored.String(), "")
+	if stripped != plain.String() {
+		t.Errorf("colored output differs from plain once ANSI is stripped:\n%s\nvs\n%s", stripped, plain.String())
+	}
+}
+
+func TestWriteLeaderboardJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeLeaderboardJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("empty leaderboard = %q, want []", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeLeaderboardJSON(&buf, testLeaderboard()); err != nil {
+		t.Fatal(err)
+	}
+	var got []domain.LeaderboardEntry
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got) != 2 || got[0].Login != "alice" {
+		t.Errorf("round trip = %+v", got)
+	}
+}
+
+func TestRunLeaderboardValidatesFlags(t *testing.T) {
+	if err := runLeaderboard("http://unused.invalid", []string{"--guild", "dragons"}); err == nil {
+		t.Error("expected error for unknown guild")
+	}
+	if err := runLeaderboard("http://unused.invalid", []string{"--limit", "0"}); err == nil {
+		t.Error("expected error for zero limit")
+	}
+}
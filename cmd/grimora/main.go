@@ -9,43 +9,132 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/naveenspark/grimora/internal/appdir"
 	"github.com/naveenspark/grimora/internal/browser"
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/internal/drafts"
+	"github.com/naveenspark/grimora/internal/outbox"
+	"github.com/naveenspark/grimora/internal/state"
 	"github.com/naveenspark/grimora/internal/tui"
+	semver "github.com/naveenspark/grimora/internal/version"
 	"github.com/naveenspark/grimora/pkg/client"
 )
 
 // version is set at build time via -ldflags "-X main.version=..."
 var version = "dev"
 
+// requestObserver is handed every API request made by any client newClient
+// returns, for --metrics-addr and --debug. run sets it before dispatching
+// to a subcommand.
+var requestObserver client.RequestObserver
+
+// newClient returns an API client that names this build in its User-Agent,
+// e.g. "grimora/1.4.0 (darwin/arm64)". When token is the saved session's,
+// the client renews it with the saved refresh token once it expires. extra
+// options apply after those.
+func newClient(apiURL, token string, extra ...client.Option) *client.Client {
+	ua := "grimora/" + version + " (" + runtime.GOOS + "/" + runtime.GOARCH + ")"
+	opts := []client.Option{client.WithUserAgent(ua), client.WithRequestObserver(requestObserver)}
+	if saved := savedTokens(); token != "" && saved.Access == token && saved.Refresh != "" {
+		opts = append(opts, client.WithRefreshToken(saved.Refresh, saveTokens))
+	}
+	return client.New(apiURL, token, append(opts, extra...)...)
+}
+
 func main() {
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		if hint := errorHint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, hint)
+		}
 		os.Exit(1)
 	}
 }
 
+// errorHint suggests a fix for API failures any command can run into.
+func errorHint(err error) string {
+	switch {
+	case client.IsUnauthorized(err):
+		return "your session has expired — run: grimora login"
+	case client.IsNetwork(err):
+		return "could not reach the Grimora API — check your connection or GRIMORA_API_URL"
+	}
+	return ""
+}
+
 // tokenFilePath returns ~/.grimora/token.
 func tokenFilePath() (string, error) {
-	home, err := os.UserHomeDir()
+	return appdir.Path("token")
+}
+
+// refreshTokenFilePath returns ~/.grimora/refresh_token.
+func refreshTokenFilePath() (string, error) {
+	return appdir.Path("refresh_token")
+}
+
+// savedTokens returns the session grimora login saved; either token is ""
+// when it isn't there.
+func savedTokens() client.Tokens {
+	var t client.Tokens
+	for _, f := range []struct {
+		path func() (string, error)
+		into *string
+	}{{tokenFilePath, &t.Access}, {refreshTokenFilePath, &t.Refresh}} {
+		path, err := f.path()
+		if err != nil {
+			continue
+		}
+		if data, err := os.ReadFile(path); err == nil {
+			*f.into = strings.TrimSpace(string(data))
+		}
+	}
+	return t
+}
+
+// saveTokens saves a session for later runs. A session without a refresh
+// token removes any old one, so it can't outlive the session it came with.
+func saveTokens(t client.Tokens) error {
+	tokPath, err := tokenFilePath()
 	if err != nil {
-		return "", fmt.Errorf("get home dir: %w", err)
+		return err
+	}
+	refreshPath, err := refreshTokenFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(tokPath), 0700); err != nil {
+		return fmt.Errorf("create ~/.grimora dir: %w", err)
 	}
-	return filepath.Join(home, ".grimora", "token"), nil
+	if err := os.WriteFile(tokPath, []byte(t.Access), 0600); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+	if t.Refresh == "" {
+		if err := os.Remove(refreshPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove refresh token: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(refreshPath, []byte(t.Refresh), 0600); err != nil {
+		return fmt.Errorf("save refresh token: %w", err)
+	}
+	return nil
 }
 
 // readToken returns the auth token using precedence: env var > file > empty.
@@ -53,15 +142,7 @@ func readToken() string {
 	if tok := os.Getenv("GRIMORA_TOKEN"); tok != "" {
 		return tok
 	}
-	path, err := tokenFilePath()
-	if err != nil {
-		return ""
-	}
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return ""
-	}
-	return strings.TrimSpace(string(data))
+	return savedTokens().Access
 }
 
 func run() error {
@@ -70,8 +151,34 @@ func run() error {
 		apiURL = "https://api.grimora.ai"
 	}
 
-	if len(os.Args) > 1 {
-		switch os.Args[1] {
+	metricsAddr, args, err := extractMetricsAddr(os.Args[1:])
+	if err != nil {
+		return err
+	}
+	debug, args := extractGlobalFlag(args, debugFlag)
+	forceAccessible, args = extractGlobalFlag(args, accessibleFlag)
+	forceLowBandwidth, args = extractGlobalFlag(args, lowBandwidthFlag)
+	demoMode, args = extractGlobalFlag(args, demoFlag)
+	traceRequests, logFile, err := startDebugLog(debug)
+	if err != nil {
+		return err
+	}
+	if logFile != nil {
+		defer logFile.Close() //nolint:errcheck
+	}
+	observeMetrics, err := startMetrics(metricsAddr)
+	if err != nil {
+		return err
+	}
+	requestObserver = client.ChainObservers(observeMetrics, traceRequests)
+	if demoMode {
+		if err := checkDemoArgs(args); err != nil {
+			return err
+		}
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
 		case "--version", "version", "-v":
 			fmt.Println("grimora " + version)
 			return nil
@@ -88,56 +195,290 @@ func run() error {
 			return runLogin(apiURL)
 		case "logout":
 			return runLogout()
+		case "open":
+			// Starts the TUI like plain grimora, on what the link points at.
+			link, err := parseOpenArgs(args[1:])
+			if err != nil {
+				return err
+			}
+			openLink = &link
+		case "tui":
+			// Starts the TUI like plain grimora, on the tab --view names.
+			if startView, err = parseViewArgs(args[1:]); err != nil {
+				return err
+			}
 		case "update":
-			return runUpdate()
+			return runUpdate(args[1:])
+		case "invites":
+			return runInvites(apiURL, args[1:])
+		case "leaderboard":
+			return runLeaderboard(apiURL, args[1:])
+		case "stream":
+			return runStream(apiURL, args[1:])
+		case "ci":
+			return runCI(apiURL, args[1:])
+		case "spellbook":
+			return runSpellbook(apiURL, args[1:])
+		case "spells":
+			return runSpells(apiURL, args[1:])
+		case "cast":
+			return runCast(apiURL, args[1:])
+		case "draft":
+			return runDraft(apiURL, args[1:])
+		case "forge":
+			return runForge(apiURL, args[1:])
+		case "journal":
+			return runJournal(args[1:])
+		case "tour":
+			return runTour()
+		case "profile":
+			return runProfile(apiURL, args[1:])
+		case "completion":
+			return runCompletion(args[1:], os.Stdout)
+		case completeHidden:
+			return runCompleteHidden(apiURL, args[1:], os.Stdout)
+		case finishUpdateArg:
+			return runFinishUpdate(args[1:])
 		case "--update-done":
-			if len(os.Args) >= 4 {
-				printUpdateSuccess(os.Args[2], os.Args[3])
+			if len(args) >= 3 {
+				printUpdateSuccess(args[1], args[2])
 			}
 			return nil
+		default:
+			if startsWithViewFlag(args) {
+				if startView, err = parseViewArgs(args); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
 	token := readToken()
+	if demoMode {
+		srv, err := startDemo()
+		if err != nil {
+			return err
+		}
+		defer srv.Close() //nolint:errcheck
+		// The demo takes any token, so none from a real login is sent.
+		apiURL, token = srv.URL, "demo"
+	}
 	if token == "" {
 		printGrimoireGreeting()
+		// A first run goes straight on to signing in, then the setup wizard.
+		if path, err := lastVersionPath(); err == nil && wantsGuidedLogin(path) && confirmLogin(os.Stdin, os.Stdout) {
+			return runLogin(apiURL)
+		}
 		return nil
 	}
-	c := client.New(apiURL, token)
-	// Only force re-login on actual auth failures (401), not transient errors.
-	if _, err := c.GetMe(context.Background()); err != nil {
-		if client.IsStatus(err, 401) {
-			printGrimoireGreeting()
-			return nil
-		}
-		// Network/server error — launch TUI anyway, it retries internally.
+	cfg := loadConfig()
+	timeout, _ := cfg.StartupTimeoutDuration() // Load already rejected bad values
+
+	// Signals that end the TUI abandon its requests through this context,
+	// which the client needs from the start: checkStartup already shares it
+	// with goroutines.
+	ctx, abandon := context.WithCancel(context.Background())
+	defer abandon()
+	c := newClient(apiURL, token, client.WithBaseContext(ctx))
+	// Only force re-login on actual auth failures (401), not transient errors
+	// or a slow API; those open the TUI in degraded mode.
+	check := checkStartup(c, timeout)
+	if check.signedOut() {
+		printGrimoireGreeting()
+		return nil
 	}
 
-	app := tui.NewApp(c, version)
+	return runTUI(apiURL, c, abandon, cfg, check)
+}
 
-	p := tea.NewProgram(app, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+// runTour runs the practice room on its own. It needs no login.
+func runTour() error {
+	tui.ApplyConfig(loadConfig())
+	if _, err := tea.NewProgram(tui.NewTour(), programOptions()...).Run(); err != nil {
 		return fmt.Errorf("tui error: %w", err)
 	}
 	return nil
 }
 
+// loadConfig reads the user's config. A broken config shouldn't lock anyone
+// out, so errors fall back to defaults with a warning.
+func loadConfig() config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v (using defaults)\n", err)
+	}
+	if forceAccessible {
+		cfg.Accessible = true
+	}
+	if forceLowBandwidth {
+		cfg.LowBandwidth = true
+	}
+	return cfg
+}
+
+// runTUI applies the user's config and runs the interactive app until it
+// exits. abandon cancels c's base context, for when a signal ends the app.
+func runTUI(apiURL string, c *client.Client, abandon context.CancelFunc, cfg config.Config, check startupCheck) error {
+	tui.ApplyConfig(cfg)
+
+	app := tui.NewApp(c, version)
+	if reason := check.banner(); reason != "" {
+		app = app.WithDegradedStart(reason, check.pending)
+	}
+	if demoMode {
+		return runDemoTUI(withOpenLink(withStartView(app)), abandon)
+	}
+	app = app.WithRelogin(watchSessionExpiry(c), func() error {
+		tokens, err := login(apiURL)
+		if err != nil {
+			return err
+		}
+		c.SetTokens(tokens)
+		return nil
+	})
+
+	statePath, stateErr := state.Path()
+	var st state.State
+	if stateErr == nil {
+		var err error
+		st, err = state.Load(statePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v (starting afresh)\n", err)
+		}
+		app = app.WithSession(st.Session)
+		app = app.WithRoomAlerts(statePath, st)
+		if !cfg.NoUpdateCheck {
+			app = app.WithUpdateCheck(statePath, st)
+		}
+		if cfg.SaveHistory {
+			app = app.WithInputHistory(st.InputHistory)
+		}
+	}
+
+	if path, err := lastVersionPath(); err == nil {
+		// Brand-new magicians are walked through setup, and anyone who left
+		// it part way picks up where they stopped. The wizard points to the
+		// practice room at the end; otherwise a first run starts there.
+		first := isFirstRun(path, version)
+		if markVersionSeen(path, version) {
+			app = app.WithReleaseNotes()
+		}
+		switch {
+		case stateErr == nil && (first || st.Onboarding.Step != ""):
+			app = app.WithOnboarding(statePath, st)
+		case first:
+			app = app.WithTour()
+		}
+	}
+
+	app = withOpenLink(withStartView(app))
+
+	if j := openJournal(); j != nil {
+		app = app.WithJournal(j)
+	}
+
+	var store *drafts.Store
+	if path, err := drafts.Path(); err == nil {
+		store, err = drafts.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v (starting without saved drafts)\n", err)
+		}
+		app = app.WithDrafts(store)
+	}
+
+	var box *outbox.Store
+	if path, err := outbox.Path(); err == nil {
+		box, err = outbox.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v (starting with an empty outbox)\n", err)
+		}
+		app = app.WithOutbox(box)
+	}
+
+	p := tea.NewProgram(app, programOptions()...)
+	stopShutdown := handleShutdown(p, abandon)
+	final, runErr := p.Run()
+	stopShutdown()
+	// Flush whatever was typed since the last periodic save, including
+	// when a signal or a closed terminal ended the TUI.
+	if err := store.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	if err := box.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	if n := box.Len(); n > 0 {
+		fmt.Fprintf(os.Stderr, "unsent messages: %d (grimora retries them next time)\n", n)
+	}
+	if app, ok := final.(tui.App); ok && stateErr == nil {
+		if err := saveSession(statePath, app.Session()); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+		if cfg.SaveHistory {
+			if err := saveInputHistory(statePath, app.InputHistory()); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			}
+		}
+	}
+	if runErr != nil {
+		return fmt.Errorf("tui error: %w", runErr)
+	}
+	return nil
+}
+
+// saveSession records where the TUI quit so the next launch resumes there.
+// The state file is reread first: the update check may have written to it
+// while the TUI ran.
+func saveSession(path string, sess state.Session) error {
+	st, _ := state.Load(path) // a corrupt file is replaced
+	st.Session = sess
+	return state.Save(path, st)
+}
+
+func saveInputHistory(path string, h state.InputHistory) error {
+	st, _ := state.Load(path)
+	st.InputHistory = h
+	return state.Save(path, st)
+}
+
 func runLogin(apiURL string) error {
+	tokens, err := login(apiURL)
+	if err != nil {
+		return err
+	}
+
+	// Verify by calling /api/me.
+	ctx, abandon := context.WithCancel(context.Background())
+	defer abandon()
+	c := newClient(apiURL, tokens.Access, client.WithBaseContext(ctx))
+	me, err := c.GetMe(context.Background())
+	if err != nil {
+		fmt.Printf("Token saved but verification failed: %v\n", err)
+		return nil
+	}
+	fmt.Printf("Authenticated as @%s\n\n", me.GitHubLogin)
+
+	// Launch TUI automatically after login; sign-in is already verified.
+	return runTUI(apiURL, c, abandon, loadConfig(), startupCheck{})
+}
+
+// login signs in through the browser and saves the session it gets back.
+func login(apiURL string) (client.Tokens, error) {
 	// Start ephemeral localhost server on random port.
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
-		return fmt.Errorf("start callback listener: %w", err)
+		return client.Tokens{}, fmt.Errorf("start callback listener: %w", err)
 	}
 	defer listener.Close() //nolint:errcheck
 
 	port := listener.Addr().(*net.TCPAddr).Port
-	tokenCh := make(chan string, 1)
+	tokenCh := make(chan client.Tokens, 1)
 	errCh := make(chan error, 1)
 
 	// Generate CSRF state token.
 	stateBytes := make([]byte, 16)
 	if _, err := rand.Read(stateBytes); err != nil {
-		return fmt.Errorf("generate oauth state: %w", err)
+		return client.Tokens{}, fmt.Errorf("generate oauth state: %w", err)
 	}
 	expectedState := hex.EncodeToString(stateBytes)
 
@@ -176,17 +517,15 @@ func runLogin(apiURL string) error {
 			errCh <- fmt.Errorf("cli code exchange: HTTP %d: %s", exchangeResp.StatusCode, string(body))
 			return
 		}
-		var result struct {
-			Token string `json:"token"`
-		}
-		if decErr := json.NewDecoder(exchangeResp.Body).Decode(&result); decErr != nil || result.Token == "" {
+		var result client.Tokens
+		if decErr := json.NewDecoder(exchangeResp.Body).Decode(&result); decErr != nil || result.Access == "" {
 			http.Error(w, "exchange failed", http.StatusInternalServerError)
 			errCh <- fmt.Errorf("cli code exchange: invalid response")
 			return
 		}
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		fmt.Fprint(w, callbackHTML) //nolint:errcheck
-		tokenCh <- result.Token
+		tokenCh <- result
 	})
 
 	srv := &http.Server{Handler: mux}
@@ -216,51 +555,28 @@ func runLogin(apiURL string) error {
 
 	fmt.Printf("Opening browser to authenticate...\n")
 	if err := browser.Open(loginURL); err != nil {
-		fmt.Printf("Could not open browser. Visit this URL manually:\n  %s\n", loginURL)
+		fmt.Printf("Could not open a browser (%v). Visit this URL manually:\n", err)
+		browser.Print(os.Stdout, loginURL)
 	}
 
 	// Wait for callback or timeout.
 	select {
-	case tok := <-tokenCh:
+	case tokens := <-tokenCh:
 		// Shut down the server.
 		shutCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
 		srv.Shutdown(shutCtx) //nolint:errcheck
 
-		// Save token.
-		tokPath, err := tokenFilePath()
-		if err != nil {
-			return err
+		if err := saveTokens(tokens); err != nil {
+			return client.Tokens{}, err
 		}
-		if err := os.MkdirAll(filepath.Dir(tokPath), 0700); err != nil {
-			return fmt.Errorf("create ~/.grimora dir: %w", err)
-		}
-		if err := os.WriteFile(tokPath, []byte(tok), 0600); err != nil {
-			return fmt.Errorf("save token: %w", err)
-		}
-
-		// Verify by calling /api/me.
-		c := client.New(apiURL, tok)
-		me, err := c.GetMe(context.Background())
-		if err != nil {
-			fmt.Printf("Token saved but verification failed: %v\n", err)
-			return nil
-		}
-		fmt.Printf("Authenticated as @%s\n\n", me.GitHubLogin)
-
-		// Launch TUI automatically after login.
-		app := tui.NewApp(c, version)
-		p := tea.NewProgram(app, tea.WithAltScreen())
-		if _, err := p.Run(); err != nil {
-			return fmt.Errorf("tui error: %w", err)
-		}
-		return nil
+		return tokens, nil
 
 	case srvErr := <-errCh:
-		return fmt.Errorf("callback server error: %w", srvErr)
+		return client.Tokens{}, fmt.Errorf("callback server error: %w", srvErr)
 
 	case <-time.After(2 * time.Minute):
-		return fmt.Errorf("login timed out — no callback received within 2 minutes")
+		return client.Tokens{}, fmt.Errorf("login timed out — no callback received within 2 minutes")
 	}
 }
 
@@ -276,91 +592,78 @@ func runLogout() error {
 	if err := os.Remove(tokPath); err != nil {
 		return fmt.Errorf("remove token: %w", err)
 	}
+	if refreshPath, err := refreshTokenFilePath(); err == nil {
+		if err := os.Remove(refreshPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove refresh token: %w", err)
+		}
+	}
 	fmt.Println("Logged out.")
 	return nil
 }
 
 type ghRelease struct {
-	TagName string `json:"tag_name"`
-	Assets  []struct {
+	TagName    string `json:"tag_name"`
+	Prerelease bool   `json:"prerelease"`
+	Draft      bool   `json:"draft"`
+	Assets     []struct {
 		Name               string `json:"name"`
 		BrowserDownloadURL string `json:"browser_download_url"`
 	} `json:"assets"`
 }
 
-// isNewerVersion returns true if latest is a newer semver than current.
-func isNewerVersion(latest, current string) bool {
-	parse := func(v string) (int, int, int) {
-		v = strings.TrimPrefix(v, "v")
-		parts := strings.SplitN(v, ".", 3)
-		atoi := func(s string) int {
-			n, _ := strconv.Atoi(s) //nolint:errcheck // zero-value on parse failure is desired
-			return n
-		}
-		var maj, min, patch int
-		if len(parts) > 0 {
-			maj = atoi(parts[0])
-		}
-		if len(parts) > 1 {
-			min = atoi(parts[1])
-		}
-		if len(parts) > 2 {
-			patch = atoi(parts[2])
+// runUpdate implements `grimora update [--channel stable|beta|nightly] [--rollback]`.
+// The channel defaults to update_channel from the config, else stable.
+func runUpdate(args []string) error {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	channel := fs.String("channel", "", "release channel: stable, beta or nightly")
+	rollback := fs.Bool("rollback", false, "restore the binary the last update replaced")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
 		}
-		return maj, min, patch
-	}
-	lMaj, lMin, lPatch := parse(latest)
-	cMaj, cMin, cPatch := parse(current)
-	if lMaj != cMaj {
-		return lMaj > cMaj
-	}
-	if lMin != cMin {
-		return lMin > cMin
+		return err
 	}
-	return lPatch > cPatch
-}
-
-func runUpdate() error {
 	if version == "dev" {
 		fmt.Println("dev build — install a release to enable updates")
 		return nil
 	}
+	if *rollback {
+		return runRollback()
+	}
 
-	// Resolve the real binary path (follow symlinks).
-	execPath, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("runUpdate: find executable: %w", err)
+	ch := strings.ToLower(*channel)
+	if ch == "" {
+		// A broken config shouldn't block updates; fall back to stable.
+		cfg, _ := config.Load() //nolint:errcheck
+		ch = cfg.UpdateChannel
 	}
-	execPath, err = filepath.EvalSymlinks(execPath)
-	if err != nil {
-		return fmt.Errorf("runUpdate: resolve symlinks: %w", err)
+	if ch == "" {
+		ch = config.ChannelStable
+	}
+	if _, ok := channelRank[ch]; !ok {
+		return fmt.Errorf("unknown channel %q (want stable, beta or nightly)", *channel)
 	}
 
-	// Fetch latest release from GitHub.
-	httpClient := &http.Client{Timeout: 15 * time.Second}
-	resp, err := httpClient.Get("https://api.github.com/repos/naveenspark/grimora/releases/latest")
+	execPath, err := resolveExecutable()
 	if err != nil {
-		return fmt.Errorf("runUpdate: check for updates: %w", err)
-	}
-	defer resp.Body.Close() //nolint:errcheck
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("runUpdate: GitHub API returned %s", resp.Status)
+		return fmt.Errorf("runUpdate: %w", err)
 	}
 
-	var release ghRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return fmt.Errorf("runUpdate: parse release: %w", err)
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+	release, err := fetchRelease(httpClient, ch)
+	if err != nil {
+		return fmt.Errorf("runUpdate: %w", err)
 	}
 
 	latestVersion := strings.TrimPrefix(release.TagName, "v")
 	currentVersion := strings.TrimPrefix(version, "v")
-	if !isNewerVersion(latestVersion, currentVersion) {
+	if !semver.Newer(latestVersion, currentVersion) {
 		printAlreadyCurrent("v" + currentVersion)
 		return nil
 	}
 
 	// Find the right asset for this platform.
-	tarballName := fmt.Sprintf("grimora_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	tarballName := releaseAssetName(runtime.GOOS, runtime.GOARCH)
 	var tarballURL, checksumsURL string
 	for _, a := range release.Assets {
 		switch a.Name {
@@ -398,15 +701,27 @@ func runUpdate() error {
 		return fmt.Errorf("runUpdate: %w", err)
 	}
 
-	// Extract the grimora binary from the tarball.
-	newBinaryPath := filepath.Join(tmpDir, "grimora")
-	if err := extractBinary(tarballPath, newBinaryPath); err != nil {
+	// Extract the grimora binary from the archive.
+	newBinaryPath := filepath.Join(tmpDir, binaryName(runtime.GOOS))
+	extract := extractBinary
+	if runtime.GOOS == "windows" {
+		extract = func(archive, dest string) error {
+			return extractZipBinary(archive, binaryName(runtime.GOOS), dest)
+		}
+	}
+	if err := extract(tarballPath, newBinaryPath); err != nil {
 		return fmt.Errorf("runUpdate: extract: %w", err)
 	}
 
-	// Atomic replace: write to .new, then rename over the original.
-	stagePath := execPath + ".new"
-	defer os.Remove(stagePath) //nolint:errcheck
+	// Stage as .new, then swap it in, keeping the current binary for
+	// `grimora update --rollback`.
+	stagePath := stagedBinaryPath(execPath)
+	keepStage := false
+	defer func() {
+		if !keepStage {
+			os.Remove(stagePath) //nolint:errcheck
+		}
+	}()
 
 	src, err := os.Open(newBinaryPath)
 	if err != nil {
@@ -429,19 +744,34 @@ func runUpdate() error {
 		return fmt.Errorf("runUpdate: close staged binary: %w", err)
 	}
 
-	if err := os.Rename(stagePath, execPath); err != nil {
-		if os.IsPermission(err) {
+	from, to := "v"+currentVersion, "v"+latestVersion
+	if err := installBinary(stagePath, execPath, oldBinaryPath(execPath)); err != nil {
+		if runtime.GOOS == "windows" {
+			// Writing the staged binary worked, so this is most likely the
+			// file being in use; the helper waits it out.
+			if err := startUpdateHelper(stagePath, execPath, from, to); err != nil {
+				return fmt.Errorf("runUpdate: start update helper: %w", err)
+			}
+			keepStage = true
+			fmt.Printf("%s is in use — the update finishes as soon as it's free\n", filepath.Base(execPath))
+			return nil
+		}
+		if errors.Is(err, os.ErrPermission) {
 			return fmt.Errorf("permission denied replacing %s — try with sudo", execPath)
 		}
-		return fmt.Errorf("runUpdate: replace binary: %w", err)
+		return fmt.Errorf("runUpdate: %w", err)
 	}
 
-	// Re-exec into the NEW binary so its updated code renders the success message.
-	// The running process still has the old code in memory after os.Rename.
-	execErr := syscall.Exec(execPath, []string{"grimora", "--update-done", "v" + currentVersion, "v" + latestVersion}, os.Environ())
-	if execErr != nil {
-		// Fallback if exec fails (e.g., Windows).
-		printUpdateSuccess("v"+currentVersion, "v"+latestVersion)
+	// Hand over to the NEW binary so its updated code renders the success message.
+	// The running process still has the old code in memory after the swap.
+	if err := reexec(execPath, []string{"--update-done", from, to}); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("runUpdate: %s is installed but failed to start (%w) — grimora update --rollback puts %s back", to, err, from)
+		}
+		// The new binary is in place but couldn't be started; finish here.
+		fmt.Fprintf(os.Stderr, "couldn't start the new binary: %v\n", err)
+		printUpdateSuccess(from, to)
 	}
 	return nil
 }
@@ -543,7 +873,7 @@ func extractBinary(tarballPath, dest string) error {
 func openLegal(page string) error {
 	url := "https://grimora.ai/" + page
 	if err := browser.Open(url); err != nil {
-		fmt.Println(url)
+		browser.Print(os.Stdout, url)
 	}
 	return nil
 }
@@ -5,39 +5,15 @@ import (
 	"compress/gzip"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
-)
 
-func TestIsNewerVersion(t *testing.T) {
-	tests := []struct {
-		latest  string
-		current string
-		want    bool
-	}{
-		{"1.0.0", "1.0.0", false},
-		{"1.1.0", "1.0.0", true},
-		{"1.0.0", "1.1.0", false},
-		{"1.10.0", "1.9.0", true},
-		{"2.0.0", "1.99.99", true},
-		{"0.0.1", "0.0.2", false},
-		{"v1.2.3", "1.2.3", false},
-		{"v1.3.0", "v1.2.0", true},
-		{"1.0.0", "0.9.0", true},
-		{"1.0.1", "1.0.0", true},
-		{"1.0.0", "1.0.1", false},
-	}
-	for _, tt := range tests {
-		t.Run(tt.latest+"_vs_"+tt.current, func(t *testing.T) {
-			got := isNewerVersion(tt.latest, tt.current)
-			if got != tt.want {
-				t.Errorf("isNewerVersion(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
-			}
-		})
-	}
-}
+	"github.com/naveenspark/grimora/pkg/client"
+)
 
 // makeTarGz creates a tar.gz file with the given entries.
 func makeTarGz(t *testing.T, dest string, entries map[string]string) {
@@ -216,3 +192,40 @@ func TestVerifyChecksum(t *testing.T) {
 		}
 	})
 }
+
+func TestErrorHint(t *testing.T) {
+	expired := fmt.Errorf("client.ListInvites: %w", &client.HTTPError{StatusCode: 401})
+	if hint := errorHint(expired); !strings.Contains(hint, "grimora login") {
+		t.Errorf("errorHint(401) = %q, want a login hint", hint)
+	}
+	offline := fmt.Errorf("client.ListInvites: %w", &client.NetworkError{Err: errors.New("dial tcp: connection refused")})
+	if hint := errorHint(offline); !strings.Contains(hint, "connection") {
+		t.Errorf("errorHint(network) = %q, want a connection hint", hint)
+	}
+	if hint := errorHint(errors.New("bad flag")); hint != "" {
+		t.Errorf("errorHint(other) = %q, want none", hint)
+	}
+}
+
+func TestSaveTokens(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GRIMORA_TOKEN", "")
+
+	if err := saveTokens(client.Tokens{Access: "a1", Refresh: "r1"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := savedTokens(); got != (client.Tokens{Access: "a1", Refresh: "r1"}) {
+		t.Errorf("savedTokens() = %+v", got)
+	}
+	if got := readToken(); got != "a1" {
+		t.Errorf("readToken() = %q, want a1", got)
+	}
+
+	// A session without a refresh token drops the old one.
+	if err := saveTokens(client.Tokens{Access: "a2"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := savedTokens(); got != (client.Tokens{Access: "a2"}) {
+		t.Errorf("savedTokens() after a plain token = %+v", got)
+	}
+}
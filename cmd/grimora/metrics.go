@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/naveenspark/grimora/internal/metrics"
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+const metricsAddrFlag = "--metrics-addr"
+
+// extractMetricsAddr pulls --metrics-addr (as "--metrics-addr :9090" or
+// "--metrics-addr=:9090") out of the global flags leading args, returning
+// the address and the remaining arguments for subcommand dispatch.
+func extractMetricsAddr(args []string) (string, []string, error) {
+	addr, rest, err := extractGlobalValue(args, metricsAddrFlag)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s needs an address, e.g. %s :9090", metricsAddrFlag, metricsAddrFlag)
+	}
+	return addr, rest, nil
+}
+
+// startMetrics serves Prometheus metrics on addr and returns the observer
+// that feeds them API requests. An empty addr leaves metrics off.
+func startMetrics(addr string) (client.RequestObserver, error) {
+	if addr == "" {
+		return nil, nil
+	}
+	if err := metrics.Serve(addr); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "metrics: serving on http://%s/metrics\n", addr)
+	return metrics.ObserveRequest, nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractMetricsAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantAddr string
+		wantRest []string
+		wantErr  bool
+	}{
+		{"absent", []string{"invites", "copy"}, "", []string{"invites", "copy"}, false},
+		{"separate value", []string{"--metrics-addr", ":9090"}, ":9090", []string{}, false},
+		{"equals form", []string{"--metrics-addr=127.0.0.1:9100", "invites"}, "127.0.0.1:9100", []string{"invites"}, false},
+		{"after other flags", []string{"--debug", "--view", "hall", "--metrics-addr", ":9090"}, ":9090", []string{"--debug", "--view", "hall"}, false},
+		{"in a subcommand's arguments", []string{"hall", "send", "--metrics-addr", ":9090"}, "", []string{"hall", "send", "--metrics-addr", ":9090"}, false},
+		{"missing value", []string{"--metrics-addr"}, "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, rest, err := extractMetricsAddr(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if addr != tt.wantAddr {
+				t.Errorf("addr = %q, want %q", addr, tt.wantAddr)
+			}
+			if !reflect.DeepEqual(rest, tt.wantRest) {
+				t.Errorf("rest = %v, want %v", rest, tt.wantRest)
+			}
+		})
+	}
+}
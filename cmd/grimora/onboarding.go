@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// wantsGuidedLogin reports whether a signed-out launch should walk through
+// signing in rather than print the greeting: only on a magician's first run,
+// and only when someone is at the keyboard to answer.
+func wantsGuidedLogin(lastVersion string) bool {
+	if !isFirstRun(lastVersion, version) {
+		return false
+	}
+	return stdinIsTerminal()
+}
+
+// confirmLogin asks whether to sign in now. Enter or yes goes ahead; no, or
+// the input closing, backs out.
+func confirmLogin(in io.Reader, out io.Writer) bool {
+	fmt.Fprint(out, "Sign in with GitHub to begin. Press enter to open your browser, or n to leave: ") //nolint:errcheck
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Fprintln(out) //nolint:errcheck
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "", "y", "yes":
+		return true
+	}
+	return false
+}
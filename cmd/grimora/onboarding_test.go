@@ -0,0 +1,26 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConfirmLogin(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"\n", true},
+		{"yes\n", true},
+		{" Y \n", true},
+		{"n\n", false},
+		{"later\n", false},
+		{"", false}, // stdin closed
+	}
+	for _, tt := range tests {
+		if got := confirmLogin(strings.NewReader(tt.in), io.Discard); got != tt.want {
+			t.Errorf("confirmLogin(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
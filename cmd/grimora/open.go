@@ -0,0 +1,28 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/naveenspark/grimora/internal/tui"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// openLink is set by `grimora open <link>`: the TUI starts on the message,
+// spell or magician it points at.
+var openLink *domain.Link
+
+// parseOpenArgs parses the arguments of `grimora open`.
+func parseOpenArgs(args []string) (domain.Link, error) {
+	if len(args) != 1 {
+		return domain.Link{}, errors.New("usage: grimora open <grimora.ai link>")
+	}
+	return domain.ParseLink(args[0])
+}
+
+// withOpenLink opens app on the link `grimora open` was given, if any.
+func withOpenLink(app tui.App) tui.App {
+	if openLink == nil {
+		return app
+	}
+	return app.WithLink(*openLink)
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestParseOpenArgs(t *testing.T) {
+	l, err := parseOpenArgs([]string{"https://grimora.ai/@ada"})
+	if err != nil || l != (domain.Link{Kind: domain.LinkMagician, Login: "ada"}) {
+		t.Errorf("parseOpenArgs = %+v, %v", l, err)
+	}
+	for _, args := range [][]string{nil, {"a", "b"}, {"https://example.com/@ada"}} {
+		if _, err := parseOpenArgs(args); err == nil {
+			t.Errorf("parseOpenArgs(%q): expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// runProfile implements `grimora profile [--timezone tz] [--active-hours 9-18]`.
+// With no flags it shows the current settings.
+func runProfile(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	tz := fs.String("timezone", "", `IANA time zone, e.g. Europe/Berlin ("" clears it)`)
+	hours := fs.String("active-hours", "", `local hours you're usually around, e.g. 9-18 ("" clears them)`)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	var u domain.ProfileUpdate
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "timezone":
+			u.Timezone = tz
+		case "active-hours":
+			u.ActiveHours = hours
+		}
+	})
+	if err := validateProfileUpdate(u); err != nil {
+		return err
+	}
+
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if u.Timezone == nil && u.ActiveHours == nil {
+		me, err := c.GetMe(ctx)
+		if err != nil {
+			return fmt.Errorf("get profile: %w", err)
+		}
+		printProfile(os.Stdout, *me, time.Now())
+		return nil
+	}
+	me, err := c.UpdateProfile(ctx, u)
+	if err != nil {
+		return fmt.Errorf("update profile: %w", err)
+	}
+	printProfile(os.Stdout, *me, time.Now())
+	return nil
+}
+
+// validateProfileUpdate checks the settings being changed before they're sent.
+func validateProfileUpdate(u domain.ProfileUpdate) error {
+	if u.Timezone != nil && *u.Timezone != "" {
+		if _, err := time.LoadLocation(*u.Timezone); err != nil {
+			return fmt.Errorf("unknown time zone %q (want an IANA name like Europe/Berlin)", *u.Timezone)
+		}
+	}
+	if u.ActiveHours != nil && *u.ActiveHours != "" {
+		if _, _, err := domain.ParseActiveHours(*u.ActiveHours); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printProfile shows the availability settings others see on peek cards.
+func printProfile(w io.Writer, m domain.Magician, now time.Time) {
+	if m.Timezone == "" {
+		fmt.Fprintln(w, "Time zone:    not set (grimora profile --timezone Europe/Berlin)")
+	} else {
+		local, _ := m.LocalTime(now)
+		fmt.Fprintf(w, "Time zone:    %s (%s there now)\n", m.Timezone, local.Format("15:04"))
+	}
+	hours := m.ActiveHours
+	if hours == "" {
+		hours = domain.DefaultActiveHours + " (default)"
+	}
+	fmt.Fprintf(w, "Active hours: %s\n", hours)
+}
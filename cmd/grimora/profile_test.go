@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestValidateProfileUpdate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		u       domain.ProfileUpdate
+		wantErr bool
+	}{
+		{"nothing", domain.ProfileUpdate{}, false},
+		{"zone", domain.ProfileUpdate{Timezone: str("America/New_York")}, false},
+		{"clear zone", domain.ProfileUpdate{Timezone: str("")}, false},
+		{"bad zone", domain.ProfileUpdate{Timezone: str("Mars/Olympus")}, true},
+		{"hours", domain.ProfileUpdate{ActiveHours: str("22-6")}, false},
+		{"bad hours", domain.ProfileUpdate{ActiveHours: str("all day")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateProfileUpdate(tt.u); (err != nil) != tt.wantErr {
+				t.Errorf("validateProfileUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrintProfile(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	printProfile(&buf, domain.Magician{Timezone: "Europe/Berlin", ActiveHours: "9-18"}, now)
+	if got := buf.String(); !strings.Contains(got, "Europe/Berlin (14:00 there now)") || !strings.Contains(got, "Active hours: 9-18") {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+
+	buf.Reset()
+	printProfile(&buf, domain.Magician{}, now)
+	if got := buf.String(); !strings.Contains(got, "not set") || !strings.Contains(got, "(default)") {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+}
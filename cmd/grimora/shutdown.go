@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// shutdownSignals end the TUI the same way q does: SIGTERM is what kill and
+// service managers send, SIGHUP what the terminal sends as its window closes.
+// Left to their defaults they would kill grimora before it saved anything.
+var shutdownSignals = []os.Signal{syscall.SIGTERM, syscall.SIGHUP}
+
+// quitter is the part of *tea.Program that shutdown needs.
+type quitter interface {
+	Quit()
+}
+
+// handleShutdown quits p when one of shutdownSignals arrives, so Run returns
+// normally: the terminal is restored and the caller goes on to flush drafts,
+// the outbox and the session. Requests still in flight are abandoned by
+// calling cancel, which should cancel the API client's base context. The
+// returned func stops listening; call it once Run returns.
+func handleShutdown(p quitter, cancel context.CancelFunc) (stop func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, shutdownSignals...)
+	done := make(chan struct{})
+	go func() {
+		quitOn(sig, done, p, cancel)
+	}()
+	return func() {
+		signal.Stop(sig)
+		close(done)
+		cancel()
+	}
+}
+
+// quitOn waits for a signal on sig, then cancels in-flight work and quits p.
+// It returns without doing either once done is closed.
+func quitOn(sig <-chan os.Signal, done <-chan struct{}, p quitter, cancel context.CancelFunc) {
+	select {
+	case <-sig:
+		cancel()
+		p.Quit()
+	case <-done:
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+)
+
+type fakeQuitter struct{ quit bool }
+
+func (q *fakeQuitter) Quit() { q.quit = true }
+
+func TestQuitOnSignal(t *testing.T) {
+	sig := make(chan os.Signal, 1)
+	sig <- syscall.SIGHUP
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &fakeQuitter{}
+
+	quitOn(sig, make(chan struct{}), q, cancel)
+	if !q.quit {
+		t.Error("a signal should quit the program")
+	}
+	if ctx.Err() == nil {
+		t.Error("a signal should cancel requests in flight")
+	}
+}
+
+func TestQuitOnDone(t *testing.T) {
+	done := make(chan struct{})
+	close(done)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := &fakeQuitter{}
+
+	quitOn(make(chan os.Signal), done, q, cancel)
+	if q.quit || ctx.Err() != nil {
+		t.Error("stopping before any signal should leave the program alone")
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/export"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// collectionSaved is the --collection value for the caller's saved spells.
+// Any other value names one of their guild's chests.
+const collectionSaved = "saved"
+
+// savedPageSize is the page size used to walk the saved spells.
+const savedPageSize = 100
+
+// runSpellbook implements `grimora spellbook [--collection c] [--format md|html|pdf] [--out file]`.
+func runSpellbook(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("spellbook", flag.ContinueOnError)
+	collection := fs.String("collection", collectionSaved, `"saved" or the name of one of your guild's chests`)
+	format := fs.String("format", export.FormatMarkdown, "output format: md, html or pdf")
+	out := fs.String("out", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	f := strings.ToLower(*format)
+	if !slices.Contains(export.Formats, f) {
+		return fmt.Errorf("unknown format %q (want md, html or pdf)", *format)
+	}
+	// A PDF dumped on a terminal is just noise.
+	if *out == "" && f == export.FormatPDF && colorEnabled(os.Stdout) {
+		return errors.New("refusing to write a PDF to the terminal — use --out spellbook.pdf or redirect")
+	}
+
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	book, err := loadSpellbook(context.Background(), c, *collection)
+	if err != nil {
+		return err
+	}
+	book.Generated = time.Now()
+
+	if *out == "" {
+		return export.Write(os.Stdout, f, book)
+	}
+	file, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", *out, err)
+	}
+	if err := export.Write(file, f, book); err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d spells to %s\n", len(book.Spells), *out)
+	return nil
+}
+
+// loadSpellbook fetches the spells in a collection.
+func loadSpellbook(ctx context.Context, c *client.Client, collection string) (export.Spellbook, error) {
+	if strings.EqualFold(collection, collectionSaved) {
+		spells, err := client.SavedSpellsIter(ctx, c, savedPageSize).Collect()
+		if err != nil {
+			return export.Spellbook{}, fmt.Errorf("list saved spells: %w", err)
+		}
+		return export.Spellbook{Title: "Saved Spells", Spells: spells}, nil
+	}
+
+	me, err := c.GetMe(ctx)
+	if err != nil {
+		return export.Spellbook{}, fmt.Errorf("get profile: %w", err)
+	}
+	overview, err := c.ListGuildChests(ctx, me.GuildID)
+	if err != nil {
+		return export.Spellbook{}, fmt.Errorf("list guild chests: %w", err)
+	}
+	chest, err := findChest(overview.Chests, collection)
+	if err != nil {
+		return export.Spellbook{}, err
+	}
+	full, err := c.GetGuildChest(ctx, me.GuildID, chest.ID.String())
+	if err != nil {
+		return export.Spellbook{}, fmt.Errorf("get chest: %w", err)
+	}
+	spells := make([]domain.Spell, len(full.Spells))
+	for i, s := range full.Spells {
+		spells[i] = s.Spell
+	}
+	return export.Spellbook{Title: full.Name, Description: full.Description, Spells: spells}, nil
+}
+
+// findChest picks a chest by name, ignoring case.
+func findChest(chests []domain.GuildChest, name string) (domain.GuildChest, error) {
+	for _, ch := range chests {
+		if strings.EqualFold(ch.Name, name) {
+			return ch, nil
+		}
+	}
+	names := make([]string, len(chests))
+	for i, ch := range chests {
+		names[i] = ch.Name
+	}
+	if len(names) == 0 {
+		return domain.GuildChest{}, fmt.Errorf("no collection %q — your guild has no chests yet", name)
+	}
+	return domain.GuildChest{}, fmt.Errorf("no collection %q (try saved, %s)", name, strings.Join(names, ", "))
+}
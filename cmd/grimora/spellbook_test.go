@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestFindChest(t *testing.T) {
+	chests := []domain.GuildChest{{Name: "Debugging Classics"}, {Name: "Onboarding"}}
+	ch, err := findChest(chests, "debugging classics")
+	if err != nil || ch.Name != "Debugging Classics" {
+		t.Errorf("findChest = %q, %v; want Debugging Classics", ch.Name, err)
+	}
+	_, err = findChest(chests, "nope")
+	if err == nil || !strings.Contains(err.Error(), "saved, Debugging Classics, Onboarding") {
+		t.Errorf("expected error listing collections, got %v", err)
+	}
+	if _, err := findChest(nil, "nope"); err == nil {
+		t.Error("expected error with no chests")
+	}
+}
+
+func TestRunSpellbookRejectsUnknownFormat(t *testing.T) {
+	if err := runSpellbook("http://127.0.0.1:0", []string{"--format", "docx"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/internal/export"
+	"github.com/naveenspark/grimora/internal/publish"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// runSpells dispatches `grimora spells <command>`.
+func runSpells(apiURL string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(spellsPullUsage)
+	}
+	switch args[0] {
+	case "pull":
+		return runSpellsPull(apiURL, args[1:])
+	case "publish":
+		return runSpellsPublish(apiURL, args[1:])
+	case "feed":
+		return runSpellsFeed(apiURL, args[1:])
+	}
+	return fmt.Errorf("unknown spells command %q (want pull, publish or feed)", args[0])
+}
+
+const spellsPullUsage = "usage: grimora spells pull <id>... [--tag tags] [--out dir]"
+
+// spellsPullPage is how many spells a --tag pull asks for at a time.
+const spellsPullPage = 50
+
+// runSpellsPull implements `grimora spells pull <id>... [--tag tags] [--out dir]`,
+// writing each spell to <dir>/<slug>.md with frontmatter so it can be
+// committed next to the code that uses it. --tag adds every spell carrying
+// any of the comma-separated tags.
+func runSpellsPull(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("spells pull", flag.ContinueOnError)
+	out := fs.String("out", export.DefaultSpellDir, "directory to write spells into")
+	tag := fs.String("tag", "", "also pull every spell with any of these comma-separated tags")
+	ids, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	var tags []string
+	for t := range strings.SplitSeq(*tag, ",") {
+		if t = domain.NormalizeTag(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	if len(ids) == 0 && len(tags) == 0 {
+		return errors.New(spellsPullUsage)
+	}
+
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	var spells []domain.Spell
+	for _, id := range ids {
+		spell, err := c.GetSpell(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get spell %s: %w", id, err)
+		}
+		spells = append(spells, *spell)
+	}
+	if len(tags) > 0 {
+		tagged, err := client.SpellsIter(ctx, c, client.SpellsOptions{Tags: tags, Sort: "new", PageSize: spellsPullPage}).Collect()
+		if err != nil {
+			return fmt.Errorf("list spells tagged %s: %w", strings.Join(tags, ","), err)
+		}
+		spells = append(spells, tagged...)
+	}
+	for _, spell := range spells {
+		path, err := export.WriteSpellFile(*out, spell)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+	}
+	return nil
+}
+
+const spellsPublishUsage = "usage: grimora spells publish <id>... [--gist [--public] | --repo owner/name[/dir]]"
+
+// runSpellsPublish implements `grimora spells publish <id>... [--gist] [--repo r]`,
+// copying each spell to a gist or into a GitHub repository with the
+// caller's GitHub token, then noting the URL in the spell's context. The
+// destination defaults to publish_repo from the config, else a gist.
+func runSpellsPublish(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("spells publish", flag.ContinueOnError)
+	gist := fs.Bool("gist", false, "publish as a gist, even when publish_repo is set")
+	public := fs.Bool("public", false, "make the gist public rather than secret")
+	repoFlag := fs.String("repo", "", "commit into owner/name or owner/name/dir instead")
+	ids, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(ids) == 0 {
+		return errors.New(spellsPublishUsage)
+	}
+	if *gist && *repoFlag != "" {
+		return errors.New("use --gist or --repo, not both")
+	}
+	target := *repoFlag
+	if target == "" && !*gist {
+		// A broken config shouldn't block publishing; fall back to a gist.
+		cfg, _ := config.Load() //nolint:errcheck
+		target = cfg.PublishRepo
+	}
+	var repo publish.Repo
+	if target != "" {
+		if repo, err = publish.ParseRepo(target); err != nil {
+			return fmt.Errorf("--repo: %w", err)
+		}
+	}
+	token := publish.Token()
+	if token == "" {
+		return publish.ErrNoToken
+	}
+
+	c, err := authedClient(apiURL)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	gh := publish.New(token)
+	for _, id := range ids {
+		spell, err := c.GetSpell(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get spell %s: %w", id, err)
+		}
+		link, err := gh.Spell(ctx, repo, *public, *spell)
+		if err != nil {
+			return fmt.Errorf("publish spell %s: %w", id, err)
+		}
+		fmt.Println(link)
+		if err := publish.Link(ctx, c, *spell, link); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: published, but couldn't add the link to spell %s: %v\n", id, err)
+		}
+	}
+	return nil
+}
+
+// feedScan caps how many of the newest spells a feed looks through for
+// ones potent enough, so a high --min-potency can't walk the whole Grimoire.
+const feedScan = 500
+
+// runSpellsFeed implements `grimora spells feed [--tag tags] [--min-potency n]
+// [--sort new|top] [--limit n] [--format atom|json] [--out file]`, writing
+// recent spells as an Atom or JSON Feed for feed readers and dashboards.
+// The format follows --out's extension unless --format says otherwise.
+// Spells are public, so a stored session is used if present but not
+// required.
+func runSpellsFeed(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("spells feed", flag.ContinueOnError)
+	tag := fs.String("tag", "", "only spells with any of these comma-separated tags")
+	minPotency := fs.Int("min-potency", 0, "only spells at least this potent")
+	sortBy := fs.String("sort", "new", "new or top")
+	limit := fs.Int("limit", 50, "most spells in the feed")
+	format := fs.String("format", "", "atom or json; default from --out, else atom")
+	out := fs.String("out", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	var tags []string
+	for t := range strings.SplitSeq(*tag, ",") {
+		if t = domain.NormalizeTag(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	if *sortBy != "new" && *sortBy != "top" {
+		return fmt.Errorf("unknown sort %q (want new or top)", *sortBy)
+	}
+	if *limit < 1 || *limit > feedScan {
+		return fmt.Errorf("--limit must be between 1 and %d", feedScan)
+	}
+	f := strings.ToLower(*format)
+	if f == "" {
+		f = export.FeedAtom
+		if strings.EqualFold(filepath.Ext(*out), ".json") {
+			f = export.FeedJSON
+		}
+	}
+	if !slices.Contains(export.FeedFormats, f) {
+		return fmt.Errorf("unknown format %q (want atom or json)", *format)
+	}
+
+	c := newClient(apiURL, readToken())
+	it := client.SpellsIter(context.Background(), c, client.SpellsOptions{Tags: tags, Sort: *sortBy, PageSize: spellsPullPage})
+	var spells []domain.Spell
+	for scanned := 0; scanned < feedScan && len(spells) < *limit && it.Next(); scanned++ {
+		if s := it.Item(); s.Potency >= *minPotency {
+			spells = append(spells, s)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("list spells: %w", err)
+	}
+	feed := spellsFeed(tags, *minPotency, *sortBy)
+	feed.Spells = spells
+
+	if *out == "" {
+		return export.WriteFeed(os.Stdout, f, feed)
+	}
+	if err := writeFeedFile(*out, f, feed); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d spells to %s\n", len(spells), *out)
+	return nil
+}
+
+// writeFeedFile replaces the feed at path in one step: it's written next to
+// it first, so a run that fails or is cut short leaves the old feed whole
+// for whatever serves it.
+func writeFeedFile(path, format string, feed export.Feed) error {
+	var sb strings.Builder
+	if err := export.WriteFeed(&sb, format, feed); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp) //nolint:errcheck // best-effort cleanup
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// spellsFeed names the feed of spells a selection picks. The ID is a tag
+// URI made from the selection, so the same command always writes the
+// same feed and a reader never sees it as new.
+func spellsFeed(tags []string, minPotency int, sortBy string) export.Feed {
+	title := "Grimora spells"
+	params := url.Values{}
+	if len(tags) > 0 {
+		title += " tagged " + strings.Join(tags, ", ")
+		params.Set("tag", strings.Join(tags, ","))
+	}
+	if minPotency > 0 {
+		title += fmt.Sprintf(" · P%d+", minPotency)
+		params.Set("min_potency", strconv.Itoa(minPotency))
+	}
+	if sortBy == "top" {
+		title += " · top"
+		params.Set("sort", sortBy)
+	}
+	id := "tag:grimora.ai,2026:spells"
+	if len(params) > 0 {
+		id += "?" + params.Encode()
+	}
+	return export.Feed{Title: title, ID: id, Link: domain.WebURL}
+}
+
+// parseInterspersed parses args with fs, allowing flags after positional
+// arguments as in `pull <id> --out dir`, and returns the positionals.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
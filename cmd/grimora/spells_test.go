@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/export"
+	"github.com/naveenspark/grimora/internal/mockapi"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantIDs []string
+		wantOut string
+	}{
+		{[]string{"abc"}, []string{"abc"}, "prompts"},
+		{[]string{"abc", "--out", "docs"}, []string{"abc"}, "docs"},
+		{[]string{"--out", "docs", "abc", "def"}, []string{"abc", "def"}, "docs"},
+		{[]string{"abc", "--out=docs", "def"}, []string{"abc", "def"}, "docs"},
+		{nil, nil, "prompts"},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		out := fs.String("out", "prompts", "")
+		ids, err := parseInterspersed(fs, tt.args)
+		if err != nil {
+			t.Fatalf("parseInterspersed(%q) error: %v", tt.args, err)
+		}
+		if !slices.Equal(ids, tt.wantIDs) || *out != tt.wantOut {
+			t.Errorf("parseInterspersed(%q) = %q, out %q; want %q, out %q", tt.args, ids, *out, tt.wantIDs, tt.wantOut)
+		}
+	}
+}
+
+func TestRunSpellsUsage(t *testing.T) {
+	if err := runSpells("http://unused.invalid", nil); err == nil {
+		t.Error("expected usage error without a command")
+	}
+	if err := runSpells("http://unused.invalid", []string{"push"}); err == nil {
+		t.Error("expected error for unknown command")
+	}
+	if err := runSpells("http://unused.invalid", []string{"pull"}); err == nil {
+		t.Error("expected usage error without an id")
+	}
+	if err := runSpells("http://unused.invalid", []string{"publish"}); err == nil {
+		t.Error("expected usage error without an id to publish")
+	}
+	if err := runSpells("http://unused.invalid", []string{"publish", "abc", "--gist", "--repo", "me/spells"}); err == nil {
+		t.Error("expected error for --gist with --repo")
+	}
+}
+
+func TestRunSpellsFeed(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GRIMORA_TOKEN", "tok")
+	now := time.Now()
+	srv := httptest.NewServer(mockapi.Handler(&clienttest.Fake{Spells: []domain.Spell{
+		{ID: uuid.New(), Text: "weak", Tag: "debugging", Potency: 1, CreatedAt: now},
+		{ID: uuid.New(), Text: "# Bisect it\nfind the commit", Tag: "debugging", Potency: 4, CreatedAt: now.Add(-time.Hour)},
+		{ID: uuid.New(), Text: "other tag", Tag: "review", Potency: 5, CreatedAt: now},
+	}}))
+	defer srv.Close()
+
+	out := filepath.Join(t.TempDir(), "feed.json")
+	if err := runSpells(srv.URL, []string{"feed", "--tag", "debugging", "--min-potency", "3", "--out", out}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var feed struct {
+		Title string `json:"title"`
+		Items []struct {
+			Title string `json:"title"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &feed); err != nil {
+		t.Fatalf("--out feed.json isn't a JSON Feed: %v\n%s", err, data)
+	}
+	if len(feed.Items) != 1 || feed.Items[0].Title != "Bisect it" || feed.Title != "Grimora spells tagged debugging · P3+" {
+		t.Errorf("feed = %+v, want only the potent debugging spell", feed)
+	}
+}
+
+func TestWriteFeedFileReplacesWhole(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	if err := os.WriteFile(path, []byte("old feed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFeedFile(path, export.FeedAtom, spellsFeed(nil, 0, "new")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "<feed") {
+		t.Errorf("feed = %q, %v; want the new Atom feed", data, err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("the temp file was left behind: %v", err)
+	}
+}
+
+func TestRunSpellsFeedRejectsBadFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"feed", "--sort", "hot"},
+		{"feed", "--format", "rss"},
+		{"feed", "--limit", "0"},
+	} {
+		if err := runSpells("http://unused.invalid", args); err == nil {
+			t.Errorf("runSpells(%q) succeeded, want an error", args)
+		}
+	}
+}
+
+func TestSpellsFeedID(t *testing.T) {
+	a := spellsFeed([]string{"go"}, 3, "new")
+	if b := spellsFeed([]string{"go"}, 3, "new"); a.ID != b.ID {
+		t.Errorf("feed IDs differ for the same selection: %q, %q", a.ID, b.ID)
+	}
+	if b := spellsFeed([]string{"go"}, 0, "new"); a.ID == b.ID {
+		t.Errorf("feed ID %q ignores --min-potency", a.ID)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+// startupCheck is what's known about the API when the TUI is about to open.
+type startupCheck struct {
+	authErr   error        // GetMe's result, when it finished in time
+	pending   <-chan error // GetMe's result, when it didn't
+	healthErr error        // the health probe's result; nil when healthy or skipped
+}
+
+// checkStartup signs in and probes the API's health in parallel, waiting at
+// most timeout. A slow sign-in keeps running in the background and is
+// handed back as pending, so the TUI can open without it.
+func checkStartup(c *client.Client, timeout time.Duration) startupCheck {
+	auth := make(chan error, 1)
+	go func() {
+		_, err := c.GetMe(context.Background())
+		auth <- err
+	}()
+	if timeout <= 0 {
+		return startupCheck{pending: auth}
+	}
+
+	health := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		health <- c.Health(ctx)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-auth:
+		return startupCheck{authErr: err}
+	case <-timer.C:
+	}
+	// The probe shares the deadline, so its answer is in or moments away.
+	return startupCheck{pending: auth, healthErr: <-health}
+}
+
+// signedOut reports whether the check proved the token is no good.
+func (s startupCheck) signedOut() bool {
+	return s.pending == nil && client.IsUnauthorized(s.authErr)
+}
+
+// banner explains why the TUI is opening without a signed-in session, or
+// returns "" when it isn't.
+func (s startupCheck) banner() string {
+	switch {
+	case s.pending != nil && s.healthErr != nil:
+		return "Grimora isn't responding · showing what's cached until it does"
+	case s.pending != nil:
+		return "signing in · the API is slow right now"
+	case s.authErr != nil:
+		return "couldn't sign in · retrying in the background"
+	}
+	return ""
+}
+
+// watchSessionExpiry returns a channel signalled when c's session lapses
+// for good. Signals coalesce, so a burst of refused requests is one.
+func watchSessionExpiry(c *client.Client) <-chan struct{} {
+	expired := make(chan struct{}, 1)
+	c.SetSessionExpiredHandler(func() {
+		select {
+		case expired <- struct{}{}:
+		default:
+		}
+	})
+	return expired
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+func TestCheckStartupFastAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"github_login":"ada"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	check := checkStartup(client.New(srv.URL, "tok"), time.Second)
+	if check.pending != nil || check.authErr != nil {
+		t.Fatalf("check = %+v, want signed in", check)
+	}
+	if b := check.banner(); b != "" {
+		t.Errorf("banner = %q, want none", b)
+	}
+}
+
+func TestCheckStartupUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	if check := checkStartup(client.New(srv.URL, "tok"), time.Second); !check.signedOut() {
+		t.Errorf("check = %+v, want signed out", check)
+	}
+}
+
+func TestCheckStartupSlowAPI(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/health" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		<-release
+		w.Write([]byte(`{"github_login":"ada"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	check := checkStartup(client.New(srv.URL, "tok"), 50*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("checkStartup took %v, want it to give up after the timeout", elapsed)
+	}
+	if check.pending == nil || check.signedOut() {
+		t.Fatalf("check = %+v, want sign-in still pending", check)
+	}
+	if b := check.banner(); !strings.Contains(b, "signing in") {
+		t.Errorf("banner = %q, want the healthy-but-slow notice", b)
+	}
+
+	close(release)
+	if err := <-check.pending; err != nil {
+		t.Errorf("background sign-in: %v", err)
+	}
+}
+
+func TestCheckStartupAPIDown(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	check := checkStartup(client.New(srv.URL, "tok"), 50*time.Millisecond)
+	if check.healthErr == nil {
+		t.Fatal("expected the health probe to miss its deadline")
+	}
+	if b := check.banner(); !strings.Contains(b, "isn't responding") {
+		t.Errorf("banner = %q, want the API-down notice", b)
+	}
+}
+
+func TestCheckStartupOff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	check := checkStartup(client.New(srv.URL, "tok"), 0)
+	if check.pending == nil {
+		t.Fatal("expected a zero timeout not to wait for sign-in")
+	}
+	<-check.pending
+}
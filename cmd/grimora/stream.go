@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// streamPage is how many events each request asks for. Bursts bigger than
+// this between two polls lose their oldest events.
+const streamPage = 50
+
+// streamSource is the part of the client the stream command needs.
+type streamSource interface {
+	GetStream(ctx context.Context, followingOnly bool, limit, offset int) ([]domain.StreamEvent, error)
+}
+
+// streamOptions are the stream command's filters and output format.
+type streamOptions struct {
+	kinds     []string // empty means every kind
+	following bool
+	asJSON    bool
+	limit     int
+}
+
+// runStream implements `grimora stream [--follow] [--kind k,...] [--following] [--limit n] [--json]`:
+// the activity stream as one line per event, oldest first, for piping into
+// notify-send, a status bar or a script.
+func runStream(apiURL string, args []string) error {
+	fs := flag.NewFlagSet("stream", flag.ContinueOnError)
+	follow := fs.Bool("follow", false, "keep running and print new events as they happen")
+	kind := fs.String("kind", "", "only these comma-separated kinds: "+strings.Join(domain.StreamKinds, ", "))
+	following := fs.Bool("following", false, "only events from magicians you follow")
+	limit := fs.Int("limit", 20, "number of recent events to print first (0-50)")
+	asJSON := fs.Bool("json", false, "print events as NDJSON")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	opts := streamOptions{following: *following, asJSON: *asJSON, limit: *limit}
+	for k := range strings.SplitSeq(*kind, ",") {
+		if k = strings.TrimSpace(strings.ToLower(k)); k == "" {
+			continue
+		}
+		if !slices.Contains(domain.StreamKinds, k) {
+			return fmt.Errorf("unknown kind %q (want %s)", k, strings.Join(domain.StreamKinds, ", "))
+		}
+		opts.kinds = append(opts.kinds, k)
+	}
+	if opts.limit < 0 || opts.limit > streamPage {
+		return fmt.Errorf("--limit must be between 0 and %d", streamPage)
+	}
+
+	token := readToken()
+	if *following && token == "" {
+		return errNotLoggedIn
+	}
+	c := newClient(apiURL, token)
+	if !*follow {
+		return printStream(context.Background(), c, opts, os.Stdout)
+	}
+	// How often --follow asks for new events; loadConfig already reported
+	// a bad setting.
+	every, err := loadConfig().PollInterval(config.PollStream)
+	if err != nil {
+		every = config.DefaultPollIntervals[config.PollStream]
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return followStream(ctx, c, opts, os.Stdout, every)
+}
+
+// printStream prints the latest opts.limit matching events and returns.
+func printStream(ctx context.Context, src streamSource, opts streamOptions, w io.Writer) error {
+	events, err := src.GetStream(ctx, opts.following, streamPage, 0)
+	if err != nil {
+		return fmt.Errorf("get stream: %w", err)
+	}
+	events = opts.filter(events)
+	if len(events) > opts.limit {
+		events = events[len(events)-opts.limit:]
+	}
+	return writeStreamEvents(w, events, opts.asJSON)
+}
+
+// followStream prints the latest events, then polls every interval and
+// prints only what's new, until ctx is done. A failed poll is reported on
+// stderr and retried; only the first request's failure is fatal.
+func followStream(ctx context.Context, src streamSource, opts streamOptions, w io.Writer, interval time.Duration) error {
+	events, err := src.GetStream(ctx, opts.following, streamPage, 0)
+	if err != nil {
+		return fmt.Errorf("get stream: %w", err)
+	}
+	seen := make(map[string]bool)
+	for _, e := range events {
+		seen[e.ID.String()] = true
+	}
+	shown := opts.filter(events)
+	if len(shown) > opts.limit {
+		shown = shown[len(shown)-opts.limit:]
+	}
+	if err := writeStreamEvents(w, shown, opts.asJSON); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		events, err := src.GetStream(ctx, opts.following, streamPage, 0)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "warning: get stream: %v (retrying)\n", err)
+			continue
+		}
+		// Only the latest page can come back, so it's all seen needs to hold.
+		var fresh []domain.StreamEvent
+		page := make(map[string]bool, len(events))
+		for _, e := range events {
+			page[e.ID.String()] = true
+			if !seen[e.ID.String()] {
+				fresh = append(fresh, e)
+			}
+		}
+		seen = page
+		if err := writeStreamEvents(w, opts.filter(fresh), opts.asJSON); err != nil {
+			return err
+		}
+	}
+}
+
+// filter keeps the events of the chosen kinds, oldest first. The API sends
+// newest first.
+func (o streamOptions) filter(events []domain.StreamEvent) []domain.StreamEvent {
+	out := make([]domain.StreamEvent, 0, len(events))
+	for _, e := range events {
+		if len(o.kinds) == 0 || slices.Contains(o.kinds, e.Kind) {
+			out = append(out, e)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b domain.StreamEvent) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return out
+}
+
+// writeStreamEvents writes one line per event, or one JSON object per line.
+func writeStreamEvents(w io.Writer, events []domain.StreamEvent, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(w)
+		for _, e := range events {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, e := range events {
+		if _, err := fmt.Fprintln(w, formatStreamEvent(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatStreamEvent renders e on one line: local time, kind, who and what.
+func formatStreamEvent(e domain.StreamEvent) string {
+	what := e.Title
+	switch e.Kind {
+	case "member":
+		what = "joined " + e.GuildID
+		if e.City != "" {
+			what += " from " + e.City
+		}
+	case "muse":
+		if e.Voice != "" {
+			what = e.Voice
+		}
+	case "reforge":
+		what = fmt.Sprintf("%s re-forged to P%d", what, e.Potency)
+	}
+	if e.Tag != "" {
+		what += " #" + e.Tag
+	}
+	line := fmt.Sprintf("%s  %-8s", e.CreatedAt.Local().Format("15:04:05"), e.Kind)
+	if e.MagicianLogin != "" {
+		line += "  @" + e.MagicianLogin
+	}
+	return line + "  " + strings.Join(strings.Fields(what), " ")
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// fakeStream serves pages in turn, repeating the last, newest event first.
+type fakeStream struct {
+	mu        sync.Mutex
+	pages     [][]domain.StreamEvent
+	calls     int
+	following bool
+}
+
+func (f *fakeStream) GetStream(_ context.Context, followingOnly bool, _, _ int) ([]domain.StreamEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.following = followingOnly
+	page := f.pages[min(f.calls, len(f.pages)-1)]
+	f.calls++
+	return page, nil
+}
+
+func streamEvent(kind, login, title string, at time.Time) domain.StreamEvent {
+	return domain.StreamEvent{ID: uuid.New(), Kind: kind, MagicianLogin: login, Title: title, CreatedAt: at}
+}
+
+func TestPrintStreamFiltersAndOrders(t *testing.T) {
+	now := time.Now()
+	src := &fakeStream{pages: [][]domain.StreamEvent{{
+		streamEvent("spell", "mona", "newest", now),
+		streamEvent("featured", "octo", "skip me", now.Add(-time.Minute)),
+		streamEvent("spell", "mona", "older", now.Add(-2*time.Minute)),
+		streamEvent("spell", "mona", "oldest", now.Add(-3*time.Minute)),
+	}}}
+	var buf bytes.Buffer
+	opts := streamOptions{kinds: []string{"spell"}, following: true, limit: 2}
+	if err := printStream(context.Background(), src, opts, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "older") || !strings.HasSuffix(lines[1], "newest") {
+		t.Errorf("lines = %q, want the two newest spells, oldest first", lines)
+	}
+	if !src.following {
+		t.Error("--following should ask for followed magicians only")
+	}
+}
+
+func TestFollowStreamPrintsOnlyNew(t *testing.T) {
+	now := time.Now()
+	first := streamEvent("spell", "mona", "first", now.Add(-time.Minute))
+	second := streamEvent("member", "octo", "", now)
+	second.GuildID = "nyx"
+	src := &fakeStream{pages: [][]domain.StreamEvent{{first}, {second, first}}}
+
+	var buf bytes.Buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := followStream(ctx, src, streamOptions{limit: 20}, &buf, 5*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Count(out, "first") != 1 || strings.Count(out, "@octo  joined nyx") != 1 {
+		t.Errorf("each event should print once:\n%s", out)
+	}
+}
+
+func TestWriteStreamEventsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	e := streamEvent("spell", "mona", "x", time.Now())
+	if err := writeStreamEvents(&buf, []domain.StreamEvent{e, e}, true); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], `{"kind":"spell"`) {
+		t.Errorf("NDJSON = %q", buf.String())
+	}
+}
+
+func TestRunStreamRejectsBadFlags(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := runStream("http://unused.invalid", []string{"--kind", "gossip"}); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+	if err := runStream("http://unused.invalid", []string{"--limit", "500"}); err == nil {
+		t.Error("expected an error for a limit past the page size")
+	}
+	if err := runStream("http://unused.invalid", []string{"--following"}); err != errNotLoggedIn {
+		t.Errorf("--following signed out = %v, want errNotLoggedIn", err)
+	}
+}
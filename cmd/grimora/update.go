@@ -0,0 +1,336 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/config"
+	semver "github.com/naveenspark/grimora/internal/version"
+)
+
+// releaseChannel classifies a release. Nightlies carry "nightly" in their
+// tag; any other prerelease (beta, rc) is on the beta channel.
+func releaseChannel(r ghRelease) string {
+	switch {
+	case strings.Contains(r.TagName, "nightly"):
+		return config.ChannelNightly
+	case r.Prerelease || strings.Contains(r.TagName, "-"):
+		return config.ChannelBeta
+	}
+	return config.ChannelStable
+}
+
+// channelRank orders channels from most to least stable. A channel accepts
+// releases from itself and every more stable channel.
+var channelRank = map[string]int{
+	config.ChannelStable:  0,
+	config.ChannelBeta:    1,
+	config.ChannelNightly: 2,
+}
+
+// pickRelease returns the newest published release the channel accepts.
+func pickRelease(releases []ghRelease, channel string) (ghRelease, bool) {
+	var best ghRelease
+	found := false
+	for _, r := range releases {
+		if r.Draft || channelRank[releaseChannel(r)] > channelRank[channel] {
+			continue
+		}
+		if !found || semver.Newer(r.TagName, best.TagName) {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+// fetchRelease finds the release to install from channel.
+func fetchRelease(httpClient *http.Client, channel string) (ghRelease, error) {
+	url := semver.ReleasesURL + "?per_page=30"
+	if channel == config.ChannelStable {
+		url = semver.ReleasesURL + "/latest"
+	}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return ghRelease{}, fmt.Errorf("check for updates: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return ghRelease{}, fmt.Errorf("GitHub API returned %s", resp.Status)
+	}
+
+	if channel == config.ChannelStable {
+		var release ghRelease
+		if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+			return ghRelease{}, fmt.Errorf("parse release: %w", err)
+		}
+		return release, nil
+	}
+	var releases []ghRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return ghRelease{}, fmt.Errorf("parse releases: %w", err)
+	}
+	release, ok := pickRelease(releases, channel)
+	if !ok {
+		return ghRelease{}, fmt.Errorf("no releases on the %s channel", channel)
+	}
+	return release, nil
+}
+
+// releaseAssetName is the archive holding the binary for goos/goarch.
+// Windows builds ship as zip, everything else as tar.gz.
+func releaseAssetName(goos, goarch string) string {
+	if goos == "windows" {
+		return fmt.Sprintf("grimora_%s_%s.zip", goos, goarch)
+	}
+	return fmt.Sprintf("grimora_%s_%s.tar.gz", goos, goarch)
+}
+
+// binaryName is the executable's file name inside release archives.
+func binaryName(goos string) string {
+	if goos == "windows" {
+		return "grimora.exe"
+	}
+	return "grimora"
+}
+
+// oldBinaryPath is where the previous binary is kept for rollback:
+// grimora.old next to grimora, or grimora.old.exe on Windows so it stays
+// runnable.
+func oldBinaryPath(execPath string) string {
+	return siblingBinaryPath(execPath, ".old")
+}
+
+// stagedBinaryPath is where a downloaded binary waits to be swapped in:
+// grimora.new, or grimora.new.exe on Windows so it can run as the update
+// helper.
+func stagedBinaryPath(execPath string) string {
+	return siblingBinaryPath(execPath, ".new")
+}
+
+func siblingBinaryPath(execPath, suffix string) string {
+	ext := filepath.Ext(execPath)
+	if ext != ".exe" {
+		ext = ""
+	}
+	return strings.TrimSuffix(execPath, ext) + suffix + ext
+}
+
+// installBinary moves the staged binary into execPath and keeps the binary
+// it replaces at oldPath.
+//
+// On Unix the current binary is hard-linked (or copied) to oldPath and the
+// staged one renamed over it, so execPath never goes missing. Windows can't
+// replace a running executable but can rename it, so there the current
+// binary is moved aside first and put back if the second rename fails.
+func installBinary(stagePath, execPath, oldPath string) error {
+	if err := os.Remove(oldPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove %s: %w", oldPath, err)
+	}
+	if runtime.GOOS == "windows" {
+		if err := os.Rename(execPath, oldPath); err != nil {
+			return fmt.Errorf("keep previous binary: %w", err)
+		}
+		if err := os.Rename(stagePath, execPath); err != nil {
+			os.Rename(oldPath, execPath) //nolint:errcheck // best-effort restore
+			return fmt.Errorf("replace binary: %w", err)
+		}
+		return nil
+	}
+	if err := os.Link(execPath, oldPath); err != nil {
+		if err := copyFile(execPath, oldPath); err != nil {
+			return fmt.Errorf("keep previous binary: %w", err)
+		}
+	}
+	if err := os.Rename(stagePath, execPath); err != nil {
+		return fmt.Errorf("replace binary: %w", err)
+	}
+	return nil
+}
+
+// copyFile copies src to dst with executable permissions.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close() //nolint:errcheck
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close() //nolint:errcheck
+		return err
+	}
+	return out.Close()
+}
+
+// rollbackBinary swaps execPath with the binary kept at oldPath, so a second
+// rollback undoes the first.
+func rollbackBinary(execPath, oldPath string) error {
+	if _, err := os.Stat(oldPath); errors.Is(err, fs.ErrNotExist) {
+		return errors.New("no previous version to roll back to — " + filepath.Base(oldPath) + " is created by grimora update")
+	}
+	stagePath := execPath + ".rollback"
+	if err := os.Rename(oldPath, stagePath); err != nil {
+		return fmt.Errorf("stage previous binary: %w", err)
+	}
+	if err := installBinary(stagePath, execPath, oldPath); err != nil {
+		os.Rename(stagePath, oldPath) //nolint:errcheck // best-effort restore
+		return err
+	}
+	return nil
+}
+
+// binaryVersion asks the binary at path for its version, e.g. "v0.9.1".
+// It returns "" if the binary doesn't answer.
+func binaryVersion(path string) string {
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	v := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(out)), "grimora"))
+	if v == "" || strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "v" + v
+}
+
+// runRollback implements `grimora update --rollback`.
+func runRollback() error {
+	execPath, err := resolveExecutable()
+	if err != nil {
+		return fmt.Errorf("runRollback: %w", err)
+	}
+	oldPath := oldBinaryPath(execPath)
+	restored := binaryVersion(oldPath)
+	if err := rollbackBinary(execPath, oldPath); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return fmt.Errorf("permission denied replacing %s — try with sudo", execPath)
+		}
+		return fmt.Errorf("runRollback: %w", err)
+	}
+	printRollbackSuccess("v"+strings.TrimPrefix(version, "v"), restored)
+	return nil
+}
+
+// resolveExecutable returns the running binary's real path.
+func resolveExecutable() (string, error) {
+	execPath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("find executable: %w", err)
+	}
+	execPath, err = filepath.EvalSymlinks(execPath)
+	if err != nil {
+		return "", fmt.Errorf("resolve symlinks: %w", err)
+	}
+	return execPath, nil
+}
+
+// reexec hands over to the binary at path. Unix replaces this process with
+// it; Windows has no exec, so it runs the binary as a child and waits.
+func reexec(path string, args []string) error {
+	if runtime.GOOS == "windows" {
+		cmd := exec.Command(path, args...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		return cmd.Run()
+	}
+	return syscall.Exec(path, append([]string{"grimora"}, args...), os.Environ())
+}
+
+// finishUpdateArg starts grimora as the update helper; see
+// startUpdateHelper.
+const finishUpdateArg = "--finish-update"
+
+// The update helper tries the swap once a second for up to half a minute.
+const (
+	swapAttempts   = 30
+	swapRetryDelay = time.Second
+)
+
+// startUpdateHelper hands an update that couldn't swap the binary in place
+// to the staged binary itself. Windows refuses to rename a file something
+// else has open, whether another grimora or a virus scanner, so the helper
+// keeps trying after this process has exited, then restarts as the new
+// version. It shares the console, so its messages show up where the update
+// was started.
+func startUpdateHelper(stagePath, execPath, from, to string) error {
+	cmd := exec.Command(stagePath, finishUpdateArg, execPath, from, to)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	return cmd.Start()
+}
+
+// runFinishUpdate is the update helper's half of startUpdateHelper: args are
+// the path to replace and the versions updated from and to.
+func runFinishUpdate(args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: grimora %s <path> <from> <to>", finishUpdateArg)
+	}
+	execPath, from, to := args[0], args[1], args[2]
+	self, err := resolveExecutable()
+	if err != nil {
+		return fmt.Errorf("finish update: %w", err)
+	}
+	if err := swapWhenFree(self, execPath, oldBinaryPath(execPath), swapAttempts, swapRetryDelay); err != nil {
+		return fmt.Errorf("finish update: %w — close any other grimora and run grimora update again", err)
+	}
+	return reexec(execPath, []string{"--update-done", from, to})
+}
+
+// swapWhenFree installs stagePath at execPath, trying again after delay
+// while the swap fails, at most attempts times in all.
+func swapWhenFree(stagePath, execPath, oldPath string, attempts int, delay time.Duration) error {
+	var err error
+	for i := range attempts {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		if err = installBinary(stagePath, execPath, oldPath); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// extractZipBinary extracts name from the zip archive at zipPath to dest.
+func extractZipBinary(zipPath, name, dest string) error {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("zip reader: %w", err)
+	}
+	defer zr.Close() //nolint:errcheck
+
+	for _, f := range zr.File {
+		if filepath.Base(f.Name) != name || f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close() //nolint:errcheck
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+		if err != nil {
+			return err
+		}
+		const maxBinarySize = 200 << 20 // 200 MB
+		if _, err := io.Copy(out, io.LimitReader(rc, maxBinarySize)); err != nil {
+			out.Close() //nolint:errcheck
+			return err
+		}
+		return out.Close()
+	}
+	return fmt.Errorf("%s not found in zip", name)
+}
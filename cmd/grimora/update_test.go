@@ -0,0 +1,201 @@
+package main
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+func testReleases() []ghRelease {
+	return []ghRelease{
+		{TagName: "v1.3.0-nightly.20261016", Prerelease: true},
+		{TagName: "v1.3.0-beta.2", Prerelease: true},
+		{TagName: "v1.4.0", Draft: true},
+		{TagName: "v1.2.1"},
+		{TagName: "v1.2.0"},
+	}
+}
+
+func TestPickRelease(t *testing.T) {
+	tests := map[string]string{
+		config.ChannelStable:  "v1.2.1",
+		config.ChannelBeta:    "v1.3.0-beta.2",
+		config.ChannelNightly: "v1.3.0-nightly.20261016",
+	}
+	for channel, want := range tests {
+		got, ok := pickRelease(testReleases(), channel)
+		if !ok || got.TagName != want {
+			t.Errorf("pickRelease(%s) = %q, %v; want %q", channel, got.TagName, ok, want)
+		}
+	}
+	// A stable release newer than every beta wins on the beta channel too.
+	releases := append(testReleases(), ghRelease{TagName: "v1.3.0"})
+	if got, _ := pickRelease(releases, config.ChannelBeta); got.TagName != "v1.3.0" {
+		t.Errorf("beta channel picked %q, want v1.3.0", got.TagName)
+	}
+	if _, ok := pickRelease(testReleases()[:2], config.ChannelStable); ok {
+		t.Error("stable channel should ignore prereleases")
+	}
+}
+
+func TestReleaseAssetName(t *testing.T) {
+	if got := releaseAssetName("linux", "arm64"); got != "grimora_linux_arm64.tar.gz" {
+		t.Errorf("linux asset = %q", got)
+	}
+	if got := releaseAssetName("windows", "amd64"); got != "grimora_windows_amd64.zip" {
+		t.Errorf("windows asset = %q", got)
+	}
+}
+
+func TestOldBinaryPath(t *testing.T) {
+	if got := oldBinaryPath("/usr/local/bin/grimora"); got != "/usr/local/bin/grimora.old" {
+		t.Errorf("unix old path = %q", got)
+	}
+	if got := oldBinaryPath(`C:\grimora\grimora.exe`); got != `C:\grimora\grimora.old.exe` {
+		t.Errorf("windows old path = %q", got)
+	}
+}
+
+func TestStagedBinaryPath(t *testing.T) {
+	if got := stagedBinaryPath("/usr/local/bin/grimora"); got != "/usr/local/bin/grimora.new" {
+		t.Errorf("unix staged path = %q", got)
+	}
+	if got := stagedBinaryPath(`C:\grimora\grimora.exe`); got != `C:\grimora\grimora.new.exe` {
+		t.Errorf("windows staged path = %q", got)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestInstallAndRollback(t *testing.T) {
+	dir := t.TempDir()
+	execPath := filepath.Join(dir, "grimora")
+	oldPath := oldBinaryPath(execPath)
+	stagePath := execPath + ".new"
+	writeFile(t, execPath, "v1")
+	writeFile(t, oldPath, "v0")
+	writeFile(t, stagePath, "v2")
+
+	if err := installBinary(stagePath, execPath, oldPath); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, execPath); got != "v2" {
+		t.Errorf("installed = %q, want v2", got)
+	}
+	if got := readFile(t, oldPath); got != "v1" {
+		t.Errorf("kept = %q, want v1", got)
+	}
+	if _, err := os.Stat(stagePath); !os.IsNotExist(err) {
+		t.Error("staged binary should be gone")
+	}
+
+	if err := rollbackBinary(execPath, oldPath); err != nil {
+		t.Fatal(err)
+	}
+	if got, kept := readFile(t, execPath), readFile(t, oldPath); got != "v1" || kept != "v2" {
+		t.Errorf("after rollback: installed %q, kept %q; want v1, v2", got, kept)
+	}
+	// Rolling back again returns to the update.
+	if err := rollbackBinary(execPath, oldPath); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, execPath); got != "v2" {
+		t.Errorf("after second rollback: %q, want v2", got)
+	}
+}
+
+func TestSwapWhenFreeRetries(t *testing.T) {
+	dir := t.TempDir()
+	execPath := filepath.Join(dir, "grimora")
+	stagePath := stagedBinaryPath(execPath)
+	writeFile(t, execPath, "v1")
+
+	// Nothing to swap in yet: every attempt fails.
+	if err := swapWhenFree(stagePath, execPath, oldBinaryPath(execPath), 2, time.Millisecond); err == nil {
+		t.Fatal("expected an error after running out of attempts")
+	}
+
+	// The swap goes through once it can.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		tmp := filepath.Join(dir, "staging")
+		os.WriteFile(tmp, []byte("v2"), 0o755) //nolint:errcheck
+		os.Rename(tmp, stagePath)              //nolint:errcheck
+	}()
+	if err := swapWhenFree(stagePath, execPath, oldBinaryPath(execPath), 200, 5*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, execPath); got != "v2" {
+		t.Errorf("installed = %q, want v2", got)
+	}
+}
+
+func TestRollbackWithoutOldBinary(t *testing.T) {
+	dir := t.TempDir()
+	execPath := filepath.Join(dir, "grimora")
+	writeFile(t, execPath, "v1")
+	if err := rollbackBinary(execPath, oldBinaryPath(execPath)); err == nil {
+		t.Error("expected error with nothing to roll back to")
+	}
+	if got := readFile(t, execPath); got != "v1" {
+		t.Errorf("binary changed to %q", got)
+	}
+}
+
+func TestExtractZipBinary(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "grimora.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, body := range map[string]string{"README.md": "docs", "grimora.exe": "binary"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(body)) //nolint:errcheck
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close() //nolint:errcheck
+
+	dest := filepath.Join(dir, "out.exe")
+	if err := extractZipBinary(zipPath, "grimora.exe", dest); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, dest); got != "binary" {
+		t.Errorf("extracted %q, want binary", got)
+	}
+	if err := extractZipBinary(zipPath, "missing.exe", dest); err == nil {
+		t.Error("expected error for a missing binary")
+	}
+}
+
+func TestRunUpdateRejectsUnknownChannel(t *testing.T) {
+	defer func(v string) { version = v }(version)
+	version = "1.0.0"
+	if err := runUpdate([]string{"--channel", "canary"}); err == nil {
+		t.Error("expected error for unknown channel")
+	}
+}
@@ -72,3 +72,28 @@ func printAlreadyCurrent(currentVersion string) {
 	fmt.Printf("\n  %s│%s %s%sTHE GRIMOIRE%s\n", ansiGold, ansiReset, ansiGold, ansiBold, ansiReset)
 	fmt.Printf("  %s│%s %s%sNo revision warranted. The pages are clean.%s\n\n", ansiGold, ansiReset, ansiGoldLight, ansiItalic, ansiReset)
 }
+
+// rollbackVoiceLines are random Grimoire messages shown after a rollback.
+var rollbackVoiceLines = []string{
+	"The old pages, restored.",
+	"We do not speak of that edition.",
+	"Struck from the record.",
+	"Back a chapter. Nobody saw.",
+}
+
+// printRollbackSuccess prints the rollback-complete message. restored is the
+// version now installed, or "" if the old binary didn't say.
+func printRollbackSuccess(fromVersion, restored string) {
+	printUpdateLogo()
+	if restored == "" {
+		restored = "previous version"
+	}
+	fmt.Printf("\n  %s%s%s  %s%s←%s  %s%s%s%s\n",
+		ansiSlate, fromVersion, ansiReset,
+		ansiGold, ansiBold, ansiReset,
+		ansiEmerald, ansiBold, restored, ansiReset,
+	)
+	line := rollbackVoiceLines[rand.Intn(len(rollbackVoiceLines))]
+	fmt.Printf("\n  %s│%s %s%sTHE GRIMOIRE%s\n", ansiGold, ansiReset, ansiGold, ansiBold, ansiReset)
+	fmt.Printf("  %s│%s %s%s%s%s\n\n", ansiGold, ansiReset, ansiGoldLight, ansiItalic, line, ansiReset)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/naveenspark/grimora/internal/tui"
+)
+
+// viewFlag opens the TUI on a tab: `grimora --view grimoire --tag debugging`.
+const viewFlag = "--view"
+
+// startView is set by --view and its filters, or by `grimora tui`: the TUI
+// opens there instead of where the last session left off.
+var startView *tui.StartView
+
+// parseViewArgs parses the arguments of `grimora tui`, which plain grimora
+// also takes when they start with --view, --tag or --search. It returns nil when they name no
+// tab or filter. --tag and --search filter the Grimoire, so alone they open
+// it.
+func parseViewArgs(args []string) (*tui.StartView, error) {
+	fs := flag.NewFlagSet("tui", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	tab := fs.String("view", "", "tab to open on: "+strings.Join(tui.TabNames(), ", "))
+	tag := fs.String("tag", "", "Grimoire tags, comma-separated")
+	search := fs.String("search", "", "Grimoire search")
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("usage: grimora tui [--view <tab>] [--tag <tags>] [--search <query>]: %w", err)
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	s := tui.StartView{Tab: strings.ToLower(strings.TrimSpace(*tab)), Search: strings.TrimSpace(*search)}
+	var tags []string
+	for t := range strings.SplitSeq(*tag, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	s.Tag = strings.Join(tags, ",")
+	switch {
+	case s.Tab == "" && s.Tag == "" && s.Search == "":
+		return nil, nil
+	case s.Tab == "":
+		s.Tab = "grimoire"
+	case !slices.Contains(tui.TabNames(), s.Tab):
+		return nil, fmt.Errorf("unknown view %q (want one of %s)", *tab, strings.Join(tui.TabNames(), ", "))
+	case s.Tab != "grimoire" && (s.Tag != "" || s.Search != ""):
+		return nil, errors.New("--tag and --search only apply to --view grimoire")
+	}
+	return &s, nil
+}
+
+// startsWithViewFlag reports whether args begin with one of the flags
+// parseViewArgs takes, in either its "--flag value" or "--flag=value" form.
+func startsWithViewFlag(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	name, _, _ := strings.Cut(args[0], "=")
+	return isGlobalValueFlag(name)
+}
+
+// withStartView opens app where --view asked, if it did.
+func withStartView(app tui.App) tui.App {
+	if startView == nil {
+		return app
+	}
+	return app.WithStartView(*startView)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/naveenspark/grimora/internal/tui"
+)
+
+func TestParseViewArgs(t *testing.T) {
+	cases := []struct {
+		args []string
+		want *tui.StartView
+	}{
+		{nil, nil},
+		{[]string{"--view", "hall"}, &tui.StartView{Tab: "hall"}},
+		{[]string{"--view=Grimoire", "--tag", "Debugging, rust", "--search", "flaky test"}, &tui.StartView{Tab: "grimoire", Tag: "debugging,rust", Search: "flaky test"}},
+		{[]string{"--tag", "testing"}, &tui.StartView{Tab: "grimoire", Tag: "testing"}},
+	}
+	for _, c := range cases {
+		got, err := parseViewArgs(c.args)
+		if err != nil {
+			t.Errorf("parseViewArgs(%q): %v", c.args, err)
+			continue
+		}
+		if (got == nil) != (c.want == nil) || got != nil && *got != *c.want {
+			t.Errorf("parseViewArgs(%q) = %+v, want %+v", c.args, got, c.want)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"--view", "create"},
+		{"--view", "board", "--tag", "go"},
+		{"--view", "hall", "extra"},
+		{"--room", "go-help"},
+	} {
+		if _, err := parseViewArgs(args); err == nil {
+			t.Errorf("parseViewArgs(%q): expected an error", args)
+		}
+	}
+}
+
+func TestStartsWithViewFlag(t *testing.T) {
+	for _, c := range []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"--view", "hall"}, true},
+		{[]string{"--view=hall"}, true},
+		{[]string{"--tag", "go"}, true},
+		{[]string{"--search=flaky test", "--view", "grimoire"}, true},
+		{[]string{"-tag", "go"}, true},
+		{[]string{"--update-done"}, false},
+		{[]string{"spells", "--tag", "go"}, false},
+	} {
+		if got := startsWithViewFlag(c.args); got != c.want {
+			t.Errorf("startsWithViewFlag(%q) = %v, want %v", c.args, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/naveenspark/grimora/internal/appdir"
+)
+
+// lastVersionPath returns ~/.grimora/last_version, which remembers the
+// version that last ran so an update can be followed by its release notes.
+func lastVersionPath() (string, error) {
+	return appdir.Path("last_version")
+}
+
+// markVersionSeen records current as the last version run and reports
+// whether a different version ran before, i.e. the user just updated. A
+// first run and dev builds never count as updates.
+func markVersionSeen(path, current string) bool {
+	if current == "" || current == "dev" {
+		return false
+	}
+	var prev string
+	if data, err := os.ReadFile(path); err == nil {
+		prev = strings.TrimSpace(string(data))
+	}
+	if prev == current {
+		return false
+	}
+	// Best-effort: if this fails, the notes simply show again next launch.
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err == nil {
+		os.WriteFile(path, []byte(current+"\n"), 0o600) //nolint:errcheck
+	}
+	return prev != ""
+}
+
+// isFirstRun reports whether no version has run before on this machine. Dev
+// builds never record a version, so they never count as a first run.
+func isFirstRun(path, current string) bool {
+	if current == "" || current == "dev" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return errors.Is(err, fs.ErrNotExist)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMarkVersionSeen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".grimora", "last_version")
+
+	if markVersionSeen(path, "0.5.0") {
+		t.Error("first run should not count as an update")
+	}
+	if markVersionSeen(path, "0.5.0") {
+		t.Error("same version should not count as an update")
+	}
+	if !markVersionSeen(path, "0.6.0") {
+		t.Error("new version should count as an update")
+	}
+	if markVersionSeen(path, "0.6.0") {
+		t.Error("notes should only show once per update")
+	}
+	if markVersionSeen(path, "dev") {
+		t.Error("dev builds never count as updates")
+	}
+}
+
+func TestIsFirstRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".grimora", "last_version")
+
+	if !isFirstRun(path, "0.5.0") {
+		t.Error("no recorded version should be a first run")
+	}
+	if isFirstRun(path, "dev") {
+		t.Error("dev builds never count as a first run")
+	}
+	markVersionSeen(path, "0.5.0")
+	if isFirstRun(path, "0.6.0") {
+		t.Error("a recorded version means this isn't the first run")
+	}
+}
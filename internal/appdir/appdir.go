@@ -0,0 +1,53 @@
+// Package appdir locates the directory grimora keeps its files in: the token,
+// config, state, drafts, outbox, journal and logs.
+//
+// On Unix that's ~/.grimora. On Windows it's %APPDATA%\grimora, where
+// per-user application data belongs, unless an older install already left a
+// %USERPROFILE%\.grimora behind; that one keeps being used so nobody is
+// signed out or loses drafts by upgrading.
+package appdir
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Dir returns grimora's directory. It doesn't create it.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir: %w", err)
+	}
+	return dirFor(runtime.GOOS, home, os.Getenv("APPDATA"), isDir), nil
+}
+
+// Path returns name inside Dir.
+func Path(name ...string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append([]string{dir}, name...)...), nil
+}
+
+// dirFor picks the directory for goos, given the home directory, the
+// APPDATA variable and a way to tell whether a directory exists.
+func dirFor(goos, home, appData string, exists func(string) bool) string {
+	legacy := filepath.Join(home, ".grimora")
+	if goos != "windows" || appData == "" || exists(legacy) {
+		return legacy
+	}
+	return filepath.Join(appData, "grimora")
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false
+	}
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,35 @@
+package appdir
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDirFor(t *testing.T) {
+	home := filepath.Join("home", "ada")
+	appData := filepath.Join("home", "ada", "AppData", "Roaming")
+	legacy := filepath.Join(home, ".grimora")
+	none := func(string) bool { return false }
+	onlyLegacy := func(p string) bool { return p == legacy }
+
+	tests := []struct {
+		name    string
+		goos    string
+		appData string
+		exists  func(string) bool
+		want    string
+	}{
+		{"unix", "linux", "", none, legacy},
+		{"unix ignores APPDATA", "darwin", appData, none, legacy},
+		{"windows", "windows", appData, none, filepath.Join(appData, "grimora")},
+		{"windows keeps an existing install", "windows", appData, onlyLegacy, legacy},
+		{"windows without APPDATA", "windows", "", none, legacy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dirFor(tt.goos, home, tt.appData, tt.exists); got != tt.want {
+				t.Errorf("dirFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,21 +1,135 @@
+// Package browser opens links in the user's web browser, and prints them
+// for another device when there's no browser to open.
 package browser
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
 )
 
-// Open opens the specified URL in the user's default browser.
+// ErrNoBrowser means there's no browser to open here: a headless machine,
+// or an SSH session whose screen is somewhere else.
+var ErrNoBrowser = errors.New("no browser available here")
+
+// Open opens url in the user's browser. $BROWSER wins when set; otherwise
+// WSL opens the Windows browser, Termux hands the link to Android, and
+// macOS, Windows and Linux desktops use their usual opener.
 func Open(url string) error {
-	switch runtime.GOOS {
+	argv, err := command(hostEnv(), url)
+	if err != nil {
+		return err
+	}
+	return exec.Command(argv[0], argv[1:]...).Start()
+}
+
+// env is what command looks at to pick a browser.
+type env struct {
+	goos     string
+	getenv   func(string) string
+	lookPath func(string) (string, error)
+	wsl      bool
+}
+
+func hostEnv() env {
+	return env{goos: runtime.GOOS, getenv: os.Getenv, lookPath: exec.LookPath, wsl: isWSL()}
+}
+
+// isWSL reports whether this is Linux running under the Windows Subsystem
+// for Linux.
+func isWSL() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	release, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	return err == nil && strings.Contains(strings.ToLower(string(release)), "microsoft")
+}
+
+// command returns the command that opens url in e.
+func command(e env, url string) ([]string, error) {
+	if argv := fromBrowserVar(e, url); argv != nil {
+		return argv, nil
+	}
+	remote := e.getenv("SSH_CONNECTION") != "" || e.getenv("SSH_TTY") != ""
+	switch {
+	case e.wsl:
+		if _, err := e.lookPath("wslview"); err == nil {
+			return []string{"wslview", url}, nil
+		}
+		// rundll32 takes the URL as one argument, so & and ? need no
+		// quoting as they would with cmd.exe /c start.
+		return []string{"rundll32.exe", "url.dll,FileProtocolHandler", url}, nil
+	case e.getenv("TERMUX_VERSION") != "" || strings.Contains(e.getenv("PREFIX"), "com.termux"):
+		return []string{"termux-open-url", url}, nil
+	}
+	switch e.goos {
 	case "darwin":
-		return exec.Command("open", url).Start()
-	case "linux":
-		return exec.Command("xdg-open", url).Start()
+		if remote {
+			// open would show it on the Mac's own screen, not the caller's.
+			return nil, ErrNoBrowser
+		}
+		return []string{"open", url}, nil
 	case "windows":
-		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
-	default:
-		return fmt.Errorf("unsupported OS: %s", runtime.GOOS)
+		return []string{"rundll32", "url.dll,FileProtocolHandler", url}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if e.getenv("DISPLAY") == "" && e.getenv("WAYLAND_DISPLAY") == "" {
+			return nil, ErrNoBrowser
+		}
+		if _, err := e.lookPath("xdg-open"); err != nil {
+			return nil, ErrNoBrowser
+		}
+		return []string{"xdg-open", url}, nil
+	}
+	return nil, fmt.Errorf("unsupported OS: %s", e.goos)
+}
+
+// fromBrowserVar returns the first command in $BROWSER, a colon-separated
+// list, that's installed. A %s in it stands for the URL; without one the
+// URL is passed as the last argument.
+func fromBrowserVar(e env, url string) []string {
+	for cmd := range strings.SplitSeq(e.getenv("BROWSER"), string(os.PathListSeparator)) {
+		fields := strings.Fields(cmd)
+		if len(fields) == 0 {
+			continue
+		}
+		if _, err := e.lookPath(fields[0]); err != nil {
+			continue
+		}
+		placed := false
+		for i, f := range fields {
+			if strings.Contains(f, "%s") {
+				fields[i] = strings.ReplaceAll(f, "%s", url)
+				placed = true
+			}
+		}
+		if !placed {
+			fields = append(fields, url)
+		}
+		return fields
+	}
+	return nil
+}
+
+// Print writes url to w with a QR code beneath it, for opening the link on
+// a phone or another machine when Open can't.
+func Print(w io.Writer, url string) {
+	fmt.Fprintf(w, "  %s\n", url)
+	q, err := qrcode.New(url, qrcode.Low)
+	if err != nil {
+		// Too long to encode; the link above is enough.
+		return
+	}
+	fmt.Fprintln(w)
+	for line := range strings.SplitSeq(strings.TrimRight(q.ToSmallString(false), "\n"), "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
 	}
 }
@@ -0,0 +1,89 @@
+package browser
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testURL = "https://grimora.ai/faq?a=1&b=2"
+
+// testEnv is a Linux desktop with xdg-open, wslview and firefox installed,
+// plus the given environment variables.
+func testEnv(vars map[string]string) env {
+	return env{
+		goos:   "linux",
+		getenv: func(k string) string { return vars[k] },
+		lookPath: func(name string) (string, error) {
+			if slices.Contains([]string{"xdg-open", "wslview", "firefox", "termux-open-url"}, name) {
+				return "/usr/bin/" + name, nil
+			}
+			return "", errors.New("not found")
+		},
+	}
+}
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		e    env
+		want []string
+	}{
+		{"desktop", testEnv(map[string]string{"DISPLAY": ":0"}), []string{"xdg-open", testURL}},
+		{"wayland", testEnv(map[string]string{"WAYLAND_DISPLAY": "wayland-0"}), []string{"xdg-open", testURL}},
+		{"browser var", testEnv(map[string]string{"BROWSER": "lynx:firefox --new-tab"}), []string{"firefox", "--new-tab", testURL}},
+		{"browser placeholder", testEnv(map[string]string{"BROWSER": "firefox %s"}), []string{"firefox", testURL}},
+		{"termux", testEnv(map[string]string{"TERMUX_VERSION": "0.118"}), []string{"termux-open-url", testURL}},
+	}
+	wsl := testEnv(nil)
+	wsl.wsl = true
+	tests = append(tests, struct {
+		name string
+		e    env
+		want []string
+	}{"wsl", wsl, []string{"wslview", testURL}})
+
+	for _, tt := range tests {
+		got, err := command(tt.e, testURL)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("%s: command = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestCommandWSLWithoutWslview(t *testing.T) {
+	e := testEnv(nil)
+	e.wsl = true
+	e.lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	got, err := command(e, testURL)
+	if err != nil || got[0] != "rundll32.exe" || got[len(got)-1] != testURL {
+		t.Errorf("command = %q, %v; want rundll32.exe", got, err)
+	}
+}
+
+func TestCommandNoBrowser(t *testing.T) {
+	mac := testEnv(map[string]string{"SSH_CONNECTION": "10.0.0.2 51234 10.0.0.1 22"})
+	mac.goos = "darwin"
+	for name, e := range map[string]env{
+		"headless linux": testEnv(nil),
+		"ssh to a mac":   mac,
+	} {
+		if _, err := command(e, testURL); !errors.Is(err, ErrNoBrowser) {
+			t.Errorf("%s: err = %v, want ErrNoBrowser", name, err)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var buf bytes.Buffer
+	Print(&buf, testURL)
+	out := buf.String()
+	if !strings.HasPrefix(out, "  "+testURL+"\n") {
+		t.Errorf("expected the link first, got:\n%s", out)
+	}
+	if !strings.ContainsAny(out, "█▀▄") {
+		t.Errorf("expected a QR code, got:\n%s", out)
+	}
+}
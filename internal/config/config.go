@@ -0,0 +1,396 @@
+// Package config loads user preferences from ~/.grimora/config.json.
+package config
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/appdir"
+	"github.com/naveenspark/grimora/internal/hooks"
+)
+
+// Cursor styles accepted by Config.CursorStyle.
+const (
+	CursorStyleBlock          = "block"
+	CursorStyleHighVisibility = "high-visibility"
+)
+
+// Color settings accepted by Config.Color. Empty detects the terminal.
+const (
+	ColorTrueColor = "truecolor"
+	Color256       = "256"
+	Color16        = "16"
+	ColorNone      = "none"
+)
+
+// Glyph sets accepted by Config.Glyphs. Empty detects the terminal.
+const (
+	GlyphsUnicode = "unicode"
+	GlyphsASCII   = "ascii"
+)
+
+// Update channels accepted by Config.UpdateChannel, most stable first.
+const (
+	ChannelStable  = "stable"
+	ChannelBeta    = "beta"
+	ChannelNightly = "nightly"
+)
+
+// DefaultCursorBlink is the cursor on/off interval used when none is configured.
+const DefaultCursorBlink = 600 * time.Millisecond
+
+// Views whose polling Config.PollIntervals can tune.
+const (
+	PollHall    = "hall"
+	PollThreads = "threads"
+	PollStream  = "stream"
+)
+
+// DefaultPollIntervals are how often each view polls when PollIntervals
+// doesn't say.
+var DefaultPollIntervals = map[string]time.Duration{
+	PollHall:    3 * time.Second,
+	PollThreads: 5 * time.Second,
+	PollStream:  10 * time.Second,
+}
+
+// MinPollInterval is the shortest poll interval accepted, so a typo can't
+// hammer the API.
+const MinPollInterval = time.Second
+
+// LowBandwidthStretch is how many times longer every poll interval is in
+// low-bandwidth mode.
+const LowBandwidthStretch = 4
+
+// DefaultStartupTimeout is how long startup waits on the API before opening
+// the TUI in degraded mode.
+const DefaultStartupTimeout = 2 * time.Second
+
+// DefaultAwayAfter is how long the TUI goes without a keypress before it
+// reports the magician as away.
+const DefaultAwayAfter = 5 * time.Minute
+
+// Config holds user preferences. The zero value is the default configuration.
+type Config struct {
+	// CursorBlink is the input cursor blink interval as a Go duration
+	// ("600ms", "1s"), or "off" for a solid cursor. Empty uses the default.
+	CursorBlink string `json:"cursor_blink,omitempty"`
+	// CursorStyle is "block" (default) or "high-visibility".
+	CursorStyle string `json:"cursor_style,omitempty"`
+	// Bell rings the terminal bell on direct messages and @mentions.
+	Bell bool `json:"bell,omitempty"`
+	// Flash briefly highlights the tab bar on direct messages and @mentions.
+	Flash bool `json:"flash,omitempty"`
+	// Color forces a color depth: "truecolor", "256", "16" or "none". Empty
+	// detects what the terminal supports.
+	Color string `json:"color,omitempty"`
+	// ASCIIEmblems shows guild emblems as letters instead of emoji.
+	ASCIIEmblems bool `json:"ascii_emblems,omitempty"`
+	// Glyphs forces the symbol set: "unicode" for ✦, ▸, ● and box drawing,
+	// or "ascii" for plain stand-ins. Empty detects what the terminal can
+	// draw. "ascii" implies ASCIIEmblems.
+	Glyphs string `json:"glyphs,omitempty"`
+	// Accessible suits screen readers and reduced motion: no animation, no
+	// box drawing, and new messages and alerts printed as plain lines. Also
+	// turned on by `grimora --accessible`.
+	Accessible bool `json:"accessible,omitempty"`
+	// UpdateChannel is the release channel `grimora update` follows:
+	// "stable" (default), "beta" or "nightly".
+	UpdateChannel string `json:"update_channel,omitempty"`
+	// NoUpdateCheck turns off the once-a-day check for new releases, shown
+	// as a banner in the TUI header.
+	NoUpdateCheck bool `json:"no_update_check,omitempty"`
+	// StartupTimeout is how long startup waits for the API before opening
+	// the TUI in degraded mode, as a Go duration ("2s"), or "off" to open it
+	// straight away. Empty uses DefaultStartupTimeout.
+	StartupTimeout string `json:"startup_timeout,omitempty"`
+	// LockAfter locks the TUI after this long without a keypress, as a Go
+	// duration ("10m"). Empty or "off" never locks. Requires LockPassphrase.
+	LockAfter string `json:"lock_after,omitempty"`
+	// LockPassphrase is the SHA-256 of the passphrase that unlocks the TUI,
+	// in hex, so the passphrase itself never sits in the config file.
+	LockPassphrase string `json:"lock_passphrase,omitempty"`
+	// AwayAfter marks you away to other magicians after this long without
+	// a keypress, as a Go duration ("10m"), or "off" to always show as
+	// online while the TUI is open. Empty uses DefaultAwayAfter.
+	AwayAfter string `json:"away_after,omitempty"`
+	// QuietHours silences the bell and flash every day between two local
+	// times, as "22:00-07:30"; a span past midnight wraps to the next day.
+	// Empty has no quiet hours.
+	QuietHours string `json:"quiet_hours,omitempty"`
+	// CloneDir is where `g` on a weapon clones its repository. A leading
+	// "~/" is the home directory. Empty copies the `git clone` command to
+	// the clipboard instead.
+	CloneDir string `json:"clone_dir,omitempty"`
+	// PublishRepo is where `P` on a spell and `grimora spells publish`
+	// commit it, as "owner/name" or "owner/name/dir". Empty publishes a
+	// secret gist instead.
+	PublishRepo string `json:"publish_repo,omitempty"`
+	// PollIntervals sets how often a view polls, keyed by view ("hall",
+	// "threads" or "stream"), as Go durations ("10s"). Views left out use
+	// DefaultPollIntervals.
+	PollIntervals map[string]string `json:"poll_intervals,omitempty"`
+	// Hooks are shell commands run when something happens while the TUI
+	// is open, keyed by event ("on_mention", "on_dm" or "on_ship"). Each
+	// gets the event as JSON on stdin and as GRIMORA_* variables.
+	Hooks map[string]string `json:"hooks,omitempty"`
+	// LowBandwidth suits slow or metered links: polling is LowBandwidthStretch
+	// times slower, nothing animates, Hall reactions aren't fetched and
+	// lists load fewer items at a time. Also turned on by
+	// `grimora --low-bandwidth`.
+	LowBandwidth bool `json:"low_bandwidth,omitempty"`
+	// SaveHistory keeps what you send from the Hall and Threads inputs in
+	// the state file, so ↑ recalls it after a restart as well as within a
+	// run.
+	SaveHistory bool `json:"save_history,omitempty"`
+	// HideJoinLeave keeps magicians coming and going out of the Hall log.
+	// The who's-here count and roster still follow them.
+	HideJoinLeave bool `json:"hide_join_leave,omitempty"`
+}
+
+// Path returns ~/.grimora/config.json.
+func Path() (string, error) {
+	return appdir.Path("config.json")
+}
+
+// Load reads the config file. A missing file yields the default configuration.
+func Load() (Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Config{}, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads and validates the config at path.
+func LoadFile(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("read config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate reports the first invalid setting.
+func (c Config) Validate() error {
+	if _, err := c.CursorBlinkInterval(); err != nil {
+		return err
+	}
+	if _, err := c.StartupTimeoutDuration(); err != nil {
+		return err
+	}
+	lockAfter, err := c.LockAfterDuration()
+	if err != nil {
+		return err
+	}
+	if _, err := c.AwayAfterDuration(); err != nil {
+		return err
+	}
+	if _, err := c.QuietHoursSpan(); err != nil {
+		return err
+	}
+	if lockAfter > 0 || c.LockPassphrase != "" {
+		if b, err := hex.DecodeString(c.LockPassphrase); err != nil || len(b) != 32 {
+			return errors.New(`lock_passphrase: want the SHA-256 of your passphrase in hex (printf '%s' "passphrase" | sha256sum)`)
+		}
+	}
+	if _, err := c.CloneDirPath(); err != nil {
+		return err
+	}
+	for view := range c.PollIntervals {
+		if _, err := c.PollInterval(view); err != nil {
+			return err
+		}
+	}
+	for event := range c.Hooks {
+		if !slices.Contains(hooks.Names, event) {
+			return fmt.Errorf("hooks: unknown event %q (want %s)", event, strings.Join(hooks.Names, ", "))
+		}
+	}
+	if c.PublishRepo != "" {
+		if owner, rest, _ := strings.Cut(strings.Trim(c.PublishRepo, "/"), "/"); owner == "" || strings.Split(rest, "/")[0] == "" {
+			return fmt.Errorf("publish_repo: want owner/name or owner/name/dir, got %q", c.PublishRepo)
+		}
+	}
+	switch c.CursorStyle {
+	case "", CursorStyleBlock, CursorStyleHighVisibility:
+	default:
+		return fmt.Errorf("cursor_style: unknown style %q (want %q or %q)", c.CursorStyle, CursorStyleBlock, CursorStyleHighVisibility)
+	}
+	switch c.Color {
+	case "", ColorTrueColor, Color256, Color16, ColorNone:
+	default:
+		return fmt.Errorf("color: unknown setting %q (want %q, %q, %q or %q)", c.Color, ColorTrueColor, Color256, Color16, ColorNone)
+	}
+	switch c.Glyphs {
+	case "", GlyphsUnicode, GlyphsASCII:
+	default:
+		return fmt.Errorf("glyphs: unknown set %q (want %q or %q)", c.Glyphs, GlyphsUnicode, GlyphsASCII)
+	}
+	switch c.UpdateChannel {
+	case "", ChannelStable, ChannelBeta, ChannelNightly:
+	default:
+		return fmt.Errorf("update_channel: unknown channel %q (want %q, %q or %q)", c.UpdateChannel, ChannelStable, ChannelBeta, ChannelNightly)
+	}
+	return nil
+}
+
+// CursorBlinkInterval returns the configured blink interval; 0 means a solid cursor.
+func (c Config) CursorBlinkInterval() (time.Duration, error) {
+	switch c.CursorBlink {
+	case "":
+		return DefaultCursorBlink, nil
+	case "off", "none", "0":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.CursorBlink)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("cursor_blink: invalid duration %q (e.g. \"600ms\" or \"off\")", c.CursorBlink)
+	}
+	return d, nil
+}
+
+// StartupTimeoutDuration returns how long to wait for the API at startup; 0
+// means don't wait.
+func (c Config) StartupTimeoutDuration() (time.Duration, error) {
+	switch c.StartupTimeout {
+	case "":
+		return DefaultStartupTimeout, nil
+	case "off", "none", "0":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.StartupTimeout)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("startup_timeout: invalid duration %q (e.g. \"2s\" or \"off\")", c.StartupTimeout)
+	}
+	return d, nil
+}
+
+// PollInterval returns how often view polls, stretched in low-bandwidth
+// mode.
+func (c Config) PollInterval(view string) (time.Duration, error) {
+	d, ok := DefaultPollIntervals[view]
+	if !ok {
+		return 0, fmt.Errorf("poll_intervals: unknown view %q (want %q, %q or %q)", view, PollHall, PollThreads, PollStream)
+	}
+	if s, set := c.PollIntervals[view]; set {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil || d < MinPollInterval {
+			return 0, fmt.Errorf("poll_intervals: %s: invalid duration %q (e.g. \"10s\", at least %s)", view, s, MinPollInterval)
+		}
+	}
+	if c.LowBandwidth {
+		d *= LowBandwidthStretch
+	}
+	return d, nil
+}
+
+// CloneDirPath returns CloneDir with "~/" expanded; "" means copy the clone
+// command instead.
+func (c Config) CloneDirPath() (string, error) {
+	dir := c.CloneDir
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("clone_dir: %w", err)
+		}
+		dir = filepath.Join(home, dir[1:])
+	}
+	if dir != "" && !filepath.IsAbs(dir) {
+		return "", fmt.Errorf("clone_dir: want an absolute path or one under ~/, got %q", c.CloneDir)
+	}
+	return dir, nil
+}
+
+// LockAfterDuration returns how long the TUI may sit idle before locking; 0
+// means never.
+func (c Config) LockAfterDuration() (time.Duration, error) {
+	switch c.LockAfter {
+	case "", "off", "none", "0":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.LockAfter)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("lock_after: invalid duration %q (e.g. \"10m\" or \"off\")", c.LockAfter)
+	}
+	return d, nil
+}
+
+// AwayAfterDuration returns how long the TUI may sit idle before reporting
+// the magician as away; 0 means never.
+func (c Config) AwayAfterDuration() (time.Duration, error) {
+	switch c.AwayAfter {
+	case "":
+		return DefaultAwayAfter, nil
+	case "off", "none", "0":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.AwayAfter)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("away_after: invalid duration %q (e.g. \"10m\" or \"off\")", c.AwayAfter)
+	}
+	return d, nil
+}
+
+// QuietHours is a daily span of local time, as offsets from midnight. The
+// zero value is no span at all.
+type QuietHours struct {
+	Start, End time.Duration
+}
+
+// Contains reports whether t's local time of day falls in q. End is
+// exclusive, and a span whose End comes before its Start runs past midnight.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.Start == q.End {
+		return false
+	}
+	h, m, s := t.Clock()
+	now := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	if q.Start < q.End {
+		return now >= q.Start && now < q.End
+	}
+	return now >= q.Start || now < q.End
+}
+
+// QuietHoursSpan returns the configured quiet hours; the zero QuietHours
+// means none.
+func (c Config) QuietHoursSpan() (QuietHours, error) {
+	if c.QuietHours == "" {
+		return QuietHours{}, nil
+	}
+	bad := fmt.Errorf("quiet_hours: want a span of local times like \"22:00-07:30\", got %q", c.QuietHours)
+	from, to, ok := strings.Cut(c.QuietHours, "-")
+	if !ok {
+		return QuietHours{}, bad
+	}
+	var q QuietHours
+	for _, part := range []struct {
+		text string
+		into *time.Duration
+	}{{from, &q.Start}, {to, &q.End}} {
+		t, err := time.Parse("15:04", strings.TrimSpace(part.text))
+		if err != nil {
+			return QuietHours{}, bad
+		}
+		*part.into = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	return q, nil
+}
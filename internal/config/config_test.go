@@ -0,0 +1,301 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFileMissingIsDefault(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "nope.json"))
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if d, _ := cfg.CursorBlinkInterval(); d != DefaultCursorBlink {
+		t.Errorf("blink = %v, want default %v", d, DefaultCursorBlink)
+	}
+}
+
+func TestLoadFileCursorSettings(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"cursor_blink":"1s","cursor_style":"high-visibility"}`))
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if d, _ := cfg.CursorBlinkInterval(); d != time.Second {
+		t.Errorf("blink = %v, want 1s", d)
+	}
+	if cfg.CursorStyle != CursorStyleHighVisibility {
+		t.Errorf("style = %q, want high-visibility", cfg.CursorStyle)
+	}
+}
+
+func TestCursorBlinkOff(t *testing.T) {
+	d, err := Config{CursorBlink: "off"}.CursorBlinkInterval()
+	if err != nil || d != 0 {
+		t.Errorf("off: got %v, %v; want 0, nil", d, err)
+	}
+}
+
+func TestLoadFileRejectsInvalid(t *testing.T) {
+	tests := []string{
+		`{"cursor_blink":"fast"}`,
+		`{"cursor_blink":"-1s"}`,
+		`{"cursor_style":"neon"}`,
+		`{not json`,
+	}
+	for _, body := range tests {
+		if _, err := LoadFile(writeConfig(t, body)); err == nil {
+			t.Errorf("expected error for %s", body)
+		}
+	}
+}
+
+func TestLoadFileAlerts(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"bell":true,"flash":true}`))
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if !cfg.Bell || !cfg.Flash {
+		t.Errorf("expected bell and flash enabled, got %+v", cfg)
+	}
+}
+
+func TestLoadFileColor(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"color":"16","ascii_emblems":true}`))
+	if err != nil {
+		t.Fatalf("LoadFile() error: %v", err)
+	}
+	if cfg.Color != Color16 || !cfg.ASCIIEmblems {
+		t.Errorf("got color %q, ascii_emblems %v; want 16, true", cfg.Color, cfg.ASCIIEmblems)
+	}
+	if _, err := LoadFile(writeConfig(t, `{"color":"sepia"}`)); err == nil {
+		t.Error("expected error for unknown color")
+	}
+}
+
+func TestLoadFileUpdateChannel(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"update_channel":"beta"}`))
+	if err != nil || cfg.UpdateChannel != ChannelBeta {
+		t.Errorf("got %q, %v; want beta", cfg.UpdateChannel, err)
+	}
+	if _, err := LoadFile(writeConfig(t, `{"update_channel":"canary"}`)); err == nil {
+		t.Error("expected error for unknown channel")
+	}
+}
+
+func TestLoadFileGlyphs(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"glyphs":"ascii"}`))
+	if err != nil || cfg.Glyphs != GlyphsASCII {
+		t.Errorf("got %q, %v; want ascii", cfg.Glyphs, err)
+	}
+	if _, err := LoadFile(writeConfig(t, `{"glyphs":"emoji"}`)); err == nil {
+		t.Error("expected error for unknown glyph set")
+	}
+}
+
+func TestLoadFileUpdateCheck(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{}`))
+	if err != nil || cfg.NoUpdateCheck {
+		t.Errorf("got %v, %v; want the update check on by default", cfg.NoUpdateCheck, err)
+	}
+	cfg, err = LoadFile(writeConfig(t, `{"no_update_check":true}`))
+	if err != nil || !cfg.NoUpdateCheck {
+		t.Errorf("got %v, %v; want no_update_check to turn it off", cfg.NoUpdateCheck, err)
+	}
+}
+
+func TestLoadFileAccessible(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"accessible":true}`))
+	if err != nil || !cfg.Accessible {
+		t.Errorf("got %v, %v; want accessible on", cfg.Accessible, err)
+	}
+}
+
+func TestLoadFileCloneDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	cfg, err := LoadFile(writeConfig(t, `{"clone_dir":"~/src"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := cfg.CloneDirPath(); got != filepath.Join(home, "src") {
+		t.Errorf("CloneDirPath() = %q, want %q", got, filepath.Join(home, "src"))
+	}
+	if got, _ := (Config{}).CloneDirPath(); got != "" {
+		t.Errorf("unset CloneDirPath() = %q, want empty", got)
+	}
+	if _, err := LoadFile(writeConfig(t, `{"clone_dir":"src"}`)); err == nil {
+		t.Error("expected error for a relative clone_dir")
+	}
+}
+
+func TestLoadFilePublishRepo(t *testing.T) {
+	for _, ok := range []string{`"me/spells"`, `"me/spells/prompts/grimora"`} {
+		if _, err := LoadFile(writeConfig(t, `{"publish_repo":`+ok+`}`)); err != nil {
+			t.Errorf("publish_repo %s: %v", ok, err)
+		}
+	}
+	for _, bad := range []string{`"spells"`, `"me/"`, `"/spells"`} {
+		if _, err := LoadFile(writeConfig(t, `{"publish_repo":`+bad+`}`)); err == nil {
+			t.Errorf("publish_repo %s: expected an error", bad)
+		}
+	}
+}
+
+func TestLoadFilePollIntervals(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"poll_intervals":{"hall":"10s"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := cfg.PollInterval(PollHall); d != 10*time.Second {
+		t.Errorf("hall = %v, want 10s", d)
+	}
+	if d, _ := cfg.PollInterval(PollThreads); d != 5*time.Second {
+		t.Errorf("threads = %v, want the 5s default", d)
+	}
+	cfg.LowBandwidth = true
+	if d, _ := cfg.PollInterval(PollHall); d != 40*time.Second {
+		t.Errorf("low-bandwidth hall = %v, want 40s", d)
+	}
+	for _, bad := range []string{`{"hall":"100ms"}`, `{"hall":"soon"}`, `{"board":"5s"}`} {
+		if _, err := LoadFile(writeConfig(t, `{"poll_intervals":`+bad+`}`)); err == nil {
+			t.Errorf("poll_intervals %s: expected an error", bad)
+		}
+	}
+}
+
+func TestLoadFileStartupTimeout(t *testing.T) {
+	tests := []struct {
+		json string
+		want time.Duration
+	}{
+		{`{}`, DefaultStartupTimeout},
+		{`{"startup_timeout":"500ms"}`, 500 * time.Millisecond},
+		{`{"startup_timeout":"off"}`, 0},
+	}
+	for _, tt := range tests {
+		cfg, err := LoadFile(writeConfig(t, tt.json))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.json, err)
+		}
+		if got, _ := cfg.StartupTimeoutDuration(); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.json, got, tt.want)
+		}
+	}
+	if _, err := LoadFile(writeConfig(t, `{"startup_timeout":"soon"}`)); err == nil {
+		t.Error("expected error for an invalid duration")
+	}
+}
+
+func TestLoadFileAwayAfter(t *testing.T) {
+	tests := []struct {
+		json string
+		want time.Duration
+	}{
+		{`{}`, DefaultAwayAfter},
+		{`{"away_after":"15m"}`, 15 * time.Minute},
+		{`{"away_after":"off"}`, 0},
+	}
+	for _, tt := range tests {
+		cfg, err := LoadFile(writeConfig(t, tt.json))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.json, err)
+		}
+		if got, _ := cfg.AwayAfterDuration(); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.json, got, tt.want)
+		}
+	}
+	if _, err := LoadFile(writeConfig(t, `{"away_after":"-1m"}`)); err == nil {
+		t.Error("expected error for a negative duration")
+	}
+}
+
+func TestLoadFileHooks(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"hooks":{"on_mention":"notify-send \"$GRIMORA_LOGIN\""}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Hooks["on_mention"] != `notify-send "$GRIMORA_LOGIN"` {
+		t.Errorf("hooks = %v", cfg.Hooks)
+	}
+	if _, err := LoadFile(writeConfig(t, `{"hooks":{"on_mentoin":"true"}}`)); err == nil {
+		t.Error("expected error for an unknown event")
+	}
+}
+
+func TestLoadFileQuietHours(t *testing.T) {
+	cfg, err := LoadFile(writeConfig(t, `{"quiet_hours":"22:00-07:30"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, _ := cfg.QuietHoursSpan()
+	if q.Start != 22*time.Hour || q.End != 7*time.Hour+30*time.Minute {
+		t.Errorf("span = %+v, want 22:00 to 07:30", q)
+	}
+	for _, bad := range []string{"22:00", "10pm-7am", "25:00-07:00"} {
+		if _, err := LoadFile(writeConfig(t, `{"quiet_hours":"`+bad+`"}`)); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestQuietHoursContains(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 1, h, m, 0, 0, time.Local) }
+	night := QuietHours{Start: 22 * time.Hour, End: 7*time.Hour + 30*time.Minute}
+	lunch := QuietHours{Start: 12 * time.Hour, End: 13 * time.Hour}
+	tests := []struct {
+		q    QuietHours
+		t    time.Time
+		want bool
+	}{
+		{night, at(23, 0), true},
+		{night, at(3, 0), true},
+		{night, at(7, 30), false},
+		{night, at(21, 59), false},
+		{lunch, at(12, 15), true},
+		{lunch, at(13, 0), false},
+		{QuietHours{}, at(0, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.q.Contains(tt.t); got != tt.want {
+			t.Errorf("%+v.Contains(%s) = %v, want %v", tt.q, tt.t.Format("15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestLoadFileLock(t *testing.T) {
+	// SHA-256 of "open sesame".
+	const hash = "41ef4bb0b23661e66301aac36066912dac037827b4ae63a7b1165a5aa93ed4eb"
+	cfg, err := LoadFile(writeConfig(t, `{"lock_after":"10m","lock_passphrase":"`+hash+`"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := cfg.LockAfterDuration(); d != 10*time.Minute || cfg.LockPassphrase != hash {
+		t.Errorf("lock = %v, %q; want 10m with the hash", d, cfg.LockPassphrase)
+	}
+	if d, _ := (Config{}).LockAfterDuration(); d != 0 {
+		t.Errorf("default lock_after = %v, want off", d)
+	}
+
+	for _, bad := range []string{
+		`{"lock_after":"10m"}`,
+		`{"lock_after":"10m","lock_passphrase":"open sesame"}`,
+		`{"lock_after":"soon","lock_passphrase":"` + hash + `"}`,
+	} {
+		if _, err := LoadFile(writeConfig(t, bad)); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
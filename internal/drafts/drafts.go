@@ -0,0 +1,110 @@
+// Package drafts persists unsent compose text to ~/.grimora/drafts.json so it
+// survives tab switches, restarts and crashes.
+package drafts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/naveenspark/grimora/internal/appdir"
+)
+
+// Store holds drafts keyed by compose context (room, thread, form field).
+// Set only touches memory; Save writes to disk. A nil *Store is a valid,
+// empty store that discards writes.
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	items map[string]string
+	dirty bool
+}
+
+// Path returns ~/.grimora/drafts.json.
+func Path() (string, error) {
+	return appdir.Path("drafts.json")
+}
+
+// Open loads the drafts file at path. A missing file yields an empty store.
+// A corrupt file also yields an empty store, along with the parse error, so
+// callers can warn and carry on.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, items: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("read drafts: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.items); err != nil {
+		s.items = make(map[string]string)
+		return s, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Get returns the draft for key, or "" if there is none.
+func (s *Store) Get(key string) string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.items[key]
+}
+
+// Set records the draft for key. Blank text removes the draft.
+func (s *Store) Set(key, text string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if strings.TrimSpace(text) == "" {
+		if _, ok := s.items[key]; ok {
+			delete(s.items, key)
+			s.dirty = true
+		}
+		return
+	}
+	if s.items[key] != text {
+		s.items[key] = text
+		s.dirty = true
+	}
+}
+
+// Save writes the drafts to disk if anything changed since the last save.
+// The file is replaced atomically so a crash mid-write never loses drafts.
+func (s *Store) Save() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode drafts: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("create drafts dir: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write drafts: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("write drafts: %w", err)
+	}
+	s.dirty = false
+	return nil
+}
@@ -0,0 +1,91 @@
+package drafts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenMissingIsEmpty(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "nope.json"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if got := s.Get("hall:the-hall"); got != "" {
+		t.Errorf("Get() = %q, want empty", got)
+	}
+}
+
+func TestSaveAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "drafts.json")
+	s, _ := Open(path)
+	s.Set("hall:the-hall", "half a thought")
+	s.Set("thread:abc", "see you")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	s2, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if got := s2.Get("hall:the-hall"); got != "half a thought" {
+		t.Errorf("hall draft = %q", got)
+	}
+	if got := s2.Get("thread:abc"); got != "see you" {
+		t.Errorf("thread draft = %q", got)
+	}
+}
+
+func TestSetBlankRemoves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drafts.json")
+	s, _ := Open(path)
+	s.Set("create:text", "draft")
+	s.Save() //nolint:errcheck
+	s.Set("create:text", "   ")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	s2, _ := Open(path)
+	if got := s2.Get("create:text"); got != "" {
+		t.Errorf("expected draft removed, got %q", got)
+	}
+}
+
+func TestSaveSkipsWhenClean(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drafts.json")
+	s, _ := Open(path)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no file for an untouched store, stat err = %v", err)
+	}
+}
+
+func TestOpenCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drafts.json")
+	os.WriteFile(path, []byte("{not json"), 0o600) //nolint:errcheck
+	s, err := Open(path)
+	if err == nil {
+		t.Fatal("expected parse error")
+	}
+	if s == nil {
+		t.Fatal("expected a usable empty store alongside the error")
+	}
+	s.Set("k", "v")
+	if s.Get("k") != "v" {
+		t.Error("store should still accept drafts")
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	s.Set("k", "v")
+	if s.Get("k") != "" {
+		t.Error("nil store should discard writes")
+	}
+	if err := s.Save(); err != nil {
+		t.Errorf("Save() on nil store = %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+// Package export renders spell collections as documents that can be printed
+// or shared outside the terminal: Markdown, standalone HTML and PDF, and
+// Atom and JSON feeds.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// Output formats.
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatPDF      = "pdf"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{FormatMarkdown, FormatHTML, FormatPDF}
+
+// Spellbook is a titled collection of spells ready to render.
+type Spellbook struct {
+	Title       string
+	Description string
+	Spells      []domain.Spell
+	Generated   time.Time
+}
+
+// Write renders b to w in the given format.
+func Write(w io.Writer, format string, b Spellbook) error {
+	switch format {
+	case FormatMarkdown:
+		return Markdown(w, b)
+	case FormatHTML:
+		return HTML(w, b)
+	case FormatPDF:
+		return PDF(w, b)
+	}
+	return fmt.Errorf("export: unknown format %q (want %s)", format, strings.Join(Formats, ", "))
+}
+
+// titleLen caps spell titles, which are taken from the spell text.
+const titleLen = 72
+
+// SpellTitle returns a one-line title for a spell: its first non-blank line
+// with any Markdown heading markers removed, cut at titleLen runes.
+func SpellTitle(s domain.Spell) string {
+	title := ""
+	for _, line := range strings.Split(s.Text, "\n") {
+		if line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#")); line != "" {
+			title = strings.Join(strings.Fields(line), " ")
+			break
+		}
+	}
+	if title == "" {
+		return "Untitled spell"
+	}
+	if utf8.RuneCountInString(title) > titleLen {
+		title = string([]rune(title)[:titleLen-1]) + "…"
+	}
+	return title
+}
+
+// Attribution names a spell's author and guild, e.g. "@alice · nyx". It is
+// empty when the server sent no author.
+func Attribution(s domain.Spell) string {
+	if s.Author == nil || s.Author.Login == "" {
+		return ""
+	}
+	a := "@" + s.Author.Login
+	if s.Author.GuildID != "" {
+		a += " · " + s.Author.GuildID
+	}
+	return a
+}
+
+// details is the metadata line under a spell title: tag, potency, model and
+// author, in that order, skipping anything unset.
+func details(s domain.Spell) []string {
+	var d []string
+	if s.Tag != "" {
+		d = append(d, s.Tag)
+	}
+	if s.Potency > 0 {
+		d = append(d, fmt.Sprintf("P%d", s.Potency))
+	}
+	if s.Model != "" {
+		d = append(d, s.Model)
+	}
+	if a := Attribution(s); a != "" {
+		d = append(d, a)
+	}
+	return d
+}
+
+// subtitle describes the book: spell count and generation date.
+func subtitle(b Spellbook) string {
+	n := len(b.Spells)
+	s := fmt.Sprintf("%d spell", n)
+	if n != 1 {
+		s += "s"
+	}
+	if !b.Generated.IsZero() {
+		s += " · " + b.Generated.Format("January 2, 2006")
+	}
+	return s
+}
+
+// Markdown renders b as a Markdown document. Spell bodies go in fenced code
+// blocks so prompts keep their exact wording and line breaks.
+func Markdown(w io.Writer, b Spellbook) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", b.Title)
+	if b.Description != "" {
+		fmt.Fprintf(&sb, "%s\n\n", b.Description)
+	}
+	fmt.Fprintf(&sb, "_%s_\n", subtitle(b))
+	for i, s := range b.Spells {
+		fmt.Fprintf(&sb, "\n## %d. %s\n\n", i+1, SpellTitle(s))
+		if d := details(s); len(d) > 0 {
+			fmt.Fprintf(&sb, "%s\n\n", strings.Join(d, " · "))
+		}
+		fence := "```"
+		for strings.Contains(s.Text, fence) {
+			fence += "`"
+		}
+		fmt.Fprintf(&sb, "%s\n%s\n%s\n", fence, strings.TrimRight(s.Text, "\n"), fence)
+		if s.Context != "" {
+			fmt.Fprintf(&sb, "\n**Context:** %s\n", s.Context)
+		}
+		if len(s.Stack) > 0 {
+			fmt.Fprintf(&sb, "\n**Stack:** %s\n", strings.Join(s.Stack, ", "))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
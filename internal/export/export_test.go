@@ -0,0 +1,169 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func testBook() Spellbook {
+	return Spellbook{
+		Title: "Debugging Classics",
+		Spells: []domain.Spell{
+			{
+				Text:    "# Rubber duck\nExplain the bug to me line by line.",
+				Tag:     "debugging",
+				Potency: 3,
+				Context: "stuck on a heisenbug",
+				Stack:   []string{"go", "postgres"},
+				Author:  &domain.Author{Login: "alice", GuildID: "nyx"},
+			},
+			{Text: "Review this <script> for XSS", Tag: "security"},
+		},
+		Generated: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSpellTitle(t *testing.T) {
+	tests := []struct{ text, want string }{
+		{"# Rubber duck\nbody", "Rubber duck"},
+		{"\n\n  plain   first line  \nmore", "plain first line"},
+		{"", "Untitled spell"},
+		{strings.Repeat("a", 100), strings.Repeat("a", titleLen-1) + "…"},
+	}
+	for _, tt := range tests {
+		if got := SpellTitle(domain.Spell{Text: tt.text}); got != tt.want {
+			t.Errorf("SpellTitle(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Markdown(&buf, testBook()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# Debugging Classics",
+		"_2 spells · March 14, 2026_",
+		"## 1. Rubber duck",
+		"debugging · P3 · @alice · nyx",
+		"```\n# Rubber duck\nExplain the bug to me line by line.\n```",
+		"**Context:** stuck on a heisenbug",
+		"**Stack:** go, postgres",
+		"## 2. Review this <script> for XSS",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkdownLongerFence(t *testing.T) {
+	b := Spellbook{Title: "t", Spells: []domain.Spell{{Text: "use ```go blocks```"}}}
+	var buf bytes.Buffer
+	if err := Markdown(&buf, b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "````\nuse ```go blocks```\n````") {
+		t.Errorf("expected a four-backtick fence:\n%s", buf.String())
+	}
+}
+
+func TestHTMLEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := HTML(&buf, testBook()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<script>") {
+		t.Error("spell text was not escaped")
+	}
+	for _, want := range []string{"<title>Debugging Classics</title>", "1. Rubber duck", "@alice · nyx", "&lt;script&gt;", "go, postgres"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("html missing %q", want)
+		}
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(io.Discard, "docx", testBook()); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	got := wrapText("the quick brown fox jumps\n\nover", 10)
+	want := []string{"the quick", "brown fox", "jumps", "", "over"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapText = %q, want %q", got, want)
+	}
+	// Words longer than a line are split.
+	if got := wrapText(strings.Repeat("x", 25), 10); len(got) != 3 || got[0] != strings.Repeat("x", 10) {
+		t.Errorf("long word = %q", got)
+	}
+}
+
+func TestPDFString(t *testing.T) {
+	tests := map[string]string{
+		"plain":    "(plain)",
+		`a (b) \c`: `(a \(b\) \\c)`,
+		"café — 🔥": `(caf\351 \227 ?)`,
+	}
+	for in, want := range tests {
+		if got := pdfString(in); got != want {
+			t.Errorf("pdfString(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestPDF(t *testing.T) {
+	b := testBook()
+	// Enough spells to spill onto a second page.
+	for range 20 {
+		b.Spells = append(b.Spells, domain.Spell{Text: "line one\nline two\nline three", Tag: "general"})
+	}
+	var buf bytes.Buffer
+	if err := PDF(&buf, b); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "%PDF-1.4") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatal("missing PDF header or trailer")
+	}
+	pages := len(layoutPDF(b))
+	if pages < 2 {
+		t.Fatalf("expected more than one page, got %d", pages)
+	}
+	if !strings.Contains(out, "/Count "+strconv.Itoa(pages)) {
+		t.Errorf("page tree does not count %d pages", pages)
+	}
+
+	// startxref must point at the xref table.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	if off, _ := strconv.Atoi(m[1]); !strings.HasPrefix(out[off:], "xref\n") {
+		t.Error("startxref does not point at the xref table")
+	}
+
+	// The first content stream holds the title.
+	start := strings.Index(out, "stream\n") + len("stream\n")
+	zr, err := zlib.NewReader(strings.NewReader(out[start:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(zr)
+	if !strings.Contains(string(content), "(Debugging Classics) Tj") {
+		t.Errorf("first page content missing title:\n%s", content)
+	}
+}
@@ -0,0 +1,207 @@
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// Feed formats.
+const (
+	FeedAtom = "atom"
+	FeedJSON = "json"
+)
+
+// FeedFormats lists the supported feed formats.
+var FeedFormats = []string{FeedAtom, FeedJSON}
+
+// Feed is a list of spells to publish for feed readers, newest first.
+type Feed struct {
+	Title   string
+	ID      string // stays the same for as long as the feed selects the same spells
+	Link    string // the page the feed follows
+	Spells  []domain.Spell
+	Updated time.Time // zero means the newest spell's creation time
+}
+
+// WriteFeed renders f to w in the given format.
+func WriteFeed(w io.Writer, format string, f Feed) error {
+	switch format {
+	case FeedAtom:
+		return Atom(w, f)
+	case FeedJSON:
+		return JSONFeed(w, f)
+	}
+	return fmt.Errorf("export: unknown feed format %q (want %s)", format, strings.Join(FeedFormats, ", "))
+}
+
+// updated returns when f last changed. An empty feed with no time set is
+// dated the zero time rather than now, so rewriting it changes nothing.
+func (f Feed) updated() time.Time {
+	if !f.Updated.IsZero() {
+		return f.Updated
+	}
+	var t time.Time
+	for _, s := range f.Spells {
+		if s.CreatedAt.After(t) {
+			t = s.CreatedAt
+		}
+	}
+	return t
+}
+
+// summary is a spell's metadata line, as under its title in a spellbook.
+func summary(s domain.Spell) string {
+	return strings.Join(details(s), " · ")
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Link    atomLink    `xml:"link"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	Title     string        `xml:"title"`
+	ID        string        `xml:"id"`
+	Link      atomLink      `xml:"link"`
+	Published string        `xml:"published"`
+	Updated   string        `xml:"updated"`
+	Author    *atomAuthor   `xml:"author,omitempty"`
+	Category  *atomCategory `xml:"category,omitempty"`
+	Summary   string        `xml:"summary,omitempty"`
+	Content   atomContent   `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// Atom renders f as an Atom 1.0 feed. Spell text goes in as plain text so
+// readers show prompts exactly as written.
+func Atom(w io.Writer, f Feed) error {
+	feed := atomFeed{
+		Title:   f.Title,
+		ID:      f.ID,
+		Link:    atomLink{Href: f.Link},
+		Updated: f.updated().UTC().Format(time.RFC3339),
+	}
+	for _, s := range f.Spells {
+		link := domain.SpellPermalink(s.ID.String())
+		created := s.CreatedAt.UTC().Format(time.RFC3339)
+		e := atomEntry{
+			Title:     SpellTitle(s),
+			ID:        link,
+			Link:      atomLink{Href: link},
+			Published: created,
+			Updated:   created,
+			Summary:   summary(s),
+			Content:   atomContent{Type: "text", Text: s.Text},
+		}
+		if s.Author != nil && s.Author.Login != "" {
+			e.Author = &atomAuthor{Name: "@" + s.Author.Login, URI: domain.MagicianPermalink(s.Author.Login)}
+		}
+		if s.Tag != "" {
+			e.Category = &atomCategory{Term: s.Tag}
+		}
+		feed.Entries = append(feed.Entries, e)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary,omitempty"`
+	DatePublished string           `json:"date_published"`
+	Tags          []string         `json:"tags,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Grimora       jsonFeedSpell    `json:"_grimora"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// jsonFeedSpell is JSON Feed's extension object for what a dashboard may
+// want to sort or filter on.
+type jsonFeedSpell struct {
+	Potency int    `json:"potency"`
+	Upvotes int    `json:"upvotes"`
+	Casts   int    `json:"casts"`
+	Model   string `json:"model,omitempty"`
+}
+
+// JSONFeed renders f as a JSON Feed 1.1 document. Each item carries the
+// spell's potency, upvotes and casts under "_grimora".
+func JSONFeed(w io.Writer, f Feed) error {
+	feed := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		Items:       []jsonFeedItem{},
+	}
+	for _, s := range f.Spells {
+		link := domain.SpellPermalink(s.ID.String())
+		item := jsonFeedItem{
+			ID:            link,
+			URL:           link,
+			Title:         SpellTitle(s),
+			ContentText:   s.Text,
+			Summary:       summary(s),
+			DatePublished: s.CreatedAt.UTC().Format(time.RFC3339),
+			Grimora:       jsonFeedSpell{Potency: s.Potency, Upvotes: s.Upvotes, Casts: s.Casts, Model: s.Model},
+		}
+		if s.Tag != "" {
+			item.Tags = []string{s.Tag}
+		}
+		if s.Author != nil && s.Author.Login != "" {
+			item.Authors = []jsonFeedAuthor{{Name: "@" + s.Author.Login, URL: domain.MagicianPermalink(s.Author.Login)}}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(feed)
+}
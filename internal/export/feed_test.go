@@ -0,0 +1,96 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func testFeed() Feed {
+	book := testBook()
+	for i := range book.Spells {
+		book.Spells[i].ID = uuid.MustParse("8f14e45f-ceea-467f-a8f5-6a0e2c1b7d3e")
+		book.Spells[i].CreatedAt = time.Date(2026, 3, 14-i, 9, 0, 0, 0, time.UTC)
+	}
+	return Feed{Title: "Debugging Classics", ID: "tag:grimora.ai,2026:spells?tag=debugging", Link: domain.WebURL, Spells: book.Spells}
+}
+
+func TestAtom(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Atom(&buf, testFeed()); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Updated string `xml:"updated"`
+		Entries []struct {
+			Title    string `xml:"title"`
+			ID       string `xml:"id"`
+			Author   string `xml:"author>name"`
+			Category struct {
+				Term string `xml:"term,attr"`
+			} `xml:"category"`
+			Content string `xml:"content"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Atom output doesn't parse: %v\n%s", err, buf.String())
+	}
+	if got.Updated != "2026-03-14T09:00:00Z" || len(got.Entries) != 2 {
+		t.Fatalf("feed = %+v, want two entries updated at the newest spell", got)
+	}
+	e := got.Entries[0]
+	if e.Title != "Rubber duck" || e.Author != "@alice" || e.Category.Term != "debugging" ||
+		e.ID != domain.SpellPermalink("8f14e45f-ceea-467f-a8f5-6a0e2c1b7d3e") || !strings.HasPrefix(e.Content, "# Rubber duck\n") {
+		t.Errorf("entry = %+v", e)
+	}
+	if !strings.Contains(buf.String(), "Review this &lt;script&gt; for XSS") {
+		t.Errorf("spell text not escaped:\n%s", buf.String())
+	}
+}
+
+func TestJSONFeed(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteFeed(&buf, FeedJSON, testFeed()); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Version string `json:"version"`
+		Items   []struct {
+			Title         string   `json:"title"`
+			DatePublished string   `json:"date_published"`
+			Tags          []string `json:"tags"`
+			Grimora       struct {
+				Potency int `json:"potency"`
+			} `json:"_grimora"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != "https://jsonfeed.org/version/1.1" || len(got.Items) != 2 {
+		t.Fatalf("feed = %+v", got)
+	}
+	if it := got.Items[0]; it.Title != "Rubber duck" || it.Grimora.Potency != 3 || it.DatePublished != "2026-03-14T09:00:00Z" || len(it.Tags) != 1 {
+		t.Errorf("item = %+v", it)
+	}
+}
+
+func TestJSONFeedEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := JSONFeed(&buf, Feed{Title: "nothing yet"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"items": []`) {
+		t.Errorf("empty feed = %s, want an empty items list", buf.String())
+	}
+	if err := WriteFeed(&buf, "rss", Feed{}); err == nil {
+		t.Error("WriteFeed(rss) succeeded, want an unknown format error")
+	}
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// htmlTemplate is a standalone page with print styles, so the browser's
+// "Print to PDF" gives a clean result too.
+var htmlTemplate = template.Must(template.New("spellbook").Funcs(template.FuncMap{
+	"title":   SpellTitle,
+	"details": func(s domain.Spell) string { return strings.Join(details(s), " · ") },
+	"join":    func(s []string) string { return strings.Join(s, ", ") },
+	"inc":     func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  body { font-family: Georgia, serif; max-width: 46rem; margin: 2rem auto; padding: 0 1rem; color: #1e1e2a; }
+  h1 { margin-bottom: .25rem; }
+  .sub { color: #606878; font-style: italic; }
+  .spell { border-top: 1px solid #c0c4d0; padding-top: 1rem; margin-top: 1.5rem; break-inside: avoid; }
+  .meta { color: #606878; font-size: .9rem; }
+  pre { white-space: pre-wrap; background: #f4f4f8; padding: .75rem; font-size: .85rem; }
+  @media print { body { margin: 0; } pre { background: none; border-left: 2px solid #c0c4d0; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+<p class="sub">{{.Subtitle}}</p>
+{{- range $i, $s := .Spells}}
+<section class="spell">
+<h2>{{inc $i}}. {{title $s}}</h2>
+{{- with details $s}}
+<p class="meta">{{.}}</p>
+{{- end}}
+<pre>{{$s.Text}}</pre>
+{{- if $s.Context}}
+<p><strong>Context:</strong> {{$s.Context}}</p>
+{{- end}}
+{{- if $s.Stack}}
+<p><strong>Stack:</strong> {{join $s.Stack}}</p>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML renders b as a standalone HTML page. All spell content is escaped.
+func HTML(w io.Writer, b Spellbook) error {
+	return htmlTemplate.Execute(w, struct {
+		Spellbook
+		Subtitle string
+	}{b, subtitle(b)})
+}
@@ -0,0 +1,240 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// A4 page geometry in points.
+const (
+	pdfPageW  = 595
+	pdfPageH  = 842
+	pdfMargin = 56
+	pdfTextW  = pdfPageW - 2*pdfMargin
+)
+
+// pdfFont is one of the standard Type 1 fonts every PDF reader ships, so
+// nothing needs embedding. width is the average glyph width as a fraction of
+// the font size, used to estimate how many characters fit on a line; Courier
+// is exact.
+type pdfFont struct {
+	name  string // resource name
+	base  string // PostScript name
+	width float64
+}
+
+var (
+	fontRegular = pdfFont{"F1", "Helvetica", 0.52}
+	fontBold    = pdfFont{"F2", "Helvetica-Bold", 0.58}
+	fontItalic  = pdfFont{"F3", "Helvetica-Oblique", 0.52}
+	fontMono    = pdfFont{"F4", "Courier", 0.6}
+	pdfFonts    = []pdfFont{fontRegular, fontBold, fontItalic, fontMono}
+)
+
+// pdfLine is a single laid-out line of text.
+type pdfLine struct {
+	font pdfFont
+	size float64
+	gray float64 // 0 is black
+	x, y float64
+	text string
+}
+
+// pdfLayout flows text onto pages top to bottom.
+type pdfLayout struct {
+	pages [][]pdfLine
+	y     float64
+}
+
+func (l *pdfLayout) newPage() {
+	l.pages = append(l.pages, nil)
+	l.y = pdfPageH - pdfMargin
+}
+
+// space moves down by pts, or starts a new page when less than need points
+// would remain below.
+func (l *pdfLayout) space(pts, need float64) {
+	l.y -= pts
+	if l.y-need < pdfMargin {
+		l.newPage()
+	}
+}
+
+// text wraps s to the text width and adds it line by line, indented by
+// indent points.
+func (l *pdfLayout) text(s string, font pdfFont, size, gray, indent float64) {
+	lead := size * 1.35
+	cols := int((pdfTextW - indent) / (size * font.width))
+	for _, line := range wrapText(s, cols) {
+		if l.y-lead < pdfMargin {
+			l.newPage()
+		}
+		l.y -= lead
+		p := len(l.pages) - 1
+		l.pages[p] = append(l.pages[p], pdfLine{font: font, size: size, gray: gray, x: pdfMargin + indent, y: l.y, text: line})
+	}
+}
+
+// wrapText splits s into lines of at most cols runes, breaking at spaces
+// where possible. Existing line breaks are kept and tabs become four spaces.
+func wrapText(s string, cols int) []string {
+	cols = max(cols, 10)
+	var out []string
+	for _, para := range strings.Split(strings.ReplaceAll(s, "\t", "    "), "\n") {
+		para = strings.TrimRight(para, " \r")
+		if para == "" {
+			out = append(out, "")
+			continue
+		}
+		for utf8.RuneCountInString(para) > cols {
+			r := []rune(para)
+			cut := cols
+			if i := strings.LastIndex(string(r[:cols+1]), " "); i > 0 {
+				cut = utf8.RuneCountInString(string(r[:cols+1])[:i])
+			}
+			out = append(out, strings.TrimRight(string(r[:cut]), " "))
+			para = strings.TrimLeft(string(r[cut:]), " ")
+		}
+		if para != "" {
+			out = append(out, para)
+		}
+	}
+	return out
+}
+
+// winAnsi maps the non-Latin-1 characters WinAnsiEncoding covers that show up
+// in spell text.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfString encodes s as a PDF literal string in WinAnsiEncoding. Characters
+// the standard fonts can't draw, emoji included, become "?".
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		var c byte
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			c = byte(r)
+		case r >= 0x20 && r < 0x7f:
+			c = byte(r)
+		case r >= 0xa0 && r <= 0xff:
+			c = byte(r)
+		default:
+			var ok bool
+			if c, ok = winAnsi[r]; !ok {
+				c = '?'
+			}
+		}
+		if c >= 0x80 {
+			fmt.Fprintf(&b, "\\%03o", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// layoutPDF lays out b on A4 pages.
+func layoutPDF(b Spellbook) [][]pdfLine {
+	l := &pdfLayout{}
+	l.newPage()
+	l.text(b.Title, fontBold, 22, 0, 0)
+	if b.Description != "" {
+		l.space(4, 0)
+		l.text(b.Description, fontRegular, 11, 0, 0)
+	}
+	l.space(2, 0)
+	l.text(subtitle(b), fontItalic, 10, 0.4, 0)
+
+	for i, s := range b.Spells {
+		// Keep a heading with at least a few lines of its spell.
+		l.space(20, 80)
+		l.text(fmt.Sprintf("%d. %s", i+1, SpellTitle(s)), fontBold, 13, 0, 0)
+		if d := details(s); len(d) > 0 {
+			l.text(strings.Join(d, " · "), fontRegular, 9, 0.4, 0)
+		}
+		l.space(6, 0)
+		l.text(strings.TrimRight(s.Text, "\n"), fontMono, 9, 0, 8)
+		if s.Context != "" {
+			l.space(6, 0)
+			l.text("Context: "+s.Context, fontRegular, 10, 0.25, 0)
+		}
+		if len(s.Stack) > 0 {
+			l.text("Stack: "+strings.Join(s.Stack, ", "), fontRegular, 10, 0.25, 0)
+		}
+	}
+	return l.pages
+}
+
+// PDF renders b as a PDF document using the standard fonts, one spell after
+// another with page numbers in the footer.
+func PDF(w io.Writer, b Spellbook) error {
+	pages := layoutPDF(b)
+
+	var buf bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1-2 are the catalog and page tree, then the info dictionary and
+	// fonts; each page is a page object followed by its content stream.
+	firstPage := 4 + len(pdfFonts)
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	obj(fmt.Sprintf("<< /Title %s /Producer (grimora) >>", pdfString(b.Title)))
+	var fontRes []string
+	for _, f := range pdfFonts {
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.base))
+		fontRes = append(fontRes, fmt.Sprintf("/%s %d 0 R", f.name, len(offsets)))
+	}
+
+	for i, lines := range pages {
+		var content strings.Builder
+		for _, ln := range lines {
+			fmt.Fprintf(&content, "%.2f g BT /%s %.1f Tf %.1f %.1f Td %s Tj ET\n", ln.gray, ln.font.name, ln.size, ln.x, ln.y, pdfString(ln.text))
+		}
+		footer := fmt.Sprintf("%d", i+1)
+		fmt.Fprintf(&content, "0.40 g BT /%s 9.0 Tf %.1f %.1f Td %s Tj ET\n", fontRegular.name, float64(pdfPageW)/2-4, float64(pdfMargin)/2, pdfString(footer))
+
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		if _, err := zw.Write([]byte(content.String())); err != nil {
+			return fmt.Errorf("export.PDF: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("export.PDF: %w", err)
+		}
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pdfPageW, pdfPageH, strings.Join(fontRes, " "), len(offsets)+2))
+		obj(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", z.Len(), z.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("export.PDF: %w", err)
+	}
+	return nil
+}
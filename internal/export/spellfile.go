@@ -0,0 +1,197 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// DefaultSpellDir is where spells are written when no directory is given,
+// relative to the working directory.
+const DefaultSpellDir = "prompts"
+
+// slugLen caps file name slugs, in bytes (slugs are ASCII).
+const slugLen = 48
+
+// Slug returns a file-name-safe name for a spell, from its title: lowercase
+// letters and digits joined by dashes. Spells with no usable title fall back
+// to the start of their ID.
+func Slug(s domain.Spell) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(SpellTitle(s)) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	slug := b.String()
+	if len(slug) > slugLen {
+		// Cut at a word boundary where there is one.
+		slug = slug[:slugLen+1]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		} else {
+			slug = slug[:slugLen]
+		}
+	}
+	if slug == "" || slug == "untitled-spell" {
+		slug = "spell-" + s.ID.String()[:8]
+	}
+	return slug
+}
+
+// UniqueSlug is Slug with the start of the spell's ID added, the name a
+// spell takes when a different spell already holds its Slug.
+func UniqueSlug(s domain.Spell) string {
+	return Slug(s) + "-" + s.ID.String()[:8]
+}
+
+// HoldsSpell reports whether data, the contents of a spell file, is s's
+// file. A file that doesn't parse or names no ID belongs to no spell.
+func HoldsSpell(data string, s domain.Spell) bool {
+	held, err := ReadSpellFile(data)
+	return err == nil && held.ID != uuid.Nil && held.ID == s.ID
+}
+
+// SpellFile renders s as Markdown with YAML frontmatter, the form spells are
+// kept in next to code. The body is the spell text exactly as written.
+// Nothing time-dependent is included, so pulling an unchanged spell again
+// leaves the file untouched.
+func SpellFile(w io.Writer, s domain.Spell) error {
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	fmt.Fprintf(&sb, "id: %s\n", s.ID)
+	fmt.Fprintf(&sb, "title: %s\n", strconv.Quote(SpellTitle(s)))
+	if s.Tag != "" {
+		fmt.Fprintf(&sb, "tag: %s\n", s.Tag)
+	}
+	if s.Model != "" {
+		fmt.Fprintf(&sb, "model: %s\n", strconv.Quote(s.Model))
+	}
+	if len(s.Stack) > 0 {
+		quoted := make([]string, len(s.Stack))
+		for i, st := range s.Stack {
+			quoted[i] = strconv.Quote(st)
+		}
+		fmt.Fprintf(&sb, "stack: [%s]\n", strings.Join(quoted, ", "))
+	}
+	if s.Author != nil && s.Author.Login != "" {
+		fmt.Fprintf(&sb, "author: %s\n", s.Author.Login)
+		if s.Author.GuildID != "" {
+			fmt.Fprintf(&sb, "guild: %s\n", s.Author.GuildID)
+		}
+	}
+	if s.Context != "" {
+		fmt.Fprintf(&sb, "context: %s\n", strconv.Quote(s.Context))
+	}
+	sb.WriteString("---\n\n")
+	sb.WriteString(strings.TrimRight(s.Text, "\n") + "\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteSpellFile writes s to dir/<slug>.md, creating dir if needed and
+// replacing any earlier copy. When that name already holds a different
+// spell, or a file written by hand, s goes to dir/<slug>-<id>.md instead.
+// It returns the path written.
+func WriteSpellFile(dir string, s domain.Spell) (string, error) {
+	if dir == "" {
+		dir = DefaultSpellDir
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create %s: %w", dir, err)
+	}
+	var sb strings.Builder
+	if err := SpellFile(&sb, s); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, Slug(s)+".md")
+	if data, err := os.ReadFile(path); err == nil && !HoldsSpell(string(data), s) {
+		path = filepath.Join(dir, UniqueSlug(s)+".md")
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(sb.String()), 0644); err != nil {
+		return "", fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// ReadSpellFile parses a spell file as SpellFile writes it, or as someone
+// writes one by hand: optional frontmatter followed by the spell text. It
+// reads the id, tag, model, stack and context; the title, author and guild
+// are only ever derived, so they're ignored. Text without frontmatter is
+// all spell.
+func ReadSpellFile(data string) (domain.Spell, error) {
+	var s domain.Spell
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(data, "---\n")
+	if !ok {
+		s.Text = strings.TrimSpace(data)
+		return s, nil
+	}
+	front, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		return s, errors.New("frontmatter has no closing ---")
+	}
+	for i, line := range strings.Split(front, "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return s, fmt.Errorf("frontmatter line %d: want key: value", i+2)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "id":
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return s, fmt.Errorf("frontmatter id: %w", err)
+			}
+			s.ID = id
+		case "tag":
+			s.Tag = value
+		case "model":
+			s.Model = unquote(value)
+		case "context":
+			s.Context = unquote(value)
+		case "stack":
+			list := strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			for item := range strings.SplitSeq(list, ",") {
+				if item = unquote(strings.TrimSpace(item)); item != "" {
+					s.Stack = append(s.Stack, item)
+				}
+			}
+		}
+	}
+	s.Text = strings.TrimSpace(body)
+	return s, nil
+}
+
+// unquote strips the quotes SpellFile puts around free text, leaving
+// unquoted values as they are.
+func unquote(v string) string {
+	if u, err := strconv.Unquote(v); err == nil {
+		return u
+	}
+	return v
+}
@@ -0,0 +1,150 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestSlug(t *testing.T) {
+	id := uuid.MustParse("1a2b3c4d-0000-0000-0000-000000000000")
+	tests := []struct {
+		text, want string
+	}{
+		{"# Rubber duck\nExplain it", "rubber-duck"},
+		{"Fix: flaky tests (again!) in CI", "fix-flaky-tests-again-in-ci"},
+		{"Übersetze das — bitte", "bersetze-das-bitte"},
+		{"🔥🔥🔥", "spell-1a2b3c4d"},
+		{"", "spell-1a2b3c4d"},
+		{strings.Repeat("word ", 30), "word-word-word-word-word-word-word-word-word"},
+	}
+	for _, tt := range tests {
+		if got := Slug(domain.Spell{ID: id, Text: tt.text}); got != tt.want {
+			t.Errorf("Slug(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSpellFile(t *testing.T) {
+	s := testBook().Spells[0]
+	s.ID = uuid.MustParse("1a2b3c4d-0000-0000-0000-000000000000")
+	s.Model = "claude-opus-4"
+	var sb strings.Builder
+	if err := SpellFile(&sb, s); err != nil {
+		t.Fatal(err)
+	}
+	want := `---
+id: 1a2b3c4d-0000-0000-0000-000000000000
+title: "Rubber duck"
+tag: debugging
+model: "claude-opus-4"
+stack: ["go", "postgres"]
+author: alice
+guild: nyx
+context: "stuck on a heisenbug"
+---
+
+# Rubber duck
+Explain the bug to me line by line.
+`
+	if got := sb.String(); got != want {
+		t.Errorf("SpellFile() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteSpellFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "prompts")
+	s := domain.Spell{ID: uuid.New(), Text: "Rubber duck\nv1"}
+	path, err := WriteSpellFile(dir, s)
+	if err != nil {
+		t.Fatalf("WriteSpellFile() error: %v", err)
+	}
+	if path != filepath.Join(dir, "rubber-duck.md") {
+		t.Errorf("path = %q", path)
+	}
+
+	// Pulling again replaces the earlier copy.
+	s.Text = "Rubber duck\nv2"
+	if _, err := WriteSpellFile(dir, s); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), "Rubber duck\nv2\n") {
+		t.Errorf("file = %q", data)
+	}
+}
+
+func TestWriteSpellFileSameTitle(t *testing.T) {
+	dir := t.TempDir()
+	a := domain.Spell{ID: uuid.MustParse("1a2b3c4d-0000-0000-0000-000000000000"), Text: "Rubber duck\nfirst"}
+	b := domain.Spell{ID: uuid.MustParse("5e6f7a8b-0000-0000-0000-000000000000"), Text: "Rubber duck\nsecond"}
+	pathA, err := WriteSpellFile(dir, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathB, err := WriteSpellFile(dir, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pathB != filepath.Join(dir, "rubber-duck-5e6f7a8b.md") {
+		t.Errorf("second spell path = %q, want the ID added", pathB)
+	}
+	// Each spell finds its own file again.
+	if again, _ := WriteSpellFile(dir, a); again != pathA {
+		t.Errorf("first spell rewrote %q, want %q", again, pathA)
+	}
+	data, _ := os.ReadFile(pathA)
+	if !strings.HasSuffix(string(data), "first\n") {
+		t.Errorf("first spell's file = %q", data)
+	}
+
+	// A file written by hand isn't overwritten either.
+	hand := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(hand, []byte("my notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := domain.Spell{ID: uuid.MustParse("9c9c9c9c-0000-0000-0000-000000000000"), Text: "Notes"}
+	if p, _ := WriteSpellFile(dir, c); p != filepath.Join(dir, "notes-9c9c9c9c.md") {
+		t.Errorf("path = %q, want the hand-written notes.md left alone", p)
+	}
+}
+
+func TestReadSpellFile(t *testing.T) {
+	s := testBook().Spells[0]
+	s.ID = uuid.MustParse("1a2b3c4d-0000-0000-0000-000000000000")
+	s.Model = "claude-opus-4"
+	s.Context = `stuck on a "heisenbug"`
+	var sb strings.Builder
+	if err := SpellFile(&sb, s); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadSpellFile(sb.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != s.ID || got.Tag != s.Tag || got.Model != s.Model || got.Context != s.Context || got.Text != s.Text {
+		t.Errorf("ReadSpellFile() = %+v, want it to round-trip %+v", got, s)
+	}
+	if strings.Join(got.Stack, ",") != "go,postgres" {
+		t.Errorf("stack = %q", got.Stack)
+	}
+
+	plain, err := ReadSpellFile("  Review this diff.\n")
+	if err != nil || plain.Text != "Review this diff." || plain.ID != uuid.Nil {
+		t.Errorf("plain file = %+v, %v", plain, err)
+	}
+	if _, err := ReadSpellFile("---\ntag: go\nno end"); err == nil {
+		t.Error("expected an error for unclosed frontmatter")
+	}
+	if _, err := ReadSpellFile("---\nid: nope\n---\ntext"); err == nil {
+		t.Error("expected an error for a bad id")
+	}
+}
@@ -0,0 +1,120 @@
+// Package hooks runs the magician's own shell commands when things happen
+// while the TUI is open: a desktop notification on a mention, a sound on a
+// DM, a post somewhere when someone ships.
+//
+// A hook gets the event as JSON on stdin and as GRIMORA_* environment
+// variables. Its output is discarded, since the TUI owns the terminal.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// Events a hook can be attached to, as keys of Config.Hooks.
+const (
+	OnMention = "on_mention" // someone @mentioned you in a room
+	OnDM      = "on_dm"      // a direct message arrived
+	OnShip    = "on_ship"    // someone shipped something in a room
+)
+
+// Names lists every event, in the order the docs give them.
+var Names = []string{OnMention, OnDM, OnShip}
+
+// Timeout is how long a hook may run before it is killed, so a hung command
+// doesn't pile up behind every new event.
+const Timeout = 10 * time.Second
+
+// waitDelay is how long Run waits, once a hook is killed, for it to let go
+// of its stdin.
+const waitDelay = time.Second
+
+// MaxRunning is how many hooks may run at once. A burst of events waits
+// for a free slot, within its Timeout, instead of starting a process each.
+const MaxRunning = 4
+
+// Event is what a hook is told about.
+type Event struct {
+	Name  string    `json:"event"`          // OnMention, OnDM or OnShip
+	Login string    `json:"login"`          // who sent the message
+	Room  string    `json:"room,omitempty"` // room slug; empty for DMs
+	Body  string    `json:"body"`
+	ID    string    `json:"id,omitempty"`  // message ID
+	URL   string    `json:"url,omitempty"` // permalink, where there is one
+	Time  time.Time `json:"time"`
+}
+
+// env returns e as GRIMORA_* variables.
+func (e Event) env() []string {
+	return []string{
+		"GRIMORA_EVENT=" + e.Name,
+		"GRIMORA_LOGIN=" + e.Login,
+		"GRIMORA_ROOM=" + e.Room,
+		"GRIMORA_BODY=" + e.Body,
+		"GRIMORA_ID=" + e.ID,
+		"GRIMORA_URL=" + e.URL,
+	}
+}
+
+// Runner runs the configured command for each event. The zero Runner has
+// no hooks.
+type Runner struct {
+	cmds  map[string]string
+	slots chan struct{} // one per running hook, up to MaxRunning
+}
+
+// New returns a Runner for cmds, keyed by event name. Commands are run by
+// the shell, so pipes and quoting work as typed.
+func New(cmds map[string]string) *Runner {
+	return &Runner{cmds: cmds, slots: make(chan struct{}, MaxRunning)}
+}
+
+// Has reports whether a hook is set for event.
+func (r *Runner) Has(event string) bool {
+	return r != nil && r.cmds[event] != ""
+}
+
+// Run runs the hook for e and waits for it, up to Timeout, counting any
+// wait for one of the MaxRunning slots. It does nothing when no hook is set
+// for e.Name.
+func (r *Runner) Run(ctx context.Context, e Event) error {
+	if !r.Has(e.Name) {
+		return nil
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("hooks: encode %s: %w", e.Name, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+	select {
+	case r.slots <- struct{}{}:
+		defer func() { <-r.slots }()
+	case <-ctx.Done():
+		return fmt.Errorf("hooks: %s: %d hooks already running: %w", e.Name, MaxRunning, ctx.Err())
+	}
+	cmd := shell(ctx, r.cmds[e.Name])
+	cmd.Env = append(os.Environ(), e.env()...)
+	cmd.Stdin = bytes.NewReader(append(payload, '\n'))
+	// Output is left to go to the null device: pipes would make Run wait for
+	// any child the shell started, long after the shell itself was killed.
+	cmd.WaitDelay = waitDelay
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("hooks: %s: %w", e.Name, err)
+	}
+	return nil
+}
+
+// shell returns a command that runs line with the platform's shell.
+func shell(ctx context.Context, line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", line)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", line)
+}
@@ -0,0 +1,90 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunPassesEvent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	t.Setenv("HOOK_OUT", out)
+	r := New(map[string]string{OnMention: `cat > "$HOOK_OUT"; printf '%s|%s' "$GRIMORA_EVENT" "$GRIMORA_LOGIN" >> "$HOOK_OUT"`})
+
+	e := Event{Name: OnMention, Login: "ada", Room: "the-hall", Body: "hey @you", ID: "m1", Time: time.Unix(0, 0).UTC()}
+	if err := r.Run(context.Background(), e); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, env, _ := strings.Cut(string(b), "\n")
+	var got Event
+	if err := json.Unmarshal([]byte(payload), &got); err != nil {
+		t.Fatalf("stdin is not the event as JSON: %q", payload)
+	}
+	if got != e {
+		t.Errorf("stdin event = %+v, want %+v", got, e)
+	}
+	if env != "on_mention|ada" {
+		t.Errorf("env = %q, want on_mention|ada", env)
+	}
+}
+
+func TestRunWithoutHook(t *testing.T) {
+	var r *Runner
+	if r.Has(OnDM) {
+		t.Error("nil Runner should have no hooks")
+	}
+	if err := New(map[string]string{OnShip: "false"}).Run(context.Background(), Event{Name: OnDM}); err != nil {
+		t.Errorf("an event without a hook should do nothing, got %v", err)
+	}
+}
+
+func TestRunReportsFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	err := New(map[string]string{OnDM: "exit 3"}).Run(context.Background(), Event{Name: OnDM})
+	if err == nil || !strings.Contains(err.Error(), "on_dm") {
+		t.Errorf("err = %v, want the failing event named", err)
+	}
+}
+
+func TestRunKillsAHungHookWithChildren(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := New(map[string]string{OnDM: "sleep 5; true"}).Run(ctx, Event{Name: OnDM})
+	if err == nil {
+		t.Error("a hook past its deadline should fail")
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("Run took %v, want it to return once the shell is killed", d)
+	}
+}
+
+func TestRunWaitsForAFreeSlot(t *testing.T) {
+	r := New(map[string]string{OnDM: "true"})
+	for range MaxRunning {
+		r.slots <- struct{}{}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := r.Run(ctx, Event{Name: OnDM}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want it to give up waiting while every slot is taken", err)
+	}
+}
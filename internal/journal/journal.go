@@ -0,0 +1,125 @@
+// Package journal keeps an append-only local record of everything the user
+// posts in ~/.grimora/journal.ndjson, one JSON object per line, so their own
+// words stay searchable without the server.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/appdir"
+)
+
+// Entry kinds.
+const (
+	KindRoom    = "room"    // a Hall or guild room message, including /seek, /build, /b and /ship
+	KindDM      = "dm"      // a direct message
+	KindSpell   = "spell"   // a spell submitted from the create form
+	KindProject = "project" // a workshop project created or edited, or an update posted to one
+)
+
+// Entry is one thing the user posted.
+type Entry struct {
+	Time  time.Time `json:"time"`
+	Kind  string    `json:"kind"`
+	ID    string    `json:"id,omitempty"`    // server ID of what was posted
+	Where string    `json:"where,omitempty"` // room slug, DM partner, spell tag or project name
+	Text  string    `json:"text"`
+}
+
+// Journal appends entries to a file. A nil *Journal discards them.
+type Journal struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Path returns ~/.grimora/journal.ndjson.
+func Path() (string, error) {
+	return appdir.Path("journal.ndjson")
+}
+
+// Open returns a journal writing to path. The file is created on the first
+// Append.
+func Open(path string) *Journal {
+	return &Journal{path: path}
+}
+
+// Append adds e to the end of the journal, stamping it with the current time
+// if it has none. Existing entries are never rewritten.
+func (j *Journal) Append(e Entry) error {
+	if j == nil {
+		return nil
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode journal entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return fmt.Errorf("create journal dir: %w", err)
+	}
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open journal: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close() //nolint:errcheck
+		return fmt.Errorf("write journal: %w", err)
+	}
+	return f.Close()
+}
+
+// Read calls fn for each entry in r, oldest first. Lines that don't parse,
+// such as one cut short by a crash, are skipped.
+func Read(r io.Reader, fn func(Entry) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 4<<20)
+	for sc.Scan() {
+		var e Entry
+		if json.Unmarshal(sc.Bytes(), &e) != nil {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// Search returns the entries in the journal at path for which match reports
+// true, oldest first. A missing journal has no entries.
+func Search(path string, match func(Entry) bool) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open journal: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+	var out []Entry
+	err = Read(f, func(e Entry) error {
+		if match(e) {
+			out = append(out, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read journal: %w", err)
+	}
+	return out, nil
+}
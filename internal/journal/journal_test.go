@@ -0,0 +1,85 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppendAndSearch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "journal.ndjson")
+	j := Open(path)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	if err := j.Append(Entry{Time: at, Kind: KindRoom, ID: "m1", Where: "the-hall", Text: "anyone tried sqlc?"}); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	if err := j.Append(Entry{Kind: KindDM, Where: "ada", Text: "thanks for the sqlc tip"}); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+	if err := j.Append(Entry{Kind: KindSpell, Where: "testing", Text: "Write table tests"}); err != nil {
+		t.Fatalf("Append() error: %v", err)
+	}
+
+	got, err := Search(path, func(e Entry) bool { return strings.Contains(e.Text, "sqlc") })
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "m1" || got[1].Where != "ada" {
+		t.Fatalf("Search() = %+v", got)
+	}
+	if !got[0].Time.Equal(at) {
+		t.Errorf("Time = %v, want %v", got[0].Time, at)
+	}
+	if got[1].Time.IsZero() {
+		t.Error("expected Append to stamp the time")
+	}
+}
+
+func TestSearchMissingJournal(t *testing.T) {
+	got, err := Search(filepath.Join(t.TempDir(), "nope.ndjson"), func(Entry) bool { return true })
+	if err != nil || got != nil {
+		t.Errorf("Search() = %v, %v; want nothing", got, err)
+	}
+}
+
+func TestSearchSkipsTornLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.ndjson")
+	data := `{"time":"2026-10-01T09:00:00Z","kind":"room","text":"one"}` + "\n" +
+		`{"time":"2026-10-01T09:01:00Z","kind":"ro` + "\n" +
+		`{"time":"2026-10-01T09:02:00Z","kind":"dm","text":"two"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Search(path, func(Entry) bool { return true })
+	if err != nil || len(got) != 2 {
+		t.Errorf("Search() = %+v, %v; want the 2 whole entries", got, err)
+	}
+}
+
+func TestNilJournalDiscards(t *testing.T) {
+	var j *Journal
+	if err := j.Append(Entry{Kind: KindRoom, Text: "hi"}); err != nil {
+		t.Errorf("Append() on nil journal = %v", err)
+	}
+}
+
+func TestConcurrentAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.ndjson")
+	j := Open(path)
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			j.Append(Entry{Kind: KindRoom, Text: strings.Repeat("x", 500)}) //nolint:errcheck
+		}()
+	}
+	wg.Wait()
+	got, err := Search(path, func(Entry) bool { return true })
+	if err != nil || len(got) != 20 {
+		t.Errorf("got %d entries, %v; want 20", len(got), err)
+	}
+}
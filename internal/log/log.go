@@ -0,0 +1,90 @@
+// Package log writes debug logs to ~/.grimora/logs/grimora.log. Logging is
+// off unless enabled with --debug or GRIMORA_DEBUG, in which case HTTP
+// requests and TUI state transitions are recorded as structured slog lines.
+//
+// The TUI owns the terminal, so nothing here ever writes to stdout or stderr.
+package log
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/appdir"
+)
+
+// EnvDebug enables debug logging when set to a true value ("1", "true", ...).
+const EnvDebug = "GRIMORA_DEBUG"
+
+var logger atomic.Pointer[slog.Logger]
+
+func init() {
+	logger.Store(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// Path returns ~/.grimora/logs/grimora.log.
+func Path() (string, error) {
+	return appdir.Path("logs", "grimora.log")
+}
+
+// EnabledByEnv reports whether GRIMORA_DEBUG asks for debug logging.
+func EnabledByEnv() bool {
+	on, err := strconv.ParseBool(os.Getenv(EnvDebug))
+	return err == nil && on
+}
+
+// Setup starts logging at debug level to a rotating file at path. The
+// returned closer flushes and closes the file; call it on exit.
+func Setup(path string) (io.Closer, error) {
+	w, err := openRotating(path, defaultMaxSize, defaultBackups)
+	if err != nil {
+		return nil, err
+	}
+	logger.Store(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	return w, nil
+}
+
+// SetOutput logs to w at debug level. Intended for tests.
+func SetOutput(w io.Writer) {
+	logger.Store(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
+
+// Logger returns the current logger. It discards everything until Setup.
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// Debug logs at debug level.
+func Debug(msg string, args ...any) { Logger().Debug(msg, args...) }
+
+// Info logs at info level.
+func Info(msg string, args ...any) { Logger().Info(msg, args...) }
+
+// Warn logs at warn level.
+func Warn(msg string, args ...any) { Logger().Warn(msg, args...) }
+
+// Error logs at error level.
+func Error(msg string, args ...any) { Logger().Error(msg, args...) }
+
+// Request logs a finished API request. Its signature matches
+// client.RequestObserver so it can be installed directly. Status 0 means the
+// request failed before a response arrived.
+func Request(method, path string, status int, elapsed time.Duration) {
+	level := slog.LevelDebug
+	switch {
+	case status == 0 || status >= 500:
+		level = slog.LevelError
+	case status >= 400:
+		level = slog.LevelWarn
+	}
+	Logger().Log(context.Background(), level, "http request",
+		"method", method,
+		"path", path,
+		"status", status,
+		"elapsed", elapsed.Round(time.Microsecond),
+	)
+}
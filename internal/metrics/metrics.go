@@ -0,0 +1,334 @@
+// Package metrics collects API, polling and render timings and serves them in
+// the Prometheus text exposition format. It is hand-rolled rather than built
+// on the Prometheus client library to keep the single binary lean.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Bucket upper bounds, in seconds.
+var (
+	requestBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	frameBuckets   = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1}
+)
+
+type histogram struct {
+	counts []uint64 // cumulative counts are derived at write time
+	sum    float64
+	count  uint64
+}
+
+func (h *histogram) observe(buckets []float64, v float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(buckets))
+	}
+	for i, le := range buckets {
+		if v <= le {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+type requestKey struct {
+	method, path, code string
+}
+
+type routeKey struct {
+	method, path string
+}
+
+// Registry holds every metric grimora exports. The zero value is not usable;
+// call NewRegistry.
+type Registry struct {
+	mu       sync.Mutex
+	requests map[requestKey]uint64
+	latency  map[routeKey]*histogram
+	polls    map[string]uint64
+	frames   histogram
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		requests: make(map[requestKey]uint64),
+		latency:  make(map[routeKey]*histogram),
+		polls:    make(map[string]uint64),
+	}
+}
+
+// Default is the process-wide registry used by the package-level functions.
+var Default = NewRegistry()
+
+// ObserveRequest records one API request. status is 0 when the request
+// failed before a response arrived.
+func (r *Registry) ObserveRequest(method, path string, status int, elapsed time.Duration) {
+	path = routeOf(path)
+	code := strconv.Itoa(status)
+	if status == 0 {
+		code = "error"
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests[requestKey{method, path, code}]++
+	h := r.latency[routeKey{method, path}]
+	if h == nil {
+		h = &histogram{}
+		r.latency[routeKey{method, path}] = h
+	}
+	h.observe(requestBuckets, elapsed.Seconds())
+}
+
+// ObservePoll records one polling cycle of the named view.
+func (r *Registry) ObservePoll(view string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.polls[view]++
+}
+
+// ObserveFrame records how long one TUI frame took to render.
+func (r *Registry) ObserveFrame(elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frames.observe(frameBuckets, elapsed.Seconds())
+}
+
+// ObserveRequest records an API request on the Default registry. Its
+// signature matches client.RequestObserver.
+func ObserveRequest(method, path string, status int, elapsed time.Duration) {
+	Default.ObserveRequest(method, path, status, elapsed)
+}
+
+// ObservePoll records a polling cycle on the Default registry.
+func ObservePoll(view string) {
+	Default.ObservePoll(view)
+}
+
+// ObserveFrame records a TUI frame on the Default registry.
+func ObserveFrame(elapsed time.Duration) {
+	Default.ObserveFrame(elapsed)
+}
+
+// WriteTo writes every metric in the Prometheus text format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP grimora_client_requests_total API requests made, by method, route and status code.\n")
+	b.WriteString("# TYPE grimora_client_requests_total counter\n")
+	reqKeys := make([]requestKey, 0, len(r.requests))
+	for k := range r.requests {
+		reqKeys = append(reqKeys, k)
+	}
+	sort.Slice(reqKeys, func(i, j int) bool {
+		a, c := reqKeys[i], reqKeys[j]
+		if a.path != c.path {
+			return a.path < c.path
+		}
+		if a.method != c.method {
+			return a.method < c.method
+		}
+		return a.code < c.code
+	})
+	for _, k := range reqKeys {
+		fmt.Fprintf(&b, "grimora_client_requests_total{method=%q,path=%q,code=%q} %d\n", k.method, k.path, k.code, r.requests[k])
+	}
+
+	b.WriteString("# HELP grimora_client_request_duration_seconds API request latency, by method and route.\n")
+	b.WriteString("# TYPE grimora_client_request_duration_seconds histogram\n")
+	routes := make([]routeKey, 0, len(r.latency))
+	for k := range r.latency {
+		routes = append(routes, k)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].path != routes[j].path {
+			return routes[i].path < routes[j].path
+		}
+		return routes[i].method < routes[j].method
+	})
+	for _, k := range routes {
+		labels := fmt.Sprintf("method=%q,path=%q", k.method, k.path)
+		writeHistogram(&b, "grimora_client_request_duration_seconds", labels, requestBuckets, r.latency[k])
+	}
+
+	b.WriteString("# HELP grimora_poll_cycles_total Background polling cycles, by view.\n")
+	b.WriteString("# TYPE grimora_poll_cycles_total counter\n")
+	views := make([]string, 0, len(r.polls))
+	for v := range r.polls {
+		views = append(views, v)
+	}
+	sort.Strings(views)
+	for _, v := range views {
+		fmt.Fprintf(&b, "grimora_poll_cycles_total{view=%q} %d\n", v, r.polls[v])
+	}
+
+	b.WriteString("# HELP grimora_tui_frame_duration_seconds Time spent rendering one TUI frame.\n")
+	b.WriteString("# TYPE grimora_tui_frame_duration_seconds histogram\n")
+	writeHistogram(&b, "grimora_tui_frame_duration_seconds", "", frameBuckets, &r.frames)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func writeHistogram(b *strings.Builder, name, labels string, buckets []float64, h *histogram) {
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+	var cum uint64
+	for i, le := range buckets {
+		if h.counts != nil {
+			cum += h.counts[i]
+		}
+		fmt.Fprintf(b, "%s_bucket{%s%sle=%q} %d\n", name, labels, sep, strconv.FormatFloat(le, 'g', -1, 64), cum)
+	}
+	fmt.Fprintf(b, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, h.count)
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(b, "%s_sum%s %s\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(b, "%s_count%s %d\n", name, labels, h.count)
+}
+
+// Handler serves the registry at any path.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteTo(w) //nolint:errcheck // client went away
+	})
+}
+
+// Serve exposes the Default registry at http://addr/metrics. It binds
+// synchronously so a bad address is reported right away, then serves in the
+// background for the life of the process.
+func Serve(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("metrics listen %s: %w", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Default.Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go srv.Serve(ln) //nolint:errcheck // runs until the process exits
+	return nil
+}
+
+// routes are the API paths grimora calls, as templates. A ":name" segment
+// matches any one segment, so IDs, logins and slugs never become labels of
+// their own. Fixed paths come before the templates that would also match
+// them, since the first match wins.
+var routes = []string{
+	"/auth/refresh",
+	"/api/health",
+	"/api/version",
+	"/api/telemetry",
+	"/api/reports",
+	"/api/stream",
+	"/api/presence/heartbeat",
+	"/api/me",
+	"/api/me/blocks",
+	"/api/me/forge-history",
+	"/api/me/forge-stats",
+	"/api/me/saved-spells",
+	"/api/me/subscriptions",
+	"/api/me/token",
+	"/api/spells",
+	"/api/spells/preview",
+	"/api/spells/random",
+	"/api/spells/tags",
+	"/api/spells/:id",
+	"/api/spells/:id/cast",
+	"/api/spells/:id/fork",
+	"/api/spells/:id/reevaluate",
+	"/api/spells/:id/save",
+	"/api/spells/:id/upvote",
+	"/api/spell-drafts",
+	"/api/spell-drafts/:id",
+	"/api/spell-drafts/:id/suggestions",
+	"/api/spell-drafts/:id/suggestions/:suggestion",
+	"/api/weapons",
+	"/api/weapons/:id",
+	"/api/weapons/:id/save",
+	"/api/magicians",
+	"/api/magicians/presence",
+	"/api/magicians/:login",
+	"/api/magicians/:login/block",
+	"/api/magicians/:login/follow",
+	"/api/magicians/:login/spells",
+	"/api/magicians/:login/workshop",
+	"/api/leaderboard",
+	"/api/leaderboard/:login",
+	"/api/guilds/standings",
+	"/api/guilds/:guild/chests",
+	"/api/rooms",
+	"/api/rooms/:slug",
+	"/api/rooms/:slug/archive",
+	"/api/rooms/:slug/join",
+	"/api/rooms/:slug/presence",
+	"/api/rooms/:slug/messages",
+	"/api/rooms/:slug/messages/reactions",
+	"/api/rooms/:slug/messages/:id/reactions",
+	"/api/threads",
+	"/api/threads/:id/messages",
+	"/api/threads/:id/read",
+	"/api/notifications",
+	"/api/notifications/read",
+	"/api/notifications/:id/read",
+	"/api/subscriptions/:type/:id",
+	"/api/invites",
+	"/api/invites/progress",
+	"/api/invites/:code",
+	"/api/workshop",
+	"/api/workshop/:id",
+	"/api/workshop/:id/restore",
+	"/api/workshop/:id/updates",
+}
+
+// otherRoute labels requests to paths missing from routes.
+const otherRoute = "other"
+
+// routeOf reduces a request path to a low-cardinality route label: the
+// template in routes it matches, with the query string dropped, or
+// otherRoute.
+func routeOf(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segs := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	for _, route := range routes {
+		if matchRoute(strings.Split(route, "/"), segs) {
+			return route
+		}
+	}
+	return otherRoute
+}
+
+// matchRoute reports whether segs fill in the template segments.
+func matchRoute(template, segs []string) bool {
+	if len(template) != len(segs) {
+		return false
+	}
+	for i, t := range template {
+		if strings.HasPrefix(t, ":") {
+			if segs[i] == "" {
+				return false
+			}
+		} else if t != segs[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRouteOf(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"/api/me", "/api/me"},
+		{"/api/spells?tag=go&limit=20", "/api/spells"},
+		{"/api/threads/6f1c2a9e-2a43-4d0f-9a55-3f1d2c7b8e10/messages", "/api/threads/:id/messages"},
+		{"/api/leaderboard/octocat", "/api/leaderboard/:login"},
+		{"/api/rooms/build-club/messages?before=m9", "/api/rooms/:slug/messages"},
+		{"/api/rooms/build-club/messages/reactions?ids=a,b", "/api/rooms/:slug/messages/reactions"},
+		{"/api/spells/tags", "/api/spells/tags"},
+		{"/api/spells/s1/save", "/api/spells/:id/save"},
+		{"/api/subscriptions/spell/s1", "/api/subscriptions/:type/:id"},
+		{"/api/rooms//messages", "other"},
+		{"/somewhere/else/42", "other"},
+	}
+	for _, tt := range tests {
+		if got := routeOf(tt.in); got != tt.want {
+			t.Errorf("routeOf(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteToExposition(t *testing.T) {
+	r := NewRegistry()
+	r.ObserveRequest("GET", "/api/spells?tag=go", 200, 80*time.Millisecond)
+	r.ObserveRequest("GET", "/api/spells", 200, 3*time.Second)
+	r.ObserveRequest("POST", "/api/spells", 0, time.Millisecond)
+	r.ObservePoll("hall")
+	r.ObservePoll("hall")
+	r.ObserveFrame(2 * time.Millisecond)
+
+	var b strings.Builder
+	if _, err := r.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		`grimora_client_requests_total{method="GET",path="/api/spells",code="200"} 2`,
+		`grimora_client_requests_total{method="POST",path="/api/spells",code="error"} 1`,
+		`grimora_client_request_duration_seconds_bucket{method="GET",path="/api/spells",le="0.1"} 1`,
+		`grimora_client_request_duration_seconds_bucket{method="GET",path="/api/spells",le="+Inf"} 2`,
+		`grimora_client_request_duration_seconds_count{method="GET",path="/api/spells"} 2`,
+		`grimora_poll_cycles_total{view="hall"} 2`,
+		`grimora_tui_frame_duration_seconds_bucket{le="0.0025"} 1`,
+		`grimora_tui_frame_duration_seconds_count 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	r.ObservePoll("board")
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `grimora_poll_cycles_total{view="board"} 1`) {
+		t.Errorf("unexpected body:\n%s", rec.Body.String())
+	}
+}
+
+func TestServeBadAddr(t *testing.T) {
+	if err := Serve("not-an-address"); err == nil {
+		t.Error("expected listen error for a bad address")
+	}
+}
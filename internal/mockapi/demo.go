@@ -0,0 +1,269 @@
+package mockapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// DemoLogin is who the demo signs you in as.
+const DemoLogin = "demo"
+
+// demoCast is the magicians the demo is populated with, strongest first.
+var demoCast = []struct {
+	login, name, guild, city, archetype, lang string
+	spells, potency                           int
+	online                                    bool
+}{
+	{"ada", "Ada", "loomari", "London", "architect", "Go", 42, 318, true},
+	{"grace", "Grace", "cipher", "New York", "sentinel", "Rust", 37, 280, true},
+	{"linus", "Linus", "ashborne", "Portland", "tinkerer", "C", 31, 233, false},
+	{"margaret", "Margaret", "fathom", "Boston", "chronicler", "Python", 24, 190, true},
+	{"alan", "Alan", "nyx", "Manchester", "oracle", "Haskell", 19, 151, false},
+	{DemoLogin, "Demo Magician", "amarok", "Lisbon", "alchemist", "TypeScript", 12, 88, true},
+	{"barbara", "Barbara", "amarok", "Lisbon", "architect", "Go", 9, 70, false},
+	{"ken", "Ken", "loomari", "Berkeley", "tinkerer", "C", 6, 41, true},
+}
+
+// demoSpells are the spells on the demo's board, newest first.
+var demoSpells = []struct {
+	author, tag, text, voice string
+	potency, upvotes         int
+}{
+	{"ada", "debugging", "You are a patient debugger. Before suggesting any fix, restate the bug in one sentence, list three hypotheses ranked by likelihood, and name the single cheapest experiment that would rule out the top one.", "Ranked hypotheses before fixes. The forge approves.", 5, 64},
+	{"grace", "security", "Review this diff as an attacker would. For every input that crosses a trust boundary, say where it is validated, or quote the line where it should be.", "Trust boundaries, named and walked.", 5, 51},
+	{"margaret", "testing", "Write table-driven tests for the function below. Cover the zero value, one boundary on each side, and the case the author most likely forgot. Explain that last one in a comment.", "The forgotten case is where the bugs live.", 4, 38},
+	{"linus", "refactoring", "Refactor this function without changing behaviour. Make at most three moves, name each one (extract, inline, rename, ...), and show the diff after each.", "Small named moves. Reviewable by design.", 4, 29},
+	{"alan", "architecture", "Given these requirements, propose two designs that differ in where state lives. For each, list what becomes easy, what becomes hard, and the first thing that breaks at 10x load.", "Two designs, honest trade-offs.", 4, 22},
+	{DemoLogin, "productivity", "Turn my messy notes below into a checklist for tomorrow. Keep my wording, drop anything already done, and put the one task that unblocks others first.", "Your own words, in order.", 3, 12},
+	{"barbara", "backend", "Design the HTTP API for the resource below. Give the routes, status codes and one example request and response each. Prefer boring over clever.", "Boring APIs age well.", 3, 9},
+	{"ken", "performance", "Profile-first: before optimising the code below, tell me what you would measure, with which tool, and what result would make you leave it alone.", "Measure, then maybe leave it alone.", 3, 7},
+}
+
+// Demo returns a Fake filled with a small, lively community for grimora
+// --demo and for tests that want realistic data: a board, a busy Hall, a
+// guild room, a couple of DMs, spells, weapons and notifications. Times are
+// relative to now, so it always looks current.
+func Demo() *clienttest.Fake {
+	now := time.Now()
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+	id := func(s string) uuid.UUID { return uuid.NewSHA1(uuid.NameSpaceURL, []byte("grimora-demo:"+s)) }
+
+	f := &clienttest.Fake{
+		Online:       make(map[string]bool),
+		Messages:     make(map[string][]domain.Message),
+		RoomMessages: make(map[string][]domain.RoomMessage),
+		RoomPresence: make(map[string]*client.RoomPresence),
+		Workshops:    make(map[string][]domain.WorkshopProject),
+		Server:       &client.ServerInfo{Version: "demo", APIVersion: client.APIVersion},
+	}
+
+	guilds := make(map[string]string)
+	for i, c := range demoCast {
+		m := domain.Magician{
+			ID:          id("magician/" + c.login),
+			GitHubID:    int64(1000 + i),
+			GitHubLogin: c.login,
+			CardNumber:  i + 1,
+			GuildID:     c.guild,
+			Archetype:   c.archetype,
+			City:        c.city,
+			DisplayName: c.name,
+			TopLanguage: c.lang,
+			CreatedAt:   ago(time.Duration(90-i*10) * 24 * time.Hour),
+		}
+		guilds[c.login] = c.guild
+		card := domain.MagicianCard{
+			Magician:     m,
+			SpellCount:   c.spells,
+			TotalPotency: c.potency,
+			Online:       c.online,
+			IsFollowing:  c.login == "ada" || c.login == "grace",
+		}
+		if c.login == DemoLogin {
+			me := m
+			me.Bio = "Trying out Grimora without an account. Nothing here leaves your machine."
+			f.Me = &me
+			card.Magician = me
+		}
+		f.Magicians = append(f.Magicians, card)
+		f.Leaderboard = append(f.Leaderboard, domain.LeaderboardEntry{
+			Rank:         i + 1,
+			Login:        c.login,
+			GuildID:      c.guild,
+			City:         c.city,
+			DisplayName:  c.name,
+			Archetype:    c.archetype,
+			SpellsForged: c.spells,
+			TotalPotency: c.potency,
+		})
+		f.Online[c.login] = c.online
+	}
+	f.ForgeStats = &domain.ForgeStats{SpellsForged: 12, TotalPotency: 88, AvgPotency: 3.4, AcceptanceRate: 0.8, Rank: 6, TotalRanked: len(demoCast), SpellsCast: 21}
+	f.InviteProgress = &domain.InviteProgress{SpellsForged: 2, SpellsRequired: 5}
+
+	for i, s := range demoSpells {
+		spell := domain.Spell{
+			ID:         id(fmt.Sprintf("spell/%d", i)),
+			MagicianID: id("magician/" + s.author),
+			Text:       s.text,
+			Tag:        s.tag,
+			Potency:    s.potency,
+			Status:     "published",
+			Upvotes:    s.upvotes,
+			Casts:      s.upvotes * 3,
+			Voice:      s.voice,
+			Author:     &domain.Author{Login: s.author, GuildID: guilds[s.author]},
+			CreatedAt:  ago(time.Duration(i*9+2) * time.Hour),
+		}
+		f.Spells = append(f.Spells, spell)
+		f.Stream = append(f.Stream, domain.StreamEvent{
+			Kind:          "spell",
+			ID:            spell.ID,
+			MagicianLogin: s.author,
+			GuildID:       guilds[s.author],
+			Title:         s.text,
+			Tag:           s.tag,
+			Upvotes:       s.upvotes,
+			Potency:       s.potency,
+			Voice:         s.voice,
+			CreatedAt:     spell.CreatedAt,
+		})
+	}
+
+	f.Weapons = []domain.Weapon{
+		{ID: id("weapon/lipgloss"), MagicianID: id("magician/ada"), Name: "lipgloss", Description: "Style definitions for nice terminal layouts", RepositoryURL: "https://github.com/charmbracelet/lipgloss", GitHubStars: 9000, GitHubForks: 250, GitHubLanguage: "Go", License: "MIT", Categories: []string{"cli", "library"}, SaveCount: 14, CreatedAt: ago(20 * 24 * time.Hour)},
+		{ID: id("weapon/ripgrep"), MagicianID: id("magician/grace"), Name: "ripgrep", Description: "Recursively search directories for a regex pattern", RepositoryURL: "https://github.com/BurntSushi/ripgrep", GitHubStars: 50000, GitHubForks: 2000, GitHubLanguage: "Rust", License: "MIT", Categories: []string{"cli"}, SaveCount: 22, CreatedAt: ago(30 * 24 * time.Hour)},
+		{ID: id("weapon/jq"), MagicianID: id("magician/linus"), Name: "jq", Description: "Command-line JSON processor", RepositoryURL: "https://github.com/jqlang/jq", GitHubStars: 31000, GitHubForks: 1600, GitHubLanguage: "C", License: "MIT", Categories: []string{"cli", "data"}, SaveCount: 9, CreatedAt: ago(40 * 24 * time.Hour)},
+	}
+
+	// Rooms: the Hall, the demo magician's guild room and a topic room.
+	f.Rooms = []domain.Room{
+		{ID: id("room/hall"), Slug: domain.HallSlug, Name: "The Hall", RoomType: domain.RoomTypeHall, CreatedAt: ago(365 * 24 * time.Hour)},
+		{ID: id("room/amarok"), Slug: "amarok", Name: "Amarok", RoomType: domain.RoomTypeGuild, GuildID: "amarok", CreatedAt: ago(365 * 24 * time.Hour)},
+		{ID: id("room/prompt-craft"), Slug: "prompt-craft", Name: "prompt-craft", RoomType: domain.RoomTypeTopic, Description: "Swapping techniques for better spells", Topic: "this week: spells that ask before they act", CreatedAt: ago(60 * 24 * time.Hour)},
+	}
+	say := func(room, login, kind, body string, at time.Duration) {
+		r := id("room/" + room)
+		slug := room
+		if room == "hall" {
+			slug = domain.HallSlug
+		}
+		f.RoomMessages[slug] = append(f.RoomMessages[slug], domain.RoomMessage{
+			ID:          id(fmt.Sprintf("msg/%s/%d", room, len(f.RoomMessages[slug]))),
+			RoomID:      r,
+			SenderID:    id("magician/" + login),
+			SenderLogin: login,
+			SenderGuild: guilds[login],
+			Body:        body,
+			Kind:        kind,
+			CreatedAt:   ago(at),
+		})
+	}
+	say("hall", "ada", "message", "morning all ☕", 95*time.Minute)
+	say("hall", "linus", "build-start", "a tiny TUI for tailing CI logs", 80*time.Minute)
+	say("hall", "margaret", "message", "anyone have a good spell for turning flaky tests into deterministic ones?", 62*time.Minute)
+	say("hall", "grace", "message", "@margaret try asking it to list every source of time and randomness first", 58*time.Minute)
+	say("hall", "ken", "seek", "how do you keep a system prompt short without losing the edge cases?", 40*time.Minute)
+	say("hall", "linus", "build-update", "log tailing works, colours next", 25*time.Minute)
+	say("hall", "ada", "message", "welcome @demo! /tour is a safe place to try the commands", 12*time.Minute)
+	say("hall", "grace", "ship", "fuzzing harness for our config parser", 5*time.Minute)
+	say("amarok", "barbara", "message", "guild chest is looking thin, drop your best backend spells in", 3*time.Hour)
+	say("amarok", "barbara", "message", "@demo yours on checklists would fit", 2*time.Hour)
+	say("prompt-craft", "alan", "message", "asking the model to name its assumptions up front has halved my retries", 5*time.Hour)
+	say("prompt-craft", "margaret", "message", "same, and asking for the cheapest experiment first", 4*time.Hour)
+	for slug, logins := range map[string][]string{
+		domain.HallSlug: {"ada", "grace", "margaret", "ken", DemoLogin},
+		"amarok":        {DemoLogin},
+		"prompt-craft":  {"margaret"},
+	} {
+		f.RoomPresence[slug] = &client.RoomPresence{RoomSlug: slug, Count: len(logins), Magicians: logins}
+	}
+
+	// DMs
+	dm := func(other string, unread int, lines ...string) {
+		tid := id("thread/" + other)
+		for i, body := range lines {
+			from := other
+			if i%2 == 1 {
+				from = DemoLogin
+			}
+			f.Messages[tid.String()] = append(f.Messages[tid.String()], domain.Message{
+				ID:          id(fmt.Sprintf("dm/%s/%d", other, i)),
+				ThreadID:    tid,
+				SenderID:    id("magician/" + from),
+				SenderLogin: from,
+				Body:        body,
+				CreatedAt:   ago(time.Duration(len(lines)-i) * 7 * time.Minute),
+			})
+		}
+		msgs := f.Messages[tid.String()]
+		last := msgs[len(msgs)-1]
+		f.Threads = append(f.Threads, domain.Thread{
+			ID:            tid,
+			OtherLogin:    other,
+			OtherGuildID:  guilds[other],
+			LastMessage:   last.Body,
+			LastMessageAt: last.CreatedAt,
+			Unread:        unread,
+			CreatedAt:     msgs[0].CreatedAt,
+		})
+	}
+	dm("ada", 1,
+		"hey! saw you joined the demo",
+		"hi ada, just looking around",
+		"press ? anywhere for the keys, and / in the library to search spells")
+	dm("barbara", 0,
+		"want to pair on a chest for amarok?",
+		"sure, tomorrow?",
+		"works for me")
+
+	// A group thread, where every voice keeps its guild colour.
+	group := id("thread/group")
+	for i, line := range []struct{ from, body string }{
+		{"grace", "starting a thread for the parser rewrite"},
+		{"ada", "count me in, I have the grammar half-done"},
+		{DemoLogin, "I can take the error messages"},
+	} {
+		f.Messages[group.String()] = append(f.Messages[group.String()], domain.Message{
+			ID:          id(fmt.Sprintf("dm/group/%d", i)),
+			ThreadID:    group,
+			SenderID:    id("magician/" + line.from),
+			SenderLogin: line.from,
+			Body:        line.body,
+			CreatedAt:   ago(time.Duration(3-i) * 11 * time.Minute),
+		})
+	}
+	f.Threads = append(f.Threads, domain.Thread{
+		ID:            group,
+		Participants:  []domain.ThreadParticipant{{Login: "ada", GuildID: guilds["ada"]}, {Login: "grace", GuildID: guilds["grace"]}},
+		LastMessage:   "I can take the error messages",
+		LastMessageAt: ago(11 * time.Minute),
+		CreatedAt:     ago(33 * time.Minute),
+	})
+
+	f.Projects = []domain.WorkshopProject{
+		{ID: id("project/checklists"), MagicianID: f.Me.ID, Name: "checklist-bot", Insight: "Turning notes into tomorrow's plan", CreatedAt: ago(14 * 24 * time.Hour), UpdatedAt: ago(2 * 24 * time.Hour)},
+	}
+	f.Workshops["linus"] = []domain.WorkshopProject{
+		{ID: id("project/citail"), MagicianID: id("magician/linus"), Name: "citail", Insight: "A tiny TUI for tailing CI logs", CreatedAt: ago(80 * time.Minute), UpdatedAt: ago(25 * time.Minute)},
+	}
+
+	f.Notifications = []domain.GroupedNotification{
+		{
+			Notification: domain.Notification{ID: id("notif/mention"), Type: domain.NotifMention, ActorID: id("magician/ada"), ActorLogin: "ada", ActorGuild: "loomari", Preview: "welcome @demo! /tour is a safe place to try the commands", RefSlug: domain.HallSlug, CreatedAt: ago(12 * time.Minute)},
+			ActorCount:   1,
+		},
+		{
+			Notification: domain.Notification{ID: id("notif/upvote"), Type: domain.NotifUpvote, ActorID: id("magician/grace"), ActorLogin: "grace", ActorGuild: "cipher", Preview: demoSpells[5].text, CreatedAt: ago(3 * time.Hour)},
+			Actors:       []domain.NotifActor{{Login: "grace", GuildID: "cipher"}, {Login: "ken", GuildID: "loomari"}},
+			ActorCount:   2,
+		},
+	}
+	return f
+}
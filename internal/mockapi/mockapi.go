@@ -0,0 +1,572 @@
+// Package mockapi serves the Grimora REST API from memory, for demos and for
+// tests that want the real client and TUI talking HTTP without a backend.
+//
+// Handler puts any client.API behind the routes pkg/client calls; usually
+// that's a clienttest.Fake, so writes land in its fields the way the API
+// would apply them. Demo returns a Fake already filled with magicians,
+// rooms, spells and DMs:
+//
+//	srv, err := mockapi.Start("127.0.0.1:0", mockapi.Demo())
+//	c := client.New(srv.URL, "demo")
+package mockapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// Server is a mock API listening on a local address.
+type Server struct {
+	URL string // base URL to hand to client.New, e.g. http://127.0.0.1:53121
+
+	srv *http.Server
+}
+
+// Start serves api on addr ("127.0.0.1:0" picks a free port) until Close.
+func Start(addr string, api client.API) (*Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("mockapi listen %s: %w", addr, err)
+	}
+	s := &Server{
+		URL: "http://" + ln.Addr().String(),
+		srv: &http.Server{Handler: Handler(api), ReadHeaderTimeout: 5 * time.Second},
+	}
+	go s.srv.Serve(ln) //nolint:errcheck // returns ErrServerClosed on Close
+	return s, nil
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.srv.Close()
+}
+
+// endpoint answers one route: a value to send as JSON, nil for an empty
+// 204, or an error to send the way the API reports them.
+type endpoint func(r *http.Request) (any, error)
+
+// Handler serves api over HTTP at the paths pkg/client uses. Every route
+// but /api/health and /api/version wants a bearer token; any token will do.
+func Handler(api client.API) http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, open bool, fn endpoint) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			if !open && !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+				writeError(w, &client.HTTPError{StatusCode: http.StatusUnauthorized, Message: "not signed in"})
+				return
+			}
+			out, err := fn(r)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			if out == nil {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			writeJSON(w, http.StatusOK, out)
+		})
+	}
+	route := func(pattern string, fn endpoint) { handle(pattern, false, fn) }
+
+	handle("GET /api/health", true, func(r *http.Request) (any, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+	handle("GET /api/version", true, func(r *http.Request) (any, error) {
+		return api.GetServerInfo(r.Context())
+	})
+
+	// Profile and stats
+	route("GET /api/me", func(r *http.Request) (any, error) {
+		return api.GetMe(r.Context())
+	})
+	route("PATCH /api/me", func(r *http.Request) (any, error) {
+		var u domain.ProfileUpdate
+		if err := decode(r, &u); err != nil {
+			return nil, err
+		}
+		return api.UpdateProfile(r.Context(), u)
+	})
+	route("GET /api/me/forge-stats", func(r *http.Request) (any, error) {
+		return api.GetForgeStats(r.Context())
+	})
+	route("GET /api/me/forge-history", func(r *http.Request) (any, error) {
+		return api.GetForgeHistory(r.Context())
+	})
+	route("GET /api/me/saved-spells", func(r *http.Request) (any, error) {
+		return api.ListSavedSpells(r.Context(), intParam(r, "limit"), intParam(r, "offset"))
+	})
+	route("GET /api/me/subscriptions", func(r *http.Request) (any, error) {
+		return api.ListSubscriptions(r.Context())
+	})
+
+	// Spells
+	route("GET /api/spells", func(r *http.Request) (any, error) {
+		q := r.URL.Query()
+		if q.Has("q") {
+			return api.SearchSpells(r.Context(), q.Get("q"))
+		}
+		return api.ListSpells(r.Context(), listParam(r, "tag"), q.Get("sort"), intParam(r, "limit"), intParam(r, "offset"))
+	})
+	route("GET /api/spells/tags", func(r *http.Request) (any, error) {
+		return api.TagStats(r.Context())
+	})
+	route("GET /api/spells/random", func(r *http.Request) (any, error) {
+		return api.GetRandomSpell(r.Context(), intParam(r, "min_potency"), r.URL.Query().Get("seed"))
+	})
+	route("GET /api/spells/{id}", func(r *http.Request) (any, error) {
+		return api.GetSpell(r.Context(), r.PathValue("id"))
+	})
+	route("POST /api/spells", func(r *http.Request) (any, error) {
+		var req client.CreateSpellRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return api.CreateSpell(r.Context(), req)
+	})
+	route("POST /api/spells/preview", func(r *http.Request) (any, error) {
+		var req client.CreateSpellRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return api.PreviewSpell(r.Context(), req)
+	})
+	route("PUT /api/spells/{id}", func(r *http.Request) (any, error) {
+		var req client.CreateSpellRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return api.UpdateSpell(r.Context(), r.PathValue("id"), req)
+	})
+	route("PATCH /api/spells/{id}", func(r *http.Request) (any, error) {
+		var body struct {
+			Context string `json:"context"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return api.SetSpellContext(r.Context(), r.PathValue("id"), body.Context)
+	})
+	route("POST /api/spells/{id}/upvote", func(r *http.Request) (any, error) {
+		return nil, api.UpvoteSpell(r.Context(), r.PathValue("id"))
+	})
+	route("POST /api/spells/{id}/cast", func(r *http.Request) (any, error) {
+		return api.CastSpell(r.Context(), r.PathValue("id"))
+	})
+	route("POST /api/spells/{id}/fork", func(r *http.Request) (any, error) {
+		return api.ForkSpell(r.Context(), r.PathValue("id"))
+	})
+	route("POST /api/spells/{id}/reevaluate", func(r *http.Request) (any, error) {
+		return api.RequestReevaluation(r.Context(), r.PathValue("id"))
+	})
+	route("POST /api/spells/{id}/save", func(r *http.Request) (any, error) {
+		return nil, api.SaveSpell(r.Context(), r.PathValue("id"))
+	})
+	route("DELETE /api/spells/{id}/save", func(r *http.Request) (any, error) {
+		return nil, api.UnsaveSpell(r.Context(), r.PathValue("id"))
+	})
+
+	// Weapons
+	route("GET /api/weapons", func(r *http.Request) (any, error) {
+		q := r.URL.Query()
+		if q.Has("q") {
+			return api.SearchWeapons(r.Context(), q.Get("q"))
+		}
+		return api.ListWeapons(r.Context(), listParam(r, "category"), intParam(r, "limit"), intParam(r, "offset"))
+	})
+	route("GET /api/weapons/{id}", func(r *http.Request) (any, error) {
+		return api.GetWeapon(r.Context(), r.PathValue("id"))
+	})
+	route("POST /api/weapons/{id}/save", func(r *http.Request) (any, error) {
+		return nil, api.SaveWeapon(r.Context(), r.PathValue("id"))
+	})
+
+	// Spell drafts
+	route("POST /api/spell-drafts", func(r *http.Request) (any, error) {
+		var req client.CreateSpellRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return api.ShareSpellDraft(r.Context(), req)
+	})
+	route("GET /api/spell-drafts/{id}", func(r *http.Request) (any, error) {
+		return api.GetSpellDraft(r.Context(), r.PathValue("id"))
+	})
+	route("PUT /api/spell-drafts/{id}", func(r *http.Request) (any, error) {
+		var req client.CreateSpellRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return api.UpdateSpellDraft(r.Context(), r.PathValue("id"), req)
+	})
+	route("POST /api/spell-drafts/{id}/suggestions", func(r *http.Request) (any, error) {
+		var body struct {
+			Field       string `json:"field"`
+			Original    string `json:"original"`
+			Replacement string `json:"replacement"`
+			Note        string `json:"note"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return api.SuggestDraftEdit(r.Context(), r.PathValue("id"), body.Field, body.Original, body.Replacement, body.Note)
+	})
+	route("PATCH /api/spell-drafts/{id}/suggestions/{suggestion}", func(r *http.Request) (any, error) {
+		var body struct {
+			Status string `json:"status"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		accept := body.Status == domain.SuggestionAccepted
+		return nil, api.ResolveDraftSuggestion(r.Context(), r.PathValue("id"), r.PathValue("suggestion"), accept)
+	})
+
+	// Magicians
+	route("GET /api/magicians", func(r *http.Request) (any, error) {
+		return api.ListMagicians(r.Context(), intParam(r, "limit"), intParam(r, "offset"))
+	})
+	route("GET /api/magicians/presence", func(r *http.Request) (any, error) {
+		return api.GetPresence(r.Context(), listParam(r, "logins"))
+	})
+	route("GET /api/magicians/{login}", func(r *http.Request) (any, error) {
+		return api.GetMagician(r.Context(), r.PathValue("login"))
+	})
+	route("GET /api/magicians/{login}/workshop", func(r *http.Request) (any, error) {
+		return api.GetMagicianWorkshop(r.Context(), r.PathValue("login"))
+	})
+	route("GET /api/magicians/{login}/spells", func(r *http.Request) (any, error) {
+		return api.ListMagicianSpells(r.Context(), r.PathValue("login"), intParam(r, "limit"))
+	})
+	route("POST /api/magicians/{login}/follow", func(r *http.Request) (any, error) {
+		return nil, api.Follow(r.Context(), r.PathValue("login"))
+	})
+	route("DELETE /api/magicians/{login}/follow", func(r *http.Request) (any, error) {
+		return nil, api.Unfollow(r.Context(), r.PathValue("login"))
+	})
+	route("GET /api/leaderboard", func(r *http.Request) (any, error) {
+		q := r.URL.Query()
+		return api.GetLeaderboard(r.Context(), q.Get("guild"), q.Get("city"), intParam(r, "limit"), intParam(r, "offset"))
+	})
+	route("GET /api/leaderboard/{login}", func(r *http.Request) (any, error) {
+		return api.GetLeaderboardRank(r.Context(), r.PathValue("login"))
+	})
+	route("GET /api/guilds/standings", func(r *http.Request) (any, error) {
+		return api.GetGuildLeaderboard(r.Context())
+	})
+	route("POST /api/presence/heartbeat", func(r *http.Request) (any, error) {
+		var body struct {
+			Status string `json:"status"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return nil, api.Heartbeat(r.Context(), body.Status)
+	})
+	route("GET /api/stream", func(r *http.Request) (any, error) {
+		following := r.URL.Query().Get("following") == "true"
+		return api.GetStream(r.Context(), following, intParam(r, "limit"), intParam(r, "offset"))
+	})
+
+	// DM threads
+	route("GET /api/threads", func(r *http.Request) (any, error) {
+		return api.ListThreads(r.Context())
+	})
+	route("POST /api/threads", func(r *http.Request) (any, error) {
+		var body struct {
+			Login  string   `json:"login"`
+			Logins []string `json:"logins"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		if len(body.Logins) > 0 {
+			return api.StartGroupThread(r.Context(), body.Logins)
+		}
+		return api.StartThread(r.Context(), body.Login)
+	})
+	route("GET /api/threads/{id}/messages", func(r *http.Request) (any, error) {
+		// GetMessages pages by offset; GetMessagesBefore by timestamp.
+		if r.URL.Query().Has("offset") {
+			return api.GetMessages(r.Context(), r.PathValue("id"), intParam(r, "limit"), intParam(r, "offset"))
+		}
+		before, err := timeParam(r, "before")
+		if err != nil {
+			return nil, err
+		}
+		return api.GetMessagesBefore(r.Context(), r.PathValue("id"), before, intParam(r, "limit"))
+	})
+	route("POST /api/threads/{id}/messages", func(r *http.Request) (any, error) {
+		var body struct {
+			Body string `json:"body"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return api.SendMessage(r.Context(), r.PathValue("id"), body.Body)
+	})
+	route("POST /api/threads/{id}/read", func(r *http.Request) (any, error) {
+		return nil, api.MarkThreadRead(r.Context(), r.PathValue("id"))
+	})
+
+	// Rooms
+	route("GET /api/rooms", func(r *http.Request) (any, error) {
+		return api.ListRooms(r.Context())
+	})
+	route("POST /api/rooms", func(r *http.Request) (any, error) {
+		var req client.CreateRoomRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return api.CreateRoom(r.Context(), req)
+	})
+	route("PATCH /api/rooms/{slug}", func(r *http.Request) (any, error) {
+		var body struct {
+			Topic string `json:"topic"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return api.SetRoomTopic(r.Context(), r.PathValue("slug"), body.Topic)
+	})
+	route("POST /api/rooms/{slug}/join", func(r *http.Request) (any, error) {
+		return nil, api.JoinRoom(r.Context(), r.PathValue("slug"))
+	})
+	route("POST /api/rooms/{slug}/archive", func(r *http.Request) (any, error) {
+		return nil, api.ArchiveRoom(r.Context(), r.PathValue("slug"))
+	})
+	route("GET /api/rooms/{slug}/presence", func(r *http.Request) (any, error) {
+		return api.GetRoomPresence(r.Context(), r.PathValue("slug"))
+	})
+	route("GET /api/rooms/{slug}/messages", func(r *http.Request) (any, error) {
+		before, err := timeParam(r, "before")
+		if err != nil {
+			return nil, err
+		}
+		return api.GetRoomMessages(r.Context(), r.PathValue("slug"), before, intParam(r, "limit"))
+	})
+	route("POST /api/rooms/{slug}/messages", func(r *http.Request) (any, error) {
+		var body struct {
+			Body     string            `json:"body"`
+			Metadata map[string]string `json:"metadata"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return api.SendRoomMessage(r.Context(), r.PathValue("slug"), body.Body, body.Metadata)
+	})
+	route("GET /api/rooms/{slug}/messages/reactions", func(r *http.Request) (any, error) {
+		return api.GetReactionCounts(r.Context(), r.PathValue("slug"), listParam(r, "ids"))
+	})
+	route("POST /api/rooms/{slug}/messages/{id}/reactions", func(r *http.Request) (any, error) {
+		var body struct {
+			Emoji string `json:"emoji"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return nil, api.AddReaction(r.Context(), r.PathValue("slug"), r.PathValue("id"), body.Emoji)
+	})
+
+	// Guild chests
+	route("GET /api/guilds/{guild}/chests", func(r *http.Request) (any, error) {
+		return api.ListGuildChests(r.Context(), r.PathValue("guild"))
+	})
+	route("GET /api/guilds/{guild}/chests/{chest}", func(r *http.Request) (any, error) {
+		return api.GetGuildChest(r.Context(), r.PathValue("guild"), r.PathValue("chest"))
+	})
+	route("POST /api/guilds/{guild}/chests/{chest}/spells", func(r *http.Request) (any, error) {
+		var body struct {
+			SpellID string `json:"spell_id"`
+		}
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return nil, api.AddChestSpell(r.Context(), r.PathValue("guild"), r.PathValue("chest"), body.SpellID)
+	})
+	route("DELETE /api/guilds/{guild}/chests/{chest}/spells/{spell}", func(r *http.Request) (any, error) {
+		return nil, api.RemoveChestSpell(r.Context(), r.PathValue("guild"), r.PathValue("chest"), r.PathValue("spell"))
+	})
+
+	// Invites
+	route("GET /api/invites", func(r *http.Request) (any, error) {
+		return api.ListInvites(r.Context())
+	})
+	route("GET /api/invites/progress", func(r *http.Request) (any, error) {
+		return api.GetInviteProgress(r.Context())
+	})
+
+	// Workshop
+	type projectBody struct {
+		Name    string `json:"name"`
+		Insight string `json:"insight"`
+		URL     string `json:"url"`
+		Kind    string `json:"kind"`
+		Body    string `json:"body"`
+	}
+	route("GET /api/workshop", func(r *http.Request) (any, error) {
+		return api.ListWorkshopProjects(r.Context())
+	})
+	route("POST /api/workshop", func(r *http.Request) (any, error) {
+		var body projectBody
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return api.CreateWorkshopProject(r.Context(), body.Name, body.Insight)
+	})
+	route("PUT /api/workshop/{id}", func(r *http.Request) (any, error) {
+		var body projectBody
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return nil, api.UpdateWorkshopProject(r.Context(), r.PathValue("id"), body.Name, body.Insight)
+	})
+	route("PATCH /api/workshop/{id}", func(r *http.Request) (any, error) {
+		var body projectBody
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return nil, api.SetWorkshopProjectURL(r.Context(), r.PathValue("id"), body.URL)
+	})
+	route("DELETE /api/workshop/{id}", func(r *http.Request) (any, error) {
+		return nil, api.DeleteWorkshopProject(r.Context(), r.PathValue("id"))
+	})
+	route("POST /api/workshop/{id}/restore", func(r *http.Request) (any, error) {
+		return api.RestoreWorkshopProject(r.Context(), r.PathValue("id"))
+	})
+	route("GET /api/workshop/{id}/updates", func(r *http.Request) (any, error) {
+		return api.ListProjectUpdates(r.Context(), r.PathValue("id"))
+	})
+	route("POST /api/workshop/{id}/updates", func(r *http.Request) (any, error) {
+		var body projectBody
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		return api.CreateProjectUpdate(r.Context(), r.PathValue("id"), body.Kind, body.Body)
+	})
+
+	// Watching and notifications
+	route("PUT /api/subscriptions/{type}/{id}", func(r *http.Request) (any, error) {
+		return api.Watch(r.Context(), r.PathValue("type"), r.PathValue("id"))
+	})
+	route("DELETE /api/subscriptions/{type}/{id}", func(r *http.Request) (any, error) {
+		return nil, api.Unwatch(r.Context(), r.PathValue("type"), r.PathValue("id"))
+	})
+	route("POST /api/subscriptions/{type}/{id}/read", func(r *http.Request) (any, error) {
+		return nil, api.MarkSubscriptionRead(r.Context(), r.PathValue("type"), r.PathValue("id"))
+	})
+	route("GET /api/notifications", func(r *http.Request) (any, error) {
+		return api.ListNotifications(r.Context(), intParam(r, "limit"))
+	})
+	route("POST /api/notifications/read", func(r *http.Request) (any, error) {
+		return nil, api.MarkAllNotificationsRead(r.Context())
+	})
+	route("POST /api/notifications/{id}/read", func(r *http.Request) (any, error) {
+		return nil, api.MarkNotificationRead(r.Context(), r.PathValue("id"))
+	})
+	route("GET /api/telemetry", func(r *http.Request) (any, error) {
+		return api.GetTelemetry(r.Context())
+	})
+
+	// Moderation
+	route("POST /api/reports", func(r *http.Request) (any, error) {
+		var body domain.Report
+		if err := decode(r, &body); err != nil {
+			return nil, err
+		}
+		switch body.TargetType {
+		case domain.ReportTargetMessage:
+			return nil, api.ReportMessage(r.Context(), body.TargetID, body.Reason)
+		case domain.ReportTargetSpell:
+			return nil, api.ReportSpell(r.Context(), body.TargetID, body.Reason)
+		}
+		return nil, &client.HTTPError{StatusCode: http.StatusBadRequest, Message: "can't report a " + body.TargetType}
+	})
+	route("GET /api/me/blocks", func(r *http.Request) (any, error) {
+		return api.ListBlocked(r.Context())
+	})
+	route("POST /api/magicians/{login}/block", func(r *http.Request) (any, error) {
+		return nil, api.BlockMagician(r.Context(), r.PathValue("login"))
+	})
+	route("DELETE /api/magicians/{login}/block", func(r *http.Request) (any, error) {
+		return nil, api.UnblockMagician(r.Context(), r.PathValue("login"))
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &client.HTTPError{StatusCode: http.StatusNotFound, Message: "route " + r.URL.Path + " not found"})
+	})
+	return mux
+}
+
+// decode reads r's JSON body into v. A body that won't parse is the
+// caller's fault, as on the real API.
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return &client.HTTPError{StatusCode: http.StatusBadRequest, Message: "invalid JSON body: " + err.Error()}
+	}
+	return nil
+}
+
+// intParam returns the integer query parameter name, or 0.
+func intParam(r *http.Request, name string) int {
+	n, _ := strconv.Atoi(r.URL.Query().Get(name)) //nolint:errcheck // absent or bad means 0
+	return n
+}
+
+// listParam splits the comma-separated query parameter name.
+func listParam(r *http.Request, name string) []string {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// timeParam parses the RFC 3339 query parameter name; absent is the zero
+// time.
+func timeParam(r *http.Request, name string) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, &client.HTTPError{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("invalid %s: %v", name, err)}
+	}
+	return t, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v) //nolint:errcheck // the client has gone
+}
+
+// writeError sends err the way the API does: an HTTPError keeps its status,
+// message, field errors and Retry-After, and anything else is a 500.
+func writeError(w http.ResponseWriter, err error) {
+	body := struct {
+		Error  string              `json:"error"`
+		Errors []client.FieldError `json:"errors,omitempty"`
+	}{Error: err.Error()}
+	status := http.StatusInternalServerError
+	var httpErr *client.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.StatusCode
+		body.Error = httpErr.Message
+		body.Errors = httpErr.Fields
+		if httpErr.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(httpErr.RetryAfter.Round(time.Second)/time.Second)))
+		}
+	}
+	writeJSON(w, status, body)
+}
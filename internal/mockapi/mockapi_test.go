@@ -0,0 +1,260 @@
+package mockapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// newDemo serves a fresh Demo and returns a client signed in to it along
+// with the Fake behind it.
+func newDemo(t *testing.T) (*client.Client, *clienttest.Fake) {
+	t.Helper()
+	f := Demo()
+	srv := httptest.NewServer(Handler(f))
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL, "demo"), f
+}
+
+func TestDemoSignsIn(t *testing.T) {
+	c, _ := newDemo(t)
+	ctx := context.Background()
+	me, err := c.GetMe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.GitHubLogin != DemoLogin {
+		t.Errorf("login = %q, want %q", me.GitHubLogin, DemoLogin)
+	}
+	if err := c.Health(ctx); err != nil {
+		t.Errorf("health: %v", err)
+	}
+	info, err := c.GetServerInfo(ctx)
+	if err != nil || info.APIVersion != client.APIVersion {
+		t.Errorf("server info = %+v, %v", info, err)
+	}
+}
+
+func TestHandlerWantsToken(t *testing.T) {
+	srv := httptest.NewServer(Handler(Demo()))
+	defer srv.Close()
+	_, err := client.New(srv.URL, "").GetMe(context.Background())
+	if !client.IsUnauthorized(err) {
+		t.Errorf("err = %v, want unauthorized", err)
+	}
+}
+
+func TestHandlerRoomRoundTrip(t *testing.T) {
+	c, f := newDemo(t)
+	ctx := context.Background()
+	sent, err := c.SendRoomMessage(ctx, domain.HallSlug, "hello from a test", map[string]string{"reply_to": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.SenderLogin != DemoLogin {
+		t.Errorf("sender = %q", sent.SenderLogin)
+	}
+	msgs, err := c.GetRoomMessages(ctx, domain.HallSlug, time.Time{}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 3 || msgs[2].Body != "hello from a test" {
+		t.Fatalf("newest messages = %+v", msgs)
+	}
+	older, err := c.GetRoomMessages(ctx, domain.HallSlug, msgs[0].CreatedAt, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range older {
+		if !m.CreatedAt.Before(msgs[0].CreatedAt) {
+			t.Errorf("message %q isn't before the cursor", m.Body)
+		}
+	}
+	args := f.Calls()[len(f.Calls())-3].Args
+	if md := args[2].(map[string]string); md["reply_to"] != "x" {
+		t.Errorf("metadata = %v", md)
+	}
+}
+
+func TestHandlerThreads(t *testing.T) {
+	c, _ := newDemo(t)
+	ctx := context.Background()
+	th, err := c.StartThread(ctx, "grace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SendMessage(ctx, th.ID.String(), "hi grace"); err != nil {
+		t.Fatal(err)
+	}
+	byOffset, err := c.GetMessages(ctx, th.ID.String(), 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTime, err := c.GetMessagesBefore(ctx, th.ID.String(), time.Time{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byOffset) != 1 || len(byTime) != 1 || byTime[0].Body != "hi grace" {
+		t.Errorf("messages = %+v / %+v", byOffset, byTime)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	c, f := newDemo(t)
+	ctx := context.Background()
+	if _, err := c.GetMagician(ctx, "nobody"); !client.IsNotFound(err) {
+		t.Errorf("missing magician: %v, want not found", err)
+	}
+	_, err := c.CreateRoom(ctx, client.CreateRoomRequest{Slug: "prompt-craft"})
+	if !client.IsConflict(err) {
+		t.Fatalf("taken slug: %v, want conflict", err)
+	}
+	if fields := client.FieldErrors(err); len(fields) != 1 || fields[0].Field != "slug" {
+		t.Errorf("field errors = %+v", fields)
+	}
+	f.Fail = map[string]error{"ListRooms": &client.HTTPError{StatusCode: 429, Message: "slow down", RetryAfter: 3 * time.Second}}
+	_, err = c.ListRooms(ctx)
+	if !client.IsRateLimited(err) {
+		t.Fatalf("err = %v, want rate limited", err)
+	}
+	var httpErr *client.HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter != 3*time.Second {
+		t.Errorf("retry after = %v", httpErr.RetryAfter)
+	}
+}
+
+func TestHandlerUnknownRoute(t *testing.T) {
+	srv := httptest.NewServer(Handler(Demo()))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/api/nope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d", resp.StatusCode)
+	}
+}
+
+// TestHandlerServesEveryMethod calls each client.API method once against
+// the demo and fails on any that reaches no route.
+func TestHandlerServesEveryMethod(t *testing.T) {
+	c, f := newDemo(t)
+	ctx := context.Background()
+	spell := f.Spells[0].ID.String()
+	thread := f.Threads[0].ID.String()
+	msg := f.RoomMessages[domain.HallSlug][0].ID.String()
+	project := f.Projects[0].ID.String()
+	calls := map[string]func() error{
+		"UpdateProfile": func() error {
+			city := "Porto"
+			_, err := c.UpdateProfile(ctx, domain.ProfileUpdate{City: &city})
+			return err
+		},
+		"GetForgeStats":   func() error { _, err := c.GetForgeStats(ctx); return err },
+		"GetForgeHistory": func() error { _, err := c.GetForgeHistory(ctx); return err },
+		"ListSpells":      func() error { _, err := c.ListSpells(ctx, []string{"testing"}, "top", 10, 0); return err },
+		"TagStats":        func() error { _, err := c.TagStats(ctx); return err },
+		"SearchSpells":    func() error { _, err := c.SearchSpells(ctx, "debug"); return err },
+		"GetSpell":        func() error { _, err := c.GetSpell(ctx, spell); return err },
+		"GetRandomSpell":  func() error { _, err := c.GetRandomSpell(ctx, 0, "2026-10-16"); return err },
+		"CreateSpell": func() error {
+			_, err := c.CreateSpell(ctx, client.CreateSpellRequest{Text: strings.Repeat("x", 30), Tag: "general"})
+			return err
+		},
+		"PreviewSpell": func() error {
+			_, err := c.PreviewSpell(ctx, client.CreateSpellRequest{Text: "x", Tag: "general"})
+			return err
+		},
+		"SetSpellContext": func() error { _, err := c.SetSpellContext(ctx, spell, "ctx"); return err },
+		"UpdateSpell": func() error {
+			_, err := c.UpdateSpell(ctx, spell, client.CreateSpellRequest{Text: strings.Repeat("y", 30), Tag: "general"})
+			return err
+		},
+		"UpvoteSpell":         func() error { return c.UpvoteSpell(ctx, spell) },
+		"CastSpell":           func() error { _, err := c.CastSpell(ctx, spell); return err },
+		"ForkSpell":           func() error { _, err := c.ForkSpell(ctx, spell); return err },
+		"RequestReevaluation": func() error { _, err := c.RequestReevaluation(ctx, spell); return err },
+		"SaveSpell":           func() error { return c.SaveSpell(ctx, spell) },
+		"UnsaveSpell":         func() error { return c.UnsaveSpell(ctx, spell) },
+		"ListSavedSpells":     func() error { _, err := c.ListSavedSpells(ctx, 10, 0); return err },
+		"ListWeapons":         func() error { _, err := c.ListWeapons(ctx, []string{"cli"}, 10, 0); return err },
+		"GetWeapon":           func() error { _, err := c.GetWeapon(ctx, f.Weapons[0].ID.String()); return err },
+		"SearchWeapons":       func() error { _, err := c.SearchWeapons(ctx, "jq"); return err },
+		"SaveWeapon":          func() error { return c.SaveWeapon(ctx, f.Weapons[0].ID.String()) },
+		"ListMagicians":       func() error { _, err := c.ListMagicians(ctx, 10, 0); return err },
+		"GetMagician":         func() error { _, err := c.GetMagician(ctx, "ada"); return err },
+		"Workshop":            func() error { _, err := c.GetMagicianWorkshop(ctx, "linus"); return err },
+		"MagicianSpells":      func() error { _, err := c.ListMagicianSpells(ctx, "ada", 5); return err },
+		"GetLeaderboard":      func() error { _, err := c.GetLeaderboard(ctx, "amarok", "", 10, 0); return err },
+		"LeaderboardRank":     func() error { _, err := c.GetLeaderboardRank(ctx, "ada"); return err },
+		"GuildLeaderboard":    func() error { _, err := c.GetGuildLeaderboard(ctx); return err },
+		"GetPresence":         func() error { _, err := c.GetPresence(ctx, []string{"ada", "alan"}); return err },
+		"Heartbeat":           func() error { return c.Heartbeat(ctx, domain.PresenceAway) },
+		"Follow":              func() error { return c.Follow(ctx, "ken") },
+		"Unfollow":            func() error { return c.Unfollow(ctx, "ken") },
+		"GetStream":           func() error { _, err := c.GetStream(ctx, true, 10, 0); return err },
+		"ListThreads":         func() error { _, err := c.ListThreads(ctx); return err },
+		"StartGroupThread":    func() error { _, err := c.StartGroupThread(ctx, []string{"ada", "alan"}); return err },
+		"MarkThreadRead":      func() error { return c.MarkThreadRead(ctx, thread) },
+		"ListRooms":           func() error { _, err := c.ListRooms(ctx); return err },
+		"JoinRoom":            func() error { return c.JoinRoom(ctx, "prompt-craft") },
+		"CreateRoom":          func() error { _, err := c.CreateRoom(ctx, client.CreateRoomRequest{Slug: "new-room"}); return err },
+		"SetRoomTopic":        func() error { _, err := c.SetRoomTopic(ctx, "new-room", "hi"); return err },
+		"ArchiveRoom":         func() error { return c.ArchiveRoom(ctx, "new-room") },
+		"GetRoomPresence":     func() error { _, err := c.GetRoomPresence(ctx, domain.HallSlug); return err },
+		"AddReaction":         func() error { return c.AddReaction(ctx, domain.HallSlug, msg, "🔥") },
+		"ReactionCounts":      func() error { _, err := c.GetReactionCounts(ctx, domain.HallSlug, []string{msg}); return err },
+		"ListGuildChests":     func() error { _, err := c.ListGuildChests(ctx, "amarok"); return err },
+		"ListInvites":         func() error { _, err := c.ListInvites(ctx); return err },
+		"InviteProgress":      func() error { _, err := c.GetInviteProgress(ctx); return err },
+		"ListProjects":        func() error { _, err := c.ListWorkshopProjects(ctx); return err },
+		"CreateProject":       func() error { _, err := c.CreateWorkshopProject(ctx, "p", "i"); return err },
+		"UpdateProject":       func() error { return c.UpdateWorkshopProject(ctx, project, "p2", "i2") },
+		"SetProjectURL":       func() error { return c.SetWorkshopProjectURL(ctx, project, "https://example.com") },
+		"ProjectUpdates":      func() error { _, err := c.ListProjectUpdates(ctx, project); return err },
+		"CreateUpdate":        func() error { _, err := c.CreateProjectUpdate(ctx, project, "update", "progress"); return err },
+		"DeleteProject":       func() error { return c.DeleteWorkshopProject(ctx, project) },
+		"RestoreProject":      func() error { _, err := c.RestoreWorkshopProject(ctx, project); return err },
+		"Watch":               func() error { _, err := c.Watch(ctx, domain.WatchTargetSpell, spell); return err },
+		"MarkSubRead":         func() error { return c.MarkSubscriptionRead(ctx, domain.WatchTargetSpell, spell) },
+		"ListSubs":            func() error { _, err := c.ListSubscriptions(ctx); return err },
+		"Unwatch":             func() error { return c.Unwatch(ctx, domain.WatchTargetSpell, spell) },
+		"Notifications":       func() error { _, err := c.ListNotifications(ctx, 10); return err },
+		"MarkNotification":    func() error { return c.MarkNotificationRead(ctx, f.Notifications[0].ID.String()) },
+		"MarkAllRead":         func() error { return c.MarkAllNotificationsRead(ctx) },
+		"GetTelemetry":        func() error { _, err := c.GetTelemetry(ctx); return err },
+		"ReportMessage":       func() error { return c.ReportMessage(ctx, msg, domain.ReportSpam) },
+		"ReportSpell":         func() error { return c.ReportSpell(ctx, spell, domain.ReportAbuse) },
+		"BlockMagician":       func() error { return c.BlockMagician(ctx, "ken") },
+		"ListBlocked":         func() error { _, err := c.ListBlocked(ctx); return err },
+		"UnblockMagician":     func() error { return c.UnblockMagician(ctx, "ken") },
+		"ShareDraft": func() error {
+			d, err := c.ShareSpellDraft(ctx, client.CreateSpellRequest{Text: "draft", Tag: "general"})
+			if err != nil {
+				return err
+			}
+			if _, err := c.UpdateSpellDraft(ctx, d.ID.String(), client.CreateSpellRequest{Text: "draft 2", Tag: "general"}); err != nil {
+				return err
+			}
+			if _, err := c.GetSpellDraft(ctx, d.ID.String()); err != nil {
+				return err
+			}
+			_, err = c.SuggestDraftEdit(ctx, d.ID.String(), "text", "draft", "sketch", "")
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); err != nil && strings.Contains(err.Error(), "route ") {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
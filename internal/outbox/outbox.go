@@ -0,0 +1,270 @@
+// Package outbox persists messages that haven't reached the server yet to
+// ~/.grimora/outbox.json, so a dropped connection or a restart doesn't lose
+// them. A message stays in the outbox from the moment it's sent until the
+// API accepts it or the magician discards it.
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/appdir"
+)
+
+// Kinds of message the outbox holds.
+const (
+	KindRoom = "room" // Target is a room slug
+	KindDM   = "dm"   // Target is a thread ID
+)
+
+// Retry backoff: the first retry waits MinBackoff, each one after that
+// twice as long, up to MaxBackoff.
+const (
+	MinBackoff = 2 * time.Second
+	MaxBackoff = 2 * time.Minute
+)
+
+// Message is one unsent message.
+type Message struct {
+	ID        string            `json:"id"` // local; the server assigns the real one
+	Kind      string            `json:"kind"`
+	Target    string            `json:"target"`
+	Login     string            `json:"login,omitempty"` // the other magician, for DMs
+	Body      string            `json:"body"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+
+	Attempts int       `json:"attempts,omitempty"`
+	Err      string    `json:"error,omitempty"`   // why the last attempt failed
+	RetryAt  time.Time `json:"retry_at,omitzero"` // next automatic attempt; zero waits for a manual retry
+	Sending  bool      `json:"-"`                 // an attempt is in flight
+}
+
+// Failed reports whether the last attempt failed and no other is running.
+func (m Message) Failed() bool {
+	return !m.Sending && m.Err != ""
+}
+
+// Backoff returns how long to wait before retrying after attempts failures.
+func Backoff(attempts int) time.Duration {
+	d := MinBackoff
+	for i := 1; i < attempts && d < MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, MaxBackoff)
+}
+
+// Store holds the outbox in memory and, when it has a path, on disk. Changes
+// only touch memory; Save writes them out. A nil *Store is a valid, empty
+// store that drops everything added to it.
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	items []Message // oldest first
+	dirty bool
+}
+
+// Path returns ~/.grimora/outbox.json.
+func Path() (string, error) {
+	return appdir.Path("outbox.json")
+}
+
+// New returns an empty store that is never saved.
+func New() *Store {
+	return &Store{}
+}
+
+// Open loads the outbox file at path. A missing file yields an empty store,
+// and a corrupt one an empty store along with the parse error, so callers
+// can warn and carry on. Failed messages keep the retry they were saved
+// with, so those waiting for a manual retry still wait. A message that was
+// in flight when grimora closed may have been posted, so it comes back
+// failed and waits too.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("read outbox: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.items); err != nil {
+		s.items = nil
+		return s, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i := range s.items {
+		if s.items[i].Err == "" {
+			s.items[i].Err = "may not have been sent before grimora closed"
+			s.items[i].RetryAt = time.Time{}
+		}
+	}
+	return s, nil
+}
+
+// Add queues m as being sent and returns it with its ID and time filled in.
+func (s *Store) Add(m Message) Message {
+	if m.ID == "" {
+		m.ID = uuid.NewString()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	m.Sending = true
+	if s == nil {
+		return m
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = append(s.items, m)
+	s.dirty = true
+	return m
+}
+
+// find returns the index of the message with id, or -1. s.mu must be held.
+func (s *Store) find(id string) int {
+	return slices.IndexFunc(s.items, func(m Message) bool { return m.ID == id })
+}
+
+// Send marks the message with id as in flight again and returns it, or
+// false if it's gone or already being sent.
+func (s *Store) Send(id string) (Message, bool) {
+	if s == nil {
+		return Message{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(id)
+	if i < 0 || s.items[i].Sending {
+		return Message{}, false
+	}
+	s.items[i].Sending = true
+	s.items[i].RetryAt = time.Time{}
+	return s.items[i], true
+}
+
+// Fail records a failed attempt on the message with id. With retry it is
+// tried again after Backoff; without, it waits for Send or Remove.
+func (s *Store) Fail(id, reason string, retry bool, now time.Time) (Message, bool) {
+	if s == nil {
+		return Message{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.find(id)
+	if i < 0 {
+		return Message{}, false
+	}
+	m := &s.items[i]
+	m.Sending = false
+	m.Attempts++
+	m.Err = reason
+	m.RetryAt = time.Time{}
+	if retry {
+		m.RetryAt = now.Add(Backoff(m.Attempts))
+	}
+	s.dirty = true
+	return *m, true
+}
+
+// Remove drops the message with id: it was delivered or discarded.
+func (s *Store) Remove(id string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.find(id); i >= 0 {
+		s.items = slices.Delete(s.items, i, i+1)
+		s.dirty = true
+	}
+}
+
+// For returns the messages queued for kind and target, oldest first.
+func (s *Store) For(kind, target string) []Message {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Message
+	for _, m := range s.items {
+		if m.Kind == kind && m.Target == target {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Due returns the failed messages whose automatic retry is at or before now.
+func (s *Store) Due(now time.Time) []Message {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Message
+	for _, m := range s.items {
+		if m.Failed() && !m.RetryAt.IsZero() && !m.RetryAt.After(now) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Len returns how many messages are queued.
+func (s *Store) Len() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// Save writes the outbox to disk if anything changed since the last save.
+// Stores from New, without a path, are never written. The file is replaced
+// atomically, and removed once the outbox is empty.
+func (s *Store) Save() error {
+	if s == nil || s.path == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.dirty {
+		return nil
+	}
+	if len(s.items) == 0 {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove outbox: %w", err)
+		}
+		s.dirty = false
+		return nil
+	}
+	data, err := json.MarshalIndent(s.items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode outbox: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("create outbox dir: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write outbox: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("write outbox: %w", err)
+	}
+	s.dirty = false
+	return nil
+}
@@ -0,0 +1,187 @@
+package outbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpenMissingIsEmpty(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "nope.json"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if n := s.Len(); n != 0 {
+		t.Errorf("Len() = %d, want 0", n)
+	}
+}
+
+func TestAddFailRetry(t *testing.T) {
+	s := New()
+	m := s.Add(Message{Kind: KindRoom, Target: "the-hall", Body: "hello"})
+	if m.ID == "" || m.CreatedAt.IsZero() || !m.Sending {
+		t.Fatalf("Add() = %+v, want ID, time and Sending set", m)
+	}
+	if _, ok := s.Send(m.ID); ok {
+		t.Error("Send() of an in-flight message should refuse")
+	}
+
+	now := time.Now()
+	got, ok := s.Fail(m.ID, "offline", true, now)
+	if !ok || !got.Failed() || got.Attempts != 1 {
+		t.Fatalf("Fail() = %+v, %v", got, ok)
+	}
+	if want := now.Add(MinBackoff); !got.RetryAt.Equal(want) {
+		t.Errorf("RetryAt = %v, want %v", got.RetryAt, want)
+	}
+	if due := s.Due(now); len(due) != 0 {
+		t.Errorf("Due(now) = %d messages, want none before the backoff", len(due))
+	}
+	if due := s.Due(now.Add(MinBackoff)); len(due) != 1 {
+		t.Fatalf("Due(after backoff) = %d messages, want 1", len(due))
+	}
+
+	if _, ok := s.Send(m.ID); !ok {
+		t.Fatal("Send() of a failed message should succeed")
+	}
+	if due := s.Due(now.Add(time.Hour)); len(due) != 0 {
+		t.Errorf("Due() while sending = %d messages, want none", len(due))
+	}
+	s.Remove(m.ID)
+	if n := s.Len(); n != 0 {
+		t.Errorf("Len() after Remove = %d", n)
+	}
+}
+
+func TestFailWithoutRetryWaits(t *testing.T) {
+	s := New()
+	m := s.Add(Message{Kind: KindDM, Target: "t1", Body: "hi"})
+	got, _ := s.Fail(m.ID, "too long", false, time.Now())
+	if !got.RetryAt.IsZero() {
+		t.Errorf("RetryAt = %v, want zero", got.RetryAt)
+	}
+	if due := s.Due(time.Now().Add(time.Hour)); len(due) != 0 {
+		t.Errorf("Due() = %d messages, want none", len(due))
+	}
+}
+
+func TestForFiltersByTarget(t *testing.T) {
+	s := New()
+	s.Add(Message{Kind: KindRoom, Target: "the-hall", Body: "one"})
+	s.Add(Message{Kind: KindRoom, Target: "amarok", Body: "two"})
+	s.Add(Message{Kind: KindDM, Target: "the-hall", Body: "three"})
+	s.Add(Message{Kind: KindRoom, Target: "the-hall", Body: "four"})
+
+	got := s.For(KindRoom, "the-hall")
+	if len(got) != 2 || got[0].Body != "one" || got[1].Body != "four" {
+		t.Errorf("For() = %+v", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 2 * time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{7, 2 * time.Minute},
+		{50, 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSaveAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "outbox.json")
+	s, _ := Open(path)
+	sending := s.Add(Message{Kind: KindRoom, Target: "the-hall", Body: "in flight"})
+	failed := s.Add(Message{Kind: KindDM, Target: "t1", Login: "zara", Body: "failed",
+		Metadata: map[string]string{"reply_to": "m1"}})
+	s.Fail(failed.ID, "too long", false, time.Now())
+	offline := s.Add(Message{Kind: KindRoom, Target: "the-hall", Body: "offline"})
+	s.Fail(offline.ID, "connection refused", true, time.Now())
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	s2, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	due := s2.Due(time.Now().Add(time.Minute))
+	if len(due) != 1 || due[0].ID != offline.ID {
+		t.Fatalf("Due() after reopen = %+v, want only the message that was retrying", due)
+	}
+	room := s2.For(KindRoom, "the-hall")
+	if len(room) != 2 || room[0].ID != sending.ID || !room[0].Failed() || !room[0].RetryAt.IsZero() {
+		t.Errorf("in-flight message reopened as %+v, want failed and waiting for a manual retry", room[0])
+	}
+	dm := s2.For(KindDM, "t1")
+	if len(dm) != 1 || dm[0].Login != "zara" || dm[0].Metadata["reply_to"] != "m1" || dm[0].Err != "too long" || !dm[0].RetryAt.IsZero() {
+		t.Errorf("failed message reopened as %+v", dm)
+	}
+}
+
+func TestSaveRemovesEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	s, _ := Open(path)
+	m := s.Add(Message{Kind: KindRoom, Target: "the-hall", Body: "x"})
+	s.Save() //nolint:errcheck
+	s.Remove(m.ID)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("outbox file still there: %v", err)
+	}
+}
+
+func TestNewIsNeverSaved(t *testing.T) {
+	s := New()
+	s.Add(Message{Kind: KindRoom, Target: "the-hall", Body: "x"})
+	if err := s.Save(); err != nil {
+		t.Errorf("Save() error: %v", err)
+	}
+}
+
+func TestOpenCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(path)
+	if err == nil {
+		t.Error("expected parse error")
+	}
+	if s == nil || s.Len() != 0 {
+		t.Error("expected an empty, usable store")
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	m := s.Add(Message{Body: "x"})
+	if m.ID == "" {
+		t.Error("Add() on nil store should still fill in the ID")
+	}
+	s.Remove(m.ID)
+	if _, ok := s.Send(m.ID); ok {
+		t.Error("Send() on nil store should refuse")
+	}
+	if _, ok := s.Fail(m.ID, "x", true, time.Now()); ok {
+		t.Error("Fail() on nil store should refuse")
+	}
+	if s.For(KindRoom, "x") != nil || s.Due(time.Now()) != nil || s.Len() != 0 {
+		t.Error("nil store should be empty")
+	}
+	if err := s.Save(); err != nil {
+		t.Errorf("Save() on nil store: %v", err)
+	}
+}
@@ -0,0 +1,289 @@
+// Package publish copies spells out to GitHub, as a gist or as a file in a
+// repository, with the magician's own GitHub token.
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/export"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// DefaultAPIURL is GitHub's REST API.
+const DefaultAPIURL = "https://api.github.com"
+
+// ErrNoToken means neither token variable is set.
+var ErrNoToken = errors.New("no GitHub token — set GRIMORA_GITHUB_TOKEN (or GITHUB_TOKEN) to a token with the gist or repo scope")
+
+// Token returns the GitHub token to publish with: GRIMORA_GITHUB_TOKEN,
+// else GITHUB_TOKEN. It is never written to disk.
+func Token() string {
+	if tok := os.Getenv("GRIMORA_GITHUB_TOKEN"); tok != "" {
+		return tok
+	}
+	return os.Getenv("GITHUB_TOKEN")
+}
+
+// Repo is a place in a GitHub repository to commit spells into.
+type Repo struct {
+	Owner string
+	Name  string
+	Dir   string // directory inside the repository; empty is the root
+}
+
+// ParseRepo parses "owner/name" or "owner/name/dir/...".
+func ParseRepo(s string) (Repo, error) {
+	parts := strings.SplitN(strings.Trim(s, "/"), "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return Repo{}, fmt.Errorf("want owner/name or owner/name/dir, got %q", s)
+	}
+	r := Repo{Owner: parts[0], Name: parts[1]}
+	if len(parts) == 3 {
+		r.Dir = strings.Trim(parts[2], "/")
+	}
+	return r, nil
+}
+
+func (r Repo) String() string {
+	return path.Join(r.Owner, r.Name, r.Dir)
+}
+
+// GitHub publishes spells through the GitHub API.
+type GitHub struct {
+	APIURL string
+	Token  string
+	HTTP   *http.Client
+}
+
+// New returns a GitHub publisher for token against DefaultAPIURL.
+func New(token string) *GitHub {
+	return &GitHub{
+		APIURL: DefaultAPIURL,
+		Token:  token,
+		HTTP:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Gist publishes s as a gist holding <slug>.md and returns its URL. Secret
+// gists are only reachable by their URL.
+func (g *GitHub) Gist(ctx context.Context, s domain.Spell, public bool) (string, error) {
+	content, err := spellContent(s)
+	if err != nil {
+		return "", err
+	}
+	body := map[string]any{
+		"description": export.SpellTitle(s) + " — a Grimora spell",
+		"public":      public,
+		"files": map[string]any{
+			export.Slug(s) + ".md": map[string]string{"content": content},
+		},
+	}
+	var out struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := g.do(ctx, http.MethodPost, "/gists", body, &out); err != nil {
+		return "", fmt.Errorf("create gist: %w", err)
+	}
+	return out.HTMLURL, nil
+}
+
+// Commit writes s to <dir>/<slug>.md in repo on its default branch,
+// replacing an earlier copy, and returns the file's URL. When that name
+// already holds a different spell, s goes to <dir>/<slug>-<id>.md instead,
+// as it does for `grimora spells pull`.
+func (g *GitHub) Commit(ctx context.Context, repo Repo, s domain.Spell) (string, error) {
+	content, err := spellContent(s)
+	if err != nil {
+		return "", err
+	}
+	file := path.Join(repo.Dir, export.Slug(s)+".md")
+	existing, err := g.lookup(ctx, repo, file)
+	if err != nil {
+		return "", err
+	}
+	if existing.SHA != "" && !existing.holds(s) {
+		file = path.Join(repo.Dir, export.UniqueSlug(s)+".md")
+		if existing, err = g.lookup(ctx, repo, file); err != nil {
+			return "", err
+		}
+	}
+	endpoint := contentsEndpoint(repo, file)
+	body := map[string]string{
+		"message": "Add spell: " + export.SpellTitle(s),
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+	}
+	if existing.SHA != "" {
+		body["message"] = "Update spell: " + export.SpellTitle(s)
+		body["sha"] = existing.SHA
+	}
+	var out struct {
+		Content struct {
+			HTMLURL string `json:"html_url"`
+		} `json:"content"`
+	}
+	if err := g.do(ctx, http.MethodPut, endpoint, body, &out); err != nil {
+		return "", fmt.Errorf("commit %s to %s/%s: %w", file, repo.Owner, repo.Name, err)
+	}
+	return out.Content.HTMLURL, nil
+}
+
+// repoFile is a file in a repository as the contents API returns it. SHA is
+// empty when there's no such file.
+type repoFile struct {
+	SHA     string `json:"sha"`
+	Content string `json:"content"` // base64, wrapped across lines
+}
+
+// holds reports whether the file is s's spell file.
+func (f repoFile) holds(s domain.Spell) bool {
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(f.Content, "\n", ""))
+	return err == nil && export.HoldsSpell(string(data), s)
+}
+
+// lookup fetches file from repo. Replacing a file needs the blob it
+// replaces.
+func (g *GitHub) lookup(ctx context.Context, repo Repo, file string) (repoFile, error) {
+	var f repoFile
+	if err := g.do(ctx, http.MethodGet, contentsEndpoint(repo, file), nil, &f); err != nil && !isStatus(err, http.StatusNotFound) {
+		return repoFile{}, fmt.Errorf("look up %s: %w", file, err)
+	}
+	return f, nil
+}
+
+func contentsEndpoint(repo Repo, file string) string {
+	return "/repos/" + url.PathEscape(repo.Owner) + "/" + url.PathEscape(repo.Name) + "/contents/" + escapePath(file)
+}
+
+// Spell commits s into repo, or publishes it as a gist when repo is the
+// zero Repo, and returns where it landed.
+func (g *GitHub) Spell(ctx context.Context, repo Repo, public bool, s domain.Spell) (string, error) {
+	if repo == (Repo{}) {
+		return g.Gist(ctx, s, public)
+	}
+	return g.Commit(ctx, repo, s)
+}
+
+// WithLink returns spell context that mentions link, leaving it as it is
+// when it already does.
+func WithLink(spellContext, link string) string {
+	if link == "" || strings.Contains(spellContext, link) {
+		return spellContext
+	}
+	line := "Published: " + link
+	if spellContext = strings.TrimRight(spellContext, "\n "); spellContext == "" {
+		return line
+	}
+	return spellContext + "\n" + line
+}
+
+// Link notes link in the spell's context so readers in the Grimoire can
+// find the published copy. Only a spell's author may edit it, so other
+// magicians' spells are published without the note.
+func Link(ctx context.Context, c client.API, s domain.Spell, link string) error {
+	updated := WithLink(s.Context, link)
+	if updated == s.Context {
+		return nil
+	}
+	if _, err := c.SetSpellContext(ctx, s.ID.String(), updated); err != nil && !client.IsForbidden(err) {
+		return err
+	}
+	return nil
+}
+
+// spellContent is the file a spell is published as.
+func spellContent(s domain.Spell) (string, error) {
+	var sb strings.Builder
+	if err := export.SpellFile(&sb, s); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// escapePath escapes each segment of a slash-separated path.
+func escapePath(p string) string {
+	segs := strings.Split(p, "/")
+	for i, s := range segs {
+		segs[i] = url.PathEscape(s)
+	}
+	return strings.Join(segs, "/")
+}
+
+// statusError is a non-2xx answer from GitHub.
+type statusError struct {
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	switch e.code {
+	case http.StatusUnauthorized:
+		return "GitHub rejected the token (HTTP 401)"
+	case http.StatusNotFound:
+		// GitHub hides repositories a token can't see behind 404s.
+		return "not found, or the token can't see it (HTTP 404)"
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.code, e.message)
+}
+
+func isStatus(err error, code int) bool {
+	var se *statusError
+	return errors.As(err, &se) && se.code == code
+}
+
+func (g *GitHub) do(ctx context.Context, method, endpoint string, body, out any) error {
+	if g.Token == "" {
+		return ErrNoToken
+	}
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, g.APIURL+endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+g.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := g.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode >= 400 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20)) //nolint:errcheck // best-effort read for error message
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		msg := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			msg = apiErr.Message
+		}
+		return &statusError{code: resp.StatusCode, message: msg}
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return nil
+}
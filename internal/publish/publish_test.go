@@ -0,0 +1,150 @@
+package publish
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func testSpell() domain.Spell {
+	return domain.Spell{ID: uuid.New(), Text: "Review this diff for race conditions", Tag: "debugging"}
+}
+
+func testGitHub(t *testing.T, h http.HandlerFunc) *GitHub {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	g := New("ghp_test")
+	g.APIURL = srv.URL
+	return g
+}
+
+func TestGist(t *testing.T) {
+	g := testGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/gists" || r.Header.Get("Authorization") != "Bearer ghp_test" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Public bool                         `json:"public"`
+			Files  map[string]map[string]string `json:"files"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		file, ok := req.Files["review-this-diff-for-race-conditions.md"]
+		if req.Public || !ok || !strings.Contains(file["content"], "Review this diff") {
+			t.Errorf("request = %+v", req)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"html_url": "https://gist.github.com/me/abc"}) //nolint:errcheck
+	})
+	link, err := g.Spell(context.Background(), Repo{}, false, testSpell())
+	if err != nil || link != "https://gist.github.com/me/abc" {
+		t.Fatalf("Spell() = %q, %v", link, err)
+	}
+}
+
+// committedFile is how the contents API returns s's spell file.
+func committedFile(t *testing.T, sha string, s domain.Spell) map[string]string {
+	t.Helper()
+	content, err := spellContent(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]string{"sha": sha, "content": base64.StdEncoding.EncodeToString([]byte(content))}
+}
+
+func TestCommitReplacesExistingFile(t *testing.T) {
+	spell := testSpell()
+	var put map[string]string
+	g := testGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/me/spells/contents/prompts/review-this-diff-for-race-conditions.md" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(committedFile(t, "abc123", spell)) //nolint:errcheck
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&put)                                                                                               //nolint:errcheck
+			json.NewEncoder(w).Encode(map[string]any{"content": map[string]string{"html_url": "https://github.com/me/spells/blob/main/x.md"}}) //nolint:errcheck
+		}
+	})
+	repo, err := ParseRepo("me/spells/prompts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, err := g.Spell(context.Background(), repo, false, spell)
+	if err != nil || link != "https://github.com/me/spells/blob/main/x.md" {
+		t.Fatalf("Spell() = %q, %v", link, err)
+	}
+	content, _ := base64.StdEncoding.DecodeString(put["content"])
+	if put["sha"] != "abc123" || !strings.HasPrefix(put["message"], "Update spell") || !strings.Contains(string(content), "Review this diff") {
+		t.Errorf("PUT body = %+v", put)
+	}
+}
+
+func TestCommitSameTitleKeepsOtherSpell(t *testing.T) {
+	other, spell := testSpell(), testSpell()
+	var putPath string
+	var put map[string]string
+	g := testGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/me/spells/contents/review-this-diff-for-race-conditions.md":
+			json.NewEncoder(w).Encode(committedFile(t, "abc123", other)) //nolint:errcheck
+		case r.Method == http.MethodPut:
+			putPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&put)                                      //nolint:errcheck
+			json.NewEncoder(w).Encode(map[string]any{"content": map[string]string{}}) //nolint:errcheck
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	if _, err := g.Commit(context.Background(), Repo{Owner: "me", Name: "spells"}, spell); err != nil {
+		t.Fatal(err)
+	}
+	want := "/repos/me/spells/contents/review-this-diff-for-race-conditions-" + spell.ID.String()[:8] + ".md"
+	if putPath != want || put["sha"] != "" || !strings.HasPrefix(put["message"], "Add spell") {
+		t.Errorf("PUT %s %+v, want a new file at %s", putPath, put, want)
+	}
+}
+
+func TestNoToken(t *testing.T) {
+	if _, err := New("").Gist(context.Background(), testSpell(), false); !errors.Is(err, ErrNoToken) {
+		t.Errorf("error = %v, want ErrNoToken", err)
+	}
+}
+
+func TestParseRepo(t *testing.T) {
+	r, err := ParseRepo("me/spells/prompts/grimora/")
+	if err != nil || r != (Repo{Owner: "me", Name: "spells", Dir: "prompts/grimora"}) {
+		t.Errorf("ParseRepo() = %+v, %v", r, err)
+	}
+	for _, bad := range []string{"", "me", "me/", "/spells"} {
+		if _, err := ParseRepo(bad); err == nil {
+			t.Errorf("ParseRepo(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestWithLink(t *testing.T) {
+	tests := []struct{ context, want string }{
+		{"", "Published: https://x.dev/a"},
+		{"Works best with Claude\n", "Works best with Claude\nPublished: https://x.dev/a"},
+		{"see https://x.dev/a", "see https://x.dev/a"},
+	}
+	for _, tt := range tests {
+		if got := WithLink(tt.context, "https://x.dev/a"); got != tt.want {
+			t.Errorf("WithLink(%q) = %q, want %q", tt.context, got, tt.want)
+		}
+	}
+}
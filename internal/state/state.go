@@ -0,0 +1,118 @@
+// Package state persists small bits of bookkeeping the CLI keeps between runs
+// in ~/.grimora/state.json. Unlike config, nothing here is edited by hand.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/appdir"
+)
+
+// State is the contents of state.json.
+type State struct {
+	// UpdateCheckedAt is when the background update check last reached GitHub.
+	UpdateCheckedAt time.Time `json:"update_checked_at,omitzero"`
+	// LatestVersion is the newest release seen by that check, e.g. "v0.5.0".
+	LatestVersion string `json:"latest_version,omitempty"`
+	// DismissedVersion is the release whose banner was dismissed. A newer
+	// release brings the banner back.
+	DismissedVersion string `json:"dismissed_version,omitempty"`
+	// Session is where the TUI was when it last quit.
+	Session Session `json:"session,omitzero"`
+	// Onboarding is how far first-run setup has got.
+	Onboarding Onboarding `json:"onboarding,omitzero"`
+	// RoomAlerts is how much each Hall room may notify, by room slug. A
+	// room not listed shows its unread count and rings for mentions.
+	RoomAlerts map[string]string `json:"room_alerts,omitempty"`
+	// InputHistory is what was sent from the TUI's inputs, kept only when
+	// the save_history setting is on.
+	InputHistory InputHistory `json:"input_history,omitzero"`
+}
+
+// Room alert levels, for State.RoomAlerts.
+const (
+	RoomMentions = "mentions" // no unread count; @mentions still ring
+	RoomMuted    = "muted"    // no unread count and no bell
+)
+
+// Onboarding tracks a new magician through first-run setup, so a wizard
+// quit halfway resumes at the same step on the next launch.
+type Onboarding struct {
+	Step string `json:"step,omitempty"` // next step to show; "" before the first
+	Done bool   `json:"done,omitempty"` // finished or skipped; never shown again
+}
+
+// Session is the TUI's place and filters, saved on quit so the next launch
+// picks up where the last one left off.
+type Session struct {
+	Tab          string `json:"tab,omitempty"`       // "hall", "grimoire", "threads", ...
+	Room         string `json:"room,omitempty"`      // Hall room slug; empty for the Hall itself
+	RoomName     string `json:"room_name,omitempty"` // display name of Room
+	GrimoireSort string `json:"grimoire_sort,omitempty"`
+	GrimoireTag  string `json:"grimoire_tag,omitempty"` // tag filters, comma-separated
+	BoardGuild   string `json:"board_guild,omitempty"`
+	BoardCity    string `json:"board_city,omitempty"`
+
+	// Where each list was scrolled to, by the item at the bottom of the Hall
+	// and under the Grimoire's cursor, so it survives new messages and spells.
+	HallAnchor    string `json:"hall_anchor,omitempty"`    // empty when the Hall was at the latest message
+	GrimoireSpell string `json:"grimoire_spell,omitempty"` // spell ID
+}
+
+// InputHistory is what was sent from the Hall and Threads inputs, oldest
+// first, for ↑ to recall in the next run.
+type InputHistory struct {
+	Hall    []string `json:"hall,omitempty"`
+	Threads []string `json:"threads,omitempty"`
+}
+
+// UpdateCheckDue reports whether a day has passed since the last update check.
+func (s State) UpdateCheckDue(now time.Time) bool {
+	return now.Sub(s.UpdateCheckedAt) >= 24*time.Hour
+}
+
+// Path returns ~/.grimora/state.json.
+func Path() (string, error) {
+	return appdir.Path("state.json")
+}
+
+// Load reads the state file at path. A missing file yields the zero State.
+func Load(path string) (State, error) {
+	var s State
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("read state: %w", err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return State{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes s to path, replacing the file atomically.
+func Save(path string, s State) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadMissingIsZero(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "nope.json"))
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !reflect.DeepEqual(s, State{}) {
+		t.Errorf("Load() = %+v, want zero State", s)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "state.json")
+	checked := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	want := State{UpdateCheckedAt: checked, LatestVersion: "v0.5.0", DismissedVersion: "v0.4.2", RoomAlerts: map[string]string{"go-tips": RoomMuted}, InputHistory: InputHistory{Hall: []string{"/mute", "gm"}}}
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !got.UpdateCheckedAt.Equal(checked) || got.LatestVersion != want.LatestVersion || got.DismissedVersion != want.DismissedVersion || !reflect.DeepEqual(got.RoomAlerts, want.RoomAlerts) || !reflect.DeepEqual(got.InputHistory, want.InputHistory) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for corrupt state file")
+	}
+}
+
+func TestUpdateCheckDue(t *testing.T) {
+	now := time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		checked time.Time
+		want    bool
+	}{
+		{"never checked", time.Time{}, true},
+		{"an hour ago", now.Add(-time.Hour), false},
+		{"a day ago", now.Add(-24 * time.Hour), true},
+		{"last week", now.Add(-7 * 24 * time.Hour), true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := (State{UpdateCheckedAt: tc.checked}).UpdateCheckDue(now); got != tc.want {
+				t.Errorf("UpdateCheckDue() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSaveAndLoadSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	want := State{LatestVersion: "v0.5.0", Session: Session{Tab: "board", Room: "go-help", RoomName: "Go help", GrimoireSort: "top", BoardCity: "Berlin", HallAnchor: "m-41", GrimoireSpell: "s-7"}}
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got.Session != want.Session {
+		t.Errorf("Session = %+v, want %+v", got.Session, want.Session)
+	}
+
+	// An empty session is left out of the file entirely.
+	if err := Save(path, State{LatestVersion: "v0.5.0"}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "session") {
+		t.Errorf("state file has an empty session: %s", data)
+	}
+}
+
+func TestSaveAndLoadOnboarding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	want := State{Onboarding: Onboarding{Step: "project"}}
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got.Onboarding != want.Onboarding {
+		t.Errorf("Onboarding = %+v, want %+v", got.Onboarding, want.Onboarding)
+	}
+
+	if err := Save(path, State{}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "onboarding") {
+		t.Errorf("state file has empty onboarding: %s", data)
+	}
+}
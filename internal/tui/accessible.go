@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// accessibleMode is set by the accessible config setting (or --accessible)
+// for screen readers and anyone who'd rather nothing moved. Every animation
+// rests on its first frame, frames go through plainFrame instead of the
+// glyph set, and new messages and alerts are also printed as lines above
+// the TUI (see announce), which then runs outside the alternate screen.
+var accessibleMode bool
+
+// layoutGlyphs only draw boxes and rules. A screen reader would spell them
+// out one by one, so accessible frames blank them, keeping the width.
+var layoutGlyphs = []rune{'─', '│', '┌', '┐', '└', '┘', '╭', '╮', '╰', '╯'}
+
+// plainReplacer is glyphReplacer with layoutGlyphs blanked.
+var plainReplacer = func() *strings.Replacer {
+	pairs := make([]string, 0, 2*len(glyphFallbacks))
+	for r, s := range glyphFallbacks {
+		for _, l := range layoutGlyphs {
+			if r == l {
+				s = " "
+			}
+		}
+		pairs = append(pairs, string(r), s)
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// plainFrame rewrites a rendered frame for accessible mode: no box drawing,
+// and ASCII stand-ins for every other glyph.
+func plainFrame(s string) string {
+	return plainReplacer.Replace(s)
+}
+
+// animated reports whether anything moves: not in accessible or
+// low-bandwidth mode.
+func animated() bool {
+	return !accessibleMode && !lowBandwidth
+}
+
+// still returns frame, or 0, the resting frame every animation starts and
+// ends on, when nothing may move.
+func still(frame int) int {
+	if !animated() {
+		return 0
+	}
+	return frame
+}
+
+// announceMsg carries a line for announce to print. The App prints it, so
+// nothing is printed while the lock screen is up.
+type announceMsg struct{ line string }
+
+// announce prints line above the TUI in accessible mode, where a screen
+// reader picks it up as ordinary terminal output. Otherwise it does nothing.
+func announce(line string) tea.Cmd {
+	if !accessibleMode {
+		return nil
+	}
+	return func() tea.Msg { return announceMsg{line: line} }
+}
+
+// printAnnounced prints msg, unless the App is locked: the lock keeps rooms
+// and DMs from being read by whoever walks by, and printed lines would
+// stay on the terminal.
+func (a App) printAnnounced(msg announceMsg) (App, tea.Cmd) {
+	if a.locked {
+		return a, nil
+	}
+	return a, tea.Println(plainFrame(msg.line))
+}
+
+// Accessible reports whether accessible mode is on, so the caller can run
+// the program inline instead of in the alternate screen.
+func Accessible() bool {
+	return accessibleMode
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+func withAccessible(t *testing.T) {
+	t.Helper()
+	withConfig(t, config.Config{Accessible: true, Flash: true, Glyphs: config.GlyphsUnicode})
+}
+
+func TestAccessibleOffByDefault(t *testing.T) {
+	if accessibleMode || announce("hi") != nil || still(3) != 3 {
+		t.Error("accessible mode should be off unless configured")
+	}
+}
+
+func TestAccessibleStopsAnimation(t *testing.T) {
+	withAccessible(t)
+	if cursorBlinkInterval != 0 || alertFlash {
+		t.Error("accessible mode should stop the cursor blinking and the flash")
+	}
+	if shimmerTickCmd() != nil {
+		t.Error("the logo shimmer should not tick")
+	}
+	if renderShimmerLogo(0) != renderShimmerLogo(37) {
+		t.Error("the logo should not change between frames")
+	}
+	if cardBorder("bottom", "", "#4ade80", 5, 40) != cardBorder("bottom", "", "#4ade80", 0, 40) {
+		t.Error("card borders should not animate")
+	}
+}
+
+func TestAccessiblePlainFrame(t *testing.T) {
+	withAccessible(t)
+	got := withGlyphs("┌── ✦ spell ──┐\n│ hi · there │")
+	if strings.ContainsAny(got, "┌─┐│✦·") {
+		t.Errorf("frame still has glyphs: %q", got)
+	}
+	if got != "    * spell    \n  hi - there  " {
+		t.Errorf("withGlyphs = %q", got)
+	}
+}
+
+func TestAccessibleAnnouncesAlerts(t *testing.T) {
+	withAccessible(t)
+	app := NewApp(nil, "dev")
+	app, cmd := app.handleAlert(alertMsg{reason: "new message from mona"}, time.Now())
+	if cmd == nil {
+		t.Fatal("an alert should be announced")
+	}
+	// A second alert inside the cooldown is still read out.
+	if _, cmd = app.handleAlert(alertMsg{reason: "again"}, time.Now()); cmd == nil {
+		t.Error("alerts inside the cooldown should still be announced")
+	}
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/hooks"
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// activityPollInterval is how often every room and DM thread is checked for
+// messages, so mentions, DMs and ships ring and run their hooks wherever
+// they land, not only in the room or conversation on screen.
+const activityPollInterval = 30 * time.Second
+
+// activityPageSize is how many of a room's or thread's newest messages the
+// activity poll looks through.
+const activityPageSize = 20
+
+// maxNotified bounds notifiedMessages; past it the oldest IDs no longer
+// matter, since every poller has moved on from them.
+const maxNotified = 2000
+
+// notifiedMessages remembers the messages that have already rung the bell
+// or run a hook. The Hall, the open DM and the activity poll can each see
+// the same message, and only the first to see it alerts. A nil one
+// remembers nothing.
+type notifiedMessages map[string]bool
+
+// first reports whether id hasn't been notified yet, and marks it.
+func (n notifiedMessages) first(id string) bool {
+	if n == nil {
+		return true
+	}
+	if n[id] {
+		return false
+	}
+	if len(n) >= maxNotified {
+		clear(n)
+	}
+	n[id] = true
+	return true
+}
+
+// activitySnapshot is what the last activity poll saw: when each thread and
+// room last had a message.
+type activitySnapshot struct {
+	loaded  bool
+	threads map[string]time.Time // thread ID → last message
+	rooms   map[string]time.Time // room slug → last message
+}
+
+type activityTickMsg struct{}
+
+func activityTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return activityTickMsg{} })
+}
+
+// activityLoadedMsg carries every thread and room, to compare with the last
+// poll.
+type activityLoadedMsg struct {
+	threads []domain.Thread
+	rooms   []domain.Room
+	err     error
+}
+
+// threadActivityMsg carries a thread's newest messages; those after since
+// arrived after the last poll.
+type threadActivityMsg struct {
+	since    time.Time
+	messages []domain.Message
+	err      error
+}
+
+// roomActivityMsg carries a room's newest messages; those after since
+// arrived after the last poll.
+type roomActivityMsg struct {
+	slug     string
+	since    time.Time
+	messages []domain.RoomMessage
+	err      error
+}
+
+func loadActivity(c client.API) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		threads, err := c.ListThreads(ctx)
+		if err != nil {
+			return activityLoadedMsg{err: err}
+		}
+		rooms, err := c.ListRooms(ctx)
+		return activityLoadedMsg{threads: threads, rooms: rooms, err: err}
+	}
+}
+
+func threadActivityCmd(c client.API, threadID string, since time.Time) tea.Cmd {
+	return func() tea.Msg {
+		msgs, err := c.GetMessages(context.Background(), threadID, activityPageSize, 0)
+		return threadActivityMsg{since: since, messages: msgs, err: err}
+	}
+}
+
+func roomActivityCmd(c client.API, slug string, since time.Time) tea.Cmd {
+	return func() tea.Msg {
+		msgs, err := c.GetRoomMessages(context.Background(), slug, time.Time{}, activityPageSize)
+		return roomActivityMsg{slug: slug, since: since, messages: msgs, err: err}
+	}
+}
+
+// handleActivity fetches the messages of every thread and room that has
+// had one since the last poll. The first poll only establishes the
+// baseline. A thread that is new since then is read from its start, since
+// it is someone writing for the first time; a room that is new was just
+// joined, and its history shouldn't ring.
+func (a App) handleActivity(msg activityLoadedMsg) (App, tea.Cmd) {
+	if msg.err != nil {
+		return a, nil
+	}
+	next := activitySnapshot{
+		loaded:  true,
+		threads: make(map[string]time.Time, len(msg.threads)),
+		rooms:   make(map[string]time.Time, len(msg.rooms)),
+	}
+	var cmds []tea.Cmd
+	for _, t := range msg.threads {
+		id := t.ID.String()
+		next.threads[id] = t.LastMessageAt
+		since, known := a.activity.threads[id]
+		if !known {
+			since = t.CreatedAt
+		}
+		if a.activity.loaded && t.LastMessageAt.After(since) {
+			cmds = append(cmds, threadActivityCmd(a.client, id, since))
+		}
+	}
+	for _, r := range msg.rooms {
+		next.rooms[r.Slug] = r.LastMessageAt
+		if since, known := a.activity.rooms[r.Slug]; known && r.LastMessageAt.After(since) {
+			cmds = append(cmds, roomActivityCmd(a.client, r.Slug, since))
+		}
+	}
+	a.activity = next
+	return a, tea.Batch(cmds...)
+}
+
+// handleThreadActivity rings for DMs that arrived since the last poll and
+// runs the on_dm hook for each, except those from magicians you've blocked.
+func (a App) handleThreadActivity(msg threadActivityMsg) (App, tea.Cmd) {
+	me := a.myLogin()
+	if msg.err != nil || me == "" {
+		return a, nil
+	}
+	var cmds []tea.Cmd
+	for _, m := range msg.messages {
+		if !m.CreatedAt.After(msg.since) || m.SenderLogin == me || a.blocked[m.SenderLogin] || !a.notified.first(m.ID.String()) {
+			continue
+		}
+		if len(cmds) == 0 {
+			cmds = append(cmds, alertCmd("new message from "+m.SenderLogin))
+		}
+		cmds = append(cmds, hookCmd(dmHookEvent(m)))
+	}
+	return a, tea.Batch(cmds...)
+}
+
+// handleRoomActivity rings for mentions that arrived in a room since the
+// last poll and runs the on_mention and on_ship hooks. A muted room does
+// neither for mentions, and magicians you've blocked do neither at all.
+func (a App) handleRoomActivity(msg roomActivityMsg) (App, tea.Cmd) {
+	me := a.myLogin()
+	if msg.err != nil || me == "" {
+		return a, nil
+	}
+	muted := a.hall.roomAlert(msg.slug) == state.RoomMuted
+	var cmds []tea.Cmd
+	rang := false
+	for _, rm := range msg.messages {
+		if !rm.CreatedAt.After(msg.since) || rm.SenderLogin == me || a.blocked[rm.SenderLogin] || !a.notified.first(rm.ID.String()) {
+			continue
+		}
+		cm := chatMessage{ID: rm.ID.String(), SenderLogin: rm.SenderLogin, Body: rm.Body, CreatedAt: rm.CreatedAt}
+		if !muted && mentionsLogin(rm.Body, me) {
+			if !rang {
+				cmds = append(cmds, alertCmd("@"+rm.SenderLogin+" mentioned you in #"+msg.slug))
+				rang = true
+			}
+			cmds = append(cmds, hookCmd(roomHookEvent(hooks.OnMention, msg.slug, cm)))
+		}
+		if rm.Kind == "ship" {
+			cmds = append(cmds, hookCmd(roomHookEvent(hooks.OnShip, msg.slug, cm)))
+		}
+	}
+	return a, tea.Batch(cmds...)
+}
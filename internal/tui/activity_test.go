@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// pollActivity runs one activity poll against f and returns the alerts it
+// raised.
+func pollActivity(t *testing.T, a App, f *clienttest.Fake) (App, []string) {
+	t.Helper()
+	model, cmd := a.Update(loadActivity(f)())
+	a = model.(App)
+	var alerts []string
+	for _, msg := range drainCmd(cmd) {
+		var next tea.Cmd
+		model, next = a.Update(msg)
+		a = model.(App)
+		if al, ok := findAlert(next); ok {
+			alerts = append(alerts, al.reason)
+		}
+	}
+	return a, alerts
+}
+
+func TestActivityAlertsOutsideTheOpenView(t *testing.T) {
+	t0 := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	thread := domain.Thread{ID: uuid.New(), OtherLogin: "ada", LastMessageAt: t0, CreatedAt: t0.Add(-time.Hour)}
+	f := &clienttest.Fake{
+		Threads: []domain.Thread{thread},
+		Rooms:   []domain.Room{{Slug: "go-tips", LastMessageAt: t0}, {Slug: "quiet", LastMessageAt: t0}},
+	}
+	a := NewApp(f, "test")
+	a.me = &domain.Magician{GitHubLogin: "me"}
+	a.hall.roomAlerts = map[string]string{"quiet": state.RoomMuted}
+	a, alerts := pollActivity(t, a, f)
+	if len(alerts) != 0 {
+		t.Fatalf("the first poll alerted %q, want it to only take a baseline", alerts)
+	}
+
+	later := t0.Add(time.Minute)
+	dm := domain.Message{ID: uuid.New(), SenderLogin: "ada", Body: "you around?", CreatedAt: later}
+	f.Messages = map[string][]domain.Message{thread.ID.String(): {dm}}
+	f.RoomMessages = map[string][]domain.RoomMessage{
+		"go-tips": {{ID: uuid.New(), SenderLogin: "grace", Body: "@me look at this", CreatedAt: later}},
+		"quiet":   {{ID: uuid.New(), SenderLogin: "linus", Body: "@me ping", CreatedAt: later}},
+	}
+	f.Threads[0].LastMessageAt = later
+	f.Rooms[0].LastMessageAt, f.Rooms[1].LastMessageAt = later, later
+
+	a, alerts = pollActivity(t, a, f)
+	slices.Sort(alerts)
+	if want := []string{"@grace mentioned you in #go-tips", "new message from ada"}; !slices.Equal(alerts, want) {
+		t.Errorf("alerts = %q, want %q and nothing from the muted room", alerts, want)
+	}
+	if a, alerts = pollActivity(t, a, f); len(alerts) != 0 {
+		t.Errorf("a quiet poll alerted %q", alerts)
+	}
+
+	// Opening the conversation later doesn't ring for the same DM again.
+	a.threads.messages = []domain.Message{{ID: uuid.New(), SenderLogin: "ada", Body: "earlier", CreatedAt: t0}}
+	if al, ok := findAlert(a.threads.incomingAlert([]domain.Message{dm})); ok {
+		t.Errorf("the open conversation alerted %q again", al.reason)
+	}
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// alertFlashDuration is how long the visual flash replaces the tab bar.
+const alertFlashDuration = 1500 * time.Millisecond
+
+// alertCooldown is the minimum gap between two alerts, so a burst of
+// mentions rings once rather than a dozen times.
+const alertCooldown = 3 * time.Second
+
+// alertBell and alertFlash enable the audible bell and the visual flash.
+// Both are off unless turned on in the config.
+var (
+	alertBell  bool
+	alertFlash bool
+)
+
+// bellFrameDuration is how long the BEL character stays in the frame. It
+// goes out through the renderer rather than straight to stdout, which the
+// renderer is also writing to; a few frames is long enough to be flushed.
+const bellFrameDuration = 50 * time.Millisecond
+
+var alertFlashStyle = lipgloss.NewStyle().
+	Background(paletteColor("#34d474")).
+	Foreground(paletteColor("#111118")).
+	Bold(true)
+
+// alertMsg asks the App to get the user's attention. Any model can emit one
+// via alertCmd; the App decides how (or whether) to alert.
+type alertMsg struct {
+	reason string
+}
+
+// alertFlashDoneMsg ends the visual flash started at the given time.
+type alertFlashDoneMsg struct {
+	started time.Time
+}
+
+// bellDoneMsg takes the BEL rung at the given time back out of the frame.
+type bellDoneMsg struct {
+	rung time.Time
+}
+
+func alertCmd(reason string) tea.Cmd {
+	return func() tea.Msg { return alertMsg{reason: reason} }
+}
+
+// mentionsLogin reports whether body contains an @mention of login.
+func mentionsLogin(body, login string) bool {
+	if login == "" {
+		return false
+	}
+	for _, m := range mentionRe.FindAllStringSubmatch(body, -1) {
+		if strings.EqualFold(m[1], login) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleAlert rings the bell and/or starts a flash for msg, subject to the
+// cooldown and do-not-disturb. now is passed in for testability.
+func (a App) handleAlert(msg alertMsg, now time.Time) (App, tea.Cmd) {
+	if a.doNotDisturb(now) {
+		return a, nil
+	}
+	// Screen readers hear every alert, cooldown or not.
+	said := announce(msg.reason)
+	if !alertBell && !alertFlash {
+		return a, said
+	}
+	if !a.lastAlert.IsZero() && now.Sub(a.lastAlert) < alertCooldown {
+		return a, said
+	}
+	a.lastAlert = now
+	cmds := []tea.Cmd{said}
+	if alertBell {
+		// The terminal (or tmux) turns BEL into a beep or a window
+		// activity marker.
+		a.bellAt = now
+		cmds = append(cmds, tea.Tick(bellFrameDuration, func(time.Time) tea.Msg {
+			return bellDoneMsg{rung: now}
+		}))
+	}
+	if alertFlash {
+		a.flashText = msg.reason
+		a.flashStart = now
+		cmds = append(cmds, tea.Tick(alertFlashDuration, func(time.Time) tea.Msg {
+			return alertFlashDoneMsg{started: now}
+		}))
+	}
+	return a, tea.Batch(cmds...)
+}
+
+// withBell puts BEL at the start of the frame's last line while a bell is
+// ringing. The help bar there rarely changes, so the renderer writes the
+// line, and with it the bell, once.
+func (a App) withBell(help string) string {
+	if a.bellAt.IsZero() {
+		return help
+	}
+	return "\a" + help
+}
+
+// renderFlash renders the full-width attention bar shown in place of the tabs.
+func renderFlash(text string, width int) string {
+	return alertFlashStyle.Width(max(width, 1)).Align(lipgloss.Center).Render("✦ " + text + " ✦")
+}
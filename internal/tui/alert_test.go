@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// drainCmd runs cmd, expanding batches, and returns the messages produced
+// promptly. Ticks and other slow commands are abandoned.
+func drainCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	ch := make(chan tea.Msg, 1)
+	go func() { ch <- cmd() }()
+	select {
+	case msg := <-ch:
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			var out []tea.Msg
+			for _, c := range batch {
+				out = append(out, drainCmd(c)...)
+			}
+			return out
+		}
+		return []tea.Msg{msg}
+	case <-time.After(50 * time.Millisecond):
+		return nil
+	}
+}
+
+// findAlert returns the first alertMsg produced by cmd.
+func findAlert(cmd tea.Cmd) (alertMsg, bool) {
+	for _, msg := range drainCmd(cmd) {
+		if a, ok := msg.(alertMsg); ok {
+			return a, true
+		}
+	}
+	return alertMsg{}, false
+}
+
+// ringing reports whether a's frame carries the bell.
+func ringing(a App) bool {
+	return strings.Contains(a.View(), "\a")
+}
+
+func TestMentionsLogin(t *testing.T) {
+	if !mentionsLogin("hey @Alice look", "alice") {
+		t.Error("expected case-insensitive mention match")
+	}
+	if mentionsLogin("hey @alicex", "alice") {
+		t.Error("expected no match for a longer login")
+	}
+	if mentionsLogin("hey @alice", "") {
+		t.Error("expected no match for empty login")
+	}
+}
+
+func TestAlertOffByDefault(t *testing.T) {
+	withConfig(t, config.Config{})
+	app := newTestApp()
+	app, cmd := app.handleAlert(alertMsg{reason: "x"}, time.Now())
+	if cmd != nil || ringing(app) || app.flashText != "" {
+		t.Error("expected no alert when bell and flash are off")
+	}
+}
+
+func TestAlertBellRespectsCooldown(t *testing.T) {
+	withConfig(t, config.Config{Bell: true})
+	app := newTestApp()
+	now := time.Now()
+	app, cmd := app.handleAlert(alertMsg{reason: "x"}, now)
+	if !ringing(app) || cmd == nil {
+		t.Fatal("expected the bell in the frame, and a timer to take it out")
+	}
+	app, _ = app.handleAlert(alertMsg{reason: "y"}, now.Add(time.Second))
+	if !app.bellAt.Equal(now) {
+		t.Error("expected no second bell within the cooldown")
+	}
+	model, _ := app.Update(bellDoneMsg{rung: now})
+	app = model.(App)
+	if ringing(app) {
+		t.Error("expected the bell out of the frame once rung")
+	}
+	app, _ = app.handleAlert(alertMsg{reason: "z"}, now.Add(alertCooldown+time.Second))
+	if !ringing(app) {
+		t.Error("expected a second bell after the cooldown")
+	}
+}
+
+func TestAlertFlashShowsAndClears(t *testing.T) {
+	withConfig(t, config.Config{Flash: true})
+	app := newTestApp()
+	now := time.Now()
+	app, cmd := app.handleAlert(alertMsg{reason: "new message from bob"}, now)
+	if cmd == nil {
+		t.Fatal("expected a timer to end the flash")
+	}
+	if !strings.Contains(app.View(), "new message from bob") {
+		t.Errorf("expected flash text in view, got:\n%s", app.View())
+	}
+
+	model, _ := app.Update(alertFlashDoneMsg{started: now.Add(-time.Second)})
+	app = model.(App)
+	if app.flashText == "" {
+		t.Error("a stale flash timer should not clear a newer flash")
+	}
+	model, _ = app.Update(alertFlashDoneMsg{started: now})
+	app = model.(App)
+	if app.flashText != "" {
+		t.Error("expected flash cleared by its own timer")
+	}
+}
+
+func TestHallMentionTriggersAlert(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m, cmd := m.Update(hallMessagesMsg{messages: []domain.RoomMessage{
+		makeTestRoomMessage("bob", "nyx", "@me old news"),
+	}})
+	if _, ok := findAlert(cmd); ok {
+		t.Error("history on first load should not alert")
+	}
+
+	_, cmd = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{
+		makeTestRoomMessage("bob", "nyx", "hey @me, look"),
+	}})
+	alert, ok := findAlert(cmd)
+	if !ok {
+		t.Fatal("expected alert for a new mention")
+	}
+	if !strings.Contains(alert.reason, "bob") {
+		t.Errorf("expected sender in alert reason, got %q", alert.reason)
+	}
+}
+
+func TestHallOwnMentionDoesNotAlert(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m, _ = m.Update(hallMessagesMsg{})
+	_, cmd := m.Update(hallMessagesMsg{messages: []domain.RoomMessage{
+		makeTestRoomMessage("me", "nyx", "talking about @me"),
+	}})
+	if _, ok := findAlert(cmd); ok {
+		t.Error("own message should not alert")
+	}
+}
+
+func TestThreadsIncomingMessageTriggersAlert(t *testing.T) {
+	m := newTestThreadsModel()
+	m.myLogin = "me"
+	m.state = threadsConvoState
+	m.openThreadID = "t1"
+	m.messages = makeTestMessages(2, time.Now())
+
+	incoming := domain.Message{ID: uuid.New(), SenderLogin: "bob", Body: "ping", CreatedAt: time.Now().Add(time.Hour)}
+	_, cmd := m.Update(threadsMessagesLoadedMsg{threadID: "t1", messages: []domain.Message{incoming}})
+	if _, ok := findAlert(cmd); !ok {
+		t.Error("expected alert for a new incoming DM")
+	}
+
+	mine := domain.Message{ID: uuid.New(), SenderLogin: "me", Body: "pong", CreatedAt: time.Now().Add(time.Hour)}
+	_, cmd = m.Update(threadsMessagesLoadedMsg{threadID: "t1", messages: []domain.Message{mine}})
+	if _, ok := findAlert(cmd); ok {
+		t.Error("own message should not alert")
+	}
+}
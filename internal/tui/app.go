@@ -4,17 +4,49 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/naveenspark/grimora/internal/browser"
+	"github.com/naveenspark/grimora/internal/drafts"
+	glog "github.com/naveenspark/grimora/internal/log"
+	"github.com/naveenspark/grimora/internal/metrics"
+	"github.com/naveenspark/grimora/internal/outbox"
+	"github.com/naveenspark/grimora/internal/state"
 	"github.com/naveenspark/grimora/pkg/client"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
 type view int
 
+func (v view) String() string {
+	switch v {
+	case viewHall:
+		return "hall"
+	case viewGrimoire:
+		return "grimoire"
+	case viewThreads:
+		return "threads"
+	case viewBoard:
+		return "board"
+	case viewYou:
+		return "you"
+	case viewCreate:
+		return "create"
+	case viewGuild:
+		return "guild"
+	case viewNotifications:
+		return "notifications"
+	case viewRealm:
+		return "realm"
+	case viewStream:
+		return "stream"
+	}
+	return fmt.Sprintf("view(%d)", int(v))
+}
+
 const (
 	viewHall view = iota
 	viewGrimoire
@@ -22,6 +54,10 @@ const (
 	viewBoard
 	viewYou
 	viewCreate
+	viewGuild
+	viewNotifications
+	viewRealm
+	viewStream
 )
 
 // meLoadedMsg carries the result of GetMe + ForgeStats.
@@ -36,23 +72,38 @@ type showPeekMsg struct {
 	login string
 }
 
+// openDMMsg asks the App to open a DM thread with login.
+type openDMMsg struct {
+	login string
+}
+
 // appChromeLines is the number of lines used by header(2) + tabs(1) + help(1).
 const appChromeLines = 4
 
 // App is the root Bubbletea model.
 type App struct {
-	client          *client.Client
+	client          client.API
 	view            view
 	hall            hallModel
 	grimoire        grimoireModel
 	threads         threadsModel
 	board           boardModel
 	you             youModel
+	guild           guildModel
 	create          createModel
+	notifications   notificationsModel
+	realm           realmModel
+	stream          streamModel
 	peek            peekModel
 	peekOpen        bool
 	helpOpen        bool
 	helpCursor      int
+	notes           notesModel
+	notesOpen       bool
+	switcher        switcherModel
+	switcherOpen    bool
+	onboarding      onboardingModel
+	onboardingOpen  bool // first-run wizard is up
 	me              *domain.Magician
 	stats           *domain.ForgeStats
 	width           int
@@ -61,25 +112,89 @@ type App struct {
 	currentVersion  string
 	latestVersion   string
 	updateAvailable bool
+	updateCheck     bool        // daily update check opted in
+	serverAhead     bool        // the server speaks a newer API than this CLI
+	statePath       string      // where state is saved; "" keeps it in memory
+	state           state.State // persisted bookkeeping, e.g. the last update check
+	lastAlert       time.Time   // when the bell/flash last fired
+	flashText       string      // non-empty while the visual flash is showing
+	flashStart      time.Time
+	bellAt          time.Time       // when the bell now in the frame rang; zero when silent
+	drafts          *drafts.Store   // unsent compose text; nil disables persistence
+	outbox          *outbox.Store   // messages not yet accepted by the API
+	degraded        string          // why the app opened without signing in; "" once signed in
+	startupPending  <-chan error    // sign-in check still running from startup
+	sessionExpired  <-chan struct{} // signalled when the API refuses the session for good
+	relogin         func() error    // signs in again; nil when the app can't
+	lastInput       time.Time       // last keypress, for the idle lock and away status
+	away            bool            // last heartbeat reported the magician away
+	dnd             bool            // do-not-disturb toggled on; see doNotDisturb
+	locked          bool            // idle lock screen is up
+	lockInput       string          // passphrase typed on the lock screen
+	lockErr         string
+	link            *domain.Link       // permalink to open at startup
+	fetchedAt       map[view]time.Time // when each prefetched tab's data last arrived
+	prefetchNext    int                // index into prefetchViews of the next tab to warm
+	blocked         map[string]bool    // logins whose messages are collapsed; see withBlocked
+
+	activity activitySnapshot // what the last activity poll saw
+	notified notifiedMessages // messages already alerted on; shared with the Hall and threads
 }
 
 // NewApp creates a new TUI application.
-func NewApp(c *client.Client, version string) App {
+func NewApp(c client.API, version string) App {
+	box := outbox.New()
+	notified := notifiedMessages{}
+	hall, threads := newHallModel(c), newThreadsModel(c)
+	hall.outbox, threads.outbox = box, box
+	hall.notified, threads.notified = notified, notified
 	return App{
 		client:         c,
+		outbox:         box,
+		notified:       notified,
 		currentVersion: version,
-		hall:           newHallModel(c),
+		lastInput:      time.Now(),
+		hall:           hall,
 		grimoire:       newGrimoireModel(c),
-		threads:        newThreadsModel(c),
+		threads:        threads,
 		board:          newBoardModel(c),
 		you:            newYouModel(c),
+		guild:          newGuildModel(c),
 		create:         newCreateModel(c),
-		peek:           newPeekModel(c),
+		notifications:  newNotificationsModel(c),
+		realm:          newRealmModel(c),
+		stream:         newStreamModel(c),
+		peek:           newPeekModel(c, ""),
 	}
 }
 
 func (a App) Init() tea.Cmd {
-	return tea.Batch(a.hall.Init(), shimmerTickCmd(), a.loadMe(), checkVersion(a.currentVersion))
+	cmds := []tea.Cmd{a.hall.Init(), a.viewInit(), shimmerTickCmd(), cursorBlinkCmd(), a.loadMe(), a.updateCheckDue(), checkServer(a.client), loadSubscriptions(a.client), loadBlocked(a.client), watchTickCmd(slowed(watchPollInterval)), loadActivity(a.client), activityTickCmd(slowed(activityPollInterval)), heartbeatCmd(a.client, domain.PresenceOnline), heartbeatTickCmd(), outboxTickCmd(a.outbox), prefetchTickCmd(prefetchDelay)}
+	if a.updateCheck {
+		cmds = append(cmds, updateCheckTickCmd())
+	}
+	if a.drafts != nil {
+		cmds = append(cmds, draftSaveTickCmd(a.drafts))
+	}
+	if a.notesOpen {
+		cmds = append(cmds, fetchReleaseNotes(a.currentVersion))
+	}
+	if a.startupPending != nil {
+		cmds = append(cmds, waitStartupCmd(a.startupPending))
+	}
+	if a.sessionExpired != nil {
+		cmds = append(cmds, waitExpiredCmd(a.sessionExpired))
+	}
+	if lockAfter > 0 {
+		cmds = append(cmds, lockTickCmd())
+	}
+	if a.onboardingOpen {
+		cmds = append(cmds, saveStateCmd(a.statePath, a.state))
+	}
+	if a.link != nil {
+		cmds = append(cmds, openLinkCmd(a.client, *a.link))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (a App) loadMe() tea.Cmd {
@@ -97,7 +212,58 @@ func (a App) loadMe() tea.Cmd {
 	}
 }
 
+// Update handles msg and, when debug logging is on, records any view or
+// overlay transition it caused.
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := a.logState()
+	m, cmd := a.update(msg)
+	if after := m.(App).logState(); after != before {
+		glog.Debug("tui state",
+			"view", after.view.String(),
+			"from", before.view.String(),
+			"editing", after.editing,
+			"help", after.help,
+			"peek", after.peek,
+			"notes", after.notes,
+			"switcher", after.switcher,
+			"onboarding", after.onboarding,
+			"locked", after.locked,
+		)
+	}
+	return m, cmd
+}
+
+// appLogState is the slice of App state whose transitions are worth logging.
+type appLogState struct {
+	view       view
+	editing    bool
+	help       bool
+	peek       bool
+	notes      bool
+	switcher   bool
+	onboarding bool
+	locked     bool
+}
+
+func (a App) logState() appLogState {
+	return appLogState{view: a.view, editing: a.isEditing(), help: a.helpOpen, peek: a.peekOpen, notes: a.notesOpen, switcher: a.switcherOpen, onboarding: a.onboardingOpen, locked: a.locked}
+}
+
+func (a App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		// The lock screen takes every key until it is unlocked.
+		if a.locked {
+			return a.updateLock(key)
+		}
+		a.lastInput = time.Now()
+		if a.away {
+			// Back from idle: say so now rather than at the next heartbeat.
+			a.away = false
+			m, cmd := a.update(msg)
+			return m, tea.Batch(cmd, heartbeatCmd(a.client, domain.PresenceOnline))
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
@@ -109,39 +275,339 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.threads, _ = a.threads.Update(bodyMsg)
 		a.board, _ = a.board.Update(bodyMsg)
 		a.you, _ = a.you.Update(bodyMsg)
+		a.guild, _ = a.guild.Update(bodyMsg)
 		a.peek, _ = a.peek.Update(bodyMsg)
 		a.create, _ = a.create.Update(bodyMsg)
+		a.notifications, _ = a.notifications.Update(bodyMsg)
+		a.realm, _ = a.realm.Update(bodyMsg)
+		a.stream, _ = a.stream.Update(bodyMsg)
+		a.notes = a.notes.Update(bodyMsg)
+		a.switcher.width = msg.Width
+		return a, nil
+
+	case releaseNotesMsg:
+		a.notes = a.notes.Update(msg)
 		return a, nil
 
+	case announceMsg:
+		return a.printAnnounced(msg)
+
 	case shimmerTickMsg:
 		a.frame++
 		return a, shimmerTickCmd()
 
-	case versionCheckMsg:
-		if msg.hasUpdate {
-			a.latestVersion = msg.latestVersion
-			a.updateAvailable = true
+	case toggleDNDMsg:
+		return a.toggleDND()
+
+	case roomAlertsMsg:
+		return a.saveRoomAlerts(msg)
+
+	case alertMsg:
+		return a.handleAlert(msg, time.Now())
+
+	case lockTickMsg:
+		return a.checkIdle(time.Time(msg))
+
+	case heartbeatTickMsg:
+		return a.heartbeat(time.Time(msg))
+
+	case alertFlashDoneMsg:
+		// Ignore timers from flashes that have since been replaced.
+		if msg.started.Equal(a.flashStart) {
+			a.flashText = ""
+		}
+		return a, nil
+
+	case bellDoneMsg:
+		if msg.rung.Equal(a.bellAt) {
+			a.bellAt = time.Time{}
 		}
 		return a, nil
 
+	case watchTickMsg:
+		return a, tea.Batch(loadSubscriptions(a.client), watchTickCmd(pollDelay(a.client, slowed(watchPollInterval))))
+
+	case subscriptionsLoadedMsg:
+		return a.handleSubscriptions(msg)
+
+	case activityTickMsg:
+		return a, tea.Batch(loadActivity(a.client), activityTickCmd(pollDelay(a.client, slowed(activityPollInterval))))
+
+	case activityLoadedMsg:
+		return a.handleActivity(msg)
+
+	case threadActivityMsg:
+		return a.handleThreadActivity(msg)
+
+	case roomActivityMsg:
+		return a.handleRoomActivity(msg)
+
+	case blockedLoadedMsg:
+		// Without the list nothing is collapsed, which is only untidy.
+		if msg.err != nil {
+			return a, nil
+		}
+		blocked := make(map[string]bool, len(msg.logins))
+		for _, login := range msg.logins {
+			blocked[login] = true
+		}
+		return a.withBlocked(blocked), nil
+
+	case blockResultMsg:
+		return a.handleBlock(msg)
+
+	case watchResultMsg:
+		// Watching happens from the Grimoire, the Hall and the You tab;
+		// each keeps its own view of watch state.
+		a.grimoire, _ = a.grimoire.Update(msg)
+		a.hall, _ = a.hall.Update(msg)
+		a.you, _ = a.you.Update(msg)
+		if msg.err != nil {
+			return a, nil
+		}
+		return a, loadSubscriptions(a.client)
+
+	case spellReforgeMsg, reforgePollMsg, reforgeCheckedMsg:
+		// A pending re-forge keeps polling whatever tab or overlay is open.
+		var cmd tea.Cmd
+		a.grimoire, cmd = a.grimoire.Update(msg)
+		return a, cmd
+
+	case draftSaveTickMsg:
+		return a, draftSaveTickCmd(a.drafts)
+
+	case outboxTickMsg:
+		return a, tea.Batch(retryDueCmd(a.client, a.outbox, a.hall.journal, time.Time(msg)), outboxTickCmd(a.outbox))
+
+	case outboxSentMsg:
+		// Room messages belong to the Hall, DMs to threads; each checks
+		// the target against what it has open.
+		var hallCmd, threadsCmd tea.Cmd
+		a.hall, hallCmd = a.hall.Update(msg)
+		a.threads, threadsCmd = a.threads.Update(msg)
+		return a, tea.Batch(hallCmd, threadsCmd)
+
+	case cursorBlinkMsg:
+		// One app-wide ticker drives every input cursor, so switching tabs
+		// never stacks up extra blink loops.
+		a.hall, _ = a.hall.Update(msg)
+		a.threads, _ = a.threads.Update(msg)
+		a.create, _ = a.create.Update(msg)
+		a.onboarding, _ = a.onboarding.Update(msg)
+		return a, cursorBlinkCmd()
+
+	case onboardingStepMsg:
+		return a.updateOnboarding(msg)
+
+	case versionCheckMsg:
+		return a.recordVersionCheck(msg)
+
+	case serverInfoMsg:
+		return a.recordServerInfo(msg), nil
+
+	case updateCheckTickMsg:
+		return a, tea.Batch(a.updateCheckDue(), updateCheckTickCmd())
+
+	case startupAuthMsg:
+		return a.finishStartup(msg)
+
+	case sessionExpiredMsg:
+		return a.expireSession()
+
+	case reloginMsg:
+		return a.finishRelogin(msg)
+
+	case degradedRetryMsg:
+		if a.degraded == "" {
+			return a, nil
+		}
+		return a, a.loadMe()
+
 	case meLoadedMsg:
+		var retry tea.Cmd
+		if msg.err != nil {
+			glog.Warn("load profile failed", "err", msg.err)
+			// While degraded, keep retrying unless the startup check is
+			// still out; it reports on its own.
+			if a.degraded != "" && a.startupPending == nil {
+				a, retry = a.finishStartup(startupAuthMsg{err: msg.err})
+			}
+		}
 		if msg.err == nil && msg.me != nil {
 			a.me = msg.me
 			a.stats = msg.stats
+			a.degraded = ""
+			a.onboarding = a.onboarding.withMe(msg.me)
+			a = a.withCity(msg.me.City)
 		}
 		// Propagate to sub-models that need user identity
 		a.you, _ = a.you.Update(msg)
 		a.hall, _ = a.hall.Update(msg)
 		a.threads, _ = a.threads.Update(msg)
 		a.board, _ = a.board.Update(msg)
+		a.grimoire, _ = a.grimoire.Update(msg)
+		var cmd tea.Cmd
+		a.guild, cmd = a.guild.Update(msg)
+		return a, tea.Batch(cmd, retry)
+
+	case profileSavedMsg:
+		// A new city changes what's near you everywhere.
+		if msg.err == nil && msg.me != nil {
+			a.me = msg.me
+			a = a.withCity(msg.me.City)
+		}
+		a.you, _ = a.you.Update(msg)
+		return a, nil
+
+	case hallSpellsMsg:
+		a.hall, _ = a.hall.Update(msg)
 		return a, nil
 
+	case spellsLoadedMsg:
+		// Whatever the Grimoire loads, the Hall can recognize when pasted.
+		if msg.err == nil {
+			a.hall = a.hall.indexSpells(msg.spells)
+		}
+		a = a.fetched(viewGrimoire, msg.err)
+		var cmd tea.Cmd
+		a.grimoire, cmd = a.grimoire.Update(msg)
+		return a, cmd
+
+	// Tab data can arrive after a switch away, or from a prefetch, so it
+	// lands in its tab whichever one is showing.
+	case weaponsLoadedMsg:
+		a = a.fetched(viewGrimoire, msg.err)
+		a.grimoire, _ = a.grimoire.Update(msg)
+		return a, nil
+
+	case tagStatsLoadedMsg:
+		a.grimoire, _ = a.grimoire.Update(msg)
+		return a, nil
+
+	case boardLoadedMsg:
+		a = a.fetched(viewBoard, msg.err)
+		var cmd tea.Cmd
+		a.board, cmd = a.board.Update(msg)
+		if a.view != viewBoard {
+			// Off screen the board doesn't sync; tabInit resumes it.
+			cmd = nil
+		}
+		return a, cmd
+
+	case workshopLoadedMsg:
+		a = a.fetched(viewYou, msg.err)
+		var cmd tea.Cmd
+		a.you, cmd = a.you.Update(msg)
+		return a, cmd
+
+	case youInvitesLoadedMsg, youInviteProgressMsg, projectUpdatesMsg:
+		a.you, _ = a.you.Update(msg)
+		return a, nil
+
+	case prefetchTickMsg:
+		return a.prefetch()
+
+	case tourReplyMsg:
+		// Scripted replies land even if the user switched tabs meanwhile.
+		a.hall, _ = a.hall.Update(msg)
+		return a, nil
+
+	case guildRoomMsg:
+		if msg.err != nil {
+			a.guild, _ = a.guild.Update(msg)
+			return a, nil
+		}
+		a.guild.statusMsg = ""
+		a.hall = a.hall.enterRoom(msg.slug, msg.name)
+		a.view = viewHall
+		return a, a.hall.loadMessages()
+
+	case chestAddRequestMsg:
+		cmd, status := a.guild.addToChest(msg.spell)
+		if status != "" {
+			a.grimoire.statusMsg = status
+		}
+		return a, cmd
+
+	case chestSpellResultMsg:
+		// Adds come from the Grimoire, removals from the Guild tab.
+		a.grimoire, _ = a.grimoire.Update(msg)
+		var cmd tea.Cmd
+		a.guild, cmd = a.guild.Update(msg)
+		return a, cmd
+
+	case switcherLoadedMsg:
+		if a.switcherOpen {
+			a.switcher = a.switcher.loaded(msg)
+		}
+		return a, nil
+
+	case notificationJumpMsg:
+		return a.jumpToNotification(msg.n)
+
+	case notificationSpellMsg:
+		return a.showNotificationSpell(msg)
+
+	case notificationReadMsg:
+		// Reads are sent as the user jumps away, so land them regardless
+		// of the active view.
+		a.notifications, _ = a.notifications.Update(msg)
+		return a, nil
+
+	case streamJumpMsg:
+		return a.jumpToStreamEvent(msg.e)
+
+	case streamSpellMsg:
+		return a.showStreamSpell(msg)
+
+	case streamWeaponMsg:
+		return a.showStreamWeapon(msg)
+
+	case linkSpellMsg:
+		return a.showLinkSpell(msg)
+
+	case spellCastMsg:
+		if msg.err == nil && a.stats != nil {
+			stats := *a.stats
+			stats.SpellsCast = msg.cast.SpellsCast
+			a.stats = &stats
+		}
+		var cmd tea.Cmd
+		a.grimoire, cmd = a.grimoire.Update(msg)
+		return a, cmd
+
 	case showPeekMsg:
 		a.peekOpen = true
-		a.peek = newPeekModel(a.client)
+		a.peek = newPeekModel(a.client, a.myLogin())
+		a.peek.blocked = a.blocked[msg.login]
 		return a, a.peek.load(msg.login)
 
+	case openDMMsg:
+		a.peekOpen = false
+		a.view = viewThreads
+		var cmd tea.Cmd
+		a.threads, cmd = a.threads.startThread(msg.login)
+		return a, cmd
+
 	case tea.KeyMsg:
+		// The first-run wizard captures all keys until finished or put away
+		if a.onboardingOpen {
+			return a.updateOnboarding(msg)
+		}
+
+		// Release notes overlay captures all keys when open
+		if a.notesOpen {
+			switch msg.String() {
+			case "esc", "enter", "h":
+				a.notesOpen = false
+			case "q", "ctrl+c":
+				return a, tea.Quit
+			default:
+				a.notes = a.notes.Update(msg)
+			}
+			return a, nil
+		}
+
 		// Help overlay captures all keys when open
 		if a.helpOpen {
 			switch msg.String() {
@@ -159,6 +625,10 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			case "enter":
 				item := helpItems[a.helpCursor]
+				if item.label == whatsNewLabel {
+					a.helpOpen = false
+					return a.openReleaseNotes()
+				}
 				if item.url != "" {
 					browser.Open(item.url) //nolint:errcheck // best-effort browser open
 				}
@@ -176,6 +646,24 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, cmd
 		}
 
+		// Quick switcher captures all keys when open
+		if a.switcherOpen {
+			s, chosen, done := a.switcher.Update(msg)
+			a.switcher = s
+			if done {
+				a.switcherOpen = false
+			}
+			if chosen != nil {
+				return a.switchTo(*chosen)
+			}
+			return a, nil
+		}
+
+		// ctrl+t can't collide with typing, so it works even mid-message.
+		if msg.String() == "ctrl+t" {
+			return a.openSwitcher()
+		}
+
 		// Global keys (only when not editing)
 		if !a.isEditing() {
 			switch msg.String() {
@@ -194,39 +682,67 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "2":
 				if a.view != viewGrimoire {
 					a.view = viewGrimoire
-					return a, a.grimoire.Init()
+					return a, a.tabInit()
 				}
 				return a, nil
 			case "3":
 				if a.view != viewThreads {
 					a.view = viewThreads
-					return a, a.threads.Init()
+					return a, a.tabInit()
 				}
 				return a, nil
 			case "4":
 				if a.view != viewBoard {
 					a.view = viewBoard
-					return a, a.board.Init()
+					return a, a.tabInit()
 				}
 				return a, nil
 			case "5":
 				if a.view != viewYou {
 					a.view = viewYou
-					return a, a.you.Init()
+					return a, a.tabInit()
+				}
+				return a, nil
+			case "6":
+				if a.view != viewGuild {
+					a.view = viewGuild
+					return a, a.tabInit()
 				}
 				return a, nil
+			case "Z":
+				return a.toggleDND()
+			case "L":
+				if a.canRelogin() {
+					return a.startRelogin()
+				}
+			case "U":
+				if a.updateAvailable {
+					return a.dismissUpdate()
+				}
 			case "n":
 				if a.view != viewCreate {
 					a.view = viewCreate
 					return a, nil
 				}
+			case "N":
+				if a.view != viewNotifications {
+					return a.openNotifications()
+				}
+			case "R":
+				if a.view != viewRealm {
+					return a.openRealm()
+				}
+			case "S":
+				if a.view != viewStream {
+					return a.openStream()
+				}
 			case "esc":
-				if a.view == viewCreate {
+				if a.view == viewCreate || a.view == viewNotifications || a.view == viewRealm || a.view == viewStream {
 					a.view = viewHall
 					return a, a.hall.Init()
 				}
 			}
-		} else if msg.String() == "esc" && a.view == viewCreate {
+		} else if msg.String() == "esc" && a.view == viewCreate && !a.create.reviewing {
 			a.view = viewHall
 			return a, a.hall.Init()
 		}
@@ -256,33 +772,62 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.you, cmd = a.you.Update(msg)
 	case viewCreate:
 		a.create, cmd = a.create.Update(msg)
+	case viewGuild:
+		a.guild, cmd = a.guild.Update(msg)
+	case viewNotifications:
+		a.notifications, cmd = a.notifications.Update(msg)
+	case viewRealm:
+		a.realm, cmd = a.realm.Update(msg)
+	case viewStream:
+		a.stream, cmd = a.stream.Update(msg)
 	}
 
 	return a, cmd
 }
 
+// myLogin returns the signed-in user's login, or "" before startup loads it.
+func (a App) myLogin() string {
+	if a.me == nil {
+		return ""
+	}
+	return a.me.GitHubLogin
+}
+
 func (a App) isEditing() bool {
 	switch a.view {
 	case viewGrimoire:
-		return a.grimoire.editing
+		return a.grimoire.editing || a.grimoire.fill != nil || a.grimoire.report != nil
 	case viewCreate:
 		return true
 	case viewHall:
-		return a.hall.inputFocused
+		// The link picker claims the digit keys that normally switch tabs,
+		// and the room panel and roster take letters for their own keys.
+		// So does a report prompt.
+		return a.hall.inputFocused || a.hall.picker.active() || a.hall.report != nil || a.hall.rooms.open || a.hall.roster.open
 	case viewThreads:
-		return a.threads.inputFocused
+		return a.threads.inputFocused || a.threads.picker.active() || a.threads.report != nil || a.threads.starting
+	case viewBoard:
+		return a.board.searching
 	case viewYou:
-		return a.you.wsState != wsNormal
+		return a.you.editing()
 	}
 	return false
 }
 
 func (a App) View() string {
+	start := time.Now()
+	defer func() { metrics.ObserveFrame(time.Since(start)) }()
+
+	if a.locked {
+		return withGlyphs(a.lockView())
+	}
+
 	// Header: centered shimmer logo
 	logo := renderShimmerLogo(a.frame)
 
 	// Stats line below logo
 	statsLine := ""
+	now := clock()
 	if a.me != nil {
 		parts := []string{}
 		if a.me.CardNumber > 0 {
@@ -291,6 +836,9 @@ func (a App) View() string {
 		if a.stats != nil {
 			parts = append(parts, fmt.Sprintf("%d forged", a.stats.SpellsForged))
 			parts = append(parts, fmt.Sprintf("%.0f%% accepted", a.stats.AcceptanceRate*100))
+			if a.stats.SpellsCast > 0 {
+				parts = append(parts, fmt.Sprintf("%d cast", a.stats.SpellsCast))
+			}
 		}
 		if a.me.GuildID != "" {
 			parts = append(parts, GuildStyle(a.me.GuildID).Render(a.me.GuildID))
@@ -299,6 +847,12 @@ func (a App) View() string {
 			statsLine = metaStyle.Render(strings.Join(parts, " . "))
 		}
 	}
+	if badge := a.dndBadge(now); badge != "" {
+		if statsLine != "" {
+			statsLine += metaStyle.Render(" . ")
+		}
+		statsLine += badge
+	}
 
 	// Center the logo within terminal width
 	logoWidth := lipgloss.Width(logo)
@@ -308,10 +862,17 @@ func (a App) View() string {
 	}
 	header := strings.Repeat(" ", logoPad) + logo
 
-	// Build optional update notice
+	// Build optional header notice: degraded mode first, then an available
+	// update, then a server newer than this CLI, then the rate-limit hint.
 	updateNotice := ""
-	if a.updateAvailable {
-		updateNotice = dimStyle.Render("↑ " + a.latestVersion + " avail · grimora update")
+	if a.degraded != "" {
+		updateNotice = a.degradedBanner()
+	} else if a.updateAvailable {
+		updateNotice = a.updateBanner()
+	} else if a.serverAhead {
+		updateNotice = a.serverBanner()
+	} else if rateLimited(a.client) {
+		updateNotice = dimStyle.Render(rateLimitedStatus)
 	}
 
 	if statsLine != "" && updateNotice != "" {
@@ -368,6 +929,7 @@ func (a App) View() string {
 		{"3", "Threads", "Thr", viewThreads},
 		{"4", "Board", "Brd", viewBoard},
 		{"5", "You", "You", viewYou},
+		{"6", "Guild", "Gld", viewGuild},
 	}
 
 	// Measure which name set fits.
@@ -387,6 +949,11 @@ func (a App) View() string {
 		fullWidth += 3
 		shortWidth += 3
 	}
+	unread := watchUnread(a.you.subs)
+	if unread > 0 {
+		fullWidth += 3
+		shortWidth += 3
+	}
 
 	useShort := fullWidth > a.width
 	numbersOnly := shortWidth > a.width
@@ -415,6 +982,10 @@ func (a App) View() string {
 		if t.v == viewHall && a.hall.presenceCount > 0 {
 			label += " " + presenceDotStyle.Render("●") + dimStyle.Render(fmt.Sprintf("%d", a.hall.presenceCount))
 		}
+		// You tab: unread activity on watched items
+		if t.v == viewYou && unread > 0 {
+			label += " " + goldStyle.Render(fmt.Sprintf("✦%d", unread))
+		}
 		tabParts = append(tabParts, label)
 	}
 
@@ -429,25 +1000,50 @@ func (a App) View() string {
 		leftPad = 0
 	}
 	centeredTabs := strings.Repeat(" ", leftPad) + tabStr
+	if a.flashText != "" {
+		centeredTabs = renderFlash(a.flashText, a.width)
+	}
 
 	// Body
 	var body string
 	var help string
-	tabsHelp := "1-5"
+	tabsHelp := "1-6"
 	switch a.view {
 	case viewHall:
 		body = a.hall.View()
 		if a.hall.inputFocused {
 			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("enter", "send") + "  " + helpEntry("esc", "nav")
+		} else if a.hall.picker.active() {
+			help = " " + helpEntry("1-9", "open link") + "  " + helpEntry("esc", "cancel")
+		} else if a.hall.report != nil {
+			help = " " + a.hall.report.helpKeys()
+		} else if a.hall.roster.open {
+			help = " " + helpEntry("j/k", "select") + "  " + helpEntry("p", "peek") + "  " + helpEntry("f", "follow") + "  " + helpEntry("d", "message") + "  " + helpEntry("@", "mention") + "  " + helpEntry("esc", "close")
+		} else if a.hall.selecting {
+			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("j/k", "select") + "  " + helpEntry("r", "reply") + "  " + helpEntry("W", "watch") + "  " + helpEntry("+", "react") + "  " + helpEntry("o", "open link") + "  " + helpEntry("y", "copy link") + "  " + helpEntry("!", "report") + "  " + helpEntry("enter", "type") + "  " + helpEntry("esc", "done")
+		} else if a.hall.room != "" {
+			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("j/k", "scroll") + "  " + helpEntry("v", "select") + "  " + helpEntry("m", "who's here") + "  " + helpEntry("enter", "type") + "  " + helpEntry("esc", "leave room") + "  " + helpEntry("q", "quit")
 		} else {
-			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("j/k", "scroll") + "  " + helpEntry("enter", "type") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("j/k", "scroll") + "  " + helpEntry("v", "select") + "  " + helpEntry("m", "who's here") + "  " + helpEntry("enter", "type") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
 		}
 	case viewGrimoire:
 		body = a.grimoire.View()
-		if a.grimoire.detail {
-			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("u", "upvote") + "  " + helpEntry("c", "copy") + "  " + helpEntry("s", "save") + "  " + helpEntry("p", "peek") + "  " + helpEntry("esc", "back")
+		if a.grimoire.fill != nil {
+			help = " " + a.grimoire.fill.helpKeys()
+		} else if a.grimoire.report != nil {
+			help = " " + a.grimoire.report.helpKeys()
+		} else if a.grimoire.detail && a.grimoire.mode == grimoireModeWeapons {
+			clone := "copy clone"
+			if cloneDir != "" {
+				clone = "clone"
+			}
+			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("o", "open repo") + "  " + helpEntry("g", clone) + "  " + helpEntry("s", "save") + "  " + helpEntry("esc", "back")
+		} else if a.grimoire.detail {
+			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("u", "upvote") + "  " + helpEntry("x", "cast") + "  " + helpEntry("f", "fork") + "  " + helpEntry("V", "re-forge") + "  " + helpEntry("c", "copy") + "  " + helpEntry("C", "to file") + "  " + helpEntry("P", "publish") + "  " + helpEntry("s", "save") + "  " + helpEntry("b", "bookmark") + "  " + helpEntry("W", "watch") + "  " + helpEntry("G", "chest") + "  " + helpEntry("p", "peek") + "  " + helpEntry("!", "report") + "  " + helpEntry("esc", "back")
+		} else if a.grimoire.mode == grimoireModeWeapons {
+			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("j/k", "nav") + "  " + helpEntry("/", "search") + "  " + helpEntry("t/T", "category") + "  " + helpEntry("s", "save") + "  " + helpEntry("o", "open repo") + "  " + helpEntry("w", "toggle") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
 		} else {
-			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("j/k", "nav") + "  " + helpEntry("/", "search") + "  " + helpEntry("t", "tag") + "  " + helpEntry("s", "sort") + "  " + helpEntry("w", "toggle") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+			help = " " + helpEntry(tabsHelp, "tabs") + "  " + helpEntry("j/k", "nav") + "  " + helpEntry("/", "search") + "  " + helpEntry("t/T", "tag") + "  " + helpEntry("s", "sort") + "  " + helpEntry("?", "random") + "  " + helpEntry("b", "bookmark") + "  " + helpEntry("B", "saved") + "  " + helpEntry("W", "watch") + "  " + helpEntry("w", "toggle") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
 		}
 	case viewThreads:
 		body = a.threads.View()
@@ -458,15 +1054,43 @@ func (a App) View() string {
 	case viewYou:
 		body = a.you.View()
 		help = " " + helpEntry(tabsHelp, "tabs") + "  " + a.you.helpKeys()
+	case viewGuild:
+		body = a.guild.View()
+		help = " " + helpEntry(tabsHelp, "tabs") + "  " + a.guild.helpKeys()
+	case viewNotifications:
+		body = a.notifications.View()
+		help = " " + helpEntry(tabsHelp, "tabs") + "  " + a.notifications.helpKeys()
+	case viewRealm:
+		body = a.realm.View()
+		help = " " + helpEntry(tabsHelp, "tabs") + "  " + a.realm.helpKeys()
+	case viewStream:
+		body = a.stream.View()
+		help = " " + helpEntry(tabsHelp, "tabs") + "  " + a.stream.helpKeys()
 	case viewCreate:
 		body = a.create.View()
-		help = " " + helpEntry("tab", "next") + "  " + helpEntry("h/l", "tag") + "  " + helpEntry("ctrl+s", "submit") + "  " + helpEntry("esc", "cancel")
+		if a.create.reviewing {
+			help = " " + helpEntry("a", "accept") + "  " + helpEntry("x", "dismiss") + "  " + helpEntry("j/k", "next") + "  " + helpEntry("esc", "done")
+		} else {
+			help = " " + helpEntry("tab", "next") + "  " + helpEntry("←/→", "tag") + "  " + helpEntry("ctrl+s", "submit") + "  " + helpEntry("ctrl+l", "share") + "  " + helpEntry("ctrl+r", "suggestions") + "  " + helpEntry("esc", "cancel")
+		}
 	}
 
 	// Peek overlay
 	if a.peekOpen {
 		body = a.peek.View()
-		help = " " + helpEntry("f", "follow") + "  " + helpEntry("esc", "close")
+		help = " " + a.peek.helpKeys()
+	}
+
+	// Release notes overlay
+	if a.notesOpen {
+		body = a.notes.View()
+		help = " " + helpEntry("j/k", "scroll") + "  " + helpEntry("esc", "close")
+	}
+
+	// Quick switcher overlay
+	if a.switcherOpen {
+		body = a.switcher.View()
+		help = " " + helpEntry("type", "filter") + "  " + helpEntry("↑/↓", "select") + "  " + helpEntry("enter", "open") + "  " + helpEntry("esc", "close")
 	}
 
 	// Help overlay
@@ -475,6 +1099,12 @@ func (a App) View() string {
 		help = " " + helpEntry("j/k", "nav") + "  " + helpEntry("enter", "open") + "  " + helpEntry("esc", "close")
 	}
 
+	// First-run wizard
+	if a.onboardingOpen {
+		body = a.onboarding.View()
+		help = " " + a.onboarding.helpKeys()
+	}
+
 	// Truncate help bar to fit width — drop trailing entries instead of cutting mid-word.
 	if lipgloss.Width(help) > a.width {
 		help = truncateHelpBar(help, a.width)
@@ -483,7 +1113,7 @@ func (a App) View() string {
 	chrome := appChromeLines
 	body = strings.TrimRight(truncateToHeight(body, a.height-chrome), "\n")
 
-	return fmt.Sprintf("%s\n%s\n%s\n%s", header, centeredTabs, body, help)
+	return withGlyphs(fmt.Sprintf("%s\n%s\n%s\n%s", header, centeredTabs, body, a.withBell(help)))
 }
 
 // truncateHelpBar drops trailing help entries (separated by "  ") that would
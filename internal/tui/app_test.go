@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 
+	glog "github.com/naveenspark/grimora/internal/log"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
@@ -29,6 +31,7 @@ func TestAppTabSwitching(t *testing.T) {
 		{"3", viewThreads},
 		{"4", viewBoard},
 		{"5", viewYou},
+		{"6", viewGuild},
 	}
 
 	for _, tc := range tests {
@@ -139,6 +142,12 @@ func TestAppIsEditingYouWorkshop(t *testing.T) {
 		t.Error("expected isEditing=false when you.wsState=wsNormal")
 	}
 
+	// The detail screen browses, so global keys stay live
+	a.you.wsState = wsDetail
+	if a.isEditing() {
+		t.Error("expected isEditing=false when you.wsState=wsDetail")
+	}
+
 	// wsEditing should be editing
 	a.you.wsState = wsEditing
 	if !a.isEditing() {
@@ -347,3 +356,40 @@ func TestAppHallLayoutConnectingState(t *testing.T) {
 
 // Re-use makeTestMagicianCard from peek_test.go (same package)
 // It's already defined there.
+
+func TestAppLinkPickerCapturesDigits(t *testing.T) {
+	app := newTestApp()
+	app.hall.inputFocused = false
+	app.hall.selecting = true
+	app.hall.picker = newLinkPicker([]string{"https://a.dev", "https://b.dev"})
+
+	model, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	app = model.(App)
+	if app.view != viewHall {
+		t.Errorf("expected digit to go to the link picker, but view switched to %d", app.view)
+	}
+}
+
+func TestAppLogsStateTransitions(t *testing.T) {
+	var buf bytes.Buffer
+	glog.SetOutput(&buf)
+	t.Cleanup(func() { glog.SetOutput(&bytes.Buffer{}) })
+
+	a := newTestApp()
+	a.hall.inputFocused = false
+	m, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("5")})
+	if !strings.Contains(buf.String(), "view=you from=hall") {
+		t.Errorf("expected view transition in log, got %q", buf.String())
+	}
+
+	buf.Reset()
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	if buf.Len() != 0 {
+		t.Errorf("resize changed no logged state, but got %q", buf.String())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if !strings.Contains(buf.String(), "help=true") {
+		t.Errorf("expected help overlay in log, got %q", buf.String())
+	}
+}
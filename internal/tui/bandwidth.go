@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+// lowBandwidth is set by the low_bandwidth config setting (or
+// --low-bandwidth) for slow or metered links. Polls are stretched (see
+// config.Config.PollInterval), animations rest on their first frame like in
+// accessible mode, Hall reactions aren't fetched, other tabs aren't
+// prefetched and lists load lowBandwidthPageSize items at a time.
+var lowBandwidth bool
+
+// lowBandwidthPageSize replaces pageSize in low-bandwidth mode.
+const lowBandwidthPageSize = 20
+
+// slowed stretches a fixed background interval in low-bandwidth mode, as
+// config does for the configurable ones.
+func slowed(d time.Duration) time.Duration {
+	if lowBandwidth {
+		return d * config.LowBandwidthStretch
+	}
+	return d
+}
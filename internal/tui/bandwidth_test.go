@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestApplyConfigPollIntervals(t *testing.T) {
+	withConfig(t, config.Config{PollIntervals: map[string]string{config.PollHall: "7s"}})
+	if hallPollInterval != 7*time.Second || threadsPollInterval != 5*time.Second {
+		t.Errorf("hall = %v, threads = %v; want 7s and the 5s default", hallPollInterval, threadsPollInterval)
+	}
+}
+
+func TestLowBandwidthMode(t *testing.T) {
+	withConfig(t, config.Config{LowBandwidth: true, Flash: true})
+	if hallPollInterval != 12*time.Second || slowed(time.Minute) != 4*time.Minute {
+		t.Errorf("hall = %v, slowed(1m) = %v; want polls stretched 4x", hallPollInterval, slowed(time.Minute))
+	}
+	if pageSize != lowBandwidthPageSize {
+		t.Errorf("pageSize = %d, want %d", pageSize, lowBandwidthPageSize)
+	}
+	if animated() || cursorBlinkInterval != 0 || alertFlash || shimmerTickCmd() != nil {
+		t.Error("nothing should animate in low-bandwidth mode")
+	}
+
+	f := &clienttest.Fake{}
+	m := newHallModel(f)
+	m.width, m.height = 80, 24
+	m, _ = m.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{makeTestRoomMessage("alice", "loomari", "hi")}})
+	if m.reactionsBusy || f.Count("GetReactionCounts") != 0 {
+		t.Error("expected no reaction fetches in low-bandwidth mode")
+	}
+}
@@ -3,20 +3,84 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/naveenspark/grimora/internal/metrics"
 	"github.com/naveenspark/grimora/pkg/client"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
+// boardSyncInterval is how often the leaderboard refreshes in the background.
+// Rank movement is slow, so this is deliberately much lazier than chat polling.
+const boardSyncInterval = 60 * time.Second
+
+// boardPageSize is how many entries each board fetch asks for. Further
+// pages load as the cursor reaches the bottom.
+const boardPageSize = 50
+
+// boardHighlightDuration is how long a row stays highlighted after its rank changes.
+const boardHighlightDuration = 8 * time.Second
+
 // -- messages --
 
 type boardLoadedMsg struct {
-	entries []domain.LeaderboardEntry
-	err     error
+	guild, city string // the filters the board was fetched with
+	entries     []domain.LeaderboardEntry
+	limit       int
+	err         error
+	background  bool // result of a background sync rather than a user action
+}
+
+// boardPageMsg carries the page of the board starting at offset.
+type boardPageMsg struct {
+	guild, city string
+	offset      int
+	entries     []domain.LeaderboardEntry
+	err         error
+}
+
+// boardRankMsg carries the overall rank of a magician searched for by login.
+type boardRankMsg struct {
+	login string
+	entry *domain.LeaderboardEntry
+	err   error
+}
+
+// boardSyncTickMsg fires when a background sync is due. gen guards against
+// stale ticks left over from earlier loads.
+type boardSyncTickMsg struct {
+	gen int
+}
+
+// boardHighlightExpireMsg clears rank-change highlights from the sync with the given gen.
+type boardHighlightExpireMsg struct {
+	gen int
+}
+
+func boardSyncCmd(gen int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return boardSyncTickMsg{gen: gen}
+	})
+}
+
+func boardHighlightExpireCmd(gen int) tea.Cmd {
+	return tea.Tick(boardHighlightDuration, func(time.Time) tea.Msg {
+		return boardHighlightExpireMsg{gen: gen}
+	})
+}
+
+// boardGuildsMsg carries the guild standings. approx is set when the server
+// has no standings of its own and they were totalled from the magician
+// list, which carries no weekly history.
+type boardGuildsMsg struct {
+	standings []domain.GuildStanding
+	approx    bool
+	err       error
 }
 
 type boardFollowMsg struct {
@@ -27,7 +91,7 @@ type boardFollowMsg struct {
 // -- model --
 
 type boardModel struct {
-	client      *client.Client
+	client      client.API
 	entries     []domain.LeaderboardEntry
 	cursor      int
 	guildFilter string // "" = all, else guild id
@@ -36,16 +100,33 @@ type boardModel struct {
 	cityCycle   int    // index into cityOrder for cycling
 	cityOrder   []string
 	err         string
+	status      string // one-line notice under the board, e.g. a failed lookup
 	loading     bool
+	more        bool // the last page came back full, so there may be another
+	loadingMore bool
+	searching   bool                     // true while typing a login after /
+	query       string                   // login prefix the board is narrowed to
+	found       *domain.LeaderboardEntry // overall rank of the login searched for, when it isn't loaded
 	myLogin     string
+	myCity      string // from your profile, for l
 	width       int
 	height      int
+	syncGen     int            // incremented each time a background sync is scheduled
+	moves       map[string]int // login -> rank delta (positive = climbed) seen during the last sync
+	movesGen    int            // syncGen at which moves were recorded
+
+	guildMode     bool // showing guild standings instead of magicians
+	guilds        []domain.GuildStanding
+	guildCursor   int
+	guildsApprox  bool // guilds were totalled from the magician list
+	guildsErr     string
+	guildsLoading bool
 }
 
 // guildOrder is the cycle order for guild filtering.
 var guildOrder = []string{"", "loomari", "ashborne", "amarok", "nyx", "cipher", "fathom"}
 
-func newBoardModel(c *client.Client) boardModel {
+func newBoardModel(c client.API) boardModel {
 	return boardModel{client: c}
 }
 
@@ -71,13 +152,124 @@ func (m boardModel) Init() tea.Cmd {
 }
 
 func (m boardModel) loadBoard() tea.Cmd {
+	return m.fetchBoard(false)
+}
+
+// fetchBoard loads the board from the top. A background sync asks for as
+// many entries as are already loaded, so paging down isn't undone by it.
+func (m boardModel) fetchBoard(background bool) tea.Cmd {
+	c := m.client
+	guild := m.guildFilter
+	city := m.cityFilter
+	limit := boardPageSize
+	if background && len(m.entries) > limit {
+		limit = len(m.entries)
+	}
+	return func() tea.Msg {
+		entries, err := c.GetLeaderboard(context.Background(), guild, city, limit, 0)
+		return boardLoadedMsg{guild: guild, city: city, entries: entries, limit: limit, err: err, background: background}
+	}
+}
+
+// resumeSync restarts background syncing for a board that was loaded while
+// off screen, without reloading it first.
+func (m boardModel) resumeSync() tea.Cmd {
+	return boardSyncCmd(m.syncGen, pollDelay(m.client, slowed(boardSyncInterval)))
+}
+
+// fetchGuilds loads the guild standings. Servers without them answer not
+// found, and the standings are totalled from the magician list instead.
+func (m boardModel) fetchGuilds() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		ctx := context.Background()
+		standings, err := c.GetGuildLeaderboard(ctx)
+		if !client.IsNotFound(err) {
+			return boardGuildsMsg{standings: standings, err: err}
+		}
+		cards, err := client.MagiciansIter(ctx, c, magicianPageSize).Collect()
+		if err != nil {
+			return boardGuildsMsg{err: err}
+		}
+		return boardGuildsMsg{standings: domain.RankGuilds(cards), approx: true}
+	}
+}
+
+// fetchPage loads the page after the entries already on the board.
+func (m boardModel) fetchPage() tea.Cmd {
 	c := m.client
 	guild := m.guildFilter
 	city := m.cityFilter
+	offset := len(m.entries)
+	return func() tea.Msg {
+		entries, err := c.GetLeaderboard(context.Background(), guild, city, boardPageSize, offset)
+		return boardPageMsg{guild: guild, city: city, offset: offset, entries: entries, err: err}
+	}
+}
+
+// fetchRank looks up login's overall rank.
+func (m boardModel) fetchRank(login string) tea.Cmd {
+	c := m.client
 	return func() tea.Msg {
-		entries, err := c.GetLeaderboard(context.Background(), guild, city, 50, 0)
-		return boardLoadedMsg{entries: entries, err: err}
+		entry, err := c.GetLeaderboardRank(context.Background(), login)
+		return boardRankMsg{login: login, entry: entry, err: err}
+	}
+}
+
+// maybeLoadMore fetches the next page once the cursor is on the last loaded
+// entry. A search narrows what's loaded, so it doesn't page.
+func (m boardModel) maybeLoadMore() (boardModel, tea.Cmd) {
+	if m.query != "" || !m.more || m.loading || m.loadingMore || m.cursor < len(m.entries)-1 {
+		return m, nil
+	}
+	m.loadingMore = true
+	return m, m.fetchPage()
+}
+
+// rows returns the entries on screen: everything loaded, or with a search,
+// the loaded logins starting with the query followed by the searched
+// magician's overall rank if they aren't loaded yet.
+func (m boardModel) rows() []domain.LeaderboardEntry {
+	if m.query == "" {
+		return m.entries
+	}
+	q := strings.ToLower(m.query)
+	var out []domain.LeaderboardEntry
+	for _, e := range m.entries {
+		if strings.HasPrefix(strings.ToLower(e.Login), q) {
+			out = append(out, e)
+		}
+	}
+	if m.found != nil && !m.loaded(m.found.Login) {
+		out = append(out, *m.found)
+	}
+	return out
+}
+
+// loaded reports whether login is among the loaded entries, ignoring case.
+func (m boardModel) loaded(login string) bool {
+	for _, e := range m.entries {
+		if strings.EqualFold(e.Login, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankMoves compares two snapshots of the same leaderboard and returns the
+// rank delta for every magician present in both whose rank changed.
+func rankMoves(prev, next []domain.LeaderboardEntry) map[string]int {
+	old := make(map[string]int, len(prev))
+	for _, e := range prev {
+		old[e.Login] = e.Rank
+	}
+	moves := make(map[string]int)
+	for _, e := range next {
+		if r, ok := old[e.Login]; ok && r != e.Rank {
+			moves[e.Login] = r - e.Rank
+		}
 	}
+	return moves
 }
 
 func (m boardModel) Update(msg tea.Msg) (boardModel, tea.Cmd) {
@@ -92,19 +284,94 @@ func (m boardModel) Update(msg tea.Msg) (boardModel, tea.Cmd) {
 		}
 
 	case boardLoadedMsg:
+		// A slow answer for filters since changed would overwrite the
+		// board they show now; the load for those is still on its way.
+		if msg.guild != m.guildFilter || msg.city != m.cityFilter {
+			return m, nil
+		}
 		m.loading = false
+		m.syncGen++
+		cmds := []tea.Cmd{boardSyncCmd(m.syncGen, pollDelay(m.client, slowed(boardSyncInterval)))}
 		if msg.err != nil {
-			m.err = msg.err.Error()
+			// A failed background sync keeps the last good board on screen.
+			if !msg.background || len(m.entries) == 0 {
+				m.err = errReason(msg.err)
+			}
 		} else {
+			m.moves = nil
+			if msg.background {
+				if moves := rankMoves(m.entries, msg.entries); len(moves) > 0 {
+					m.moves = moves
+					m.movesGen = m.syncGen
+					cmds = append(cmds, boardHighlightExpireCmd(m.syncGen))
+				}
+			}
 			m.entries = msg.entries
+			m.more = msg.limit > 0 && len(msg.entries) >= msg.limit
 			m.err = ""
-			if m.cursor >= len(m.entries) {
+			if m.cursor >= len(m.rows()) {
 				m.cursor = 0
 			}
-			if m.cityFilter == "" {
+			if m.cityFilter == "" && !msg.background {
 				m.buildCityOrder()
 			}
 		}
+		return m, tea.Batch(cmds...)
+
+	case boardPageMsg:
+		// A page for other filters, or after a reload, no longer lines up.
+		// Either way the fetch is over, so the next one may start.
+		m.loadingMore = false
+		if msg.guild != m.guildFilter || msg.city != m.cityFilter || msg.offset != len(m.entries) {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.status = errText("could not load more", msg.err)
+			return m, nil
+		}
+		m.entries = append(m.entries, msg.entries...)
+		m.more = len(msg.entries) >= boardPageSize
+		m.status = ""
+
+	case boardRankMsg:
+		if !strings.EqualFold(msg.login, m.query) {
+			return m, nil
+		}
+		switch {
+		case client.IsNotFound(msg.err):
+			m.status = "no magician named " + msg.login + " on the board"
+		case msg.err != nil:
+			m.status = errText("could not look up "+msg.login, msg.err)
+		default:
+			m.found = msg.entry
+			m.status = ""
+		}
+
+	case boardSyncTickMsg:
+		if msg.gen == m.syncGen && !m.loading {
+			metrics.ObservePoll("board")
+			return m, m.fetchBoard(true)
+		}
+
+	case boardHighlightExpireMsg:
+		if msg.gen == m.movesGen {
+			m.moves = nil
+		}
+
+	case boardGuildsMsg:
+		m.guildsLoading = false
+		if msg.err != nil {
+			if len(m.guilds) == 0 {
+				m.guildsErr = errReason(msg.err)
+			}
+			return m, nil
+		}
+		m.guilds = msg.standings
+		m.guildsApprox = msg.approx
+		m.guildsErr = ""
+		if m.guildCursor >= len(m.guilds) {
+			m.guildCursor = 0
+		}
 
 	case boardFollowMsg:
 		// Refresh after follow action
@@ -119,15 +386,41 @@ func (m boardModel) Update(msg tea.Msg) (boardModel, tea.Cmd) {
 }
 
 func (m boardModel) handleKey(msg tea.KeyMsg) (boardModel, tea.Cmd) {
+	if m.searching {
+		return m.handleSearchKey(msg)
+	}
+	if m.guildMode {
+		return m.handleGuildKey(msg)
+	}
+	rows := m.rows()
 	switch msg.String() {
 	case "j", "down":
-		if m.cursor < len(m.entries)-1 {
+		if m.cursor < len(rows)-1 {
 			m.cursor++
 		}
+		return m.maybeLoadMore()
 	case "k", "up":
 		if m.cursor > 0 {
 			m.cursor--
 		}
+	case "ctrl+d":
+		m.cursor = min(m.cursor+m.visibleRows()/2, max(len(rows)-1, 0))
+		return m.maybeLoadMore()
+	case "ctrl+u":
+		m.cursor = max(m.cursor-m.visibleRows()/2, 0)
+	case "/":
+		m.searching = true
+		m.query = ""
+		m.found = nil
+		m.status = ""
+		m.cursor = 0
+	case "esc":
+		if m.query != "" {
+			m.query = ""
+			m.found = nil
+			m.status = ""
+			m.cursor = 0
+		}
 	case "g":
 		// Cycle guild filter
 		m.guildCycle = (m.guildCycle + 1) % len(guildOrder)
@@ -143,14 +436,16 @@ func (m boardModel) handleKey(msg tea.KeyMsg) (boardModel, tea.Cmd) {
 			m.loading = true
 			return m, m.loadBoard()
 		}
+	case "l":
+		return m.toggleNearMe()
 	case "p":
-		if len(m.entries) > 0 && m.cursor < len(m.entries) {
-			login := m.entries[m.cursor].Login
+		if m.cursor < len(rows) {
+			login := rows[m.cursor].Login
 			return m, func() tea.Msg { return showPeekMsg{login: login} }
 		}
 	case "f":
-		if len(m.entries) > 0 && m.cursor < len(m.entries) {
-			login := m.entries[m.cursor].Login
+		if m.cursor < len(rows) {
+			login := rows[m.cursor].Login
 			c := m.client
 			return m, func() tea.Msg {
 				err := c.Follow(context.Background(), login)
@@ -160,11 +455,90 @@ func (m boardModel) handleKey(msg tea.KeyMsg) (boardModel, tea.Cmd) {
 	case "r":
 		m.loading = true
 		return m, m.loadBoard()
+	case "G":
+		m.guildMode = true
+		m.status = ""
+		if !m.guildsLoading {
+			m.guildsLoading = true
+			return m, m.fetchGuilds()
+		}
+	}
+	return m, nil
+}
+
+// handleGuildKey handles keys while the board shows guild standings. Enter
+// goes back to the magicians, filtered to the guild under the cursor.
+func (m boardModel) handleGuildKey(msg tea.KeyMsg) (boardModel, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.guildCursor < len(m.guilds)-1 {
+			m.guildCursor++
+		}
+	case "k", "up":
+		if m.guildCursor > 0 {
+			m.guildCursor--
+		}
+	case "enter":
+		if m.guildCursor < len(m.guilds) {
+			id := m.guilds[m.guildCursor].GuildID
+			m.guildMode = false
+			m.guildFilter = id
+			m.guildCycle = max(slices.Index(guildOrder, id), 0)
+			m.cursor = 0
+			m.loading = true
+			return m, m.loadBoard()
+		}
+	case "r":
+		m.guildsLoading = true
+		return m, m.fetchGuilds()
+	case "G", "esc":
+		m.guildMode = false
 	}
 	return m, nil
 }
 
+// handleSearchKey edits the login search. Enter keeps the narrowed board
+// and, unless the login is already loaded, looks up its overall rank.
+func (m boardModel) handleSearchKey(msg tea.KeyMsg) (boardModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.query = strings.TrimPrefix(strings.TrimSpace(m.query), "@")
+		if m.query != "" && !m.loaded(m.query) && m.client != nil {
+			m.status = "looking up " + m.query + "..."
+			return m, m.fetchRank(m.query)
+		}
+	case "esc":
+		m.searching = false
+		m.query = ""
+	default:
+		key := msg.String()
+		if msg.Paste {
+			key = string(msg.Runes)
+		}
+		m.query = editRune(m.query, key)
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+// boardChromeLines is the search line, status line and blank line plus
+// hint under the rows.
+const boardChromeLines = 4
+
+// visibleRows is how many board rows fit on screen.
+func (m boardModel) visibleRows() int {
+	n := m.height - boardChromeLines
+	if m.guildFilter != "" || m.cityFilter != "" {
+		n--
+	}
+	return max(n, 3)
+}
+
 func (m boardModel) View() string {
+	if m.guildMode {
+		return m.guildsView()
+	}
 	var b strings.Builder
 
 	// Filter line (only show if a filter is active)
@@ -174,11 +548,21 @@ func (m boardModel) View() string {
 			parts = append(parts, GuildStyle(m.guildFilter).Render(m.guildFilter))
 		}
 		if m.cityFilter != "" {
-			parts = append(parts, dimStyle.Render(m.cityFilter))
+			city := m.cityFilter
+			if m.nearMe() {
+				city += " (near you)"
+			}
+			parts = append(parts, dimStyle.Render(city))
 		}
 		b.WriteString(" " + strings.Join(parts, dimStyle.Render(" · ")) + "\n")
 	}
 
+	if m.searching {
+		b.WriteString(" " + searchStyle.Render("/ "+m.query+"\u2588") + "\n")
+	} else if m.query != "" {
+		b.WriteString(" " + searchStyle.Render("/ "+m.query) + "  " + dimStyle.Render("esc clear") + "\n")
+	}
+
 	if m.loading && len(m.entries) == 0 {
 		b.WriteString(" " + dimStyle.Render("loading...") + "\n")
 		return b.String()
@@ -192,7 +576,23 @@ func (m boardModel) View() string {
 		return b.String()
 	}
 
-	for i, entry := range m.entries {
+	rows := m.rows()
+	if len(rows) == 0 {
+		if m.status != "" {
+			b.WriteString(" " + dimStyle.Render(m.status) + "\n")
+		} else {
+			b.WriteString(" " + dimStyle.Render("no magician on the board starts with "+m.query) + "\n")
+		}
+		return b.String()
+	}
+
+	visible := m.visibleRows()
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	for i := start; i < len(rows) && i < start+visible; i++ {
+		entry := rows[i]
 		isActive := i == m.cursor
 		isYou := m.myLogin != "" && entry.Login == m.myLogin
 
@@ -231,24 +631,101 @@ func (m boardModel) View() string {
 			youMarker = " " + accentStyle.Render("<- you")
 		}
 
-		row := fmt.Sprintf(" %s %s  %s  %s", cursor, rankStr, loginStyled, spells)
+		moveStr := "   "
+		delta, moved := m.moves[entry.Login]
+		switch {
+		case delta > 0:
+			moveStr = upvoteStyle.Render(fmt.Sprintf("▲%-2d", delta))
+		case delta < 0:
+			moveStr = rejectStyle.Render(fmt.Sprintf("▼%-2d", -delta))
+		}
+
+		row := fmt.Sprintf(" %s %s %s  %s  %s", cursor, rankStr, moveStr, loginStyled, spells)
 		if potencyStr != "" {
 			row += "  " + potencyStr
 		}
 		if cityStr != "" {
 			row += "  " + cityStr
 		}
-		row += youMarker + "\n"
-		b.WriteString(row)
+		row += youMarker
+		if m.found != nil && entry.Login == m.found.Login && (m.guildFilter != "" || m.cityFilter != "") {
+			row += "  " + dimStyle.Render("overall")
+		}
+		row = clipWidth(row, m.width)
+		if moved {
+			row = selectedRowBg.Render(row)
+		}
+		b.WriteString(row + "\n")
+	}
+
+	if m.status != "" {
+		b.WriteString(" " + dimStyle.Render(m.status) + "\n")
 	}
 
 	// Filter hint
-	filterHint := dimStyle.Render("g cycle guild · c cycle city")
-	b.WriteString("\n " + filterHint + "\n")
+	filterHint := dimStyle.Render("g cycle guild · c cycle city · l near you · / find a magician · G guild standings")
+	if m.loadingMore {
+		filterHint = dimStyle.Render("loading more...")
+	}
+	b.WriteString("\n " + clipWidth(filterHint, m.width-1) + "\n")
 
 	return b.String()
 }
 
+// guildsView ranks the six guilds by total potency, with each one's weekly
+// gains when the server keeps them.
+func (m boardModel) guildsView() string {
+	var b strings.Builder
+	b.WriteString(" " + accentStyle.Render("guild standings") + "\n")
+	if m.guildsLoading && len(m.guilds) == 0 {
+		b.WriteString(" " + dimStyle.Render("loading...") + "\n")
+		return b.String()
+	}
+	if m.guildsErr != "" {
+		b.WriteString(" " + dimStyle.Render("error: "+m.guildsErr) + "\n")
+		return b.String()
+	}
+	for i, s := range m.guilds {
+		cursor := " "
+		if i == m.guildCursor {
+			cursor = accentStyle.Render("▸")
+		}
+		name := s.GuildID
+		if g, ok := domain.Guilds[s.GuildID]; ok {
+			name = g.Name
+		}
+		moveStr := "   "
+		switch delta := s.RankChange(); {
+		case delta > 0:
+			moveStr = upvoteStyle.Render(fmt.Sprintf("▲%-2d", delta))
+		case delta < 0:
+			moveStr = rejectStyle.Render(fmt.Sprintf("▼%-2d", -delta))
+		}
+		row := fmt.Sprintf(" %s %s %s  %s  %s  %s  %s", cursor,
+			rankStyle(s.Rank).Render(fmt.Sprintf("#%-3d", s.Rank)), moveStr,
+			GuildStyle(s.GuildID).Render(fmt.Sprintf("%-10s", name)),
+			metaStyle.Render(fmt.Sprintf("%-11s", fmt.Sprintf("%d member%s", s.Members, plural(s.Members)))),
+			metaStyle.Render(fmt.Sprintf("%-10s", fmt.Sprintf("%d spell%s", s.Spells, plural(s.Spells)))),
+			goldStyle.Render(fmt.Sprintf("P%d", s.Potency)))
+		// The week's gains are the first thing to go on a narrow terminal.
+		if s.Week != nil && (s.Week.Spells > 0 || s.Week.Potency > 0) {
+			week := fmt.Sprintf("+%d spells · +%d potency this week", s.Week.Spells, s.Week.Potency)
+			if m.width <= 0 || textWidth(row)+2+textWidth(week) <= m.width {
+				row += "  " + dimStyle.Render(week)
+			}
+		}
+		b.WriteString(clipWidth(row, m.width) + "\n")
+	}
+	if m.guildsApprox {
+		b.WriteString(" " + dimStyle.Render("totals from the magician list · no weekly history") + "\n")
+	}
+	b.WriteString("\n " + dimStyle.Render("enter show its magicians · G magicians") + "\n")
+	return b.String()
+}
+
 func (m boardModel) helpKeys() string {
-	return helpEntry("j/k", "nav") + "  " + helpEntry("g", "guild") + "  " + helpEntry("c", "city") + "  " + helpEntry("p", "peek") + "  " + helpEntry("f", "follow") + "  " + helpEntry("r", "refresh") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+	if m.guildMode {
+		return helpEntry("j/k", "nav") + "  " + helpEntry("enter", "magicians") + "  " + helpEntry("G", "magicians") + "  " + helpEntry("r", "refresh") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+	}
+	return helpEntry("j/k", "nav") + "  " + helpEntry("^d/^u", "page") + "  " + helpEntry("/", "find") + "  " + helpEntry("g", "guild") + "  " + helpEntry("c", "city") + "  " + helpEntry("l", "near you") + "  " + helpEntry("G", "guilds") + "  " + helpEntry("p", "peek") + "  " + helpEntry("f", "follow") + "  " + helpEntry("r", "refresh") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
 }
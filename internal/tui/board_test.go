@@ -1,12 +1,17 @@
 package tui
 
 import (
+	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/google/uuid"
 
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
@@ -168,3 +173,287 @@ func TestBoardCityFilterInHeader(t *testing.T) {
 		t.Errorf("expected 'Tokyo' in board header, got:\n%s", view)
 	}
 }
+
+func TestBoardBackgroundSyncHighlightsRankChanges(t *testing.T) {
+	m := newTestBoardModel()
+	m, _ = m.Update(boardLoadedMsg{entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "alpha", "nyx", 10, 5),
+		makeTestLeaderboardEntry(2, "beta", "cipher", 9, 4),
+		makeTestLeaderboardEntry(3, "gamma", "fathom", 8, 3),
+	}})
+	if len(m.moves) != 0 {
+		t.Fatalf("expected no moves after initial load, got %v", m.moves)
+	}
+
+	m, cmd := m.Update(boardLoadedMsg{background: true, entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "beta", "cipher", 11, 6),
+		makeTestLeaderboardEntry(2, "alpha", "nyx", 10, 5),
+		makeTestLeaderboardEntry(3, "gamma", "fathom", 8, 3),
+	}})
+	if cmd == nil {
+		t.Fatal("expected sync and highlight-expiry commands")
+	}
+	if m.moves["beta"] != 1 || m.moves["alpha"] != -1 {
+		t.Errorf("unexpected moves: %v", m.moves)
+	}
+	if _, ok := m.moves["gamma"]; ok {
+		t.Error("unchanged rank should not be highlighted")
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "▲1") || !strings.Contains(view, "▼1") {
+		t.Errorf("expected movement arrows in board view, got:\n%s", view)
+	}
+
+	m, _ = m.Update(boardHighlightExpireMsg{gen: m.movesGen})
+	if m.moves != nil {
+		t.Error("expected highlights cleared after expiry")
+	}
+}
+
+func TestBoardManualReloadDoesNotHighlight(t *testing.T) {
+	m := newTestBoardModel()
+	m, _ = m.Update(boardLoadedMsg{entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "alpha", "nyx", 10, 5),
+		makeTestLeaderboardEntry(2, "beta", "cipher", 9, 4),
+	}})
+	m, _ = m.Update(boardLoadedMsg{entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "beta", "cipher", 11, 6),
+		makeTestLeaderboardEntry(2, "alpha", "nyx", 10, 5),
+	}})
+	if len(m.moves) != 0 {
+		t.Errorf("manual reload should not highlight, got %v", m.moves)
+	}
+}
+
+func TestBoardSyncTickIgnoresStaleGeneration(t *testing.T) {
+	m := newTestBoardModel()
+	m, _ = m.Update(boardLoadedMsg{entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "alpha", "nyx", 10, 5),
+	}})
+
+	_, cmd := m.Update(boardSyncTickMsg{gen: m.syncGen - 1})
+	if cmd != nil {
+		t.Error("stale sync tick should not trigger a fetch")
+	}
+	_, cmd = m.Update(boardSyncTickMsg{gen: m.syncGen})
+	if cmd == nil {
+		t.Error("current sync tick should trigger a background fetch")
+	}
+}
+
+func TestBoardDropsBoardForOldFilters(t *testing.T) {
+	m := newTestBoardModel()
+	m.guildFilter, m.loading = "nyx", true
+	m, _ = m.Update(boardLoadedMsg{entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "alpha", "loomari", 10, 5),
+	}})
+	if len(m.entries) != 0 || !m.loading {
+		t.Fatalf("entries = %v, want the unfiltered board dropped", m.entries)
+	}
+	m, _ = m.Update(boardLoadedMsg{guild: "nyx", entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "beta", "nyx", 8, 4),
+	}})
+	if len(m.entries) != 1 || m.entries[0].Login != "beta" || m.loading {
+		t.Errorf("entries = %v, want the nyx board", m.entries)
+	}
+}
+
+func TestBoardBackgroundSyncErrorKeepsEntries(t *testing.T) {
+	m := newTestBoardModel()
+	m, _ = m.Update(boardLoadedMsg{entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "alpha", "nyx", 10, 5),
+	}})
+	m, _ = m.Update(boardLoadedMsg{background: true, err: fmt.Errorf("boom")})
+	if m.err != "" {
+		t.Errorf("background error should not replace board, got err %q", m.err)
+	}
+	if !strings.Contains(m.View(), "alpha") {
+		t.Error("expected previous entries to remain visible")
+	}
+}
+
+func makeTestLeaderboard(n int) []domain.LeaderboardEntry {
+	entries := make([]domain.LeaderboardEntry, n)
+	for i := range entries {
+		entries[i] = makeTestLeaderboardEntry(i+1, fmt.Sprintf("mage%03d", i+1), "nyx", n-i, 0)
+	}
+	return entries
+}
+
+func TestBoardLoadsNextPageAtBottom(t *testing.T) {
+	f := &clienttest.Fake{Leaderboard: makeTestLeaderboard(boardPageSize + 10)}
+	m := newBoardModel(f)
+	m.height = 30
+	m, _ = m.Update(m.fetchBoard(false)())
+	if len(m.entries) != boardPageSize || !m.more {
+		t.Fatalf("got %d entries (more %v), want a full first page", len(m.entries), m.more)
+	}
+
+	var cmd tea.Cmd
+	for range boardPageSize - 1 {
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	if cmd == nil || !m.loadingMore {
+		t.Fatal("expected reaching the last entry to fetch the next page")
+	}
+	m, _ = m.Update(cmd())
+	if len(m.entries) != boardPageSize+10 || m.more {
+		t.Errorf("got %d entries (more %v), want all of them and no more", len(m.entries), m.more)
+	}
+	if !strings.Contains(m.View(), "mage050") {
+		t.Error("expected the view to scroll with the cursor")
+	}
+
+	// A page fetched before the guild changed is dropped.
+	m.loadingMore = true
+	m, _ = m.Update(boardPageMsg{guild: "cipher", offset: len(m.entries), entries: makeTestLeaderboard(3)})
+	if len(m.entries) != boardPageSize+10 {
+		t.Errorf("stale page appended: %d entries", len(m.entries))
+	}
+	if m.loadingMore {
+		t.Error("a stale page should still end the fetch, or paging stops")
+	}
+}
+
+func TestBoardPageKeys(t *testing.T) {
+	m := newTestBoardModel()
+	m, _ = m.Update(boardLoadedMsg{entries: makeTestLeaderboard(20), limit: boardPageSize})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if m.cursor != m.visibleRows()/2 {
+		t.Errorf("cursor = %d after ctrl+d, want %d", m.cursor, m.visibleRows()/2)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d after ctrl+u, want 0", m.cursor)
+	}
+}
+
+func TestBoardSearchByLoginPrefix(t *testing.T) {
+	m := newTestBoardModel()
+	m, _ = m.Update(boardLoadedMsg{entries: []domain.LeaderboardEntry{
+		makeTestLeaderboardEntry(1, "alpha", "nyx", 10, 5),
+		makeTestLeaderboardEntry(2, "beta", "nyx", 8, 3),
+		makeTestLeaderboardEntry(3, "Alfred", "nyx", 6, 1),
+	}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("al")})
+	if !m.searching {
+		t.Fatal("expected / to start a search")
+	}
+	rows := m.rows()
+	if len(rows) != 2 || rows[0].Login != "alpha" || rows[1].Login != "Alfred" {
+		t.Errorf("rows = %+v, want alpha and Alfred", rows)
+	}
+	view := m.View()
+	if strings.Contains(view, "beta") {
+		t.Errorf("expected beta filtered out, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.searching || m.query != "" || len(m.rows()) != 3 {
+		t.Errorf("expected esc to clear the search, got query %q", m.query)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("be"), Paste: true})
+	if m.query != "be" {
+		t.Errorf("query = %q after a paste, want it without brackets", m.query)
+	}
+}
+
+func TestBoardSearchFetchesRank(t *testing.T) {
+	board := makeTestLeaderboard(boardPageSize + 400)
+	f := &clienttest.Fake{Leaderboard: board}
+	m := newBoardModel(f)
+	m.height = 30
+	m, _ = m.Update(m.fetchBoard(false)())
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("mage412")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to look up a login that isn't loaded")
+	}
+	m, _ = m.Update(cmd())
+	rows := m.rows()
+	if len(rows) != 1 || rows[0].Rank != 412 {
+		t.Fatalf("rows = %+v, want mage412 at 412", rows)
+	}
+	if !strings.Contains(m.View(), "#412") {
+		t.Error("expected the looked-up rank on screen")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("nobody")})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(cmd())
+	if !strings.Contains(m.View(), "no magician named nobody") {
+		t.Errorf("expected a not-found notice, got:\n%s", m.View())
+	}
+}
+
+func TestAppBoardSearchSwallowsGlobalKeys(t *testing.T) {
+	a := newTestApp()
+	a.view = viewBoard
+	a.board.searching = true
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	a = model.(App)
+	if a.board.query != "q" {
+		t.Errorf("query = %q, want q typed rather than quitting", a.board.query)
+	}
+}
+
+func TestBoardGuildStandings(t *testing.T) {
+	f := &clienttest.Fake{GuildStandings: []domain.GuildStanding{
+		{GuildID: "nyx", Rank: 1, Members: 3, Spells: 12, Potency: 40, Week: &domain.GuildWeek{Spells: 2, Potency: 5, LastRank: 2}},
+		{GuildID: "cipher", Rank: 2, Members: 1, Spells: 4, Potency: 9, Week: &domain.GuildWeek{LastRank: 1}},
+	}}
+	m := newBoardModel(f)
+	m.height = 30
+
+	m, cmd := m.Update(keyRune('G'))
+	if !m.guildMode || cmd == nil {
+		t.Fatalf("G: guildMode = %v, cmd = %v; want guild standings loading", m.guildMode, cmd)
+	}
+	m, _ = m.Update(cmd())
+
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Nyx", "▲1", "3 members", "P40", "+2 spells · +5 potency this week", "Cipher", "▼1", "1 member "} {
+		if !strings.Contains(view, want) {
+			t.Errorf("guild standings missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "no weekly history") {
+		t.Errorf("server standings shown as approximate:\n%s", view)
+	}
+
+	m, _ = m.Update(keyRune('j'))
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.guildMode || m.guildFilter != "cipher" || guildOrder[m.guildCycle] != "cipher" || cmd == nil {
+		t.Errorf("enter: guildMode = %v, guildFilter = %q; want cipher's magicians loading", m.guildMode, m.guildFilter)
+	}
+}
+
+func TestBoardGuildStandingsFallBack(t *testing.T) {
+	f := &clienttest.Fake{
+		Fail: map[string]error{"GetGuildLeaderboard": &client.HTTPError{StatusCode: http.StatusNotFound}},
+		Magicians: []domain.MagicianCard{
+			{Magician: domain.Magician{GuildID: "fathom"}, SpellCount: 2, TotalPotency: 7},
+		},
+	}
+	m := newBoardModel(f)
+	m, cmd := m.Update(keyRune('G'))
+	m, _ = m.Update(cmd())
+
+	if len(m.guilds) != len(domain.Guilds) || m.guilds[0].GuildID != "fathom" || !m.guildsApprox {
+		t.Fatalf("guilds = %+v, approx = %v; want totals from the magician list", m.guilds, m.guildsApprox)
+	}
+	if view := m.View(); !strings.Contains(view, "no weekly history") {
+		t.Errorf("approximate standings not marked:\n%s", view)
+	}
+	if m, _ = m.Update(keyRune('G')); m.guildMode {
+		t.Error("G again stayed on guild standings")
+	}
+}
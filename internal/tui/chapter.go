@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// noCityStatus asks for a city before anything near you can be found.
+const noCityStatus = "set your city first: E on the You tab"
+
+// withCity tells the views that find things near you which city is on
+// your profile.
+func (a App) withCity(city string) App {
+	a.hall.myCity = city
+	a.board.myCity = city
+	a.realm.myCity = city
+	return a
+}
+
+// joinCityRoom joins the chapter room for magicians in the city on your
+// profile, or says why it can't. Servers that open chapter rooms themselves
+// list it already; otherwise the first magician to come along creates it.
+func (m hallModel) joinCityRoom() (tea.Cmd, string) {
+	if m.myCity == "" {
+		return nil, noCityStatus
+	}
+	slug := domain.CityRoomSlug(m.myCity)
+	if slug == "" {
+		return nil, "no chapter room can be named after " + m.myCity
+	}
+	for _, r := range m.roomList {
+		if r.Slug == slug {
+			return m.joinRoom(r), ""
+		}
+	}
+	c, city := m.client, m.myCity
+	return func() tea.Msg {
+		ctx := context.Background()
+		room, err := c.CreateRoom(ctx, client.CreateRoomRequest{Slug: slug, Name: city, Description: "magicians in " + city})
+		if client.IsConflict(err) {
+			// Someone opened it since the room list was loaded.
+			if err := c.JoinRoom(ctx, slug); err != nil {
+				return roomJoinedMsg{err: err}
+			}
+			return roomJoinedMsg{room: domain.Room{Slug: slug, Name: city}}
+		}
+		if err != nil {
+			return roomJoinedMsg{err: err}
+		}
+		return roomCreatedMsg{room: room}
+	}, ""
+}
+
+func slashCity(m hallModel, _ string) (hallModel, tea.Cmd) {
+	cmd, problem := m.joinCityRoom()
+	m.status = problem
+	return m, cmd
+}
+
+// nearMe reports whether the board is showing only magicians in your city.
+func (m boardModel) nearMe() bool {
+	return m.myCity != "" && strings.EqualFold(m.cityFilter, m.myCity)
+}
+
+// toggleNearMe narrows the board to magicians in your city, or back to
+// everyone.
+func (m boardModel) toggleNearMe() (boardModel, tea.Cmd) {
+	if m.myCity == "" {
+		m.status = noCityStatus
+		return m, nil
+	}
+	city := m.myCity
+	if m.nearMe() {
+		city = ""
+	}
+	m.cityFilter = city
+	m.cityCycle = max(slices.Index(m.cityOrder, city), 0)
+	m.cursor = 0
+	m.status = ""
+	m.loading = true
+	return m, m.loadBoard()
+}
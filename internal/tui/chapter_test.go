@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestSlashCityCreatesChapterRoom(t *testing.T) {
+	fake := &clienttest.Fake{Me: &domain.Magician{ID: uuid.New(), GitHubLogin: "me"}}
+	m := newRoomsTestHall(fake.Me.ID)
+	m.client = fake
+	m.myCity = "São Paulo"
+
+	m.input = "/city"
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected /city to open the chapter room, status %q", m.status)
+	}
+	m, _ = m.Update(cmd())
+	if m.room != "city-sao-paulo" {
+		t.Errorf("room = %q, want the new chapter room", m.room)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || calls[0].Method != "CreateRoom" {
+		t.Errorf("calls = %v, want the room created", calls)
+	}
+
+	// The next magician along joins it instead, even before their room
+	// list has it.
+	other := newRoomsTestHall(uuid.New())
+	other.client = fake
+	other.myCity = "sao paulo"
+	other.input = "/city"
+	other, cmd = other.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	other, _ = other.Update(cmd())
+	if other.room != "city-sao-paulo" || fake.Count("JoinRoom") != 1 {
+		t.Errorf("room = %q after %v, want the existing chapter room joined", other.room, fake.Calls())
+	}
+}
+
+func TestRoomPanelCityKey(t *testing.T) {
+	m := newRoomsTestHall(uuid.New())
+	m.rooms = roomPanel{open: true}
+	m, cmd := m.Update(keyRune('c'))
+	if cmd != nil || m.rooms.err != noCityStatus {
+		t.Errorf("without a city: err = %q, want %q", m.rooms.err, noCityStatus)
+	}
+
+	lisbon := domain.Room{Slug: "city-lisbon", Name: "Lisbon", RoomType: domain.RoomTypeTopic}
+	fake := &clienttest.Fake{Rooms: []domain.Room{lisbon}}
+	m.client = fake
+	m.roomList = append(m.roomList, lisbon)
+	m.myCity = "Lisbon"
+	_, cmd = m.Update(keyRune('c'))
+	msg, ok := cmd().(roomJoinedMsg)
+	if !ok || msg.room.Slug != "city-lisbon" || fake.Count("CreateRoom") != 0 {
+		t.Errorf("c joined %+v, want the listed chapter room", msg.room)
+	}
+}
+
+func TestBoardNearYou(t *testing.T) {
+	m := newTestBoardModel()
+	m, cmd := m.Update(keyRune('l'))
+	if cmd != nil || m.status != noCityStatus {
+		t.Errorf("without a city: status = %q, want %q", m.status, noCityStatus)
+	}
+
+	m.myCity = "Lisbon"
+	m.cityOrder = []string{"", "Berlin", "Lisbon"}
+	m, cmd = m.Update(keyRune('l'))
+	if cmd == nil || m.cityFilter != "Lisbon" || m.cityCycle != 2 {
+		t.Errorf("filter = %q (cycle %d), want Lisbon", m.cityFilter, m.cityCycle)
+	}
+	m, _ = m.Update(boardLoadedMsg{entries: []domain.LeaderboardEntry{{Rank: 1, Login: "ana", City: "Lisbon"}}})
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Lisbon (near you)") {
+		t.Errorf("expected the filter marked as near you, got:\n%s", view)
+	}
+	m, _ = m.Update(keyRune('l'))
+	if m.cityFilter != "" {
+		t.Errorf("second l left filter %q, want everyone", m.cityFilter)
+	}
+}
+
+func TestProfileCityReachesOtherTabs(t *testing.T) {
+	a := newTestApp()
+	model, _ := a.Update(profileSavedMsg{me: &domain.Magician{GitHubLogin: "me", City: "Lagos"}})
+	a = model.(App)
+	if a.hall.myCity != "Lagos" || a.board.myCity != "Lagos" || a.realm.myCity != "Lagos" {
+		t.Errorf("cities = %q %q %q, want the saved one everywhere", a.hall.myCity, a.board.myCity, a.realm.myCity)
+	}
+}
+
+func TestRealmMarksYourCity(t *testing.T) {
+	data := testTelemetry()
+	for i := range realmTopCities {
+		data.Cities = append(data.Cities, client.CityCountEntry{City: fmt.Sprintf("Town %d", i), Count: 50 - i})
+	}
+	data.Cities = append(data.Cities, client.CityCountEntry{City: "Porto", Count: 4})
+	a := openViewTestApp(t, &clienttest.Fake{Telemetry: data}, "R", viewRealm)
+	a = a.withCity("porto")
+	view := ansi.Strip(a.View())
+	if !strings.Contains(view, "Porto") || !strings.Contains(view, "<- you") {
+		t.Errorf("expected Porto listed and marked though it isn't a top city:\n%s", view)
+	}
+	if !strings.Contains(view, "/city") {
+		t.Errorf("expected a pointer to the chapter room:\n%s", view)
+	}
+}
@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// minCitedLen is the shortest paste, in runes, checked against known
+// spells. Shorter pastes are phrases, not spells.
+const minCitedLen = 40
+
+// hallSpellsMsg carries the spells the Hall recognizes in pastes.
+type hallSpellsMsg struct {
+	spells []domain.Spell
+	err    error
+}
+
+// spellCitation is a paste that matched a Grimoire spell. Until the user
+// picks, the Hall offers to credit the author or to send a spell card
+// instead of the raw text.
+type spellCitation struct {
+	spell    domain.Spell
+	pasted   string
+	credited bool // send the paste with "via @author's spell"
+	card     bool // send a spell card; the input holds an optional comment
+}
+
+// offered reports whether the citation still awaits the user's choice.
+func (c *spellCitation) offered() bool {
+	return c != nil && !c.credited && !c.card
+}
+
+// citationKey normalizes text for matching pastes to spells: terminals and
+// chat inputs mangle line breaks and runs of spaces, so only the words
+// count.
+func citationKey(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// loadSpellIndex fetches the top spells so pastes of popular spells are
+// recognized before the Grimoire has been opened.
+func (m hallModel) loadSpellIndex() tea.Cmd {
+	c := m.client
+	if c == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		spells, err := c.ListSpells(context.Background(), nil, "top", pageSize, 0)
+		return hallSpellsMsg{spells: spells, err: err}
+	}
+}
+
+// indexSpells adds spells to the paste index. List views may leave Text
+// empty; those can't be matched and are skipped.
+func (m hallModel) indexSpells(spells []domain.Spell) hallModel {
+	if m.spellIndex == nil {
+		m.spellIndex = make(map[string]domain.Spell)
+	}
+	for _, s := range spells {
+		if key := citationKey(s.Text); len([]rune(key)) >= minCitedLen {
+			m.spellIndex[key] = s
+		}
+	}
+	return m
+}
+
+// pasteInput inserts pasted text into the input and, when it is a known
+// spell, offers to cite it.
+func (m hallModel) pasteInput(text string) hallModel {
+	m.input = m.inputCursor.edit(m.input, text)
+	key := citationKey(text)
+	if len([]rune(key)) < minCitedLen {
+		return m
+	}
+	if s, ok := m.spellIndex[key]; ok && s.MagicianID != m.myID {
+		m.cite = &spellCitation{spell: s, pasted: text}
+	}
+	return m
+}
+
+// updateCitation handles the keys of a citation offer: tab credits the
+// author, ctrl+o turns the paste into a card and esc backs out. It reports
+// whether it consumed the key.
+func (m hallModel) updateCitation(key string) (hallModel, bool) {
+	if m.cite == nil {
+		return m, false
+	}
+	c := *m.cite
+	switch {
+	case key == "esc":
+		if c.card {
+			// Put the paste back in front of whatever was typed since.
+			m.input = strings.TrimSpace(c.pasted + " " + m.input)
+		}
+		m.cite = nil
+	case key == "tab" && c.offered():
+		c.credited = true
+		m.cite = &c
+	case key == "ctrl+o" && c.offered():
+		c.card = true
+		m.cite = &c
+		m.input = strings.TrimSpace(strings.Replace(m.input, c.pasted, "", 1))
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// dropStaleCitation forgets an offer or credit once the pasted text has
+// been edited away. A card no longer needs the paste.
+func (m hallModel) dropStaleCitation() hallModel {
+	if m.cite != nil && !m.cite.card && !strings.Contains(m.input, m.cite.pasted) {
+		m.cite = nil
+	}
+	return m
+}
+
+// citationBody returns what to send for a spell card: the user's comment,
+// or the spell's title when there is none.
+func citationBody(c *spellCitation, comment string) string {
+	if c == nil || !c.card || comment != "" {
+		return comment
+	}
+	return spellTitle(c.spell)
+}
+
+// citationMetadata returns the metadata that credits a cited spell, or nil
+// when nothing was cited.
+func citationMetadata(c *spellCitation) map[string]string {
+	if c == nil || (!c.credited && !c.card) {
+		return nil
+	}
+	meta := map[string]string{
+		"spell_id":    c.spell.ID.String(),
+		"spell_title": spellTitle(c.spell),
+	}
+	if author := spellAuthor(c.spell); author != "" {
+		meta["spell_author"] = author
+	}
+	if c.card {
+		meta["spell_card"] = "1"
+		meta["spell_potency"] = strconv.Itoa(c.spell.Potency)
+		meta["spell_tag"] = c.spell.Tag
+	}
+	return meta
+}
+
+// mergeMetadata combines metadata maps; later maps win on conflicts.
+func mergeMetadata(maps ...map[string]string) map[string]string {
+	var out map[string]string
+	for _, m := range maps {
+		for k, v := range m {
+			if out == nil {
+				out = make(map[string]string)
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// spellTitle returns the one-line title of a spell.
+func spellTitle(s domain.Spell) string {
+	text := s.Preview
+	if text == "" {
+		text = s.Text
+	}
+	first, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return truncStr(cleanTitle(first), 60)
+}
+
+// spellAuthor returns the login of a spell's author, if known.
+func spellAuthor(s domain.Spell) string {
+	if s.Author == nil {
+		return ""
+	}
+	return s.Author.Login
+}
+
+// spellCredit names a spell's author for attribution lines.
+func spellCredit(author string) string {
+	if author == "" {
+		return "a Grimoire spell"
+	}
+	return "@" + author + "'s spell"
+}
+
+// renderCitationBanner renders the line above the input while a citation
+// is offered or chosen.
+func (m hallModel) renderCitationBanner() string {
+	credit := spellCredit(spellAuthor(m.cite.spell))
+	var head, hint string
+	switch {
+	case m.cite.card:
+		head, hint = " ✦ sharing "+credit+" as a card", " · type a comment or enter to send · esc to cancel"
+	case m.cite.credited:
+		head, hint = " ✦ crediting "+credit, " · esc to cancel"
+	default:
+		head, hint = " ✦ that's "+credit, " · tab credit · ctrl+o send as card · esc ignore"
+	}
+	title := truncStr(`"`+spellTitle(m.cite.spell)+`"`, max(m.width-lipgloss.Width(head)-lipgloss.Width(hint)-1, 10))
+	return goldStyle.Render(head) + " " + dimStyle.Render(title+hint)
+}
+
+// renderVia renders the attribution under a message that credits a spell.
+func (m hallModel) renderVia(msg chatMessage, indent int) string {
+	if msg.Metadata["spell_id"] == "" {
+		return ""
+	}
+	return strings.Repeat(" ", indent) + metaStyle.Render("via "+spellCredit(msg.Metadata["spell_author"]))
+}
+
+// renderSpellCard renders a spell shared as a card: who shared whose
+// spell, its title and potency, and the sharer's comment if any.
+func (m hallModel) renderSpellCard(msg chatMessage) string {
+	name := GuildStyle(msg.SenderGuild).Render(msg.SenderLogin)
+	if msg.IsSelf {
+		name = chatSelfNameStyle.Render(msg.SenderLogin)
+	}
+	title := msg.Metadata["spell_title"]
+	head := " " + goldStyle.Render("✦") + " " + name + dimStyle.Render(" shared "+spellCredit(msg.Metadata["spell_author"])+" · ")
+	line := head + goldStyle.Render(`"`+truncStr(title, max(m.width-lipgloss.Width(head)-8, 10))+`"`)
+	if p := msg.Metadata["spell_potency"]; p != "" && p != "0" {
+		line += " " + potencyStyle(potencyFromStr(p)).Render("P"+p)
+	}
+	if msg.Body == "" || msg.Body == title {
+		return line
+	}
+	for _, l := range wrapInputLines(msg.Body, max(m.width-6, 20)) {
+		line += "\n     " + chatTextStyle.Render(l)
+	}
+	return line
+}
@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+const citedText = "# Flaky test triage\nRerun the failing test 20 times with -count and -race before touching the code."
+
+// newCitationHall returns a Hall that knows one spell by @ada and sends
+// through f.
+func newCitationHall(f *clienttest.Fake) (hallModel, domain.Spell) {
+	spell := domain.Spell{ID: uuid.New(), MagicianID: uuid.New(), Text: citedText, Tag: "testing", Potency: 3, Author: &domain.Author{Login: "ada"}}
+	m := newTestHallModel()
+	m.client = f
+	m.myLogin, m.myID = "me", uuid.New()
+	m = m.indexSpells([]domain.Spell{spell})
+	return m, spell
+}
+
+func paste(m hallModel, text string) hallModel {
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text), Paste: true})
+	return m
+}
+
+// sendAndCapture sends the input and returns what reached the API.
+func sendAndCapture(t *testing.T, m hallModel, f *clienttest.Fake) (hallModel, domain.RoomMessage) {
+	t.Helper()
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected enter to send")
+	}
+	cmd()
+	sent := f.RoomMessages[hallSlug]
+	if len(sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sent))
+	}
+	return m, sent[0]
+}
+
+func metadataOf(t *testing.T, msg domain.RoomMessage) map[string]string {
+	t.Helper()
+	var meta map[string]string
+	if err := json.Unmarshal(msg.Metadata, &meta); err != nil {
+		t.Fatal(err)
+	}
+	return meta
+}
+
+func TestPasteOffersCitation(t *testing.T) {
+	m, _ := newCitationHall(&clienttest.Fake{})
+	// Terminals reflow pastes; whitespace differences still match.
+	m = paste(m, strings.ReplaceAll(citedText, " ", "  "))
+	if m.cite == nil || !m.cite.offered() {
+		t.Fatal("expected a citation offer")
+	}
+	if strings.Contains(m.input, "[") {
+		t.Errorf("input = %q; paste brackets leaked in", m.input)
+	}
+	if v := m.View(); !strings.Contains(v, "that's @ada's spell") {
+		t.Errorf("expected the offer banner, got:\n%s", v)
+	}
+}
+
+func TestPasteWithoutCitation(t *testing.T) {
+	m, spell := newCitationHall(&clienttest.Fake{})
+	if m = paste(m, "Rerun the failing test"); m.cite != nil {
+		t.Error("short pastes shouldn't be offered")
+	}
+
+	own, _ := newCitationHall(&clienttest.Fake{})
+	own.myID = spell.MagicianID
+	own = own.indexSpells([]domain.Spell{spell})
+	if own = paste(own, citedText); own.cite != nil {
+		t.Error("pasting your own spell shouldn't be offered")
+	}
+}
+
+func TestCitationCredit(t *testing.T) {
+	f := &clienttest.Fake{}
+	m, spell := newCitationHall(f)
+	m = paste(m, citedText)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !m.cite.credited {
+		t.Fatal("expected tab to credit the spell")
+	}
+
+	m, sent := sendAndCapture(t, m, f)
+	if m.cite != nil {
+		t.Error("expected the citation to clear after sending")
+	}
+	if sent.Body != citedText {
+		t.Errorf("body = %q, want the pasted text", sent.Body)
+	}
+	meta := metadataOf(t, sent)
+	if meta["spell_id"] != spell.ID.String() || meta["spell_author"] != "ada" || meta["spell_card"] != "" {
+		t.Errorf("metadata = %v", meta)
+	}
+
+	line := m.renderMessage(chatMessage{SenderLogin: "me", Body: sent.Body, Metadata: meta})
+	if !strings.Contains(line, "via @ada's spell") {
+		t.Errorf("expected the attribution line, got:\n%s", line)
+	}
+}
+
+func TestCitationCard(t *testing.T) {
+	f := &clienttest.Fake{}
+	m, _ := newCitationHall(f)
+	m = paste(m, citedText)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.input != "" || !m.cite.card {
+		t.Fatalf("input = %q, card = %v; want the paste replaced by a card", m.input, m.cite.card)
+	}
+
+	_, sent := sendAndCapture(t, m, f)
+	meta := metadataOf(t, sent)
+	if meta["spell_card"] == "" || sent.Body != "Flaky test triage" {
+		t.Errorf("body = %q, metadata = %v", sent.Body, meta)
+	}
+
+	card := m.renderMessage(chatMessage{SenderLogin: "bob", Body: sent.Body, Metadata: meta})
+	if !strings.Contains(card, "shared @ada's spell") || !strings.Contains(card, "Flaky test triage") {
+		t.Errorf("unexpected card:\n%s", card)
+	}
+}
+
+func TestCitationCancel(t *testing.T) {
+	m, _ := newCitationHall(&clienttest.Fake{})
+	m = paste(m, citedText)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.cite != nil || m.input != citedText {
+		t.Errorf("esc on a card: cite = %v, input = %q; want the paste back", m.cite, m.input)
+	}
+	if !m.inputFocused {
+		t.Error("esc should back out of the citation, not the input")
+	}
+
+	m, _ = newCitationHall(&clienttest.Fake{})
+	m = paste(m, citedText)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	for range len([]rune(citedText)) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	if m.cite != nil {
+		t.Error("expected the credit to drop once the paste is deleted")
+	}
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cloneDir is where "g" on a weapon clones its repository; empty copies the
+// clone command instead. Set from config by ApplyConfig.
+var cloneDir string
+
+// runGit runs git with args. Tests replace it.
+var runGit = func(args ...string) ([]byte, error) {
+	return exec.Command("git", args...).CombinedOutput()
+}
+
+// weaponCloneMsg reports a clone, or a copied clone command when dest is
+// empty.
+type weaponCloneMsg struct {
+	dest string
+	err  error
+}
+
+// httpsRepoURL reports whether repoURL, which comes from the server, is an
+// https URL with a host: the only kind "o" opens and "g" clones.
+func httpsRepoURL(repoURL string) bool {
+	u, err := url.Parse(repoURL)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+// cloneCommand is the shell command that clones repoURL, quoted so it pastes
+// as one argument whatever the URL holds.
+func cloneCommand(repoURL string) string {
+	return "git clone -- " + shellQuote(repoURL)
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// repoDirName is the directory git would clone repoURL into.
+func repoDirName(repoURL string) string {
+	p := repoURL
+	if u, err := url.Parse(repoURL); err == nil && u.Path != "" {
+		p = u.Path
+	}
+	return strings.TrimSuffix(path.Base(strings.TrimRight(p, "/")), ".git")
+}
+
+// cloneRepoCmd copies the clone command for repoURL, or clones it into
+// dir/<repo> when dir is set. An existing checkout is left alone.
+func cloneRepoCmd(repoURL, dir string) tea.Cmd {
+	return func() tea.Msg {
+		if repoURL == "" {
+			return weaponCloneMsg{err: errors.New("no repository")}
+		}
+		if !httpsRepoURL(repoURL) {
+			return weaponCloneMsg{err: fmt.Errorf("not an https repository: %s", repoURL)}
+		}
+		if dir == "" {
+			return weaponCloneMsg{err: clipboard.WriteAll(cloneCommand(repoURL))}
+		}
+		name := repoDirName(repoURL)
+		if name == "" || name == "." || name == "/" {
+			return weaponCloneMsg{err: fmt.Errorf("can't tell the repository name from %s", repoURL)}
+		}
+		dest := filepath.Join(dir, name)
+		if _, err := os.Stat(dest); err == nil {
+			return weaponCloneMsg{dest: dest, err: fmt.Errorf("%s already exists", dest)}
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return weaponCloneMsg{dest: dest, err: err}
+		}
+		// "--" keeps a URL starting with a dash from being read as a flag.
+		if out, err := runGit("clone", "--quiet", "--", repoURL, dest); err != nil {
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				err = errors.New(msg)
+			}
+			return weaponCloneMsg{dest: dest, err: err}
+		}
+		return weaponCloneMsg{dest: dest}
+	}
+}
+
+// cloneStatus describes a weaponCloneMsg for the status line.
+func cloneStatus(msg weaponCloneMsg) string {
+	switch {
+	case msg.err != nil && msg.dest == "":
+		return errText("copy failed", msg.err)
+	case msg.err != nil:
+		return errText("clone failed", msg.err)
+	case msg.dest == "":
+		return "copied git clone command"
+	default:
+		return "cloned into " + msg.dest
+	}
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestRepoDirName(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/BurntSushi/ripgrep":      "ripgrep",
+		"https://github.com/junegunn/fzf.git":        "fzf",
+		"https://gitlab.com/group/sub/tool/":         "tool",
+		"git@github.com:charmbracelet/bubbletea.git": "bubbletea",
+	}
+	for in, want := range tests {
+		if got := repoDirName(in); got != want {
+			t.Errorf("repoDirName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// withGit replaces runGit for a test and records its arguments.
+func withGit(t *testing.T, out string, err error) *[]string {
+	t.Helper()
+	orig := runGit
+	var got []string
+	runGit = func(args ...string) ([]byte, error) {
+		got = args
+		return []byte(out), err
+	}
+	t.Cleanup(func() { runGit = orig })
+	return &got
+}
+
+func TestCloneRepoCmdClonesIntoDir(t *testing.T) {
+	args := withGit(t, "", nil)
+	dir := filepath.Join(t.TempDir(), "src")
+
+	msg := cloneRepoCmd("https://github.com/test/repo", dir)().(weaponCloneMsg)
+	if msg.err != nil {
+		t.Fatal(msg.err)
+	}
+	want := []string{"clone", "--quiet", "--", "https://github.com/test/repo", filepath.Join(dir, "repo")}
+	if !slices.Equal(*args, want) {
+		t.Errorf("git args = %q, want %q", *args, want)
+	}
+	if got := cloneStatus(msg); got != "cloned into "+filepath.Join(dir, "repo") {
+		t.Errorf("status = %q", got)
+	}
+}
+
+func TestCloneRepoCmdOnlyHTTPS(t *testing.T) {
+	args := withGit(t, "", nil)
+	for _, repoURL := range []string{"git@github.com:test/repo.git", "ext::sh -c touch% /tmp/x", "file:///etc", "https:///nohost"} {
+		if msg := cloneRepoCmd(repoURL, t.TempDir())().(weaponCloneMsg); msg.err == nil {
+			t.Errorf("cloneRepoCmd(%q) cloned", repoURL)
+		}
+	}
+	if *args != nil {
+		t.Errorf("git ran with %q", *args)
+	}
+}
+
+func TestCloneCommandQuotesURL(t *testing.T) {
+	got := cloneCommand("https://example.com/a'b;rm -rf ~")
+	want := `git clone -- 'https://example.com/a'\''b;rm -rf ~'`
+	if got != want {
+		t.Errorf("cloneCommand = %q, want %q", got, want)
+	}
+}
+
+func TestCloneRepoCmdLeavesExistingCheckout(t *testing.T) {
+	args := withGit(t, "", nil)
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "repo"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	msg := cloneRepoCmd("https://github.com/test/repo", dir)().(weaponCloneMsg)
+	if msg.err == nil {
+		t.Fatal("expected an error for an existing checkout")
+	}
+	if *args != nil {
+		t.Errorf("git ran anyway: %q", *args)
+	}
+}
+
+func TestCloneRepoCmdReportsGitOutput(t *testing.T) {
+	withGit(t, "fatal: repository not found\n", errors.New("exit status 128"))
+	msg := cloneRepoCmd("https://github.com/test/gone", t.TempDir())().(weaponCloneMsg)
+	if got := cloneStatus(msg); got != "clone failed: fatal: repository not found" {
+		t.Errorf("status = %q", got)
+	}
+}
+
+func TestGrimoireWeaponDetailOpenAndClone(t *testing.T) {
+	orig := cloneDir
+	cloneDir = t.TempDir()
+	t.Cleanup(func() { cloneDir = orig })
+	withGit(t, "", nil)
+
+	m := newTestGrimoireModel()
+	m.mode = grimoireModeWeapons
+	m.weapons = []domain.Weapon{makeTestWeapon("ripgrep")}
+	m.detail = true
+
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")}); cmd == nil {
+		t.Error("expected o to open the repository")
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if cmd == nil || m.statusMsg != "cloning repo..." {
+		t.Fatalf("status = %q, cmd = %v; want a clone under way", m.statusMsg, cmd)
+	}
+	m, _ = m.Update(cmd())
+	if m.statusMsg != "cloned into "+filepath.Join(cloneDir, "repo") {
+		t.Errorf("status = %q after clone", m.statusMsg)
+	}
+}
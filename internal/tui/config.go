@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/internal/hooks"
+	"github.com/naveenspark/grimora/internal/publish"
+)
+
+// ApplyConfig applies user preferences that affect rendering and alerts.
+// Call it once before starting the program.
+func ApplyConfig(cfg config.Config) {
+	if d, err := cfg.CursorBlinkInterval(); err == nil {
+		cursorBlinkInterval = d
+	}
+	cursorHighVisibility = cfg.CursorStyle == config.CursorStyleHighVisibility
+	alertBell = cfg.Bell
+	alertFlash = cfg.Flash
+	accessibleMode = cfg.Accessible
+	lowBandwidth = cfg.LowBandwidth
+	showJoinLeave = !cfg.HideJoinLeave
+	pageSize = defaultPageSize
+	if lowBandwidth {
+		pageSize = lowBandwidthPageSize
+	}
+	if d, err := cfg.PollInterval(config.PollHall); err == nil {
+		hallPollInterval = d
+	}
+	if d, err := cfg.PollInterval(config.PollThreads); err == nil {
+		threadsPollInterval = d
+	}
+	if accessibleMode || lowBandwidth {
+		// Nothing blinks or flashes.
+		cursorBlinkInterval = 0
+		alertFlash = false
+	}
+	if d, err := cfg.LockAfterDuration(); err == nil && cfg.LockPassphrase != "" {
+		lockAfter = d
+		lockPassphraseHash = cfg.LockPassphrase
+	}
+	if d, err := cfg.AwayAfterDuration(); err == nil {
+		awayAfter = d
+	}
+	if q, err := cfg.QuietHoursSpan(); err == nil {
+		quietHours = q
+	}
+	eventHooks = nil
+	if len(cfg.Hooks) > 0 {
+		eventHooks = hooks.New(cfg.Hooks)
+	}
+	if dir, err := cfg.CloneDirPath(); err == nil {
+		cloneDir = dir
+	}
+	if repo, err := publish.ParseRepo(cfg.PublishRepo); err == nil {
+		publishRepo = repo
+	}
+	applyTerminal(cfg, runtime.GOOS, os.Getenv)
+}
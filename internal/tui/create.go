@@ -3,11 +3,13 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
-	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/naveenspark/grimora/internal/drafts"
+	"github.com/naveenspark/grimora/internal/journal"
 	"github.com/naveenspark/grimora/pkg/client"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
@@ -25,20 +27,45 @@ const (
 )
 
 type createModel struct {
-	client    *client.Client
+	client    client.API
+	drafts    *drafts.Store
+	journal   *journal.Journal // copy of every spell submitted; nil keeps none
 	fields    [numFields]string
+	cursors   [numFields]editCursor
 	focus     createField
+	fieldErrs [numFields]string // inline error per field, "" when fine
 	err       error
 	statusMsg string
 	submitted bool
+	animFrame int // cursor blink frame
+
+	// fieldWarns are the pre-submit check's advice, shown inline but not
+	// blocking; checked is the form they were given for, so a second
+	// ctrl+s on an unchanged form submits anyway.
+	fieldWarns [numFields]string
+	checked    [numFields]string
+	checking   bool
+	noPreview  bool // the server has no dry-run endpoint
+
+	// Shared draft state: the draft collaborators see, and the suggestions
+	// they've left that still need a decision.
+	draftID     string
+	shareURL    string
+	suggestions []domain.DraftSuggestion
+	reviewing   bool
+	sugCursor   int
 }
 
+// createFieldNames are the JSON names the API uses for each field in
+// validation errors.
+var createFieldNames = [numFields]string{"text", "tag", "model", "context"}
+
 type spellCreatedMsg struct {
 	spell *domain.Spell
 	err   error
 }
 
-func newCreateModel(c *client.Client) createModel {
+func newCreateModel(c client.API) createModel {
 	m := createModel{client: c}
 	m.fields[fieldModel] = defaultModel
 	return m
@@ -50,22 +77,90 @@ func (m createModel) Init() tea.Cmd {
 
 func (m createModel) Update(msg tea.Msg) (createModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case spellCheckedMsg:
+		return m.spellChecked(msg)
+
 	case spellCreatedMsg:
 		m.submitted = false
 		if msg.err != nil {
 			m.err = msg.err
 			m.statusMsg = "failed to create spell"
+			if fields := client.FieldErrors(msg.err); len(fields) > 0 {
+				m = m.applyFieldErrors(fields)
+			}
 		} else {
 			m.statusMsg = fmt.Sprintf("spell created: %s", msg.spell.ID.String()[:8])
 			// Reset form
 			m.fields = [numFields]string{}
 			m.fields[fieldModel] = defaultModel
+			m.cursors = [numFields]editCursor{}
 			m.focus = fieldText
+			m.fieldErrs = [numFields]string{}
+			m.fieldWarns, m.checked = [numFields]string{}, [numFields]string{}
+			m.draftID, m.shareURL = "", ""
+			m.suggestions, m.reviewing = nil, false
+			m.saveDraft()
+		}
+		return m, nil
+
+	case draftSharedMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("share failed", msg.err)
+			return m, nil
+		}
+		m.draftID = msg.draft.ID.String()
+		m.shareURL = msg.draft.ShareURL
+		m.suggestions = msg.draft.Pending()
+		if msg.copied {
+			m.statusMsg = "draft link copied"
+		} else {
+			m.statusMsg = "draft shared"
+		}
+		m.saveDraft()
+		return m, nil
+
+	case draftLoadedMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("could not load suggestions", msg.err)
+			return m, nil
+		}
+		m.shareURL = msg.draft.ShareURL
+		m.suggestions = msg.draft.Pending()
+		m.sugCursor = 0
+		m.reviewing = len(m.suggestions) > 0
+		if !m.reviewing {
+			m.statusMsg = "no new suggestions"
+		} else {
+			m.statusMsg = ""
 		}
 		return m, nil
 
+	case suggestionResolvedMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("could not record decision", msg.err)
+		}
+		return m, nil
+
+	case cursorBlinkMsg:
+		m.animFrame++
+		return m, nil
+
 	case tea.KeyMsg:
-		return m.updateKeys(msg)
+		m.animFrame = 0
+		before := m.fields
+		var cmd tea.Cmd
+		m, cmd = m.updateKeys(msg)
+		// Editing a field clears its error and warning; the rest stay until
+		// resubmit. A check still running is for the old text.
+		for i := range m.fields {
+			if m.fields[i] != before[i] {
+				m.fieldErrs[i] = ""
+				m.fieldWarns[i] = ""
+				m.checking = false
+			}
+		}
+		m.saveDraft()
+		return m, cmd
 	}
 	return m, nil
 }
@@ -74,85 +169,150 @@ func (m createModel) updateKeys(msg tea.KeyMsg) (createModel, tea.Cmd) {
 	m.statusMsg = ""
 	m.err = nil
 
+	if m.reviewing {
+		return m.updateReview(msg)
+	}
+
 	switch msg.String() {
 	case "ctrl+s":
 		return m.submit()
+	case "ctrl+l":
+		return m.shareDraft()
+	case "ctrl+r":
+		return m.checkSuggestions()
 	case "tab", "down":
 		m.focus = (m.focus + 1) % numFields
 	case "shift+tab", "up":
 		m.focus = (m.focus - 1 + numFields) % numFields
-	case "backspace":
-		f := &m.fields[m.focus]
-		*f = editRune(*f, "backspace")
 	case "enter":
 		if m.focus == fieldText {
-			if utf8.RuneCountInString(m.fields[fieldText]) < maxInputLen {
-				m.fields[fieldText] += "\n"
-			}
+			m.fields[fieldText] = m.cursors[fieldText].edit(m.fields[fieldText], "\n")
 		} else {
 			m.focus = (m.focus + 1) % numFields
 		}
 	default:
 		key := msg.String()
 		if m.focus == fieldTag {
-			// Cycle through tags with h/l
-			if key == "h" || key == "l" {
+			// Cycle through the curated tags with ←/→; any other tag can
+			// be typed in.
+			if key == "left" || key == "right" {
 				tags := domain.ValidTags
-				current := m.fields[fieldTag]
-				idx := 0
-				for i, t := range tags {
-					if t == current {
-						idx = i
-						break
-					}
-				}
-				if key == "l" {
+				idx := slices.Index(tags, m.fields[fieldTag])
+				if key == "right" {
 					idx = (idx + 1) % len(tags)
 				} else {
-					idx = (idx - 1 + len(tags)) % len(tags)
+					idx = (max(idx, 0) - 1 + len(tags)) % len(tags)
 				}
 				m.fields[fieldTag] = tags[idx]
 				return m, nil
 			}
+			if key == " " {
+				key = "-"
+			}
 		}
-		if len(key) == 1 {
-			m.fields[m.focus] = editRune(m.fields[m.focus], key)
+		if msg.Paste {
+			key = string(msg.Runes)
 		}
+		m.fields[m.focus] = m.cursors[m.focus].edit(m.fields[m.focus], key)
 	}
 	return m, nil
 }
 
 func (m createModel) submit() (createModel, tea.Cmd) {
 	text := strings.TrimSpace(m.fields[fieldText])
-	tag := m.fields[fieldTag]
+	tag := domain.NormalizeTag(m.fields[fieldTag])
+	m.fields[fieldTag] = tag
+	m.fieldErrs = [numFields]string{}
 
-	if text == "" {
-		m.statusMsg = "text is required"
+	if p := domain.SpellLengthProblem(text); p != "" {
+		m.statusMsg = "text is " + p
+		m.fieldErrs[fieldText], m.focus = p, fieldText
 		return m, nil
 	}
 	if tag == "" {
-		m.statusMsg = "tag is required (use h/l to select)"
+		m.statusMsg = "tag is required (type one or use ←/→ to pick)"
+		m.fieldErrs[fieldTag], m.focus = "required", fieldTag
 		return m, nil
 	}
-	if !domain.ValidTag(tag) {
-		m.statusMsg = "invalid tag"
+	if !domain.WellFormedTag(tag) {
+		m.statusMsg = fmt.Sprintf("tags are 2-%d lowercase letters, digits and hyphens", domain.MaxTagLen)
+		m.fieldErrs[fieldTag], m.focus = "invalid tag", fieldTag
 		return m, nil
 	}
+	if m.checking {
+		return m, nil
+	}
+	if m.checked != m.fields {
+		m.checking = true
+		m.fieldWarns = [numFields]string{}
+		return m, m.checkSpell()
+	}
 
 	m.submitted = true
-	req := client.CreateSpellRequest{
-		Text:    text,
-		Tag:     tag,
-		Model:   m.fields[fieldModel],
-		Context: m.fields[fieldContext],
-	}
+	req := m.request()
 
+	c, j := m.client, m.journal
 	return m, func() tea.Msg {
-		spell, err := m.client.CreateSpell(context.Background(), req)
+		spell, err := c.CreateSpell(context.Background(), req)
+		if err == nil {
+			record(j, journal.Entry{Kind: journal.KindSpell, ID: spell.ID.String(), Where: req.Tag, Text: req.Text})
+		}
 		return spellCreatedMsg{spell: spell, err: err}
 	}
 }
 
+// applyFieldErrors marks each field the API rejected and moves focus to the
+// first one. Errors for fields the form doesn't have go in the status line.
+func (m createModel) applyFieldErrors(errs []client.FieldError) createModel {
+	m.fieldErrs = [numFields]string{}
+	var other []string
+	first := numFields
+	for _, fe := range errs {
+		text := fieldErrorText(fe)
+		f := createField(0)
+		for f < numFields && createFieldNames[f] != fe.Field {
+			f++
+		}
+		if f == numFields {
+			other = append(other, text)
+			continue
+		}
+		if m.fieldErrs[f] == "" {
+			m.fieldErrs[f] = text
+		}
+		first = min(first, f)
+	}
+	if first < numFields {
+		m.focus = first
+		m.statusMsg = "fix the marked fields and press ctrl+s"
+	}
+	if len(other) > 0 {
+		m.statusMsg = "failed to create spell: " + strings.Join(other, "; ")
+	}
+	return m
+}
+
+// fieldErrorText is what's shown next to a rejected field: the API's message,
+// or a description of its code when there is none.
+func fieldErrorText(fe client.FieldError) string {
+	if fe.Message != "" {
+		return fe.Message
+	}
+	switch fe.Code {
+	case client.CodeRequired:
+		return "required"
+	case client.CodeTooShort:
+		return "too short"
+	case client.CodeTooLong:
+		return "too long"
+	case client.CodeInvalid:
+		return "invalid"
+	case client.CodeDuplicate:
+		return "a spell like this already exists"
+	}
+	return fe.Code
+}
+
 func (m createModel) View() string {
 	var b strings.Builder
 
@@ -168,21 +328,40 @@ func (m createModel) View() string {
 			style = selectedStyle
 		}
 
+		marker := ""
+		if e := m.fieldErrs[i]; e != "" {
+			cursor = rejectStyle.Render("!")
+			marker = "  " + rejectStyle.Render("✗ "+e)
+		} else if w := m.fieldWarns[i]; w != "" {
+			marker = "  " + goldStyle.Render("⚠ "+w)
+		}
+
 		if i == fieldTag {
-			fmt.Fprintf(&b, "%s %s: %s  (h/l to cycle)\n",
-				cursor, style.Render(label), TagStyle(value).Render(value))
+			display := TagStyle(value).Render(value)
+			if i == m.focus && !m.reviewing {
+				before, after := m.cursors[i].split(value)
+				display = TagStyle(value).Render(before) + renderCursor(m.animFrame) + TagStyle(value).Render(after)
+			}
+			fmt.Fprintf(&b, "%s %s: %s  (type or ←/→ to cycle)%s\n",
+				cursor, style.Render(label), display, marker)
 		} else {
 			displayValue := value
-			if i == m.focus {
-				displayValue += "█"
+			if m.reviewing && len(m.suggestions) > 0 && suggestionField(m.suggestions[m.sugCursor]) == i {
+				displayValue = renderSuggestionInline(value, m.suggestions[m.sugCursor])
+			} else if i == m.focus && !m.reviewing {
+				before, after := m.cursors[i].split(value)
+				displayValue = before + renderCursor(m.animFrame) + after
 			}
-			fmt.Fprintf(&b, "%s %s: %s\n", cursor, style.Render(label), displayValue)
+			fmt.Fprintf(&b, "%s %s: %s%s\n", cursor, style.Render(label), displayValue, marker)
 		}
 	}
 
 	b.WriteString("\n")
+	b.WriteString(m.viewShareStatus())
 	if m.submitted {
 		b.WriteString(dimStyle.Render("creating..."))
+	} else if m.checking {
+		b.WriteString(dimStyle.Render("checking..."))
 	} else if m.statusMsg != "" {
 		b.WriteString(upvoteStyle.Render(m.statusMsg))
 	}
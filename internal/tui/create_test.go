@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func validationErr(fields ...client.FieldError) error {
+	return fmt.Errorf("client.CreateSpell: %w", &client.HTTPError{StatusCode: 422, Message: "validation failed", Fields: fields})
+}
+
+func TestCreateFieldErrorsMarkFields(t *testing.T) {
+	m := newCreateModel(nil)
+	m.fields[fieldText] = "hi"
+	m.fields[fieldTag] = "debugging"
+	m.focus = fieldContext
+	m, _ = m.Update(spellCreatedMsg{err: validationErr(
+		client.FieldError{Field: "tag", Code: client.CodeInvalid, Message: "unknown tag"},
+		client.FieldError{Field: "text", Code: client.CodeTooShort},
+	)})
+
+	if m.fieldErrs[fieldText] != "too short" || m.fieldErrs[fieldTag] != "unknown tag" {
+		t.Errorf("fieldErrs = %q", m.fieldErrs)
+	}
+	if m.focus != fieldText {
+		t.Errorf("focus = %d, want the first rejected field", m.focus)
+	}
+	view := m.View()
+	for _, want := range []string{"✗ too short", "✗ unknown tag", "fix the marked fields"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+}
+
+func TestCreateFieldErrorClearsOnEdit(t *testing.T) {
+	m := newCreateModel(nil)
+	m.fields[fieldText] = "hi"
+	m, _ = m.Update(spellCreatedMsg{err: validationErr(
+		client.FieldError{Field: "text", Code: client.CodeDuplicate},
+		client.FieldError{Field: "context", Code: client.CodeTooLong},
+	)})
+	if m.fieldErrs[fieldText] != "a spell like this already exists" {
+		t.Fatalf("text error = %q", m.fieldErrs[fieldText])
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	if m.fieldErrs[fieldText] != "" {
+		t.Error("expected editing text to clear its error")
+	}
+	if m.fieldErrs[fieldContext] == "" {
+		t.Error("expected the untouched field to keep its error")
+	}
+}
+
+func TestCreateFieldErrorsUnknownField(t *testing.T) {
+	m := newCreateModel(nil)
+	m, _ = m.Update(spellCreatedMsg{err: validationErr(client.FieldError{Field: "stack", Code: client.CodeInvalid, Message: "too many entries"})})
+	if m.statusMsg != "failed to create spell: too many entries" {
+		t.Errorf("statusMsg = %q", m.statusMsg)
+	}
+	if m.fieldErrs != [numFields]string{} {
+		t.Errorf("fieldErrs = %q, want none", m.fieldErrs)
+	}
+}
+
+func TestCreateLocalValidationMarksField(t *testing.T) {
+	m := newCreateModel(nil)
+	m.fields[fieldText] = "a real spell about evals"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.fieldErrs[fieldTag] != "required" || m.focus != fieldTag {
+		t.Errorf("fieldErrs = %q, focus = %d", m.fieldErrs, m.focus)
+	}
+}
+
+func TestCreateTagFieldAcceptsTypedTags(t *testing.T) {
+	f := &clienttest.Fake{}
+	m := newCreateModel(f)
+	m.fields[fieldText] = "a real spell about evals"
+	m.focus = fieldTag
+	for _, r := range "LLM evals" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if m.fields[fieldTag] != "LLM-evals" {
+		t.Fatalf("tag field = %q, want h/l typed and space as a hyphen", m.fields[fieldTag])
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatalf("expected a custom tag to submit, got %q (%q)", m.statusMsg, m.fieldErrs)
+	}
+	// The pre-submit check finds nothing, so the spell goes straight out.
+	_, cmd = m.Update(cmd())
+	if cmd == nil {
+		t.Fatal("expected a clean check to submit")
+	}
+	cmd()
+	if got := f.Spells; len(got) != 1 || got[0].Tag != "llm-evals" {
+		t.Errorf("created spells = %+v, want tag llm-evals", got)
+	}
+}
+
+func TestCreateTagFieldRejectsMalformedTag(t *testing.T) {
+	m := newCreateModel(nil)
+	m.fields[fieldText] = "a real spell about evals"
+	m.fields[fieldTag] = "c++"
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd != nil || m.fieldErrs[fieldTag] != "invalid tag" || m.focus != fieldTag {
+		t.Errorf("fieldErrs = %q, focus = %d", m.fieldErrs, m.focus)
+	}
+}
+
+func TestCreateTagFieldCyclesWithArrows(t *testing.T) {
+	m := newCreateModel(nil)
+	m.focus = fieldTag
+	m.fields[fieldTag] = "rust"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if m.fields[fieldTag] != domain.ValidTags[0] {
+		t.Errorf("right from a custom tag = %q, want %q", m.fields[fieldTag], domain.ValidTags[0])
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if want := domain.ValidTags[len(domain.ValidTags)-1]; m.fields[fieldTag] != want {
+		t.Errorf("left from the first tag = %q, want %q", m.fields[fieldTag], want)
+	}
+}
+
+func TestCreateRejectsShortText(t *testing.T) {
+	m := newCreateModel(nil)
+	m.fields[fieldText] = "be terse"
+	m.fields[fieldTag] = "writing"
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd != nil || !strings.HasPrefix(m.fieldErrs[fieldText], "too short") {
+		t.Errorf("fieldErrs = %q, want text too short", m.fieldErrs)
+	}
+}
+
+func TestCreateCheckWarnsThenSubmitsAnyway(t *testing.T) {
+	text := "Ask for a failing test before any fix\n```go\nt.Fatal()"
+	f := &clienttest.Fake{
+		Spells:  []domain.Spell{{ID: uuid.New(), Text: "ASK for a failing test before any fix\n```go\n  t.Fatal()", Author: &domain.Author{Login: "ada"}}},
+		Verdict: &domain.ForgeVerdict{Verdict: "REJECT", Reason: "generic advice"},
+	}
+	m := newCreateModel(f)
+	m.fields[fieldText] = text
+	m.fields[fieldTag] = "testing"
+	m.focus = fieldTag
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil || !m.checking {
+		t.Fatal("expected ctrl+s to start the pre-submit check")
+	}
+	m, cmd = m.Update(cmd())
+	// The duplicate search only needs the opening words.
+	if calls := f.Calls(); len(calls) == 0 || calls[0].Args[0] != "Ask for a failing test before any fix" {
+		t.Errorf("calls = %+v, want a search for the first %d words", calls, dupQueryWords)
+	}
+	if cmd != nil {
+		t.Fatal("expected warnings to hold the spell back")
+	}
+	warn := m.fieldWarns[fieldText]
+	for _, want := range []string{"looks like a duplicate", "by @ada", "would likely reject this: generic advice", "never closed"} {
+		if !strings.Contains(warn, want) {
+			t.Errorf("warning %q missing %q", warn, want)
+		}
+	}
+	if view := m.View(); !strings.Contains(view, "⚠ looks like a duplicate") || !strings.Contains(view, "ctrl+s to submit anyway") {
+		t.Errorf("expected inline warning in view:\n%s", view)
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil || !m.submitted {
+		t.Fatal("expected a second ctrl+s to submit anyway")
+	}
+	cmd()
+	if f.Count("CreateSpell") != 1 {
+		t.Errorf("CreateSpell calls = %d, want 1", f.Count("CreateSpell"))
+	}
+}
+
+func TestCreateCheckEditResetsWarnings(t *testing.T) {
+	f := &clienttest.Fake{Verdict: &domain.ForgeVerdict{Verdict: "REJECT"}}
+	m := newCreateModel(f)
+	m.fields[fieldText] = "Always **bold the key step"
+	m.fields[fieldTag] = "writing"
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	checked := cmd()
+
+	// Typing while the check runs makes its result stale.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	m, _ = m.Update(checked)
+	if m.fieldWarns[fieldText] != "" {
+		t.Errorf("stale check produced warnings %q", m.fieldWarns[fieldText])
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil || m.submitted {
+		t.Fatal("expected the edited form to be checked again, not submitted")
+	}
+}
+
+func TestCreateCheckWithoutPreviewEndpoint(t *testing.T) {
+	f := &clienttest.Fake{Fail: map[string]error{"PreviewSpell": &client.HTTPError{StatusCode: 404}}}
+	m := newCreateModel(f)
+	m.fields[fieldText] = "Name the invariant before writing the loop"
+	m.fields[fieldTag] = "coding"
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m, cmd = m.Update(cmd())
+	if !m.noPreview || cmd == nil {
+		t.Fatalf("noPreview = %v, cmd = %v; want previews skipped and the spell submitted", m.noPreview, cmd)
+	}
+	cmd()
+
+	m.fields[fieldText] = "Name the invariant before writing any loop"
+	m.fields[fieldTag] = "coding"
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	cmd()
+	if got := f.Count("PreviewSpell"); got != 1 {
+		t.Errorf("PreviewSpell calls = %d, want 1", got)
+	}
+}
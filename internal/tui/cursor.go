@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+// cursorFrameInterval is the animation frame rate shared by the cursor blink
+// and the animated "you" label.
+const cursorFrameInterval = 150 * time.Millisecond
+
+// cursorBlinkInterval is how long the cursor stays on (and then off); 0 keeps it solid.
+var cursorBlinkInterval = config.DefaultCursorBlink
+
+// cursorHighVisibility swaps the block cursor for a bright, always-visible one.
+var cursorHighVisibility bool
+
+var (
+	cursorHighVisStyle = lipgloss.NewStyle().
+		Foreground(paletteColor("#facc15")).
+		Bold(true)
+)
+
+// cursorBlinkMsg advances the input cursor animation by one frame.
+type cursorBlinkMsg struct{}
+
+func cursorBlinkCmd() tea.Cmd {
+	return tea.Tick(cursorFrameInterval, func(time.Time) tea.Msg {
+		return cursorBlinkMsg{}
+	})
+}
+
+// cursorVisible reports whether the blinking cursor is in its "on" phase at frame.
+func cursorVisible(frame int) bool {
+	if cursorBlinkInterval <= 0 {
+		return true
+	}
+	elapsed := time.Duration(frame) * cursorFrameInterval
+	return (elapsed/cursorBlinkInterval)%2 == 0
+}
+
+// renderCursor returns the input cursor for the given animation frame.
+// The high-visibility cursor never disappears: its "off" phase is an underline.
+func renderCursor(frame int) string {
+	if cursorHighVisibility {
+		if cursorVisible(frame) {
+			return cursorHighVisStyle.Render("█")
+		}
+		return cursorHighVisStyle.Render("▁")
+	}
+	if cursorVisible(frame) {
+		return accentStyle.Render("█")
+	}
+	return " "
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+func TestCursorVisibleDefaultBlink(t *testing.T) {
+	withConfig(t, config.Config{})
+	// 600ms on, 600ms off at 150ms per frame.
+	for frame, want := range []bool{true, true, true, true, false, false, false, false, true} {
+		if got := cursorVisible(frame); got != want {
+			t.Errorf("frame %d: visible = %v, want %v", frame, got, want)
+		}
+	}
+}
+
+func TestCursorVisibleCustomBlink(t *testing.T) {
+	withConfig(t, config.Config{CursorBlink: "300ms"})
+	if !cursorVisible(1) || cursorVisible(2) {
+		t.Error("expected a 2-frame on/off cycle for 300ms blink")
+	}
+	if cursorBlinkInterval != 300*time.Millisecond {
+		t.Errorf("blink interval = %v, want 300ms", cursorBlinkInterval)
+	}
+}
+
+func TestCursorBlinkOffIsSolid(t *testing.T) {
+	withConfig(t, config.Config{CursorBlink: "off"})
+	for frame := 0; frame < 20; frame++ {
+		if !cursorVisible(frame) {
+			t.Fatalf("frame %d: expected solid cursor when blink is off", frame)
+		}
+	}
+}
+
+func TestHighVisibilityCursorNeverBlank(t *testing.T) {
+	withConfig(t, config.Config{CursorStyle: config.CursorStyleHighVisibility})
+	for frame := 0; frame < 10; frame++ {
+		if strings.TrimSpace(renderCursor(frame)) == "" {
+			t.Fatalf("frame %d: high-visibility cursor rendered blank", frame)
+		}
+	}
+}
+
+func TestCreateViewUsesConfiguredCursor(t *testing.T) {
+	withConfig(t, config.Config{CursorStyle: config.CursorStyleHighVisibility})
+	m := newCreateModel(nil)
+	m, _ = m.Update(cursorBlinkMsg{})
+	m.animFrame = 4 // "off" phase at the default blink rate
+	if !strings.Contains(m.View(), "▁") {
+		t.Errorf("expected high-visibility off-phase cursor in create view, got:\n%s", m.View())
+	}
+}
+
+func TestAppCursorBlinkForwardsToInputs(t *testing.T) {
+	app := newTestApp()
+	model, cmd := app.Update(cursorBlinkMsg{})
+	app = model.(App)
+	if cmd == nil {
+		t.Error("expected the app to re-arm the blink ticker")
+	}
+	if app.hall.animFrame != 1 || app.create.animFrame != 1 {
+		t.Errorf("expected blink forwarded to inputs, hall=%d create=%d", app.hall.animFrame, app.create.animFrame)
+	}
+}
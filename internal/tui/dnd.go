@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+// quietHours is the daily span in which alerts are silenced without the
+// magician asking. It comes from the config.
+var quietHours config.QuietHours
+
+// toggleDNDMsg asks the App to turn do-not-disturb on or off.
+type toggleDNDMsg struct{}
+
+func toggleDNDCmd() tea.Cmd {
+	return func() tea.Msg { return toggleDNDMsg{} }
+}
+
+// doNotDisturb reports whether alerts are silenced at now: toggled on with
+// Z or /dnd, or during quiet hours. Mentions and messages still arrive and
+// collect in Notifications; only the bell, flash and spoken alert stop.
+func (a App) doNotDisturb(now time.Time) bool {
+	return a.dnd || quietHours.Contains(now)
+}
+
+// toggleDND flips do-not-disturb and says so in the Hall's status line.
+func (a App) toggleDND() (App, tea.Cmd) {
+	a.dnd = !a.dnd
+	switch {
+	case a.dnd:
+		a.hall.status = "do not disturb · alerts are silenced until you press Z again"
+	case quietHours.Contains(time.Now()):
+		a.hall.status = "do not disturb off · quiet hours still silence alerts"
+	default:
+		a.hall.status = "do not disturb off"
+	}
+	// Nothing is flashing under do-not-disturb.
+	a.flashText = ""
+	return a, nil
+}
+
+// dndBadge is the muted bell shown in the header while alerts are silenced.
+func (a App) dndBadge(now time.Time) string {
+	switch {
+	case a.dnd:
+		return dimStyle.Render("🔕 dnd")
+	case quietHours.Contains(now):
+		return dimStyle.Render("🔕 quiet hours")
+	}
+	return ""
+}
+
+func slashDND(m hallModel, _ string) (hallModel, tea.Cmd) {
+	return m, toggleDNDCmd()
+}
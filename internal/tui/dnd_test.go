@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+func TestDNDSilencesAlerts(t *testing.T) {
+	withConfig(t, config.Config{Bell: true, Flash: true})
+	a := newTestApp()
+	a.hall.inputFocused = false // nav mode so global keys work
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	a = model.(App)
+	if !a.dnd {
+		t.Fatal("expected Z to turn on do not disturb")
+	}
+	a, cmd := a.handleAlert(alertMsg{reason: "@ada mentioned you"}, time.Now())
+	if cmd != nil || ringing(a) || a.flashText != "" {
+		t.Error("expected no bell, flash or announcement under do not disturb")
+	}
+
+	model, _ = a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	a = model.(App)
+	a, _ = a.handleAlert(alertMsg{reason: "@ada mentioned you"}, time.Now())
+	if !ringing(a) || a.flashText == "" {
+		t.Error("expected alerts back once do not disturb is off")
+	}
+}
+
+func TestQuietHoursSilenceAlerts(t *testing.T) {
+	withConfig(t, config.Config{Bell: true, QuietHours: "22:00-07:00"})
+	t.Cleanup(func() { quietHours = config.QuietHours{} })
+	a := newTestApp()
+	night := time.Date(2026, 3, 1, 23, 30, 0, 0, time.Local)
+	a, _ = a.handleAlert(alertMsg{reason: "x"}, night)
+	if ringing(a) {
+		t.Error("expected the bell silenced during quiet hours")
+	}
+	if !strings.Contains(a.dndBadge(night), "quiet hours") {
+		t.Errorf("badge = %q, want quiet hours", a.dndBadge(night))
+	}
+	a, _ = a.handleAlert(alertMsg{reason: "x"}, night.Add(9*time.Hour))
+	if !ringing(a) {
+		t.Error("expected the bell after quiet hours end")
+	}
+}
+
+func TestDNDBadgeInHeader(t *testing.T) {
+	a := newTestApp()
+	if strings.Contains(a.View(), "dnd") {
+		t.Error("expected no badge with alerts on")
+	}
+	a, _ = a.toggleDND()
+	if !strings.Contains(a.View(), "🔕 dnd") {
+		t.Errorf("expected the muted bell in the header, got:\n%s", a.View())
+	}
+}
+
+func TestSlashDNDToggles(t *testing.T) {
+	m := newTestHallModel()
+	m.input = "/dnd"
+	_, cmd, handled := m.runSlash(m.input)
+	if !handled || cmd == nil {
+		t.Fatal("expected /dnd to be handled in the client")
+	}
+	if _, ok := cmd().(toggleDNDMsg); !ok {
+		t.Error("expected /dnd to toggle do not disturb")
+	}
+}
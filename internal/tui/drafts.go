@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/drafts"
+)
+
+// draftSaveInterval is how often unsaved drafts are flushed to disk, bounding
+// what a crash can lose.
+const draftSaveInterval = 2 * time.Second
+
+// draftRestoredStatus is shown when a view picks up a saved draft.
+const draftRestoredStatus = "draft restored"
+
+// roomDraftKey keys the Hall input draft by room.
+func roomDraftKey(slug string) string {
+	return "hall:" + slug
+}
+
+// threadDraftKey keys a reply draft by thread ID.
+func threadDraftKey(threadID string) string {
+	return "thread:" + threadID
+}
+
+// createDraftKey keys one field of the spell form.
+func createDraftKey(f createField) string {
+	switch f {
+	case fieldTag:
+		return "create:tag"
+	case fieldModel:
+		return "create:model"
+	case fieldContext:
+		return "create:context"
+	default:
+		return "create:text"
+	}
+}
+
+type draftSaveTickMsg struct{}
+
+// draftSaveTickCmd flushes s on each tick. Save is a no-op when nothing changed.
+func draftSaveTickCmd(s *drafts.Store) tea.Cmd {
+	return tea.Tick(draftSaveInterval, func(time.Time) tea.Msg {
+		s.Save() //nolint:errcheck // best-effort; retried on the next tick
+		return draftSaveTickMsg{}
+	})
+}
+
+// WithDrafts attaches a draft store, restoring any saved Hall input and spell
+// form. Thread drafts are restored as each thread is opened.
+func (a App) WithDrafts(s *drafts.Store) App {
+	a.drafts = s
+	a.hall.drafts = s
+	a.threads.drafts = s
+	a.create.drafts = s
+	a.hall = a.hall.restoreDraft()
+	a.create = a.create.restoreDraft()
+	return a
+}
+
+// restoreDraft loads the saved Hall input, if any.
+func (m hallModel) restoreDraft() hallModel {
+	if d := m.drafts.Get(roomDraftKey(m.slug())); d != "" {
+		m.input, m.inputCursor = d, editCursor{}
+		m.status = draftRestoredStatus
+	}
+	return m
+}
+
+// restoreDraft loads the saved reply for the open thread, if any.
+func (m threadsModel) restoreDraft() threadsModel {
+	if d := m.drafts.Get(threadDraftKey(m.openThreadID)); d != "" {
+		m.input, m.inputCursor = d, editCursor{}
+		m.status = draftRestoredStatus
+	}
+	return m
+}
+
+// restoreDraft loads a saved spell form, if any.
+func (m createModel) restoreDraft() createModel {
+	restored := false
+	for f := createField(0); f < numFields; f++ {
+		if d := m.drafts.Get(createDraftKey(f)); d != "" {
+			m.fields[f], m.cursors[f] = d, editCursor{}
+			restored = true
+		}
+	}
+	m.draftID = m.drafts.Get(createShareKey)
+	if restored {
+		m.statusMsg = draftRestoredStatus
+	}
+	return m
+}
+
+// saveDraft records the spell form. An untouched form clears the draft so the
+// default model alone never counts as one.
+func (m createModel) saveDraft() {
+	blank := m.fields[fieldModel] == defaultModel
+	for f := createField(0); f < numFields; f++ {
+		if f != fieldModel && m.fields[f] != "" {
+			blank = false
+		}
+	}
+	for f := createField(0); f < numFields; f++ {
+		text := m.fields[f]
+		if blank {
+			text = ""
+		}
+		m.drafts.Set(createDraftKey(f), text)
+	}
+	m.drafts.Set(createShareKey, m.draftID)
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/drafts"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func newTestDraftStore(t *testing.T) *drafts.Store {
+	t.Helper()
+	s, err := drafts.Open(filepath.Join(t.TempDir(), "drafts.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestHallInputSavedAsDraft(t *testing.T) {
+	a := newTestApp().WithDrafts(newTestDraftStore(t))
+	a.hall, _ = a.hall.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("half typed")})
+	if got := a.drafts.Get(roomDraftKey(hallSlug)); got != "half typed" {
+		t.Errorf("hall draft = %q, want %q", got, "half typed")
+	}
+}
+
+func TestHallDraftKeptPerRoom(t *testing.T) {
+	a := newTestApp().WithDrafts(newTestDraftStore(t))
+	a.hall, _ = a.hall.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("for the hall")})
+
+	a.hall = a.hall.enterRoom("amarok-den", "Amarok Den")
+	if a.hall.input != "" {
+		t.Errorf("expected an empty input in the guild room, got %q", a.hall.input)
+	}
+	a.hall, _ = a.hall.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("for the pack")})
+
+	a.hall = a.hall.enterRoom("", "")
+	if a.hall.input != "for the hall" {
+		t.Errorf("hall input = %q, want the hall draft back", a.hall.input)
+	}
+	if got := a.drafts.Get(roomDraftKey("amarok-den")); got != "for the pack" {
+		t.Errorf("guild room draft = %q", got)
+	}
+}
+
+func TestWithDraftsRestoresHallAndCreate(t *testing.T) {
+	s := newTestDraftStore(t)
+	s.Set(roomDraftKey(hallSlug), "unsent hello")
+	s.Set(createDraftKey(fieldText), "a spell in progress")
+
+	a := newTestApp().WithDrafts(s)
+	if a.hall.input != "unsent hello" || a.hall.status != draftRestoredStatus {
+		t.Errorf("hall input=%q status=%q, want restored draft", a.hall.input, a.hall.status)
+	}
+	if a.create.fields[fieldText] != "a spell in progress" || a.create.statusMsg != draftRestoredStatus {
+		t.Errorf("create text=%q status=%q, want restored draft", a.create.fields[fieldText], a.create.statusMsg)
+	}
+}
+
+func TestThreadDraftRestoredOnOpen(t *testing.T) {
+	s := newTestDraftStore(t)
+	m := newTestThreadsModel()
+	m.drafts = s
+	thread := makeTestThread("alice", "loomari", "hi")
+	m, _ = m.Update(threadsListLoadedMsg{threads: []domain.Thread{thread}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("brb")})
+
+	// Leave the thread: the input clears but the draft survives.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.input != "" {
+		t.Fatalf("expected input cleared after leaving, got %q", m.input)
+	}
+	if got := s.Get(threadDraftKey(thread.ID.String())); got != "brb" {
+		t.Fatalf("thread draft = %q, want brb", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.input != "brb" || m.status != draftRestoredStatus {
+		t.Errorf("input=%q status=%q, want restored draft", m.input, m.status)
+	}
+}
+
+func TestCreateDraftClearedAfterSubmit(t *testing.T) {
+	s := newTestDraftStore(t)
+	m := newCreateModel(nil)
+	m.drafts = s
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if s.Get(createDraftKey(fieldText)) != "x" {
+		t.Fatal("expected spell text saved as draft")
+	}
+	m, _ = m.Update(spellCreatedMsg{spell: &domain.Spell{}})
+	for f := createField(0); f < numFields; f++ {
+		if got := s.Get(createDraftKey(f)); got != "" {
+			t.Errorf("draft %s = %q after submit, want cleared", createDraftKey(f), got)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// editCursor is the insertion point of a text input, kept as the number of
+// runes after it. The zero value sits at the end, so an input whose text is
+// replaced wholesale (cleared by a send, restored from a draft) still types
+// at the end. Movement steps over whole grapheme clusters, like backspace.
+//
+// The keys follow readline: ←/→ (ctrl+b/ctrl+f) move a character,
+// alt+b/alt+f (ctrl+←/ctrl+→) a word, home/end (ctrl+a/ctrl+e) to the ends of
+// the line and ↑/↓ between lines. ctrl+w deletes the word before the cursor,
+// alt+d the word after, ctrl+u back to the start of the line and ctrl+k to
+// its end.
+type editCursor struct {
+	tail int
+}
+
+// split returns the text before and after the cursor.
+func (c editCursor) split(text string) (before, after string) {
+	n := utf8.RuneCountInString(text)
+	if c.tail <= 0 || n == 0 {
+		return text, ""
+	}
+	if c.tail >= n {
+		return "", text
+	}
+	i := len(text)
+	for range c.tail {
+		_, size := utf8.DecodeLastRuneInString(text[:i])
+		i -= size
+	}
+	return text[:i], text[i:]
+}
+
+// atEnd reports whether the cursor is after the last character of text.
+func (c editCursor) atEnd(text string) bool {
+	_, after := c.split(text)
+	return after == ""
+}
+
+// set moves the cursor to between before and after.
+func (c *editCursor) set(after string) {
+	c.tail = utf8.RuneCountInString(after)
+}
+
+// edit applies key to text at the cursor and returns the new text. Printable
+// keys and pastes are inserted, clamped to maxInputLen runes; other keys
+// that aren't editing keys leave text and cursor alone.
+func (c *editCursor) edit(text, key string) string {
+	before, after := c.split(text)
+	switch key {
+	case "left", "ctrl+b":
+		if before != "" {
+			g := lastGrapheme(before)
+			before, after = before[:len(before)-len(g)], g+after
+		}
+	case "right", "ctrl+f":
+		if after != "" {
+			g, _ := ansi.FirstGraphemeCluster(after, ansi.GraphemeWidth)
+			before, after = before+g, after[len(g):]
+		}
+	case "alt+b", "ctrl+left", "alt+left":
+		i := wordStart(before)
+		before, after = before[:i], before[i:]+after
+	case "alt+f", "ctrl+right", "alt+right":
+		i := wordEnd(after)
+		before, after = before+after[:i], after[i:]
+	case "home", "ctrl+a":
+		i := strings.LastIndexByte(before, '\n') + 1
+		before, after = before[:i], before[i:]+after
+	case "end", "ctrl+e":
+		i := lineEnd(after)
+		before, after = before+after[:i], after[i:]
+	case "up":
+		before, after = moveLine(before, after, -1)
+	case "down":
+		before, after = moveLine(before, after, 1)
+	case "backspace", "ctrl+h":
+		before = trimLastGrapheme(before)
+	case "delete", "ctrl+d":
+		if after != "" {
+			g, _ := ansi.FirstGraphemeCluster(after, ansi.GraphemeWidth)
+			after = after[len(g):]
+		}
+	case "ctrl+w", "alt+backspace":
+		before = before[:wordStart(before)]
+	case "alt+d":
+		after = after[wordEnd(after):]
+	case "ctrl+u":
+		before = before[:strings.LastIndexByte(before, '\n')+1]
+	case "ctrl+k":
+		after = after[lineEnd(after):]
+	default:
+		keyLen := utf8.RuneCountInString(key)
+		if keyLen < 1 || isNamedKey(key) {
+			return text
+		}
+		remaining := maxInputLen - utf8.RuneCountInString(text)
+		if remaining <= 0 {
+			return text
+		}
+		// Clamp paste to remaining capacity.
+		if keyLen > remaining {
+			key = clampGraphemes(key, remaining)
+		}
+		before += key
+	}
+	c.set(after)
+	return before + after
+}
+
+// lastGrapheme returns the last grapheme cluster of s.
+func lastGrapheme(s string) string {
+	return s[len(trimLastGrapheme(s)):]
+}
+
+// wordStart returns where the word ending s starts, skipping any spaces
+// between it and the end first.
+func wordStart(s string) int {
+	last := strings.LastIndexFunc(s, notSpace)
+	if last < 0 {
+		return 0
+	}
+	space := strings.LastIndexFunc(s[:last], unicode.IsSpace)
+	if space < 0 {
+		return 0
+	}
+	_, size := utf8.DecodeRuneInString(s[space:])
+	return space + size
+}
+
+func notSpace(r rune) bool { return !unicode.IsSpace(r) }
+
+// wordEnd returns where the word starting s ends, skipping any spaces
+// before it first.
+func wordEnd(s string) int {
+	i := strings.IndexFunc(s, notSpace)
+	if i < 0 {
+		return len(s)
+	}
+	j := strings.IndexFunc(s[i:], unicode.IsSpace)
+	if j < 0 {
+		return len(s)
+	}
+	return i + j
+}
+
+// lineEnd returns where the line starting s ends.
+func lineEnd(s string) int {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return i
+	}
+	return len(s)
+}
+
+// moveLine moves the cursor between before and after by dir lines, keeping
+// its column in runes where the target line is long enough. On the first or
+// last line it stays put.
+func moveLine(before, after string, dir int) (string, string) {
+	text := before + after
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+	col := utf8.RuneCountInString(before[lineStart:])
+	var start int
+	if dir < 0 {
+		if lineStart == 0 {
+			return before, after
+		}
+		start = strings.LastIndexByte(text[:lineStart-1], '\n') + 1
+	} else {
+		end := len(before) + lineEnd(after)
+		if end == len(text) {
+			return before, after
+		}
+		start = end + 1
+	}
+	line := text[start : start+lineEnd(text[start:])]
+	i := 0
+	for range col {
+		if i >= len(line) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+	}
+	return text[:start+i], text[start+i:]
+}
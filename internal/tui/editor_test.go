@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// typeKeys applies keys in turn to text with c, returning the text.
+func typeKeys(c *editCursor, text string, keys ...string) string {
+	for _, k := range keys {
+		text = c.edit(text, k)
+	}
+	return text
+}
+
+// withCaret shows where c sits in text, as "|".
+func withCaret(c editCursor, text string) string {
+	before, after := c.split(text)
+	return before + "|" + after
+}
+
+func TestEditCursorMovesAndInserts(t *testing.T) {
+	tests := []struct {
+		name  string
+		start string
+		keys  []string
+		want  string
+	}{
+		{"insert mid-text", "helo", []string{"left", "l"}, "hell|o"},
+		{"home then type", "world", []string{"home", "hello "}, "hello |world"},
+		{"end after home", "abc", []string{"home", "end"}, "abc|"},
+		{"right stops at end", "ab", []string{"right", "right"}, "ab|"},
+		{"left over an emoji", "hi 👋🏽", []string{"left"}, "hi |👋🏽"},
+		{"word back", "one two three", []string{"alt+b"}, "one two |three"},
+		{"word back over spaces", "one two  ", []string{"alt+b"}, "one |two  "},
+		{"word forward", "one two three", []string{"home", "alt+f"}, "one| two three"},
+		{"ctrl arrows move words", "one two", []string{"ctrl+left", "ctrl+left", "ctrl+right"}, "one| two"},
+		{"home is the line's start", "first\nsecond", []string{"home"}, "first\n|second"},
+		{"up keeps the column", "abcdef\nxy", []string{"up"}, "ab|cdef\nxy"},
+		{"down clamps to a short line", "abcdef\nxy", []string{"up", "end", "down"}, "abcdef\nxy|"},
+		{"up on the first line stays", "abc", []string{"left", "up"}, "ab|c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c editCursor
+			text := typeKeys(&c, tt.start, tt.keys...)
+			if got := withCaret(c, text); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditCursorDeletes(t *testing.T) {
+	tests := []struct {
+		name  string
+		start string
+		keys  []string
+		want  string
+	}{
+		{"backspace mid-text", "hello", []string{"left", "backspace"}, "hel|o"},
+		{"delete forward", "hello", []string{"home", "delete"}, "|ello"},
+		{"ctrl+w deletes the word before", "git commit -m", []string{"ctrl+w"}, "git commit |"},
+		{"ctrl+w takes trailing spaces", "git commit  ", []string{"ctrl+w"}, "git |"},
+		{"alt+d deletes the word after", "one two three", []string{"home", "alt+f", "alt+d"}, "one| three"},
+		{"ctrl+u deletes to line start", "keep\ndrop this", []string{"left", "ctrl+u"}, "keep\n|s"},
+		{"ctrl+k deletes to line end", "one two\nnext", []string{"up", "home", "alt+f", "ctrl+k"}, "one|\nnext"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c editCursor
+			text := typeKeys(&c, tt.start, tt.keys...)
+			if got := withCaret(c, text); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditCursorClampsWhenTextShrinks(t *testing.T) {
+	c := editCursor{tail: 10}
+	if got := withCaret(c, "abc"); got != "|abc" {
+		t.Errorf("got %q, want the cursor clamped to the start", got)
+	}
+	if !c.atEnd("") {
+		t.Error("expected an empty input to have the cursor at its end")
+	}
+}
+
+func TestEditCursorInsertRespectsMaxLen(t *testing.T) {
+	var c editCursor
+	full := strings.Repeat("a", maxInputLen)
+	text := typeKeys(&c, full, "home", "b")
+	if text != full {
+		t.Error("expected no insert at the limit")
+	}
+}
+
+func TestRenderChatInputCursorMidText(t *testing.T) {
+	var c editCursor
+	text := typeKeys(&c, "hello world", "alt+b")
+	out := renderChatInput("mage", text, c, "", true, 0, 80)
+	if strings.Contains(out, cursorMark) {
+		t.Fatal("cursor mark leaked into the frame")
+	}
+	plain := ansi.Strip(out)
+	if !strings.Contains(plain, "hello █world") {
+		t.Errorf("expected the cursor before world, got %q", plain)
+	}
+	if strings.HasSuffix(plain, "█") {
+		t.Error("expected no second cursor at the end")
+	}
+}
+
+func TestHallInputEditsAtCursor(t *testing.T) {
+	m := newTestHallModel()
+	m.inputFocused = true
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("hllo")},
+		{Type: tea.KeyHome},
+		{Type: tea.KeyRight},
+		{Type: tea.KeyRunes, Runes: []rune("e")},
+		{Type: tea.KeyEnd},
+		{Type: tea.KeyRunes, Runes: []rune(" @")},
+	} {
+		m, _ = m.Update(k)
+	}
+	if m.input != "hello @" {
+		t.Errorf("input = %q, want %q", m.input, "hello @")
+	}
+
+	m.input, m.inputCursor = "see you", editCursor{}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	if m.input != "see " {
+		t.Errorf("input = %q after ctrl+w, want %q", m.input, "see ")
+	}
+}
@@ -0,0 +1,35 @@
+package tui
+
+import "github.com/naveenspark/grimora/pkg/client"
+
+// Status text for API failures that have the same fix wherever they happen.
+// A lapsed session offers L in the header instead, when the app can sign in
+// again; see canRelogin.
+const (
+	errTextUnauthorized = "not authenticated -- run: grimora login"
+	errTextNetwork      = "offline -- check your connection"
+	errTextRateLimited  = "rate limited -- try again in a moment"
+)
+
+// errReason describes an API failure for the user: what to do about it
+// when there's something to do, otherwise the error itself.
+func errReason(err error) string {
+	switch {
+	case client.IsUnauthorized(err):
+		return errTextUnauthorized
+	case client.IsNetwork(err):
+		return errTextNetwork
+	case client.IsRateLimited(err):
+		return errTextRateLimited
+	}
+	return err.Error()
+}
+
+// errText is errReason for a status line, after a prefix saying what
+// failed. A lapsed login reads the same everywhere, so it drops the prefix.
+func errText(prefix string, err error) string {
+	if client.IsUnauthorized(err) {
+		return errTextUnauthorized
+	}
+	return prefix + ": " + errReason(err)
+}
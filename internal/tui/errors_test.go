@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+func TestErrText(t *testing.T) {
+	httpErr := func(code int) error {
+		return fmt.Errorf("client.UpvoteSpell: %w", &client.HTTPError{StatusCode: code, Message: "nope"})
+	}
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{httpErr(401), errTextUnauthorized},
+		{httpErr(429), "upvote failed: " + errTextRateLimited},
+		{fmt.Errorf("client.UpvoteSpell: %w", &client.NetworkError{Err: errors.New("dial tcp: refused")}), "upvote failed: " + errTextNetwork},
+		{httpErr(500), "upvote failed: client.UpvoteSpell: HTTP 500: nope"},
+	}
+	for _, tt := range tests {
+		if got := errText("upvote failed", tt.err); got != tt.want {
+			t.Errorf("errText(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestGrimoireUnauthorizedPromptsLogin(t *testing.T) {
+	m := newTestGrimoireModel()
+	m, _ = m.Update(upvoteResultMsg{err: fmt.Errorf("client.UpvoteSpell: %w", &client.HTTPError{StatusCode: 401})})
+	if m.statusMsg != errTextUnauthorized {
+		t.Errorf("statusMsg = %q, want %q", m.statusMsg, errTextUnauthorized)
+	}
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// spellFill is the form "c" opens on a spell with placeholders such as
+// {{language}} or <PROJECT>: one field per blank, copied filled in.
+type spellFill struct {
+	text   string
+	blanks []domain.Placeholder
+	values []string // by blank
+	field  int      // blank being typed into
+}
+
+// copySpell copies the spell's text, first asking for its blanks if it
+// has any.
+func (m grimoireModel) copySpell(spell domain.Spell) (grimoireModel, tea.Cmd) {
+	blanks := domain.Placeholders(spell.Text)
+	if len(blanks) == 0 {
+		return m, copyTextCmd(spell.Text)
+	}
+	m.fill = &spellFill{text: spell.Text, blanks: blanks, values: make([]string, len(blanks))}
+	return m, nil
+}
+
+func copyTextCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		return copyResultMsg{err: clipboard.WriteAll(text)}
+	}
+}
+
+// filled returns the spell text with the values typed so far. Blanks still
+// empty keep their placeholder.
+func (f spellFill) filled() string {
+	values := make(map[string]string, len(f.blanks))
+	for i, b := range f.blanks {
+		values[b.Name] = f.values[i]
+	}
+	return domain.FillPlaceholders(f.text, values)
+}
+
+// updateFill handles keys while the fill form is open: tab moves between
+// blanks, enter copies and esc copies nothing.
+func (m grimoireModel) updateFill(msg tea.KeyMsg) (grimoireModel, tea.Cmd) {
+	f := *m.fill
+	switch msg.String() {
+	case "esc":
+		m.fill = nil
+		return m, nil
+	case "enter":
+		m.fill = nil
+		return m, copyTextCmd(f.filled())
+	case "tab", "down":
+		f.field = (f.field + 1) % len(f.blanks)
+	case "shift+tab", "up":
+		f.field = (f.field + len(f.blanks) - 1) % len(f.blanks)
+	default:
+		key := msg.String()
+		if msg.Paste {
+			key = string(msg.Runes)
+		}
+		f.values = slices.Clone(f.values)
+		f.values[f.field] = editRune(f.values[f.field], key)
+	}
+	m.fill = &f
+	return m, nil
+}
+
+// viewFill renders the fill form: a field per blank, then the spell as it
+// will be copied.
+func (m grimoireModel) viewFill() string {
+	f := m.fill
+	var b strings.Builder
+	b.WriteString(" " + grimLabelStyle.Render("FILL IN THE SPELL") + "  " + dimStyle.Render("blanks left empty are copied as written") + "\n")
+	b.WriteString(" " + metaStyle.Render(strings.Repeat("─", max(m.width-2, 4))) + "\n")
+
+	labelW := 0
+	for _, blank := range f.blanks {
+		labelW = max(labelW, textWidth(blank.Name))
+	}
+	labelW = min(labelW, 24)
+	for i, blank := range f.blanks {
+		cursor := "  "
+		label := dimStyle.Render(padRight(truncStr(blank.Name, labelW), labelW))
+		value := f.values[i]
+		if i == f.field {
+			cursor = accentStyle.Render("▸") + " "
+			label = selectedStyle.Render(padRight(truncStr(blank.Name, labelW), labelW))
+			value += accentStyle.Render("▏")
+		}
+		b.WriteString(clipWidth(" "+cursor+label+"  "+value, m.width) + "\n")
+	}
+
+	b.WriteString("\n")
+	preview := lipgloss.NewStyle().Width(max(m.width-4, 20)).Render(f.filled())
+	for _, line := range strings.Split(preview, "\n") {
+		b.WriteString(" " + normalStyle.Render(line) + "\n")
+	}
+	return truncateToHeight(b.String(), m.height)
+}
+
+// helpKeys lists the fill form's keys for the help bar.
+func (f spellFill) helpKeys() string {
+	return helpEntry("tab", "next blank") + "  " + helpEntry("enter", "copy") + "  " + helpEntry("esc", "cancel")
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestGrimoireCopyAsksForBlanks(t *testing.T) {
+	m := newTestGrimoireModel()
+	m, _ = m.Update(spellsLoadedMsg{spells: []domain.Spell{
+		makeTestSpell("Port this {{language}} service to <TARGET> and keep the {{language}} tests.", "refactor"),
+	}})
+
+	m, cmd := m.Update(keyRune('c'))
+	if m.fill == nil || cmd != nil {
+		t.Fatalf("c on a spell with blanks: fill = %v, cmd = %v; want the form and no copy yet", m.fill, cmd)
+	}
+	if n := len(m.fill.blanks); n != 2 {
+		t.Fatalf("form has %d blanks, want language and TARGET", n)
+	}
+	for _, r := range "Go" {
+		m, _ = m.Update(keyRune(r))
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	for _, r := range "Rust" {
+		m, _ = m.Update(keyRune(r))
+	}
+	want := "Port this Go service to Rust and keep the Go tests."
+	if got := m.fill.filled(); got != want {
+		t.Errorf("filled() = %q, want %q", got, want)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, want) {
+		t.Errorf("expected the filled-in preview, got:\n%s", view)
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.fill != nil || cmd == nil {
+		t.Error("enter should close the form and copy")
+	}
+}
+
+func TestGrimoireFillEscCopiesNothing(t *testing.T) {
+	m := newTestGrimoireModel()
+	m, _ = m.Update(spellsLoadedMsg{spells: []domain.Spell{makeTestSpell("Summarize <REPO>", "docs")}})
+	m, _ = m.Update(keyRune('c'))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.fill != nil || cmd != nil {
+		t.Error("esc should close the form without copying")
+	}
+}
+
+func TestGrimoireFillTakesPastes(t *testing.T) {
+	m := newTestGrimoireModel()
+	m, _ = m.Update(spellsLoadedMsg{spells: []domain.Spell{makeTestSpell("Summarize <REPO>", "docs")}})
+	m, _ = m.Update(keyRune('c'))
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("naveenspark/grimora"), Paste: true})
+	if got := m.fill.filled(); got != "Summarize naveenspark/grimora" {
+		t.Errorf("filled() = %q, want the paste without brackets", got)
+	}
+}
+
+func TestGrimoireCopyWithoutBlanks(t *testing.T) {
+	m := newTestGrimoireModel()
+	m, _ = m.Update(spellsLoadedMsg{spells: []domain.Spell{makeTestSpell("Explain func Map[T any](xs []T)", "debug")}})
+	m, cmd := m.Update(keyRune('c'))
+	if m.fill != nil || cmd == nil {
+		t.Error("a spell without blanks should copy straight away")
+	}
+}
+
+func TestAppFillSwallowsGlobalKeys(t *testing.T) {
+	a := newTestApp()
+	a.view = viewGrimoire
+	a.grimoire.loading = false
+	a.grimoire.spells = []domain.Spell{makeTestSpell("Review <PROJECT>", "review")}
+	model, _ := a.Update(keyRune('c'))
+	a = model.(App)
+	model, _ = a.Update(keyRune('4'))
+	a = model.(App)
+	if a.view != viewGrimoire || a.grimoire.fill == nil || a.grimoire.fill.values[0] != "4" {
+		t.Errorf("view = %v, fill = %+v; want 4 typed into the blank", a.view, a.grimoire.fill)
+	}
+}
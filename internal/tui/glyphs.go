@@ -0,0 +1,100 @@
+package tui
+
+import "strings"
+
+// asciiGlyphs is set when the terminal can't draw the Unicode glyph set
+// (see applyTerminal). Every frame is then rewritten through glyphFallbacks.
+var asciiGlyphs bool
+
+// glyphFallbacks maps each non-ASCII glyph the views draw to an ASCII
+// stand-in of the same cell width, so layouts don't shift. Guild emblems
+// have their own fallbacks (asciiEmblems). TestGlyphFallbacksCoverViews
+// fails when a view starts drawing a glyph missing here.
+var glyphFallbacks = map[rune]string{
+	// Punctuation
+	'·': "-",
+	'—': "-",
+	'…': "~",
+	'•': "*",
+	'›': ">",
+
+	// Box drawing
+	'─': "-",
+	'│': "|",
+	'┌': "+",
+	'┐': "+",
+	'└': "+",
+	'┘': "+",
+	'╭': "+",
+	'╮': "+",
+	'╰': "+",
+	'╯': "+",
+
+	// Markers
+	'●': "*",
+	'○': "o",
+	'◆': "*",
+	'★': "*",
+	'✦': "*",
+	'✧': "+",
+	'▸': ">",
+	'▲': "^",
+	'▼': "v",
+	'↑': "^",
+	'↓': "v",
+	'←': "<",
+	'→': ">",
+	'↪': ">",
+	'↻': "~",
+	'✔': "+",
+	'✓': "+",
+	'✘': "x",
+	'✗': "x",
+	'✎': "\"",
+	'✉': "m",
+	'⚠': "!",
+
+	// Blocks and bars
+	'█': "#",
+	'░': ".",
+	'▏': "|",
+	'▁': "_",
+	'▂': ".",
+	'▃': "-",
+	'▄': "=",
+	'▅': "+",
+	'▆': "*",
+	'▇': "#",
+
+	// Emoji take two cells.
+	'⚡': ">>",
+	'🔥': "**",
+	'🔨': "##",
+	'🔕': "zz",
+
+	// The input's cursor mark is swapped for the cursor before a frame is
+	// drawn; its fallback is the cursor's.
+	'\ue000': "#",
+}
+
+// glyphReplacer rewrites a frame with glyphFallbacks.
+var glyphReplacer = func() *strings.Replacer {
+	pairs := make([]string, 0, 2*len(glyphFallbacks))
+	for r, s := range glyphFallbacks {
+		pairs = append(pairs, string(r), s)
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// withGlyphs returns s as the terminal can draw it: unchanged, or with
+// every known glyph replaced by its ASCII stand-in. Accessible mode goes
+// further; see plainFrame.
+func withGlyphs(s string) string {
+	if accessibleMode {
+		return plainFrame(s)
+	}
+	if !asciiGlyphs {
+		return s
+	}
+	return glyphReplacer.Replace(s)
+}
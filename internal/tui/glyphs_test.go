@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestGlyphFallbacksKeepWidth(t *testing.T) {
+	for r, s := range glyphFallbacks {
+		if w := lipgloss.Width(string(r)); w != len(s) {
+			t.Errorf("%q is %d cells wide but its fallback %q is %d", r, w, s, len(s))
+		}
+	}
+}
+
+// TestGlyphFallbacksCoverViews checks every non-ASCII rune in the package's
+// string and rune literals has an ASCII fallback, so no view depends on a
+// glyph the terminal may not draw.
+func TestGlyphFallbacksCoverViews(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	emblems := map[rune]bool{}
+	for _, e := range guildEmblems {
+		for _, r := range e {
+			emblems[r] = true
+		}
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || (lit.Kind != token.STRING && lit.Kind != token.CHAR) {
+				return true
+			}
+			s, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+			for _, r := range s {
+				if r > 127 && glyphFallbacks[r] == "" && !emblems[r] {
+					t.Errorf("%s: %q has no ASCII fallback", fset.Position(lit.Pos()), r)
+				}
+			}
+			return true
+		})
+	}
+}
+
+func TestWithGlyphs(t *testing.T) {
+	defer func() { asciiGlyphs = false }()
+	in := " ▸ ● ada · 2m ─── ✦"
+	if got := withGlyphs(in); got != in {
+		t.Errorf("unicode terminal: got %q", got)
+	}
+	asciiGlyphs = true
+	if got, want := withGlyphs(in), " > * ada - 2m --- *"; got != want {
+		t.Errorf("ascii terminal: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// Run `make golden` after an intended layout
+// change and review the diff of testdata/golden before committing it.
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenNow is the clock every golden frame renders against.
+var goldenNow = time.Date(2026, time.March, 14, 15, 9, 26, 0, time.UTC)
+
+// goldenSizes are the terminal sizes each view is snapshotted at: narrow
+// enough to abbreviate the tabs, the classic 80x24, and roomy.
+var goldenSizes = []struct{ width, height int }{
+	{50, 16},
+	{80, 24},
+	{120, 40},
+}
+
+// assertGolden compares frame, stripped of styling, with
+// testdata/golden/<name>.golden, rewriting the file instead under -update.
+func assertGolden(t *testing.T, name, frame string) {
+	t.Helper()
+	got := ansi.Strip(frame)
+	if !strings.HasSuffix(got, "\n") {
+		got += "\n"
+	}
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from %s (run with -update if the change is intended)\n got:\n%s\nwant:\n%s", name, path, got, want)
+	}
+}
+
+// pinGolden fixes the clock and glyph set golden frames depend on for the
+// rest of the test.
+func pinGolden(t *testing.T) {
+	t.Helper()
+	oldClock, oldGlyphs := clock, asciiGlyphs
+	clock = func() time.Time { return goldenNow }
+	asciiGlyphs = false
+	t.Cleanup(func() { clock, asciiGlyphs = oldClock, oldGlyphs })
+}
+
+// goldenID returns a stable UUID for fixture n.
+func goldenID(n byte) uuid.UUID {
+	var id uuid.UUID
+	id[15] = n
+	return id
+}
+
+func goldenMe() meLoadedMsg {
+	return meLoadedMsg{
+		me: &domain.Magician{
+			ID:          goldenID(1),
+			GitHubLogin: "ada",
+			CardNumber:  42,
+			GuildID:     "loomari",
+			City:        "Lisbon",
+			Bio:         "Prompts for parsers and other small machines.",
+			CreatedAt:   goldenNow.AddDate(0, -3, 0),
+		},
+		stats: &domain.ForgeStats{SpellsForged: 12, TotalPotency: 41, AvgPotency: 3.4, AcceptanceRate: 0.75, Rank: 7, TotalRanked: 88, SpellsCast: 30},
+	}
+}
+
+func goldenSpells() []domain.Spell {
+	return []domain.Spell{
+		{ID: goldenID(10), Text: "Refactor this function into smaller pure helpers and keep the public signature unchanged.", Tag: "refactor", Model: "sonnet", Potency: 5, Upvotes: 31, Casts: 120, Author: &domain.Author{Login: "ada", GuildID: "loomari"}, CreatedAt: goldenNow.Add(-2 * time.Hour)},
+		{ID: goldenID(11), Text: "Write table-driven tests for the parser, covering every error branch.", Tag: "testing", Potency: 4, Upvotes: 12, Casts: 40, Author: &domain.Author{Login: "grace", GuildID: "cipher"}, CreatedAt: goldenNow.Add(-26 * time.Hour)},
+		{ID: goldenID(12), Text: "Explain this stack trace like I'm new to the codebase.", Tag: "debug", Potency: 3, Upvotes: 4, Author: &domain.Author{Login: "linus", GuildID: "nyx"}, CreatedAt: goldenNow.AddDate(0, 0, -9)},
+	}
+}
+
+func goldenMessages() []domain.RoomMessage {
+	return []domain.RoomMessage{
+		{ID: goldenID(20), SenderLogin: "grace", SenderGuild: "cipher", Body: "morning all, anyone tried the new refactor spell?", CreatedAt: goldenNow.Add(-40 * time.Minute)},
+		{ID: goldenID(21), SenderLogin: "ada", SenderGuild: "loomari", Body: "yes, it kept the signature stable every time", CreatedAt: goldenNow.Add(-35 * time.Minute)},
+		{ID: goldenID(22), SenderLogin: "linus", SenderGuild: "nyx", Body: "@ada does it handle generics? I've got a long function that is mostly type switches and would love to split it up without breaking callers", CreatedAt: goldenNow.Add(-5 * time.Minute)},
+	}
+}
+
+func goldenBoard() []domain.LeaderboardEntry {
+	return []domain.LeaderboardEntry{
+		{Rank: 1, Login: "grace", GuildID: "cipher", SpellsForged: 30, TotalPotency: 120},
+		{Rank: 2, Login: "linus", GuildID: "nyx", SpellsForged: 22, TotalPotency: 80},
+		{Rank: 3, Login: "ada", GuildID: "loomari", SpellsForged: 12, TotalPotency: 41},
+	}
+}
+
+func goldenGuilds() []domain.GuildStanding {
+	return []domain.GuildStanding{
+		{GuildID: "cipher", Rank: 1, Members: 14, Spells: 120, Potency: 430, Week: &domain.GuildWeek{Members: 2, Spells: 9, Potency: 30, LastRank: 2}},
+		{GuildID: "loomari", Rank: 2, Members: 11, Spells: 98, Potency: 410, Week: &domain.GuildWeek{Spells: 4, Potency: 12, LastRank: 1}},
+		{GuildID: "nyx", Rank: 3, Members: 9, Spells: 60, Potency: 200},
+	}
+}
+
+func goldenThreads() []domain.Thread {
+	return []domain.Thread{
+		{ID: goldenID(30), OtherLogin: "grace", OtherGuildID: "cipher", LastMessage: "sent you the parser spell", LastMessageAt: goldenNow.Add(-20 * time.Minute), Unread: 2, CreatedAt: goldenNow.AddDate(0, 0, -3)},
+		{ID: goldenID(31), OtherLogin: "linus", OtherGuildID: "nyx", LastMessage: "thanks!", LastMessageAt: goldenNow.AddDate(0, 0, -2), CreatedAt: goldenNow.AddDate(0, 0, -4)},
+	}
+}
+
+func goldenRoster() []domain.MagicianCard {
+	return []domain.MagicianCard{
+		{Magician: domain.Magician{GitHubLogin: "ada", GuildID: "loomari"}, SpellCount: 12, TotalPotency: 41, Online: true},
+		{Magician: domain.Magician{GitHubLogin: "mira", GuildID: "loomari"}, SpellCount: 8, TotalPotency: 30, Away: true},
+		{Magician: domain.Magician{GitHubLogin: "grace", GuildID: "cipher"}, SpellCount: 30, TotalPotency: 120},
+	}
+}
+
+func goldenNotifications() []domain.GroupedNotification {
+	spell := goldenID(10)
+	return []domain.GroupedNotification{
+		{Notification: domain.Notification{ID: goldenID(40), Type: domain.NotifMention, ActorLogin: "linus", ActorGuild: "nyx", RefSlug: hallSlug, Preview: "@ada does it handle generics?", CreatedAt: goldenNow.Add(-5 * time.Minute)}},
+		{Notification: domain.Notification{ID: goldenID(41), Type: domain.NotifUpvote, ActorLogin: "grace", ActorGuild: "cipher", RefID: &spell, Preview: "Refactor this function into smaller pure helpers", CreatedAt: goldenNow.Add(-2 * time.Hour)}, ActorCount: 3},
+		{Notification: domain.Notification{ID: goldenID(42), Type: domain.NotifFollow, ActorLogin: "mira", ActorGuild: "loomari", Read: true, CreatedAt: goldenNow.AddDate(0, 0, -1)}},
+	}
+}
+
+func goldenTelemetry() *client.TelemetryResponse {
+	return &client.TelemetryResponse{
+		Total:  34,
+		Cities: []client.CityCountEntry{{City: "Lisbon", Count: 12}, {City: "Berlin", Count: 9}, {City: "Toronto", Count: 5}},
+		Joins:  []client.DayCountEntry{{Day: "2026-03-12", Count: 2}, {Day: "2026-03-13", Count: 5}, {Day: "2026-03-14", Count: 3}},
+		Guilds: []client.GuildCountEntry{{GuildID: "cipher", Count: 14}, {GuildID: "loomari", Count: 11}, {GuildID: "nyx", Count: 9}},
+	}
+}
+
+func goldenStream() []domain.StreamEvent {
+	return []domain.StreamEvent{
+		{Kind: "spell", ID: goldenID(10), MagicianLogin: "ada", GuildID: "loomari", Title: "Refactor this function into smaller pure helpers", Tag: "refactor", Potency: 5, Upvotes: 31, CreatedAt: goldenNow.Add(-2 * time.Hour)},
+		{Kind: "member", ID: goldenID(50), MagicianLogin: "mira", GuildID: "loomari", City: "Lisbon", Contributions: 420, TopLanguage: "Go", CreatedAt: goldenNow.Add(-5 * time.Hour)},
+		{Kind: "spell", ID: goldenID(11), MagicianLogin: "grace", GuildID: "cipher", Title: "Write table-driven tests for the parser", Tag: "testing", Potency: 4, CreatedAt: goldenNow.Add(-26 * time.Hour)},
+	}
+}
+
+// goldenApp is a signed-in App at the given size showing v. It has no
+// client: tests deliver each view's data as messages and drop the commands
+// that come back.
+func goldenApp(width, height int, v view) App {
+	a := NewApp(nil, "dev")
+	a.view = v
+	a.hall.inputFocused = false
+	for _, msg := range []tea.Msg{tea.WindowSizeMsg{Width: width, Height: height}, goldenMe()} {
+		model, _ := a.Update(msg)
+		a = model.(App)
+	}
+	return a
+}
+
+func TestGoldenViews(t *testing.T) {
+	pinGolden(t)
+	cases := []struct {
+		name  string
+		view  view
+		setup func(a *App)
+		msgs  []tea.Msg
+	}{
+		{name: "hall", view: viewHall, msgs: []tea.Msg{
+			hallMessagesMsg{messages: goldenMessages()},
+			hallPresenceMsg{count: 3, logins: []string{"ada", "grace", "linus"}},
+		}},
+		{name: "grimoire", view: viewGrimoire, msgs: []tea.Msg{spellsLoadedMsg{spells: goldenSpells()}}},
+		{name: "threads", view: viewThreads, msgs: []tea.Msg{threadsListLoadedMsg{threads: goldenThreads()}}},
+		{name: "board", view: viewBoard, msgs: []tea.Msg{boardLoadedMsg{entries: goldenBoard()}}},
+		{name: "board_guilds", view: viewBoard, setup: func(a *App) { a.board.guildMode = true }, msgs: []tea.Msg{
+			boardLoadedMsg{entries: goldenBoard()},
+			boardGuildsMsg{standings: goldenGuilds()},
+		}},
+		{name: "you", view: viewYou},
+		{name: "guild", view: viewGuild, msgs: []tea.Msg{guildRosterMsg{cards: goldenRoster()}}},
+		{name: "create", view: viewCreate},
+		{name: "notifications", view: viewNotifications, msgs: []tea.Msg{notificationsLoadedMsg{items: goldenNotifications()}}},
+		{name: "realm", view: viewRealm, msgs: []tea.Msg{realmLoadedMsg{data: goldenTelemetry()}}},
+		{name: "stream", view: viewStream, msgs: []tea.Msg{streamLoadedMsg{events: goldenStream()}}},
+	}
+	for _, tc := range cases {
+		for _, size := range goldenSizes {
+			name := fmt.Sprintf("%s_%dx%d", tc.name, size.width, size.height)
+			t.Run(name, func(t *testing.T) {
+				a := goldenApp(size.width, size.height, tc.view)
+				if tc.setup != nil {
+					tc.setup(&a)
+				}
+				for _, msg := range tc.msgs {
+					model, _ := a.Update(msg)
+					a = model.(App)
+				}
+				frame := a.View()
+				lines := strings.Split(frame, "\n")
+				if len(lines) > size.height {
+					t.Errorf("frame is %d lines, taller than the %d-line terminal", len(lines), size.height)
+				}
+				for i, line := range lines {
+					if w := ansi.StringWidth(line); w > size.width {
+						t.Errorf("line %d is %d cells, wider than the %d-cell terminal: %q", i+1, w, size.width, ansi.Strip(line))
+					}
+				}
+				assertGolden(t, name, frame)
+			})
+		}
+	}
+}
@@ -3,13 +3,16 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
 
+	"github.com/naveenspark/grimora/internal/export"
 	"github.com/naveenspark/grimora/pkg/client"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
@@ -22,21 +25,35 @@ const (
 )
 
 type grimoireModel struct {
-	client    *client.Client
-	mode      grimoireMode
-	spells    []domain.Spell
-	weapons   []domain.Weapon
-	cursor    int
-	search    string
-	editing   bool // true when typing in search
-	tagFilter string
-	sortBy    string // "new", "top", or "casts"
-	detail    bool   // in detail view
-	err       error
-	width     int
-	height    int
-	loading   bool
-	statusMsg string
+	client     client.API
+	mode       grimoireMode
+	spells     []domain.Spell
+	weapons    []domain.Weapon
+	cursor     int
+	search     string
+	editing    bool             // true when typing in search
+	tagFilters []string         // spells carrying any of these; empty means all
+	tagStats   []domain.TagStat // live tag counts for the tag bar; nil until loaded
+	categories []string         // weapons in any of these categories; empty means all
+	sortBy     string           // "new", "top", or "casts"
+	savedOnly  bool             // showing only the caller's bookmarked spells
+	detail     bool             // in detail view
+	err        error
+	width      int
+	height     int
+	loading    bool
+	statusMsg  string
+
+	fill   *spellFill    // blanks being filled in before a copy; nil when not copying
+	report *reportPrompt // reason being chosen for a report; nil when not reporting
+
+	watchPending string    // spell ID of an in-flight watch toggle
+	myID         uuid.UUID // the signed-in magician, whose own spells can't be forked but can be re-forged
+
+	resumeID string // spell a saved session had the cursor on, found again once the list loads
+
+	strayID     string // spell showSpell opened from outside the loaded page; dropped again on esc
+	strayCursor int    // where the cursor was before it
 }
 
 // Reuse message types from old spells/weapons
@@ -50,11 +67,45 @@ type weaponsLoadedMsg struct {
 	err     error
 }
 
+// tagStatsLoadedMsg carries the live per-tag spell counts.
+type tagStatsLoadedMsg struct {
+	stats []domain.TagStat
+	err   error
+}
+
 type upvoteResultMsg struct{ err error }
 type copyResultMsg struct{ err error }
+
+// spellCastMsg carries the result of casting a spell with "x".
+type spellCastMsg struct {
+	cast   *domain.SpellCast
+	copied bool // spell text is on the clipboard
+	err    error
+}
+
+// spellForkedMsg carries the result of forking a spell with "f".
+type spellForkedMsg struct {
+	originalID uuid.UUID
+	fork       *domain.Spell
+	err        error
+}
+
+// spellFileMsg reports where "C" wrote the spell, relative to the working
+// directory.
+type spellFileMsg struct {
+	path string
+	err  error
+}
 type saveWeaponResultMsg struct{ err error }
 
-func newGrimoireModel(c *client.Client) grimoireModel {
+// spellSaveResultMsg carries the result of bookmarking or unbookmarking a spell.
+type spellSaveResultMsg struct {
+	id    string
+	saved bool
+	err   error
+}
+
+func newGrimoireModel(c client.API) grimoireModel {
 	return grimoireModel{
 		client:  c,
 		loading: true,
@@ -66,10 +117,13 @@ func (m grimoireModel) loadSpells() tea.Cmd {
 	return func() tea.Msg {
 		var spells []domain.Spell
 		var err error
-		if m.search != "" {
+		if m.savedOnly {
+			spells, err = allSavedSpells(m.client)
+			spells = filterSavedSpells(spells, m.tagFilters, m.search)
+		} else if m.search != "" {
 			spells, err = m.client.SearchSpells(context.Background(), m.search)
 		} else {
-			spells, err = m.client.ListSpells(context.Background(), m.tagFilter, m.sortBy, pageSize, 0)
+			spells, err = m.client.ListSpells(context.Background(), m.tagFilters, m.sortBy, pageSize, 0)
 		}
 		return spellsLoadedMsg{spells: spells, err: err}
 	}
@@ -82,7 +136,7 @@ func (m grimoireModel) loadWeapons() tea.Cmd {
 		if m.search != "" {
 			weapons, err = m.client.SearchWeapons(context.Background(), m.search)
 		} else {
-			weapons, err = m.client.ListWeapons(context.Background(), pageSize, 0)
+			weapons, err = m.client.ListWeapons(context.Background(), m.categories, pageSize, 0)
 		}
 		return weaponsLoadedMsg{weapons: weapons, err: err}
 	}
@@ -95,8 +149,17 @@ func (m grimoireModel) loadCurrent() tea.Cmd {
 	return m.loadSpells()
 }
 
+// loadTagStats fetches the tag bar's tags and counts.
+func (m grimoireModel) loadTagStats() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		stats, err := c.TagStats(context.Background())
+		return tagStatsLoadedMsg{stats: stats, err: err}
+	}
+}
+
 func (m grimoireModel) Init() tea.Cmd {
-	return m.loadCurrent()
+	return tea.Batch(m.loadCurrent(), m.loadTagStats())
 }
 
 func (m grimoireModel) Update(msg tea.Msg) (grimoireModel, tea.Cmd) {
@@ -105,11 +168,25 @@ func (m grimoireModel) Update(msg tea.Msg) (grimoireModel, tea.Cmd) {
 		m.loading = false
 		m.spells = msg.spells
 		m.err = msg.err
+		m.strayID = ""
+		if m.resumeID != "" {
+			if i := slices.IndexFunc(m.spells, func(s domain.Spell) bool { return s.ID.String() == m.resumeID }); i >= 0 {
+				m.cursor = i
+			}
+			m.resumeID = ""
+		}
 		if m.cursor >= len(m.spells) {
 			m.cursor = 0
 		}
 		return m, nil
 
+	case tagStatsLoadedMsg:
+		// Without live stats the bar keeps showing displayTags.
+		if msg.err == nil {
+			m.tagStats = msg.stats
+		}
+		return m, nil
+
 	case weaponsLoadedMsg:
 		m.loading = false
 		m.weapons = msg.weapons
@@ -121,11 +198,7 @@ func (m grimoireModel) Update(msg tea.Msg) (grimoireModel, tea.Cmd) {
 
 	case upvoteResultMsg:
 		if msg.err != nil {
-			if client.IsStatus(msg.err, 401) {
-				m.statusMsg = "not authenticated -- run: grimora login"
-			} else {
-				m.statusMsg = fmt.Sprintf("upvote failed: %v", msg.err)
-			}
+			m.statusMsg = errText("upvote failed", msg.err)
 			return m, nil
 		}
 		m.statusMsg = "upvoted!"
@@ -133,16 +206,132 @@ func (m grimoireModel) Update(msg tea.Msg) (grimoireModel, tea.Cmd) {
 
 	case saveWeaponResultMsg:
 		if msg.err != nil {
-			if client.IsStatus(msg.err, 401) {
-				m.statusMsg = "not authenticated -- run: grimora login"
-			} else {
-				m.statusMsg = fmt.Sprintf("save failed: %v", msg.err)
-			}
+			m.statusMsg = errText("save failed", msg.err)
 		} else {
 			m.statusMsg = "saved!"
 		}
 		return m, m.loadWeapons()
 
+	case chestSpellResultMsg:
+		if !msg.added {
+			return m, nil
+		}
+		if msg.err != nil {
+			if client.IsConflict(msg.err) {
+				m.statusMsg = "already in " + msg.chestName
+			} else {
+				m.statusMsg = errText("add to chest failed", msg.err)
+			}
+		} else {
+			m.statusMsg = "added to " + msg.chestName
+		}
+		return m, nil
+
+	case watchResultMsg:
+		if msg.targetType != domain.WatchTargetSpell {
+			return m, nil
+		}
+		if msg.err != nil {
+			if m.watchPending == msg.targetID {
+				m.watchPending = ""
+				if msg.watching {
+					m.statusMsg = errText("watch failed", msg.err)
+				} else {
+					m.statusMsg = errText("unwatch failed", msg.err)
+				}
+			}
+			return m, nil
+		}
+		for i := range m.spells {
+			if m.spells[i].ID.String() == msg.targetID {
+				m.spells[i].Watching = msg.watching
+				break
+			}
+		}
+		// Only report toggles made here; unwatching from the You tab is
+		// silent in the Grimoire.
+		if m.watchPending == msg.targetID {
+			m.watchPending = ""
+			if msg.watching {
+				m.statusMsg = "watching -- new comments and variants show up in You"
+			} else {
+				m.statusMsg = "stopped watching"
+			}
+		}
+		return m, nil
+
+	case spellSaveResultMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("save failed", msg.err)
+			return m, nil
+		}
+		for i := range m.spells {
+			if m.spells[i].ID.String() != msg.id {
+				continue
+			}
+			m.spells[i].Saved = msg.saved
+			if m.savedOnly && !msg.saved {
+				m.spells = append(m.spells[:i], m.spells[i+1:]...)
+				if m.cursor >= len(m.spells) && m.cursor > 0 {
+					m.cursor--
+				}
+				if len(m.spells) == 0 {
+					m.detail = false
+				}
+			}
+			break
+		}
+		if msg.saved {
+			m.statusMsg = "bookmarked!"
+		} else {
+			m.statusMsg = "removed from saved"
+		}
+		return m, nil
+
+	case spellCastMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("cast failed", msg.err)
+			return m, nil
+		}
+		for i := range m.spells {
+			if m.spells[i].ID == msg.cast.SpellID {
+				m.spells[i].Casts = msg.cast.Casts
+			}
+		}
+		m.statusMsg = "cast!"
+		if msg.copied {
+			m.statusMsg = "cast and copied!"
+		}
+		return m, nil
+
+	case spellForkedMsg:
+		switch {
+		case client.IsConflict(msg.err):
+			m.statusMsg = "you've already forked this spell"
+		case msg.err != nil:
+			m.statusMsg = errText("fork failed", msg.err)
+		default:
+			for i := range m.spells {
+				if m.spells[i].ID == msg.originalID {
+					m.spells[i].Forks++
+				}
+			}
+			m.statusMsg = "forked into your grimoire"
+		}
+		return m, nil
+
+	case randomSpellMsg:
+		return m.showRandomSpell(msg), nil
+
+	case spellReforgeMsg, reforgePollMsg, reforgeCheckedMsg:
+		return m.updateReforge(msg)
+
+	case meLoadedMsg:
+		if msg.me != nil {
+			m.myID = msg.me.ID
+		}
+		return m, nil
+
 	case copyResultMsg:
 		if msg.err != nil {
 			m.statusMsg = fmt.Sprintf("copy failed: %v", msg.err)
@@ -151,6 +340,30 @@ func (m grimoireModel) Update(msg tea.Msg) (grimoireModel, tea.Cmd) {
 		}
 		return m, nil
 
+	case spellFileMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("write failed: %v", msg.err)
+		} else {
+			m.statusMsg = "wrote " + msg.path
+		}
+		return m, nil
+
+	case linkOpenedMsg:
+		m.statusMsg = linkStatus(msg)
+		return m, nil
+
+	case weaponCloneMsg:
+		m.statusMsg = cloneStatus(msg)
+		return m, nil
+
+	case spellPublishedMsg:
+		m.statusMsg = publishStatus(msg)
+		return m, nil
+
+	case reportedMsg:
+		m.statusMsg = reportStatus(msg)
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -158,6 +371,12 @@ func (m grimoireModel) Update(msg tea.Msg) (grimoireModel, tea.Cmd) {
 
 	case tea.KeyMsg:
 		m.statusMsg = ""
+		if m.fill != nil {
+			return m.updateFill(msg)
+		}
+		if m.report != nil {
+			return m.updateReport(msg)
+		}
 		if m.editing {
 			return m.updateSearch(msg)
 		}
@@ -238,31 +457,23 @@ func (m grimoireModel) updateList(msg tea.KeyMsg) (grimoireModel, tea.Cmd) {
 			}
 		}
 	case "t":
-		if m.mode == grimoireModeSpells {
-			// Cycle through display tags (no filter → first tag → ... → last tag → no filter)
-			if m.tagFilter == "" {
-				m.tagFilter = displayTags[0]
-			} else {
-				found := false
-				for i, tag := range displayTags {
-					if tag == m.tagFilter {
-						if i+1 < len(displayTags) {
-							m.tagFilter = displayTags[i+1]
-						} else {
-							m.tagFilter = "" // wrap to "all"
-						}
-						found = true
-						break
-					}
-				}
-				if !found {
-					m.tagFilter = ""
-				}
-			}
-			m.cursor = 0
-			m.loading = true
-			return m, m.loadSpells()
+		if m.mode == grimoireModeWeapons {
+			m.categories = cycleTag(m.categories, m.barCategories())
+		} else {
+			m.tagFilters = cycleTag(m.tagFilters, m.barTags())
+		}
+		m.cursor = 0
+		m.loading = true
+		return m, m.loadCurrent()
+	case "T":
+		if m.mode == grimoireModeWeapons {
+			m.categories = addTag(m.categories, m.barCategories())
+		} else {
+			m.tagFilters = addTag(m.tagFilters, m.barTags())
 		}
+		m.cursor = 0
+		m.loading = true
+		return m, m.loadCurrent()
 	case "u":
 		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
 			spell := m.spells[m.cursor]
@@ -273,12 +484,21 @@ func (m grimoireModel) updateList(msg tea.KeyMsg) (grimoireModel, tea.Cmd) {
 		}
 	case "c":
 		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
-			text := m.spells[m.cursor].Text
-			return m, func() tea.Msg {
-				err := clipboard.WriteAll(text)
-				return copyResultMsg{err: err}
-			}
+			return m.copySpell(m.spells[m.cursor])
 		}
+	case "b":
+		return m, m.toggleSpellSave()
+	case "W":
+		return m.toggleSpellWatch()
+	case "B":
+		if m.mode == grimoireModeSpells {
+			m.savedOnly = !m.savedOnly
+			m.cursor = 0
+			m.loading = true
+			return m, m.loadSpells()
+		}
+	case "?":
+		return m.randomSpell()
 	case "r":
 		m.loading = true
 		return m, m.loadCurrent()
@@ -290,6 +510,9 @@ func (m grimoireModel) updateDetail(msg tea.KeyMsg) (grimoireModel, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
 		m.detail = false
+		m = m.dropStray()
+	case "?":
+		return m.randomSpell()
 	case "u":
 		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
 			spell := m.spells[m.cursor]
@@ -300,12 +523,37 @@ func (m grimoireModel) updateDetail(msg tea.KeyMsg) (grimoireModel, tea.Cmd) {
 		}
 	case "c":
 		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
-			text := m.spells[m.cursor].Text
+			return m.copySpell(m.spells[m.cursor])
+		}
+	case "x":
+		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
+			c, spell := m.client, m.spells[m.cursor]
+			m.statusMsg = "casting..."
+			return m, func() tea.Msg {
+				cast, err := c.CastSpell(context.Background(), spell.ID.String())
+				if err != nil {
+					return spellCastMsg{err: err}
+				}
+				return spellCastMsg{cast: cast, copied: clipboard.WriteAll(spell.Text) == nil}
+			}
+		}
+	case "f":
+		return m.forkSpell()
+	case "V":
+		return m.requestReforge()
+	case "C":
+		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
+			spell := m.spells[m.cursor]
 			return m, func() tea.Msg {
-				err := clipboard.WriteAll(text)
-				return copyResultMsg{err: err}
+				path, err := export.WriteSpellFile(export.DefaultSpellDir, spell)
+				return spellFileMsg{path: path, err: err}
 			}
 		}
+	case "P":
+		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
+			m.statusMsg = "publishing..."
+			return m, publishSpellCmd(m.client, m.spells[m.cursor])
+		}
 	case "s":
 		if m.mode == grimoireModeWeapons && m.cursor < len(m.weapons) {
 			weapon := m.weapons[m.cursor]
@@ -314,6 +562,42 @@ func (m grimoireModel) updateDetail(msg tea.KeyMsg) (grimoireModel, tea.Cmd) {
 				return saveWeaponResultMsg{err: err}
 			}
 		}
+	case "o":
+		if m.mode == grimoireModeWeapons && m.cursor < len(m.weapons) && m.weapons[m.cursor].RepositoryURL != "" {
+			repoURL := m.weapons[m.cursor].RepositoryURL
+			if !httpsRepoURL(repoURL) {
+				m.statusMsg = "not an https repository: " + repoURL
+				return m, nil
+			}
+			return m, openURLCmd(repoURL)
+		}
+	case "g":
+		if m.mode == grimoireModeWeapons && m.cursor < len(m.weapons) {
+			repoURL := m.weapons[m.cursor].RepositoryURL
+			if cloneDir != "" {
+				m.statusMsg = "cloning " + repoDirName(repoURL) + "..."
+			}
+			return m, cloneRepoCmd(repoURL, cloneDir)
+		}
+	case "b":
+		return m, m.toggleSpellSave()
+	case "W":
+		return m.toggleSpellWatch()
+	case "G":
+		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
+			spell := m.spells[m.cursor]
+			return m, func() tea.Msg { return chestAddRequestMsg{spell: spell} }
+		}
+	case "!":
+		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
+			spell := m.spells[m.cursor]
+			if m.myID != uuid.Nil && spell.MagicianID == m.myID {
+				m.statusMsg = "that's your own spell"
+				return m, nil
+			}
+			m.report = &reportPrompt{targetType: domain.ReportTargetSpell, targetID: spell.ID.String()}
+			m.statusMsg = m.report.question()
+		}
 	case "p":
 		if m.mode == grimoireModeSpells && m.cursor < len(m.spells) {
 			spell := m.spells[m.cursor]
@@ -328,6 +612,166 @@ func (m grimoireModel) updateDetail(msg tea.KeyMsg) (grimoireModel, tea.Cmd) {
 	return m, nil
 }
 
+// updateReport handles keys while the reason for a spell report is chosen.
+func (m grimoireModel) updateReport(msg tea.KeyMsg) (grimoireModel, tea.Cmd) {
+	p := *m.report
+	reason, done := p.handleKey(msg.String())
+	if !done {
+		m.statusMsg = p.question()
+		return m, nil
+	}
+	m.report = nil
+	if reason == "" {
+		return m, nil
+	}
+	m.statusMsg = "reporting..."
+	return m, p.send(m.client, reason)
+}
+
+// forkSpell copies the selected spell into my grimoire, crediting its author.
+func (m grimoireModel) forkSpell() (grimoireModel, tea.Cmd) {
+	if m.mode != grimoireModeSpells || m.cursor >= len(m.spells) {
+		return m, nil
+	}
+	spell := m.spells[m.cursor]
+	if m.myID != uuid.Nil && spell.MagicianID == m.myID {
+		m.statusMsg = "that's your own spell"
+		return m, nil
+	}
+	c := m.client
+	m.statusMsg = "forking..."
+	return m, func() tea.Msg {
+		fork, err := c.ForkSpell(context.Background(), spell.ID.String())
+		return spellForkedMsg{originalID: spell.ID, fork: fork, err: err}
+	}
+}
+
+// toggleSpellSave bookmarks the selected spell, or removes the bookmark if
+// it is already saved.
+func (m grimoireModel) toggleSpellSave() tea.Cmd {
+	if m.mode != grimoireModeSpells || m.cursor >= len(m.spells) {
+		return nil
+	}
+	spell := m.spells[m.cursor]
+	id := spell.ID.String()
+	save := !spell.Saved
+	c := m.client
+	return func() tea.Msg {
+		var err error
+		if save {
+			err = c.SaveSpell(context.Background(), id)
+		} else {
+			err = c.UnsaveSpell(context.Background(), id)
+		}
+		return spellSaveResultMsg{id: id, saved: save, err: err}
+	}
+}
+
+// toggleSpellWatch subscribes to new comments and variants on the selected
+// spell, or unsubscribes if already watching.
+func (m grimoireModel) toggleSpellWatch() (grimoireModel, tea.Cmd) {
+	if m.mode != grimoireModeSpells || m.cursor >= len(m.spells) {
+		return m, nil
+	}
+	spell := m.spells[m.cursor]
+	m.watchPending = spell.ID.String()
+	return m, watchCmd(m.client, domain.WatchTargetSpell, m.watchPending, !spell.Watching)
+}
+
+// allSavedSpells fetches the whole saved library a page at a time. Filters
+// apply locally, so a bookmark past the first page must still be found.
+func allSavedSpells(c client.API) ([]domain.Spell, error) {
+	var all []domain.Spell
+	for {
+		spells, err := c.ListSavedSpells(context.Background(), pageSize, len(all))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, spells...)
+		if len(spells) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// filterSavedSpells narrows the saved library by tags and search text. The
+// saved endpoint has no server-side filters, and a personal library is
+// small enough to filter here.
+func filterSavedSpells(spells []domain.Spell, tags []string, search string) []domain.Spell {
+	if len(tags) == 0 && search == "" {
+		return spells
+	}
+	q := strings.ToLower(search)
+	var out []domain.Spell
+	for _, s := range spells {
+		if len(tags) > 0 && !slices.Contains(tags, s.Tag) {
+			continue
+		}
+		if q != "" && !strings.Contains(strings.ToLower(s.Text), q) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// showSpell opens the detail view for s. When the loaded page doesn't hold
+// it, it goes at the top of the list only until esc, since it may not match
+// the list's search or filters.
+func (m grimoireModel) showSpell(s domain.Spell) grimoireModel {
+	m = m.dropStray()
+	m.mode = grimoireModeSpells
+	m.editing = false
+	m.statusMsg = ""
+	prev := m.cursor
+	m.cursor = slices.IndexFunc(m.spells, func(sp domain.Spell) bool { return sp.ID == s.ID })
+	if m.cursor < 0 {
+		m.spells = append([]domain.Spell{s}, m.spells...)
+		m.cursor = 0
+		m.strayID, m.strayCursor = s.ID.String(), prev
+	}
+	m.detail = true
+	return m
+}
+
+// dropStray takes the spell showSpell added back out of the list and puts
+// the cursor back where it was.
+func (m grimoireModel) dropStray() grimoireModel {
+	if m.strayID == "" {
+		return m
+	}
+	if i := slices.IndexFunc(m.spells, func(s domain.Spell) bool { return s.ID.String() == m.strayID }); i >= 0 {
+		m.spells = slices.Delete(m.spells, i, i+1)
+		m.cursor = m.strayCursor
+	}
+	m.strayID = ""
+	if m.cursor >= len(m.spells) || m.cursor < 0 {
+		m.cursor = 0
+	}
+	return m
+}
+
+// showWeapon opens the detail view for w, adding it to the top of the list
+// when the loaded page doesn't hold it.
+func (m grimoireModel) showWeapon(w domain.Weapon) grimoireModel {
+	m.mode = grimoireModeWeapons
+	m.editing = false
+	m.statusMsg = ""
+	m.cursor = -1
+	for i, wp := range m.weapons {
+		if wp.ID == w.ID {
+			m.cursor = i
+			break
+		}
+	}
+	if m.cursor < 0 {
+		m.weapons = append([]domain.Weapon{w}, m.weapons...)
+		m.cursor = 0
+	}
+	m.detail = true
+	return m
+}
+
 func (m grimoireModel) listLen() int {
 	if m.mode == grimoireModeWeapons {
 		return len(m.weapons)
@@ -335,14 +779,88 @@ func (m grimoireModel) listLen() int {
 	return len(m.spells)
 }
 
-// displayTags is the curated set shown in the inline tag bar.
+// displayTags is the curated set shown in the inline tag bar until the live
+// tag stats arrive, or when they can't be fetched.
 // Values must exist in domain.ValidTags.
 var displayTags = []string{
 	"debugging", "data", "performance", "architecture",
 	"system-prompt", "testing", "refactoring", "security", "devops",
 }
 
+// barTags returns the tags t cycles through, in tag bar order: the live
+// tags, most used first, or displayTags without them. Filters on tags the
+// bar doesn't list, such as one restored from the last session, lead.
+func (m grimoireModel) barTags() []string {
+	tags := displayTags
+	if len(m.tagStats) > 0 {
+		tags = make([]string, 0, len(m.tagStats))
+		for _, st := range m.tagStats {
+			tags = append(tags, st.Tag)
+		}
+	}
+	var extra []string
+	for _, f := range m.tagFilters {
+		if !slices.Contains(tags, f) {
+			extra = append(extra, f)
+		}
+	}
+	return append(extra, tags...)
+}
+
+// barCategories returns the weapon categories t cycles through, in
+// category bar order, with filters the bar doesn't list leading.
+func (m grimoireModel) barCategories() []string {
+	var extra []string
+	for _, c := range m.categories {
+		if !domain.ValidWeaponCategory(c) {
+			extra = append(extra, c)
+		}
+	}
+	return append(extra, domain.WeaponCategories...)
+}
+
+// tagCount returns the live spell count for tag, or -1 when unknown.
+func (m grimoireModel) tagCount(tag string) int {
+	for _, st := range m.tagStats {
+		if st.Tag == tag {
+			return st.SpellCount
+		}
+	}
+	return -1
+}
+
+// cycleTag moves the last filter to the next tag in bar that isn't already
+// a filter. Past the end it drops off, so with a single filter t runs
+// all → first tag → ... → last tag → all.
+func cycleTag(filters, bar []string) []string {
+	if len(filters) == 0 {
+		return addTag(filters, bar)
+	}
+	n := len(filters) - 1
+	kept := filters[:n:n]
+	for i := slices.Index(bar, filters[n]) + 1; i < len(bar); i++ {
+		if !slices.Contains(kept, bar[i]) {
+			return append(kept, bar[i])
+		}
+	}
+	return kept
+}
+
+// addTag adds the first tag in bar that isn't already a filter.
+func addTag(filters, bar []string) []string {
+	for _, tag := range bar {
+		if !slices.Contains(filters, tag) {
+			return append(filters[:len(filters):len(filters)], tag)
+		}
+	}
+	return filters
+}
+
 func (m grimoireModel) View() string {
+	if m.fill != nil {
+		return m.viewFill()
+	}
+
 	// Detail view
 	if m.detail {
 		if m.mode == grimoireModeWeapons {
@@ -382,37 +900,22 @@ func (m grimoireModel) View() string {
 		b.WriteString(searchStyle.Render("[weapons]"))
 	}
 	b.WriteString("  " + helpKeyStyle.Render("w"))
+	if m.mode == grimoireModeSpells && m.savedOnly {
+		b.WriteString("   " + goldStyle.Render("[saved]") + " " + helpKeyStyle.Render("B"))
+	}
 	b.WriteString("\n")
 
-	// --- Tag bar + sort (spells mode only) ---
+	// --- Tag bar + sort, or the weapon category bar ---
 	if m.mode == grimoireModeSpells {
 		// Sort indicator at the end: "new↑ s" (~8 chars)
 		sortLabel := m.sortBy + "\u2191"
 		sortPart := "   " + searchStyle.Render(sortLabel) + " " + helpKeyStyle.Render("s")
-		sortWidth := lipgloss.Width(sortPart)
-
-		b.WriteString(" ")
-		usedWidth := 1 // leading space
-		for i, tag := range displayTags {
-			sep := "  "
-			if i == 0 {
-				sep = ""
-			}
-			needed := len(sep) + len(tag)
-			if usedWidth+needed+sortWidth > m.width {
-				break // don't overflow
-			}
-			b.WriteString(sep)
-			isActive := tag == m.tagFilter
-			if isActive {
-				b.WriteString(TagStyle(tag).Bold(true).Render(tag))
-			} else {
-				b.WriteString(dimStyle.Render(tag))
-			}
-			usedWidth += needed
-		}
+		b.WriteString(" " + m.renderFilterBar(m.barTags(), m.tagFilters, m.tagCount, lipgloss.Width(sortPart)))
 		b.WriteString(sortPart)
 		b.WriteString("\n")
+	} else {
+		noCount := func(string) int { return -1 }
+		b.WriteString(" " + m.renderFilterBar(m.barCategories(), m.categories, noCount, 0) + "\n")
 	}
 
 	// Separator
@@ -445,11 +948,50 @@ func (m grimoireModel) View() string {
 // grimoireChromeLines accounts for editorial + search/mode + tag bar + separator + detail chrome.
 const grimoireChromeLines = 10
 
-// grimoireWeaponChromeLines is the same but without the tag bar (weapons don't have tags).
-const grimoireWeaponChromeLines = 9
+// renderFilterBar renders the tags in bar that fit the width, leaving
+// reserve columns free, with those in active highlighted. count gives the
+// number shown beside a tag, or -1 for none.
+func (m grimoireModel) renderFilterBar(bar, active []string, count func(string) int, reserve int) string {
+	var b strings.Builder
+	usedWidth := 1 // leading space
+	for i, tag := range bar {
+		sep := "  "
+		if i == 0 {
+			sep = ""
+		}
+		label := tag
+		if n := count(tag); n >= 0 {
+			label += " " + formatNum(n)
+		}
+		needed := len(sep) + lipgloss.Width(label)
+		if usedWidth+needed+reserve > m.width {
+			break // don't overflow
+		}
+		b.WriteString(sep)
+		if slices.Contains(active, tag) {
+			b.WriteString(TagStyle(tag).Bold(true).Render(label))
+		} else {
+			b.WriteString(dimStyle.Render(label))
+		}
+		usedWidth += needed
+	}
+	return b.String()
+}
+
+// renderCategories lists a weapon's categories.
+func renderCategories(categories []string) string {
+	parts := make([]string, len(categories))
+	for i, c := range categories {
+		parts[i] = TagStyle(c).Render(c)
+	}
+	return strings.Join(parts, " ")
+}
 
 func (m grimoireModel) viewSpellList() string {
 	if len(m.spells) == 0 {
+		if m.savedOnly {
+			return " " + dimStyle.Render("no saved spells yet · press b on a spell to bookmark it")
+		}
 		return " " + dimStyle.Render("no spells found")
 	}
 
@@ -481,8 +1023,11 @@ func (m grimoireModel) viewSpellList() string {
 			titleStyle = normalStyle.Bold(true)
 		}
 
-		// Dot in tag color
+		// Dot in tag color; bookmarked spells get a diamond instead.
 		dot := TagStyle(spell.Tag).Render("●") + " "
+		if spell.Saved {
+			dot = TagStyle(spell.Tag).Render("◆") + " "
+		}
 
 		// Right-side columns: responsive based on width.
 		// Wide (>=70): author(12) + casts(11) + potency(3) + gaps(4) = 30
@@ -500,10 +1045,7 @@ func (m grimoireModel) viewSpellList() string {
 				if spell.Author.DisplayName != "" {
 					name = spell.Author.DisplayName
 				}
-				if len(name) > 12 {
-					name = name[:11] + "…"
-				}
-				authorCol = GuildStyle(spell.Author.GuildID).Render(fmt.Sprintf("%-12s", name))
+				authorCol = GuildStyle(spell.Author.GuildID).Render(padRight(truncStr(name, 12), 12))
 			} else {
 				authorCol = strings.Repeat(" ", 12)
 			}
@@ -511,10 +1053,10 @@ func (m grimoireModel) viewSpellList() string {
 			rightWidth += 13 // 12 + gap
 		}
 		if compactCasts {
-			rightParts = append(rightParts, metaStyle.Render(fmt.Sprintf("%d", spell.Upvotes)+"c"))
+			rightParts = append(rightParts, metaStyle.Render(fmt.Sprintf("%d", spell.Casts)+"c"))
 			rightWidth += 5
 		} else {
-			rightParts = append(rightParts, metaStyle.Render(fmt.Sprintf("%6d casts", spell.Upvotes)))
+			rightParts = append(rightParts, metaStyle.Render(fmt.Sprintf("%6d casts", spell.Casts)))
 			rightWidth += 12
 		}
 		if spell.Potency > 0 {
@@ -530,7 +1072,7 @@ func (m grimoireModel) viewSpellList() string {
 		}
 		title := strings.ReplaceAll(spell.Text, "\n", " ")
 		title = truncStr(title, titleWidth)
-		titlePadded := fmt.Sprintf("%-*s", titleWidth, `"`+title+`"`)
+		titlePadded := padRight(`"`+title+`"`, titleWidth)
 
 		line := cursor + dot + titleStyle.Render(titlePadded) + " " + strings.Join(rightParts, " ")
 		if i == m.cursor {
@@ -592,7 +1134,7 @@ func (m grimoireModel) viewWeaponList() string {
 
 	var b strings.Builder
 
-	viewChrome := grimoireWeaponChromeLines
+	viewChrome := grimoireChromeLines
 	available := m.height - viewChrome
 	if available < 6 {
 		available = 6
@@ -637,7 +1179,7 @@ func (m grimoireModel) viewWeaponList() string {
 		}
 		name := w.Name
 		name = truncStr(name, titleWidth)
-		namePadded := fmt.Sprintf("%-*s", titleWidth, name)
+		namePadded := padRight(name, titleWidth)
 
 		line := cursor + dot + titleStyle.Render(namePadded) + " " + catCol + " " + starCol
 		if i == m.cursor {
@@ -660,6 +1202,9 @@ func (m grimoireModel) viewWeaponList() string {
 		if w.License != "" {
 			header += "  " + metaStyle.Render(w.License)
 		}
+		if len(w.Categories) > 0 {
+			header += "  " + renderCategories(w.Categories)
+		}
 		b.WriteString(header + "\n")
 
 		if w.Description != "" {
@@ -715,20 +1260,36 @@ func (m grimoireModel) viewSpellDetail() string {
 	if spell.Potency > 0 {
 		meta += metaStyle.Render(" · ") + potencyStyle(spell.Potency).Render(fmt.Sprintf("P%d", spell.Potency))
 	}
+	if spell.Casts > 0 {
+		meta += metaStyle.Render(fmt.Sprintf(" · %d casts", spell.Casts))
+	}
 	if spell.Upvotes > 0 {
-		meta += metaStyle.Render(fmt.Sprintf(" · %d casts", spell.Upvotes))
 		meta += metaStyle.Render(fmt.Sprintf(" · \u2191%d", spell.Upvotes))
 	}
+	if spell.Forks > 0 {
+		meta += metaStyle.Render(fmt.Sprintf(" · %d fork%s", spell.Forks, plural(spell.Forks)))
+	}
+	if spell.Saved {
+		meta += metaStyle.Render(" · ") + goldStyle.Render("saved")
+	}
+	if spell.Watching {
+		meta += metaStyle.Render(" · ") + accentStyle.Render("watching")
+	}
 	b.WriteString(meta + "\n")
+	for _, line := range forkLines(spell.ForkedFrom, m.width-2) {
+		b.WriteString(" " + line + "\n")
+	}
+	if line := reforgeLine(spell.Reforge); line != "" {
+		b.WriteString(" " + line + "\n")
+	}
 
 	b.WriteString("\n")
 	detailWidth := m.width - 4
 	if detailWidth < 40 {
 		detailWidth = 40
 	}
-	wrapped := lipgloss.NewStyle().Width(detailWidth).Render(spell.Text)
-	for _, line := range strings.Split(wrapped, "\n") {
-		b.WriteString(" " + normalStyle.Render(line) + "\n")
+	for _, line := range renderMarkdown(spell.Text, normalStyle, detailWidth, nil, spellHighlighter(spell.Stack, lipgloss.ColorProfile())) {
+		b.WriteString(" " + line + "\n")
 	}
 
 	if len(spell.Stack) > 0 {
@@ -746,9 +1307,8 @@ func (m grimoireModel) viewSpellDetail() string {
 		if voiceWidth < 20 {
 			voiceWidth = 20
 		}
-		voiceWrapped := lipgloss.NewStyle().Width(voiceWidth).Render(spell.Voice)
-		for _, line := range strings.Split(voiceWrapped, "\n") {
-			b.WriteString(goldStyle.Render("\u2502") + " " + grimVoiceStyle.Render(line) + "\n")
+		for _, line := range renderMarkdown(spell.Voice, grimVoiceStyle, voiceWidth, nil, nil) {
+			b.WriteString(goldStyle.Render("\u2502") + " " + line + "\n")
 		}
 	}
 
@@ -771,6 +1331,37 @@ func (m grimoireModel) viewSpellDetail() string {
 	return truncateToHeight(b.String(), m.height)
 }
 
+// maxForkLines caps how much of a long attribution chain the detail view
+// shows; the rest is summed up on one more line.
+const maxForkLines = 3
+
+// forkLines renders a fork's attribution chain, nearest origin first: the
+// spell it was forked from, then where that one came from, and so on. Each
+// line fits in width cells.
+func forkLines(chain []domain.SpellOrigin, width int) []string {
+	var lines []string
+	for i, o := range chain {
+		if i == maxForkLines {
+			rest := len(chain) - i
+			lines = append(lines, metaStyle.Render(fmt.Sprintf("  and %d earlier fork%s", rest, plural(rest))))
+			break
+		}
+		lead := "forked from "
+		if i > 0 {
+			lead = "  which was forked from "
+		}
+		who := "@" + o.Login
+		if o.Login == "" {
+			who = "a removed magician"
+		}
+		head := lead + who + "'s "
+		preview := strings.Join(strings.Fields(o.Preview), " ")
+		quoted := `"` + truncStr(preview, max(width-textWidth(head)-2, 10)) + `"`
+		lines = append(lines, metaStyle.Render(lead)+accentStyle.Render(who)+metaStyle.Render("'s "+quoted))
+	}
+	return lines
+}
+
 func (m grimoireModel) viewWeaponDetail() string {
 	if m.cursor >= len(m.weapons) {
 		return ""
@@ -789,6 +1380,9 @@ func (m grimoireModel) viewWeaponDetail() string {
 		info += "  " + metaStyle.Render(w.License)
 	}
 	info += "  " + upvoteStyle.Render("\u2605"+formatNum(w.GitHubStars))
+	if len(w.Categories) > 0 {
+		info += "  " + renderCategories(w.Categories)
+	}
 	b.WriteString(info + "\n\n")
 
 	if w.Description != "" {
@@ -813,7 +1407,7 @@ func (m grimoireModel) viewWeaponDetail() string {
 
 // formatCommentTime formats a comment timestamp as a short relative or absolute string.
 func formatCommentTime(t time.Time) string {
-	d := time.Since(t)
+	d := clock().Sub(t)
 	switch {
 	case d < time.Minute:
 		return "just now"
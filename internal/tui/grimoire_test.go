@@ -1,13 +1,22 @@
 package tui
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/google/uuid"
 
+	"github.com/naveenspark/grimora/internal/publish"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
@@ -225,15 +234,15 @@ func TestGrimoireTagCyclesOnT(t *testing.T) {
 	m, _ = m.Update(spellsLoadedMsg{spells: spells})
 
 	// Initially no tag filter (= "all")
-	if m.tagFilter != "" {
-		t.Errorf("expected empty tagFilter initially, got %q", m.tagFilter)
+	if len(m.tagFilters) != 0 {
+		t.Errorf("expected no tag filters initially, got %q", m.tagFilters)
 	}
 
 	// The 't' key cycles to the next tag and triggers a reload
 	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
 
-	if m.tagFilter == "" {
-		t.Error("expected tagFilter to change after 't' key, got empty (still 'all')")
+	if len(m.tagFilters) != 1 {
+		t.Errorf("expected one tag filter after 't' key, got %q", m.tagFilters)
 	}
 	if cmd == nil {
 		t.Error("expected tag cycle to return a reload command, got nil")
@@ -258,6 +267,119 @@ func TestGrimoireInlineTagBarRendered(t *testing.T) {
 	}
 }
 
+func TestGrimoireTagBarUsesLiveStats(t *testing.T) {
+	m := newTestGrimoireModel()
+	m, _ = m.Update(spellsLoadedMsg{spells: []domain.Spell{makeTestSpell("spell", "rust")}})
+	m, _ = m.Update(tagStatsLoadedMsg{stats: []domain.TagStat{
+		{Tag: "rust", SpellCount: 1200},
+		{Tag: "debugging", SpellCount: 42},
+	}})
+
+	view := m.View()
+	for _, want := range []string{"rust 1.2k", "debugging 42"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in tag bar, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "performance") {
+		t.Errorf("expected curated tags to give way to live ones, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if len(m.tagFilters) != 1 || m.tagFilters[0] != "rust" {
+		t.Errorf("t cycled to %q, want the most used live tag", m.tagFilters)
+	}
+}
+
+func TestGrimoireTagStatsErrorKeepsCuratedTags(t *testing.T) {
+	m := newTestGrimoireModel()
+	m, _ = m.Update(tagStatsLoadedMsg{err: errors.New("boom")})
+	if got := m.barTags(); !slices.Equal(got, displayTags) {
+		t.Errorf("barTags() = %q, want displayTags", got)
+	}
+}
+
+func TestGrimoireMultiTagFilter(t *testing.T) {
+	f := &clienttest.Fake{Spells: []domain.Spell{
+		makeTestSpell("bisect", "debugging"),
+		makeTestSpell("borrowck", "rust"),
+		makeTestSpell("pipeline", "data"),
+	}}
+	m := newGrimoireModel(f)
+	m.tagStats = []domain.TagStat{{Tag: "debugging"}, {Tag: "rust"}, {Tag: "data"}}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	if !slices.Equal(m.tagFilters, []string{"debugging", "rust"}) {
+		t.Fatalf("tagFilters = %q after t, T", m.tagFilters)
+	}
+	m, _ = m.Update(cmd())
+	if len(m.spells) != 2 {
+		t.Errorf("loaded %d spells for two tags, want 2", len(m.spells))
+	}
+
+	// t moves only the tag T added, skipping ones already filtered on, and
+	// drops it past the end.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if !slices.Equal(m.tagFilters, []string{"debugging", "data"}) {
+		t.Errorf("tagFilters = %q after second t", m.tagFilters)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if !slices.Equal(m.tagFilters, []string{"debugging"}) {
+		t.Errorf("tagFilters = %q after third t", m.tagFilters)
+	}
+}
+
+func TestGrimoireRestoredCustomTagLeadsBar(t *testing.T) {
+	m := newTestGrimoireModel()
+	m.tagFilters = []string{"llm-evals"}
+	if got := m.barTags(); got[0] != "llm-evals" || len(got) != len(displayTags)+1 {
+		t.Errorf("barTags() = %q", got)
+	}
+	if !strings.Contains(m.View(), "llm-evals") {
+		t.Error("expected the restored tag in the tag bar")
+	}
+}
+
+func TestGrimoireWeaponCategoryFilter(t *testing.T) {
+	rg, jq, ollama := makeTestWeapon("ripgrep"), makeTestWeapon("jq"), makeTestWeapon("ollama")
+	rg.Categories = []string{"cli"}
+	jq.Categories = []string{"cli", "data"}
+	ollama.Categories = []string{"ai"}
+	f := &clienttest.Fake{Weapons: []domain.Weapon{rg, jq, ollama}}
+	m := newGrimoireModel(f)
+	m.width, m.height = 100, 30
+	m.mode = grimoireModeWeapons
+
+	m, cmd := m.Update(keyRune('t'))
+	if !slices.Equal(m.categories, []string{"cli"}) || len(m.tagFilters) != 0 {
+		t.Fatalf("t in weapons mode filtered %q (tags %q), want the first category", m.categories, m.tagFilters)
+	}
+	m, _ = m.Update(cmd())
+	if len(m.weapons) != 2 {
+		t.Errorf("loaded %d cli weapons, want 2", len(m.weapons))
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"cli  ai  devops", "ripgrep  Go  cli"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+
+	m, cmd = m.Update(keyRune('T'))
+	m, _ = m.Update(cmd())
+	if !slices.Equal(m.categories, []string{"cli", "ai"}) || len(m.weapons) != 3 {
+		t.Errorf("categories = %q with %d weapons, want cli and ai together", m.categories, len(m.weapons))
+	}
+
+	// The spell tags are untouched, and back in spells mode t cycles them.
+	m, _ = m.Update(keyRune('w'))
+	m, _ = m.Update(keyRune('t'))
+	if len(m.tagFilters) != 1 || len(m.categories) != 2 {
+		t.Errorf("tags %q, categories %q", m.tagFilters, m.categories)
+	}
+}
+
 func TestDisplayTagsAreAllValid(t *testing.T) {
 	for _, tag := range displayTags {
 		if !domain.ValidTag(tag) {
@@ -325,3 +447,255 @@ func TestGrimoireUpvoteResultSetsStatusMsg(t *testing.T) {
 		t.Errorf("expected statusMsg='upvoted!', got %q", m.statusMsg)
 	}
 }
+
+func TestGrimoireBookmarkToggle(t *testing.T) {
+	m := newTestGrimoireModel()
+	m.spells = []domain.Spell{makeTestSpell("bisect regressions", "debugging")}
+
+	if cmd := m.toggleSpellSave(); cmd == nil {
+		t.Fatal("expected 'b' to issue a save command")
+	}
+	id := m.spells[0].ID.String()
+	m, _ = m.Update(spellSaveResultMsg{id: id, saved: true})
+	if !m.spells[0].Saved {
+		t.Error("expected spell marked saved")
+	}
+	if !strings.Contains(m.View(), "◆") {
+		t.Error("expected bookmark marker in list")
+	}
+
+	m, _ = m.Update(spellSaveResultMsg{id: id, saved: false})
+	if m.spells[0].Saved {
+		t.Error("expected bookmark removed")
+	}
+}
+
+func TestGrimoireSavedFilterDropsUnsaved(t *testing.T) {
+	m := newTestGrimoireModel()
+	m.savedOnly = true
+	a, b := makeTestSpell("first", "debugging"), makeTestSpell("second", "testing")
+	a.Saved, b.Saved = true, true
+	m.spells = []domain.Spell{a, b}
+	m.cursor = 1
+
+	m, _ = m.Update(spellSaveResultMsg{id: b.ID.String(), saved: false})
+	if len(m.spells) != 1 || m.spells[0].ID != a.ID {
+		t.Fatalf("expected unsaved spell removed from saved view, got %d spells", len(m.spells))
+	}
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want clamped to 0", m.cursor)
+	}
+	if !strings.Contains(m.View(), "[saved]") {
+		t.Error("expected saved filter indicator")
+	}
+}
+
+func TestGrimoireSavedFilterKey(t *testing.T) {
+	m := newTestGrimoireModel()
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")})
+	if !m.savedOnly || cmd == nil {
+		t.Error("expected 'B' to switch to saved spells and reload")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")})
+	if m.savedOnly {
+		t.Error("expected 'B' to toggle the saved filter off")
+	}
+}
+
+func TestGrimoireSavedLoadsEveryPage(t *testing.T) {
+	old := pageSize
+	pageSize = 2
+	t.Cleanup(func() { pageSize = old })
+
+	fake := &clienttest.Fake{}
+	for _, tag := range []string{"go", "go", "go", "go", "testing"} {
+		s := makeTestSpell("spell about "+tag, tag)
+		s.Saved = true
+		fake.Spells = append(fake.Spells, s)
+	}
+	m := newTestGrimoireModel()
+	m.client = fake
+	m.savedOnly = true
+	m.tagFilters = []string{"testing"}
+
+	m, _ = m.Update(m.loadSpells()())
+	if len(m.spells) != 1 || m.spells[0].Tag != "testing" {
+		t.Errorf("spells = %+v, want the bookmark on the last page", m.spells)
+	}
+	if n := fake.Count("ListSavedSpells"); n != 3 {
+		t.Errorf("ListSavedSpells called %d times, want 3 pages", n)
+	}
+}
+
+func TestFilterSavedSpells(t *testing.T) {
+	spells := []domain.Spell{
+		makeTestSpell("Bisect regressions", "debugging"),
+		makeTestSpell("table-driven tests", "testing"),
+	}
+	if got := filterSavedSpells(spells, []string{"testing"}, ""); len(got) != 1 || got[0].Tag != "testing" {
+		t.Errorf("tag filter = %+v", got)
+	}
+	if got := filterSavedSpells(spells, nil, "bisect"); len(got) != 1 || got[0].Tag != "debugging" {
+		t.Errorf("search filter = %+v", got)
+	}
+	if got := filterSavedSpells(spells, nil, ""); len(got) != 2 {
+		t.Errorf("no filter = %d spells, want 2", len(got))
+	}
+}
+
+func TestGrimoireDetailWritesSpellFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	m := newTestGrimoireModel()
+	m.spells = []domain.Spell{makeTestSpell("Rubber duck\nExplain it line by line.", "debugging")}
+	m.detail = true
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	if cmd == nil {
+		t.Fatal("expected a command to write the spell")
+	}
+	m, _ = m.Update(cmd())
+	want := filepath.Join("prompts", "rubber-duck.md")
+	if m.statusMsg != "wrote "+want {
+		t.Errorf("statusMsg = %q", m.statusMsg)
+	}
+	data, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "tag: debugging\n") || !strings.HasSuffix(string(data), "Explain it line by line.\n") {
+		t.Errorf("file = %q", data)
+	}
+}
+
+func TestGrimoireDetailPublishLinksSpell(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"html_url":"https://gist.github.com/me/abc"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+	orig := newPublisher
+	newPublisher = func() *publish.GitHub {
+		g := publish.New("ghp_test")
+		g.APIURL = srv.URL
+		return g
+	}
+	t.Cleanup(func() { newPublisher = orig })
+
+	spell := makeTestSpell("Find the flaky test", "testing")
+	f := &clienttest.Fake{Spells: []domain.Spell{spell}}
+	m := newTestGrimoireModel()
+	m.client = f
+	m.spells = []domain.Spell{spell}
+	m.detail = true
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	if cmd == nil {
+		t.Fatal("expected P to publish")
+	}
+	m, _ = m.Update(cmd())
+	if m.statusMsg != "published to https://gist.github.com/me/abc" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	if got := f.Spells[0].Context; got != "Published: https://gist.github.com/me/abc" {
+		t.Errorf("spell context = %q", got)
+	}
+}
+
+func TestGrimoireDetailCastsSpell(t *testing.T) {
+	spell := makeTestSpell("Find the flaky test", "testing")
+	f := &clienttest.Fake{Spells: []domain.Spell{spell}}
+	m := newTestGrimoireModel()
+	m.client = f
+	m.spells = []domain.Spell{spell}
+	m.detail = true
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd == nil {
+		t.Fatal("expected x to cast the spell")
+	}
+	m, _ = m.Update(cmd())
+	if !strings.HasPrefix(m.statusMsg, "cast") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	if m.spells[0].Casts != 1 || f.Spells[0].Casts != 1 {
+		t.Errorf("casts = %d here, %d on the server; want 1", m.spells[0].Casts, f.Spells[0].Casts)
+	}
+	if !strings.Contains(m.View(), "1 casts") {
+		t.Error("expected the cast count in the detail view")
+	}
+}
+
+func TestGrimoireDetailForksSpell(t *testing.T) {
+	spell := makeTestSpell("Find the flaky test", "testing")
+	f := &clienttest.Fake{Me: &domain.Magician{ID: uuid.New(), GitHubLogin: "me"}, Spells: []domain.Spell{spell}}
+	m := newTestGrimoireModel()
+	m.client = f
+	m, _ = m.Update(meLoadedMsg{me: f.Me})
+	m.spells = []domain.Spell{spell}
+	m.detail = true
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if cmd == nil {
+		t.Fatal("expected f to fork the spell")
+	}
+	m, _ = m.Update(cmd())
+	if m.statusMsg != "forked into your grimoire" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	if len(f.Spells) != 2 || f.Spells[1].ForkedFrom[0].ID != spell.ID {
+		t.Errorf("server spells after fork = %+v", f.Spells)
+	}
+	if !strings.Contains(m.View(), "1 fork") {
+		t.Error("expected the fork count in the detail view")
+	}
+
+	// A fork credits the spell it came from.
+	m.spells = []domain.Spell{f.Spells[1]}
+	if view := m.View(); !strings.Contains(view, `forked from @testauthor's "Find the flaky test"`) {
+		t.Errorf("expected attribution in the detail view:\n%s", view)
+	}
+}
+
+func TestGrimoireWontForkOwnSpell(t *testing.T) {
+	me := &domain.Magician{ID: uuid.New(), GitHubLogin: "me"}
+	spell := makeTestSpell("Find the flaky test", "testing")
+	spell.MagicianID = me.ID
+	m := newTestGrimoireModel()
+	m, _ = m.Update(meLoadedMsg{me: me})
+	m.spells = []domain.Spell{spell}
+	m.detail = true
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if cmd != nil || m.statusMsg != "that's your own spell" {
+		t.Errorf("forking my own spell: status %q, cmd %v", m.statusMsg, cmd != nil)
+	}
+}
+
+func TestForkLines(t *testing.T) {
+	chain := []domain.SpellOrigin{
+		{Login: "bob", Preview: "Rerun the failing test\nwith -race"},
+		{Login: "carol", Preview: "Rerun it"},
+		{Preview: "Ancient"},
+		{Login: "dan", Preview: "x"},
+		{Login: "eve", Preview: "y"},
+	}
+	got := forkLines(chain, 80)
+	want := []string{
+		`forked from @bob's "Rerun the failing test with -race"`,
+		`  which was forked from @carol's "Rerun it"`,
+		`  which was forked from a removed magician's "Ancient"`,
+		`  and 2 earlier forks`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("forkLines() = %q", got)
+	}
+	for i := range want {
+		if line := ansi.Strip(got[i]); line != want[i] {
+			t.Errorf("line %d = %q, want %q", i, line, want[i])
+		}
+	}
+	for _, line := range forkLines(chain[:1], 40) {
+		if w := textWidth(line); w > 40 {
+			t.Errorf("line %q is %d cells wide, want <= 40", ansi.Strip(line), w)
+		}
+	}
+}
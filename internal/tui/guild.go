@@ -0,0 +1,559 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// guildChestsMsg carries the guild's chest overview.
+type guildChestsMsg struct {
+	chests *domain.GuildChests
+	err    error
+}
+
+// guildChestMsg carries one chest with its spells.
+type guildChestMsg struct {
+	chest *domain.GuildChest
+	err   error
+}
+
+// guildRosterMsg carries every magician, for the roster and guild rankings.
+type guildRosterMsg struct {
+	cards []domain.MagicianCard
+	err   error
+}
+
+// guildRoomMsg carries the guild's chat room once joined.
+type guildRoomMsg struct {
+	slug string
+	name string
+	err  error
+}
+
+// errNoGuildRoom is reported when the server lists no room for the guild.
+var errNoGuildRoom = errors.New("no chat room for this guild")
+
+// chestAddRequestMsg asks the App to add a Grimoire spell to the chest
+// selected on the Guild tab.
+type chestAddRequestMsg struct {
+	spell domain.Spell
+}
+
+// chestSpellResultMsg carries the result of adding or removing a chest spell.
+type chestSpellResultMsg struct {
+	chestID   string
+	chestName string
+	spellID   string
+	added     bool
+	err       error
+}
+
+// rosterLimit caps the members listed on the Guild tab.
+const rosterLimit = 8
+
+// guildModel is the Guild tab: the caller's guild, its standing against the
+// other guilds, its members and its shared chests.
+type guildModel struct {
+	client      client.API
+	guildID     string
+	roster      []domain.MagicianCard // guild members, most potent first
+	standings   []domain.GuildStanding
+	chests      *domain.GuildChests
+	cursor      int                // selected chest on the overview
+	open        *domain.GuildChest // chest being browsed; nil on the overview
+	spellCursor int
+	loading     bool
+	err         error
+	statusMsg   string
+	width       int
+	height      int
+}
+
+func newGuildModel(c client.API) guildModel {
+	return guildModel{client: c}
+}
+
+func (m guildModel) Init() tea.Cmd {
+	if m.guildID == "" {
+		return nil
+	}
+	return tea.Batch(m.loadChests(), m.loadRoster())
+}
+
+// loadRoster fetches magicians for the roster and rankings. The magicians
+// endpoint has no guild filter, so the roster is filtered here.
+func (m guildModel) loadRoster() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		cards, err := client.MagiciansIter(context.Background(), c, magicianPageSize).Collect()
+		return guildRosterMsg{cards: cards, err: err}
+	}
+}
+
+// joinRoom finds the guild's chat room and joins it.
+func (m guildModel) joinRoom() tea.Cmd {
+	c, guild := m.client, m.guildID
+	return func() tea.Msg {
+		rooms, err := c.ListRooms(context.Background())
+		if err != nil {
+			return guildRoomMsg{err: err}
+		}
+		for _, r := range rooms {
+			if r.RoomType != domain.RoomTypeGuild || r.GuildID != guild {
+				continue
+			}
+			if err := c.JoinRoom(context.Background(), r.Slug); err != nil {
+				return guildRoomMsg{err: err}
+			}
+			return guildRoomMsg{slug: r.Slug, name: r.Name}
+		}
+		return guildRoomMsg{err: errNoGuildRoom}
+	}
+}
+
+// guildRoster returns the members of guild, most potent first.
+func guildRoster(cards []domain.MagicianCard, guild string) []domain.MagicianCard {
+	var out []domain.MagicianCard
+	for _, c := range cards {
+		if c.GuildID == guild {
+			out = append(out, c)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].TotalPotency > out[j].TotalPotency })
+	return out
+}
+
+// standing returns the guild's entry in the rankings.
+func (m guildModel) standing() (domain.GuildStanding, bool) {
+	for _, s := range m.standings {
+		if s.GuildID == m.guildID {
+			return s, true
+		}
+	}
+	return domain.GuildStanding{}, false
+}
+
+func (m guildModel) loadChests() tea.Cmd {
+	c, guild := m.client, m.guildID
+	return func() tea.Msg {
+		chests, err := c.ListGuildChests(context.Background(), guild)
+		return guildChestsMsg{chests: chests, err: err}
+	}
+}
+
+func (m guildModel) loadChest(id string) tea.Cmd {
+	c, guild := m.client, m.guildID
+	return func() tea.Msg {
+		chest, err := c.GetGuildChest(context.Background(), guild, id)
+		return guildChestMsg{chest: chest, err: err}
+	}
+}
+
+// selectedChest returns the chest under the overview cursor.
+func (m guildModel) selectedChest() (domain.GuildChest, bool) {
+	if m.chests == nil || m.cursor >= len(m.chests.Chests) {
+		return domain.GuildChest{}, false
+	}
+	return m.chests.Chests[m.cursor], true
+}
+
+// addToChest returns the command that adds spell to the selected chest, or a
+// status explaining why it can't.
+func (m guildModel) addToChest(spell domain.Spell) (tea.Cmd, string) {
+	if m.chests == nil {
+		return nil, "guild chests haven't loaded yet"
+	}
+	if !m.chests.CanCurate() {
+		return nil, "only guild curators can add to chests"
+	}
+	chest, ok := m.selectedChest()
+	if !ok {
+		return nil, "your guild has no chests yet"
+	}
+	c, guild := m.client, m.guildID
+	chestID, spellID := chest.ID.String(), spell.ID.String()
+	return func() tea.Msg {
+		err := c.AddChestSpell(context.Background(), guild, chestID, spellID)
+		return chestSpellResultMsg{chestID: chestID, chestName: chest.Name, spellID: spellID, added: true, err: err}
+	}, ""
+}
+
+func (m guildModel) Update(msg tea.Msg) (guildModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case meLoadedMsg:
+		// Preload so Grimoire curators can add to chests right away.
+		if msg.err == nil && msg.me != nil && msg.me.GuildID != m.guildID {
+			m.guildID = msg.me.GuildID
+			m.chests = nil
+			m.open = nil
+			m.roster, m.standings = nil, nil
+			if m.guildID != "" && m.client != nil {
+				m.loading = true
+				return m, tea.Batch(m.loadChests(), m.loadRoster())
+			}
+		}
+
+	case guildRosterMsg:
+		if msg.err == nil {
+			m.roster = guildRoster(msg.cards, m.guildID)
+			m.standings = domain.RankGuilds(msg.cards)
+		}
+
+	case guildRoomMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("could not join the guild room", msg.err)
+		}
+
+	case guildChestsMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil && msg.chests != nil {
+			m.chests = msg.chests
+			if m.cursor >= len(m.chests.Chests) {
+				m.cursor = max(len(m.chests.Chests)-1, 0)
+			}
+		}
+
+	case guildChestMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.statusMsg = errText("could not open chest", msg.err)
+			m.open = nil
+			return m, nil
+		}
+		m.open = msg.chest
+		m.spellCursor = 0
+
+	case chestSpellResultMsg:
+		if msg.err != nil {
+			if !msg.added {
+				m.statusMsg = errText("remove failed", msg.err)
+			}
+			return m, nil
+		}
+		if !msg.added && m.open != nil && m.open.ID.String() == msg.chestID {
+			for i, s := range m.open.Spells {
+				if s.ID.String() == msg.spellID {
+					m.open.Spells = append(m.open.Spells[:i], m.open.Spells[i+1:]...)
+					break
+				}
+			}
+			if m.spellCursor >= len(m.open.Spells) && m.spellCursor > 0 {
+				m.spellCursor--
+			}
+			m.statusMsg = "removed from chest"
+		}
+		// Counts and contribution stats changed either way.
+		return m, m.loadChests()
+
+	case copyResultMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("copy failed: %v", msg.err)
+		} else {
+			m.statusMsg = "copied!"
+		}
+
+	case tea.KeyMsg:
+		m.statusMsg = ""
+		if m.open != nil {
+			return m.updateChest(msg)
+		}
+		return m.updateOverview(msg)
+	}
+	return m, nil
+}
+
+func (m guildModel) updateOverview(msg tea.KeyMsg) (guildModel, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.chests != nil && m.cursor < len(m.chests.Chests)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "enter":
+		if chest, ok := m.selectedChest(); ok && m.client != nil {
+			m.loading = true
+			return m, m.loadChest(chest.ID.String())
+		}
+	case "g":
+		if m.guildID != "" && m.client != nil {
+			m.statusMsg = "opening the guild room..."
+			return m, m.joinRoom()
+		}
+	case "r":
+		if m.guildID != "" && m.client != nil {
+			m.loading = true
+			return m, tea.Batch(m.loadChests(), m.loadRoster())
+		}
+	}
+	return m, nil
+}
+
+func (m guildModel) updateChest(msg tea.KeyMsg) (guildModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "backspace":
+		m.open = nil
+	case "j", "down":
+		if m.spellCursor < len(m.open.Spells)-1 {
+			m.spellCursor++
+		}
+	case "k", "up":
+		if m.spellCursor > 0 {
+			m.spellCursor--
+		}
+	case "c":
+		if m.spellCursor < len(m.open.Spells) {
+			text := m.open.Spells[m.spellCursor].Text
+			return m, func() tea.Msg {
+				return copyResultMsg{err: clipboard.WriteAll(text)}
+			}
+		}
+	case "x":
+		if m.chests == nil || !m.chests.CanCurate() {
+			m.statusMsg = "only guild curators can remove spells"
+			return m, nil
+		}
+		if m.spellCursor < len(m.open.Spells) {
+			c, guild := m.client, m.guildID
+			chestID, chestName := m.open.ID.String(), m.open.Name
+			spellID := m.open.Spells[m.spellCursor].ID.String()
+			return m, func() tea.Msg {
+				err := c.RemoveChestSpell(context.Background(), guild, chestID, spellID)
+				return chestSpellResultMsg{chestID: chestID, chestName: chestName, spellID: spellID, err: err}
+			}
+		}
+	}
+	return m, nil
+}
+
+// helpKeys returns context-sensitive help text.
+func (m guildModel) helpKeys() string {
+	if m.open != nil {
+		keys := helpEntry("j/k", "nav") + "  " + helpEntry("c", "copy")
+		if m.chests != nil && m.chests.CanCurate() {
+			keys += "  " + helpEntry("x", "remove")
+		}
+		return keys + "  " + helpEntry("esc", "back")
+	}
+	return helpEntry("j/k", "nav") + "  " + helpEntry("enter", "open chest") + "  " + helpEntry("g", "guild room") + "  " + helpEntry("r", "refresh") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+}
+
+func (m guildModel) View() string {
+	var sb strings.Builder
+	if m.guildID == "" {
+		sb.WriteString("\n   " + dimStyle.Render("the Grimoire hasn't sorted you into a guild yet") + "\n")
+		return sb.String()
+	}
+
+	name := m.guildID
+	if g, ok := domain.Guilds[m.guildID]; ok {
+		name = g.Name
+	}
+	header := " " + GuildStyle(m.guildID).Render(name)
+	if e := GuildEmblem(m.guildID); e != "" {
+		header = " " + e + header
+	}
+	if m.chests != nil && m.chests.CanCurate() {
+		header += metaStyle.Render(" · ") + goldStyle.Render("curator")
+	}
+	sb.WriteString(header + "\n")
+	if line := m.viewGuildStats(); line != "" {
+		sb.WriteString(clipWidth("   "+line, m.width) + "\n")
+	}
+
+	if m.statusMsg != "" {
+		sb.WriteString("\n " + upvoteStyle.Render(m.statusMsg) + "\n")
+	}
+
+	switch {
+	case m.loading && m.chests == nil:
+		sb.WriteString("\n   " + dimStyle.Render("opening the guild chests...") + "\n")
+		return sb.String()
+	case m.err != nil && m.chests == nil:
+		sb.WriteString("\n   " + dimStyle.Render("could not load guild chests · r to retry") + "\n")
+		return sb.String()
+	case m.chests == nil:
+		return sb.String()
+	}
+
+	if m.open != nil {
+		sb.WriteString(m.viewChest())
+		return sb.String()
+	}
+	sb.WriteString(m.viewRoster())
+	sb.WriteString(m.viewChests())
+	sb.WriteString(m.viewContributions())
+	return sb.String()
+}
+
+// viewGuildStats summarizes the guild: members, online, spells, potency and
+// rank among the six guilds.
+func (m guildModel) viewGuildStats() string {
+	s, ok := m.standing()
+	if !ok {
+		return ""
+	}
+	online := 0
+	for _, c := range m.roster {
+		if c.Online {
+			online++
+		}
+	}
+	parts := []string{
+		fmt.Sprintf("%d member%s", s.Members, plural(s.Members)),
+		fmt.Sprintf("%d online", online),
+		fmt.Sprintf("%d spell%s", s.Spells, plural(s.Spells)),
+		fmt.Sprintf("%d potency", s.Potency),
+	}
+	line := metaStyle.Render(strings.Join(parts, " · "))
+	return line + metaStyle.Render(" · ") + goldStyle.Render(fmt.Sprintf("rank #%d of %d", s.Rank, len(m.standings)))
+}
+
+// availabilityRank orders magicians by how likely they are to answer now:
+// online, then away, then inside their active hours, then unknown, then
+// likely asleep.
+func availabilityRank(c domain.MagicianCard, now time.Time) int {
+	if c.Online {
+		if c.Away {
+			return 1
+		}
+		return 0
+	}
+	switch c.AvailabilityAt(now) {
+	case domain.AvailabilityActive:
+		return 2
+	case domain.AvailabilityUnknown:
+		return 3
+	}
+	return 4
+}
+
+// byAvailability returns the roster with the members most likely to be
+// around first, most potent first within each group.
+func byAvailability(roster []domain.MagicianCard, now time.Time) []domain.MagicianCard {
+	out := slices.Clone(roster)
+	sort.SliceStable(out, func(i, j int) bool { return availabilityRank(out[i], now) < availabilityRank(out[j], now) })
+	return out
+}
+
+// viewRoster lists the guild's members, likely-awake and most potent first.
+func (m guildModel) viewRoster() string {
+	if len(m.roster) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n " + sectionHeaderStyle.Render(fmt.Sprintf("── ROSTER %d ──", len(m.roster))) + "\n")
+	roster := byAvailability(m.roster, clock())
+	for _, c := range roster[:min(len(roster), rosterLimit)] {
+		sb.WriteString(fmt.Sprintf("   %s %s %s\n",
+			presenceDot(c.Online, c.Away),
+			GuildStyle(m.guildID).Render(padRight(truncStr(c.GitHubLogin, 16), 16)),
+			metaStyle.Render(fmt.Sprintf("%d spells · %d potency", c.SpellCount, c.TotalPotency))))
+	}
+	if extra := len(m.roster) - rosterLimit; extra > 0 {
+		sb.WriteString("   " + dimStyle.Render(fmt.Sprintf("+%d more", extra)) + "\n")
+	}
+	return sb.String()
+}
+
+func (m guildModel) viewChests() string {
+	var sb strings.Builder
+	chests := m.chests.Chests
+	sb.WriteString("\n " + sectionHeaderStyle.Render(fmt.Sprintf("── CHESTS %d ──", len(chests))) + "\n")
+	if len(chests) == 0 {
+		sb.WriteString("   " + dimStyle.Render("no chests yet · curators can start one on grimora.ai") + "\n")
+		return sb.String()
+	}
+	nameW := max(m.width-20, 16)
+	for i, c := range chests {
+		cursor := "  "
+		nameStyle := dimStyle
+		if i == m.cursor {
+			cursor = accentStyle.Render("▸") + " "
+			nameStyle = normalStyle.Bold(true)
+		}
+		sb.WriteString(" " + cursor + nameStyle.Render(truncStr(c.Name, nameW)) + "  " +
+			metaStyle.Render(fmt.Sprintf("%d spell%s", c.SpellCount, plural(c.SpellCount))) + "\n")
+		if c.Description != "" {
+			sb.WriteString("     " + dimStyle.Render(truncStr(c.Description, nameW)) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// viewContributions lists members by how much they've added to the chests.
+func (m guildModel) viewContributions() string {
+	contribs := m.chests.Contributions
+	if len(contribs) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n " + sectionHeaderStyle.Render("── CONTRIBUTORS ──") + "\n")
+	most := 0
+	for _, c := range contribs {
+		most = max(most, c.Added)
+	}
+	barW := min(max(m.width-50, 6), 20)
+	for _, c := range contribs {
+		role := "         "
+		if c.Role == domain.GuildRoleCurator {
+			role = goldStyle.Render("✦ curator")
+		}
+		sb.WriteString(fmt.Sprintf("   %s %s %s %s\n",
+			GuildStyle(m.guildID).Render(padRight(truncStr(c.Login, 16), 16)),
+			role,
+			GuildStyle(m.guildID).Render(hbar(c.Added, most, barW)),
+			metaStyle.Render(fmt.Sprintf("%d added · %d casts", c.Added, c.Casts))))
+	}
+	return sb.String()
+}
+
+func (m guildModel) viewChest() string {
+	var sb strings.Builder
+	chest := m.open
+	sb.WriteString("\n " + sectionHeaderStyle.Render(fmt.Sprintf("── %s ──", strings.ToUpper(chest.Name))) + "\n")
+	if chest.Description != "" {
+		sb.WriteString("   " + dimStyle.Render(chest.Description) + "\n")
+	}
+	if len(chest.Spells) == 0 {
+		sb.WriteString("   " + dimStyle.Render("this chest is empty · curators add spells with G in the Grimoire") + "\n")
+		return sb.String()
+	}
+	textW := max(m.width-24, 20)
+	for i, s := range chest.Spells {
+		cursor := "  "
+		textStyle := dimStyle
+		if i == m.spellCursor {
+			cursor = accentStyle.Render("▸") + " "
+			textStyle = normalStyle.Bold(true)
+		}
+		text := s.Preview
+		if text == "" {
+			text = s.Text
+		}
+		line := " " + cursor + TagStyle(s.Tag).Render("●") + " " + textStyle.Render(truncStr(cleanTitle(text), textW))
+		if s.AddedBy != "" {
+			line += "  " + metaStyle.Render("by "+s.AddedBy)
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
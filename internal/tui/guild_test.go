@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func newTestGuildModel(role string, chests ...domain.GuildChest) guildModel {
+	m := newGuildModel(nil)
+	m.width = 80
+	m.height = 30
+	m.guildID = "amarok"
+	m.chests = &domain.GuildChests{Role: role, Chests: chests}
+	return m
+}
+
+func makeTestChest(name string, spells ...string) domain.GuildChest {
+	c := domain.GuildChest{ID: uuid.New(), GuildID: "amarok", Name: name, SpellCount: len(spells)}
+	for _, text := range spells {
+		c.Spells = append(c.Spells, domain.ChestSpell{Spell: makeTestSpell(text, "debugging"), AddedBy: "wolf"})
+	}
+	return c
+}
+
+func TestGuildViewListsChestsAndContributors(t *testing.T) {
+	m := newTestGuildModel(domain.GuildRoleCurator, makeTestChest("Debugging", "a", "b"), makeTestChest("Testing"))
+	m.chests.Contributions = []domain.ChestContribution{{Login: "wolf", Role: domain.GuildRoleCurator, Added: 3, Casts: 12}}
+
+	view := m.View()
+	for _, want := range []string{"Amarok", "curator", "CHESTS 2", "Debugging", "2 spells", "CONTRIBUTORS", "wolf", "3 added · 12 casts"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in guild view, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestGuildViewWithoutGuild(t *testing.T) {
+	m := newGuildModel(nil)
+	if !strings.Contains(m.View(), "guild yet") {
+		t.Errorf("expected a no-guild message, got:\n%s", m.View())
+	}
+}
+
+func TestGuildAddToChestRequiresCurator(t *testing.T) {
+	spell := makeTestSpell("bisect first", "debugging")
+
+	m := newTestGuildModel(domain.GuildRoleMember, makeTestChest("Debugging"))
+	if cmd, status := m.addToChest(spell); cmd != nil || !strings.Contains(status, "curators") {
+		t.Errorf("member add: cmd = %v, status = %q", cmd != nil, status)
+	}
+
+	m = newTestGuildModel(domain.GuildRoleCurator)
+	if cmd, status := m.addToChest(spell); cmd != nil || !strings.Contains(status, "no chests") {
+		t.Errorf("empty guild add: cmd = %v, status = %q", cmd != nil, status)
+	}
+
+	m = newTestGuildModel(domain.GuildRoleCurator, makeTestChest("Debugging"))
+	if cmd, status := m.addToChest(spell); cmd == nil || status != "" {
+		t.Errorf("curator add: cmd = %v, status = %q", cmd != nil, status)
+	}
+}
+
+func TestGuildChestRemoveSpell(t *testing.T) {
+	chest := makeTestChest("Debugging", "a", "b")
+	m := newTestGuildModel(domain.GuildRoleCurator, chest)
+	m, _ = m.Update(guildChestMsg{chest: &chest})
+	if m.open == nil || !strings.Contains(m.View(), "by wolf") {
+		t.Fatalf("expected the chest open, got:\n%s", m.View())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected x to remove the spell")
+	}
+	removed := chest.Spells[1].ID.String()
+	m, _ = m.Update(chestSpellResultMsg{chestID: chest.ID.String(), spellID: removed})
+	if len(m.open.Spells) != 1 || m.spellCursor != 0 {
+		t.Errorf("expected one spell left and cursor clamped, got %d spells cursor %d", len(m.open.Spells), m.spellCursor)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.open != nil {
+		t.Error("expected esc to return to the overview")
+	}
+}
+
+func TestGuildChestRemoveRequiresCurator(t *testing.T) {
+	chest := makeTestChest("Debugging", "a")
+	m := newTestGuildModel(domain.GuildRoleMember, chest)
+	m.open = &chest
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd != nil || !strings.Contains(m.statusMsg, "curators") {
+		t.Errorf("expected members to be refused, status = %q", m.statusMsg)
+	}
+}
+
+func TestAppGrimoireChestAddReportsStatus(t *testing.T) {
+	a := newTestApp()
+	a.view = viewGrimoire
+	a.guild = newTestGuildModel(domain.GuildRoleMember, makeTestChest("Debugging"))
+
+	m, _ := a.Update(chestAddRequestMsg{spell: makeTestSpell("bisect first", "debugging")})
+	a = m.(App)
+	if !strings.Contains(a.grimoire.statusMsg, "curators") {
+		t.Errorf("grimoire status = %q", a.grimoire.statusMsg)
+	}
+
+	m, _ = a.Update(chestSpellResultMsg{chestName: "Debugging", added: true})
+	a = m.(App)
+	if a.grimoire.statusMsg != "added to Debugging" {
+		t.Errorf("grimoire status = %q", a.grimoire.statusMsg)
+	}
+}
+
+func makeTestCard(login, guild string, spells, potency int, online bool) domain.MagicianCard {
+	return domain.MagicianCard{
+		Magician:     domain.Magician{GitHubLogin: login, GuildID: guild},
+		SpellCount:   spells,
+		TotalPotency: potency,
+		Online:       online,
+	}
+}
+
+func TestGuildRosterAndStanding(t *testing.T) {
+	m := newTestGuildModel(domain.GuildRoleMember)
+	m, _ = m.Update(guildRosterMsg{cards: []domain.MagicianCard{
+		makeTestCard("pup", "amarok", 1, 2, false),
+		makeTestCard("alpha", "amarok", 5, 40, true),
+		makeTestCard("raven", "nyx", 9, 90, true),
+	}})
+
+	if len(m.roster) != 2 || m.roster[0].GitHubLogin != "alpha" {
+		t.Fatalf("expected amarok members, most potent first, got %+v", m.roster)
+	}
+	view := m.View()
+	for _, want := range []string{"2 members", "1 online", "6 spells", "42 potency", "rank #2 of 6", "ROSTER 2", "alpha", "5 spells · 40 potency"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in guild view, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "raven") {
+		t.Error("expected other guilds' members left off the roster")
+	}
+}
+
+func TestAppGuildRoomSwitchesHall(t *testing.T) {
+	a := newTestApp()
+	a.view = viewGuild
+
+	m, _ := a.Update(guildRoomMsg{slug: "amarok-den", name: "Amarok Den"})
+	a = m.(App)
+	if a.view != viewHall || a.hall.slug() != "amarok-den" {
+		t.Fatalf("view = %v, room = %q", a.view, a.hall.slug())
+	}
+	if !strings.Contains(a.hall.View(), "Amarok Den") {
+		t.Error("expected a room banner in the Hall")
+	}
+
+	// Batches from the room we left are dropped.
+	a.hall, _ = a.hall.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{makeTestRoomMessage("x", "nyx", "stale")}})
+	if len(a.hall.messages) != 0 {
+		t.Error("expected messages from another room to be ignored")
+	}
+
+	a.hall.inputFocused = false
+	a.hall, _ = a.hall.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if a.hall.room != "" || a.hall.slug() != hallSlug {
+		t.Errorf("expected esc to return to the main Hall, room = %q", a.hall.room)
+	}
+}
+
+func TestAppGuildRoomErrorStaysOnGuild(t *testing.T) {
+	a := newTestApp()
+	a.view = viewGuild
+	m, _ := a.Update(guildRoomMsg{err: errNoGuildRoom})
+	a = m.(App)
+	if a.view != viewGuild || !strings.Contains(a.guild.statusMsg, "no chat room") {
+		t.Errorf("view = %v, status = %q", a.view, a.guild.statusMsg)
+	}
+}
+
+func TestRosterSortedByAvailability(t *testing.T) {
+	// 12:00 UTC: daytime in Berlin, night in Honolulu.
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	card := func(login, tz string, potency int, online bool) domain.MagicianCard {
+		return domain.MagicianCard{Magician: domain.Magician{GitHubLogin: login, Timezone: tz}, TotalPotency: potency, Online: online}
+	}
+	roster := []domain.MagicianCard{
+		card("sleepy", "Pacific/Honolulu", 90, false),
+		card("nozone", "", 80, false),
+		card("awake", "Europe/Berlin", 70, false),
+		card("here", "Pacific/Honolulu", 10, true),
+	}
+	got := byAvailability(roster, now)
+	var logins []string
+	for _, c := range got {
+		logins = append(logins, c.GitHubLogin)
+	}
+	if want := "here awake nozone sleepy"; strings.Join(logins, " ") != want {
+		t.Errorf("order = %v, want %s", logins, want)
+	}
+	if roster[0].GitHubLogin != "sleepy" {
+		t.Error("byAvailability should not reorder the roster in place")
+	}
+}
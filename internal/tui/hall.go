@@ -10,9 +10,18 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/internal/drafts"
+	"github.com/naveenspark/grimora/internal/hooks"
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/internal/metrics"
+	"github.com/naveenspark/grimora/internal/outbox"
+	"github.com/naveenspark/grimora/internal/state"
 	"github.com/naveenspark/grimora/pkg/client"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
@@ -23,11 +32,9 @@ var mentionRe = regexp.MustCompile(`@(\w+)`)
 // urlRe matches http/https URLs in message text.
 var urlRe = regexp.MustCompile(`https?://[^\s<>\[\]()]+`)
 
-// osc8Re matches OSC 8 hyperlink sequences (ESC]8;;...BEL...ESC]8;;BEL).
-var osc8Re = regexp.MustCompile("\033\\]8;;[^\a]*\a[^\a]*\033\\]8;;\a")
-
-// hallPollInterval is how often the Hall polls for new messages.
-const hallPollInterval = 3 * time.Second
+// hallPollInterval is how often the Hall polls for new messages. Set from
+// config by ApplyConfig.
+var hallPollInterval = config.DefaultPollIntervals[config.PollHall]
 
 // hallAnimInterval is the frame rate for card entrance animations.
 const hallAnimInterval = 80 * time.Millisecond
@@ -41,17 +48,8 @@ type hallTickMsg time.Time
 // hallAnimTickMsg fires on each animation frame interval.
 type hallAnimTickMsg time.Time
 
-// cursorBlinkMsg toggles the input cursor on/off.
-type cursorBlinkMsg struct{}
-
-func cursorBlinkCmd() tea.Cmd {
-	return tea.Tick(150*time.Millisecond, func(time.Time) tea.Msg {
-		return cursorBlinkMsg{}
-	})
-}
-
-func hallTickCmd() tea.Cmd {
-	return tea.Tick(hallPollInterval, func(t time.Time) tea.Msg {
+func hallTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
 		return hallTickMsg(t)
 	})
 }
@@ -64,31 +62,30 @@ func hallAnimTickCmd() tea.Cmd {
 
 // hallMessagesMsg carries a batch of room messages from the API.
 type hallMessagesMsg struct {
+	room     string // slug the batch was fetched from
 	messages []domain.RoomMessage
 	err      error
 }
 
 // hallPresenceMsg carries room presence data from the API.
 type hallPresenceMsg struct {
+	room   string
 	count  int
 	logins []string
 	err    error
 }
 
-// hallSendMsg carries the result of a send attempt.
-type hallSendMsg struct {
-	err error
-}
-
 // hallProjectsMsg carries the user's workshop projects for # autocomplete.
 type hallProjectsMsg struct {
 	projects []domain.WorkshopProject
 	err      error
 }
 
-// hallLoginsMsg carries all registered logins for @mention autocomplete.
+// hallLoginsMsg carries all registered magicians, for @mention autocomplete
+// and the roster.
 type hallLoginsMsg struct {
 	logins []string
+	cards  []domain.MagicianCard
 }
 
 // reactionCount is an emoji + count for display.
@@ -109,40 +106,72 @@ type chatMessage struct {
 	IsSystem    bool
 	IsGrimoire  bool
 	IsSelf      bool
+	IsBot       bool
 	Reactions   []reactionCount
 	// Animation state (Phase 5)
 	animFrame int
 	animStart time.Time
 }
 
-// hallReactionsMsg carries batch reaction counts from the API.
+// defaultReaction is the emoji added by + on a selected message.
+const defaultReaction = "🔥"
+
+// hallReactedMsg carries the result of reacting to a message.
+type hallReactedMsg struct {
+	id  string // the message reacted to
+	err error
+}
+
+// hallCopiedMsg carries the result of copying a message permalink.
+type hallCopiedMsg struct {
+	link string
+	err  error
+}
+
+// hallReactionsMsg carries batch reaction counts from the API for the
+// messages in ids. A requested message missing from reactions has none.
 type hallReactionsMsg struct {
+	room      string
+	ids       []string
 	reactions map[string][]reactionCount
 	err       error
 }
 
 // hallModel is the Hall (group chat) tab model.
-// It polls /api/rooms/hall/messages every 3 seconds and renders messages
+// It polls the current room every hallPollInterval and renders messages
 // in a scrollable log with an inline text input at the bottom.
 type hallModel struct {
-	client         *client.Client
+	client         client.API
+	drafts         *drafts.Store
+	journal        *journal.Journal // copy of everything sent; nil keeps none
+	outbox         *outbox.Store    // messages on their way out; shared with threads
+	notified       notifiedMessages // messages already alerted on; shared with threads and the App
 	messages       []chatMessage
 	input          string
-	status         string // ephemeral status line (e.g. "sending not yet implemented")
+	inputCursor    editCursor
+	history        inputHistory // sent messages, for ↑ and ↓
+	status         string       // ephemeral status line (e.g. "sending not yet implemented")
 	err            string
 	connected      bool
 	inputFocused   bool
 	width          int
 	height         int
 	scroll         int    // lines scrolled up from bottom (0 = at bottom)
+	newBelow       int    // messages that arrived below the viewport while scrolled up
 	myLogin        string // populated from the App.me after first load
+	myID           uuid.UUID
+	myCity         string // from your profile, for /city
 	seenIDs        map[string]bool
 	presenceCount  int
+	reactionsDue   map[string]bool // message IDs whose reaction counts need fetching
+	reactionsBusy  bool            // a reaction fetch is in flight
 	presenceLogins []string
-	animFrame      int // 0-2 sweep frame for "you" label + cursor blink
+	announced      map[string]time.Time // login → when their coming or going was last shown
+	animFrame      int                  // 0-2 sweep frame for "you" label + cursor blink
 
 	// @mention autocomplete state
-	allLogins      []string // all registered logins (pre-fetched for autocomplete)
+	allLogins      []string                       // all registered logins (pre-fetched for autocomplete)
+	magicians      map[string]domain.MagicianCard // login → card, for guild and follow state in the roster
 	mentionActive  bool
 	mentionQuery   string
 	mentionMatches []string
@@ -154,9 +183,34 @@ type hallModel struct {
 	projectMatches []domain.WorkshopProject
 	projectCursor  int
 	myProjects     []domain.WorkshopProject
+
+	// Message selection state (nav mode)
+	selecting  bool
+	selectedID string          // ID of the selected message
+	picker     linkPicker      // numbered link chooser for the selected message
+	focusID    string          // message a permalink opened, selected once it loads
+	report     *reportPrompt   // reason being chosen for a report; nil when not reporting
+	blocked    map[string]bool // logins whose messages are collapsed; shared, see App.withBlocked
+	revealed   map[string]bool // IDs of collapsed messages x has shown
+
+	replyTo *chatMessage // message being quote-replied to, nil when composing normally
+
+	spellIndex map[string]domain.Spell // spell text → spell, for recognizing pastes
+	cite       *spellCitation          // pasted spell being offered or credited
+
+	room       string // slug of a joined room such as a guild room; "" is the main Hall
+	roomName   string
+	roomList   []domain.Room     // every open room, for /rooms and the header topic
+	roomAlerts map[string]string // alert level by room slug; see state.RoomAlerts
+	rooms      roomPanel
+	roster     rosterPanel
+
+	tour tourState // practice room script progress
+
+	resumeID string // message a saved session was scrolled to, shown at the bottom once it loads
 }
 
-func newHallModel(c *client.Client) hallModel {
+func newHallModel(c client.API) hallModel {
 	return hallModel{
 		client:       c,
 		seenIDs:      make(map[string]bool),
@@ -165,7 +219,7 @@ func newHallModel(c *client.Client) hallModel {
 }
 
 func (m hallModel) Init() tea.Cmd {
-	return tea.Batch(m.loadMessages(), m.loadProjects(), m.loadAllLogins(), cursorBlinkCmd(), hallAnimTickCmd())
+	return tea.Batch(m.loadMessages(), m.loadProjects(), m.loadAllLogins(), m.loadRooms(), m.loadSpellIndex(), hallAnimTickCmd())
 }
 
 // loadProjects fetches the user's workshop projects for # autocomplete.
@@ -187,7 +241,7 @@ func (m hallModel) loadAllLogins() tea.Cmd {
 		return nil
 	}
 	return func() tea.Msg {
-		cards, err := c.ListMagicians(context.Background(), 200, 0)
+		cards, err := client.MagiciansIter(context.Background(), c, magicianPageSize).Collect()
 		if err != nil {
 			return hallLoginsMsg{}
 		}
@@ -197,50 +251,156 @@ func (m hallModel) loadAllLogins() tea.Cmd {
 				logins = append(logins, card.GitHubLogin)
 			}
 		}
-		return hallLoginsMsg{logins: logins}
+		return hallLoginsMsg{logins: logins, cards: cards}
 	}
 }
 
 // hallSlug is the default public chat room.
-const hallSlug = "the-hall"
+const hallSlug = domain.HallSlug
+
+// slug returns the room the Hall tab is showing.
+func (m hallModel) slug() string {
+	if m.room == "" {
+		return hallSlug
+	}
+	return m.room
+}
+
+// enterRoom switches the Hall tab to another room, or back to the main Hall
+// when slug is empty. The unsent input is kept as that room's draft.
+func (m hallModel) enterRoom(slug, name string) hallModel {
+	m.drafts.Set(roomDraftKey(m.slug()), m.input)
+	m.exitSelect()
+	m.room, m.roomName = slug, name
+	m.messages = nil
+	m.seenIDs = make(map[string]bool)
+	m.reactionsDue = nil
+	m.connected = false
+	m.err = ""
+	m.status = ""
+	m.scroll, m.newBelow = 0, 0
+	m.presenceCount, m.presenceLogins = 0, nil
+	m.announced = nil
+	m.roster = rosterPanel{}
+	m.replyTo = nil
+	m.cite = nil
+	m.focusID = ""
+	m.resumeID = ""
+	m.input = m.drafts.Get(roomDraftKey(m.slug()))
+	m.inputCursor = editCursor{}
+	return m
+}
 
-// loadMessages fetches the 50 most recent messages and presence from the hall room.
+// loadMessages fetches the pageSize most recent messages and presence from
+// the current room.
 func (m hallModel) loadMessages() tea.Cmd {
-	c := m.client
+	if m.practicing() {
+		return nil
+	}
+	c, slug := m.client, m.slug()
 	fetchMsgs := func() tea.Msg {
-		msgs, err := c.GetRoomMessages(context.Background(), hallSlug, time.Time{}, 50)
-		return hallMessagesMsg{messages: msgs, err: err}
+		msgs, err := c.GetRoomMessages(context.Background(), slug, time.Time{}, pageSize)
+		return hallMessagesMsg{room: slug, messages: msgs, err: err}
 	}
 	fetchPresence := func() tea.Msg {
-		p, err := c.GetRoomPresence(context.Background(), hallSlug)
+		p, err := c.GetRoomPresence(context.Background(), slug)
 		if err != nil {
-			return hallPresenceMsg{err: err}
+			return hallPresenceMsg{room: slug, err: err}
 		}
-		return hallPresenceMsg{count: p.Count, logins: p.Magicians}
+		return hallPresenceMsg{room: slug, count: p.Count, logins: p.Magicians}
 	}
 	return tea.Batch(fetchMsgs, fetchPresence)
 }
 
-// sendRoomMessage sends a message to the hall via REST POST.
+// sendRoomMessage queues a message for the current room and sends it. It
+// shows as pending until the API takes it, and is retried if that fails.
 func (m hallModel) sendRoomMessage(body string) tea.Cmd {
-	c := m.client
-	return func() tea.Msg {
-		_, err := c.SendRoomMessage(context.Background(), hallSlug, body)
-		return hallSendMsg{err: err}
+	meta := mergeMetadata(replyMetadata(m.replyTo), citationMetadata(m.cite))
+	msg := m.outbox.Add(outbox.Message{Kind: outbox.KindRoom, Target: m.slug(), Body: body, Metadata: meta})
+	return sendQueuedCmd(m.client, m.outbox, m.journal, msg)
+}
+
+// updateOutbox handles the keys for this room's failed messages: ctrl+r
+// sends them again now, ctrl+x discards the newest.
+func (m hallModel) updateOutbox(key string) (hallModel, tea.Cmd, bool) {
+	switch key {
+	case "ctrl+r":
+		cmd := retryFailedCmd(m.client, m.outbox, m.journal, outbox.KindRoom, m.slug())
+		if cmd == nil {
+			m.status = "nothing to retry"
+		}
+		return m, cmd, true
+	case "ctrl+x":
+		if discardFailed(m.outbox, outbox.KindRoom, m.slug()) == "" {
+			m.status = "nothing to discard"
+		} else {
+			m.status = "unsent message discarded"
+		}
+		return m, nil, true
 	}
+	return m, nil, false
 }
 
-// loadReactions fetches reaction counts for all currently loaded messages.
-func (m hallModel) loadReactions() tea.Cmd {
-	c := m.client
-	ids := make([]string, 0, len(m.messages))
+// markReactionsDue queues message IDs for the next reaction fetch.
+func (m *hallModel) markReactionsDue(ids ...string) {
+	if m.reactionsDue == nil {
+		m.reactionsDue = make(map[string]bool)
+	}
+	for _, id := range ids {
+		if id != "" {
+			m.reactionsDue[id] = true
+		}
+	}
+}
+
+// visibleMessageIDs returns the IDs of the messages at least partly inside
+// the viewport.
+func (m hallModel) visibleMessageIDs() []string {
+	if len(m.messages) == 0 {
+		return nil
+	}
+	lines, starts := m.messageLines()
+	total := len(lines)
+	vh := m.viewportHeight()
+	end := total - min(m.scroll, max(total-vh, 0))
+	start := end - vh
+	var ids []string
+	for i, msg := range m.messages {
+		last := total
+		if i+1 < len(starts) {
+			last = starts[i+1]
+		}
+		if last > start && starts[i] < end {
+			ids = append(ids, msg.ID)
+		}
+	}
+	return ids
+}
+
+// loadReactions sends every queued reaction fetch as one batch, unless a
+// batch is already in flight; what queues up meanwhile goes out when it
+// returns. Only new, visible and just-reacted-to messages are ever queued,
+// so a full buffer doesn't mean a fetch over every message on each poll.
+func (m hallModel) loadReactions() (hallModel, tea.Cmd) {
+	if m.client == nil || lowBandwidth || m.reactionsBusy || len(m.reactionsDue) == 0 {
+		return m, nil
+	}
+	ids := make([]string, 0, len(m.reactionsDue))
 	for _, msg := range m.messages {
-		ids = append(ids, msg.ID)
+		if m.reactionsDue[msg.ID] {
+			ids = append(ids, msg.ID)
+		}
 	}
-	return func() tea.Msg {
-		counts, err := c.GetReactionCounts(context.Background(), hallSlug, ids)
+	m.reactionsDue = nil
+	if len(ids) == 0 {
+		return m, nil
+	}
+	m.reactionsBusy = true
+	c, slug := m.client, m.slug()
+	return m, func() tea.Msg {
+		counts, err := c.GetReactionCounts(context.Background(), slug, ids)
 		if err != nil {
-			return hallReactionsMsg{err: err}
+			return hallReactionsMsg{room: slug, ids: ids, err: err}
 		}
 		result := make(map[string][]reactionCount, len(counts))
 		for msgID, rcs := range counts {
@@ -250,7 +410,7 @@ func (m hallModel) loadReactions() tea.Cmd {
 			}
 			result[msgID] = converted
 		}
-		return hallReactionsMsg{reactions: result}
+		return hallReactionsMsg{room: slug, ids: ids, reactions: result}
 	}
 }
 
@@ -265,20 +425,46 @@ func (m hallModel) Update(msg tea.Msg) (hallModel, tea.Cmd) {
 	case meLoadedMsg:
 		if msg.err == nil && msg.me != nil {
 			m.myLogin = msg.me.GitHubLogin
+			m.myID = msg.me.ID
 			// Re-classify any already-loaded messages as self.
 			for i := range m.messages {
 				m.messages[i].IsSelf = (m.messages[i].SenderLogin == m.myLogin)
 			}
 		}
 
+	case watchResultMsg:
+		// Seeks are only ever watched from here; unwatching happens in You.
+		if msg.targetType == domain.WatchTargetSeek && msg.watching {
+			if msg.err != nil {
+				m.status = errText("watch failed", msg.err)
+			} else {
+				m.status = "watching this seek -- answers show up in You"
+			}
+		}
+
 	case hallMessagesMsg:
+		// Drop batches for a room we've since left; the new room polls on
+		// its own.
+		if msg.room != "" && msg.room != m.slug() {
+			return m, nil
+		}
 		if msg.err != nil {
-			m.err = msg.err.Error()
+			if m.room != "" && client.IsNotFound(msg.err) {
+				// The room is gone, e.g. archived since the session was saved.
+				m = m.enterRoom("", "")
+				m.status = "that room is gone -- back in the Hall"
+				return m, m.loadMessages()
+			}
+			m.err = errReason(msg.err)
 			// Keep polling even on error — transient network issues are common.
-			return m, hallTickCmd()
+			return m, hallTickCmd(pollDelay(m.client, hallPollInterval))
 		}
 		m.err = ""
+		// The first batch is history; only messages arriving after it can alert.
+		firstLoad := !m.connected
 		m.connected = true
+		var alerts []tea.Cmd
+		var added []chatMessage
 
 		// Merge new messages (de-duplicate by ID, then sort by time).
 		for _, raw := range msg.messages {
@@ -308,15 +494,50 @@ func (m hallModel) Update(msg tea.Msg) (hallModel, tea.Cmd) {
 				Metadata:    meta,
 				CreatedAt:   raw.CreatedAt,
 				IsSelf:      (raw.SenderLogin == m.myLogin),
+				IsBot:       raw.SenderIsBot,
 			}
 
 			// Animate new rich messages
-			if kind != "message" && kind != "" {
+			if kind != "message" && kind != "" && animated() {
 				cm.animFrame = 1
 				cm.animStart = time.Now()
 			}
 
+			// The activity poll may have alerted on it already. A blocked
+			// magician neither rings, runs hooks nor is read aloud.
+			blocked := m.collapsible(cm)
+			notify := !firstLoad && !cm.IsSelf && !blocked && m.notified.first(id)
+			// A muted room neither rings nor runs the mention hook.
+			if notify && mentionsLogin(cm.Body, m.myLogin) && m.roomAlert(m.slug()) != state.RoomMuted {
+				if len(alerts) == 0 {
+					alerts = append(alerts, alertCmd("@"+cm.SenderLogin+" mentioned you"))
+				}
+				alerts = append(alerts, hookCmd(roomHookEvent(hooks.OnMention, m.slug(), cm)))
+			}
+			if notify && kind == "ship" {
+				alerts = append(alerts, hookCmd(roomHookEvent(hooks.OnShip, m.slug(), cm)))
+			}
+			if !firstLoad && !cm.IsSelf && !blocked {
+				alerts = append(alerts, announce(cm.SenderLogin+": "+cm.Body))
+			}
+
 			m.messages = append(m.messages, cm)
+			added = append(added, cm)
+		}
+
+		if firstLoad && m.roomAlert(m.slug()) == state.RoomMuted {
+			m.messages = append(m.messages, chatMessage{IsSystem: true, Body: mutedRoomLine, CreatedAt: clock()})
+		}
+
+		// While scrolled up, hold the reader's place: push the offset up by
+		// the lines that landed below and count them for the "new" pill.
+		if m.scroll > 0 && !firstLoad {
+			for _, cm := range added {
+				m.scroll += m.messageLineCount(cm)
+				if !cm.IsSelf {
+					m.newBelow++
+				}
+			}
 		}
 
 		// Sort chronologically — oldest first, newest at bottom near input.
@@ -336,45 +557,89 @@ func (m hallModel) Update(msg tea.Msg) (hallModel, tea.Cmd) {
 			}
 		}
 
-		// Fetch reaction counts for loaded messages.
-		cmds := []tea.Cmd{hallTickCmd()}
-		if m.client != nil && len(m.messages) > 0 {
-			cmds = append(cmds, m.loadReactions())
+		if m.focusID != "" {
+			m = m.focusMessage()
+		} else if firstLoad && m.resumeID != "" {
+			m = m.resumeScroll()
 		}
+
+		// Refresh reaction counts for what arrived and what's on screen.
+		for _, cm := range added {
+			m.markReactionsDue(cm.ID)
+		}
+		m.markReactionsDue(m.visibleMessageIDs()...)
+		var reactions tea.Cmd
+		m, reactions = m.loadReactions()
+		cmds := append([]tea.Cmd{hallTickCmd(pollDelay(m.client, hallPollInterval)), reactions}, alerts...)
 		return m, tea.Batch(cmds...)
 
+	case hallReactedMsg:
+		if msg.err != nil {
+			m.status = errText("react failed", msg.err)
+			return m, nil
+		}
+		m.status = ""
+		m.markReactionsDue(msg.id)
+		return m.loadReactions()
+
+	case reportedMsg:
+		m.status = reportStatus(msg)
+
+	case hallCopiedMsg:
+		if msg.err != nil {
+			// Headless sessions have no clipboard; show the link to copy by hand.
+			m.status = "no clipboard · " + msg.link
+			return m, nil
+		}
+		m.status = "copied " + msg.link
+
+	case tourReplyMsg:
+		if !m.practicing() {
+			return m, nil
+		}
+		m.messages = append(m.messages, msg.msgs...)
+		if m.scroll > 0 {
+			for _, cm := range msg.msgs {
+				m.scroll += m.messageLineCount(cm)
+				m.newBelow++
+			}
+		}
+		var said []tea.Cmd
+		for _, cm := range msg.msgs {
+			said = append(said, announce(cm.SenderLogin+": "+cm.Body))
+		}
+		return m, tea.Batch(said...)
+
 	case hallReactionsMsg:
-		if msg.err == nil && msg.reactions != nil {
-			for i := range m.messages {
-				if rcs, ok := msg.reactions[m.messages[i].ID]; ok {
-					m.messages[i].Reactions = rcs
-				}
+		m.reactionsBusy = false
+		if msg.room != m.slug() {
+			return m, nil
+		}
+		if msg.err != nil {
+			// Try these again with the next poll.
+			m.markReactionsDue(msg.ids...)
+			return m, nil
+		}
+		requested := make(map[string]bool, len(msg.ids))
+		for _, id := range msg.ids {
+			requested[id] = true
+		}
+		for i := range m.messages {
+			if requested[m.messages[i].ID] {
+				m.messages[i].Reactions = msg.reactions[m.messages[i].ID]
 			}
 		}
-		return m, nil
+		// Anything queued while this batch was out goes now.
+		return m.loadReactions()
 
 	case hallPresenceMsg:
+		if msg.room != "" && msg.room != m.slug() {
+			return m, nil
+		}
 		if msg.err == nil {
-			// Diff presence to show join/leave system messages (skip first load)
+			// Say who came and went since the last poll (skip first load).
 			if m.presenceLogins != nil {
-				oldSet := make(map[string]bool, len(m.presenceLogins))
-				for _, l := range m.presenceLogins {
-					oldSet[l] = true
-				}
-				newSet := make(map[string]bool, len(msg.logins))
-				for _, l := range msg.logins {
-					newSet[l] = true
-				}
-				for _, l := range msg.logins {
-					if !oldSet[l] && l != m.myLogin {
-						m.messages = append(m.messages, chatMessage{IsSystem: true, Body: l + " joined"})
-					}
-				}
-				for _, l := range m.presenceLogins {
-					if !newSet[l] && l != m.myLogin {
-						m.messages = append(m.messages, chatMessage{Kind: "leave", SenderLogin: l})
-					}
-				}
+				m = m.announcePresence(msg.logins, clock())
 			}
 			m.presenceCount = msg.count
 			m.presenceLogins = msg.logins
@@ -389,22 +654,48 @@ func (m hallModel) Update(msg tea.Msg) (hallModel, tea.Cmd) {
 
 	case hallLoginsMsg:
 		m.allLogins = msg.logins
+		if msg.cards != nil {
+			m.magicians = make(map[string]domain.MagicianCard, len(msg.cards))
+			for _, card := range msg.cards {
+				m.magicians[card.GitHubLogin] = card
+			}
+		}
 		return m, nil
 
-	case hallSendMsg:
+	case rosterFollowMsg:
+		return m.updateRosterFollow(msg), nil
+
+	case hallSpellsMsg:
+		if msg.err == nil {
+			m = m.indexSpells(msg.spells)
+		}
+		return m, nil
+
+	case outboxSentMsg:
+		if msg.msg.Kind != outbox.KindRoom || msg.msg.Target != m.slug() {
+			return m, nil
+		}
 		if msg.err != nil {
-			m.status = "error: " + msg.err.Error()
+			m.status = errText("send failed", msg.err)
 			return m, nil
 		}
 		m.status = ""
 		return m, m.loadMessages()
 
 	case hallTickMsg:
+		metrics.ObservePoll("hall")
 		return m, m.loadMessages()
 
+	case linkOpenedMsg:
+		m.status = linkStatus(msg)
+		return m, nil
+
+	case hallRoomsMsg, roomJoinedMsg, roomCreatedMsg, roomArchivedMsg, roomTopicMsg:
+		return m.updateRoomResult(msg)
+
 	case cursorBlinkMsg:
 		m.animFrame++
-		return m, cursorBlinkCmd()
+		return m, nil
 
 	case hallAnimTickMsg:
 		active := false
@@ -426,10 +717,23 @@ func (m hallModel) Update(msg tea.Msg) (hallModel, tea.Cmd) {
 	case tea.KeyMsg:
 		// Any keypress resets sweep to frame 0 (bright on 'y', cursor visible)
 		m.animFrame = 0
-		if m.inputFocused {
-			return m.updateInput(msg)
+		var cmd tea.Cmd
+		if m.rooms.open {
+			m, cmd = m.updateRooms(msg)
+		} else if m.roster.open {
+			m, cmd = m.updateRoster(msg)
+		} else if next, outboxCmd, ok := m.updateOutbox(msg.String()); ok {
+			m, cmd = next, outboxCmd
+		} else if m.inputFocused {
+			m, cmd = m.updateInput(msg)
+		} else {
+			m, cmd = m.updateNav(msg)
+		}
+		if m.scroll == 0 {
+			m.newBelow = 0
 		}
-		return m.updateNav(msg)
+		m.drafts.Set(roomDraftKey(m.slug()), m.input)
+		return m, cmd
 	}
 
 	return m, nil
@@ -437,6 +741,11 @@ func (m hallModel) Update(msg tea.Msg) (hallModel, tea.Cmd) {
 
 // updateInput handles key events when the text input is focused.
 func (m hallModel) updateInput(msg tea.KeyMsg) (hallModel, tea.Cmd) {
+	// Pastes arrive whole; they never complete a mention or project.
+	if msg.Paste {
+		m.mentionActive, m.projectActive = false, false
+		return m.pasteInput(string(msg.Runes)), nil
+	}
 	key := msg.String()
 
 	// --- Mention autocomplete active ---
@@ -604,31 +913,60 @@ func (m hallModel) updateInput(msg tea.KeyMsg) (hallModel, tea.Cmd) {
 		}
 	}
 
+	if next, ok := m.updateCitation(key); ok {
+		return next, nil
+	}
+	if h, text, ok := m.history.recall(m.input, key); ok {
+		m.history, m.input, m.inputCursor = h, text, editCursor{}
+		return m.dropStaleCitation(), nil
+	}
+
 	// --- Normal input handling ---
 	switch key {
 	case "esc":
+		if m.replyTo != nil {
+			m.replyTo = nil
+			return m, nil
+		}
 		m.inputFocused = false
 		m.status = ""
 		return m, nil
 
 	case "shift+enter", "alt+enter":
-		if utf8.RuneCountInString(m.input) < maxInputLen {
-			m.input += "\n"
-		}
+		m.input = m.inputCursor.edit(m.input, "\n")
 		return m, nil
 
 	case "enter":
-		body := strings.TrimSpace(m.input)
+		body := citationBody(m.cite, strings.TrimSpace(m.input))
 		if body == "" {
+			// With nothing to send, enter follows the "new messages" pill.
+			if m.newBelow > 0 {
+				m.scroll = 0
+			}
 			return m, nil
 		}
+		if typed := strings.TrimSpace(m.input); typed != "" {
+			m.history = m.history.add(typed)
+		}
+		if text, escaped := unescapeSlash(body); escaped {
+			body = text
+		} else if m, cmd, handled := m.runSlash(body); handled {
+			return m, cmd
+		}
+		if m.practicing() {
+			m.input, m.inputCursor = "", editCursor{}
+			m.replyTo = nil
+			return m.tourSend(body)
+		}
 		if m.myLogin == "" {
 			m.status = "run: grimora login"
 			return m, nil
 		}
-		m.input = ""
+		m.input, m.inputCursor = "", editCursor{}
 		m.status = ""
 		cmds := []tea.Cmd{m.sendRoomMessage(body)}
+		m.replyTo = nil
+		m.cite = nil
 		// Refresh projects after /build so # picks it up
 		if strings.HasPrefix(body, "/build ") {
 			cmds = append(cmds, m.loadProjects())
@@ -639,6 +977,11 @@ func (m hallModel) updateInput(msg tea.KeyMsg) (hallModel, tea.Cmd) {
 		if utf8.RuneCountInString(m.input) >= maxInputLen {
 			return m, nil
 		}
+		if !m.inputCursor.atEnd(m.input) {
+			// Completion works on the end of the input; mid-text it's just a character.
+			m.input = m.inputCursor.edit(m.input, key)
+			return m, nil
+		}
 		m.input += "@"
 		m.mentionActive = true
 		m.mentionQuery = ""
@@ -653,6 +996,10 @@ func (m hallModel) updateInput(msg tea.KeyMsg) (hallModel, tea.Cmd) {
 		if utf8.RuneCountInString(m.input) >= maxInputLen {
 			return m, nil
 		}
+		if !m.inputCursor.atEnd(m.input) {
+			m.input = m.inputCursor.edit(m.input, key)
+			return m, nil
+		}
 		m.input += "#"
 		if len(m.myProjects) > 0 {
 			m.projectActive = true
@@ -663,14 +1010,35 @@ func (m hallModel) updateInput(msg tea.KeyMsg) (hallModel, tea.Cmd) {
 		return m, nil
 
 	default:
-		m.input = editRune(m.input, key)
-		return m, nil
+		m.input = m.inputCursor.edit(m.input, key)
+		return m.dropStaleCitation(), nil
 	}
 }
 
 // updateNav handles key events when the input is not focused (scroll mode).
 func (m hallModel) updateNav(msg tea.KeyMsg) (hallModel, tea.Cmd) {
+	if m.selecting {
+		return m.updateSelect(msg)
+	}
 	switch msg.String() {
+	case "esc":
+		if m.room != "" {
+			m = m.enterRoom("", "")
+			return m, m.loadMessages()
+		}
+	case "v":
+		// Enter message selection, starting from the newest selectable message.
+		for i := len(m.messages) - 1; i >= 0; i-- {
+			if m.messages[i].ID != "" {
+				m.selecting = true
+				m.selectedID = m.messages[i].ID
+				m.status = ""
+				m.ensureSelectedVisible()
+				break
+			}
+		}
+	case "m":
+		m = m.openRoster()
 	case "j":
 		// Scroll down (toward bottom).
 		if m.scroll > 0 {
@@ -683,6 +1051,9 @@ func (m hallModel) updateNav(msg tea.KeyMsg) (hallModel, tea.Cmd) {
 			m.scroll++
 		}
 	case "enter", "i", "/":
+		if msg.String() == "enter" && m.newBelow > 0 {
+			m.scroll = 0
+		}
 		m.inputFocused = true
 		m.animFrame = 0
 		m.status = ""
@@ -690,51 +1061,215 @@ func (m hallModel) updateNav(msg tea.KeyMsg) (hallModel, tea.Cmd) {
 	return m, nil
 }
 
-// View renders the Hall tab.
-func (m hallModel) View() string {
-	var b strings.Builder
-
-	// Reserve lines: input(1 + extra newlines) + status(0-1) + autocomplete.
-	bodyWidth := m.width - inputPrefixWidth(m.myLogin) - 1 // -1 for cursor
-	if bodyWidth < 10 {
-		bodyWidth = 10
+// updateSelect handles key events while a message is selected.
+func (m hallModel) updateSelect(msg tea.KeyMsg) (hallModel, tea.Cmd) {
+	key := msg.String()
+	if m.picker.active() {
+		url, done := m.picker.handleKey(key)
+		if done {
+			m.picker = linkPicker{}
+		}
+		if url != "" {
+			return m, openURLCmd(url)
+		}
+		return m, nil
 	}
-	chrome := countInputVisualLines(m.input, bodyWidth)
-	if m.status != "" {
-		chrome++
+	if m.report != nil {
+		p := *m.report
+		reason, done := p.handleKey(key)
+		if !done {
+			return m, nil
+		}
+		m.report, m.status = nil, ""
+		if reason == "" {
+			return m, nil
+		}
+		m.status = "reporting..."
+		return m, p.send(m.client, reason)
 	}
-	// Slash hints and autocomplete popups steal lines from the message viewport.
-	if strings.HasPrefix(m.input, "/") && m.inputFocused {
-		chrome += m.countSlashHints()
+
+	idx := m.selectedIndex()
+	if idx < 0 {
+		m.exitSelect()
+		return m, nil
 	}
-	mentionLines := 0
-	if m.mentionActive && len(m.mentionMatches) > 0 {
-		mentionLines = len(m.mentionMatches)
-		if mentionLines > 5 {
-			mentionLines = 5
+	switch key {
+	case "j", "down":
+		// Select the next newer message.
+		for i := idx + 1; i < len(m.messages); i++ {
+			if m.messages[i].ID != "" {
+				m.selectedID = m.messages[i].ID
+				break
+			}
 		}
-		chrome += mentionLines
+		m.ensureSelectedVisible()
+	case "k", "up":
+		// Select the next older message.
+		for i := idx - 1; i >= 0; i-- {
+			if m.messages[i].ID != "" {
+				m.selectedID = m.messages[i].ID
+				break
+			}
+		}
+		m.ensureSelectedVisible()
+	case "o":
+		urls := extractURLs(m.messages[idx].Body)
+		switch len(urls) {
+		case 0:
+			m.status = "no links in this message"
+		case 1:
+			return m, openURLCmd(urls[0])
+		default:
+			m.picker = newLinkPicker(urls)
+		}
+	case "r":
+		target := m.messages[idx]
+		if target.IsSystem || target.Kind == "join" || target.Kind == "leave" {
+			m.status = "can't reply to that"
+			return m, nil
+		}
+		m.replyTo = &target
+		m.exitSelect()
+		m.inputFocused = true
+		m.animFrame = 0
+		m.status = ""
+	case "W":
+		target := m.messages[idx]
+		switch {
+		case m.practicing():
+			m.status = "practice messages can't be watched"
+			return m, nil
+		case target.Kind != "seek":
+			m.status = "only seeks can be watched"
+			return m, nil
+		}
+		m.status = "watching..."
+		return m, watchCmd(m.client, domain.WatchTargetSeek, target.ID, true)
+	case "+":
+		if m.practicing() {
+			return m.tourReact(idx)
+		}
+		c, slug, id := m.client, m.slug(), m.messages[idx].ID
+		m.status = "reacting..."
+		return m, func() tea.Msg {
+			return hallReactedMsg{id: id, err: c.AddReaction(context.Background(), slug, id, defaultReaction)}
+		}
+	case "y":
+		if m.practicing() {
+			m.status = "practice messages have no permalink"
+			return m, nil
+		}
+		link := domain.MessagePermalink(m.room, m.messages[idx].ID)
+		return m, func() tea.Msg {
+			return hallCopiedMsg{link: link, err: clipboard.WriteAll(link)}
+		}
+	case "!":
+		target := m.messages[idx]
+		switch {
+		case m.practicing():
+			m.status = "practice messages can't be reported"
+		case target.IsSelf:
+			m.status = "that's your own message"
+		case target.IsSystem:
+			m.status = "can't report that"
+		default:
+			m.report = &reportPrompt{targetType: domain.ReportTargetMessage, targetID: target.ID}
+			m.status = m.report.question()
+		}
+	case "x":
+		target := m.messages[idx]
+		if !m.collapsible(target) {
+			m.status = "x reveals messages from magicians you've blocked"
+			return m, nil
+		}
+		m.revealed = toggleReveal(m.revealed, target.ID)
+		m.ensureSelectedVisible()
+	case "esc", "v":
+		m.exitSelect()
+	case "enter", "i":
+		m.exitSelect()
+		m.inputFocused = true
+		m.animFrame = 0
+		m.status = ""
 	}
-	if m.projectActive && len(m.projectMatches) > 0 {
-		projectLines := len(m.projectMatches)
-		if projectLines > 5 {
-			projectLines = 5
+	return m, nil
+}
+
+// exitSelect leaves message selection mode.
+func (m *hallModel) exitSelect() {
+	m.selecting = false
+	m.selectedID = ""
+	m.picker = linkPicker{}
+	m.report = nil
+}
+
+// collapsible reports whether msg is from a magician you've blocked.
+func (m hallModel) collapsible(msg chatMessage) bool {
+	return !msg.IsSelf && !msg.IsSystem && m.blocked[msg.SenderLogin]
+}
+
+// selectedIndex returns the index of the selected message, or -1 if it has
+// scrolled out of the buffer.
+func (m hallModel) selectedIndex() int {
+	if m.selectedID == "" {
+		return -1
+	}
+	for i, msg := range m.messages {
+		if msg.ID == m.selectedID {
+			return i
 		}
-		chrome += projectLines
 	}
-	viewportHeight := m.height - chrome
-	if viewportHeight < 2 {
-		viewportHeight = 2
+	return -1
+}
+
+// ensureSelectedVisible adjusts scroll so the selected message is inside the viewport.
+func (m *hallModel) ensureSelectedVisible() {
+	idx := m.selectedIndex()
+	if idx < 0 {
+		return
+	}
+	lines, starts := m.messageLines()
+	total := len(lines)
+	start := starts[idx]
+	end := total
+	if idx+1 < len(starts) {
+		end = starts[idx+1]
+	}
+	vh := m.viewportHeight()
+	if end > total-m.scroll {
+		m.scroll = total - end
+	}
+	if start < total-m.scroll-vh {
+		m.scroll = total - vh - start
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+}
+
+// View renders the Hall tab.
+func (m hallModel) View() string {
+	var b strings.Builder
+
+	viewportHeight := m.viewportHeight()
+
+	// --- Room banner (only outside the main Hall) ---
+	if m.room != "" {
+		b.WriteString(m.renderRoomBanner() + "\n")
+	}
+	if m.roomTopic() != "" && !m.practicing() {
+		b.WriteString(m.renderTopicLine() + "\n")
 	}
 
 	// --- Message area ---
-	if m.err != "" && len(m.messages) == 0 {
+	empty := len(m.messages) == 0 && m.outbox.For(outbox.KindRoom, m.slug()) == nil
+	if m.err != "" && empty {
 		padLines(viewportHeight-1, &b)
-		b.WriteString(" " + dimStyle.Render("could not connect · check your connection or run: grimora login") + "\n")
+		b.WriteString(" " + dimStyle.Render("could not connect · "+m.err) + "\n")
 	} else if m.myLogin == "" && !m.connected {
 		padLines(viewportHeight-1, &b)
 		b.WriteString(" " + dimStyle.Render("connecting...") + "\n")
-	} else if len(m.messages) == 0 && m.err == "" {
+	} else if empty {
 		padLines(viewportHeight-1, &b)
 		b.WriteString(" " + dimStyle.Render("no messages yet") + "\n")
 	} else {
@@ -756,6 +1291,36 @@ func (m hallModel) View() string {
 		b.WriteString(m.renderProjectPopup())
 	}
 
+	// --- Link picker ---
+	if m.picker.active() {
+		b.WriteString(m.picker.View(m.width))
+	}
+
+	// --- Room panel ---
+	if m.rooms.open {
+		b.WriteString(m.renderRoomPanel())
+	}
+
+	// --- Roster ---
+	if m.roster.open {
+		b.WriteString(m.renderRosterPanel())
+	}
+
+	// --- New messages pill ---
+	if m.newBelow > 0 {
+		b.WriteString(m.renderNewBelowPill() + "\n")
+	}
+
+	// --- Citation banner ---
+	if m.cite != nil {
+		b.WriteString(m.renderCitationBanner() + "\n")
+	}
+
+	// --- Reply banner ---
+	if m.replyTo != nil {
+		b.WriteString(m.renderReplyBanner() + "\n")
+	}
+
 	// --- Input line ---
 	b.WriteString(m.renderInput())
 	b.WriteByte('\n')
@@ -768,21 +1333,71 @@ func (m hallModel) View() string {
 	return b.String()
 }
 
+// viewportHeight returns the number of lines available to the message log
+// after the input, status line and any popups have taken their share.
+func (m hallModel) viewportHeight() int {
+	// Reserve lines: input(1 + extra newlines) + status(0-1) + autocomplete.
+	bodyWidth := m.width - inputPrefixWidth(m.myLogin) - 1 // -1 for cursor
+	if bodyWidth < 10 {
+		bodyWidth = 10
+	}
+	chrome := countInputVisualLines(markCursor(m.input, m.inputCursor, m.inputFocused), bodyWidth)
+	if m.status != "" {
+		chrome++
+	}
+	// Slash hints and autocomplete popups steal lines from the message viewport.
+	if strings.HasPrefix(m.input, "/") && m.inputFocused {
+		chrome += m.countSlashHints()
+	}
+	mentionLines := 0
+	if m.mentionActive && len(m.mentionMatches) > 0 {
+		mentionLines = len(m.mentionMatches)
+		if mentionLines > 5 {
+			mentionLines = 5
+		}
+		chrome += mentionLines
+	}
+	if m.projectActive && len(m.projectMatches) > 0 {
+		projectLines := len(m.projectMatches)
+		if projectLines > 5 {
+			projectLines = 5
+		}
+		chrome += projectLines
+	}
+	chrome += m.picker.height()
+	chrome += m.roomPanelHeight()
+	chrome += m.rosterPanelHeight()
+	if m.newBelow > 0 {
+		chrome++
+	}
+	if m.replyTo != nil {
+		chrome++
+	}
+	if m.cite != nil {
+		chrome++
+	}
+	if m.room != "" {
+		chrome++
+	}
+	if m.roomTopic() != "" && !m.practicing() {
+		chrome++
+	}
+	viewportHeight := m.height - chrome
+	if viewportHeight < 2 {
+		viewportHeight = 2
+	}
+	return viewportHeight
+}
+
 // renderMessages renders the message log clipped to viewportHeight lines,
 // respecting the scroll offset. Newest messages appear at the bottom.
 func (m hallModel) renderMessages(viewportHeight int) string {
-	if len(m.messages) == 0 {
+	allLines, starts := m.messageLines()
+	if len(allLines) == 0 {
 		return ""
 	}
-
-	// Build all rendered lines from all messages (wrapped messages produce multiple lines).
-	var allLines []string
-	for _, msg := range m.messages {
-		rendered := m.renderMessage(msg)
-		allLines = append(allLines, strings.Split(rendered, "\n")...)
-		if len(msg.Reactions) > 0 {
-			allLines = append(allLines, renderReactionLine(msg.Reactions))
-		}
+	if idx := m.selectedIndex(); m.selecting && idx >= 0 {
+		allLines[starts[idx]] = markSelectedLine(allLines[starts[idx]])
 	}
 
 	total := len(allLines)
@@ -821,6 +1436,50 @@ func (m hallModel) renderMessages(viewportHeight int) string {
 	return b.String()
 }
 
+// messageLines renders every message into visual lines (wrapped messages produce
+// multiple lines) and returns them with the index of each message's first line.
+// Messages still on their way out follow the rest.
+func (m hallModel) messageLines() ([]string, []int) {
+	var allLines []string
+	starts := make([]int, len(m.messages))
+	for i, msg := range m.messages {
+		starts[i] = len(allLines)
+		rendered := m.renderMessage(msg)
+		allLines = append(allLines, strings.Split(rendered, "\n")...)
+		if len(msg.Reactions) > 0 {
+			allLines = append(allLines, renderReactionLine(msg.Reactions))
+		}
+	}
+	allLines = append(allLines, renderPending(m.outbox, outbox.KindRoom, m.slug(), m.myLogin, m.width, clock())...)
+	return allLines, starts
+}
+
+// messageLineCount returns the number of log lines msg occupies, matching
+// messageLines.
+func (m hallModel) messageLineCount(msg chatMessage) int {
+	n := strings.Count(m.renderMessage(msg), "\n") + 1
+	if len(msg.Reactions) > 0 {
+		n++
+	}
+	return n
+}
+
+// renderNewBelowPill renders the indicator shown above the input while new
+// messages are waiting below a scrolled-up viewport.
+func (m hallModel) renderNewBelowPill() string {
+	label := fmt.Sprintf("▼ %d new message", m.newBelow)
+	if m.newBelow != 1 {
+		label += "s"
+	}
+	return " " + accentStyle.Render(label) + dimStyle.Render(" · enter to jump")
+}
+
+// markSelectedLine replaces the leading gutter space of a rendered line with a
+// selection marker.
+func markSelectedLine(line string) string {
+	return accentStyle.Render("▸") + strings.TrimPrefix(line, " ")
+}
+
 // renderMessage renders a single chat message, wrapping body text to fit the terminal width.
 // May return multiple newline-separated lines for wrapped messages.
 func (m hallModel) renderMessage(msg chatMessage) string {
@@ -830,6 +1489,14 @@ func (m hallModel) renderMessage(msg chatMessage) string {
 		return " " + chatSysStyle.Render(centered)
 	}
 
+	if m.collapsible(msg) && !m.revealed[msg.ID] {
+		return renderBlockedMessage(msg.CreatedAt)
+	}
+
+	if msg.Metadata["spell_card"] != "" {
+		return m.renderSpellCard(msg)
+	}
+
 	// Rich message rendering by kind
 	switch msg.Kind {
 	case "build-start":
@@ -848,6 +1515,14 @@ func (m hallModel) renderMessage(msg chatMessage) string {
 		return m.renderJoin(msg)
 	case "leave":
 		return m.renderLeave(msg)
+	case domain.MessageKindAnnounce:
+		return m.renderAnnouncement(msg)
+	case domain.MessageKindCI:
+		return m.renderCIResult(msg)
+	}
+	if msg.Body == "" && !(domain.RoomMessage{Kind: msg.Kind}).KnownKind() {
+		// A kind from a newer server with nothing to fall back on.
+		msg.Body = "sent a " + msg.Kind + " this version of grimora can't show"
 	}
 
 	// Default: plain message
@@ -879,13 +1554,13 @@ func (m hallModel) renderPlainMessage(msg chatMessage) string {
 			namePart = chatTextStyle.Render(name)
 		}
 	}
+	if msg.IsBot {
+		namePart += " " + botBadge()
+	}
 
-	renderBody := func(s string) string {
-		highlighted := renderBodyWithMentions(s, m.myLogin, msg.IsSelf)
-		if msg.IsSelf {
-			return chatSelfTextStyle.Render(highlighted)
-		}
-		return chatTextStyle.Render(highlighted)
+	bodyStyle := chatTextStyle
+	if msg.IsSelf {
+		bodyStyle = chatSelfTextStyle
 	}
 
 	// Compute prefix: " " + time + "  " + name + " · "
@@ -895,17 +1570,23 @@ func (m hallModel) renderPlainMessage(msg chatMessage) string {
 	if bodyWidth < 20 {
 		bodyWidth = 20
 	}
-	linkified := linkifyURLs(msg.Body, bodyWidth)
-	wrapped := hardWrap(stripTrailingSpaces(lipgloss.NewStyle().Width(bodyWidth).Render(linkified)), bodyWidth)
-	lines := strings.Split(wrapped, "\n")
+	lines := renderMarkdown(msg.Body, bodyStyle, bodyWidth, func(text string, style lipgloss.Style) []span {
+		return messageSpans(text, m.myLogin, style, bodyWidth)
+	}, nil)
 
-	result := " " + timePart + "  " + namePart + sep + renderBody(lines[0])
+	result := " " + timePart + "  " + namePart + sep + lines[0]
+	if quote := m.renderReplyQuote(msg, prefixWidth); quote != "" {
+		result = quote + "\n" + result
+	}
 	if len(lines) > 1 {
 		indent := strings.Repeat(" ", prefixWidth)
 		for _, line := range lines[1:] {
-			result += "\n" + indent + renderBody(line)
+			result += "\n" + indent + line
 		}
 	}
+	if via := m.renderVia(msg, prefixWidth); via != "" {
+		result += "\n" + via
+	}
 	return result
 }
 
@@ -927,6 +1608,18 @@ func (m hallModel) renderShipCard(msg chatMessage) string {
 	return top + "\n" + body + "\n" + bottom
 }
 
+// renderRoomBanner names the joined room and how to get back to the Hall.
+func (m hallModel) renderRoomBanner() string {
+	name := m.roomName
+	if name == "" {
+		name = m.room
+	}
+	if m.practicing() {
+		return " " + accentStyle.Render("#") + " " + selectedStyle.Render(name) + metaStyle.Render(" · only you can see this · esc to leave")
+	}
+	return " " + accentStyle.Render("#") + " " + selectedStyle.Render(name) + metaStyle.Render(" · esc back to the Hall")
+}
+
 // renderSeek renders a compact seek line.
 func (m hallModel) renderSeek(msg chatMessage) string {
 	return " " + dimStyle.Render("✧") + " " + goldStyle.Render(msg.SenderLogin) + " seeks · " + chatTextStyle.Render(truncStr(msg.Body, 60))
@@ -957,14 +1650,14 @@ func (m hallModel) renderCast(msg chatMessage) string {
 	if bodyWidth < 20 {
 		bodyWidth = 20
 	}
-	linkified := linkifyURLs(msg.Body, bodyWidth)
-	wrapped := hardWrap(stripTrailingSpaces(lipgloss.NewStyle().Width(bodyWidth).Render(linkified)), bodyWidth)
-	lines := strings.Split(wrapped, "\n")
-	result := " " + castStyle.Render("✦") + " " + label + " " + grimVoiceStyle.Render(lines[0])
+	lines := renderMarkdown(msg.Body, grimVoiceStyle, bodyWidth, func(text string, style lipgloss.Style) []span {
+		return messageSpans(text, "", style, bodyWidth)
+	}, nil)
+	result := " " + castStyle.Render("✦") + " " + label + " " + lines[0]
 	if len(lines) > 1 {
 		indent := strings.Repeat(" ", prefixWidth)
 		for _, line := range lines[1:] {
-			result += "\n" + indent + grimVoiceStyle.Render(line)
+			result += "\n" + indent + line
 		}
 	}
 	return result
@@ -988,6 +1681,111 @@ func (m hallModel) renderJoin(msg chatMessage) string {
 	return line
 }
 
+// botBadge marks messages posted by bot accounts.
+func botBadge() string {
+	return botBadgeStyle.Render("[bot]")
+}
+
+// renderAnnouncement renders an announcement: sender and title on one line,
+// with the detail and link beneath. Only bot accounts get the badge.
+func (m hallModel) renderAnnouncement(msg chatMessage) string {
+	head := " "
+	if msg.IsBot {
+		head += botBadge() + " "
+	}
+	head += botBadgeStyle.Render(msg.SenderLogin) + chatSepStyle.Render(" · ") +
+		normalStyle.Render(truncStr(msg.metaTitle(), max(m.width-lipgloss.Width(msg.SenderLogin)-12, 20)))
+	lines := []string{head}
+	if body := msg.Metadata["body"]; body != "" {
+		for _, l := range wrapInputLines(body, max(m.width-6, 20)) {
+			lines = append(lines, "     "+chatTextStyle.Render(l))
+		}
+	}
+	if u := msg.Metadata["url"]; u != "" {
+		lines = append(lines, "     "+dimStyle.Render(truncStr(u, max(m.width-6, 20))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderCIResult renders a build result posted by `grimora ci notify`: a
+// pass/fail marker and title, then repo, ref, commit and the run link.
+func (m hallModel) renderCIResult(msg chatMessage) string {
+	var mark, label string
+	switch msg.Metadata["status"] {
+	case domain.CIStatusSuccess:
+		mark, label = upvoteStyle.Render("✔"), upvoteStyle.Render("passed")
+	case domain.CIStatusFailure:
+		mark, label = rejectStyle.Render("✘"), rejectStyle.Render("failed")
+	default:
+		mark, label = dimStyle.Render("○"), dimStyle.Render("cancelled")
+	}
+	sender := dimStyle.Render(msg.SenderLogin)
+	if msg.IsBot {
+		sender += " " + botBadge()
+	}
+	title := normalStyle.Render(truncStr(msg.metaTitle(), max(m.width-lipgloss.Width(msg.SenderLogin)-24, 20)))
+	lines := []string{" " + mark + " " + title + " " + label + chatSepStyle.Render(" · ") + sender}
+
+	var details []string
+	for _, k := range []string{"repo", "ref", "commit"} {
+		if v := msg.Metadata[k]; v != "" {
+			details = append(details, v)
+		}
+	}
+	if body := msg.Metadata["body"]; body != "" {
+		details = append(details, body)
+	}
+	if len(details) > 0 {
+		lines = append(lines, "   "+metaStyle.Render(truncStr(strings.Join(details, " · "), max(m.width-4, 20))))
+	}
+	if u := msg.Metadata["url"]; u != "" {
+		lines = append(lines, "   "+dimStyle.Render(truncStr(u, max(m.width-4, 20))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// replyExcerptLen caps the quoted text carried with a reply.
+const replyExcerptLen = 80
+
+// replyMetadata returns the metadata that marks a message as a reply to
+// target, or nil when not replying.
+func replyMetadata(target *chatMessage) map[string]string {
+	if target == nil {
+		return nil
+	}
+	return map[string]string{
+		"reply_to":      target.ID,
+		"reply_login":   target.SenderLogin,
+		"reply_excerpt": replyExcerpt(target.metaTitle()),
+	}
+}
+
+// replyExcerpt flattens body to a single line short enough to quote.
+func replyExcerpt(body string) string {
+	return truncStr(strings.Join(strings.Fields(body), " "), replyExcerptLen)
+}
+
+// renderReplyQuote renders the compact "↪ replying to @alice: …" line shown
+// above a reply, indented to line up with the body. It is empty for
+// messages that aren't replies.
+func (m hallModel) renderReplyQuote(msg chatMessage, indent int) string {
+	login := msg.Metadata["reply_login"]
+	if msg.Metadata["reply_to"] == "" || login == "" {
+		return ""
+	}
+	head := "↪ replying to @" + login + ": "
+	excerpt := truncStr(msg.Metadata["reply_excerpt"], max(m.width-indent-lipgloss.Width(head), 10))
+	return strings.Repeat(" ", indent) + metaStyle.Render(head+excerpt)
+}
+
+// renderReplyBanner renders the line above the input while composing a reply.
+func (m hallModel) renderReplyBanner() string {
+	head := " ↪ replying to @" + m.replyTo.SenderLogin + ": "
+	hint := " · esc to cancel"
+	excerpt := truncStr(replyExcerpt(m.replyTo.metaTitle()), max(m.width-lipgloss.Width(head)-lipgloss.Width(hint), 10))
+	return accentStyle.Render(head) + dimStyle.Render(excerpt+hint)
+}
+
 // renderLeave renders a departure notice when someone disconnects from the room.
 func (m hallModel) renderLeave(msg chatMessage) string {
 	label := leaveLabelStyle.Render("✧ a soul departs")
@@ -1013,59 +1811,25 @@ func (m hallModel) renderInput() string {
 	if m.myLogin == "" {
 		placeholder = "grimora login to chat"
 	}
-	return renderChatInput(m.myLogin, m.input, placeholder, m.inputFocused, m.animFrame, m.width)
-}
-
-// slashCommands defines the available slash commands and their descriptions.
-var slashCommands = []struct {
-	cmd  string
-	desc string
-}{
-	{"/build <title>", "start a build"},
-	{"/b <update>", "update a build"},
-	{"/ship <title>", "ship something"},
-	{"/seek <question>", "ask for help"},
+	return renderChatInput(m.myLogin, m.input, m.inputCursor, placeholder, m.inputFocused, m.animFrame, m.width)
 }
 
-// countSlashHints returns the number of slash hint lines that will be rendered.
-func (m hallModel) countSlashHints() int {
-	prefix := strings.TrimPrefix(m.input, "/")
-	n := 0
-	for _, sc := range slashCommands {
-		trimmedCmd := strings.TrimPrefix(sc.cmd, "/")
-		if prefix != "" && !strings.HasPrefix(trimmedCmd, prefix) {
-			continue
-		}
-		n++
-	}
-	return n
+// renderMentionPopup renders the autocomplete suggestion list above the input line.
+func (m hallModel) renderMentionPopup() string {
+	return renderLoginPopup(m.mentionMatches, m.mentionCursor)
 }
 
-// renderSlashHints renders slash command hints above the input when typing "/".
-func (m hallModel) renderSlashHints() string {
-	prefix := strings.TrimPrefix(m.input, "/")
-	var b strings.Builder
-	for _, sc := range slashCommands {
-		// Filter by prefix
-		trimmedCmd := strings.TrimPrefix(sc.cmd, "/")
-		if prefix != "" && !strings.HasPrefix(trimmedCmd, prefix) {
-			continue
-		}
-		b.WriteString("   " + accentStyle.Render(sc.cmd) + "  " + dimStyle.Render(sc.desc) + "\n")
-	}
-	return b.String()
-}
+// mentionPopupRows is the most logins a mention popup shows at once.
+const mentionPopupRows = 5
 
-// renderMentionPopup renders the autocomplete suggestion list above the input line.
-func (m hallModel) renderMentionPopup() string {
+// renderLoginPopup renders up to mentionPopupRows logins from matches, with
+// the one at cursor marked.
+func renderLoginPopup(matches []string, cursor int) string {
 	var b strings.Builder
-	limit := len(m.mentionMatches)
-	if limit > 5 {
-		limit = 5
-	}
+	limit := min(len(matches), mentionPopupRows)
 	for i := 0; i < limit; i++ {
-		login := m.mentionMatches[i]
-		if i == m.mentionCursor {
+		login := matches[i]
+		if i == cursor {
 			b.WriteString("   " + accentStyle.Render("▸ "+login))
 		} else {
 			b.WriteString("     " + dimStyle.Render(login))
@@ -1125,7 +1889,7 @@ func padLines(n int, b *strings.Builder) {
 // formatChatTime formats a message timestamp as a short wall-clock time (H:MM).
 // For messages older than today it shows "NdAgo" to save column space.
 func formatChatTime(t time.Time) string {
-	now := time.Now()
+	now := clock()
 	// Same calendar day.
 	y1, mo1, d1 := t.Date()
 	y2, mo2, d2 := now.Date()
@@ -1183,51 +1947,51 @@ func (m hallModel) filterLogins(query string) []string {
 	return matches
 }
 
-// renderBodyWithMentions highlights @mentions in message body text.
-// Self-mentions get extra bright styling. URL linkification is done earlier in the pipeline.
-// OSC 8 escape sequences are preserved — mentions inside hyperlinks are not styled.
-func renderBodyWithMentions(body, myLogin string, isSelf bool) string {
-	styleMention := func(s string) string {
-		return mentionRe.ReplaceAllStringFunc(s, func(match string) string {
-			login := match[1:] // strip leading @
-			if strings.EqualFold(login, myLogin) {
-				return mentionSelfStyle.Render(match)
-			}
-			return mentionStyle.Render(match)
-		})
+// messageSpans splits a message body into spans for wrapSpans: URLs become
+// OSC 8 hyperlinks, @mentions are highlighted (self-mentions brighter), and
+// the rest is drawn in base. A URL's display is cut to maxWidth with "…"
+// while the full URL stays the link target, so clicking still opens it.
+// An @ inside a URL is part of the link, not a mention.
+func messageSpans(body, myLogin string, base lipgloss.Style, maxWidth int) []span {
+	var spans []span
+	add := func(text string, style lipgloss.Style) {
+		if text != "" {
+			spans = append(spans, styled(text, style))
+		}
 	}
-	// Split around OSC 8 sequences so we don't corrupt them with mention styling.
-	parts := osc8Re.FindAllStringIndex(body, -1)
-	if len(parts) == 0 {
-		return styleMention(body)
+	mentions := func(text string) {
+		prev := 0
+		for _, loc := range mentionRe.FindAllStringIndex(text, -1) {
+			add(text[prev:loc[0]], base)
+			if strings.EqualFold(text[loc[0]+1:loc[1]], myLogin) {
+				add(text[loc[0]:loc[1]], mentionSelfStyle)
+			} else {
+				add(text[loc[0]:loc[1]], mentionStyle)
+			}
+			prev = loc[1]
+		}
+		add(text[prev:], base)
 	}
-	var b strings.Builder
 	prev := 0
-	for _, loc := range parts {
-		b.WriteString(styleMention(body[prev:loc[0]]))
-		b.WriteString(body[loc[0]:loc[1]])
+	for _, loc := range urlRe.FindAllStringIndex(body, -1) {
+		mentions(body[prev:loc[0]])
+		spans = append(spans, linkSpan(body[loc[0]:loc[1]], base, maxWidth))
 		prev = loc[1]
 	}
-	b.WriteString(styleMention(body[prev:]))
-	return b.String()
+	mentions(body[prev:])
+	return spans
 }
 
-// linkifyURLs replaces URLs in body with OSC 8 hyperlinks.
-// If a URL's display text would exceed maxWidth, the display is truncated with "…"
-// while the full URL remains the OSC 8 target (so clicking still opens it).
-func linkifyURLs(body string, maxWidth int) string {
-	if maxWidth <= 0 {
-		return body
+// linkSpan is rawURL as an OSC 8 hyperlink drawn in style, its display cut
+// to maxWidth when that is positive.
+func linkSpan(rawURL string, style lipgloss.Style, maxWidth int) span {
+	display := rawURL
+	if maxWidth > 0 {
+		display = truncStr(rawURL, maxWidth)
 	}
-	return urlRe.ReplaceAllStringFunc(body, func(rawURL string) string {
-		runes := []rune(rawURL)
-		if len(runes) <= maxWidth {
-			return "\033]8;;" + rawURL + "\a" + rawURL + "\033]8;;\a"
-		}
-		// Truncate display text to maxWidth-1 runes + ellipsis.
-		display := string(runes[:maxWidth-1]) + "…"
-		return "\033]8;;" + rawURL + "\a" + display + "\033]8;;\a"
-	})
+	return span{text: display, render: func(s string) string {
+		return "\033]8;;" + rawURL + "\a" + style.Render(s) + "\033]8;;\a"
+	}}
 }
 
 // stripTrailingSpaces removes trailing spaces from each line.
@@ -1239,34 +2003,3 @@ func stripTrailingSpaces(s string) string {
 	}
 	return strings.Join(lines, "\n")
 }
-
-// hardWrap scans each line and hard-breaks any that exceed width at the rune boundary.
-// This handles long tokens (like URLs) that lipgloss word-wrap can't break.
-func hardWrap(s string, width int) string {
-	if width <= 0 {
-		return s
-	}
-	lines := strings.Split(s, "\n")
-	var result []string
-	for _, line := range lines {
-		if lipgloss.Width(line) <= width {
-			result = append(result, line)
-			continue
-		}
-		// Hard-break at rune boundaries.
-		runes := []rune(line)
-		for len(runes) > 0 {
-			// Find how many runes fit in width.
-			end := len(runes)
-			for end > 0 && lipgloss.Width(string(runes[:end])) > width {
-				end--
-			}
-			if end == 0 {
-				end = 1 // at least one rune per line to avoid infinite loop
-			}
-			result = append(result, string(runes[:end]))
-			runes = runes[end:]
-		}
-	}
-	return strings.Join(result, "\n")
-}
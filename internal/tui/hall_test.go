@@ -1,14 +1,19 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/google/uuid"
 
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
@@ -387,6 +392,27 @@ func TestHallViewLineCount(t *testing.T) {
 			},
 			wantLen: 24,
 		},
+		{
+			name: "room panel open with topic",
+			setup: func(m *hallModel) {
+				m.myLogin = "naveenspark"
+				m.connected = true
+				m.roomList = []domain.Room{
+					{Slug: hallSlug, Name: "The Hall", Topic: "ship week"},
+					{Slug: "go-tips", Description: "small Go tricks"},
+				}
+				m.rooms = roomPanel{open: true, err: "press a again to archive #go-tips"}
+				for i := 0; i < 5; i++ {
+					id := fmt.Sprintf("msg-%d", i)
+					m.seenIDs[id] = true
+					m.messages = append(m.messages, chatMessage{
+						ID: id, SenderLogin: "alice", Body: "hi",
+						Kind: "message", CreatedAt: time.Now(),
+					})
+				}
+			},
+			wantLen: 24,
+		},
 	}
 
 	for _, tc := range tests {
@@ -514,72 +540,82 @@ func TestHardWrapShortLinesUnchanged(t *testing.T) {
 	}
 }
 
-func TestRenderBodyWithMentionsNoLinkification(t *testing.T) {
-	// Linkification is now done in linkifyURLs, not renderBodyWithMentions.
-	body := "check https://grimora.ai for details"
-	result := renderBodyWithMentions(body, "me", false)
-	if strings.Contains(result, "\033]8;;") {
-		t.Errorf("renderBodyWithMentions should not add OSC 8 links, got: %q", result)
-	}
-	if !strings.Contains(result, "https://grimora.ai") {
-		t.Errorf("expected URL preserved verbatim, got: %q", result)
-	}
+// spanText renders spans without wrapping them.
+func spanText(spans []span) string {
+	return strings.Join(wrapSpans(spans, 0), "\n")
 }
 
-func TestLinkifyURLsShortURL(t *testing.T) {
-	body := "check https://grimora.ai for details"
-	result := linkifyURLs(body, 80)
+func TestMessageSpansShortURL(t *testing.T) {
+	result := spanText(messageSpans("check https://grimora.ai for details", "me", chatTextStyle, 80))
 	// Short URL should be fully visible with OSC 8.
 	if !strings.Contains(result, "\033]8;;https://grimora.ai\a") {
 		t.Errorf("expected OSC 8 target, got: %q", result)
 	}
-	if !strings.Contains(result, "https://grimora.ai\033]8;;\a") {
+	if !strings.Contains(ansi.Strip(result), "check https://grimora.ai for details") {
 		t.Errorf("expected full URL as display text, got: %q", result)
 	}
 }
 
-func TestLinkifyURLsTruncatesLongURL(t *testing.T) {
+func TestMessageSpansTruncatesLongURL(t *testing.T) {
 	url := "https://github.com/naveenspark/grimora/releases/tag/v0.4.0-with-extra-long-path"
-	body := "see " + url
-	result := linkifyURLs(body, 30)
+	result := spanText(messageSpans("see "+url, "me", chatTextStyle, 30))
 	// Full URL should be in OSC 8 target.
 	if !strings.Contains(result, "\033]8;;"+url+"\a") {
 		t.Errorf("expected full URL in OSC 8 target, got: %q", result)
 	}
 	// Display text should be truncated with ellipsis.
-	if !strings.Contains(result, "…") {
-		t.Errorf("expected ellipsis in truncated display, got: %q", result)
+	display := ansi.Strip(result)
+	if !strings.Contains(display, "…") {
+		t.Errorf("expected ellipsis in truncated display, got: %q", display)
 	}
-	// Display text should NOT contain the full URL.
-	if strings.Contains(result, url+"\033]8;;\a") {
-		t.Errorf("display text should be truncated, not full URL, got: %q", result)
+	if strings.Contains(display, url) {
+		t.Errorf("display text should be truncated, not full URL, got: %q", display)
 	}
 }
 
-func TestStripTrailingSpaces(t *testing.T) {
-	input := "hello   \nworld  \nfoo"
-	want := "hello\nworld\nfoo"
-	got := stripTrailingSpaces(input)
-	if got != want {
-		t.Errorf("stripTrailingSpaces(%q) = %q, want %q", input, got, want)
+func TestMessageSpansZeroWidthKeepsURL(t *testing.T) {
+	url := "https://github.com/naveenspark/grimora/releases/tag/v0.4.0-with-extra-long-path"
+	if got := ansi.Strip(spanText(messageSpans("see "+url, "me", chatTextStyle, 0))); got != "see "+url {
+		t.Errorf("with no width the URL should be shown whole, got: %q", got)
 	}
 }
 
-func TestLinkifyURLsZeroWidth(t *testing.T) {
-	body := "see https://grimora.ai"
-	got := linkifyURLs(body, 0)
-	if got != body {
-		t.Errorf("linkifyURLs with maxWidth=0 should return body unchanged, got: %q", got)
+func TestMessageSpansMentions(t *testing.T) {
+	spans := messageSpans("hey @Me and @ada", "me", chatTextStyle, 80)
+	var mentions []string
+	for _, sp := range spans {
+		if strings.HasPrefix(sp.text, "@") {
+			mentions = append(mentions, sp.text)
+		}
+	}
+	if strings.Join(mentions, ",") != "@Me,@ada" {
+		t.Errorf("mention spans = %q", mentions)
+	}
+	if got := ansi.Strip(spanText(spans)); got != "hey @Me and @ada" {
+		t.Errorf("text = %q", got)
 	}
 }
 
-func TestMentionInsideOSC8Preserved(t *testing.T) {
+func TestMentionInsideURLIsNotStyled(t *testing.T) {
 	// A URL with @ should not have its @-part styled as a mention.
-	linkified := linkifyURLs("connect via https://user@host.example.com/path ok", 80)
-	result := renderBodyWithMentions(linkified, "me", false)
-	// The OSC 8 target must be intact (not corrupted by mention styling).
-	if !strings.Contains(result, "\033]8;;https://user@host.example.com/path\a") {
-		t.Errorf("OSC 8 target corrupted by mention styling: %q", result)
+	spans := messageSpans("connect via https://user@host.example.com/path ok", "me", chatTextStyle, 80)
+	for _, sp := range spans {
+		if sp.text == "@host" {
+			t.Errorf("@ inside a URL became a mention span: %+v", spans)
+		}
+	}
+	// The OSC 8 target must be intact.
+	if result := spanText(spans); !strings.Contains(result, "\033]8;;https://user@host.example.com/path\a") {
+		t.Errorf("OSC 8 target corrupted: %q", result)
+	}
+}
+
+func TestStripTrailingSpaces(t *testing.T) {
+	input := "hello   \nworld  \nfoo"
+	want := "hello\nworld\nfoo"
+	got := stripTrailingSpaces(input)
+	if got != want {
+		t.Errorf("stripTrailingSpaces(%q) = %q, want %q", input, got, want)
 	}
 }
 
@@ -608,7 +644,389 @@ func TestLongURLMessageTruncatesDisplay(t *testing.T) {
 	}
 }
 
+func TestUnknownKindWithoutBodyFallsBack(t *testing.T) {
+	m := newTestHallModel()
+	m.width = 80
+	rendered := m.renderMessage(chatMessage{ID: "p-1", SenderLogin: "ada", Kind: "poll", CreatedAt: time.Now()})
+	if !strings.Contains(rendered, "sent a poll this version of grimora can't show") {
+		t.Errorf("expected a fallback line for an unknown kind, got: %q", rendered)
+	}
+}
+
 // testErr is a simple error type for tests.
 type testErr struct{ msg string }
 
 func (e *testErr) Error() string { return e.msg }
+
+func newTestHallSelectModel(bodies ...string) hallModel {
+	m := newTestHallModel()
+	m.myLogin = "user"
+	m.connected = true
+	m.inputFocused = false
+	for i, body := range bodies {
+		id := uuid.New().String()
+		m.seenIDs[id] = true
+		m.messages = append(m.messages, chatMessage{
+			ID:          id,
+			SenderLogin: "other",
+			Body:        body,
+			CreatedAt:   time.Now().Add(time.Duration(i) * time.Second),
+		})
+	}
+	return m
+}
+
+func TestHallSelectModeStartsAtNewest(t *testing.T) {
+	m := newTestHallSelectModel("first", "second", "third")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if !m.selecting {
+		t.Fatal("expected selection mode after 'v'")
+	}
+	if m.selectedID != m.messages[2].ID {
+		t.Errorf("expected newest message selected")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if m.selectedID != m.messages[1].ID {
+		t.Errorf("expected 'k' to select the previous message")
+	}
+	if !strings.Contains(m.View(), "▸") {
+		t.Error("expected selection marker in view")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.selecting || m.selectedID != "" {
+		t.Error("expected esc to leave selection mode")
+	}
+}
+
+func TestHallSelectSkipsSystemMessages(t *testing.T) {
+	m := newTestHallSelectModel("first")
+	m.messages = append(m.messages, chatMessage{IsSystem: true, Body: "bob joined"})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if m.selectedID != m.messages[0].ID {
+		t.Error("expected system message to be skipped when selecting")
+	}
+}
+
+func TestHallOpenSingleLink(t *testing.T) {
+	m := newTestHallSelectModel("look at https://grimora.ai/spells/1")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if cmd == nil {
+		t.Error("expected open command for single link")
+	}
+	if m.picker.active() {
+		t.Error("single link should not open the picker")
+	}
+}
+
+func TestHallOpenNoLinks(t *testing.T) {
+	m := newTestHallSelectModel("just words")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if cmd != nil {
+		t.Error("expected no command without links")
+	}
+	if !strings.Contains(m.status, "no links") {
+		t.Errorf("expected 'no links' status, got %q", m.status)
+	}
+}
+
+func TestHallLinkPickerForMultipleLinks(t *testing.T) {
+	m := newTestHallSelectModel("https://a.dev and https://b.dev")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if !m.picker.active() {
+		t.Fatal("expected link picker for multiple links")
+	}
+	if !strings.Contains(m.View(), "https://b.dev") {
+		t.Errorf("expected picker to list links, got:\n%s", m.View())
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if cmd == nil {
+		t.Error("expected open command after picking a link")
+	}
+	if m.picker.active() {
+		t.Error("expected picker closed after pick")
+	}
+	if !m.selecting {
+		t.Error("expected to stay in selection mode after pick")
+	}
+}
+
+func TestHallNewMessagesPillWhileScrolledUp(t *testing.T) {
+	m := newTestHallModel()
+	history := make([]domain.RoomMessage, 30)
+	for i := range history {
+		history[i] = makeTestRoomMessage("alice", "loomari", fmt.Sprintf("history %02d", i))
+		history[i].CreatedAt = time.Now().Add(-time.Duration(60-i) * time.Minute)
+	}
+	m, _ = m.Update(hallMessagesMsg{messages: history})
+	m.scroll = 5
+	before := m.scroll
+
+	incoming := []domain.RoomMessage{
+		makeTestRoomMessage("bob", "cipher", "fresh one"),
+		makeTestRoomMessage("carol", "cipher", "fresh two"),
+	}
+	m, _ = m.Update(hallMessagesMsg{messages: incoming})
+	if m.newBelow != 2 {
+		t.Fatalf("newBelow = %d, want 2", m.newBelow)
+	}
+	if m.scroll <= before {
+		t.Errorf("expected scroll to grow to hold position, got %d (was %d)", m.scroll, before)
+	}
+	view := m.View()
+	if !strings.Contains(view, "▼ 2 new messages") {
+		t.Errorf("expected new messages pill, got:\n%s", view)
+	}
+	if strings.Contains(view, "fresh two") {
+		t.Error("new messages should stay below the viewport until jumping")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.scroll != 0 || m.newBelow != 0 {
+		t.Errorf("expected enter to jump to bottom, got scroll=%d newBelow=%d", m.scroll, m.newBelow)
+	}
+	if !strings.Contains(m.View(), "fresh two") {
+		t.Error("expected newest message visible after jumping")
+	}
+}
+
+func TestHallNoPillAtBottom(t *testing.T) {
+	m := newTestHallModel()
+	m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{makeTestRoomMessage("alice", "loomari", "first")}})
+	m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{makeTestRoomMessage("bob", "cipher", "second")}})
+	if m.newBelow != 0 {
+		t.Errorf("newBelow = %d at bottom, want 0", m.newBelow)
+	}
+	if strings.Contains(m.View(), "new message") {
+		t.Error("pill should not show when following the live tail")
+	}
+}
+
+func TestHallBotMessageShowsBadge(t *testing.T) {
+	m := newTestHallModel()
+	raw := makeTestRoomMessage("deploybot", "", "deploy finished")
+	raw.SenderIsBot = true
+	m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{
+		raw,
+		makeTestRoomMessage("alice", "loomari", "nice"),
+	}})
+
+	lines, starts := m.messageLines()
+	if !strings.Contains(lines[starts[0]], "[bot]") {
+		t.Errorf("expected [bot] badge on bot message, got %q", lines[starts[0]])
+	}
+	if strings.Contains(lines[starts[1]], "[bot]") {
+		t.Errorf("human message should not carry a badge, got %q", lines[starts[1]])
+	}
+}
+
+func TestHallAnnouncementCard(t *testing.T) {
+	m := newTestHallModel()
+	raw := makeTestRoomMessage("shipbot", "", "api v1.2 shipped · release notes · https://example.com/v1.2")
+	raw.SenderIsBot = true
+	raw.Kind = domain.MessageKindAnnounce
+	raw.Metadata = []byte(`{"title":"api v1.2 shipped","body":"release notes","url":"https://example.com/v1.2"}`)
+	m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{raw}})
+
+	view := m.View()
+	for _, want := range []string{"[bot]", "shipbot", "api v1.2 shipped", "release notes", "https://example.com/v1.2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in announcement, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestHallAnnouncementFromHumanHasNoBadge(t *testing.T) {
+	m := newTestHallModel()
+	raw := makeTestRoomMessage("alice", "loomari", "api v1.2 shipped")
+	raw.Kind = domain.MessageKindAnnounce
+	raw.Metadata = []byte(`{"title":"api v1.2 shipped"}`)
+	m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{raw}})
+
+	if view := m.View(); !strings.Contains(view, "api v1.2 shipped") || strings.Contains(view, "[bot]") {
+		t.Errorf("expected the announcement without a [bot] badge, got:\n%s", view)
+	}
+}
+
+func TestHallCIResultCard(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{domain.CIStatusSuccess, "passed"},
+		{domain.CIStatusFailure, "failed"},
+		{domain.CIStatusCancelled, "cancelled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			m := newTestHallModel()
+			raw := makeTestRoomMessage("ci", "", "api v1.2")
+			raw.SenderIsBot = true
+			raw.Kind = domain.MessageKindCI
+			raw.Metadata = []byte(`{"status":"` + tt.status + `","title":"api v1.2","repo":"me/api","ref":"main","commit":"0123456","url":"https://github.com/me/api/actions/runs/42"}`)
+			m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{raw}})
+
+			view := m.View()
+			for _, want := range []string{"api v1.2", tt.want, "[bot]", "me/api · main · 0123456", "actions/runs/42"} {
+				if !strings.Contains(view, want) {
+					t.Errorf("expected %q in CI card, got:\n%s", want, view)
+				}
+			}
+		})
+	}
+}
+
+func TestHallReplyFromSelection(t *testing.T) {
+	m := newTestHallSelectModel("first", "what's the best way to cache embeddings?")
+	m.myLogin = "me"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if m.replyTo == nil || m.replyTo.ID != m.messages[1].ID {
+		t.Fatal("expected 'r' to start a reply to the selected message")
+	}
+	if m.selecting || !m.inputFocused {
+		t.Error("expected reply to leave selection and focus the input")
+	}
+	if !strings.Contains(m.View(), "replying to @other") {
+		t.Errorf("expected reply banner in view:\n%s", m.View())
+	}
+
+	meta := replyMetadata(m.replyTo)
+	if meta["reply_to"] != m.messages[1].ID || meta["reply_login"] != "other" || meta["reply_excerpt"] != "what's the best way to cache embeddings?" {
+		t.Errorf("reply metadata = %v", meta)
+	}
+
+	// Esc cancels the reply but keeps the input focused.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.replyTo != nil || !m.inputFocused {
+		t.Error("expected esc to cancel the reply only")
+	}
+}
+
+func TestHallReplySendClearsTarget(t *testing.T) {
+	m := newTestHallSelectModel("first")
+	m.myLogin = "me"
+	m.client = client.New("http://unused.invalid", "")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m.input = "agreed"
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a send command")
+	}
+	if m.replyTo != nil {
+		t.Error("expected reply target cleared after sending")
+	}
+}
+
+func TestHallRendersReplyQuote(t *testing.T) {
+	m := newTestHallModel()
+	raw := makeTestRoomMessage("bob", "cipher", "use a sqlite cache")
+	raw.Metadata = []byte(`{"reply_to":"abc","reply_login":"alice","reply_excerpt":"best way to cache embeddings?"}`)
+	m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{raw}})
+
+	lines, starts := m.messageLines()
+	if !strings.Contains(lines[starts[0]], "↪ replying to @alice: best way to cache embeddings?") {
+		t.Errorf("expected reply quote above body, got %q", lines[starts[0]])
+	}
+	if !strings.Contains(lines[starts[0]+1], "use a sqlite cache") {
+		t.Errorf("expected body after quote, got %q", lines[starts[0]+1])
+	}
+}
+
+func TestHallReactionsFetchOnlyNewAndVisibleMessages(t *testing.T) {
+	f := &clienttest.Fake{}
+	m := newHallModel(f)
+	m.width, m.height = 80, 24
+
+	base := time.Now().Add(-time.Hour)
+	var history []domain.RoomMessage
+	for i := range 60 {
+		msg := makeTestRoomMessage("alice", "loomari", fmt.Sprintf("message %d", i))
+		msg.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		history = append(history, msg)
+	}
+	m, _ = m.Update(hallMessagesMsg{room: hallSlug, messages: history})
+	if !m.reactionsBusy {
+		t.Fatal("expected the first poll to fetch reactions")
+	}
+
+	// The next poll lands while that fetch is still out: its work queues.
+	fresh := makeTestRoomMessage("bob", "cipher", "just in")
+	m, _ = m.Update(hallMessagesMsg{room: hallSlug, messages: append(history, fresh)})
+	if !m.reactionsDue[fresh.ID.String()] {
+		t.Error("expected the new message to be queued for reactions")
+	}
+	if m.reactionsDue[history[0].ID.String()] {
+		t.Error("expected the oldest, off-screen message not to be queued")
+	}
+
+	// The first fetch returning sends the queue as one batch.
+	m, cmd := m.Update(hallReactionsMsg{room: hallSlug})
+	if cmd == nil {
+		t.Fatal("expected the queued reactions to be fetched")
+	}
+	got := cmd().(hallReactionsMsg)
+	if len(got.ids) == 0 || len(got.ids) > m.viewportHeight() || !slices.Contains(got.ids, fresh.ID.String()) {
+		t.Errorf("fetched reactions for %d messages %v, want the new and visible ones", len(got.ids), got.ids)
+	}
+	if f.Count("GetReactionCounts") != 1 {
+		t.Errorf("GetReactionCounts called %d times, want 1", f.Count("GetReactionCounts"))
+	}
+}
+
+func TestHallReactedFetchesThatMessage(t *testing.T) {
+	f := &clienttest.Fake{Reactions: map[string][]client.ReactionCount{"msg-1": {{Emoji: "🔥", Count: 2}}}}
+	m := newHallModel(f)
+	m.width, m.height = 80, 24
+	m.messages = []chatMessage{
+		{ID: "msg-1", SenderLogin: "alice", Body: "Hello!", CreatedAt: time.Now()},
+		{ID: "msg-2", SenderLogin: "bob", Body: "Hi!", CreatedAt: time.Now()},
+	}
+
+	m, cmd := m.Update(hallReactedMsg{id: "msg-1"})
+	if cmd == nil {
+		t.Fatal("expected a reaction fetch")
+	}
+	got := cmd().(hallReactionsMsg)
+	if !slices.Equal(got.ids, []string{"msg-1"}) {
+		t.Errorf("fetched %v, want only the message reacted to", got.ids)
+	}
+	m, _ = m.Update(got)
+	if m.reactionsBusy || len(m.messages[0].Reactions) != 1 || m.messages[0].Reactions[0].Count != 2 {
+		t.Errorf("reactions = %+v, busy = %v", m.messages[0].Reactions, m.reactionsBusy)
+	}
+}
+
+func TestHallReactionsResult(t *testing.T) {
+	m := newTestHallModel()
+	m.messages = []chatMessage{
+		{ID: "msg-1", Reactions: []reactionCount{{Emoji: "🔥", Count: 1}}},
+		{ID: "msg-2", Reactions: []reactionCount{{Emoji: "✨", Count: 1}}},
+	}
+
+	// A requested message missing from the result has lost its reactions;
+	// ones that weren't requested keep theirs.
+	m.reactionsBusy = true
+	m, _ = m.Update(hallReactionsMsg{room: hallSlug, ids: []string{"msg-1"}, reactions: map[string][]reactionCount{}})
+	if m.messages[0].Reactions != nil || len(m.messages[1].Reactions) != 1 || m.reactionsBusy {
+		t.Errorf("messages = %+v, busy = %v", m.messages, m.reactionsBusy)
+	}
+
+	// A failed fetch is retried with the next poll.
+	m, _ = m.Update(hallReactionsMsg{room: hallSlug, ids: []string{"msg-2"}, err: errors.New("boom")})
+	if !m.reactionsDue["msg-2"] {
+		t.Error("expected a failed fetch to be queued again")
+	}
+
+	// Counts for a room we've left are dropped.
+	m, _ = m.Update(hallReactionsMsg{room: "go-help", ids: []string{"msg-2"}, reactions: map[string][]reactionCount{}})
+	if len(m.messages[1].Reactions) != 1 {
+		t.Error("expected reactions from another room to be ignored")
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"strings"
 	"time"
-	"unicode/utf8"
 )
 
+// clock is the time views render relative timestamps against. Golden tests
+// pin it so frames don't change with the hour they run.
+var clock = time.Now
+
 // formatTime renders a relative timestamp for stream/workshop displays.
 func formatTime(t time.Time) string {
-	d := time.Since(t)
+	d := clock().Sub(t)
 	switch {
 	case d < time.Minute:
 		return "just now"
@@ -22,15 +25,6 @@ func formatTime(t time.Time) string {
 	}
 }
 
-// truncStr truncates a string to maxLen runes, appending an ellipsis if needed.
-func truncStr(s string, maxLen int) string {
-	if utf8.RuneCountInString(s) <= maxLen {
-		return s
-	}
-	runes := []rune(s)
-	return string(runes[:maxLen-1]) + "\u2026"
-}
-
 // cleanTitle strips markdown headers and collapses whitespace from a spell title
 // so stream entries show meaningful content instead of "# Header Name".
 func cleanTitle(raw string) string {
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+)
+
+// newSizedTestApp returns an App backed by f at width by height, out of the
+// Hall's input so global keys work.
+func newSizedTestApp(f *clienttest.Fake, width, height int) App {
+	a := NewApp(f, "dev")
+	model, _ := a.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	a = model.(App)
+	a.hall.inputFocused = false // global keys don't fire while typing
+	return a
+}
+
+// openViewTestApp opens the view key leads to on an App backed by f, with
+// whatever it loads already in.
+func openViewTestApp(t *testing.T, f *clienttest.Fake, key string, want view) App {
+	t.Helper()
+	a := pressAppKey(t, newSizedTestApp(f, 100, 30), key)
+	if a.view != want {
+		t.Fatalf("view = %v, want %v", a.view, want)
+	}
+	return a
+}
+
+// pressAppKey presses key and feeds the resulting messages back into the
+// App until they stop producing commands.
+func pressAppKey(t *testing.T, a App, key string) App {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == "enter" {
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	model, cmd := a.Update(msg)
+	a = model.(App)
+	for pending := drainCmd(cmd); len(pending) > 0; pending = pending[1:] {
+		model, cmd = a.Update(pending[0])
+		a = model.(App)
+		pending = append(pending, drainCmd(cmd)...)
+	}
+	return a
+}
+
+// withConfig applies cfg for the duration of a test, then puts back every
+// setting ApplyConfig changes.
+func withConfig(t *testing.T, cfg config.Config) {
+	t.Helper()
+	blink, highVis, bell, flash := cursorBlinkInterval, cursorHighVisibility, alertBell, alertFlash
+	accessible, low, joinLeave, size := accessibleMode, lowBandwidth, showJoinLeave, pageSize
+	hall, threads, lock, lockHash := hallPollInterval, threadsPollInterval, lockAfter, lockPassphraseHash
+	away, quiet, onEvent, clone, repo := awayAfter, quietHours, eventHooks, cloneDir, publishRepo
+	ascii, emoji, profile := asciiGlyphs, emojiEmblems, lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		cursorBlinkInterval, cursorHighVisibility, alertBell, alertFlash = blink, highVis, bell, flash
+		accessibleMode, lowBandwidth, showJoinLeave, pageSize = accessible, low, joinLeave, size
+		hallPollInterval, threadsPollInterval, lockAfter, lockPassphraseHash = hall, threads, lock, lockHash
+		awayAfter, quietHours, eventHooks, cloneDir, publishRepo = away, quiet, onEvent, clone, repo
+		asciiGlyphs, emojiEmblems = ascii, emoji
+		lipgloss.SetColorProfile(profile)
+	})
+	ApplyConfig(cfg)
+}
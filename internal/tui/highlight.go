@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+// Syntax colors for highlighted code, on the code background. They come
+// from the palette, so they follow the terminal's color profile like the
+// rest of the TUI.
+var (
+	codeKeywordStyle  = codeStyle.Foreground(paletteColor("#c084e0"))
+	codeTypeStyle     = codeStyle.Foreground(paletteColor("#7aa2f7"))
+	codeFunctionStyle = codeStyle.Foreground(paletteColor("#60a0e0"))
+	codeStringStyle   = codeStyle.Foreground(paletteColor("#86efac"))
+	codeNumberStyle   = codeStyle.Foreground(paletteColor("#f0944a"))
+	codeCommentStyle  = codeStyle.Foreground(paletteColor("#606878")).Italic(true)
+	codeSymbolStyle   = codeStyle.Foreground(paletteColor("#8890a0"))
+)
+
+// spellHighlighter highlights the code blocks in a spell: each in the
+// language its fence names, or else the first language in the spell's
+// stack. Below 256 colors the token colors blur into one another and the
+// code background, so blocks stay plain there.
+func spellHighlighter(stack []string, profile termenv.Profile) mdCode {
+	if profile > termenv.ANSI256 {
+		return nil
+	}
+	return func(lang string, code []string, width int) []string {
+		lexer := codeLexer(lang, stack)
+		if lexer == nil {
+			return nil
+		}
+		return highlightLines(lexer, code, width)
+	}
+}
+
+// codeLexer finds the lexer for a code block. A language on the fence wins,
+// even one chroma doesn't know; stack entries it doesn't know, like
+// kubernetes or aws, are skipped.
+func codeLexer(lang string, stack []string) chroma.Lexer {
+	if lang != "" {
+		return lexers.Get(lang)
+	}
+	for _, s := range stack {
+		if lexer := lexers.Get(strings.TrimSpace(s)); lexer != nil {
+			return lexer
+		}
+	}
+	return nil
+}
+
+// highlightLines is codeBlockLines with each token in its syntax color. It
+// returns nil when the code can't be tokenised, so the block stays plain.
+func highlightLines(lexer chroma.Lexer, code []string, width int) []string {
+	it, err := chroma.Coalesce(lexer).Tokenise(nil, strings.Join(code, "\n")+"\n")
+	if err != nil {
+		return nil
+	}
+	tokens := chroma.SplitTokensIntoLines(it.Tokens())
+	if len(tokens) < len(code) {
+		return nil
+	}
+	width = max(width-2, 10)
+	var lines []string
+	for _, line := range tokens[:len(code)] {
+		lines = append(lines, wrapTokens(line, width)...)
+	}
+	widest := 0
+	for _, l := range lines {
+		widest = max(widest, textWidth(l))
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = "  " + l + codeStyle.Render(strings.Repeat(" ", widest-textWidth(l)))
+	}
+	return out
+}
+
+// wrapTokens draws one line of code, broken every width cells. Tokens are
+// split where they cross a break so each piece keeps its color.
+func wrapTokens(tokens []chroma.Token, width int) []string {
+	var lines []string
+	var line strings.Builder
+	used := 0
+	for _, tok := range tokens {
+		style := tokenStyle(tok.Type)
+		text := strings.TrimSuffix(tok.Value, "\n")
+		for text != "" {
+			part := ansi.Truncate(text, width-used, "")
+			if part == "" && used > 0 {
+				lines = append(lines, line.String())
+				line.Reset()
+				used = 0
+				continue
+			}
+			if part == "" {
+				// Wider than a whole line; let it overflow.
+				_, n := utf8.DecodeRuneInString(text)
+				part = text[:n]
+			}
+			line.WriteString(style.Render(part))
+			used += textWidth(part)
+			text = text[len(part):]
+		}
+	}
+	return append(lines, line.String())
+}
+
+// tokenStyle picks the color for a token. Names, plain text and anything
+// unusual keep the code foreground.
+func tokenStyle(t chroma.TokenType) lipgloss.Style {
+	switch {
+	case t.InCategory(chroma.Comment):
+		return codeCommentStyle
+	case t == chroma.KeywordType || t == chroma.NameBuiltin || t == chroma.NameClass:
+		return codeTypeStyle
+	case t.InCategory(chroma.Keyword) || t == chroma.OperatorWord:
+		return codeKeywordStyle
+	case t == chroma.NameFunction || t == chroma.NameFunctionMagic:
+		return codeFunctionStyle
+	case t.InSubCategory(chroma.LiteralString):
+		return codeStringStyle
+	case t.InSubCategory(chroma.LiteralNumber):
+		return codeNumberStyle
+	case t.InCategory(chroma.Operator) || t.InCategory(chroma.Punctuation):
+		return codeSymbolStyle
+	}
+	return codeStyle
+}
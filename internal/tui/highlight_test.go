@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func TestCodeLexer(t *testing.T) {
+	for _, tc := range []struct {
+		lang  string
+		stack []string
+		want  string
+	}{
+		{"python", []string{"go"}, "Python"},
+		{"", []string{"kubernetes", "sql", "go"}, "SQL"},
+		{"", []string{"Go"}, "Go"},
+		{"", []string{"kubernetes"}, ""},
+		{"nosuchlang", []string{"go"}, ""},
+	} {
+		got := ""
+		if lexer := codeLexer(tc.lang, tc.stack); lexer != nil {
+			got = lexer.Config().Name
+		}
+		if got != tc.want {
+			t.Errorf("codeLexer(%q, %q) = %q, want %q", tc.lang, tc.stack, got, tc.want)
+		}
+	}
+}
+
+func TestSpellHighlighter(t *testing.T) {
+	for _, p := range []termenv.Profile{termenv.ANSI, termenv.Ascii} {
+		if spellHighlighter([]string{"go"}, p) != nil {
+			t.Errorf("profile %v: want plain code blocks", p)
+		}
+	}
+
+	code := []string{"// retry " + strings.Repeat("x", 30), "for i := range 3 {", "    call(\"ok\")", "}"}
+	highlight := spellHighlighter([]string{"go"}, termenv.TrueColor)
+	got := highlight("", code, 20)
+	want := codeBlockLines(code, 20)
+	if len(got) != len(want) {
+		t.Fatalf("highlighted %d lines, want %d like the plain block:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range got {
+		if ansi.Strip(got[i]) != ansi.Strip(want[i]) {
+			t.Errorf("line %d = %q, want %q", i, ansi.Strip(got[i]), ansi.Strip(want[i]))
+		}
+	}
+	if highlight("", code, 20) == nil || highlight("kubernetes", code, 20) != nil {
+		t.Error("want Go highlighted and an unknown fence language left plain")
+	}
+}
+
+func TestTokenStyle(t *testing.T) {
+	for tok, want := range map[chroma.TokenType]string{
+		chroma.Keyword:              "#c084e0",
+		chroma.KeywordType:          "#7aa2f7",
+		chroma.NameFunction:         "#60a0e0",
+		chroma.LiteralStringDouble:  "#86efac",
+		chroma.LiteralNumberInteger: "#f0944a",
+		chroma.CommentSingle:        "#606878",
+		chroma.Name:                 "#c0c4d0",
+	} {
+		if got := tokenStyle(tok).GetForeground(); got != paletteColor(want) {
+			t.Errorf("tokenStyle(%v) = %v, want %s", tok, got, want)
+		}
+	}
+}
+
+func TestRenderMarkdownPassesFenceLanguage(t *testing.T) {
+	var langs []string
+	code := func(lang string, code []string, width int) []string {
+		langs = append(langs, lang)
+		if lang == "go" {
+			return []string{"highlighted"}
+		}
+		return nil
+	}
+	got := renderMarkdown("```go main\nx := 1\n```\n```\nplain\n```", normalStyle, 40, nil, code)
+	if strings.Join(langs, ",") != "go," || got[0] != "highlighted" || ansi.Strip(got[1]) != "  plain" {
+		t.Errorf("langs = %q, lines = %q", langs, got)
+	}
+}
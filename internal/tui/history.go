@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/naveenspark/grimora/internal/state"
+)
+
+// maxInputHistory is how many sent messages each input remembers.
+const maxInputHistory = 100
+
+// inputHistory is what's been sent from an input, oldest first, for
+// recalling with ↑ and ↓ like a shell. Slash commands count too.
+type inputHistory struct {
+	entries []string
+	pos     int // entry being recalled; len(entries) when none is
+}
+
+func newInputHistory(entries []string) inputHistory {
+	if len(entries) > maxInputHistory {
+		entries = entries[len(entries)-maxInputHistory:]
+	}
+	entries = slices.Clone(entries)
+	return inputHistory{entries: entries, pos: len(entries)}
+}
+
+// add records a sent message and stops recalling. Sending the same thing
+// twice in a row records it once.
+func (h inputHistory) add(text string) inputHistory {
+	entries := h.entries
+	if n := len(entries); n == 0 || entries[n-1] != text {
+		// Never append into an array another copy of the model shares.
+		entries = append(slices.Clip(entries), text)
+	}
+	return newInputHistory(entries)
+}
+
+// recall handles ↑ and ↓ in an input holding input. They walk the history
+// while the input is empty or still shows the entry last recalled; once it's
+// edited they move the cursor as usual and ok is false. ↓ past the newest
+// entry clears the input.
+func (h inputHistory) recall(input, key string) (next inputHistory, text string, ok bool) {
+	recalling := h.pos < len(h.entries) && input == h.entries[h.pos]
+	if !recalling && input != "" {
+		return h, input, false
+	}
+	switch key {
+	case "up":
+		if !recalling {
+			h.pos = len(h.entries)
+		}
+		if h.pos == 0 {
+			return h, input, len(h.entries) > 0
+		}
+		h.pos--
+		return h, h.entries[h.pos], true
+	case "down":
+		if !recalling {
+			return h, input, false
+		}
+		h.pos++
+		if h.pos == len(h.entries) {
+			return h, "", true
+		}
+		return h, h.entries[h.pos], true
+	}
+	return h, input, false
+}
+
+// InputHistory returns what's been sent from the Hall and Threads inputs,
+// for saving between runs.
+func (a App) InputHistory() state.InputHistory {
+	return state.InputHistory{
+		Hall:    slices.Clone(a.hall.history.entries),
+		Threads: slices.Clone(a.threads.history.entries),
+	}
+}
+
+// WithInputHistory lets ↑ recall messages sent in earlier runs.
+func (a App) WithInputHistory(h state.InputHistory) App {
+	a.hall.history = newInputHistory(h.Hall)
+	a.threads.history = newInputHistory(h.Threads)
+	return a
+}
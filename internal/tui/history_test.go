@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/state"
+)
+
+func TestInputHistoryRecall(t *testing.T) {
+	h := inputHistory{}.add("first").add("/mute").add("/mute").add("third")
+	if len(h.entries) != 3 {
+		t.Fatalf("entries = %q, want repeats recorded once", h.entries)
+	}
+
+	var got []string
+	input := ""
+	for _, key := range []string{"up", "up", "up", "up", "down", "down", "down"} {
+		var ok bool
+		h, input, ok = h.recall(input, key)
+		if !ok {
+			t.Fatalf("%s with %q should recall", key, input)
+		}
+		got = append(got, input)
+	}
+	want := []string{"third", "/mute", "first", "first", "/mute", "third", ""}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("recalled %q, want %q", got, want)
+		}
+	}
+
+	h, input, _ = h.recall("", "up")
+	if _, _, ok := h.recall(input+"!", "up"); ok {
+		t.Error("an edited entry should keep ↑ for moving the cursor")
+	}
+	if _, _, ok := h.recall("draft", "up"); ok {
+		t.Error("↑ in a non-empty input shouldn't replace it")
+	}
+	if _, _, ok := (inputHistory{}).recall("", "up"); ok {
+		t.Error("nothing to recall yet")
+	}
+}
+
+func TestInputHistoryCapped(t *testing.T) {
+	var h inputHistory
+	for i := range maxInputHistory + 5 {
+		h = h.add(string(rune('a' + i%26)))
+	}
+	if len(h.entries) != maxInputHistory {
+		t.Errorf("kept %d entries, want %d", len(h.entries), maxInputHistory)
+	}
+}
+
+func TestHallUpRecallsSent(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m.inputFocused = true
+	for _, body := range []string{"hello hall", "/mute"} {
+		m.input = body
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.input != "/mute" {
+		t.Errorf("↑ recalled %q, want the slash command", m.input)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.input != "hello hall" {
+		t.Errorf("↑↑ recalled %q, want the message before it", m.input)
+	}
+	m, _ = m.Update(keyRune('!'))
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.input != "hello hall!" {
+		t.Errorf("↑ after resending an edit = %q, want the edit", m.input)
+	}
+}
+
+func TestThreadsUpRecallsSent(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = uuid.New().String()
+	m.inputFocused = true
+	m.input = "see you at the forge"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.input != "" {
+		t.Fatalf("input = %q after sending", m.input)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.input != "see you at the forge" {
+		t.Errorf("↑ recalled %q", m.input)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.input != "" {
+		t.Errorf("↓ past the newest = %q, want an empty input", m.input)
+	}
+}
+
+func TestInputHistoryCarriesOver(t *testing.T) {
+	a := newTestApp().WithInputHistory(state.InputHistory{Hall: []string{"gm"}, Threads: []string{"ping"}})
+	a.hall.input, a.hall.inputFocused = "", true
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyUp})
+	a = model.(App)
+	if a.hall.input != "gm" {
+		t.Errorf("↑ in a new run recalled %q, want the saved message", a.hall.input)
+	}
+	if h := a.InputHistory(); len(h.Hall) != 1 || len(h.Threads) != 1 {
+		t.Errorf("InputHistory() = %+v, want what was loaded", h)
+	}
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/hooks"
+	glog "github.com/naveenspark/grimora/internal/log"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// eventHooks runs the shell commands the config attaches to events; nil
+// runs none.
+var eventHooks *hooks.Runner
+
+// hookCmd runs the hook for e, if one is set, off the UI goroutine. A
+// failing hook is logged and otherwise ignored: it's the magician's own
+// script, and the TUI has nowhere useful to show its output.
+func hookCmd(e hooks.Event) tea.Cmd {
+	r := eventHooks
+	if !r.Has(e.Name) {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := r.Run(context.Background(), e); err != nil {
+			glog.Warn("hook failed", "event", e.Name, "err", err)
+		}
+		return nil
+	}
+}
+
+// roomHookEvent describes a room message for a hook.
+func roomHookEvent(name, room string, cm chatMessage) hooks.Event {
+	return hooks.Event{
+		Name:  name,
+		Login: cm.SenderLogin,
+		Room:  room,
+		Body:  cm.Body,
+		ID:    cm.ID,
+		URL:   domain.MessagePermalink(room, cm.ID),
+		Time:  cm.CreatedAt,
+	}
+}
+
+// dmHookEvent describes a direct message for a hook.
+func dmHookEvent(msg domain.Message) hooks.Event {
+	return hooks.Event{
+		Name:  hooks.OnDM,
+		Login: msg.SenderLogin,
+		Body:  msg.Body,
+		ID:    msg.ID.String(),
+		Time:  msg.CreatedAt,
+	}
+}
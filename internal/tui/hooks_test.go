@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/hooks"
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// withHooks installs cmds as the event hooks for the duration of a test.
+func withHooks(t *testing.T, cmds map[string]string) {
+	t.Helper()
+	prev := eventHooks
+	eventHooks = hooks.New(cmds)
+	t.Cleanup(func() { eventHooks = prev })
+}
+
+// waitForFile returns the contents of path once a hook has written it.
+func waitForFile(t *testing.T, path string) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if b, err := os.ReadFile(path); err == nil && len(b) > 0 {
+			return string(b)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("hook never wrote %s", path)
+	return ""
+}
+
+func TestHallRunsMentionAndShipHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := t.TempDir()
+	t.Setenv("HOOK_DIR", dir)
+	withHooks(t, map[string]string{
+		hooks.OnMention: `printf '%s %s' "$GRIMORA_LOGIN" "$GRIMORA_URL" > "$HOOK_DIR/mention"`,
+		hooks.OnShip:    `printf '%s' "$GRIMORA_BODY" > "$HOOK_DIR/ship"`,
+	})
+
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m, _ = m.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{
+		makeTestRoomMessage("ada", "nyx", "@me from history"),
+	}})
+	mention := makeTestRoomMessage("ada", "nyx", "hey @me")
+	ship := makeTestRoomMessage("grace", "cipher", "shipped the compiler")
+	ship.Kind = "ship"
+	_, cmd := m.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{mention, ship}})
+	drainCmd(cmd)
+
+	want := "ada " + domain.MessagePermalink(hallSlug, mention.ID.String())
+	if got := waitForFile(t, filepath.Join(dir, "mention")); got != want {
+		t.Errorf("on_mention got %q, want %q", got, want)
+	}
+	if got := waitForFile(t, filepath.Join(dir, "ship")); got != "shipped the compiler" {
+		t.Errorf("on_ship got %q", got)
+	}
+}
+
+func TestHallMutedRoomSkipsMentionHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := t.TempDir()
+	t.Setenv("HOOK_DIR", dir)
+	withHooks(t, map[string]string{
+		hooks.OnMention: `echo x > "$HOOK_DIR/mention"`,
+		hooks.OnShip:    `echo x > "$HOOK_DIR/ship"`,
+	})
+
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m.roomAlerts = map[string]string{hallSlug: state.RoomMuted}
+	m, _ = m.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{makeTestRoomMessage("ada", "nyx", "history")}})
+	ship := makeTestRoomMessage("grace", "cipher", "shipped it")
+	ship.Kind = "ship"
+	_, cmd := m.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{makeTestRoomMessage("ada", "nyx", "hey @me"), ship}})
+	drainCmd(cmd)
+
+	// The ship hook runs after the mention would have, so once it's done
+	// the mention hook has had its chance.
+	waitForFile(t, filepath.Join(dir, "ship"))
+	if _, err := os.Stat(filepath.Join(dir, "mention")); err == nil {
+		t.Error("a muted room ran the on_mention hook")
+	}
+}
+
+func TestThreadsRunsDMHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := filepath.Join(t.TempDir(), "dm")
+	t.Setenv("HOOK_OUT", out)
+	withHooks(t, map[string]string{hooks.OnDM: `cat > "$HOOK_OUT"`})
+
+	m := newThreadsModel(nil)
+	m.myLogin = "me"
+	m.messages = []domain.Message{{ID: uuid.New(), SenderLogin: "ada", Body: "earlier"}}
+	drainCmd(m.incomingAlert([]domain.Message{{ID: uuid.New(), SenderLogin: "ada", Body: "you around?"}}))
+	if got := waitForFile(t, out); !strings.Contains(got, `"event":"on_dm"`) || !strings.Contains(got, `"body":"you around?"`) {
+		t.Errorf("on_dm stdin = %q", got)
+	}
+}
+
+func TestHookCmdWithoutHook(t *testing.T) {
+	withHooks(t, nil)
+	if cmd := hookCmd(hooks.Event{Name: hooks.OnMention}); cmd != nil {
+		t.Error("expected no command when no hook is set")
+	}
+}
@@ -2,7 +2,6 @@ package tui
 
 import (
 	"strings"
-	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -30,43 +29,28 @@ func isNamedKey(key string) bool {
 	return false
 }
 
-// pageSize is the default number of items fetched per API call.
-const pageSize = 50
+// pageSize is the default number of items fetched per API call. Low-bandwidth
+// mode lowers it to lowBandwidthPageSize.
+var pageSize = defaultPageSize
+
+const defaultPageSize = 50
+
+// magicianPageSize is how many magicians are fetched per call when loading
+// all of them, for mentions and guild rosters.
+const magicianPageSize = 200
 
 // maxInputLen is the maximum number of runes allowed in chat and form inputs.
 const maxInputLen = 2000
 
-// editRune processes a keystroke for inline text editing.
-// Handles backspace (rune-aware), single printable characters, and multi-rune
-// paste strings. Returns the text unchanged for non-printable keys (enter, esc, etc.).
-// Input is clamped to maxInputLen runes.
+// editRune applies a keystroke to text with the cursor at its end, for
+// short fields (search boxes, slugs) that don't track a cursor. Inputs that
+// do keep an editCursor and call its edit method. Backspace deletes one
+// grapheme cluster, so a flag or family emoji goes in one keystroke; ctrl+w
+// and ctrl+u delete a word or the line. Returns the text unchanged for
+// non-printable keys (enter, esc, etc.). Input is clamped to maxInputLen runes.
 func editRune(text string, key string) string {
-	switch key {
-	case "backspace":
-		if len(text) > 0 {
-			runes := []rune(text)
-			return string(runes[:len(runes)-1])
-		}
-		return text
-	default:
-		keyLen := utf8.RuneCountInString(key)
-		if keyLen < 1 || isNamedKey(key) {
-			return text
-		}
-		textLen := utf8.RuneCountInString(text)
-		if textLen >= maxInputLen {
-			return text
-		}
-		// Clamp paste to remaining capacity.
-		if keyLen > 1 {
-			remaining := maxInputLen - textLen
-			if keyLen > remaining {
-				runes := []rune(key)
-				key = string(runes[:remaining])
-			}
-		}
-		return text + key
-	}
+	var c editCursor
+	return c.edit(text, key)
 }
 
 // truncateToHeight limits output to maxLines newline-delimited lines.
@@ -126,12 +110,29 @@ func countInputVisualLines(input string, bodyWidth int) int {
 	return count
 }
 
+// cursorMark stands in for the cursor while a focused input is wrapped, so
+// the cursor wraps with the text around it. It is a private-use rune one
+// cell wide, like the cursor that replaces it.
+const cursorMark = "\ue000"
+
+// markCursor returns input laid out for wrapping: with cursorMark at the
+// cursor when it is focused and the cursor isn't at the end. countInputVisualLines
+// must be given the same text renderChatInput wraps.
+func markCursor(input string, c editCursor, focused bool) string {
+	before, after := c.split(input)
+	if !focused || after == "" {
+		return input
+	}
+	return before + cursorMark + after
+}
+
 // renderChatInput renders the shared inline text input used by Hall and Threads.
 // It shows an animated name, cursor blink, and placeholder when empty.
 // Supports multiline input: first line gets the name prefix, continuation lines
 // are indented to align with the message body. Long lines are word-wrapped
-// and hard-wrapped to fit within the given terminal width.
-func renderChatInput(login, input, placeholder string, focused bool, animFrame int, width int) string {
+// and hard-wrapped to fit within the given terminal width. The cursor sits
+// at cur, which is the end of the input unless it has been moved.
+func renderChatInput(login, input string, cur editCursor, placeholder string, focused bool, animFrame int, width int) string {
 	const timeIndent = "           " // 11 spaces — matches " " + 8-char timestamp + "  "
 
 	sep := chatSepStyle.Render(" · ")
@@ -147,10 +148,7 @@ func renderChatInput(login, input, placeholder string, focused bool, animFrame i
 		}
 		return timeIndent + namePart + sep + dimStyle.Render(firstLine)
 	}
-	cursor := " "
-	if (animFrame/4)%2 == 0 {
-		cursor = accentStyle.Render("█")
-	}
+	cursor := renderCursor(animFrame)
 	if input == "" {
 		return timeIndent + namePart + sep + cursor
 	}
@@ -163,8 +161,11 @@ func renderChatInput(login, input, placeholder string, focused bool, animFrame i
 		bodyWidth = 10
 	}
 
+	marked := markCursor(input, cur, focused)
+	inside := marked != input
+
 	// Split by explicit newlines, then wrap each logical line.
-	logicalLines := strings.Split(input, "\n")
+	logicalLines := strings.Split(marked, "\n")
 	lastLogIdx := len(logicalLines) - 1
 	var b strings.Builder
 	first := true
@@ -181,15 +182,21 @@ func renderChatInput(login, input, placeholder string, focused bool, animFrame i
 					vl = strings.TrimRight(vl, " ") + trailing
 				}
 			}
+			rendered := chatComposingStyle.Render(vl)
+			if left, right, ok := strings.Cut(vl, cursorMark); ok {
+				rendered = chatComposingStyle.Render(left) + cursor + chatComposingStyle.Render(right)
+			}
 			if first {
-				b.WriteString(prefix + chatComposingStyle.Render(vl))
+				b.WriteString(prefix + rendered)
 				first = false
 			} else {
 				b.WriteByte('\n')
-				b.WriteString(contIndent + chatComposingStyle.Render(vl))
+				b.WriteString(contIndent + rendered)
 			}
 		}
 	}
-	b.WriteString(cursor)
+	if !inside {
+		b.WriteString(cursor)
+	}
 	return b.String()
 }
@@ -108,8 +108,10 @@ func TestTruncStr(t *testing.T) {
 		{"over limit", "hello world", 5, "hell\u2026"},
 		{"empty string", "", 5, ""},
 		{"single char over", "ab", 1, "\u2026"},
-		{"emoji", "\U0001f600\U0001f601\U0001f602", 2, "\U0001f600\u2026"},
-		{"CJK chars", "\u4f60\u597d\u4e16\u754c", 3, "\u4f60\u597d\u2026"},
+		// Emoji and CJK take two cells each: the limit counts columns.
+		{"emoji", "\U0001f600\U0001f601\U0001f602", 3, "\U0001f600\u2026"},
+		{"CJK chars", "\u4f60\u597d\u4e16\u754c", 5, "\u4f60\u597d\u2026"},
+		{"wide char straddling limit", "\u4f60\u597d\u4e16\u754c", 4, "\u4f60\u2026"},
 		{"multi-byte at boundary", "caf\u00e9s are nice", 5, "caf\u00e9\u2026"},
 	}
 	for _, tt := range tests {
@@ -250,7 +252,7 @@ func TestEditRuneShiftEnterIgnored(t *testing.T) {
 
 func TestRenderChatInputMultiline(t *testing.T) {
 	// Multiline input should render continuation lines indented.
-	result := renderChatInput("testuser", "line1\nline2", "placeholder", true, 0, 80)
+	result := renderChatInput("testuser", "line1\nline2", editCursor{}, "placeholder", true, 0, 80)
 	if !strings.Contains(result, "line1") || !strings.Contains(result, "line2") {
 		t.Errorf("multiline input missing lines: %q", result)
 	}
@@ -262,7 +264,7 @@ func TestRenderChatInputMultiline(t *testing.T) {
 
 func TestRenderChatInputUnfocusedMultilineCollapse(t *testing.T) {
 	// When unfocused, multiline input shows first line with ellipsis.
-	result := renderChatInput("testuser", "line1\nline2\nline3", "placeholder", false, 0, 80)
+	result := renderChatInput("testuser", "line1\nline2\nline3", editCursor{}, "placeholder", false, 0, 80)
 	if !strings.Contains(result, "line1") {
 		t.Errorf("unfocused multiline should show first line: %q", result)
 	}
@@ -274,7 +276,7 @@ func TestRenderChatInputUnfocusedMultilineCollapse(t *testing.T) {
 func TestRenderChatInputWrapsLongLine(t *testing.T) {
 	// A single long line should wrap to multiple visual lines.
 	longInput := strings.Repeat("abcdefghij ", 10) // ~110 chars
-	result := renderChatInput("testuser", longInput, "placeholder", true, 0, 60)
+	result := renderChatInput("testuser", longInput, editCursor{}, "placeholder", true, 0, 60)
 	newlines := strings.Count(result, "\n")
 	if newlines < 1 {
 		t.Errorf("long input should wrap to multiple lines, got 0 newlines: %q", result)
@@ -284,13 +286,13 @@ func TestRenderChatInputWrapsLongLine(t *testing.T) {
 func TestRenderChatInputPreservesTrailingSpace(t *testing.T) {
 	// Trailing spaces must be visible so the cursor sits after them.
 	// Use animFrame=1 so cursor is a plain space (not █) for easier assertion.
-	result := renderChatInput("testuser", "hello ", "placeholder", true, 2, 80)
+	result := renderChatInput("testuser", "hello ", editCursor{}, "placeholder", true, 2, 80)
 	// The rendered output should contain "hello " (with space) before the cursor.
 	if !strings.Contains(result, "hello ") {
 		t.Errorf("trailing space lost in render: %q", result)
 	}
 	// Multiple trailing spaces
-	result2 := renderChatInput("testuser", "hello   ", "placeholder", true, 2, 80)
+	result2 := renderChatInput("testuser", "hello   ", editCursor{}, "placeholder", true, 2, 80)
 	if !strings.Contains(result2, "hello   ") {
 		t.Errorf("multiple trailing spaces lost in render: %q", result2)
 	}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"github.com/naveenspark/grimora/internal/journal"
+	glog "github.com/naveenspark/grimora/internal/log"
+)
+
+// WithJournal records everything sent from the Hall, DM threads, the create
+// form, the workshop and the first-run wizard in j.
+func (a App) WithJournal(j *journal.Journal) App {
+	a.hall.journal = j
+	a.threads.journal = j
+	a.create.journal = j
+	a.you.journal = j
+	a.onboarding.journal = j
+	return a
+}
+
+// record appends e to j. A failure is only logged: the post itself already
+// went through, and the journal is a convenience copy.
+func record(j *journal.Journal, e journal.Entry) {
+	if err := j.Append(e); err != nil {
+		glog.Warn("journal append failed", "err", err)
+	}
+}
+
+// projectUpdateJournalText is how an update posted to a workshop project
+// reads in the journal. A ship needs no words, so it may have none.
+func projectUpdateJournalText(kind, body string) string {
+	if kind != "ship" {
+		return body
+	}
+	if body == "" {
+		return "shipped"
+	}
+	return "shipped: " + body
+}
+
+// projectJournalText is how a workshop project reads in the journal.
+func projectJournalText(name, insight string) string {
+	if insight == "" {
+		return name
+	}
+	return name + ": " + insight
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// newJournalTestServer answers every POST with an object carrying id, or
+// fails with status when it is non-zero.
+func newJournalTestServer(t *testing.T, id uuid.UUID, status int) *client.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": id}) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL, "tok")
+}
+
+func journalEntries(t *testing.T, path string) []journal.Entry {
+	t.Helper()
+	entries, err := journal.Search(path, func(journal.Entry) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestJournalRecordsSends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.ndjson")
+	id := uuid.New()
+	a := NewApp(newJournalTestServer(t, id, 0), "dev").WithJournal(journal.Open(path))
+
+	a.hall.sendRoomMessage("/seek how do I mock time?")()
+	a.threads.openThreadID, a.threads.openThreadLogin = uuid.NewString(), "ada"
+	a.threads.sendMessage("thanks!")()
+	a.create.fields[fieldText] = "Write table tests first"
+	a.create.fields[fieldTag] = "testing"
+	a.create.checked = a.create.fields // skip the pre-submit checks
+	_, cmd := a.create.submit()
+	cmd()
+
+	got := journalEntries(t, path)
+	want := []journal.Entry{
+		{Kind: journal.KindRoom, ID: id.String(), Where: a.hall.slug(), Text: "/seek how do I mock time?"},
+		{Kind: journal.KindDM, ID: id.String(), Where: "ada", Text: "thanks!"},
+		{Kind: journal.KindSpell, ID: id.String(), Where: "testing", Text: "Write table tests first"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		got[i].Time = want[i].Time
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestJournalRecordsProjectUpdatesAndOnboarding(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "journal.ndjson")
+	id := uuid.New()
+	a := NewApp(newJournalTestServer(t, id, 0), "dev").WithJournal(journal.Open(path)).
+		WithOnboarding(filepath.Join(dir, "state.json"), state.State{Onboarding: state.Onboarding{Step: onboardProject}})
+
+	a.you.projects = []domain.WorkshopProject{{ID: uuid.New(), Name: "grimora"}}
+	a.you.postKind = "ship"
+	_, cmd := a.you.handleKeyPosting(tea.KeyMsg{Type: tea.KeyEnter})
+	cmd()
+	a.onboarding.input = "loom"
+	_, cmd = a.onboarding.submit()
+	cmd()
+	a.onboarding.step = slices.Index(onboardingSteps, onboardHall)
+	a.onboarding.input = "hello, hall"
+	_, cmd = a.onboarding.submit()
+	cmd()
+
+	got := journalEntries(t, path)
+	want := []journal.Entry{
+		{Kind: journal.KindProject, ID: id.String(), Where: "grimora", Text: "shipped"},
+		{Kind: journal.KindProject, ID: id.String(), Text: "loom"},
+		{Kind: journal.KindRoom, ID: id.String(), Where: hallSlug, Text: "hello, hall"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		got[i].Time = want[i].Time
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestJournalSkipsFailedSends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.ndjson")
+	a := NewApp(newJournalTestServer(t, uuid.New(), http.StatusInternalServerError), "dev").WithJournal(journal.Open(path))
+	a.threads.openThreadID = uuid.NewString()
+	a.threads.sendMessage("lost")()
+	if got := journalEntries(t, path); len(got) != 0 {
+		t.Errorf("expected no entries after a failed send, got %+v", got)
+	}
+}
+
+func TestProjectJournalText(t *testing.T) {
+	if got := projectJournalText("grimora", ""); got != "grimora" {
+		t.Errorf("got %q", got)
+	}
+	if got := projectJournalText("grimora", "TUIs are fun"); got != "grimora: TUIs are fun" {
+		t.Errorf("got %q", got)
+	}
+}
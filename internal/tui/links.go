@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/browser"
+)
+
+// maxPickerLinks caps the link picker at the single-digit keys 1-9.
+const maxPickerLinks = 9
+
+// linkOpenedMsg carries the result of opening a URL in the browser.
+type linkOpenedMsg struct {
+	url string
+	err error
+}
+
+// openURLCmd opens url in the user's browser off the update loop.
+func openURLCmd(url string) tea.Cmd {
+	return func() tea.Msg {
+		return linkOpenedMsg{url: url, err: browser.Open(url)}
+	}
+}
+
+// extractURLs returns the distinct URLs in body in order of appearance.
+// Trailing sentence punctuation is trimmed so "see https://x.dev." opens x.dev.
+func extractURLs(body string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, u := range urlRe.FindAllString(body, -1) {
+		u = strings.TrimRight(u, ".,;:!?'\"")
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+// linkStatus describes the outcome of a linkOpenedMsg for a status line.
+func linkStatus(msg linkOpenedMsg) string {
+	if errors.Is(msg.err, browser.ErrNoBrowser) {
+		return "no browser here · " + msg.url
+	}
+	if msg.err != nil {
+		return "could not open link: " + msg.err.Error()
+	}
+	return "opened " + truncStr(msg.url, 60)
+}
+
+// linkPicker is a numbered chooser shown when a message holds several URLs.
+type linkPicker struct {
+	urls []string
+}
+
+// newLinkPicker returns a picker over the first maxPickerLinks urls.
+func newLinkPicker(urls []string) linkPicker {
+	if len(urls) > maxPickerLinks {
+		urls = urls[:maxPickerLinks]
+	}
+	return linkPicker{urls: urls}
+}
+
+func (p linkPicker) active() bool {
+	return len(p.urls) > 0
+}
+
+// height returns the number of lines View occupies.
+func (p linkPicker) height() int {
+	if !p.active() {
+		return 0
+	}
+	return len(p.urls) + 1
+}
+
+// handleKey resolves a keypress while the picker is open. It returns the
+// chosen URL, if any, and whether the picker should close.
+func (p linkPicker) handleKey(key string) (string, bool) {
+	if key == "esc" {
+		return "", true
+	}
+	if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+		i := int(key[0] - '1')
+		if i < len(p.urls) {
+			return p.urls[i], true
+		}
+	}
+	return "", false
+}
+
+// View renders the numbered link list, one URL per line.
+func (p linkPicker) View(width int) string {
+	var b strings.Builder
+	b.WriteString(" " + dimStyle.Render(fmt.Sprintf("open which link? 1-%d · esc cancel", len(p.urls))) + "\n")
+	for i, u := range p.urls {
+		fmt.Fprintf(&b, "  %s %s\n", accentStyle.Render(strconv.Itoa(i+1)), dimStyle.Render(truncStr(u, max(width-6, 10))))
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/naveenspark/grimora/internal/browser"
+)
+
+func TestExtractURLs(t *testing.T) {
+	urls := extractURLs("see https://a.dev/x. and http://b.io, then https://a.dev/x again")
+	if len(urls) != 2 {
+		t.Fatalf("expected 2 distinct urls, got %v", urls)
+	}
+	if urls[0] != "https://a.dev/x" || urls[1] != "http://b.io" {
+		t.Errorf("unexpected urls: %v", urls)
+	}
+}
+
+func TestExtractURLsNone(t *testing.T) {
+	if urls := extractURLs("no links here"); len(urls) != 0 {
+		t.Errorf("expected no urls, got %v", urls)
+	}
+}
+
+func TestLinkPickerHandleKey(t *testing.T) {
+	p := newLinkPicker([]string{"https://one.dev", "https://two.dev"})
+	if !p.active() {
+		t.Fatal("expected picker to be active")
+	}
+	if p.height() != 3 {
+		t.Errorf("expected height 3 (title + 2 links), got %d", p.height())
+	}
+
+	url, done := p.handleKey("2")
+	if url != "https://two.dev" || !done {
+		t.Errorf("expected second url and close, got %q done=%v", url, done)
+	}
+	url, done = p.handleKey("5")
+	if url != "" || done {
+		t.Errorf("out-of-range digit should be ignored, got %q done=%v", url, done)
+	}
+	url, done = p.handleKey("esc")
+	if url != "" || !done {
+		t.Errorf("esc should close without a url, got %q done=%v", url, done)
+	}
+}
+
+func TestLinkPickerCapsAtNine(t *testing.T) {
+	urls := make([]string, 12)
+	for i := range urls {
+		urls[i] = "https://x.dev/" + strings.Repeat("a", i+1)
+	}
+	p := newLinkPicker(urls)
+	if len(p.urls) != maxPickerLinks {
+		t.Errorf("expected %d urls, got %d", maxPickerLinks, len(p.urls))
+	}
+	if !strings.Contains(p.View(80), "1-9") {
+		t.Errorf("expected 1-9 prompt in picker view, got:\n%s", p.View(80))
+	}
+}
+
+func TestLinkStatusWithoutBrowser(t *testing.T) {
+	err := fmt.Errorf("open: %w", browser.ErrNoBrowser)
+	if got := linkStatus(linkOpenedMsg{url: "https://x.dev", err: err}); got != "no browser here · https://x.dev" {
+		t.Errorf("status = %q, want the link to copy", got)
+	}
+	if got := linkStatus(linkOpenedMsg{url: "https://x.dev", err: errors.New("boom")}); !strings.Contains(got, "could not open link") {
+		t.Errorf("status = %q", got)
+	}
+}
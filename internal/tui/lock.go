@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lockCheckInterval is how often the App checks whether it has sat idle
+// long enough to lock.
+const lockCheckInterval = 5 * time.Second
+
+// lockAfter is how long the App may go without a keypress before it locks;
+// 0 never locks. lockPassphraseHash is the hex SHA-256 of the passphrase
+// that unlocks it. Both come from the config.
+var (
+	lockAfter          time.Duration
+	lockPassphraseHash string
+)
+
+// lockTickMsg fires every lockCheckInterval while idle locking is on.
+type lockTickMsg time.Time
+
+func lockTickCmd() tea.Cmd {
+	if lockAfter <= 0 {
+		return nil
+	}
+	return tea.Tick(lockCheckInterval, func(t time.Time) tea.Msg {
+		return lockTickMsg(t)
+	})
+}
+
+// checkIdle locks the App once it has gone lockAfter without a keypress.
+func (a App) checkIdle(now time.Time) (App, tea.Cmd) {
+	if !a.locked && !a.lastInput.IsZero() && now.Sub(a.lastInput) >= lockAfter {
+		a.locked = true
+		a.lockInput = ""
+		a.lockErr = ""
+	}
+	return a, lockTickCmd()
+}
+
+// updateLock handles keys on the lock screen. Everything else keeps running
+// underneath; only input is held back until the passphrase is entered.
+func (a App) updateLock(msg tea.KeyMsg) (App, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return a, tea.Quit
+	case tea.KeyEnter:
+		if passphraseMatches(a.lockInput, lockPassphraseHash) {
+			a.locked = false
+			a.lastInput = time.Now()
+		} else {
+			a.lockErr = "wrong passphrase"
+		}
+		a.lockInput = ""
+	case tea.KeyBackspace:
+		a.lockInput = trimLastGrapheme(a.lockInput)
+	case tea.KeyEsc, tea.KeyCtrlU:
+		a.lockInput = ""
+	case tea.KeyRunes, tea.KeySpace:
+		a.lockInput += string(msg.Runes)
+		a.lockErr = ""
+	}
+	return a, nil
+}
+
+// passphraseMatches reports whether passphrase hashes to wantHex.
+func passphraseMatches(passphrase, wantHex string) bool {
+	want, err := hex.DecodeString(wantHex)
+	if err != nil {
+		return false
+	}
+	got := sha256.Sum256([]byte(passphrase))
+	return subtle.ConstantTimeCompare(got[:], want) == 1
+}
+
+// lockView replaces the whole screen while locked, so nothing from rooms
+// or DMs is left readable.
+func (a App) lockView() string {
+	var sb strings.Builder
+	sb.WriteString(goldStyle.Render("✦ grimora is locked") + "\n\n")
+	sb.WriteString(dimStyle.Render("passphrase ") + accentStyle.Render(strings.Repeat("*", len([]rune(a.lockInput)))) + renderCursor(0) + "\n\n")
+	if a.lockErr != "" {
+		sb.WriteString(rejectStyle.Render(a.lockErr) + "\n")
+	} else {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(helpEntry("enter", "unlock") + "  " + helpEntry("ctrl+c", "quit"))
+	return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, sb.String())
+}
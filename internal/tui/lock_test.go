@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// openSesame is the SHA-256 of "open sesame".
+const openSesame = "41ef4bb0b23661e66301aac36066912dac037827b4ae63a7b1165a5aa93ed4eb"
+
+func withIdleLock(t *testing.T, after time.Duration) {
+	t.Helper()
+	oldAfter, oldHash := lockAfter, lockPassphraseHash
+	lockAfter, lockPassphraseHash = after, openSesame
+	t.Cleanup(func() { lockAfter, lockPassphraseHash = oldAfter, oldHash })
+}
+
+func typeString(a App, s string) App {
+	for _, r := range s {
+		model, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		a = model.(App)
+	}
+	return a
+}
+
+func TestIdleLock(t *testing.T) {
+	withIdleLock(t, 10*time.Minute)
+	a := NewApp(&clienttest.Fake{}, "dev")
+	model, _ := a.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	a = model.(App)
+	idleSince := a.lastInput
+
+	model, cmd := a.Update(lockTickMsg(idleSince.Add(9 * time.Minute)))
+	a = model.(App)
+	if a.locked || cmd == nil {
+		t.Fatalf("locked = %v after 9m idle; want unlocked and still ticking", a.locked)
+	}
+
+	model, _ = a.Update(lockTickMsg(idleSince.Add(10 * time.Minute)))
+	a = model.(App)
+	if !a.locked {
+		t.Fatal("expected the app to lock after 10m idle")
+	}
+	view := a.View()
+	if !strings.Contains(view, "locked") || strings.Contains(view, "Hall") {
+		t.Errorf("lock screen should hide the app:\n%s", view)
+	}
+
+	// Keys are held back while locked.
+	a = typeString(a, "2")
+	if a.view != viewHall || a.hall.input != "" {
+		t.Errorf("view = %v, hall input = %q; keys should not reach the app while locked", a.view, a.hall.input)
+	}
+
+	model, _ = typeString(a, "wrong").Update(tea.KeyMsg{Type: tea.KeyEnter})
+	a = model.(App)
+	if !a.locked || !strings.Contains(a.View(), "wrong passphrase") {
+		t.Fatal("a wrong passphrase should keep the app locked")
+	}
+
+	model, _ = typeString(a, "open sesame").Update(tea.KeyMsg{Type: tea.KeyEnter})
+	a = model.(App)
+	if a.locked {
+		t.Fatal("the right passphrase should unlock the app")
+	}
+	if !a.lastInput.After(idleSince) {
+		t.Error("unlocking should restart the idle clock")
+	}
+}
+
+func TestIdleLockOff(t *testing.T) {
+	withIdleLock(t, 0)
+	a := NewApp(&clienttest.Fake{}, "dev")
+	if lockTickCmd() != nil {
+		t.Error("no lock ticks should run when lock_after is off")
+	}
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if model.(App).locked {
+		t.Error("app locked with lock_after off")
+	}
+}
+
+func TestPassphraseMatches(t *testing.T) {
+	if !passphraseMatches("open sesame", openSesame) {
+		t.Error("expected the passphrase to match its hash")
+	}
+	if passphraseMatches("open sesame ", openSesame) || passphraseMatches("open sesame", "not hex") {
+		t.Error("expected mismatches to fail")
+	}
+}
+
+func TestLockedAppAnnouncesNothing(t *testing.T) {
+	withAccessible(t)
+	a := NewApp(&clienttest.Fake{}, "dev")
+	a.hall.myLogin = "me"
+	model, _ := a.Update(hallMessagesMsg{room: hallSlug})
+	a = model.(App)
+	a.locked = true
+
+	model, cmd := a.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{makeTestRoomMessage("ada", "nyx", "@me the deploy key is in the vault")}})
+	a = model.(App)
+	announced := 0
+	for _, msg := range drainCmd(cmd) {
+		if _, ok := msg.(announceMsg); !ok {
+			continue
+		}
+		announced++
+		if _, cmd := a.Update(msg); cmd != nil {
+			t.Errorf("printed %#v while locked", msg)
+		}
+	}
+	if announced == 0 {
+		t.Fatal("expected the message to be announced")
+	}
+
+	a.locked = false
+	if _, cmd := a.Update(announceMsg{line: "ada: hi"}); cmd == nil {
+		t.Error("an unlocked app should print announcements")
+	}
+}
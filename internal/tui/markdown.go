@@ -0,0 +1,254 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// mdLinkRe matches a markdown link, [text](url).
+var mdLinkRe = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+
+// mdInline turns a run of prose into spans drawn in style. Chat bodies use
+// it to pick out @mentions and links; nil draws the run as is.
+type mdInline func(text string, style lipgloss.Style) []span
+
+// mdCode draws a fenced code block whose fence names lang, for width cells.
+// It returns nil to leave the block plain.
+type mdCode func(lang string, code []string, width int) []string
+
+// renderMarkdown renders the markdown people actually write in spells, the
+// Grimoire's voice, chat and release notes: fenced code blocks on a subtle
+// background, headings, bullet and numbered lists, and **bold**, *italic*
+// and `code` inline. Links show as "text (url)". Prose is drawn in base and
+// wrapped to width; each source line starts a new line, as in chat. Code
+// blocks go through code when it's set. There is always at least one line.
+func renderMarkdown(src string, base lipgloss.Style, width int, inline mdInline, code mdCode) []string {
+	var out []string
+	blank := func() {
+		if len(out) > 0 && out[len(out)-1] != "" {
+			out = append(out, "")
+		}
+	}
+	var fence []string
+	var lang string // from the opening fence, "```go"
+	inFence := false
+	closeFence := func() {
+		var lines []string
+		if code != nil {
+			lines = code(lang, fence, width)
+		}
+		if lines == nil {
+			lines = codeBlockLines(fence, width)
+		}
+		out = append(out, lines...)
+		fence = nil
+	}
+	for _, raw := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		line := strings.TrimRight(raw, " \t")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			if inFence {
+				closeFence()
+			} else if info := strings.Fields(strings.TrimPrefix(trimmed, "```")); len(info) > 0 {
+				lang = info[0]
+			} else {
+				lang = ""
+			}
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			fence = append(fence, strings.ReplaceAll(line, "\t", "    "))
+			continue
+		}
+
+		if trimmed == "" {
+			// Collapse runs of blank lines.
+			blank()
+			continue
+		}
+		if heading, ok := mdHeading(trimmed); ok {
+			blank()
+			out = append(out, wrapSpans(inlineSpans(heading, goldStyle.Bold(true), inline), width)...)
+			continue
+		}
+		if marker, item, ok := mdListItem(trimmed); ok {
+			indent := (len(line) - len(strings.TrimLeft(line, " "))) / 2 * 2
+			pad := strings.Repeat(" ", indent)
+			gap := strings.Repeat(" ", textWidth(marker)+1)
+			for i, w := range wrapSpans(inlineSpans(item, base, inline), max(width-indent-len(gap), 10)) {
+				bullet := accentStyle.Render(marker) + " "
+				if i > 0 {
+					bullet = gap
+				}
+				out = append(out, pad+bullet+w)
+			}
+			continue
+		}
+		out = append(out, wrapSpans(inlineSpans(trimmed, base, inline), width)...)
+	}
+	if inFence {
+		// An unclosed fence runs to the end.
+		closeFence()
+	}
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	if len(out) == 0 {
+		return []string{""}
+	}
+	return out
+}
+
+// mdHeading returns the text of a "# heading" line. A # needs a space after
+// it, so chat hashtags and #123 project links stay text.
+func mdHeading(line string) (string, bool) {
+	rest := strings.TrimLeft(line, "#")
+	if n := len(line) - len(rest); n == 0 || n > 6 || !strings.HasPrefix(rest, " ") {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// mdListItem splits a "- item", "* item", "+ item" or "1. item" line into
+// the marker to draw and the item's text.
+func mdListItem(line string) (marker, item string, ok bool) {
+	if len(line) > 2 && strings.ContainsRune("-*+", rune(line[0])) && line[1] == ' ' {
+		return "•", strings.TrimSpace(line[2:]), true
+	}
+	digits := 0
+	for digits < len(line) && digits < 3 && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && len(line) > digits+2 && (line[digits] == '.' || line[digits] == ')') && line[digits+1] == ' ' {
+		return line[:digits+1], strings.TrimSpace(line[digits+2:]), true
+	}
+	return "", "", false
+}
+
+// codeBlockLines draws a fenced code block indented by two cells, every line
+// on the code background and padded to the block's widest line so it reads
+// as one block. Lines too long for width are broken, not cut, so no code is
+// lost.
+func codeBlockLines(code []string, width int) []string {
+	width = max(width-2, 10)
+	var lines []string
+	for _, l := range code {
+		lines = append(lines, strings.Split(hardWrap(l, width), "\n")...)
+	}
+	widest := 0
+	for _, l := range lines {
+		widest = max(widest, textWidth(l))
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = "  " + codeStyle.Render(padRight(l, widest))
+	}
+	return out
+}
+
+// inlineSpans styles one line of prose: **bold**, *italic* and `code`, with
+// links shown as "text (url)". Bare URLs are left alone, so an underscore or
+// asterisk in one never starts emphasis. Everything but code goes through
+// inline when it's set.
+func inlineSpans(s string, base lipgloss.Style, inline mdInline) []span {
+	draw := func(text string, style lipgloss.Style) []span {
+		if inline != nil {
+			return inline(text, style)
+		}
+		return []span{styled(text, style)}
+	}
+	s = mdLinkRe.ReplaceAllString(s, "$1 ($2)")
+	var spans []span
+	prev := 0
+	for _, loc := range urlRe.FindAllStringIndex(s, -1) {
+		spans = append(spans, emphasisSpans(s[prev:loc[0]], base, draw)...)
+		spans = append(spans, draw(s[loc[0]:loc[1]], base)...)
+		prev = loc[1]
+	}
+	return append(spans, emphasisSpans(s[prev:], base, draw)...)
+}
+
+// emphasisSpans finds the code, bold and italic runs in s, which holds no
+// URLs.
+func emphasisSpans(s string, base lipgloss.Style, draw func(string, lipgloss.Style) []span) []span {
+	var spans []span
+	plain := 0 // start of the pending run of plain text
+	flush := func(to int) {
+		if to > plain {
+			spans = append(spans, draw(s[plain:to], base)...)
+		}
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c == '`' {
+			if j := strings.IndexByte(s[i+1:], '`'); j > 0 {
+				flush(i)
+				spans = append(spans, styled(s[i+1:i+1+j], codeStyle))
+				i += j + 2
+				plain = i
+				continue
+			}
+		}
+		if c == '*' || c == '_' {
+			delim := s[i : i+1]
+			style := base.Italic(true)
+			if strings.HasPrefix(s[i:], strings.Repeat(delim, 2)) {
+				delim, style = delim+delim, base.Bold(true)
+			}
+			if end, ok := closeEmphasis(s, i, delim); ok {
+				flush(i)
+				spans = append(spans, draw(s[i+len(delim):end], style)...)
+				i = end + len(delim)
+				plain = i
+				continue
+			}
+			i += len(delim)
+			continue
+		}
+		i++
+	}
+	flush(len(s))
+	return spans
+}
+
+// closeEmphasis finds where the emphasis opened by delim at s[open:] closes.
+// As in markdown, the text inside can't start or end with a space, and the
+// delimiters can't touch a letter or digit outside them, so snake_case and
+// 2*3*4 stay as written.
+func closeEmphasis(s string, open int, delim string) (int, bool) {
+	from := open + len(delim)
+	if from >= len(s) || s[from] == ' ' || wordBefore(s, open) {
+		return 0, false
+	}
+	for i := from + 1; i <= len(s)-len(delim); i++ {
+		if !strings.HasPrefix(s[i:], delim) {
+			continue
+		}
+		after := i + len(delim)
+		if s[i-1] == ' ' || (after < len(s) && s[after] == delim[0]) {
+			continue
+		}
+		if wordAfter(s, after) {
+			continue
+		}
+		return i, true
+	}
+	return 0, false
+}
+
+// wordBefore reports whether a letter or digit ends s[:i].
+func wordBefore(s string, i int) bool {
+	r, _ := utf8.DecodeLastRuneInString(s[:i])
+	return i > 0 && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// wordAfter reports whether a letter or digit starts s[i:].
+func wordAfter(s string, i int) bool {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	return i < len(s) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderMarkdownBlocks(t *testing.T) {
+	src := "# Retry with backoff\n\nUse it for:\n1. flaky calls\n- timeouts\n\n```go\nfor i := range 3 {\n\tcall()\n}\n```\n#tag stays text"
+	got := renderMarkdown(src, normalStyle, 40, nil, nil)
+	for i := range got {
+		got[i] = ansi.Strip(got[i])
+	}
+	want := []string{
+		"Retry with backoff",
+		"",
+		"Use it for:",
+		"1. flaky calls",
+		"• timeouts",
+		"",
+		"  for i := range 3 {",
+		"      call()        ",
+		"  }                 ",
+		"#tag stays text",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("renderMarkdown =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRenderMarkdownBreaksLongCode(t *testing.T) {
+	src := "```\n" + strings.Repeat("x", 30) + "\n```"
+	got := renderMarkdown(src, normalStyle, 20, nil, nil)
+	if len(got) != 2 || ansi.Strip(got[0]) != "  "+strings.Repeat("x", 18) {
+		t.Errorf("long code line = %q, want it broken at 18 cells", got)
+	}
+}
+
+func TestRenderMarkdownEmpty(t *testing.T) {
+	if got := renderMarkdown("\n\n", normalStyle, 20, nil, nil); len(got) != 1 || got[0] != "" {
+		t.Errorf("renderMarkdown(blank) = %q, want one empty line", got)
+	}
+}
+
+func TestInlineSpans(t *testing.T) {
+	var runs []string
+	record := func(text string, style lipgloss.Style) []span {
+		kind := "plain"
+		switch {
+		case style.GetBold():
+			kind = "bold"
+		case style.GetItalic():
+			kind = "italic"
+		}
+		runs = append(runs, kind+":"+text)
+		return []span{styled(text, style)}
+	}
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"**bold** and *it* and _it_", "bold:bold|plain: and |italic:it|plain: and |italic:it"},
+		{"__bold__ then `code` here", "bold:bold|plain: then |plain: here"},
+		{"snake_case_name stays", "plain:snake_case_name stays"},
+		{"2*3*4 and a * b * c", "plain:2*3*4 and a * b * c"},
+		{"see https://x.io/a_b_c_ now", "plain:see |plain:https://x.io/a_b_c_|plain: now"},
+		{"[docs](https://grimora.ai)", "plain:docs (|plain:https://grimora.ai|plain:)"},
+		{"**unclosed", "plain:**unclosed"},
+	}
+	for _, tt := range tests {
+		runs = nil
+		inlineSpans(tt.in, normalStyle, record)
+		if got := strings.Join(runs, "|"); got != tt.want {
+			t.Errorf("inlineSpans(%q) runs = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestInlineSpansCodeSkipsHook(t *testing.T) {
+	called := false
+	spans := inlineSpans("`@ada`", normalStyle, func(text string, style lipgloss.Style) []span {
+		called = true
+		return nil
+	})
+	if called || len(spans) != 1 || spans[0].text != "@ada" {
+		t.Errorf("code span = %+v, hook called %v; want the code kept whole", spans, called)
+	}
+}
+
+func TestHallRendersMarkdown(t *testing.T) {
+	m := newTestHallModel()
+	msg := chatMessage{SenderLogin: "ada", Body: "try **this** with `go test`"}
+	got := ansi.Strip(m.renderPlainMessage(msg))
+	if !strings.Contains(got, "try this with go test") {
+		t.Errorf("renderPlainMessage = %q, want the markup gone", got)
+	}
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// blockedMessageText stands in for a blocked magician's message until x
+// reveals it.
+const blockedMessageText = "blocked message (press x to reveal)"
+
+// threadPreview is the last message of t to show in a list, or "" in a
+// thread with a magician you've blocked: the preview doesn't say who wrote
+// it, so it could be theirs.
+func threadPreview(t domain.Thread, blocked map[string]bool) string {
+	for _, login := range t.Logins() {
+		if blocked[login] {
+			return ""
+		}
+	}
+	return t.LastMessage
+}
+
+// renderBlockedMessage renders the line a collapsed message shows in place
+// of its sender and body.
+func renderBlockedMessage(at time.Time) string {
+	return " " + metaStyle.Render(fmt.Sprintf("%8s", formatChatTime(at))) + "  " + dimStyle.Render(blockedMessageText)
+}
+
+// reportPrompt asks why a message or spell is being reported before the
+// report goes to the moderators.
+type reportPrompt struct {
+	targetType string // domain.ReportTargetMessage or domain.ReportTargetSpell
+	targetID   string
+}
+
+// reportedMsg carries the result of filing a report.
+type reportedMsg struct {
+	err error
+}
+
+func (p reportPrompt) question() string {
+	return "report this " + p.targetType + " as spam, abuse or something else?"
+}
+
+func (p reportPrompt) helpKeys() string {
+	return helpEntry("s", "spam") + "  " + helpEntry("a", "abuse") + "  " + helpEntry("o", "other") + "  " + helpEntry("esc", "cancel")
+}
+
+// handleKey resolves a keypress while the prompt is open. It returns the
+// reason chosen, if any, and whether the prompt should close.
+func (p reportPrompt) handleKey(key string) (string, bool) {
+	switch key {
+	case "s":
+		return domain.ReportSpam, true
+	case "a":
+		return domain.ReportAbuse, true
+	case "o":
+		return domain.ReportOther, true
+	case "esc":
+		return "", true
+	}
+	return "", false
+}
+
+// send files the report with reason.
+func (p reportPrompt) send(c client.API, reason string) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if p.targetType == domain.ReportTargetSpell {
+			err = c.ReportSpell(context.Background(), p.targetID, reason)
+		} else {
+			err = c.ReportMessage(context.Background(), p.targetID, reason)
+		}
+		return reportedMsg{err: err}
+	}
+}
+
+// reportStatus describes how filing a report went.
+func reportStatus(msg reportedMsg) string {
+	if msg.err != nil {
+		return errText("report failed", msg.err)
+	}
+	return "reported · thanks, a moderator will take a look"
+}
+
+// blockedLoadedMsg carries the logins the caller has blocked.
+type blockedLoadedMsg struct {
+	logins []string
+	err    error
+}
+
+// blockResultMsg carries the result of blocking or unblocking a magician.
+type blockResultMsg struct {
+	login   string
+	blocked bool
+	err     error
+}
+
+func loadBlocked(c client.API) tea.Cmd {
+	return func() tea.Msg {
+		logins, err := c.ListBlocked(context.Background())
+		return blockedLoadedMsg{logins: logins, err: err}
+	}
+}
+
+// blockCmd blocks login, or with block false lifts the block.
+func blockCmd(c client.API, login string, block bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if block {
+			err = c.BlockMagician(context.Background(), login)
+		} else {
+			err = c.UnblockMagician(context.Background(), login)
+		}
+		return blockResultMsg{login: login, blocked: block, err: err}
+	}
+}
+
+// withBlocked tells the chat views whose messages to collapse. The set is
+// shared read-only; changes replace it.
+func (a App) withBlocked(blocked map[string]bool) App {
+	a.blocked = blocked
+	a.hall.blocked = blocked
+	a.threads.blocked = blocked
+	return a
+}
+
+// handleBlock applies a block or unblock made from the peek card.
+func (a App) handleBlock(msg blockResultMsg) (App, tea.Cmd) {
+	a.peek, _ = a.peek.Update(msg)
+	if msg.err != nil {
+		return a, nil
+	}
+	blocked := maps.Clone(a.blocked)
+	if blocked == nil {
+		blocked = make(map[string]bool)
+	}
+	if msg.blocked {
+		blocked[msg.login] = true
+	} else {
+		delete(blocked, msg.login)
+	}
+	return a.withBlocked(blocked), nil
+}
+
+// toggleReveal shows a collapsed message from a blocked magician, or
+// collapses it again.
+func toggleReveal(revealed map[string]bool, id string) map[string]bool {
+	revealed = maps.Clone(revealed)
+	if revealed == nil {
+		revealed = make(map[string]bool)
+	}
+	if revealed[id] {
+		delete(revealed, id)
+	} else {
+		revealed[id] = true
+	}
+	return revealed
+}
@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestHallReportMessage(t *testing.T) {
+	fake := &clienttest.Fake{}
+	m := newHallModel(fake)
+	m.width, m.height = 80, 24
+	m.myLogin = "me"
+	m.inputFocused = false
+	m.messages = []chatMessage{
+		{ID: "mine", SenderLogin: "me", Body: "hi", IsSelf: true, CreatedAt: time.Now()},
+		{ID: "spam", SenderLogin: "spammer", Body: "buy tokens", CreatedAt: time.Now()},
+	}
+	m, _ = m.Update(keyRune('v'))
+	m, _ = m.Update(keyRune('!'))
+	if m.report == nil || m.report.targetID != "spam" {
+		t.Fatalf("! opened %+v, want a report of the selected message", m.report)
+	}
+	m, cmd := m.Update(keyRune('s'))
+	if cmd == nil || m.report != nil {
+		t.Fatal("s should close the prompt and file the report")
+	}
+	m, _ = m.Update(cmd())
+	if len(fake.Reports) != 1 || fake.Reports[0] != (domain.Report{TargetType: domain.ReportTargetMessage, TargetID: "spam", Reason: domain.ReportSpam}) {
+		t.Errorf("reports = %+v", fake.Reports)
+	}
+	if !strings.Contains(m.status, "reported") {
+		t.Errorf("status = %q", m.status)
+	}
+
+	m, _ = m.Update(keyRune('k'))
+	m, _ = m.Update(keyRune('!'))
+	if m.report != nil {
+		t.Error("your own message shouldn't be reportable")
+	}
+}
+
+func TestHallBlockedCollapsed(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m.connected = true
+	m.inputFocused = false
+	m.blocked = map[string]bool{"spammer": true}
+	m.messages = []chatMessage{
+		{ID: "a", SenderLogin: "ada", Body: "morning all", CreatedAt: time.Now()},
+		{ID: "b", SenderLogin: "spammer", Body: "buy tokens", CreatedAt: time.Now()},
+	}
+	view := ansi.Strip(m.View())
+	if strings.Contains(view, "buy tokens") || !strings.Contains(view, blockedMessageText) {
+		t.Fatalf("expected the blocked message collapsed:\n%s", view)
+	}
+	if !strings.Contains(view, "morning all") {
+		t.Errorf("other messages should show as usual:\n%s", view)
+	}
+
+	m, _ = m.Update(keyRune('v'))
+	m, _ = m.Update(keyRune('x'))
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "buy tokens") {
+		t.Errorf("x should reveal it:\n%s", view)
+	}
+	m, _ = m.Update(keyRune('x'))
+	if view := ansi.Strip(m.View()); strings.Contains(view, "buy tokens") {
+		t.Errorf("a second x should collapse it again:\n%s", view)
+	}
+}
+
+func TestThreadsBlockedCollapsed(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID, m.openThreadLogin = uuid.New().String(), "spammer"
+	m.blocked = map[string]bool{"spammer": true}
+	m.messages = []domain.Message{{ID: uuid.New(), SenderLogin: "spammer", Body: "buy tokens", CreatedAt: time.Now()}}
+	if view := ansi.Strip(m.View()); strings.Contains(view, "buy tokens") || !strings.Contains(view, blockedMessageText) {
+		t.Fatalf("expected the DM collapsed:\n%s", view)
+	}
+	m, _ = m.Update(keyRune('v'))
+	m, _ = m.Update(keyRune('x'))
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "buy tokens") {
+		t.Errorf("x should reveal it:\n%s", view)
+	}
+}
+
+func TestBlockedSendersStayQuiet(t *testing.T) {
+	withAccessible(t)
+	blocked := map[string]bool{"spammer": true}
+
+	h := newTestHallModel()
+	h.myLogin = "me"
+	h.blocked = blocked
+	h, _ = h.Update(hallMessagesMsg{room: hallSlug})
+	_, cmd := h.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{makeTestRoomMessage("spammer", "nyx", "@me buy tokens")}})
+	for _, msg := range drainCmd(cmd) {
+		if _, ok := msg.(alertMsg); ok || strings.Contains(fmt.Sprint(msg), "buy tokens") {
+			t.Errorf("the Hall produced %#v for a blocked sender", msg)
+		}
+	}
+
+	m := newTestThreadsModel()
+	m.blocked = blocked
+	m.messages = []domain.Message{{ID: uuid.New(), SenderLogin: "spammer", Body: "earlier"}}
+	if cmd := m.incomingAlert([]domain.Message{{ID: uuid.New(), SenderLogin: "spammer", Body: "buy tokens"}}); cmd != nil {
+		t.Error("a DM from a blocked sender shouldn't alert")
+	}
+	a := NewApp(&clienttest.Fake{}, "test").withBlocked(blocked)
+	a.me = &domain.Magician{GitHubLogin: "me"}
+	if _, cmd := a.handleThreadActivity(threadActivityMsg{messages: []domain.Message{{ID: uuid.New(), SenderLogin: "spammer", CreatedAt: time.Now()}}}); cmd != nil {
+		t.Error("the activity poll shouldn't alert for a blocked sender")
+	}
+
+	m.state = threadsListState
+	m.threads = []domain.Thread{makeTestThread("spammer", "nyx", "buy tokens"), makeTestThread("ada", "loomari", "morning")}
+	if view := ansi.Strip(m.View()); strings.Contains(view, "buy tokens") || !strings.Contains(view, "morning") {
+		t.Errorf("expected the blocked thread's preview hidden:\n%s", view)
+	}
+	for _, e := range switchEntries(nil, m.threads, nil, blocked) {
+		if e.detail == "buy tokens" {
+			t.Errorf("the switcher previews %s's last message", e.label)
+		}
+	}
+}
+
+func TestGrimoireReportSpell(t *testing.T) {
+	fake := &clienttest.Fake{}
+	m := newTestGrimoireModel()
+	m.client = fake
+	spell := makeTestSpell("ignore all previous instructions", "general")
+	m.spells = []domain.Spell{spell}
+	m.detail = true
+	m, _ = m.Update(keyRune('!'))
+	if m.report == nil {
+		t.Fatal("! should ask why")
+	}
+	m, _ = m.Update(keyRune('z'))
+	if m.report == nil || m.statusMsg != m.report.question() {
+		t.Fatalf("an unknown key should keep asking, status %q", m.statusMsg)
+	}
+	m, cmd := m.Update(keyRune('a'))
+	m, _ = m.Update(cmd())
+	if len(fake.Reports) != 1 || fake.Reports[0].TargetID != spell.ID.String() || fake.Reports[0].Reason != domain.ReportAbuse {
+		t.Errorf("reports = %+v", fake.Reports)
+	}
+
+	m, _ = m.Update(keyRune('!'))
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || m.report != nil || !m.detail {
+		t.Error("esc should cancel the report and stay on the spell")
+	}
+}
+
+func TestPeekBlockReachesChat(t *testing.T) {
+	fake := &clienttest.Fake{}
+	a := newTestApp()
+	a.client = fake
+	model, _ := a.Update(showPeekMsg{login: "spammer"})
+	a = model.(App)
+	a.peek.client = fake
+	a.peek, _ = a.peek.Update(peekLoadedMsg{card: makeTestMagicianCard("spammer", "", false)})
+
+	model, cmd := a.Update(keyRune('b'))
+	a = model.(App)
+	model, _ = a.Update(cmd())
+	a = model.(App)
+	if !a.hall.blocked["spammer"] || !a.threads.blocked["spammer"] || !a.peek.blocked {
+		t.Fatalf("blocked = %v, want spammer blocked everywhere", a.blocked)
+	}
+	if !strings.Contains(ansi.Strip(a.View()), "unblock") {
+		t.Error("the peek card should offer to unblock")
+	}
+
+	model, cmd = a.Update(keyRune('b'))
+	a = model.(App)
+	model, _ = a.Update(cmd())
+	a = model.(App)
+	if a.hall.blocked["spammer"] || len(fake.Blocked) != 0 {
+		t.Errorf("b again should unblock, blocked = %v", fake.Blocked)
+	}
+}
+
+func TestBlockedLoadedAtStart(t *testing.T) {
+	a := newTestApp()
+	model, _ := a.Update(blockedLoadedMsg{logins: []string{"spammer"}})
+	a = model.(App)
+	if !a.hall.blocked["spammer"] || !a.threads.blocked["spammer"] {
+		t.Errorf("blocked = %v", a.blocked)
+	}
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/version"
+)
+
+// releasesURL is the GitHub releases API for the CLI. Tests point it at a
+// local server.
+var releasesURL = version.ReleasesURL
+
+// releaseNotesMsg carries the release body fetched from GitHub.
+type releaseNotesMsg struct {
+	version string
+	body    string
+	err     error
+}
+
+// fetchReleaseNotes loads the notes for version, or for the latest release
+// when running a dev build.
+func fetchReleaseNotes(version string) tea.Cmd {
+	endpoint := releasesURL + "/latest"
+	if version != "" && version != "dev" {
+		endpoint = releasesURL + "/tags/v" + strings.TrimPrefix(version, "v")
+	}
+	return func() tea.Msg {
+		client := &http.Client{Timeout: 5 * time.Second}
+		resp, err := client.Get(endpoint)
+		if err != nil {
+			return releaseNotesMsg{err: err}
+		}
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode != http.StatusOK {
+			return releaseNotesMsg{err: fmt.Errorf("GitHub returned %s", resp.Status)}
+		}
+		var release struct {
+			TagName string `json:"tag_name"`
+			Body    string `json:"body"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+			return releaseNotesMsg{err: err}
+		}
+		return releaseNotesMsg{version: release.TagName, body: release.Body}
+	}
+}
+
+// WithReleaseNotes opens the "What's new" overlay on startup, e.g. right
+// after an update.
+func (a App) WithReleaseNotes() App {
+	a.notesOpen = true
+	a.notes = newNotesModel(a.width, a.height-appChromeLines)
+	return a
+}
+
+// openReleaseNotes shows the "What's new" overlay and fetches its contents.
+func (a App) openReleaseNotes() (App, tea.Cmd) {
+	a = a.WithReleaseNotes()
+	return a, fetchReleaseNotes(a.currentVersion)
+}
+
+// notesModel is the scrollable "What's new" overlay.
+type notesModel struct {
+	version string
+	body    string
+	loading bool
+	err     error
+	scroll  int
+	width   int
+	height  int
+}
+
+func newNotesModel(width, height int) notesModel {
+	return notesModel{loading: true, width: width, height: height}
+}
+
+func (m notesModel) Update(msg tea.Msg) notesModel {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case releaseNotesMsg:
+		m.loading = false
+		m.err = msg.err
+		m.version = msg.version
+		m.body = msg.body
+		m.scroll = 0
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if m.scroll < m.maxScroll() {
+				m.scroll++
+			}
+		case "k", "up":
+			if m.scroll > 0 {
+				m.scroll--
+			}
+		case "g":
+			m.scroll = 0
+		case "G":
+			m.scroll = m.maxScroll()
+		}
+	}
+	return m
+}
+
+// rows is the number of note lines visible under the title.
+func (m notesModel) rows() int {
+	return max(m.height-4, 3)
+}
+
+func (m notesModel) maxScroll() int {
+	return max(len(m.lines())-m.rows(), 0)
+}
+
+func (m notesModel) lines() []string {
+	return renderMarkdown(m.body, normalStyle, max(m.width-6, 20), nil, nil)
+}
+
+func (m notesModel) View() string {
+	var b strings.Builder
+	title := "What's new"
+	if m.version != "" {
+		title += " in " + m.version
+	}
+	b.WriteString("\n  " + selectedStyle.Render(title) + "\n\n")
+
+	switch {
+	case m.loading:
+		b.WriteString("  " + dimStyle.Render("fetching release notes...") + "\n")
+		return b.String()
+	case m.err != nil:
+		b.WriteString("  " + dimStyle.Render("could not load release notes · "+m.err.Error()) + "\n")
+		return b.String()
+	case strings.TrimSpace(m.body) == "":
+		b.WriteString("  " + dimStyle.Render("this release has no notes") + "\n")
+		return b.String()
+	}
+
+	lines := m.lines()
+	start := min(m.scroll, m.maxScroll())
+	end := min(start+m.rows(), len(lines))
+	for _, l := range lines[start:end] {
+		b.WriteString("  " + l + "\n")
+	}
+	if end < len(lines) {
+		b.WriteString("  " + metaStyle.Render(fmt.Sprintf("↓ %d more", len(lines)-end)) + "\n")
+	}
+	return b.String()
+}
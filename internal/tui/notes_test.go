@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	src := "## Features\r\n\r\n\r\n- **Replies**: press `r` on a message\n- see [docs](https://grimora.ai/faq)\n\n```\ngrimora --debug\n```\n"
+	lines := renderMarkdown(src, normalStyle, 60, nil, nil)
+	// Tests run without a TTY, so lipgloss emits no color codes.
+	plain := lines
+	want := []string{
+		"Features",
+		"",
+		"• Replies: press r on a message",
+		"• see docs (https://grimora.ai/faq)",
+		"",
+		"  grimora --debug",
+	}
+	if strings.Join(plain, "\n") != strings.Join(want, "\n") {
+		t.Errorf("renderMarkdown =\n%s\nwant\n%s", strings.Join(plain, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestFetchReleaseNotes(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"tag_name":"v0.6.0","body":"- new stuff"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+	old := releasesURL
+	releasesURL = srv.URL + "/releases"
+	t.Cleanup(func() { releasesURL = old })
+
+	msg := fetchReleaseNotes("0.6.0")().(releaseNotesMsg)
+	if msg.err != nil || msg.version != "v0.6.0" || msg.body != "- new stuff" {
+		t.Errorf("msg = %+v", msg)
+	}
+	if gotPath != "/releases/tags/v0.6.0" {
+		t.Errorf("path = %q, want the tag for the running version", gotPath)
+	}
+
+	fetchReleaseNotes("dev")()
+	if gotPath != "/releases/latest" {
+		t.Errorf("dev build path = %q, want latest", gotPath)
+	}
+}
+
+func TestAppWhatsNewFromHelp(t *testing.T) {
+	a := newTestApp()
+	a.hall.inputFocused = false
+	m, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	a = m.(App)
+	if !a.notesOpen || a.helpOpen {
+		t.Fatal("expected enter on \"What's new\" to swap help for the notes overlay")
+	}
+	if cmd == nil {
+		t.Error("expected a fetch command")
+	}
+
+	long := strings.Repeat("- item\n", 60)
+	m, _ = a.Update(releaseNotesMsg{version: "v0.6.0", body: long})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	a = m.(App)
+	if a.notes.scroll != 1 {
+		t.Errorf("scroll = %d, want 1", a.notes.scroll)
+	}
+	if view := a.View(); !strings.Contains(view, "What's new in v0.6.0") {
+		t.Errorf("expected notes title in view:\n%s", view)
+	}
+
+	m, _ = a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.(App).notesOpen {
+		t.Error("expected esc to close the notes")
+	}
+}
@@ -0,0 +1,339 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	glog "github.com/naveenspark/grimora/internal/log"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// notificationsLimit is how many notifications the screen loads.
+const notificationsLimit = 50
+
+// notificationsLoadedMsg carries the notification list.
+type notificationsLoadedMsg struct {
+	items []domain.GroupedNotification
+	err   error
+}
+
+// notificationReadMsg carries the result of marking notifications read.
+type notificationReadMsg struct {
+	err error
+}
+
+// notificationJumpMsg asks the App to open the source of a notification.
+type notificationJumpMsg struct {
+	n domain.GroupedNotification
+}
+
+// notificationSpellMsg carries the spell an upvote or comment points at.
+type notificationSpellMsg struct {
+	spell *domain.Spell
+	err   error
+}
+
+// notificationsModel is the notification center: mentions, follows, spell
+// upvotes and comments, and DMs in one list.
+type notificationsModel struct {
+	client  client.API
+	items   []domain.GroupedNotification
+	cursor  int
+	loading bool
+	err     string
+	status  string
+	width   int
+	height  int
+}
+
+func newNotificationsModel(c client.API) notificationsModel {
+	return notificationsModel{client: c}
+}
+
+func (m notificationsModel) Init() tea.Cmd {
+	return m.load()
+}
+
+func (m notificationsModel) load() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		items, err := c.ListNotifications(context.Background(), notificationsLimit)
+		return notificationsLoadedMsg{items: items, err: err}
+	}
+}
+
+func (m notificationsModel) Update(msg tea.Msg) (notificationsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case notificationsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = errReason(msg.err)
+			return m, nil
+		}
+		m.err = ""
+		m.items = msg.items
+		m.cursor = min(m.cursor, max(len(m.items)-1, 0))
+
+	case notificationReadMsg:
+		if msg.err != nil {
+			glog.Warn("mark notifications read failed", "err", msg.err)
+			m.status = errText("could not mark read", msg.err)
+		}
+
+	case tea.KeyMsg:
+		return m.updateKeys(msg)
+	}
+	return m, nil
+}
+
+func (m notificationsModel) updateKeys(msg tea.KeyMsg) (notificationsModel, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "enter":
+		if m.cursor >= len(m.items) {
+			return m, nil
+		}
+		n := m.items[m.cursor]
+		m.status = ""
+		cmds := []tea.Cmd{func() tea.Msg { return notificationJumpMsg{n: n} }}
+		if !n.Read {
+			m.items[m.cursor].Read = true
+			cmds = append(cmds, m.markRead(n.ID.String()))
+		}
+		return m, tea.Batch(cmds...)
+	case "a":
+		if m.unread() == 0 {
+			return m, nil
+		}
+		for i := range m.items {
+			m.items[i].Read = true
+		}
+		m.status = "all caught up"
+		c := m.client
+		return m, func() tea.Msg {
+			return notificationReadMsg{err: c.MarkAllNotificationsRead(context.Background())}
+		}
+	case "r":
+		m.loading = true
+		return m, m.load()
+	}
+	return m, nil
+}
+
+func (m notificationsModel) markRead(id string) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		return notificationReadMsg{err: c.MarkNotificationRead(context.Background(), id)}
+	}
+}
+
+// unread returns how many loaded notifications are unread.
+func (m notificationsModel) unread() int {
+	n := 0
+	for _, it := range m.items {
+		if !it.Read {
+			n++
+		}
+	}
+	return n
+}
+
+func (m notificationsModel) helpKeys() string {
+	return helpEntry("j/k", "nav") + "  " + helpEntry("enter", "open") + "  " + helpEntry("a", "mark all read") + "  " + helpEntry("r", "refresh") + "  " + helpEntry("esc", "back")
+}
+
+func (m notificationsModel) View() string {
+	var b strings.Builder
+
+	title := " " + presenceTitleStyle.Render("Notifications")
+	if u := m.unread(); u > 0 {
+		title += "  " + accentStyle.Render(fmt.Sprintf("%d unread", u))
+	}
+	b.WriteString(title + "\n")
+	sep := strings.Repeat("─", max(m.width-2, 4))
+	b.WriteString(" " + metaStyle.Render(sep) + "\n")
+
+	if m.loading && len(m.items) == 0 {
+		b.WriteString(" " + dimStyle.Render("loading...") + "\n")
+		return b.String()
+	}
+	if m.err != "" {
+		b.WriteString(" " + dimStyle.Render("error: "+m.err) + "\n")
+		return b.String()
+	}
+	if len(m.items) == 0 {
+		b.WriteString("\n " + dimStyle.Render("nothing yet · mentions, follows, upvotes and DMs land here") + "\n")
+		return b.String()
+	}
+
+	// Keep the cursor in view; title, separator and status take 3 lines.
+	rows := max(m.height-3, 1)
+	start := max(0, m.cursor-rows+1)
+	end := min(len(m.items), start+rows)
+	for i := start; i < end; i++ {
+		b.WriteString(m.renderItem(m.items[i], i == m.cursor) + "\n")
+	}
+
+	if m.status != "" {
+		b.WriteString(" " + dimStyle.Render(m.status) + "\n")
+	}
+	return b.String()
+}
+
+// renderItem renders one notification line. Unread ones get a dot and
+// bright text.
+func (m notificationsModel) renderItem(n domain.GroupedNotification, selected bool) string {
+	cursor := "  "
+	if selected {
+		cursor = accentStyle.Render("▸") + " "
+	}
+	dot := " "
+	if !n.Read {
+		dot = accentStyle.Render("●")
+	}
+	text := truncStr(notificationText(n), max(m.width-20, 20))
+	switch {
+	case selected:
+		text = selectedStyle.Render(text)
+	case n.Read:
+		text = dimStyle.Render(text)
+	default:
+		text = chatTextStyle.Render(text)
+	}
+	return fmt.Sprintf(" %s%s %s %s  %s", cursor, dot, goldStyle.Render(notificationIcon(n.Type)), text, metaStyle.Render(formatTime(n.CreatedAt)))
+}
+
+// notificationIcon returns the glyph for a notification type.
+func notificationIcon(typ string) string {
+	switch typ {
+	case domain.NotifMention:
+		return "@"
+	case domain.NotifFollow:
+		return "+"
+	case domain.NotifUpvote:
+		return "▲"
+	case domain.NotifComment:
+		return "✎"
+	case domain.NotifDM:
+		return "✉"
+	}
+	return "·"
+}
+
+// notificationText describes a notification in one line, e.g.
+// "ada and 2 others upvoted "flaky test triage"".
+func notificationText(n domain.GroupedNotification) string {
+	who := n.ActorLogin
+	if n.ActorCount > 1 {
+		others := "others"
+		if n.ActorCount == 2 {
+			others = "other"
+		}
+		who = fmt.Sprintf("%s and %d %s", who, n.ActorCount-1, others)
+	}
+	preview := cleanTitle(n.Preview)
+	switch n.Type {
+	case domain.NotifMention:
+		where := n.RefSlug
+		if where == "" || where == hallSlug {
+			where = "the Hall"
+		} else {
+			where = "#" + where
+		}
+		return who + " mentioned you in " + where + withPreview(preview)
+	case domain.NotifFollow:
+		return who + " followed you"
+	case domain.NotifUpvote:
+		if preview == "" {
+			return who + " upvoted your spell"
+		}
+		return who + " upvoted " + `"` + preview + `"`
+	case domain.NotifComment:
+		return who + " commented on your spell" + withPreview(preview)
+	case domain.NotifDM:
+		return who + " sent you a message" + withPreview(preview)
+	}
+	// A type from a newer server: say what it is, if not how to open it.
+	return who + " · " + n.Type + withPreview(preview)
+}
+
+func withPreview(preview string) string {
+	if preview == "" {
+		return ""
+	}
+	return ": " + preview
+}
+
+// openNotifications switches to the notification center and refreshes it.
+func (a App) openNotifications() (App, tea.Cmd) {
+	a.view = viewNotifications
+	a.notifications.loading = true
+	a.notifications.status = ""
+	return a, a.notifications.Init()
+}
+
+// jumpToNotification opens the room, thread, spell or profile n came from.
+func (a App) jumpToNotification(n domain.GroupedNotification) (App, tea.Cmd) {
+	switch n.Type {
+	case domain.NotifMention:
+		slug := n.RefSlug
+		if slug == hallSlug {
+			slug = ""
+		}
+		a.hall = a.hall.enterRoom(slug, slug)
+		a.view = viewHall
+		return a, a.hall.loadMessages()
+
+	case domain.NotifDM:
+		a.view = viewThreads
+		if n.RefID == nil {
+			return a, a.threads.Init()
+		}
+		var cmd tea.Cmd
+		a.threads, cmd = a.threads.openThread(domain.Thread{ID: *n.RefID, OtherLogin: n.ActorLogin, OtherGuildID: n.ActorGuild})
+		return a, cmd
+
+	case domain.NotifUpvote, domain.NotifComment:
+		if n.RefID == nil {
+			a.notifications.status = "that spell is gone"
+			return a, nil
+		}
+		c, id := a.client, n.RefID.String()
+		return a, func() tea.Msg {
+			spell, err := c.GetSpell(context.Background(), id)
+			return notificationSpellMsg{spell: spell, err: err}
+		}
+
+	case domain.NotifFollow:
+		login := n.ActorLogin
+		return a, func() tea.Msg { return showPeekMsg{login: login} }
+	}
+	a.notifications.status = "this version of grimora can't open " + n.Type + " notifications · run grimora update"
+	return a, nil
+}
+
+// showNotificationSpell opens the fetched spell in the Grimoire.
+func (a App) showNotificationSpell(msg notificationSpellMsg) (App, tea.Cmd) {
+	if msg.err != nil {
+		a.notifications.status = errText("could not open spell", msg.err)
+		return a, nil
+	}
+	a.grimoire = a.grimoire.showSpell(*msg.spell)
+	a.view = viewGrimoire
+	return a, nil
+}
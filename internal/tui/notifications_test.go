@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestNotificationText(t *testing.T) {
+	spell := uuid.New()
+	tests := []struct {
+		n    domain.GroupedNotification
+		want string
+	}{
+		{
+			domain.GroupedNotification{Notification: domain.Notification{Type: domain.NotifMention, ActorLogin: "ada", RefSlug: hallSlug, Preview: "@you look"}},
+			"ada mentioned you in the Hall: @you look",
+		},
+		{
+			domain.GroupedNotification{Notification: domain.Notification{Type: domain.NotifMention, ActorLogin: "ada", RefSlug: "go-tips"}},
+			"ada mentioned you in #go-tips",
+		},
+		{
+			domain.GroupedNotification{Notification: domain.Notification{Type: domain.NotifFollow, ActorLogin: "linus"}, ActorCount: 2},
+			"linus and 1 other followed you",
+		},
+		{
+			domain.GroupedNotification{Notification: domain.Notification{Type: domain.NotifUpvote, ActorLogin: "ada", RefID: &spell, Preview: "# Flaky test triage"}, ActorCount: 3},
+			`ada and 2 others upvoted "Flaky test triage"`,
+		},
+		{
+			domain.GroupedNotification{Notification: domain.Notification{Type: domain.NotifDM, ActorLogin: "ada", Preview: "hey"}},
+			"ada sent you a message: hey",
+		},
+	}
+	for _, tt := range tests {
+		if got := notificationText(tt.n); got != tt.want {
+			t.Errorf("notificationText(%s) = %q, want %q", tt.n.Type, got, tt.want)
+		}
+	}
+}
+
+// pressEnter presses enter on the selected notification and feeds the
+// resulting messages back into the App.
+func pressEnter(t *testing.T, a App) App {
+	t.Helper()
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	a = model.(App)
+	if cmd == nil {
+		t.Fatal("expected enter to produce a command")
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		batch = tea.BatchMsg{func() tea.Msg { return msg }}
+	}
+	for _, c := range batch {
+		model, next := a.Update(c())
+		a = model.(App)
+		// A spell jump fetches the spell first.
+		if next != nil {
+			if m, ok := next().(notificationSpellMsg); ok {
+				model, _ = a.Update(m)
+				a = model.(App)
+			}
+		}
+	}
+	return a
+}
+
+func TestNotificationsUnreadAndMarkAll(t *testing.T) {
+	f := &clienttest.Fake{Notifications: []domain.GroupedNotification{
+		{Notification: domain.Notification{ID: uuid.New(), Type: domain.NotifFollow, ActorLogin: "ada"}},
+		{Notification: domain.Notification{ID: uuid.New(), Type: domain.NotifFollow, ActorLogin: "linus", Read: true}},
+	}}
+	a := openViewTestApp(t, f, "N", viewNotifications)
+	if !strings.Contains(a.View(), "1 unread") {
+		t.Error("expected the unread count in the header")
+	}
+
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	a = model.(App)
+	if a.notifications.unread() != 0 {
+		t.Error("expected a to mark everything read")
+	}
+	cmd()
+	if f.Count("MarkAllNotificationsRead") != 1 {
+		t.Errorf("calls = %v, want MarkAllNotificationsRead", f.Calls())
+	}
+}
+
+func TestNotificationJumpToRoom(t *testing.T) {
+	id := uuid.New()
+	f := &clienttest.Fake{Notifications: []domain.GroupedNotification{
+		{Notification: domain.Notification{ID: id, Type: domain.NotifMention, ActorLogin: "ada", RefSlug: "go-tips"}},
+	}}
+	a := openViewTestApp(t, f, "N", viewNotifications)
+	a = pressEnter(t, a)
+	if a.view != viewHall || a.hall.room != "go-tips" {
+		t.Errorf("view = %v, room = %q; want the Hall in #go-tips", a.view, a.hall.room)
+	}
+	if !a.notifications.items[0].Read {
+		t.Error("expected the opened notification to be marked read")
+	}
+	if !f.Notifications[0].Read {
+		t.Errorf("calls = %v, want MarkNotificationRead(%s)", f.Calls(), id)
+	}
+}
+
+func TestNotificationJumpToThread(t *testing.T) {
+	thread := uuid.New()
+	a := openViewTestApp(t, &clienttest.Fake{Notifications: []domain.GroupedNotification{
+		{Notification: domain.Notification{ID: uuid.New(), Type: domain.NotifDM, ActorLogin: "ada", RefID: &thread, Read: true}},
+	}}, "N", viewNotifications)
+	a = pressEnter(t, a)
+	if a.view != viewThreads || a.threads.openThreadID != thread.String() || a.threads.openThreadLogin != "ada" {
+		t.Errorf("view = %v, thread = %q with %q", a.view, a.threads.openThreadID, a.threads.openThreadLogin)
+	}
+}
+
+func TestNotificationJumpToSpell(t *testing.T) {
+	spell := uuid.New()
+	a := openViewTestApp(t, &clienttest.Fake{
+		Spells: []domain.Spell{{ID: spell, Text: "triage flaky tests"}},
+		Notifications: []domain.GroupedNotification{
+			{Notification: domain.Notification{ID: uuid.New(), Type: domain.NotifComment, ActorLogin: "ada", RefID: &spell, Read: true}},
+		},
+	}, "N", viewNotifications)
+	a = pressEnter(t, a)
+	if a.view != viewGrimoire || !a.grimoire.detail {
+		t.Fatalf("view = %v, detail = %v; want the spell detail", a.view, a.grimoire.detail)
+	}
+	if got := a.grimoire.spells[a.grimoire.cursor].ID; got != spell {
+		t.Errorf("showing spell %s, want %s", got, spell)
+	}
+}
+
+func TestNotificationsEscReturnsToHall(t *testing.T) {
+	a := openViewTestApp(t, &clienttest.Fake{}, "N", viewNotifications)
+	if !strings.Contains(a.View(), "nothing yet") {
+		t.Error("expected the empty state")
+	}
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(App).view != viewHall {
+		t.Error("expected esc to go back to the Hall")
+	}
+}
+
+func TestNotificationUnknownType(t *testing.T) {
+	n := domain.GroupedNotification{Notification: domain.Notification{Type: "badge", ActorLogin: "ada", Preview: "first ship"}}
+	if got, want := notificationText(n), "ada · badge: first ship"; got != want {
+		t.Errorf("notificationText = %q, want %q", got, want)
+	}
+	f := &clienttest.Fake{Notifications: []domain.GroupedNotification{n}}
+	a := openViewTestApp(t, f, "N", viewNotifications)
+	a = pressEnter(t, a)
+	if a.view != viewNotifications || !strings.Contains(a.notifications.status, "can't open badge notifications") {
+		t.Errorf("view %v, status %q; want to stay with an explanation", a.view, a.notifications.status)
+	}
+}
@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// Onboarding steps, in order. Signing in happens before the TUI starts, in
+// `grimora`'s guided login; these pick up once there's an account.
+const (
+	onboardGuild   = "guild"
+	onboardCity    = "city"
+	onboardProject = "project"
+	onboardHall    = "hall"
+)
+
+var onboardingSteps = []string{onboardGuild, onboardCity, onboardProject, onboardHall}
+
+// maxOnboardingInput caps the city and project name; the hall message uses
+// maxInputLen like the Hall itself.
+const maxOnboardingInput = 80
+
+// guildEssences is the one-line character of each guild.
+var guildEssences = map[string]string{
+	"loomari":  "the architects",
+	"ashborne": "the reborn",
+	"amarok":   "the pack",
+	"nyx":      "the rememberers",
+	"cipher":   "the hidden",
+	"fathom":   "the deep",
+}
+
+// onboardingModel walks a new magician through first-run setup: what their
+// guild is, their city, a first workshop project and a first Hall message.
+// Each step past the guild can be skipped.
+type onboardingModel struct {
+	client    client.API
+	step      int // index into onboardingSteps; len(onboardingSteps) when finished
+	guild     string
+	input     string
+	busy      bool // the step's request is in flight
+	err       string
+	animFrame int
+
+	journal *journal.Journal // copy of the project and Hall message sent; nil keeps none
+}
+
+// onboardingStepMsg reports the request behind a step.
+type onboardingStepMsg struct {
+	step string
+	err  error
+}
+
+// newOnboardingModel starts at the saved step, or the first.
+func newOnboardingModel(c client.API, saved string) onboardingModel {
+	m := onboardingModel{client: c}
+	if i := slices.Index(onboardingSteps, saved); i >= 0 {
+		m.step = i
+	}
+	return m
+}
+
+// current returns the step being shown, or "" once finished.
+func (m onboardingModel) current() string {
+	if m.step >= len(onboardingSteps) {
+		return ""
+	}
+	return onboardingSteps[m.step]
+}
+
+func (m onboardingModel) finished() bool {
+	return m.current() == ""
+}
+
+// progress is what to save: the step to resume at.
+func (m onboardingModel) progress() state.Onboarding {
+	return state.Onboarding{Step: m.current(), Done: m.finished()}
+}
+
+// withMe fills in what the profile already knows.
+func (m onboardingModel) withMe(me *domain.Magician) onboardingModel {
+	m.guild = me.GuildID
+	if m.current() == onboardCity && m.input == "" {
+		m.input = me.City
+	}
+	return m
+}
+
+// next moves on to the following step with an empty input.
+func (m onboardingModel) next() onboardingModel {
+	m.step++
+	m.input, m.err, m.busy = "", "", false
+	return m
+}
+
+func (m onboardingModel) Update(msg tea.Msg) (onboardingModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case onboardingStepMsg:
+		if msg.step != m.current() {
+			return m, nil
+		}
+		m.busy = false
+		if msg.err != nil {
+			m.err = errText("that didn't work", msg.err)
+			return m, nil
+		}
+		return m.next(), nil
+
+	case cursorBlinkMsg:
+		m.animFrame++
+
+	case tea.KeyMsg:
+		m.animFrame = 0
+		if m.busy || m.finished() {
+			return m, nil
+		}
+		switch msg.String() {
+		case "enter":
+			return m.submit()
+		case "tab":
+			if m.current() != onboardGuild {
+				return m.next(), nil
+			}
+		default:
+			if m.current() != onboardGuild {
+				key := msg.String()
+				if msg.Paste {
+					key = string(msg.Runes)
+				}
+				m.input = editRune(m.input, key)
+				if utf8.RuneCountInString(m.input) > m.inputLimit() {
+					m.input = clampGraphemes(m.input, m.inputLimit())
+				}
+				m.err = ""
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m onboardingModel) inputLimit() int {
+	if m.current() == onboardHall {
+		return maxInputLen
+	}
+	return maxOnboardingInput
+}
+
+// submit sends the current step's answer. The guild step only explains.
+func (m onboardingModel) submit() (onboardingModel, tea.Cmd) {
+	step, text := m.current(), strings.TrimSpace(m.input)
+	if step == onboardGuild {
+		return m.next(), nil
+	}
+	if text == "" {
+		m.err = "type something, or press tab to skip"
+		return m, nil
+	}
+	c, j := m.client, m.journal
+	var run func() error
+	switch step {
+	case onboardCity:
+		run = func() error {
+			_, err := c.UpdateProfile(context.Background(), domain.ProfileUpdate{City: &text})
+			return err
+		}
+	case onboardProject:
+		run = func() error {
+			project, err := c.CreateWorkshopProject(context.Background(), text, "")
+			if err == nil {
+				record(j, journal.Entry{Kind: journal.KindProject, ID: project.ID.String(), Text: projectJournalText(text, "")})
+			}
+			return err
+		}
+	case onboardHall:
+		run = func() error {
+			sent, err := c.SendRoomMessage(context.Background(), hallSlug, text, nil)
+			if err == nil {
+				record(j, journal.Entry{Kind: journal.KindRoom, ID: sent.ID.String(), Where: hallSlug, Text: text})
+			}
+			return err
+		}
+	}
+	m.busy, m.err = true, ""
+	return m, func() tea.Msg {
+		return onboardingStepMsg{step: step, err: run()}
+	}
+}
+
+func (m onboardingModel) View() string {
+	var b strings.Builder
+	title := fmt.Sprintf("Welcome to Grimora · %d/%d", min(m.step+1, len(onboardingSteps)), len(onboardingSteps))
+	b.WriteString("\n  " + selectedStyle.Render(title) + "\n\n")
+
+	var lines []string
+	prompt := ""
+	switch m.current() {
+	case onboardGuild:
+		if m.guild == "" {
+			lines = append(lines, "The Grimoire reads your GitHub and places you in one of six guilds.")
+		} else {
+			lines = append(lines, "The Grimoire read your GitHub and placed you in "+
+				GuildEmblem(m.guild)+" "+GuildStyle(m.guild).Render(m.guild)+", "+guildEssences[m.guild]+".")
+		}
+		lines = append(lines,
+			"You don't choose your guild. It colors your name and card everywhere,",
+			"has its own room (tab 6, then g) and a chest of spells its curators keep.")
+	case onboardCity:
+		lines = append(lines,
+			"Where do you build from? Your city shows on your card and the leaderboard",
+			"can be filtered by it.")
+		prompt = "city"
+	case onboardProject:
+		lines = append(lines,
+			"What are you working on? A workshop project is where your build updates",
+			"and ships collect, and others can #tag it in the Hall.")
+		prompt = "project name"
+	case onboardHall:
+		lines = append(lines,
+			"Last step: say hello in the Hall, the room everyone shares.")
+		prompt = "message"
+	default:
+		lines = append(lines, "You're all set.")
+	}
+	for _, l := range lines {
+		b.WriteString("  " + normalStyle.Render(l) + "\n")
+	}
+	b.WriteString("\n")
+
+	if prompt != "" {
+		field := m.input
+		if !m.busy {
+			field += renderCursor(m.animFrame)
+		}
+		b.WriteString("  " + dimStyle.Render(prompt+" ") + field + "\n\n")
+	}
+	switch {
+	case m.busy:
+		b.WriteString("  " + dimStyle.Render("saving...") + "\n")
+	case m.err != "":
+		b.WriteString("  " + rejectStyle.Render(m.err) + "\n")
+	}
+	return b.String()
+}
+
+// helpKeys lists the keys for the current step.
+func (m onboardingModel) helpKeys() string {
+	keys := helpEntry("enter", "continue")
+	if m.current() != onboardGuild {
+		keys += "  " + helpEntry("tab", "skip")
+	}
+	return keys + "  " + helpEntry("esc", "later") + "  " + helpEntry("ctrl+c", "quit")
+}
+
+// WithOnboarding opens the first-run wizard at the step saved in st, unless
+// it is done, remembering progress in the state file at path.
+func (a App) WithOnboarding(path string, st state.State) App {
+	if st.Onboarding.Done {
+		return a
+	}
+	a.statePath = path
+	a.state = st
+	a.onboarding = newOnboardingModel(a.client, st.Onboarding.Step)
+	a.onboarding.journal = a.hall.journal
+	a.onboardingOpen = true
+	// Saved in Init, so quitting on the first step still resumes there.
+	a.state.Onboarding = a.onboarding.progress()
+	return a
+}
+
+// updateOnboarding hands msg to the wizard and saves any progress it makes.
+// Finishing lands on the Hall; esc puts the wizard away until next launch.
+func (a App) updateOnboarding(msg tea.Msg) (App, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "ctrl+c":
+			return a, tea.Quit
+		case "esc":
+			a.onboardingOpen = false
+			a.hall.status = "setup continues next time you open grimora"
+			return a, nil
+		}
+	}
+	before := a.onboarding.step
+	var cmd tea.Cmd
+	a.onboarding, cmd = a.onboarding.Update(msg)
+	if a.onboarding.step == before {
+		return a, cmd
+	}
+	a.state.Onboarding = a.onboarding.progress()
+	save := saveStateCmd(a.statePath, a.state)
+	if a.onboarding.finished() {
+		a.onboardingOpen = false
+		a.view = viewHall
+		a.hall.status = "welcome to the Hall -- press h for help, or run grimora tour to practice"
+	}
+	return a, tea.Batch(cmd, save)
+}
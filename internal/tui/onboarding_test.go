@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// runAll runs cmd and any batch it returns, handing back the messages.
+func runAll(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var out []tea.Msg
+	for _, c := range batch {
+		out = append(out, runAll(c)...)
+	}
+	return out
+}
+
+func typeOnboarding(a App, text string) App {
+	for _, r := range text {
+		model, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		a = model.(App)
+	}
+	return a
+}
+
+// pressOnboarding sends key, feeds the messages its commands produce back in
+// and runs what those return.
+func pressOnboarding(t *testing.T, a App, key tea.KeyMsg) App {
+	t.Helper()
+	model, cmd := a.Update(key)
+	a = model.(App)
+	for _, msg := range runAll(cmd) {
+		if msg == nil {
+			continue
+		}
+		model, cmd = a.Update(msg)
+		a = model.(App)
+		runAll(cmd) // the progress save
+	}
+	return a
+}
+
+func TestOnboardingWalkthrough(t *testing.T) {
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "mona", GuildID: "nyx"}}
+	path := filepath.Join(t.TempDir(), "state.json")
+	a := NewApp(f, "dev").WithOnboarding(path, state.State{})
+	model, _ := a.Update(meLoadedMsg{me: f.Me})
+	a = model.(App)
+
+	if !a.onboardingOpen || !strings.Contains(a.onboarding.View(), "the rememberers") {
+		t.Fatalf("wizard should open on the guild step, got:\n%s", a.onboarding.View())
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	a = pressOnboarding(t, a, enter)
+	if a.onboarding.current() != onboardCity {
+		t.Fatalf("step = %q after the guild, want city", a.onboarding.current())
+	}
+
+	a = pressOnboarding(t, typeOnboarding(a, "Lisbon"), enter)
+	if f.Me.City != "Lisbon" || a.onboarding.current() != onboardProject {
+		t.Fatalf("city = %q, step = %q; want Lisbon saved and the project step", f.Me.City, a.onboarding.current())
+	}
+	if got, _ := state.Load(path); got.Onboarding.Step != onboardProject {
+		t.Errorf("saved step = %q, want project", got.Onboarding.Step)
+	}
+
+	a = pressOnboarding(t, a, tea.KeyMsg{Type: tea.KeyTab})
+	if f.Count("CreateWorkshopProject") != 0 || a.onboarding.current() != onboardHall {
+		t.Fatalf("tab should skip the project, step = %q", a.onboarding.current())
+	}
+
+	a = pressOnboarding(t, typeOnboarding(a, "hello all"), enter)
+	if f.Count("SendRoomMessage") != 1 {
+		t.Errorf("SendRoomMessage called %d times, want 1", f.Count("SendRoomMessage"))
+	}
+	if a.onboardingOpen || a.view != viewHall {
+		t.Errorf("finishing should close the wizard on the Hall, open = %v view = %v", a.onboardingOpen, a.view)
+	}
+	if got, _ := state.Load(path); !got.Onboarding.Done {
+		t.Errorf("saved onboarding = %+v, want done", got.Onboarding)
+	}
+}
+
+func TestOnboardingResumesAndSkipsWhenDone(t *testing.T) {
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "mona"}}
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	a := NewApp(f, "dev").WithOnboarding(path, state.State{Onboarding: state.Onboarding{Step: onboardProject}})
+	if a.onboarding.current() != onboardProject {
+		t.Errorf("resumed at %q, want project", a.onboarding.current())
+	}
+	if done := NewApp(f, "dev").WithOnboarding(path, state.State{Onboarding: state.Onboarding{Done: true}}); done.onboardingOpen {
+		t.Error("a finished wizard should not open again")
+	}
+}
+
+func TestOnboardingEmptyAnswerAndEsc(t *testing.T) {
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "mona"}}
+	a := NewApp(f, "dev").WithOnboarding(filepath.Join(t.TempDir(), "state.json"), state.State{Onboarding: state.Onboarding{Step: onboardCity}})
+
+	a = pressOnboarding(t, a, tea.KeyMsg{Type: tea.KeyEnter})
+	if a.onboarding.current() != onboardCity || a.onboarding.err == "" || f.Count("UpdateProfile") != 0 {
+		t.Errorf("an empty city should stay put with a hint, err = %q", a.onboarding.err)
+	}
+
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if a = model.(App); a.onboardingOpen {
+		t.Error("esc should put the wizard away")
+	}
+}
+
+func TestOnboardingPasteIsClamped(t *testing.T) {
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "mona", GuildID: "nyx"}}
+	path := filepath.Join(t.TempDir(), "state.json")
+	a := NewApp(f, "dev").WithOnboarding(path, state.State{Onboarding: state.Onboarding{Step: onboardCity}})
+
+	a = pressOnboarding(t, a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Lisbon"), Paste: true})
+	if a.onboarding.input != "Lisbon" {
+		t.Errorf("input = %q, want the paste without brackets", a.onboarding.input)
+	}
+	long := strings.Repeat("é", maxOnboardingInput+10)
+	a = pressOnboarding(t, a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(long), Paste: true})
+	if n := len([]rune(a.onboarding.input)); n != maxOnboardingInput {
+		t.Errorf("input has %d runes after a long paste, want %d", n, maxOnboardingInput)
+	}
+}
+
+func TestOnboardingStepFailureStays(t *testing.T) {
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "mona"}}
+	f.Fail = map[string]error{"CreateWorkshopProject": errors.New("boom")}
+	a := NewApp(f, "dev").WithOnboarding(filepath.Join(t.TempDir(), "state.json"), state.State{Onboarding: state.Onboarding{Step: onboardProject}})
+
+	a = pressOnboarding(t, typeOnboarding(a, "grimora"), tea.KeyMsg{Type: tea.KeyEnter})
+	if a.onboarding.current() != onboardProject || a.onboarding.err == "" {
+		t.Errorf("a failed request should keep the step with an error, step = %q err = %q", a.onboarding.current(), a.onboarding.err)
+	}
+}
@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/internal/outbox"
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+// outboxTickInterval is how often due retries go out and the outbox is saved.
+// It also keeps the "will retry in" countdown current.
+const outboxTickInterval = time.Second
+
+// outboxSentMsg carries the result of one attempt at sending queued messages:
+// the last one tried, and why it failed. The outbox itself is already updated
+// by the time it arrives.
+type outboxSentMsg struct {
+	msg outbox.Message
+	err error
+}
+
+type outboxTickMsg time.Time
+
+// outboxTickCmd flushes s on each tick. Save is a no-op when nothing changed.
+func outboxTickCmd(s *outbox.Store) tea.Cmd {
+	return tea.Tick(outboxTickInterval, func(t time.Time) tea.Msg {
+		s.Save() //nolint:errcheck // best-effort; retried on the next tick
+		return outboxTickMsg(t)
+	})
+}
+
+// WithOutbox swaps the in-memory outbox for s, typically one opened from
+// disk, so unsent messages survive a restart.
+func (a App) WithOutbox(s *outbox.Store) App {
+	a.outbox = s
+	a.hall.outbox = s
+	a.threads.outbox = s
+	return a
+}
+
+// sendQueuedCmd makes one attempt at delivering msg, which must already be
+// marked as sending.
+func sendQueuedCmd(c client.API, box *outbox.Store, j *journal.Journal, msg outbox.Message) tea.Cmd {
+	return sendBatchCmd(c, box, j, []outbox.Message{msg})
+}
+
+// sendBatchCmd delivers msgs, all marked as sending and all for one target,
+// one after another in a single command, so a backlog goes out in order and
+// the conversation reloads once for it rather than once per message.
+// Delivered messages leave the outbox and go into the journal. The first
+// failure stops the batch: that message and the ones behind it stay,
+// due for a retry if the failure looks passing.
+func sendBatchCmd(c client.API, box *outbox.Store, j *journal.Journal, msgs []outbox.Message) tea.Cmd {
+	return func() tea.Msg {
+		var last outbox.Message
+		for i, msg := range msgs {
+			last = msg
+			if err := deliver(c, j, msg); err != nil {
+				now := time.Now()
+				for _, rest := range msgs[i:] {
+					box.Fail(rest.ID, errReason(err), retryable(err), now)
+				}
+				return outboxSentMsg{msg: msg, err: err}
+			}
+			box.Remove(msg.ID)
+		}
+		return outboxSentMsg{msg: last}
+	}
+}
+
+// deliver sends msg to the API, journaling it once it's accepted.
+func deliver(c client.API, j *journal.Journal, msg outbox.Message) error {
+	ctx := context.Background()
+	if msg.Kind == outbox.KindDM {
+		sent, err := c.SendMessage(ctx, msg.Target, msg.Body)
+		if err == nil {
+			record(j, journal.Entry{Kind: journal.KindDM, ID: sent.ID.String(), Where: msg.Login, Text: msg.Body})
+		}
+		return err
+	}
+	sent, err := c.SendRoomMessage(ctx, msg.Target, msg.Body, msg.Metadata)
+	if err == nil {
+		record(j, journal.Entry{Kind: journal.KindRoom, ID: sent.ID.String(), Where: msg.Target, Text: msg.Body})
+	}
+	return err
+}
+
+// retryable reports whether a failed send is safe to retry unprompted: the
+// API couldn't be reached, or it turned the message away as overloaded. A
+// timeout, a dropped connection or a server error may come after the
+// message was posted, so those wait for ctrl+r rather than risk posting it
+// twice; anything else (too long, not a member, signed out) would only fail
+// the same way again.
+func retryable(err error) bool {
+	return client.IsUnsent(err) || client.IsRateLimited(err) || client.IsStatus(err, http.StatusServiceUnavailable)
+}
+
+// retryDueCmd sends every queued message whose backoff has run out, in one
+// batch per conversation.
+func retryDueCmd(c client.API, box *outbox.Store, j *journal.Journal, now time.Time) tea.Cmd {
+	type conversation struct{ kind, target string }
+	var order []conversation
+	batches := make(map[conversation][]outbox.Message)
+	for _, msg := range box.Due(now) {
+		msg, ok := box.Send(msg.ID)
+		if !ok {
+			continue
+		}
+		conv := conversation{msg.Kind, msg.Target}
+		if batches[conv] == nil {
+			order = append(order, conv)
+		}
+		batches[conv] = append(batches[conv], msg)
+	}
+	cmds := make([]tea.Cmd, len(order))
+	for i, conv := range order {
+		cmds[i] = sendBatchCmd(c, box, j, batches[conv])
+	}
+	return tea.Batch(cmds...)
+}
+
+// retryFailedCmd sends every failed message queued for kind and target now,
+// without waiting out the backoff. It returns nil when nothing has failed.
+func retryFailedCmd(c client.API, box *outbox.Store, j *journal.Journal, kind, target string) tea.Cmd {
+	var batch []outbox.Message
+	for _, msg := range box.For(kind, target) {
+		if !msg.Failed() {
+			continue
+		}
+		if msg, ok := box.Send(msg.ID); ok {
+			batch = append(batch, msg)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return sendBatchCmd(c, box, j, batch)
+}
+
+// discardFailed drops the newest failed message queued for kind and target
+// and returns its body, or "" when nothing has failed.
+func discardFailed(box *outbox.Store, kind, target string) string {
+	pending := box.For(kind, target)
+	for i := len(pending) - 1; i >= 0; i-- {
+		if pending[i].Failed() {
+			box.Remove(pending[i].ID)
+			return pending[i].Body
+		}
+	}
+	return ""
+}
+
+// renderPending renders the messages queued for kind and target as they
+// would appear once sent, but dimmed, each with a line saying where it's at.
+func renderPending(box *outbox.Store, kind, target, login string, width int, now time.Time) []string {
+	var lines []string
+	for _, msg := range box.For(kind, target) {
+		prefix := " " + metaStyle.Render(fmt.Sprintf("%8s", formatChatTime(msg.CreatedAt))) +
+			"  " + dimStyle.Render(login) + chatSepStyle.Render(" · ")
+		prefixWidth := lipgloss.Width(prefix)
+		bodyWidth := max(width-prefixWidth, 20)
+		indent := strings.Repeat(" ", prefixWidth)
+		for i, line := range wrapSpans([]span{styled(msg.Body, dimStyle)}, bodyWidth) {
+			if i == 0 {
+				lines = append(lines, prefix+line)
+			} else {
+				lines = append(lines, indent+line)
+			}
+		}
+		lines = append(lines, indent+pendingStatus(msg, now))
+	}
+	return lines
+}
+
+// pendingStatus is the marker under a queued message.
+func pendingStatus(msg outbox.Message, now time.Time) string {
+	if msg.Sending {
+		return metaStyle.Render("sending…")
+	}
+	keys := metaStyle.Render(" · ctrl+r retry · ctrl+x discard")
+	if msg.RetryAt.IsZero() {
+		return rejectStyle.Render("failed: "+msg.Err) + keys
+	}
+	wait := max(msg.RetryAt.Sub(now).Round(time.Second), 0)
+	return rejectStyle.Render("failed, will retry in "+wait.String()) + keys
+}
@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/outbox"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// errOffline is a send that couldn't reach the API at all, so it's retried.
+var errOffline = fmt.Errorf("client.SendRoomMessage: %w", &client.NetworkError{Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}})
+
+// newOutboxHall returns a signed-in Hall sending through f into its own outbox.
+func newOutboxHall(f *clienttest.Fake) hallModel {
+	m := newTestHallModel()
+	m.client = f
+	m.myLogin = "me"
+	m.outbox = outbox.New()
+	return m
+}
+
+// sendInput types body into m and presses enter, returning the result of
+// the send.
+func sendInput(t *testing.T, m hallModel, body string) (hallModel, outboxSentMsg) {
+	t.Helper()
+	m.inputFocused = true
+	m.input = body
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, msg := range drainCmd(cmd) {
+		if sent, ok := msg.(outboxSentMsg); ok {
+			m, _ = m.Update(sent)
+			return m, sent
+		}
+	}
+	t.Fatal("expected enter to send")
+	return m, outboxSentMsg{}
+}
+
+func TestHallFailedSendStaysQueued(t *testing.T) {
+	f := &clienttest.Fake{Fail: map[string]error{"SendRoomMessage": errOffline}}
+	m := newOutboxHall(f)
+
+	m, sent := sendInput(t, m, "anyone around?")
+	if sent.err == nil {
+		t.Fatal("expected the send to fail")
+	}
+	pending := m.outbox.For(outbox.KindRoom, m.slug())
+	if len(pending) != 1 || !pending[0].Failed() || pending[0].RetryAt.IsZero() {
+		t.Fatalf("outbox = %+v, want one failed message due for a retry", pending)
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"anyone around?", "failed, will retry in", "ctrl+r retry"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "no messages yet") {
+		t.Error("a pending message should replace the empty-room note")
+	}
+
+	// Back online: ctrl+r sends it without waiting out the backoff.
+	f.Fail = nil
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	for _, msg := range drainCmd(cmd) {
+		m, _ = m.Update(msg)
+	}
+	if n := m.outbox.Len(); n != 0 {
+		t.Errorf("outbox has %d messages after a successful retry", n)
+	}
+	if got := f.RoomMessages[m.slug()]; len(got) != 1 || got[0].Body != "anyone around?" {
+		t.Errorf("API got %+v", got)
+	}
+}
+
+func TestHallRejectedSendWaitsForRetry(t *testing.T) {
+	rejected := &client.HTTPError{StatusCode: 422, Message: "message too long"}
+	f := &clienttest.Fake{Fail: map[string]error{"SendRoomMessage": rejected}}
+	m := newOutboxHall(f)
+
+	m, _ = sendInput(t, m, "a very long message")
+	if due := m.outbox.Due(time.Now().Add(time.Hour)); len(due) != 0 {
+		t.Errorf("a rejected message should not retry by itself, got %+v", due)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "failed: HTTP 422: message too long") {
+		t.Errorf("view missing the failure reason:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	if n := m.outbox.Len(); n != 0 {
+		t.Errorf("outbox has %d messages after ctrl+x", n)
+	}
+	if m.status != "unsent message discarded" {
+		t.Errorf("status = %q", m.status)
+	}
+}
+
+func TestThreadsFailedSendRetriesWhenDue(t *testing.T) {
+	threadID := uuid.New()
+	f := &clienttest.Fake{
+		Threads: []domain.Thread{{ID: threadID, OtherLogin: "ada"}},
+		Fail:    map[string]error{"SendMessage": errOffline},
+	}
+	a := NewApp(f, "dev")
+	a.threads.myLogin = "me"
+	a.threads.state = threadsConvoState
+	a.threads.openThreadID, a.threads.openThreadLogin = threadID.String(), "ada"
+
+	for _, msg := range drainCmd(a.threads.sendMessage("see you at 6")) {
+		model, _ := a.Update(msg)
+		a = model.(App)
+	}
+	if view := ansi.Strip(a.threads.View()); !strings.Contains(view, "see you at 6") || !strings.Contains(view, "will retry") {
+		t.Errorf("convo should show the pending message:\n%s", view)
+	}
+
+	// The outbox tick sends it again once the backoff has run out.
+	f.Fail = nil
+	model, cmd := a.Update(outboxTickMsg(time.Now().Add(outbox.MinBackoff)))
+	a = model.(App)
+	for _, msg := range drainCmd(cmd) {
+		if _, ok := msg.(outboxSentMsg); ok {
+			model, _ = a.Update(msg)
+			a = model.(App)
+		}
+	}
+	if n := a.outbox.Len(); n != 0 {
+		t.Errorf("outbox has %d messages after the retry", n)
+	}
+	if got := f.Messages[threadID.String()]; len(got) != 1 || got[0].Body != "see you at 6" {
+		t.Errorf("API got %+v", got)
+	}
+}
+
+func TestRetryDueSendsOneBatchPerConversation(t *testing.T) {
+	box := outbox.New()
+	now := time.Now()
+	for _, m := range []outbox.Message{
+		{Kind: outbox.KindRoom, Target: hallSlug, Body: "one"},
+		{Kind: outbox.KindRoom, Target: "go-help", Body: "elsewhere"},
+		{Kind: outbox.KindRoom, Target: hallSlug, Body: "two"},
+		{Kind: outbox.KindRoom, Target: hallSlug, Body: "three"},
+	} {
+		box.Fail(box.Add(m).ID, "offline", true, now)
+	}
+	due := now.Add(outbox.MinBackoff)
+
+	// While offline, each conversation tries once and keeps its backlog.
+	f := &clienttest.Fake{Fail: map[string]error{"SendRoomMessage": errOffline}}
+	if msgs := drainCmd(retryDueCmd(f, box, nil, due)); len(msgs) != 2 {
+		t.Fatalf("got %d results, want one per conversation", len(msgs))
+	}
+	if n := f.Count("SendRoomMessage"); n != 2 || box.Len() != 4 {
+		t.Fatalf("%d sends, %d queued; want one attempt per conversation and nothing lost", n, box.Len())
+	}
+
+	f = &clienttest.Fake{}
+	drainCmd(retryDueCmd(f, box, nil, now.Add(outbox.MaxBackoff)))
+	var bodies []string
+	for _, call := range f.Calls() {
+		if call.Args[0] == hallSlug {
+			bodies = append(bodies, call.Args[1].(string))
+		}
+	}
+	if strings.Join(bodies, ",") != "one,two,three" || box.Len() != 0 {
+		t.Errorf("sent %q, %d left; want the Hall's backlog in order", bodies, box.Len())
+	}
+}
+
+func TestOutboxSentMsgIgnoresOtherTargets(t *testing.T) {
+	m := newOutboxHall(&clienttest.Fake{})
+	m.status = "keep"
+	other := outbox.Message{Kind: outbox.KindRoom, Target: "elsewhere"}
+	m, cmd := m.Update(outboxSentMsg{msg: other, err: errOffline})
+	if cmd != nil || m.status != "keep" {
+		t.Errorf("a send to another room changed the Hall: status %q", m.status)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errOffline, true},
+		{&client.HTTPError{StatusCode: 429}, true},
+		{&client.HTTPError{StatusCode: 503}, true},
+		// These may come after the message was posted.
+		{&client.NetworkError{Err: context.DeadlineExceeded}, false},
+		{&client.HTTPError{StatusCode: 502}, false},
+		{&client.HTTPError{StatusCode: 500}, false},
+		{&client.HTTPError{StatusCode: 422}, false},
+		{&client.HTTPError{StatusCode: 401}, false},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.err); got != tt.want {
+			t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestPendingStatus(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		msg  outbox.Message
+		want string
+	}{
+		{outbox.Message{Sending: true}, "sending…"},
+		{outbox.Message{Err: "offline", RetryAt: now.Add(4 * time.Second)}, "failed, will retry in 4s"},
+		{outbox.Message{Err: "offline", RetryAt: now.Add(-time.Second)}, "failed, will retry in 0s"},
+		{outbox.Message{Err: "not a member"}, "failed: not a member"},
+	}
+	for _, tt := range tests {
+		if got := ansi.Strip(pendingStatus(tt.msg, now)); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("pendingStatus(%+v) = %q, want prefix %q", tt.msg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+// ansi16 maps palette colors to hand-picked ANSI colors for 16-color
+// terminals. Nearest-match degradation turns the dark greys black and folds
+// most accents into the same two or three colors.
+var ansi16 = map[string]string{
+	// Neutrals
+	"#e4e4ec": "15",
+	"#c0c4d0": "7",
+	"#8890a0": "7",
+	"#606878": "8",
+	"#505868": "8",
+	"#404858": "8",
+	"#343c4a": "8",
+	"#1e1e2a": "0",
+	"#111118": "0",
+	// Greens
+	"#4ade80": "10",
+	"#43e88c": "10",
+	"#86efac": "10",
+	"#34d474": "2",
+	// Golds and oranges
+	"#ffd700": "11",
+	"#d4a844": "3",
+	"#c8a84c": "3",
+	"#f59e0b": "3",
+	"#f0944a": "9",
+	// Reds
+	"#e06060": "9",
+	"#d05050": "1",
+	"#b45555": "1",
+	// Blues, cyans and purples
+	"#22d3ee": "14",
+	"#3ecce4": "6",
+	"#60a0e0": "12",
+	"#7aa2f7": "12",
+	"#b8ccdf": "12",
+	"#b080d0": "5",
+	"#c084e0": "13",
+	// Cursor, potency and event accents
+	"#facc15": "11",
+	"#fbbf24": "11",
+	"#fff":    "15",
+	"#D4A017": "3",
+	"#92711a": "3",
+	"#60a5fa": "12",
+	"#f87171": "9",
+	"#8891a5": "7",
+}
+
+// paletteColor returns a color that renders hex exactly on truecolor
+// terminals, as the nearest match on 256-color terminals, and as its
+// ansi16 entry on 16-color terminals.
+func paletteColor(hex string) lipgloss.TerminalColor {
+	ansi, ok := ansi16[hex]
+	if !ok {
+		ansi = hex
+	}
+	return lipgloss.CompleteColor{TrueColor: hex, ANSI256: hex, ANSI: ansi}
+}
+
+// shimmerRamp256 is the logo's green ramp on 256-color terminals, dark to
+// bright. Blending between arbitrary greens there just flickers between
+// whichever cube colors are nearest.
+var shimmerRamp256 = []string{"22", "28", "29", "35", "41", "78", "84"}
+
+func rgbHex(r, g, b int) string {
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
+}
+
+// shimmerColor returns the logo color for brightness b (0..1) under profile.
+func shimmerColor(profile termenv.Profile, b float64) lipgloss.TerminalColor {
+	switch profile {
+	case termenv.TrueColor:
+		// Continuous RGB interpolation: deep forest green -> bright emerald
+		// Deep:   (26, 58, 36)   #1a3a24
+		// Bright: (74, 222, 128) #4ade80
+		r := clampByte(26 + b*(74-26))
+		g := clampByte(58 + b*(222-58))
+		bl := clampByte(36 + b*(128-36))
+		return lipgloss.Color(rgbHex(r, g, bl))
+	case termenv.ANSI256:
+		i := int(b*float64(len(shimmerRamp256)-1) + 0.5)
+		return lipgloss.Color(shimmerRamp256[min(max(i, 0), len(shimmerRamp256)-1)])
+	case termenv.ANSI:
+		if b > 0.55 {
+			return lipgloss.Color("10")
+		}
+		return lipgloss.Color("2")
+	}
+	return lipgloss.NoColor{}
+}
+
+// emojiEmblems selects emoji guild emblems; when false GuildEmblem returns
+// the ASCII fallbacks.
+var emojiEmblems = true
+
+// asciiEmblems are two-cell stand-ins for the guild emoji, so layouts keep
+// their width.
+var asciiEmblems = map[string]string{
+	"loomari":  "Lo",
+	"ashborne": "As",
+	"amarok":   "Am",
+	"nyx":      "Ny",
+	"cipher":   "Ci",
+	"fathom":   "Fa",
+}
+
+// colorProfiles maps the config "color" setting to a termenv profile.
+var colorProfiles = map[string]termenv.Profile{
+	config.ColorTrueColor: termenv.TrueColor,
+	config.Color256:       termenv.ANSI256,
+	config.Color16:        termenv.ANSI,
+	config.ColorNone:      termenv.Ascii,
+}
+
+// applyTerminal sets the color profile, glyph set and emblem set. Explicit
+// color and glyphs settings override what is detected; emoji emblems need
+// Unicode glyphs and no ascii_emblems preference.
+func applyTerminal(cfg config.Config, goos string, getenv func(string) string) {
+	if p, ok := colorProfiles[cfg.Color]; ok {
+		lipgloss.SetColorProfile(p)
+	}
+	switch cfg.Glyphs {
+	case config.GlyphsUnicode:
+		asciiGlyphs = false
+	case config.GlyphsASCII:
+		asciiGlyphs = true
+	default:
+		asciiGlyphs = !supportsUnicode(goos, getenv)
+	}
+	emojiEmblems = !cfg.ASCIIEmblems && !asciiGlyphs && supportsUnicode(goos, getenv)
+}
+
+// supportsUnicode guesses whether the terminal can draw the Unicode glyph
+// set and emoji. The Linux console, VT-style and dumb terminals can't, and
+// neither can anything in a non-UTF-8 locale or the legacy Windows console.
+// With no locale set at all, assume a modern terminal.
+func supportsUnicode(goos string, getenv func(string) string) bool {
+	switch term := getenv("TERM"); {
+	case term == "linux", term == "dumb", strings.HasPrefix(term, "vt"):
+		return false
+	}
+	for _, k := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := strings.ToLower(getenv(k)); v != "" {
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	if goos == "windows" {
+		return modernWindowsTerminal(getenv)
+	}
+	return true
+}
+
+// modernWindowsTerminal reports whether a Windows session runs in a terminal
+// that draws Unicode: Windows Terminal, ConEmu, VS Code, or anything that
+// sets TERM such as mintty. The classic console host sets none of these.
+func modernWindowsTerminal(getenv func(string) string) bool {
+	return getenv("WT_SESSION") != "" || getenv("TERM_PROGRAM") != "" ||
+		getenv("ConEmuANSI") == "ON" || getenv("TERM") != ""
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/naveenspark/grimora/internal/config"
+)
+
+func envFunc(env map[string]string) func(string) string {
+	return func(k string) string { return env[k] }
+}
+
+func TestSupportsUnicode(t *testing.T) {
+	tests := []struct {
+		goos string
+		env  map[string]string
+		want bool
+	}{
+		{"linux", map[string]string{}, true},
+		{"linux", map[string]string{"TERM": "xterm-256color", "LANG": "en_US.UTF-8"}, true},
+		{"linux", map[string]string{"TERM": "xterm", "LC_ALL": "C.utf8", "LANG": "C"}, true},
+		{"linux", map[string]string{"TERM": "linux", "LANG": "en_US.UTF-8"}, false},
+		{"linux", map[string]string{"TERM": "vt100"}, false},
+		{"linux", map[string]string{"TERM": "dumb"}, false},
+		{"linux", map[string]string{"TERM": "xterm", "LANG": "C"}, false},
+		{"linux", map[string]string{"TERM": "xterm", "LC_CTYPE": "en_US.ISO-8859-1"}, false},
+		{"windows", map[string]string{}, false},
+		{"windows", map[string]string{"WT_SESSION": "1f2e"}, true},
+		{"windows", map[string]string{"TERM_PROGRAM": "vscode"}, true},
+		{"windows", map[string]string{"TERM": "xterm-256color"}, true},
+	}
+	for _, tt := range tests {
+		if got := supportsUnicode(tt.goos, envFunc(tt.env)); got != tt.want {
+			t.Errorf("supportsUnicode(%s, %v) = %v, want %v", tt.goos, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestPaletteColorANSI(t *testing.T) {
+	c, ok := paletteColor("#343c4a").(lipgloss.CompleteColor)
+	if !ok {
+		t.Fatalf("paletteColor returned %T, want CompleteColor", paletteColor("#343c4a"))
+	}
+	if c.TrueColor != "#343c4a" || c.ANSI != "8" {
+		t.Errorf("got %+v, want truecolor #343c4a and ANSI 8", c)
+	}
+	// Unmapped colors fall back to lipgloss's nearest match.
+	if c := paletteColor("#123456").(lipgloss.CompleteColor); c.ANSI != "#123456" {
+		t.Errorf("unmapped ANSI = %q, want the hex", c.ANSI)
+	}
+}
+
+func TestShimmerColorDegrades(t *testing.T) {
+	if got := shimmerColor(termenv.TrueColor, 1); got != lipgloss.Color("#4ADE80") {
+		t.Errorf("truecolor bright = %v, want #4ADE80", got)
+	}
+	if got := shimmerColor(termenv.ANSI256, 0); got != lipgloss.Color(shimmerRamp256[0]) {
+		t.Errorf("256 dark = %v, want %s", got, shimmerRamp256[0])
+	}
+	if got := shimmerColor(termenv.ANSI256, 1); got != lipgloss.Color(shimmerRamp256[len(shimmerRamp256)-1]) {
+		t.Errorf("256 bright = %v, want top of ramp", got)
+	}
+	if got := shimmerColor(termenv.ANSI, 1); got != lipgloss.Color("10") {
+		t.Errorf("ANSI bright = %v, want 10", got)
+	}
+	if _, ok := shimmerColor(termenv.Ascii, 1).(lipgloss.NoColor); !ok {
+		t.Error("Ascii profile should render the logo without color")
+	}
+}
+
+func TestApplyTerminalASCIIEmblems(t *testing.T) {
+	defer func() { emojiEmblems, asciiGlyphs = true, false }()
+	env := envFunc(map[string]string{"LANG": "en_US.UTF-8"})
+
+	applyTerminal(config.Config{ASCIIEmblems: true}, "linux", env)
+	if got := GuildEmblem("nyx"); got != "Ny" {
+		t.Errorf("ascii emblem = %q, want Ny", got)
+	}
+	applyTerminal(config.Config{}, "linux", env)
+	if got := GuildEmblem("nyx"); got == "Ny" {
+		t.Error("expected the emoji emblem back")
+	}
+	applyTerminal(config.Config{}, "linux", envFunc(map[string]string{"TERM": "linux"}))
+	if got := GuildEmblem("cipher"); got != "Ci" {
+		t.Errorf("linux console emblem = %q, want Ci", got)
+	}
+}
+
+func TestApplyTerminalGlyphs(t *testing.T) {
+	defer func() { emojiEmblems, asciiGlyphs = true, false }()
+	console := envFunc(map[string]string{"TERM": "linux"})
+
+	applyTerminal(config.Config{}, "linux", console)
+	if !asciiGlyphs {
+		t.Error("expected ASCII glyphs on the Linux console")
+	}
+	applyTerminal(config.Config{Glyphs: config.GlyphsUnicode}, "linux", console)
+	if asciiGlyphs {
+		t.Error("glyphs: unicode should override detection")
+	}
+	applyTerminal(config.Config{Glyphs: config.GlyphsASCII}, "linux", envFunc(map[string]string{"LANG": "en_US.UTF-8"}))
+	if !asciiGlyphs || GuildEmblem("nyx") != "Ny" {
+		t.Error("glyphs: ascii should force ASCII glyphs and emblems")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -12,6 +13,21 @@ import (
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
+// availabilityHint guesses from m's time zone and active hours whether
+// they're around, e.g. "active now · 14:05 local". It returns "" when they
+// haven't set a time zone.
+func availabilityHint(m domain.Magician, now time.Time) string {
+	local, ok := m.LocalTime(now)
+	if !ok {
+		return ""
+	}
+	clock := dimStyle.Render(" · " + local.Format("15:04") + " local")
+	if m.AvailabilityAt(now) == domain.AvailabilityActive {
+		return presenceDotStyle.Render("active now") + clock
+	}
+	return dimStyle.Render("likely asleep") + clock
+}
+
 type peekLoadedMsg struct {
 	card *domain.MagicianCard
 	err  error
@@ -22,6 +38,15 @@ type peekWorkshopMsg struct {
 	err      error
 }
 
+// peekSpellsMsg carries the magician's most recent spells.
+type peekSpellsMsg struct {
+	spells []domain.Spell
+	err    error
+}
+
+// peekRecentSpells is how many recent spells the peek card lists.
+const peekRecentSpells = 3
+
 type peekFollowMsg struct {
 	login string
 	err   error
@@ -34,17 +59,20 @@ type peekProjectUpdatesMsg struct {
 }
 
 type peekModel struct {
-	client         *client.Client
+	client         client.API
 	card           *domain.MagicianCard
 	projects       []domain.WorkshopProject
+	spells         []domain.Spell
 	projectUpdates map[string][]domain.ProjectUpdate
+	myLogin        string
+	blocked        bool // you've blocked the peeked magician
 	closed         bool
 	err            string
 	width          int
 }
 
-func newPeekModel(c *client.Client) peekModel {
-	return peekModel{client: c, projectUpdates: make(map[string][]domain.ProjectUpdate)}
+func newPeekModel(c client.API, myLogin string) peekModel {
+	return peekModel{client: c, myLogin: myLogin, projectUpdates: make(map[string][]domain.ProjectUpdate)}
 }
 
 func (m peekModel) load(login string) tea.Cmd {
@@ -63,14 +91,32 @@ func (m peekModel) load(login string) tea.Cmd {
 		}
 		return peekWorkshopMsg{projects: projects}
 	}
-	return tea.Batch(cardCmd, workshopCmd)
+	spellsCmd := func() tea.Msg {
+		spells, err := c.ListMagicianSpells(context.Background(), login, peekRecentSpells)
+		return peekSpellsMsg{spells: spells, err: err}
+	}
+	return tea.Batch(cardCmd, workshopCmd, spellsCmd)
+}
+
+// helpKeys returns the help bar for the peek card.
+func (m peekModel) helpKeys() string {
+	block := "block"
+	if m.blocked {
+		block = "unblock"
+	}
+	return helpEntry("f", "follow") + "  " + helpEntry("d", "message") + "  " + helpEntry("b", block) + "  " + helpEntry("esc", "close")
+}
+
+// isSelf reports whether the peeked magician is the user.
+func (m peekModel) isSelf() bool {
+	return m.card != nil && m.myLogin != "" && m.card.GitHubLogin == m.myLogin
 }
 
 func (m peekModel) Update(msg tea.Msg) (peekModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case peekLoadedMsg:
 		if msg.err != nil {
-			m.err = msg.err.Error()
+			m.err = errReason(msg.err)
 		} else {
 			m.card = msg.card
 		}
@@ -97,6 +143,12 @@ func (m peekModel) Update(msg tea.Msg) (peekModel, tea.Cmd) {
 		}
 		return m, nil
 
+	case peekSpellsMsg:
+		if msg.err == nil {
+			m.spells = msg.spells[:min(len(msg.spells), peekRecentSpells)]
+		}
+		return m, nil
+
 	case peekProjectUpdatesMsg:
 		if msg.err == nil {
 			m.projectUpdates[msg.projectID] = msg.updates
@@ -105,12 +157,20 @@ func (m peekModel) Update(msg tea.Msg) (peekModel, tea.Cmd) {
 
 	case peekFollowMsg:
 		if msg.err != nil {
-			m.err = msg.err.Error()
+			m.err = errReason(msg.err)
 		} else if m.card != nil {
 			m.card.IsFollowing = !m.card.IsFollowing
 		}
 		return m, nil
 
+	case blockResultMsg:
+		if msg.err != nil {
+			m.err = errReason(msg.err)
+		} else if m.card != nil && m.card.GitHubLogin == msg.login {
+			m.blocked = msg.blocked
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		return m, nil
@@ -134,6 +194,16 @@ func (m peekModel) Update(msg tea.Msg) (peekModel, tea.Cmd) {
 					return peekFollowMsg{login: login, err: err}
 				}
 			}
+		case "d":
+			if m.card != nil && !m.isSelf() {
+				login := m.card.GitHubLogin
+				m.closed = true
+				return m, func() tea.Msg { return openDMMsg{login: login} }
+			}
+		case "b":
+			if m.card != nil && !m.isSelf() {
+				return m, blockCmd(m.client, m.card.GitHubLogin, !m.blocked)
+			}
 		}
 	}
 	return m, nil
@@ -166,21 +236,27 @@ func (m peekModel) View() string {
 	if emblem != "" {
 		sb.WriteString(emblem + " ")
 	}
-	sb.WriteString(selectedStyle.Render(card.GitHubLogin) + "\n")
+	sb.WriteString(selectedStyle.Render(card.GitHubLogin))
+	if card.FollowsYou {
+		sb.WriteString("  " + accentStyle.Render("follows you"))
+	}
+	if m.blocked {
+		sb.WriteString("  " + dimStyle.Render("blocked"))
+	}
+	sb.WriteString("\n")
 
 	// Guild + presence dot + city
 	if card.GuildID != "" {
 		sb.WriteString("   " + GuildStyle(card.GuildID).Render(card.GuildID))
 	}
-	if card.Online {
-		sb.WriteString("  " + presenceDotStyle.Render("●") + " " + presenceDotStyle.Render("online"))
-	} else {
-		sb.WriteString("  " + dimStyle.Render("○") + " " + dimStyle.Render("offline"))
-	}
+	sb.WriteString("  " + presenceLabel(card.Online, card.Away))
 	if card.City != "" {
 		sb.WriteString(" · " + metaStyle.Render(card.City))
 	}
 	sb.WriteString("\n")
+	if hint := availabilityHint(card.Magician, clock()); hint != "" {
+		sb.WriteString("   " + hint + "\n")
+	}
 
 	// Stats
 	sb.WriteString(metaStyle.Render("---") + "\n")
@@ -189,6 +265,17 @@ func (m peekModel) View() string {
 	sb.WriteString(metaStyle.Render(stats) + "\n")
 	sb.WriteString(metaStyle.Render("---") + "\n")
 
+	// Recent spells
+	if len(m.spells) > 0 {
+		sb.WriteString("\n" + sectionHeaderStyle.Render("── RECENT SPELLS ──") + "\n")
+		for _, sp := range m.spells {
+			potency := potencyStyle(sp.Potency).Render(fmt.Sprintf("P%d", sp.Potency))
+			title := truncStr(spellTitle(sp), max(cardWidth-4-2-lipgloss.Width(potency)-2, 10))
+			pad := max(cardWidth-4-2-lipgloss.Width(title)-lipgloss.Width(potency), 2)
+			sb.WriteString("  " + normalStyle.Render(title) + strings.Repeat(" ", pad) + potency + "\n")
+		}
+	}
+
 	// Workshop section with timelines
 	if len(m.projects) > 0 {
 		sb.WriteString("\n" + sectionHeaderStyle.Render("── BUILD JOURNAL ──") + "\n")
@@ -230,10 +317,7 @@ func (m peekModel) View() string {
 							sb.WriteString("    " + dimStyle.Render("│") + " " + dimStyle.Render(u.Body) + "\n")
 						}
 					default:
-						body := u.Body
-						if len([]rune(body)) > 30 {
-							body = string([]rune(body)[:29]) + "…"
-						}
+						body := truncStr(u.Body, 30)
 						sb.WriteString("    " + dimStyle.Render("●") + " " + dimStyle.Render(body) + "  " + ts + "\n")
 					}
 					if j < len(updates)-1 {
@@ -255,6 +339,9 @@ func (m peekModel) View() string {
 	} else {
 		sb.WriteString(helpKeyStyle.Render("f") + " " + helpLabelStyle.Render("follow"))
 	}
+	if !m.isSelf() {
+		sb.WriteString("  " + helpKeyStyle.Render("d") + " " + helpLabelStyle.Render("message"))
+	}
 	sb.WriteString("  " + helpKeyStyle.Render("esc") + " " + helpLabelStyle.Render("close"))
 
 	return "\n" + border.Render(sb.String())
@@ -9,11 +9,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
 func newTestPeekModel() peekModel {
-	return newPeekModel(nil)
+	return newPeekModel(nil, "me")
 }
 
 func makeTestMagicianCard(login, guildID string, online bool) *domain.MagicianCard {
@@ -182,6 +183,83 @@ func TestPeekFollowKeySendsCommand(t *testing.T) {
 	}
 }
 
+func TestPeekRecentSpellsAndFollowsYou(t *testing.T) {
+	m := newTestPeekModel()
+	m.width = 80
+	card := makeTestMagicianCard("octo", "cipher", true)
+	card.FollowsYou = true
+	m, _ = m.Update(peekLoadedMsg{card: card})
+	m, _ = m.Update(peekSpellsMsg{spells: []domain.Spell{
+		{Text: "Bisect the regression", Potency: 4},
+		{Text: "Write the failing test first", Potency: 2},
+		{Text: "Name the invariant", Potency: 1},
+		{Text: "One too many", Potency: 1},
+	}})
+
+	view := m.View()
+	for _, want := range []string{"follows you", "RECENT SPELLS", "Bisect the regression", "P4", "Name the invariant"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("peek view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "One too many") {
+		t.Errorf("peek view lists more than %d spells:\n%s", peekRecentSpells, view)
+	}
+}
+
+func TestPeekDMKey(t *testing.T) {
+	m := newTestPeekModel()
+	m, _ = m.Update(peekLoadedMsg{card: makeTestMagicianCard("octo", "cipher", true)})
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if cmd == nil {
+		t.Fatal("expected a command on 'd'")
+	}
+	if msg, ok := cmd().(openDMMsg); !ok || msg.login != "octo" {
+		t.Errorf("'d' sent %#v, want openDMMsg for octo", cmd())
+	}
+	if !m.closed {
+		t.Error("peek should close when starting a DM")
+	}
+
+	self := newTestPeekModel()
+	self, _ = self.Update(peekLoadedMsg{card: makeTestMagicianCard("me", "cipher", true)})
+	if _, cmd := self.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")}); cmd != nil {
+		t.Error("'d' on your own card should do nothing")
+	}
+	if strings.Contains(self.View(), "message") {
+		t.Error("own card should not offer to message yourself")
+	}
+}
+
+func TestPeekDMOpensThread(t *testing.T) {
+	existing := domain.Thread{ID: uuid.New(), OtherLogin: "mona"}
+	f := &clienttest.Fake{Threads: []domain.Thread{existing}}
+	a := NewApp(f, "dev")
+	a.threads.threads = f.Threads
+	a.peekOpen = true
+
+	model, cmd := a.Update(openDMMsg{login: "mona"})
+	a = model.(App)
+	if a.peekOpen || a.view != viewThreads {
+		t.Fatalf("peekOpen = %v, view = %v; want the threads view", a.peekOpen, a.view)
+	}
+	if a.threads.openThreadID != existing.ID.String() || cmd == nil {
+		t.Errorf("opened thread %q, want the existing thread with mona", a.threads.openThreadID)
+	}
+	if f.Count("StartThread") != 0 {
+		t.Error("an existing thread should open without starting a new one")
+	}
+
+	model, cmd = a.Update(openDMMsg{login: "octo"})
+	a = model.(App)
+	model, _ = a.Update(cmd())
+	a = model.(App)
+	if a.threads.openThreadLogin != "octo" || f.Count("StartThread") != 1 {
+		t.Errorf("open thread with %q after %d StartThread calls, want octo after 1", a.threads.openThreadLogin, f.Count("StartThread"))
+	}
+}
+
 func TestPeekLoadingStateBeforeCard(t *testing.T) {
 	m := newTestPeekModel()
 	// No peekLoadedMsg sent — card is nil
@@ -204,3 +282,27 @@ func TestPeekStatsDisplayed(t *testing.T) {
 		t.Errorf("expected spell count '42' in peek stats, got:\n%s", view)
 	}
 }
+
+func TestAvailabilityHint(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	if got := availabilityHint(domain.Magician{}, now); got != "" {
+		t.Errorf("expected no hint without a time zone, got %q", got)
+	}
+	if got := availabilityHint(domain.Magician{Timezone: "Europe/Berlin", ActiveHours: "9-18"}, now); got != "active now · 14:00 local" {
+		t.Errorf("hint = %q", got)
+	}
+	if got := availabilityHint(domain.Magician{Timezone: "Asia/Tokyo", ActiveHours: "9-18"}, now); got != "likely asleep · 21:00 local" {
+		t.Errorf("hint = %q", got)
+	}
+}
+
+func TestPeekShowsAvailability(t *testing.T) {
+	m := newTestPeekModel()
+	card := makeTestMagicianCard("wizarduser", "cipher", false)
+	card.Timezone = "UTC"
+	card.ActiveHours = "0-24"
+	m, _ = m.Update(peekLoadedMsg{card: card})
+	if view := m.View(); !strings.Contains(view, "active now") {
+		t.Errorf("expected availability hint in peek view, got:\n%s", view)
+	}
+}
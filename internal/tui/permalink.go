@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// linkSpellMsg carries the spell a permalink points at.
+type linkSpellMsg struct {
+	spell *domain.Spell
+	err   error
+}
+
+// WithLink opens the app on what a grimora.ai permalink points at: a
+// message is selected in its room once the room loads, a spell opens in
+// the Grimoire and a magician in the peek overlay. It takes precedence over
+// a saved session.
+func (a App) WithLink(l domain.Link) App {
+	a.link = &l
+	if l.Kind == domain.LinkMessage {
+		a.view = viewHall
+		a.hall = a.hall.enterRoom(l.Room, l.Room)
+		a.hall.focusID = l.ID
+	}
+	return a
+}
+
+// openLinkCmd fetches what a spell or magician permalink points at. Message
+// links need nothing beyond the room's first load.
+func openLinkCmd(c client.API, l domain.Link) tea.Cmd {
+	switch l.Kind {
+	case domain.LinkSpell:
+		return func() tea.Msg {
+			spell, err := c.GetSpell(context.Background(), l.ID)
+			return linkSpellMsg{spell: spell, err: err}
+		}
+	case domain.LinkMagician:
+		return func() tea.Msg { return showPeekMsg{login: l.Login} }
+	}
+	return nil
+}
+
+// showLinkSpell opens the spell a permalink points at in the Grimoire.
+func (a App) showLinkSpell(msg linkSpellMsg) (App, tea.Cmd) {
+	if msg.err != nil {
+		a.hall.status = errText("could not open spell", msg.err)
+		return a, nil
+	}
+	a.grimoire = a.grimoire.showSpell(*msg.spell)
+	a.view = viewGrimoire
+	return a, nil
+}
+
+// focusMessage selects the message a permalink opened the room on. Only
+// the latest pageSize messages are fetched, so an older one can't be shown.
+func (m hallModel) focusMessage() hallModel {
+	id := m.focusID
+	m.focusID = ""
+	if !m.seenIDs[id] {
+		m.status = "that message is no longer among the latest · showing the room as it is now"
+		return m
+	}
+	m.inputFocused = false
+	m.selecting = true
+	m.selectedID = id
+	m.ensureSelectedVisible()
+	return m
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestHallCopyPermalink(t *testing.T) {
+	m := newTestHallSelectModel("first", "second")
+	m.room = "guild-loomari"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("expected y to copy the permalink")
+	}
+	msg, ok := cmd().(hallCopiedMsg)
+	if !ok {
+		t.Fatal("expected a hallCopiedMsg")
+	}
+	want := "https://grimora.ai/hall/guild-loomari/" + m.messages[1].ID
+	if msg.link != want {
+		t.Errorf("link = %q, want %q", msg.link, want)
+	}
+
+	m, _ = m.Update(hallCopiedMsg{link: want})
+	if m.status != "copied "+want {
+		t.Errorf("status = %q", m.status)
+	}
+	m, _ = m.Update(hallCopiedMsg{link: want, err: &testErr{"no xclip"}})
+	if !strings.Contains(m.status, want) {
+		t.Errorf("expected the link in the status without a clipboard, got %q", m.status)
+	}
+}
+
+func TestWithLinkSelectsMessage(t *testing.T) {
+	id := uuid.New()
+	a := newTestApp().WithLink(domain.Link{Kind: domain.LinkMessage, Room: "guild-loomari", ID: id.String()})
+	if a.view != viewHall || a.hall.room != "guild-loomari" {
+		t.Fatalf("view %v room %q, want the guild room in the Hall", a.view, a.hall.room)
+	}
+
+	model, _ := a.Update(hallMessagesMsg{room: "guild-loomari", messages: []domain.RoomMessage{
+		{ID: id, SenderLogin: "ada", Body: "linked", CreatedAt: time.Now().Add(-time.Minute)},
+		{ID: uuid.New(), SenderLogin: "grace", Body: "later", CreatedAt: time.Now()},
+	}})
+	a = model.(App)
+	if !a.hall.selecting || a.hall.selectedID != id.String() {
+		t.Errorf("selecting %v %q, want the linked message selected", a.hall.selecting, a.hall.selectedID)
+	}
+	if a.hall.inputFocused {
+		t.Error("expected nav mode so the selection keys work")
+	}
+}
+
+func TestWithLinkMissingMessage(t *testing.T) {
+	a := newTestApp().WithLink(domain.Link{Kind: domain.LinkMessage, ID: uuid.NewString()})
+	model, _ := a.Update(hallMessagesMsg{room: hallSlug, messages: []domain.RoomMessage{
+		{ID: uuid.New(), SenderLogin: "ada", Body: "hello", CreatedAt: time.Now()},
+	}})
+	a = model.(App)
+	if a.hall.selecting || a.hall.focusID != "" {
+		t.Error("expected nothing selected and the focus dropped")
+	}
+	if !strings.Contains(a.hall.status, "no longer among the latest") {
+		t.Errorf("status = %q", a.hall.status)
+	}
+}
+
+func TestWithLinkOpensSpell(t *testing.T) {
+	spell := domain.Spell{ID: uuid.New(), Text: "bisect before you guess"}
+	f := &clienttest.Fake{Spells: []domain.Spell{spell}}
+	a := NewApp(f, "dev").WithLink(domain.Link{Kind: domain.LinkSpell, ID: spell.ID.String()})
+	model, _ := a.Update(openLinkCmd(f, *a.link)())
+	a = model.(App)
+	if a.view != viewGrimoire || !a.grimoire.detail || a.grimoire.spells[a.grimoire.cursor].ID != spell.ID {
+		t.Errorf("view %v, want the spell open in the Grimoire", a.view)
+	}
+
+	a = NewApp(f, "dev").WithLink(domain.Link{Kind: domain.LinkSpell, ID: uuid.NewString()})
+	model, _ = a.Update(openLinkCmd(f, *a.link)())
+	a = model.(App)
+	if a.view != viewHall || !strings.Contains(a.hall.status, "could not open spell") {
+		t.Errorf("view %v status %q, want an error in the Hall", a.view, a.hall.status)
+	}
+}
+
+func TestWithLinkPeeksMagician(t *testing.T) {
+	msg := openLinkCmd(nil, domain.Link{Kind: domain.LinkMagician, Login: "ada"})()
+	if peek, ok := msg.(showPeekMsg); !ok || peek.login != "ada" {
+		t.Errorf("msg = %#v, want a peek at ada", msg)
+	}
+}
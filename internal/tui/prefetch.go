@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"maps"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Background prefetching keeps the Grimoire, Board and You tabs warm so
+// switching to them shows their data straight away instead of "loading…".
+const (
+	prefetchDelay    = 3 * time.Second // let the first view load before warming others
+	prefetchGap      = time.Second     // between one tab's fetch and the next
+	prefetchInterval = 2 * time.Minute // between rounds
+	prefetchFresh    = 2 * time.Minute // data younger than this isn't refetched on a tab switch
+)
+
+// prefetchViews are the tabs warmed in the background, in order.
+var prefetchViews = []view{viewGrimoire, viewBoard, viewYou}
+
+// prefetchTickMsg fires when the next tab is due to be warmed.
+type prefetchTickMsg struct{}
+
+func prefetchTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return prefetchTickMsg{} })
+}
+
+// prefetch warms the next tab in prefetchViews, unless it's on screen or
+// already fresh. Rounds stretch with the rate limit and pause entirely while
+// it's tight, while the app is idle or signed out, and in low-bandwidth mode.
+func (a App) prefetch() (App, tea.Cmd) {
+	var cmd tea.Cmd
+	quiet := lowBandwidth || a.degraded != "" || a.away || a.locked || rateLimited(a.client)
+	if v := prefetchViews[a.prefetchNext]; !quiet && v != a.view && !a.fresh(v) {
+		cmd = a.initFor(v)
+	}
+	a.prefetchNext = (a.prefetchNext + 1) % len(prefetchViews)
+	next := prefetchGap
+	if a.prefetchNext == 0 {
+		next = pollDelay(a.client, prefetchInterval)
+	}
+	return a, tea.Batch(cmd, prefetchTickCmd(next))
+}
+
+// fetched records that v's data arrived, unless the fetch failed.
+func (a App) fetched(v view, err error) App {
+	if err != nil {
+		return a
+	}
+	// App is copied by value, so never write to a map another copy holds.
+	at := maps.Clone(a.fetchedAt)
+	if at == nil {
+		at = make(map[view]time.Time)
+	}
+	at[v] = clock()
+	a.fetchedAt = at
+	return a
+}
+
+// fresh reports whether v's data arrived recently enough to show as is.
+func (a App) fresh(v view) bool {
+	at, ok := a.fetchedAt[v]
+	return ok && clock().Sub(at) < prefetchFresh
+}
+
+// tabInit loads the tab just switched to, or, when a prefetch or earlier
+// visit left it fresh, just picks up where it left off.
+func (a App) tabInit() tea.Cmd {
+	if !a.fresh(a.view) {
+		return a.viewInit()
+	}
+	if a.view == viewBoard {
+		return a.board.resumeSync()
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func newPrefetchTestApp(t *testing.T, fake *clienttest.Fake) App {
+	t.Helper()
+	now := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+	old := clock
+	clock = func() time.Time { return now }
+	t.Cleanup(func() { clock = old })
+
+	fake.Spells = []domain.Spell{makeTestSpell("Trace the flaky test before touching it", "debug")}
+	fake.Leaderboard = []domain.LeaderboardEntry{{Rank: 1, Login: "alice", GuildID: "nyx"}}
+	fake.Projects = []domain.WorkshopProject{{ID: uuid.New(), Name: "grimora"}}
+	return newSizedTestApp(fake, 80, 30)
+}
+
+// prefetchRound runs one prefetch round, feeding what it loads back in.
+func prefetchRound(a App) App {
+	for range prefetchViews {
+		model, cmd := a.Update(prefetchTickMsg{})
+		a = model.(App)
+		for _, msg := range drainCmd(cmd) {
+			model, _ = a.Update(msg)
+			a = model.(App)
+		}
+	}
+	return a
+}
+
+func TestPrefetchWarmsTabs(t *testing.T) {
+	fake := &clienttest.Fake{}
+	a := prefetchRound(newPrefetchTestApp(t, fake))
+
+	for _, method := range []string{"ListSpells", "GetLeaderboard", "ListWorkshopProjects"} {
+		if n := fake.Count(method); n != 1 {
+			t.Errorf("%s called %d times by the prefetch, want 1", method, n)
+		}
+	}
+	if len(a.board.entries) != 1 || len(a.you.projects) != 1 {
+		t.Errorf("board = %d entries, you = %d projects; want both warmed off screen", len(a.board.entries), len(a.you.projects))
+	}
+
+	model, cmd := a.Update(keyRune('2'))
+	a = model.(App)
+	if cmd != nil {
+		t.Error("switching to a freshly prefetched tab shouldn't refetch it")
+	}
+	if view := a.View(); strings.Contains(view, "loading") || !strings.Contains(view, "Trace the flaky test") {
+		t.Errorf("expected the prefetched spells straight away, got:\n%s", view)
+	}
+
+	model, cmd = a.Update(keyRune('4'))
+	if cmd == nil {
+		t.Error("switching to a prefetched board should resume its sync")
+	}
+	if fake.Count("GetLeaderboard") != 1 {
+		t.Error("switching to a freshly prefetched board shouldn't refetch it")
+	}
+
+	a = model.(App)
+	clock = func() time.Time { return time.Date(2026, 3, 14, 15, 5, 0, 0, time.UTC) }
+	_, cmd = a.Update(keyRune('5'))
+	drainCmd(cmd)
+	if fake.Count("ListWorkshopProjects") != 2 {
+		t.Error("a tab prefetched minutes ago should load again")
+	}
+}
+
+func TestPrefetchSkipsOnScreenTab(t *testing.T) {
+	fake := &clienttest.Fake{}
+	a := newPrefetchTestApp(t, fake)
+	a.view = viewBoard
+	prefetchRound(a)
+	if fake.Count("GetLeaderboard") != 0 || fake.Count("ListSpells") != 1 {
+		t.Errorf("calls = %v, want the Grimoire warmed but not the board on screen", fake.Calls())
+	}
+}
+
+func TestPrefetchPausesWhenRateLimited(t *testing.T) {
+	fake := &clienttest.Fake{Limit: client.RateLimit{Limit: 100, Remaining: 0, Reset: time.Now().Add(time.Minute)}}
+	a := prefetchRound(newPrefetchTestApp(t, fake))
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("prefetch made %v while rate limited", calls)
+	}
+	if a.prefetchNext != 0 {
+		t.Errorf("prefetchNext = %d, want a full round skipped", a.prefetchNext)
+	}
+}
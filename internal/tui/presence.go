@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// heartbeatInterval is how often the App tells the server the magician is
+// still here, and checks whether they've gone idle.
+const heartbeatInterval = time.Minute
+
+// awayAfter is how long the App may go without a keypress before it reports
+// the magician as away; 0 never does. It comes from the config.
+var awayAfter = config.DefaultAwayAfter
+
+// heartbeatTickMsg fires every heartbeatInterval.
+type heartbeatTickMsg time.Time
+
+func heartbeatTickCmd() tea.Cmd {
+	return tea.Tick(heartbeatInterval, func(t time.Time) tea.Msg {
+		return heartbeatTickMsg(t)
+	})
+}
+
+// heartbeatCmd sends one heartbeat. A failed one is dropped: the next tick
+// tries again, and presence is best effort anyway.
+func heartbeatCmd(c client.API, status string) tea.Cmd {
+	if c == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		_ = c.Heartbeat(context.Background(), status) //nolint:errcheck // best effort
+		return nil
+	}
+}
+
+// presenceStatus returns the status to report when the last keypress was
+// at lastInput.
+func presenceStatus(lastInput, now time.Time) string {
+	if awayAfter > 0 && !lastInput.IsZero() && now.Sub(lastInput) >= awayAfter {
+		return domain.PresenceAway
+	}
+	return domain.PresenceOnline
+}
+
+// heartbeat reports the magician's presence and schedules the next beat.
+func (a App) heartbeat(now time.Time) (App, tea.Cmd) {
+	status := presenceStatus(a.lastInput, now)
+	a.away = status == domain.PresenceAway
+	return a, tea.Batch(heartbeatCmd(a.client, status), heartbeatTickCmd())
+}
+
+// presenceDot marks a magician as online (green), away (dim) or offline
+// (hollow).
+func presenceDot(online, away bool) string {
+	switch {
+	case online && away:
+		return dimStyle.Render("●")
+	case online:
+		return presenceDotStyle.Render("●")
+	}
+	return dimStyle.Render("○")
+}
+
+// presenceLabel is presenceDot followed by the status in words.
+func presenceLabel(online, away bool) string {
+	switch {
+	case online && away:
+		return presenceDot(online, away) + " " + dimStyle.Render("away")
+	case online:
+		return presenceDot(online, away) + " " + presenceDotStyle.Render("online")
+	}
+	return presenceDot(online, away) + " " + dimStyle.Render("offline")
+}
+
+// joinLeaveCooldown is how long after showing someone come or go the Hall
+// stays quiet about them, so a flapping connection isn't a stream of joins
+// and leaves.
+const joinLeaveCooldown = 5 * time.Minute
+
+// showJoinLeave shows magicians coming and going in the Hall log. It comes
+// from the config.
+var showJoinLeave = true
+
+// announcePresence notes in the log who has come and gone since the last
+// presence poll, now logins are here: one line for arrivals and one for
+// departures, naming up to two magicians and counting more. You, and anyone
+// shown within joinLeaveCooldown, are left out.
+func (m hallModel) announcePresence(logins []string, now time.Time) hallModel {
+	if !showJoinLeave {
+		return m
+	}
+	joined, left := presenceChanges(m.presenceLogins, logins)
+	announced := make(map[string]time.Time, len(m.announced))
+	for l, at := range m.announced {
+		if now.Sub(at) < joinLeaveCooldown {
+			announced[l] = at
+		}
+	}
+	quiet := func(l string) bool {
+		if l == m.myLogin {
+			return true
+		}
+		if _, recent := announced[l]; recent {
+			return true
+		}
+		announced[l] = now
+		return false
+	}
+	joined = slices.DeleteFunc(joined, quiet)
+	left = slices.DeleteFunc(left, quiet)
+	m.announced = announced
+
+	if len(joined) > 0 {
+		m.messages = append(m.messages, chatMessage{IsSystem: true, Body: presenceSummary(joined, "joined")})
+	}
+	switch len(left) {
+	case 0:
+	case 1:
+		m.messages = append(m.messages, chatMessage{Kind: "leave", SenderLogin: left[0]})
+	default:
+		m.messages = append(m.messages, chatMessage{IsSystem: true, Body: presenceSummary(left, "left")})
+	}
+	return m
+}
+
+// presenceChanges returns who is in after but not before, and who was in
+// before but not after.
+func presenceChanges(before, after []string) (joined, left []string) {
+	oldSet := make(map[string]bool, len(before))
+	for _, l := range before {
+		oldSet[l] = true
+	}
+	newSet := make(map[string]bool, len(after))
+	for _, l := range after {
+		newSet[l] = true
+		if !oldSet[l] {
+			joined = append(joined, l)
+		}
+	}
+	for _, l := range before {
+		if !newSet[l] {
+			left = append(left, l)
+		}
+	}
+	return joined, left
+}
+
+// presenceSummary describes several magicians coming or going at once, e.g.
+// "ada and grace joined" or "3 magicians left".
+func presenceSummary(logins []string, verb string) string {
+	switch len(logins) {
+	case 1:
+		return logins[0] + " " + verb
+	case 2:
+		return logins[0] + " and " + logins[1] + " " + verb
+	}
+	return fmt.Sprintf("%d magicians %s", len(logins), verb)
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestPresenceStatus(t *testing.T) {
+	defer func(d time.Duration) { awayAfter = d }(awayAfter)
+	awayAfter = 5 * time.Minute
+	now := time.Now()
+	if got := presenceStatus(now.Add(-time.Minute), now); got != domain.PresenceOnline {
+		t.Errorf("after a minute: %q, want online", got)
+	}
+	if got := presenceStatus(now.Add(-5*time.Minute), now); got != domain.PresenceAway {
+		t.Errorf("after five minutes: %q, want away", got)
+	}
+	awayAfter = 0
+	if got := presenceStatus(now.Add(-time.Hour), now); got != domain.PresenceOnline {
+		t.Errorf("with away off: %q, want online", got)
+	}
+}
+
+func TestHeartbeatAwayAndBack(t *testing.T) {
+	defer func(d time.Duration) { awayAfter = d }(awayAfter)
+	awayAfter = 5 * time.Minute
+	f := &clienttest.Fake{}
+	a := NewApp(f, "dev")
+	a.hall.inputFocused = false
+	a.lastInput = time.Now().Add(-10 * time.Minute)
+
+	a, _ = a.heartbeat(time.Now())
+	if !a.away {
+		t.Fatal("expected the heartbeat to report away after ten idle minutes")
+	}
+	heartbeatCmd(f, domain.PresenceAway)()
+	if f.Presence != domain.PresenceAway {
+		t.Fatalf("presence = %q, want away", f.Presence)
+	}
+
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	a = model.(App)
+	if a.away {
+		t.Error("expected a keypress to end away")
+	}
+	if cmd == nil {
+		t.Fatal("expected a heartbeat on coming back")
+	}
+	cmd()
+	if f.Presence != domain.PresenceOnline {
+		t.Errorf("presence = %q, want online straight away", f.Presence)
+	}
+}
+
+func TestPeekShowsAway(t *testing.T) {
+	m := newPeekModel(nil, "")
+	m.card = &domain.MagicianCard{Magician: domain.Magician{GitHubLogin: "ada"}, Online: true, Away: true}
+	if got := m.View(); !strings.Contains(got, "away") || strings.Contains(got, "online") {
+		t.Errorf("expected the peek to show away, got:\n%s", got)
+	}
+}
+
+func TestRosterRanksAwayAfterOnline(t *testing.T) {
+	now := time.Now()
+	roster := byAvailability([]domain.MagicianCard{
+		{Magician: domain.Magician{GitHubLogin: "idle"}, Online: true, Away: true},
+		{Magician: domain.Magician{GitHubLogin: "gone"}},
+		{Magician: domain.Magician{GitHubLogin: "here"}, Online: true},
+	}, now)
+	var got []string
+	for _, c := range roster {
+		got = append(got, c.GitHubLogin)
+	}
+	if strings.Join(got, ",") != "here,idle,gone" {
+		t.Errorf("order = %v, want here,idle,gone", got)
+	}
+}
+
+func TestHallPresenceBatched(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m, _ = m.Update(hallPresenceMsg{logins: []string{"me", "ada"}})
+	m, _ = m.Update(hallPresenceMsg{logins: []string{"me", "ada", "grace", "linus", "ken"}})
+	m, _ = m.Update(hallPresenceMsg{logins: []string{"me", "grace", "linus", "ken"}})
+	var got []string
+	for _, msg := range m.messages {
+		got = append(got, msg.Body+msg.SenderLogin)
+	}
+	want := []string{"3 magicians joined", "ada"}
+	if !slices.Equal(got, want) {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+	if m.messages[1].Kind != "leave" {
+		t.Errorf("a lone leave = %+v, want the usual departure line", m.messages[1])
+	}
+}
+
+func TestHallPresenceCooldown(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := newTestHallModel()
+	m.presenceLogins = []string{"ada"}
+	m = m.announcePresence([]string{"ada", "grace"}, now)
+	m.presenceLogins = []string{"ada", "grace"}
+	// grace's connection flaps: gone and back within the cooldown.
+	m = m.announcePresence([]string{"ada"}, now.Add(time.Minute))
+	m.presenceLogins = []string{"ada"}
+	m = m.announcePresence([]string{"ada", "grace"}, now.Add(2*time.Minute))
+	m.presenceLogins = []string{"ada", "grace"}
+	if len(m.messages) != 1 || m.messages[0].Body != "grace joined" {
+		t.Fatalf("log = %+v, want grace's first join only", m.messages)
+	}
+	m = m.announcePresence([]string{"ada"}, now.Add(joinLeaveCooldown+time.Minute))
+	if len(m.messages) != 2 || m.messages[1].SenderLogin != "grace" {
+		t.Errorf("log = %+v, want grace's leave once the cooldown is over", m.messages)
+	}
+}
+
+func TestHallPresenceHidden(t *testing.T) {
+	defer func(old bool) { showJoinLeave = old }(showJoinLeave)
+	showJoinLeave = false
+	m := newTestHallModel()
+	m, _ = m.Update(hallPresenceMsg{count: 1, logins: []string{"ada"}})
+	m, _ = m.Update(hallPresenceMsg{count: 2, logins: []string{"ada", "grace"}})
+	if len(m.messages) != 0 || m.presenceCount != 2 {
+		t.Errorf("log = %+v, count %d; want nothing logged but the count kept", m.messages, m.presenceCount)
+	}
+}
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// Profile form fields, in tab order.
+const (
+	profileDisplayName = iota
+	profileCity
+	profileBio
+	profileArchetype
+	profileFieldCount
+)
+
+// profileFields are the JSON names of the form fields, which is how the API
+// reports validation failures.
+var profileFields = [profileFieldCount]string{"display_name", "city", "bio", "archetype"}
+
+var profileLabels = [profileFieldCount]string{"name:", "city:", "bio:", "archetype:"}
+
+// profileForm edits the magician's own display name, city, bio and
+// archetype. The archetype is picked from domain.Archetypes, not typed.
+type profileForm struct {
+	values [profileFieldCount]string
+	focus  int
+	errs   map[string]string // per field, by JSON name
+	err    string            // a failure that isn't about one field
+}
+
+// profileSavedMsg carries the result of a profile save. prev is the profile
+// before the optimistic update, put back if the save failed.
+type profileSavedMsg struct {
+	me   *domain.Magician
+	prev *domain.Magician
+	err  error
+}
+
+// openProfile starts editing the current profile.
+func (m youModel) openProfile() youModel {
+	if m.me == nil {
+		m.statusMsg = "profile not loaded yet"
+		return m
+	}
+	m.profile = profileForm{values: [profileFieldCount]string{m.me.DisplayName, m.me.City, m.me.Bio, m.me.Archetype}}
+	m.profileOpen = true
+	return m
+}
+
+// profileUpdate returns the fields that differ from the current profile.
+func (m youModel) profileUpdate() (domain.ProfileUpdate, bool) {
+	var u domain.ProfileUpdate
+	changed := false
+	set := func(dst **string, v, old string) {
+		if v != old {
+			*dst = &v
+			changed = true
+		}
+	}
+	v := m.profile.values
+	set(&u.DisplayName, strings.TrimSpace(v[profileDisplayName]), m.me.DisplayName)
+	set(&u.City, strings.TrimSpace(v[profileCity]), m.me.City)
+	set(&u.Bio, strings.TrimSpace(v[profileBio]), m.me.Bio)
+	set(&u.Archetype, v[profileArchetype], m.me.Archetype)
+	return u, changed
+}
+
+func (m youModel) handleKeyProfile(msg tea.KeyMsg) (youModel, tea.Cmd) {
+	f := &m.profile
+	switch msg.String() {
+	case "esc":
+		m.profileOpen = false
+	case "tab", "down":
+		f.focus = (f.focus + 1) % profileFieldCount
+	case "shift+tab", "up":
+		f.focus = (f.focus + profileFieldCount - 1) % profileFieldCount
+	case "enter":
+		return m.saveProfile()
+	case "left", "right":
+		if f.focus == profileArchetype {
+			f.values[profileArchetype] = cycleArchetype(f.values[profileArchetype], msg.String() == "right")
+			delete(f.errs, profileFields[profileArchetype])
+		}
+	default:
+		if f.focus != profileArchetype {
+			key := msg.String()
+			if msg.Paste {
+				key = string(msg.Runes)
+			}
+			f.values[f.focus] = editRune(f.values[f.focus], key)
+			delete(f.errs, profileFields[f.focus])
+		}
+	}
+	return m, nil
+}
+
+// cycleArchetype steps through none and then domain.Archetypes, wrapping at
+// either end, so a claimed archetype can be dropped again.
+func cycleArchetype(current string, forward bool) string {
+	choices := append([]string{""}, domain.Archetypes...)
+	n := len(choices)
+	i := max(slices.Index(choices, current), 0)
+	if forward {
+		i = (i + 1) % n
+	} else {
+		i = (i + n - 1) % n
+	}
+	return choices[i]
+}
+
+// saveProfile checks the form and, if it's fine, shows the new profile
+// straight away while the save goes out.
+func (m youModel) saveProfile() (youModel, tea.Cmd) {
+	u, changed := m.profileUpdate()
+	if !changed {
+		m.profileOpen = false
+		return m, nil
+	}
+	m.profile.err = ""
+	if m.profile.errs = u.TextProblems(); m.profile.errs != nil {
+		return m, nil
+	}
+
+	prev := m.me
+	updated := *m.me
+	if u.DisplayName != nil {
+		updated.DisplayName = *u.DisplayName
+	}
+	if u.City != nil {
+		updated.City = *u.City
+	}
+	if u.Bio != nil {
+		updated.Bio = *u.Bio
+	}
+	if u.Archetype != nil {
+		updated.Archetype = *u.Archetype
+	}
+	m.me = &updated
+	m.profileOpen = false
+	m.statusMsg = "saving profile..."
+
+	c := m.client
+	return m, func() tea.Msg {
+		me, err := c.UpdateProfile(context.Background(), u)
+		return profileSavedMsg{me: me, prev: prev, err: err}
+	}
+}
+
+// profileSaved settles an optimistic save: keep what the server returned,
+// or put the old profile back and reopen the form with what went wrong.
+func (m youModel) profileSaved(msg profileSavedMsg) youModel {
+	if msg.err == nil {
+		if msg.me != nil {
+			m.me = msg.me
+		}
+		m.statusMsg = "profile saved"
+		return m
+	}
+	m.me = msg.prev
+	m.statusMsg = ""
+	m.profileOpen = true
+	m.profile.errs = nil
+	for _, fe := range client.FieldErrors(msg.err) {
+		if slices.Contains(profileFields[:], fe.Field) {
+			if m.profile.errs == nil {
+				m.profile.errs = make(map[string]string)
+			}
+			m.profile.errs[fe.Field] = fe.Message
+		}
+	}
+	if m.profile.errs == nil {
+		m.profile.err = errText("save failed", msg.err)
+	}
+	return m
+}
+
+func (m youModel) viewProfileForm() string {
+	var sb strings.Builder
+	sb.WriteString("\n " + sectionHeaderStyle.Render("── EDIT PROFILE ──") + "\n\n")
+	f := m.profile
+	for i := range profileFieldCount {
+		label := inputPromptStyle.Render(profileLabels[i])
+		value := f.values[i]
+		if i == profileArchetype {
+			if value == "" {
+				value = "none"
+			}
+			if i == f.focus {
+				value = "< " + value + " >"
+			}
+		}
+		if i == f.focus {
+			line := "   " + accentStyle.Render(">") + " " + label + " " + value
+			if i != profileArchetype {
+				line += accentStyle.Render("_")
+			}
+			sb.WriteString(line + "\n")
+		} else {
+			sb.WriteString("     " + label + " " + dimStyle.Render(value) + "\n")
+		}
+		if e := f.errs[profileFields[i]]; e != "" {
+			sb.WriteString("       " + rejectStyle.Render(e) + "\n")
+		}
+	}
+	if f.err != "" {
+		sb.WriteString("\n   " + rejectStyle.Render(f.err) + "\n")
+	}
+	sb.WriteString("\n   " + dimStyle.Render("tab next · ←/→ archetype · enter save · esc cancel") + "\n")
+	return sb.String()
+}
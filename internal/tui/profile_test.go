@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func newTestProfileModel(f *clienttest.Fake) youModel {
+	m := newYouModel(f)
+	m.width, m.height = 80, 40
+	m, _ = m.Update(meLoadedMsg{me: &domain.Magician{GitHubLogin: "mona", City: "Oslo", Archetype: "oracle"}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	return m
+}
+
+func typeYou(m youModel, text string) youModel {
+	for _, r := range text {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestProfileEditSavesOptimistically(t *testing.T) {
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "mona", City: "Oslo", Archetype: "oracle"}}
+	m := newTestProfileModel(f)
+	if !m.profileOpen || !m.editing() {
+		t.Fatal("E should open the profile form and capture keys")
+	}
+
+	m = typeYou(m, "Mona")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.profileOpen || m.me.DisplayName != "Mona" || m.me.Archetype != "tinkerer" {
+		t.Fatalf("profile should update before the save lands, got %+v", *m.me)
+	}
+	if cmd == nil {
+		t.Fatal("expected a save command")
+	}
+	m, _ = m.Update(cmd())
+	if m.statusMsg != "profile saved" || f.Me.DisplayName != "Mona" || f.Me.Archetype != "tinkerer" {
+		t.Errorf("status %q, server profile %+v", m.statusMsg, *f.Me)
+	}
+	if f.Me.City != "Oslo" {
+		t.Errorf("an unchanged city should be left alone, got %q", f.Me.City)
+	}
+	if view := m.View(); !strings.Contains(view, "Mona") || !strings.Contains(view, "tinkerer") {
+		t.Errorf("identity should show the new profile:\n%s", view)
+	}
+}
+
+func TestProfileEditTakesPastes(t *testing.T) {
+	m := newTestProfileModel(&clienttest.Fake{})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Mona Lisa"), Paste: true})
+	if got := m.profile.values[profileDisplayName]; got != "Mona Lisa" {
+		t.Errorf("display name = %q, want the paste without brackets", got)
+	}
+}
+
+func TestProfileEditValidatesInline(t *testing.T) {
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "mona"}}
+	m := newTestProfileModel(f)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeYou(m, strings.Repeat("x", domain.MaxCityLen+1))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !m.profileOpen || m.profile.errs["city"] == "" {
+		t.Fatalf("a long city should be caught before saving, errs = %v", m.profile.errs)
+	}
+	if !strings.Contains(m.View(), "at most 60 characters") {
+		t.Error("the problem should show under the field")
+	}
+}
+
+func TestProfileEditFailureReverts(t *testing.T) {
+	f := &clienttest.Fake{Me: &domain.Magician{GitHubLogin: "mona"}}
+	f.Fail = map[string]error{"UpdateProfile": &client.HTTPError{StatusCode: 422, Message: "invalid",
+		Fields: []client.FieldError{{Field: "display_name", Code: client.CodeInvalid, Message: "name is taken"}}}}
+	m := newTestProfileModel(f)
+	m = typeYou(m, "Mona")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(cmd())
+	if m.me.DisplayName != "" {
+		t.Errorf("a failed save should put the old name back, got %q", m.me.DisplayName)
+	}
+	if !m.profileOpen || m.profile.errs["display_name"] != "name is taken" || m.profile.values[profileDisplayName] != "Mona" {
+		t.Errorf("form should reopen with the typed name and the API's error, errs = %v", m.profile.errs)
+	}
+}
+
+func TestCycleArchetype(t *testing.T) {
+	if got := cycleArchetype("", true); got != domain.Archetypes[0] {
+		t.Errorf("forward from none = %q", got)
+	}
+	last := domain.Archetypes[len(domain.Archetypes)-1]
+	if got := cycleArchetype(domain.Archetypes[0], false); got != "" {
+		t.Errorf("back from the first = %q, want none", got)
+	}
+	if got := cycleArchetype("", false); got != last {
+		t.Errorf("back from none = %q", got)
+	}
+	if got := cycleArchetype(last, true); got != "" {
+		t.Errorf("forward from the last = %q, want none", got)
+	}
+}
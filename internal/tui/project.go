@@ -0,0 +1,283 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// projectUpdateCreatedMsg carries the result of posting a timeline entry.
+type projectUpdateCreatedMsg struct {
+	projectID string
+	update    *domain.ProjectUpdate
+	err       error
+}
+
+// workshopURLSetMsg carries the result of linking a project to a repo URL.
+type workshopURLSetMsg struct {
+	id  string
+	url string
+	err error
+}
+
+// inDetail reports whether the project detail screen is showing.
+func (m youModel) inDetail() bool {
+	return m.wsState == wsDetail || m.wsState == wsPosting || m.wsState == wsLinking
+}
+
+// editing reports whether the You tab is capturing text or a confirmation,
+// so global keys must not fire.
+func (m youModel) editing() bool {
+	return m.profileOpen || (m.wsState != wsNormal && m.wsState != wsDetail)
+}
+
+// selectedProject returns the project under the workshop cursor, if any.
+func (m youModel) selectedProject() (domain.WorkshopProject, bool) {
+	if len(m.projects) == 0 || m.wsCursor >= len(m.projects) {
+		return domain.WorkshopProject{}, false
+	}
+	return m.projects[m.wsCursor], true
+}
+
+// openProjectDetail switches to the detail screen for the selected project,
+// scrolled to the latest updates, and refreshes its timeline.
+func (m youModel) openProjectDetail() (youModel, tea.Cmd) {
+	proj, ok := m.selectedProject()
+	if !ok {
+		return m, nil
+	}
+	m.wsState = wsDetail
+	m.detailScroll = m.maxDetailScroll()
+	if m.client == nil {
+		return m, nil
+	}
+	return m, m.loadProjectUpdates(proj.ID.String())
+}
+
+func (m youModel) handleKeyDetail(msg tea.KeyMsg) (youModel, tea.Cmd) {
+	proj, ok := m.selectedProject()
+	if !ok {
+		m.wsState = wsNormal
+		return m, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		if m.detailScroll < m.maxDetailScroll() {
+			m.detailScroll++
+		}
+	case "k", "up":
+		if m.detailScroll > 0 {
+			m.detailScroll--
+		}
+	case "g":
+		m.detailScroll = 0
+	case "G":
+		m.detailScroll = m.maxDetailScroll()
+	case "u":
+		m.wsState = wsPosting
+		m.postKind = "update"
+		m.wsDraft = ""
+	case "s":
+		if projectStatus(m.projectUpdates[proj.ID.String()]) == "shipped" {
+			m.statusMsg = "already shipped"
+			return m, nil
+		}
+		m.wsState = wsPosting
+		m.postKind = "ship"
+		m.wsDraft = ""
+	case "l":
+		m.wsState = wsLinking
+		m.wsDraft = proj.URL
+	case "o":
+		if proj.URL == "" {
+			m.statusMsg = "no repo linked · press l to link one"
+			return m, nil
+		}
+		return m, openURLCmd(proj.URL)
+	case "esc", "backspace":
+		m.wsState = wsNormal
+		m.detailScroll = 0
+	}
+	return m, nil
+}
+
+func (m youModel) handleKeyPosting(msg tea.KeyMsg) (youModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		proj, ok := m.selectedProject()
+		if !ok {
+			m.wsState = wsNormal
+			return m, nil
+		}
+		body := strings.TrimSpace(m.wsDraft)
+		// A ship event stands on its own; a progress update needs words.
+		if body == "" && m.postKind == "update" {
+			m.statusMsg = "update text required"
+			return m, nil
+		}
+		id, name := proj.ID.String(), proj.Name
+		kind := m.postKind
+		c, j := m.client, m.journal
+		return m, func() tea.Msg {
+			update, err := c.CreateProjectUpdate(context.Background(), id, kind, body)
+			if err == nil && update != nil {
+				record(j, journal.Entry{Kind: journal.KindProject, ID: update.ID.String(), Where: name, Text: projectUpdateJournalText(kind, body)})
+			}
+			return projectUpdateCreatedMsg{projectID: id, update: update, err: err}
+		}
+	case "esc":
+		m.wsState = wsDetail
+		m.wsDraft = ""
+	default:
+		m.wsDraft = editRune(m.wsDraft, msg.String())
+	}
+	return m, nil
+}
+
+func (m youModel) handleKeyLinking(msg tea.KeyMsg) (youModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		proj, ok := m.selectedProject()
+		if !ok {
+			m.wsState = wsNormal
+			return m, nil
+		}
+		repoURL := strings.TrimSpace(m.wsDraft)
+		if repoURL != "" && !validRepoURL(repoURL) {
+			m.statusMsg = "enter an http(s) URL, or leave empty to unlink"
+			return m, nil
+		}
+		id := proj.ID.String()
+		c := m.client
+		return m, func() tea.Msg {
+			err := c.SetWorkshopProjectURL(context.Background(), id, repoURL)
+			return workshopURLSetMsg{id: id, url: repoURL, err: err}
+		}
+	case "esc":
+		m.wsState = wsDetail
+		m.wsDraft = ""
+	default:
+		m.wsDraft = editRune(m.wsDraft, msg.String())
+	}
+	return m, nil
+}
+
+// validRepoURL reports whether s is an absolute http or https URL.
+func validRepoURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// detailRows is the number of timeline lines visible on the detail screen.
+func (m youModel) detailRows() int {
+	return max(m.height-16, 5)
+}
+
+// maxDetailScroll returns the largest scroll offset that still fills the
+// timeline window.
+func (m youModel) maxDetailScroll() int {
+	proj, ok := m.selectedProject()
+	if !ok {
+		return 0
+	}
+	lines := m.detailTimelineLines(m.projectUpdates[proj.ID.String()])
+	return max(len(lines)-m.detailRows(), 0)
+}
+
+// detailTimelineLines renders every update of a project, oldest first,
+// with bodies shown in full rather than truncated as on the journal card.
+func (m youModel) detailTimelineLines(updates []domain.ProjectUpdate) []string {
+	var lines []string
+	bodyWidth := max(m.width-10, 20)
+	for i, u := range updates {
+		var dotLine string
+		switch u.Kind {
+		case "start":
+			dotLine = "   " + accentStyle.Render("●") + " " + accentStyle.Render("started building")
+		case "ship":
+			dotLine = "   " + goldStyle.Render("✦") + " " + goldStyle.Render("shipped")
+		default:
+			dotLine = "   " + dimStyle.Render("●") + " " + dimStyle.Render("update")
+		}
+		ts := metaStyle.Render(formatTime(u.CreatedAt))
+		tsPad := max(m.width-2-lipgloss.Width(dotLine)-lipgloss.Width(ts), 2)
+		lines = append(lines, dotLine+strings.Repeat(" ", tsPad)+ts)
+
+		if u.Body != "" {
+			for _, l := range wrapInputLines(u.Body, bodyWidth) {
+				lines = append(lines, "   "+dimStyle.Render("│")+" "+normalStyle.Render(l))
+			}
+		}
+		if i < len(updates)-1 {
+			lines = append(lines, "   "+dimStyle.Render("│"))
+		}
+	}
+	return lines
+}
+
+// viewProjectDetail renders the project detail screen: header, repo link,
+// the scrollable timeline, and the posting or linking form when active.
+func (m youModel) viewProjectDetail() string {
+	proj, ok := m.selectedProject()
+	if !ok {
+		return ""
+	}
+	var sb strings.Builder
+	updates := m.projectUpdates[proj.ID.String()]
+
+	badge := dimStyle.Render("building")
+	if projectStatus(updates) == "shipped" {
+		badge = goldStyle.Render("shipped")
+	}
+	name := selectedStyle.Render(truncStr(proj.Name, 40))
+	pad := max(m.width-3-lipgloss.Width(name)-lipgloss.Width(badge), 2)
+	sb.WriteString("\n " + name + strings.Repeat(" ", pad) + badge + "\n")
+	if proj.Insight != "" {
+		sb.WriteString("   " + dimStyle.Render(proj.Insight) + "\n")
+	}
+	if proj.URL != "" {
+		sb.WriteString("   " + metaStyle.Render("repo ") + accentStyle.Render(proj.URL) + "\n")
+	} else {
+		sb.WriteString("   " + metaStyle.Render("no repo linked · press l to link one") + "\n")
+	}
+
+	sb.WriteString("\n " + sectionHeaderStyle.Render(fmt.Sprintf("── TIMELINE %d updates ──", len(updates))) + "\n")
+	lines := m.detailTimelineLines(updates)
+	if len(lines) == 0 {
+		sb.WriteString("   " + dimStyle.Render("no updates yet · press u to post one") + "\n")
+	} else {
+		start := min(m.detailScroll, m.maxDetailScroll())
+		end := min(start+m.detailRows(), len(lines))
+		if start > 0 {
+			sb.WriteString("   " + metaStyle.Render(fmt.Sprintf("↑ %d more", start)) + "\n")
+		}
+		for _, l := range lines[start:end] {
+			sb.WriteString(l + "\n")
+		}
+		if end < len(lines) {
+			sb.WriteString("   " + metaStyle.Render(fmt.Sprintf("↓ %d more", len(lines)-end)) + "\n")
+		}
+	}
+
+	switch m.wsState {
+	case wsPosting:
+		label := "update:"
+		if m.postKind == "ship" {
+			label = "ship note:"
+		}
+		sb.WriteString("\n   " + accentStyle.Render(">") + " " + inputPromptStyle.Render(label) + " " + m.wsDraft + accentStyle.Render("_") + "\n")
+	case wsLinking:
+		sb.WriteString("\n   " + accentStyle.Render(">") + " " + inputPromptStyle.Render("repo url:") + " " + m.wsDraft + accentStyle.Render("_") + "\n")
+	}
+	return sb.String()
+}
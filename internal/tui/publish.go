@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/publish"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// publishRepo is where "P" commits a spell; the zero Repo publishes a
+// secret gist. Set from config by ApplyConfig.
+var publishRepo publish.Repo
+
+// newPublisher returns the GitHub publisher "P" uses. Tests replace it.
+var newPublisher = func() *publish.GitHub {
+	return publish.New(publish.Token())
+}
+
+// spellPublishedMsg reports where "P" published a spell. linkErr is a
+// failure to note the link on the spell afterwards.
+type spellPublishedMsg struct {
+	link    string
+	err     error
+	linkErr error
+}
+
+// publishSpellCmd publishes s to GitHub and notes the URL in its context.
+func publishSpellCmd(c client.API, s domain.Spell) tea.Cmd {
+	gh := newPublisher()
+	repo := publishRepo
+	return func() tea.Msg {
+		ctx := context.Background()
+		link, err := gh.Spell(ctx, repo, false, s)
+		if err != nil {
+			return spellPublishedMsg{err: err}
+		}
+		return spellPublishedMsg{link: link, linkErr: publish.Link(ctx, c, s, link)}
+	}
+}
+
+// publishStatus describes a spellPublishedMsg for the status line.
+func publishStatus(msg spellPublishedMsg) string {
+	switch {
+	case msg.err != nil:
+		return "publish failed: " + msg.err.Error()
+	case msg.linkErr != nil:
+		return "published to " + msg.link + " (" + errText("couldn't link it on the spell", msg.linkErr) + ")"
+	}
+	return "published to " + msg.link
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// randomSpellPotency is the least potency "?" in the Grimoire and the spell
+// of the day will pick.
+const randomSpellPotency = 3
+
+// randomSpellMsg carries the spell "?" picked.
+type randomSpellMsg struct {
+	spell *domain.Spell
+	err   error
+}
+
+// dailySpellMsg carries the spell of the day for seed.
+type dailySpellMsg struct {
+	seed  string
+	spell *domain.Spell
+	err   error
+}
+
+// dailySeed is the seed for the spell of the day at t. It goes by the UTC
+// date, so everyone sees the same spell on the same day.
+func dailySeed(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// randomSpell asks for a potent spell at random, to open in the detail view.
+func (m grimoireModel) randomSpell() (grimoireModel, tea.Cmd) {
+	c := m.client
+	m.statusMsg = "summoning a spell..."
+	return m, func() tea.Msg {
+		spell, err := c.GetRandomSpell(context.Background(), randomSpellPotency, "")
+		return randomSpellMsg{spell: spell, err: err}
+	}
+}
+
+// showRandomSpell opens the spell "?" picked.
+func (m grimoireModel) showRandomSpell(msg randomSpellMsg) grimoireModel {
+	switch {
+	case client.IsNotFound(msg.err):
+		m.statusMsg = fmt.Sprintf("no P%d spells to pick from yet", randomSpellPotency)
+	case msg.err != nil:
+		m.statusMsg = errText("random spell failed", msg.err)
+	default:
+		m = m.showSpell(*msg.spell)
+	}
+	return m
+}
+
+// loadDaily fetches the spell of the day, unless today's is already here.
+func (m streamModel) loadDaily() tea.Cmd {
+	seed := dailySeed(clock())
+	if m.daily != nil && m.dailySeed == seed {
+		return nil
+	}
+	c := m.client
+	return func() tea.Msg {
+		spell, err := c.GetRandomSpell(context.Background(), randomSpellPotency, seed)
+		return dailySpellMsg{seed: seed, spell: spell, err: err}
+	}
+}
+
+// dailyCardLines is how many lines the spell of the day card takes at the
+// top of the stream, or 0 when there's none.
+func (m streamModel) dailyCardLines() int {
+	if m.daily == nil {
+		return 0
+	}
+	return 3
+}
+
+// dailyHelp is the help entry for d, when there's a spell of the day to
+// open.
+func (m streamModel) dailyHelp() string {
+	if m.daily == nil {
+		return ""
+	}
+	return helpEntry("d", "spell of the day") + "  "
+}
+
+// renderDailyCard renders the spell of the day: who forged it and its
+// potency, then its title, then a blank line before the events.
+func (m streamModel) renderDailyCard() string {
+	s := *m.daily
+	header := " " + goldStyle.Render(streamIcon("spell")+" Spell of the day")
+	if s.Author != nil {
+		header += "  " + GuildStyle(s.Author.GuildID).Render("@"+s.Author.Login)
+	}
+	if s.Tag != "" {
+		header += " " + metaStyle.Render("#"+s.Tag)
+	}
+	header += "  " + potencyStyle(s.Potency).Render(fmt.Sprintf("P%d", s.Potency))
+	title := truncStr(spellTitle(s), max(m.width-6, 20))
+	return header + "\n   " + chatTextStyle.Render(title) + "  " + metaStyle.Render("d to open") + "\n\n"
+}
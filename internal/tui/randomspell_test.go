@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestGrimoireRandomSpell(t *testing.T) {
+	potent := makeTestSpell("Bisect the regression before guessing at the cause.", "debugging")
+	potent.Potency = 3
+	weak := makeTestSpell("Say hello.", "general")
+	weak.Potency = 1
+	fake := &clienttest.Fake{Spells: []domain.Spell{weak, potent}}
+	m := newTestGrimoireModel()
+	m.client = fake
+	m.spells = []domain.Spell{weak}
+
+	m, cmd := m.Update(keyRune('?'))
+	if cmd == nil {
+		t.Fatal("? should ask for a random spell")
+	}
+	m, _ = m.Update(cmd())
+	if !m.detail || m.spells[m.cursor].ID != potent.ID {
+		t.Fatalf("opened %+v, want the potent spell in the detail view", m.spells[m.cursor])
+	}
+	if calls := fake.Calls(); len(calls) != 1 || calls[0].Args[0] != randomSpellPotency {
+		t.Errorf("calls = %v", calls)
+	}
+
+	// The list may be filtered to spells the pick doesn't match, so it
+	// leaves again on esc.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.detail || len(m.spells) != 1 || m.spells[0].ID != weak.ID || m.cursor != 0 {
+		t.Fatalf("after esc %d spells (cursor %d), want only the list's own", len(m.spells), m.cursor)
+	}
+
+	fake.Spells = []domain.Spell{weak}
+	m, cmd = m.Update(keyRune('?'))
+	m, _ = m.Update(cmd())
+	if !strings.Contains(m.statusMsg, "no P3 spells") {
+		t.Errorf("status = %q, want nothing to pick from", m.statusMsg)
+	}
+}
+
+func TestStreamSpellOfTheDay(t *testing.T) {
+	old := clock
+	clock = func() time.Time { return time.Date(2026, 10, 16, 23, 30, 0, 0, time.FixedZone("PDT", -7*3600)) }
+	t.Cleanup(func() { clock = old })
+
+	spell := domain.Spell{
+		ID: uuid.New(), Text: "Write the failing test first.", Tag: "testing", Potency: 3, Status: "published",
+		Author: &domain.Author{Login: "grace", GuildID: "loomari"},
+	}
+	f := &clienttest.Fake{
+		Spells: []domain.Spell{spell},
+		Stream: []domain.StreamEvent{{Kind: "member", MagicianLogin: "linus"}},
+	}
+	a := openViewTestApp(t, f, "S", viewStream)
+	if a.stream.daily == nil || a.stream.dailySeed != "2026-10-17" {
+		t.Fatalf("daily = %+v (seed %q), want today's spell by the UTC date", a.stream.daily, a.stream.dailySeed)
+	}
+	view := ansi.Strip(a.View())
+	if !strings.Contains(view, "Spell of the day  @grace #testing  P3") || !strings.Contains(view, "Write the failing test first.") {
+		t.Errorf("expected the card above the events:\n%s", view)
+	}
+
+	// Refreshing on the same day keeps the spell already fetched.
+	a = pressAppKey(t, a, "r")
+	if n := f.Count("GetRandomSpell"); n != 1 {
+		t.Errorf("GetRandomSpell called %d times, want once a day", n)
+	}
+
+	a = pressAppKey(t, a, "d")
+	if a.view != viewGrimoire || !a.grimoire.detail || a.grimoire.spells[a.grimoire.cursor].ID != spell.ID {
+		t.Errorf("view = %v, want the spell of the day open in the Grimoire", a.view)
+	}
+}
+
+func TestStreamWithoutSpellOfTheDay(t *testing.T) {
+	f := &clienttest.Fake{Stream: []domain.StreamEvent{{Kind: "member", MagicianLogin: "linus"}}}
+	a := openViewTestApp(t, f, "S", viewStream)
+	if a.stream.daily != nil || strings.Contains(a.View(), "Spell of the day") {
+		t.Error("with no potent spells there should be no card")
+	}
+	if a = pressAppKey(t, a, "d"); a.view != viewStream {
+		t.Errorf("view = %v, want d to do nothing", a.view)
+	}
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+// Quota thresholds below which background polling backs off.
+const (
+	rateLimitLowFraction      = 0.25 // poll half as often
+	rateLimitCriticalFraction = 0.10 // poll a quarter as often
+)
+
+// rateLimitedStatus is the header hint shown while polling is stretched.
+const rateLimitedStatus = "slowed due to rate limits"
+
+// pollDelay stretches a view's base poll interval when the API reports low
+// quota, and waits out any Retry-After or exhausted window entirely.
+func pollDelay(c client.API, base time.Duration) time.Duration {
+	if c == nil {
+		return base
+	}
+	return stretchInterval(c.RateLimit(), base, time.Now())
+}
+
+func stretchInterval(rl client.RateLimit, base time.Duration, now time.Time) time.Duration {
+	d := base
+	switch f := rl.Fraction(now); {
+	case rl.Exhausted(now):
+		d = max(d, rl.Reset.Sub(now))
+	case f < rateLimitCriticalFraction:
+		d = base * 4
+	case f < rateLimitLowFraction:
+		d = base * 2
+	}
+	if rl.RetryAfter.After(now) {
+		d = max(d, rl.RetryAfter.Sub(now))
+	}
+	return d
+}
+
+// rateLimited reports whether polling is currently being stretched.
+func rateLimited(c client.API) bool {
+	if c == nil {
+		return false
+	}
+	return stretchInterval(c.RateLimit(), time.Second, time.Now()) > time.Second
+}
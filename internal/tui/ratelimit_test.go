@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+func TestStretchInterval(t *testing.T) {
+	now := time.Now()
+	base := 3 * time.Second
+	tests := []struct {
+		name string
+		rl   client.RateLimit
+		want time.Duration
+	}{
+		{"unknown quota", client.RateLimit{}, base},
+		{"plenty left", client.RateLimit{Limit: 100, Remaining: 80, Reset: now.Add(time.Minute)}, base},
+		{"running low", client.RateLimit{Limit: 100, Remaining: 20, Reset: now.Add(time.Minute)}, 2 * base},
+		{"nearly out", client.RateLimit{Limit: 100, Remaining: 5, Reset: now.Add(time.Minute)}, 4 * base},
+		{"exhausted waits for reset", client.RateLimit{Limit: 100, Remaining: 0, Reset: now.Add(time.Minute)}, time.Minute},
+		{"window already reset", client.RateLimit{Limit: 100, Remaining: 0, Reset: now.Add(-time.Second)}, base},
+		{"retry after", client.RateLimit{RetryAfter: now.Add(20 * time.Second)}, 20 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stretchInterval(tt.rl, base, now); got != tt.want {
+				t.Errorf("stretchInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPollDelayNilClient(t *testing.T) {
+	if got := pollDelay(nil, time.Second); got != time.Second {
+		t.Errorf("pollDelay(nil) = %v, want base", got)
+	}
+	if rateLimited(nil) {
+		t.Error("nil client should never report rate limiting")
+	}
+}
@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// realmTopCities is the most cities the Realm chart lists.
+const realmTopCities = 8
+
+// realmLoadedMsg carries the platform telemetry.
+type realmLoadedMsg struct {
+	data *client.TelemetryResponse
+	err  error
+}
+
+// realmModel is the Realm observatory: how many magicians there are, where
+// they live, how fast they're joining, and how the guilds split them.
+type realmModel struct {
+	client  client.API
+	myCity  string // from your profile; marked among the cities
+	data    *client.TelemetryResponse
+	loading bool
+	err     string
+	width   int
+	height  int
+}
+
+func newRealmModel(c client.API) realmModel {
+	return realmModel{client: c}
+}
+
+func (m realmModel) Init() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		data, err := c.GetTelemetry(context.Background())
+		return realmLoadedMsg{data: data, err: err}
+	}
+}
+
+func (m realmModel) Update(msg tea.Msg) (realmModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case realmLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			// Keep showing the last numbers; the error says they're stale.
+			m.err = errReason(msg.err)
+			return m, nil
+		}
+		m.err = ""
+		m.data = msg.data
+
+	case tea.KeyMsg:
+		if msg.String() == "r" {
+			m.loading = true
+			return m, m.Init()
+		}
+	}
+	return m, nil
+}
+
+func (m realmModel) helpKeys() string {
+	return helpEntry("r", "refresh") + "  " + helpEntry("esc", "back")
+}
+
+// barWidth is how wide the Realm's bars are at full scale.
+func (m realmModel) barWidth() int {
+	return min(max(m.width-36, 8), 30)
+}
+
+// isMyCity reports whether city is the one on your profile.
+func (m realmModel) isMyCity(city string) bool {
+	return m.myCity != "" && strings.EqualFold(city, m.myCity)
+}
+
+// renderCity renders one city's bar, scaled against most and marked when
+// it's yours.
+func (m realmModel) renderCity(c client.CityCountEntry, most int) string {
+	line := fmt.Sprintf("   %s %s %s", normalStyle.Render(padRight(truncStr(c.City, 16), 16)), goldStyle.Render(hbar(c.Count, most, m.barWidth())), normalStyle.Render(fmt.Sprintf("%5d", c.Count)))
+	if m.isMyCity(c.City) {
+		line += " " + accentStyle.Render("<- you")
+	}
+	return line
+}
+
+func (m realmModel) View() string {
+	var b strings.Builder
+
+	title := " " + presenceTitleStyle.Render("The Realm")
+	if m.data != nil {
+		title += "  " + goldStyle.Render(formatNum(m.data.Total)) + " " + dimStyle.Render("magicians")
+	}
+	b.WriteString(title + "\n")
+	b.WriteString(" " + metaStyle.Render(strings.Repeat("─", max(m.width-2, 4))) + "\n")
+
+	switch {
+	case m.data == nil && m.err != "":
+		b.WriteString(" " + dimStyle.Render("error: "+m.err+" · r to retry") + "\n")
+		return b.String()
+	case m.data == nil:
+		b.WriteString(" " + dimStyle.Render("loading...") + "\n")
+		return b.String()
+	}
+	d := m.data
+
+	if len(d.Joins) > 0 {
+		counts := make([]float64, len(d.Joins))
+		joined := 0
+		for i, j := range d.Joins {
+			counts[i] = float64(j.Count)
+			joined += j.Count
+		}
+		b.WriteString("\n " + sectionHeaderStyle.Render("── GROWTH ──") + "  " + accentStyle.Render(sparkline(counts)) + "  " +
+			metaStyle.Render(fmt.Sprintf("+%s in %d days", formatNum(joined), len(d.Joins))) + "\n")
+	}
+
+	b.WriteString("\n " + sectionHeaderStyle.Render("── TOP CITIES ──") + "\n")
+	if len(d.Cities) == 0 {
+		b.WriteString("   " + dimStyle.Render("no cities yet") + "\n")
+	}
+	cities := d.Cities[:min(len(d.Cities), realmTopCities)]
+	most := 0
+	for _, c := range cities {
+		most = max(most, c.Count)
+	}
+	mine := false
+	for _, c := range cities {
+		b.WriteString(m.renderCity(c, most) + "\n")
+		mine = mine || m.isMyCity(c.City)
+	}
+	if !mine {
+		// Your city still shows, under the chart, when it isn't a top one.
+		for _, c := range d.Cities[len(cities):] {
+			if m.isMyCity(c.City) {
+				b.WriteString("   " + metaStyle.Render("…") + "\n" + m.renderCity(c, most) + "\n")
+			}
+		}
+	}
+	if m.myCity != "" {
+		hint := "/city in the Hall joins your city's chapter room · l on the Board shows who's near you"
+		b.WriteString("   " + dimStyle.Render(truncStr(hint, max(m.width-4, 10))) + "\n")
+	}
+
+	if len(d.Guilds) > 0 {
+		b.WriteString("\n " + sectionHeaderStyle.Render("── GUILDS ──") + "\n")
+		guilds := append([]client.GuildCountEntry(nil), d.Guilds...)
+		sort.SliceStable(guilds, func(i, j int) bool { return guilds[i].Count > guilds[j].Count })
+		total, most := 0, 0
+		for _, g := range guilds {
+			total += g.Count
+			most = max(most, g.Count)
+		}
+		for _, g := range guilds {
+			name := g.GuildID
+			if info, ok := domain.Guilds[g.GuildID]; ok {
+				name = info.Name
+			}
+			style := GuildStyle(g.GuildID)
+			fmt.Fprintf(&b, "   %s %s %s %s %s\n", GuildEmblem(g.GuildID), style.Render(fmt.Sprintf("%-10s", name)), style.Render(hbar(g.Count, most, m.barWidth())), normalStyle.Render(fmt.Sprintf("%5d", g.Count)), metaStyle.Render(fmt.Sprintf("%3d%%", g.Count*100/max(total, 1))))
+		}
+	}
+
+	if m.err != "" {
+		b.WriteString("\n " + dimStyle.Render("refresh failed: "+m.err) + "\n")
+	} else if m.loading {
+		b.WriteString("\n " + dimStyle.Render("refreshing...") + "\n")
+	}
+	return b.String()
+}
+
+// openRealm switches to the Realm view and refreshes it.
+func (a App) openRealm() (App, tea.Cmd) {
+	a.view = viewRealm
+	a.realm.loading = true
+	return a, a.realm.Init()
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+)
+
+func testTelemetry() *client.TelemetryResponse {
+	return &client.TelemetryResponse{
+		Total:  1234,
+		Cities: []client.CityCountEntry{{City: "Berlin", Count: 120}, {City: "Lagos", Count: 60}},
+		Joins:  []client.DayCountEntry{{Day: "2026-10-14", Count: 3}, {Day: "2026-10-15", Count: 9}},
+		Guilds: []client.GuildCountEntry{{GuildID: "nyx", Count: 25}, {GuildID: "loomari", Count: 75}},
+	}
+}
+
+func TestRealmRendersTelemetry(t *testing.T) {
+	a := openViewTestApp(t, &clienttest.Fake{Telemetry: testTelemetry()}, "R", viewRealm)
+	view := a.View()
+	for _, want := range []string{"The Realm", "1.2k", "GROWTH", "+12 in 2 days", "Berlin", "Lagos", "Loomari", "75%", "Nyx", "25%"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in realm view:\n%s", want, view)
+		}
+	}
+	// Guilds are listed largest first.
+	if strings.Index(view, "Loomari") > strings.Index(view, "Nyx") {
+		t.Error("expected the larger guild first")
+	}
+}
+
+func TestRealmOlderServerOmitsNewSections(t *testing.T) {
+	a := openViewTestApp(t, &clienttest.Fake{Telemetry: &client.TelemetryResponse{Total: 5, Cities: []client.CityCountEntry{{City: "Oslo", Count: 5}}}}, "R", viewRealm)
+	view := a.View()
+	if !strings.Contains(view, "Oslo") {
+		t.Errorf("expected the city chart:\n%s", view)
+	}
+	if strings.Contains(view, "GROWTH") || strings.Contains(view, "GUILDS") {
+		t.Errorf("expected no growth or guild sections without data:\n%s", view)
+	}
+}
+
+func TestRealmRefreshErrorKeepsNumbers(t *testing.T) {
+	f := &clienttest.Fake{Telemetry: testTelemetry()}
+	a := openViewTestApp(t, f, "R", viewRealm)
+	f.Fail = map[string]error{"GetTelemetry": errors.New("boom")}
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	model, _ = model.(App).Update(cmd())
+	view := model.(App).View()
+	if !strings.Contains(view, "Berlin") || !strings.Contains(view, "refresh failed: boom") {
+		t.Errorf("expected stale numbers with the error:\n%s", view)
+	}
+
+	model, _ = model.(App).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(App).view != viewHall {
+		t.Error("expected esc to go back to the Hall")
+	}
+}
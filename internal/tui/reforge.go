@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// reforgePollInterval is how often a pending re-forge is checked on. The
+// Grimoire takes a while to weigh a spell, so there's no hurry.
+const reforgePollInterval = 15 * time.Second
+
+// spellReforgeMsg carries the result of asking for a re-forge with "V".
+type spellReforgeMsg struct {
+	id      uuid.UUID
+	reforge *domain.Reevaluation
+	err     error
+}
+
+// reforgePollMsg is due when a pending re-forge should be checked again.
+type reforgePollMsg struct {
+	id uuid.UUID
+}
+
+// reforgeCheckedMsg carries the spell fetched to see whether its re-forge
+// has been ruled on.
+type reforgeCheckedMsg struct {
+	id    uuid.UUID
+	spell *domain.Spell
+	err   error
+}
+
+func reforgePollCmd(id uuid.UUID) tea.Cmd {
+	return tea.Tick(reforgePollInterval, func(time.Time) tea.Msg {
+		return reforgePollMsg{id: id}
+	})
+}
+
+// requestReforge asks the Grimoire to weigh the selected spell again. Only
+// its author can, and only when a request isn't already waiting.
+func (m grimoireModel) requestReforge() (grimoireModel, tea.Cmd) {
+	if m.mode != grimoireModeSpells || m.cursor >= len(m.spells) {
+		return m, nil
+	}
+	spell := m.spells[m.cursor]
+	if m.myID == uuid.Nil || spell.MagicianID != m.myID {
+		m.statusMsg = "only your own spells can be re-forged"
+		return m, nil
+	}
+	if spell.Reforge.Pending() {
+		m.statusMsg = "already waiting on the Grimoire"
+		return m, nil
+	}
+	c := m.client
+	m.statusMsg = "asking the Grimoire..."
+	return m, func() tea.Msg {
+		r, err := c.RequestReevaluation(context.Background(), spell.ID.String())
+		return spellReforgeMsg{id: spell.ID, reforge: r, err: err}
+	}
+}
+
+// updateReforge applies re-forge requests and polls, and reports the new
+// verdict when it lands.
+func (m grimoireModel) updateReforge(msg tea.Msg) (grimoireModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case spellReforgeMsg:
+		switch {
+		case client.IsConflict(msg.err):
+			m.statusMsg = "a re-forge is already pending"
+			return m, nil
+		case client.IsForbidden(msg.err):
+			m.statusMsg = "only your own spells can be re-forged"
+			return m, nil
+		case msg.err != nil:
+			m.statusMsg = errText("re-forge failed", msg.err)
+			return m, nil
+		}
+		if i := m.findSpell(msg.id); i >= 0 {
+			m.spells[i].Reforge = msg.reforge
+		}
+		m.statusMsg = "re-forge requested · the Grimoire will weigh it again"
+		return m, reforgePollCmd(msg.id)
+
+	case reforgePollMsg:
+		i := m.findSpell(msg.id)
+		if i < 0 || !m.spells[i].Reforge.Pending() {
+			return m, nil
+		}
+		c, id := m.client, msg.id
+		return m, func() tea.Msg {
+			spell, err := c.GetSpell(context.Background(), id.String())
+			return reforgeCheckedMsg{id: id, spell: spell, err: err}
+		}
+
+	case reforgeCheckedMsg:
+		i := m.findSpell(msg.id)
+		if i < 0 {
+			return m, nil
+		}
+		if msg.err != nil || msg.spell.Reforge.Pending() {
+			// Still out, or a blip; look again later.
+			return m, reforgePollCmd(msg.id)
+		}
+		m.spells[i].Potency = msg.spell.Potency
+		m.spells[i].Voice = msg.spell.Voice
+		m.spells[i].Reforge = msg.spell.Reforge
+		if r := msg.spell.Reforge; r != nil {
+			m.statusMsg = fmt.Sprintf("the Grimoire re-forged your spell: P%d → P%d", r.OldPotency, r.Potency)
+		}
+		return m, announce(m.statusMsg)
+	}
+	return m, nil
+}
+
+// findSpell returns where the spell with id is in the list, or -1.
+func (m grimoireModel) findSpell(id uuid.UUID) int {
+	for i := range m.spells {
+		if m.spells[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// reforgeLine describes a spell's latest re-forge for the detail view, or
+// returns "" when it has none.
+func reforgeLine(r *domain.Reevaluation) string {
+	switch {
+	case r == nil:
+		return ""
+	case r.Pending():
+		return accentStyle.Render("↻ re-forge pending") + metaStyle.Render(" · asked "+formatTime(r.RequestedAt))
+	}
+	line := accentStyle.Render("↻ re-forged ") + potencyStyle(r.OldPotency).Render(fmt.Sprintf("P%d", r.OldPotency)) +
+		metaStyle.Render(" → ") + potencyStyle(r.Potency).Render(fmt.Sprintf("P%d", r.Potency))
+	if !r.ResolvedAt.IsZero() {
+		line += metaStyle.Render(" · " + formatTime(r.ResolvedAt))
+	}
+	return line
+}
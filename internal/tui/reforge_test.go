@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// newReforgeGrimoire returns a detail view of one of my own spells.
+func newReforgeGrimoire() (grimoireModel, *clienttest.Fake) {
+	me := &domain.Magician{ID: uuid.New(), GitHubLogin: "me"}
+	spell := makeTestSpell("Find the flaky test", "testing")
+	spell.MagicianID = me.ID
+	f := &clienttest.Fake{Me: me, Spells: []domain.Spell{spell}}
+	m := newTestGrimoireModel()
+	m.client = f
+	m, _ = m.Update(meLoadedMsg{me: me})
+	m.spells = []domain.Spell{spell}
+	m.detail = true
+	return m, f
+}
+
+func TestGrimoireReforgeRequestAndVerdict(t *testing.T) {
+	m, f := newReforgeGrimoire()
+	id := m.spells[0].ID
+
+	m, cmd := m.Update(keyRune('V'))
+	if cmd == nil {
+		t.Fatal("expected V to ask for a re-forge")
+	}
+	m, poll := m.Update(cmd())
+	if poll == nil || !m.spells[0].Reforge.Pending() {
+		t.Fatalf("after request: reforge %+v, poll scheduled %v", m.spells[0].Reforge, poll != nil)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "re-forge pending") {
+		t.Errorf("expected the pending line:\n%s", view)
+	}
+
+	// Asking again while it's pending doesn't reach the API.
+	m, cmd = m.Update(keyRune('V'))
+	if cmd != nil || m.statusMsg != "already waiting on the Grimoire" {
+		t.Errorf("second V: status %q, cmd %v", m.statusMsg, cmd != nil)
+	}
+
+	// A poll before the verdict keeps polling.
+	m, cmd = m.Update(reforgePollMsg{id: id})
+	m, cmd = m.Update(cmd())
+	if cmd == nil || !m.spells[0].Reforge.Pending() {
+		t.Fatal("expected another poll while the re-forge is pending")
+	}
+
+	f.Spells[0].Potency = 4
+	f.Spells[0].Voice = "Sharper than it looked."
+	f.Spells[0].Reforge = &domain.Reevaluation{Status: domain.ReevaluationDone, OldPotency: 2, Potency: 4, ResolvedAt: time.Now()}
+	m, cmd = m.Update(reforgePollMsg{id: id})
+	m, _ = m.Update(cmd())
+	if m.spells[0].Potency != 4 || m.spells[0].Voice != "Sharper than it looked." {
+		t.Errorf("spell after verdict = potency %d, voice %q", m.spells[0].Potency, m.spells[0].Voice)
+	}
+	if m.statusMsg != "the Grimoire re-forged your spell: P2 → P4" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "re-forged P2 → P4") {
+		t.Errorf("expected the verdict line:\n%s", view)
+	}
+
+	// Once resolved, polls stop.
+	if _, cmd = m.Update(reforgePollMsg{id: id}); cmd != nil {
+		t.Error("expected no poll after the verdict")
+	}
+}
+
+func TestGrimoireReforgeOnlyOwnSpells(t *testing.T) {
+	m, f := newReforgeGrimoire()
+	m.spells[0].MagicianID = uuid.New()
+
+	m, cmd := m.Update(keyRune('V'))
+	if cmd != nil || m.statusMsg != "only your own spells can be re-forged" {
+		t.Errorf("V on someone else's spell: status %q, cmd %v", m.statusMsg, cmd != nil)
+	}
+	if f.Count("RequestReevaluation") != 0 {
+		t.Error("expected no API call")
+	}
+}
+
+func TestGrimoireReforgeAlreadyPending(t *testing.T) {
+	m, f := newReforgeGrimoire()
+	// Pending on the server, but the list was loaded before.
+	f.Spells[0].Reforge = &domain.Reevaluation{Status: domain.ReevaluationPending}
+
+	m, cmd := m.Update(keyRune('V'))
+	m, poll := m.Update(cmd())
+	if poll != nil || m.statusMsg != "a re-forge is already pending" {
+		t.Errorf("status = %q, poll scheduled %v", m.statusMsg, poll != nil)
+	}
+}
+
+func TestStreamEventTextReforge(t *testing.T) {
+	e := domain.StreamEvent{Kind: "reforge", Title: "Find the flaky test", Potency: 4, Tag: "testing"}
+	if got := streamEventText(e); got != "Find the flaky test re-forged to P4 #testing" {
+		t.Errorf("streamEventText() = %q", got)
+	}
+	if streamIcon("reforge") != "↻" {
+		t.Errorf("streamIcon(reforge) = %q", streamIcon("reforge"))
+	}
+}
+
+func TestReforgePollsOnOtherTabs(t *testing.T) {
+	m, f := newReforgeGrimoire()
+	m.spells[0].Reforge = &domain.Reevaluation{Status: domain.ReevaluationPending}
+	f.Spells[0].Reforge = m.spells[0].Reforge
+	a := newSizedTestApp(f, 100, 30)
+	a.grimoire = m
+	a.view = viewHall
+	a.peekOpen = true
+
+	model, cmd := a.Update(reforgePollMsg{id: m.spells[0].ID})
+	if cmd == nil {
+		t.Fatal("the poll was dropped away from the Grimoire")
+	}
+	if _, cmd = model.(App).Update(cmd()); cmd == nil {
+		t.Error("expected the next poll scheduled while the re-forge is pending")
+	}
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"io"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	glog "github.com/naveenspark/grimora/internal/log"
+)
+
+// Banners for a session that lapsed while the app was open.
+const (
+	sessionExpiredBanner = "session expired · press L to re-login"
+	reloginFailedBanner  = "sign-in didn't finish · press L to try again"
+)
+
+// sessionExpiredMsg reports that the API refused the session and it
+// couldn't be renewed.
+type sessionExpiredMsg struct{}
+
+// reloginMsg carries the result of signing in again with L.
+type reloginMsg struct {
+	err error
+}
+
+// WithRelogin lets the app recover from a lapsed session without quitting.
+// expired is signalled whenever the API refuses the session for good; the
+// header then offers L, which hands the terminal to login until it
+// returns. login signs in afresh and points the app's client at the new
+// session.
+func (a App) WithRelogin(expired <-chan struct{}, login func() error) App {
+	a.sessionExpired = expired
+	a.relogin = login
+	return a
+}
+
+// waitExpiredCmd waits for the client to report the session expired.
+func waitExpiredCmd(expired <-chan struct{}) tea.Cmd {
+	if expired == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		<-expired
+		return sessionExpiredMsg{}
+	}
+}
+
+// expireSession puts up the re-login banner and goes back to waiting.
+func (a App) expireSession() (App, tea.Cmd) {
+	if a.relogin != nil && a.degraded != reloginFailedBanner {
+		a.degraded = sessionExpiredBanner
+	}
+	return a, waitExpiredCmd(a.sessionExpired)
+}
+
+// canRelogin reports whether L would sign in again right now.
+func (a App) canRelogin() bool {
+	return a.relogin != nil && (a.degraded == sessionExpiredBanner || a.degraded == reloginFailedBanner)
+}
+
+// startRelogin suspends the TUI and runs login in the plain terminal, where
+// it can open the browser and print its link.
+func (a App) startRelogin() (App, tea.Cmd) {
+	return a, tea.Exec(loginExec{run: a.relogin}, func(err error) tea.Msg {
+		return reloginMsg{err: err}
+	})
+}
+
+// finishRelogin reloads what's on screen under the new session, or keeps
+// the banner up so L can be tried again.
+func (a App) finishRelogin(msg reloginMsg) (App, tea.Cmd) {
+	if msg.err != nil {
+		glog.Warn("re-login failed", "err", msg.err)
+		a.degraded = reloginFailedBanner
+		return a, nil
+	}
+	a.degraded = ""
+	return a, tea.Batch(a.loadMe(), a.hall.Init(), a.viewInit())
+}
+
+// loginExec runs a login function as a tea.ExecCommand. It uses the
+// process's own terminal, so the std streams Exec hands it are ignored.
+type loginExec struct {
+	run func() error
+}
+
+func (e loginExec) Run() error          { return e.run() }
+func (e loginExec) SetStdin(io.Reader)  {}
+func (e loginExec) SetStdout(io.Writer) {}
+func (e loginExec) SetStderr(io.Writer) {}
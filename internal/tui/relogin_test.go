@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+func TestSessionExpiredOffersRelogin(t *testing.T) {
+	expired := make(chan struct{}, 1)
+	logins := 0
+	a := NewApp(nil, "dev").WithRelogin(expired, func() error {
+		logins++
+		return nil
+	})
+	model, _ := a.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	a = model.(App)
+	a.view = viewBoard
+
+	expired <- struct{}{}
+	model, cmd := a.Update(waitExpiredCmd(expired)())
+	a = model.(App)
+	if a.degraded != sessionExpiredBanner || cmd == nil {
+		t.Fatalf("degraded = %q, cmd = %v; want the re-login banner and another wait", a.degraded, cmd)
+	}
+	if !strings.Contains(a.View(), "press L to re-login") {
+		t.Error("expected the re-login banner in the header")
+	}
+
+	model, cmd = a.Update(keyRune('L'))
+	a = model.(App)
+	if cmd == nil {
+		t.Fatal("L: expected the login to run")
+	}
+	if err := (loginExec{run: a.relogin}).Run(); err != nil || logins != 1 {
+		t.Fatalf("login ran %d times (err %v)", logins, err)
+	}
+
+	model, cmd = a.Update(reloginMsg{})
+	a = model.(App)
+	if a.degraded != "" || cmd == nil {
+		t.Errorf("after signing in: degraded = %q, cmd = %v; want cleared and reloading", a.degraded, cmd)
+	}
+}
+
+func TestReloginFailureKeepsBanner(t *testing.T) {
+	a := NewApp(nil, "dev").WithRelogin(make(chan struct{}), func() error { return nil })
+	a.degraded = sessionExpiredBanner
+
+	model, _ := a.Update(reloginMsg{err: errors.New("login timed out")})
+	a = model.(App)
+	if a.degraded != reloginFailedBanner || !a.canRelogin() {
+		t.Errorf("degraded = %q, want L to stay on offer", a.degraded)
+	}
+	// A later refusal doesn't hide that the last attempt failed.
+	if a, _ = a.expireSession(); a.degraded != reloginFailedBanner {
+		t.Errorf("degraded = %q after another refusal", a.degraded)
+	}
+}
+
+func TestReloginNeedsLapsedSession(t *testing.T) {
+	a := NewApp(nil, "dev")
+	a.degraded = sessionExpiredBanner
+	if a.canRelogin() {
+		t.Error("canRelogin() without WithRelogin = true")
+	}
+	a = a.WithRelogin(nil, func() error { return nil })
+	a.degraded = ""
+	if a.canRelogin() {
+		t.Error("canRelogin() while signed in = true")
+	}
+
+	unauthorized := fmt.Errorf("client.GetMe: %w", &client.HTTPError{StatusCode: 401})
+	if got, _ := a.finishStartup(startupAuthMsg{err: unauthorized}); got.degraded != sessionExpiredBanner {
+		t.Errorf("startup 401 with re-login: degraded = %q", got.degraded)
+	}
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"maps"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/state"
+)
+
+// roomAlertsMsg carries every room's alert level after one changed, for
+// the App to save.
+type roomAlertsMsg struct {
+	alerts map[string]string
+}
+
+// WithRoomAlerts applies the per-room alert levels saved in st and
+// remembers changes to them in the state file at path.
+func (a App) WithRoomAlerts(path string, st state.State) App {
+	a.statePath = path
+	a.state = st
+	a.hall.roomAlerts = st.RoomAlerts
+	return a
+}
+
+// saveRoomAlerts records the levels the Hall changed.
+func (a App) saveRoomAlerts(msg roomAlertsMsg) (App, tea.Cmd) {
+	a.state.RoomAlerts = msg.alerts
+	return a, saveStateCmd(a.statePath, a.state)
+}
+
+// roomAlert returns how much the room may notify: "" for as usual,
+// state.RoomMentions or state.RoomMuted.
+func (m hallModel) roomAlert(slug string) string {
+	return m.roomAlerts[slug]
+}
+
+// nextRoomAlert is the level after level as m cycles through them in the
+// room panel: as usual, mentions only, muted.
+func nextRoomAlert(level string) string {
+	switch level {
+	case "":
+		return state.RoomMentions
+	case state.RoomMentions:
+		return state.RoomMuted
+	}
+	return ""
+}
+
+// roomAlertText says what level means for the room slug.
+func roomAlertText(slug, level string) string {
+	switch level {
+	case state.RoomMentions:
+		return "#" + slug + " · no unread count, only @mentions ring"
+	case state.RoomMuted:
+		return "#" + slug + " muted · no unread count or bell"
+	}
+	return "#" + slug + " unmuted"
+}
+
+// setRoomAlert changes the alert level of the room slug. The map is copied,
+// not changed in place, since the App's saved state shares it.
+func (m hallModel) setRoomAlert(slug, level string) (hallModel, tea.Cmd) {
+	alerts := maps.Clone(m.roomAlerts)
+	if alerts == nil {
+		alerts = make(map[string]string)
+	}
+	if level == "" {
+		delete(alerts, slug)
+	} else {
+		alerts[slug] = level
+	}
+	m.roomAlerts = alerts
+	return m, func() tea.Msg { return roomAlertsMsg{alerts: alerts} }
+}
+
+// roomAlertBadge marks a room that doesn't alert as usual, for the room
+// panel and the quick switcher.
+func roomAlertBadge(level string) string {
+	switch level {
+	case state.RoomMentions:
+		return metaStyle.Render("@mentions")
+	case state.RoomMuted:
+		return dimStyle.Render("muted")
+	}
+	return ""
+}
+
+// mutedRoomLine is the system line shown on entering a muted room.
+const mutedRoomLine = "this room is muted · /mute off to hear it again"
+
+func slashMute(m hallModel, arg string) (hallModel, tea.Cmd) {
+	if m.practicing() {
+		m.status = "the practice room never rings"
+		return m, nil
+	}
+	var level string
+	switch arg {
+	case "":
+		level = state.RoomMuted
+	case "mentions":
+		level = state.RoomMentions
+	case "off":
+		level = ""
+	default:
+		m.status = "usage: /mute [mentions|off]"
+		return m, nil
+	}
+	m, cmd := m.setRoomAlert(m.slug(), level)
+	m.status = roomAlertText(m.slug(), level)
+	return m, cmd
+}
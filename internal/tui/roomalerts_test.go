@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestRoomPanelCyclesAlertLevel(t *testing.T) {
+	m := newRoomsTestHall(uuid.New())
+	m.rooms = roomPanel{open: true, cursor: 1} // go-tips
+
+	var levels []string
+	for range 3 {
+		var cmd tea.Cmd
+		m, cmd = m.Update(keyRune('m'))
+		msg, ok := cmd().(roomAlertsMsg)
+		if !ok {
+			t.Fatal("expected m to report the new levels for saving")
+		}
+		levels = append(levels, msg.alerts["go-tips"])
+	}
+	if want := []string{state.RoomMentions, state.RoomMuted, ""}; strings.Join(levels, ",") != strings.Join(want, ",") {
+		t.Errorf("m cycled through %q, want %q", levels, want)
+	}
+
+	m, _ = m.Update(keyRune('m'))
+	m, _ = m.Update(keyRune('m'))
+	line := ansi.Strip(m.renderRoomLine(m.roomList[1], false))
+	if !strings.Contains(line, "muted") {
+		t.Errorf("room line = %q, want it marked muted", line)
+	}
+}
+
+func TestSlashMute(t *testing.T) {
+	m := newRoomsTestHall(uuid.New())
+	m = m.enterRoom("go-tips", "go-tips")
+	for _, tc := range []struct{ input, want string }{
+		{"/mute", state.RoomMuted},
+		{"/mute mentions", state.RoomMentions},
+		{"/mute off", ""},
+	} {
+		m.input = tc.input
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if got := m.roomAlert("go-tips"); got != tc.want {
+			t.Errorf("%s: level = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+	m.input = "/mute loudly"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.status, "usage") {
+		t.Errorf("status = %q, want usage for an unknown level", m.status)
+	}
+}
+
+func TestMutedRoomNeitherRingsNorCounts(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m.roomAlerts = map[string]string{"go-tips": state.RoomMuted}
+	m = m.enterRoom("go-tips", "go-tips")
+
+	m, _ = m.Update(hallMessagesMsg{room: "go-tips"})
+	if !strings.Contains(m.View(), mutedRoomLine) {
+		t.Errorf("expected a muted line on entering, got:\n%s", m.View())
+	}
+	_, cmd := m.Update(hallMessagesMsg{room: "go-tips", messages: []domain.RoomMessage{
+		makeTestRoomMessage("bob", "nyx", "hey @me, look"),
+	}})
+	if _, ok := findAlert(cmd); ok {
+		t.Error("a mention in a muted room should not ring")
+	}
+
+	rooms := []domain.Room{{Slug: "go-tips", Unread: 4}, {Slug: "rustaceans", Unread: 2}}
+	s := newSwitcher(rooms, nil, m.roomAlerts, nil, 80)
+	view := ansi.Strip(s.View())
+	if strings.Contains(view, "4 new") || !strings.Contains(view, "2 new") {
+		t.Errorf("switcher should hide only the muted room's count:\n%s", view)
+	}
+	if !strings.Contains(view, "#go-tips muted") {
+		t.Errorf("switcher should mark the muted room:\n%s", view)
+	}
+}
+
+func TestMentionsOnlyRoomStillRings(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m.roomAlerts = map[string]string{hallSlug: state.RoomMentions}
+	m, _ = m.Update(hallMessagesMsg{})
+	if strings.Contains(m.View(), mutedRoomLine) {
+		t.Error("a mentions-only room isn't muted")
+	}
+	_, cmd := m.Update(hallMessagesMsg{messages: []domain.RoomMessage{
+		makeTestRoomMessage("bob", "nyx", "hey @me, look"),
+	}})
+	if _, ok := findAlert(cmd); !ok {
+		t.Error("expected a mention to ring in a mentions-only room")
+	}
+}
+
+func TestRoomAlertsSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	a := newTestApp().WithRoomAlerts(path, state.State{LatestVersion: "v0.5.0"})
+
+	model, cmd := a.Update(roomAlertsMsg{alerts: map[string]string{"go-tips": state.RoomMuted}})
+	a = model.(App)
+	drainCmd(cmd)
+	st, err := state.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.RoomAlerts["go-tips"] != state.RoomMuted || st.LatestVersion != "v0.5.0" {
+		t.Errorf("saved state = %+v, want go-tips muted and the rest kept", st)
+	}
+
+	b := newTestApp().WithRoomAlerts(path, st)
+	if b.hall.roomAlert("go-tips") != state.RoomMuted {
+		t.Error("expected the saved level applied on the next launch")
+	}
+}
@@ -0,0 +1,476 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// maxRoomTopicLen caps a room topic so it fits on the header line.
+const maxRoomTopicLen = 120
+
+// maxRoomDescLen caps a new room's description.
+const maxRoomDescLen = 200
+
+// maxPanelRooms is how many rooms the panel lists at once.
+const maxPanelRooms = 8
+
+// Room panel modes.
+const (
+	roomsBrowse = iota
+	roomsCreate
+	roomsTopic
+)
+
+// hallRoomsMsg carries the room list for the panel and the header topic.
+type hallRoomsMsg struct {
+	rooms []domain.Room
+	err   error
+}
+
+// roomJoinedMsg carries the result of joining a room picked in the panel.
+type roomJoinedMsg struct {
+	room domain.Room
+	err  error
+}
+
+// roomCreatedMsg carries the result of creating a topic room.
+type roomCreatedMsg struct {
+	room *domain.Room
+	err  error
+}
+
+// roomArchivedMsg carries the result of archiving a room.
+type roomArchivedMsg struct {
+	slug string
+	err  error
+}
+
+// roomTopicMsg carries the result of setting a room topic.
+type roomTopicMsg struct {
+	room *domain.Room
+	err  error
+}
+
+// roomPanel is the /rooms browser: pick a room to join, create a topic room,
+// or, for rooms you own, set the topic or archive it.
+type roomPanel struct {
+	open    bool
+	mode    int
+	cursor  int
+	slug    string // create form
+	desc    string
+	field   int    // 0 = slug, 1 = description
+	topic   string // topic form
+	archive string // slug awaiting a second "a" to confirm
+	err     string
+}
+
+// loadRooms fetches the room list.
+func (m hallModel) loadRooms() tea.Cmd {
+	c := m.client
+	if c == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		rooms, err := c.ListRooms(context.Background())
+		return hallRoomsMsg{rooms: rooms, err: err}
+	}
+}
+
+// roomTopic returns the topic of the room the Hall is showing.
+func (m hallModel) roomTopic() string {
+	slug := m.slug()
+	for _, r := range m.roomList {
+		if r.Slug == slug {
+			return r.Topic
+		}
+	}
+	return ""
+}
+
+// selectedRoom returns the room under the panel cursor.
+func (m hallModel) selectedRoom() (domain.Room, bool) {
+	if m.rooms.cursor < 0 || m.rooms.cursor >= len(m.roomList) {
+		return domain.Room{}, false
+	}
+	return m.roomList[m.rooms.cursor], true
+}
+
+// updateRoomResult applies the results of room panel commands.
+func (m hallModel) updateRoomResult(msg tea.Msg) (hallModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case hallRoomsMsg:
+		if msg.err != nil {
+			m.rooms.err = errText("could not load rooms", msg.err)
+			return m, nil
+		}
+		m.roomList = msg.rooms
+		m.rooms.cursor = min(m.rooms.cursor, max(len(m.roomList)-1, 0))
+
+	case roomJoinedMsg:
+		if msg.err != nil {
+			if m.rooms.open {
+				m.rooms.err = errText("could not join", msg.err)
+			} else {
+				m.status = errText("could not join", msg.err)
+			}
+			return m, nil
+		}
+		m.rooms = roomPanel{}
+		if msg.room.Slug == hallSlug {
+			m = m.enterRoom("", "")
+		} else {
+			m = m.enterRoom(msg.room.Slug, msg.room.Name)
+		}
+		return m, m.loadMessages()
+
+	case roomCreatedMsg:
+		if msg.err != nil {
+			m.rooms.err = roomErrorText("could not create room", msg.err)
+			return m, nil
+		}
+		m.roomList = append(m.roomList, *msg.room)
+		m.rooms = roomPanel{}
+		m = m.enterRoom(msg.room.Slug, msg.room.Name)
+		m.status = "created #" + msg.room.Slug
+		return m, m.loadMessages()
+
+	case roomArchivedMsg:
+		if msg.err != nil {
+			m.rooms.err = roomErrorText("could not archive", msg.err)
+			return m, nil
+		}
+		for i, r := range m.roomList {
+			if r.Slug == msg.slug {
+				m.roomList = append(m.roomList[:i:i], m.roomList[i+1:]...)
+				break
+			}
+		}
+		m.rooms.cursor = min(m.rooms.cursor, max(len(m.roomList)-1, 0))
+		m.rooms.err = "archived #" + msg.slug
+		if m.room == msg.slug {
+			m = m.enterRoom("", "")
+			return m, m.loadMessages()
+		}
+
+	case roomTopicMsg:
+		if msg.err != nil {
+			m.rooms.err = roomErrorText("could not set topic", msg.err)
+			return m, nil
+		}
+		for i, r := range m.roomList {
+			if r.Slug == msg.room.Slug {
+				m.roomList[i].Topic = msg.room.Topic
+			}
+		}
+		m.rooms.mode = roomsBrowse
+		m.rooms.err = ""
+	}
+	return m, nil
+}
+
+// roomErrorText describes a failed room change, preferring the server's
+// field messages (e.g. a taken slug) over the bare status.
+func roomErrorText(prefix string, err error) string {
+	if fields := client.FieldErrors(err); len(fields) > 0 {
+		return prefix + ": " + fields[0].Message
+	}
+	if client.IsForbidden(err) {
+		return prefix + ": only the room's owner can do that"
+	}
+	return errText(prefix, err)
+}
+
+// openRooms shows the room panel and refreshes the list behind it.
+func (m hallModel) openRooms() (hallModel, tea.Cmd) {
+	m.rooms = roomPanel{open: true}
+	for i, r := range m.roomList {
+		if r.Slug == m.slug() {
+			m.rooms.cursor = i
+		}
+	}
+	return m, m.loadRooms()
+}
+
+// updateRooms handles keys while the room panel is open.
+func (m hallModel) updateRooms(msg tea.KeyMsg) (hallModel, tea.Cmd) {
+	switch m.rooms.mode {
+	case roomsCreate:
+		return m.updateRoomCreate(msg)
+	case roomsTopic:
+		return m.updateRoomTopic(msg)
+	}
+
+	key := msg.String()
+	if key != "a" {
+		m.rooms.archive = ""
+	}
+	switch key {
+	case "esc", "q":
+		m.rooms = roomPanel{}
+	case "j", "down":
+		if m.rooms.cursor < len(m.roomList)-1 {
+			m.rooms.cursor++
+		}
+	case "k", "up":
+		if m.rooms.cursor > 0 {
+			m.rooms.cursor--
+		}
+	case "enter":
+		r, ok := m.selectedRoom()
+		if !ok {
+			return m, nil
+		}
+		m.rooms.err = ""
+		return m, m.joinRoom(r)
+	case "c":
+		cmd, problem := m.joinCityRoom()
+		m.rooms.err = problem
+		return m, cmd
+	case "n":
+		if m.myLogin == "" {
+			m.rooms.err = "run: grimora login"
+			return m, nil
+		}
+		m.rooms.mode = roomsCreate
+		m.rooms.slug, m.rooms.desc, m.rooms.field = "", "", 0
+		m.rooms.err = ""
+	case "t":
+		r, ok := m.selectedRoom()
+		if !ok {
+			return m, nil
+		}
+		if !r.OwnedBy(m.myID) {
+			m.rooms.err = "only the room's owner can set its topic"
+			return m, nil
+		}
+		m.rooms.mode = roomsTopic
+		m.rooms.topic = r.Topic
+		m.rooms.err = ""
+	case "a":
+		r, ok := m.selectedRoom()
+		if !ok {
+			return m, nil
+		}
+		if !r.OwnedBy(m.myID) {
+			m.rooms.err = "only the room's owner can archive it"
+			return m, nil
+		}
+		if m.rooms.archive != r.Slug {
+			m.rooms.archive = r.Slug
+			m.rooms.err = "press a again to archive #" + r.Slug
+			return m, nil
+		}
+		m.rooms.archive = ""
+		m.rooms.err = ""
+		return m, m.archiveRoom(r.Slug)
+	case "m":
+		r, ok := m.selectedRoom()
+		if !ok {
+			return m, nil
+		}
+		level := nextRoomAlert(m.roomAlert(r.Slug))
+		var cmd tea.Cmd
+		m, cmd = m.setRoomAlert(r.Slug, level)
+		m.rooms.err = roomAlertText(r.Slug, level)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateRoomCreate handles keys in the new room form.
+func (m hallModel) updateRoomCreate(msg tea.KeyMsg) (hallModel, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "esc":
+		m.rooms.mode = roomsBrowse
+		m.rooms.err = ""
+		return m, nil
+	case "tab", "shift+tab", "up", "down":
+		m.rooms.field = 1 - m.rooms.field
+		return m, nil
+	case "enter":
+		slug := strings.TrimSpace(m.rooms.slug)
+		if !domain.ValidRoomSlug(slug) {
+			m.rooms.field = 0
+			m.rooms.err = fmt.Sprintf("slug: %d-%d lowercase letters, digits or dashes", domain.MinRoomSlugLen, domain.MaxRoomSlugLen)
+			return m, nil
+		}
+		m.rooms.err = "creating #" + slug + "..."
+		return m, m.createRoom(client.CreateRoomRequest{Slug: slug, Description: strings.TrimSpace(m.rooms.desc)})
+	}
+	if m.rooms.field == 0 {
+		// Slugs are typed the way they'll be stored.
+		if key == " " {
+			key = "-"
+		}
+		if len(key) == 1 && utf8.RuneCountInString(m.rooms.slug) >= domain.MaxRoomSlugLen {
+			return m, nil
+		}
+		m.rooms.slug = strings.ToLower(editRune(m.rooms.slug, key))
+	} else {
+		if len(key) == 1 && utf8.RuneCountInString(m.rooms.desc) >= maxRoomDescLen {
+			return m, nil
+		}
+		m.rooms.desc = editRune(m.rooms.desc, key)
+	}
+	return m, nil
+}
+
+// updateRoomTopic handles keys in the topic form.
+func (m hallModel) updateRoomTopic(msg tea.KeyMsg) (hallModel, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "esc":
+		m.rooms.mode = roomsBrowse
+		m.rooms.err = ""
+		return m, nil
+	case "enter":
+		r, ok := m.selectedRoom()
+		if !ok {
+			m.rooms.mode = roomsBrowse
+			return m, nil
+		}
+		return m, m.setRoomTopic(r.Slug, strings.TrimSpace(m.rooms.topic))
+	}
+	if len(key) == 1 && utf8.RuneCountInString(m.rooms.topic) >= maxRoomTopicLen {
+		return m, nil
+	}
+	m.rooms.topic = editRune(m.rooms.topic, key)
+	return m, nil
+}
+
+// joinRoom joins r and reports back so the Hall can switch to it. The main
+// Hall needs no join.
+func (m hallModel) joinRoom(r domain.Room) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if r.Slug != hallSlug {
+			if err := c.JoinRoom(context.Background(), r.Slug); err != nil {
+				return roomJoinedMsg{err: err}
+			}
+		}
+		return roomJoinedMsg{room: r}
+	}
+}
+
+func (m hallModel) createRoom(req client.CreateRoomRequest) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		room, err := c.CreateRoom(context.Background(), req)
+		return roomCreatedMsg{room: room, err: err}
+	}
+}
+
+func (m hallModel) archiveRoom(slug string) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		return roomArchivedMsg{slug: slug, err: c.ArchiveRoom(context.Background(), slug)}
+	}
+}
+
+func (m hallModel) setRoomTopic(slug, topic string) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		room, err := c.SetRoomTopic(context.Background(), slug, topic)
+		return roomTopicMsg{room: room, err: err}
+	}
+}
+
+// roomPanelHeight returns the number of lines the room panel occupies.
+func (m hallModel) roomPanelHeight() int {
+	if !m.rooms.open {
+		return 0
+	}
+	n := 1 // title
+	switch m.rooms.mode {
+	case roomsCreate:
+		n += 2
+	case roomsTopic:
+		n++
+	default:
+		n += max(min(len(m.roomList), maxPanelRooms), 1)
+	}
+	if m.rooms.err != "" {
+		n++
+	}
+	return n
+}
+
+// renderRoomPanel renders the room panel above the input line.
+func (m hallModel) renderRoomPanel() string {
+	var b strings.Builder
+	switch m.rooms.mode {
+	case roomsCreate:
+		b.WriteString(" " + dimStyle.Render("new topic room · tab next field · enter create · esc back") + "\n")
+		b.WriteString(m.renderRoomField("slug ", m.rooms.slug, m.rooms.field == 0) + "\n")
+		b.WriteString(m.renderRoomField("about", m.rooms.desc, m.rooms.field == 1) + "\n")
+	case roomsTopic:
+		r, _ := m.selectedRoom()
+		b.WriteString(" " + dimStyle.Render("topic for #"+r.Slug+" · enter save (empty clears) · esc back") + "\n")
+		b.WriteString(m.renderRoomField("topic", m.rooms.topic, true) + "\n")
+	default:
+		b.WriteString(" " + dimStyle.Render("rooms · enter join · c your city · n new · t topic · a archive · m mute · esc close") + "\n")
+		if len(m.roomList) == 0 {
+			b.WriteString("   " + dimStyle.Render("loading rooms...") + "\n")
+		}
+		start := max(0, m.rooms.cursor-maxPanelRooms+1)
+		end := min(len(m.roomList), start+maxPanelRooms)
+		for i := start; i < end; i++ {
+			b.WriteString(m.renderRoomLine(m.roomList[i], i == m.rooms.cursor) + "\n")
+		}
+	}
+	if m.rooms.err != "" {
+		b.WriteString(" " + rejectStyle.Render(m.rooms.err) + "\n")
+	}
+	return b.String()
+}
+
+// renderRoomLine renders one room in the panel list.
+func (m hallModel) renderRoomLine(r domain.Room, selected bool) string {
+	marker := "  "
+	name := dimStyle.Render("#" + r.Slug)
+	if selected {
+		marker = accentStyle.Render("▸ ")
+		name = selectedStyle.Render("#" + r.Slug)
+	}
+	line := " " + marker + name
+	if r.Slug == m.slug() {
+		line += " " + presenceDotStyle.Render("●")
+	}
+	if r.OwnedBy(m.myID) {
+		line += " " + goldStyle.Render("owner")
+	}
+	if badge := roomAlertBadge(m.roomAlert(r.Slug)); badge != "" {
+		line += " " + badge
+	}
+	about := r.Topic
+	if about == "" {
+		about = r.Description
+	}
+	if about != "" {
+		line += metaStyle.Render(" · " + truncStr(about, max(m.width-len(r.Slug)-20, 10)))
+	}
+	return line
+}
+
+// renderRoomField renders a labelled single-line form field.
+func (m hallModel) renderRoomField(label, value string, focused bool) string {
+	line := "   " + dimStyle.Render(label) + "  " + value
+	if focused {
+		line += accentStyle.Render("▏")
+	}
+	return line
+}
+
+// renderTopicLine renders the current room's topic under the banner.
+func (m hallModel) renderTopicLine() string {
+	return " " + metaStyle.Render("topic: "+truncStr(m.roomTopic(), max(m.width-10, 10)))
+}
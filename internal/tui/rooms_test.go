@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func typeRoomKeys(m hallModel, s string) hallModel {
+	for _, r := range s {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func newRoomsTestHall(owner uuid.UUID) hallModel {
+	m := newTestHallModel()
+	m.myLogin = "naveenspark"
+	m.myID = owner
+	m.roomList = []domain.Room{
+		{Slug: hallSlug, Name: "The Hall", RoomType: domain.RoomTypeHall},
+		{Slug: "go-tips", Name: "go-tips", RoomType: domain.RoomTypeTopic, CreatedBy: &owner},
+		{Slug: "rustaceans", Name: "rustaceans", RoomType: domain.RoomTypeTopic},
+	}
+	return m
+}
+
+func TestHallRoomsSlashCommandOpensPanel(t *testing.T) {
+	m := newRoomsTestHall(uuid.New())
+	m.input = "/rooms"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.rooms.open {
+		t.Fatal("expected /rooms to open the room panel")
+	}
+	if m.input != "" {
+		t.Errorf("input = %q, want cleared", m.input)
+	}
+	if !strings.Contains(m.View(), "#go-tips") {
+		t.Error("expected the panel to list rooms")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.rooms.open {
+		t.Error("expected esc to close the panel")
+	}
+}
+
+func TestHallRoomsOwnerOnlyActions(t *testing.T) {
+	m := newRoomsTestHall(uuid.New())
+	m.rooms = roomPanel{open: true, cursor: 2} // rustaceans, not ours
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd != nil || !strings.Contains(m.rooms.err, "owner") {
+		t.Errorf("archive on someone else's room: err = %q, cmd = %v", m.rooms.err, cmd)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if m.rooms.mode != roomsBrowse {
+		t.Error("expected topic form to stay closed for a room we don't own")
+	}
+}
+
+func TestHallRoomsArchiveNeedsConfirm(t *testing.T) {
+	var archived string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		archived = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	owner := uuid.New()
+	m := newRoomsTestHall(owner)
+	m.client = client.New(srv.URL, "tok")
+	m = m.enterRoom("go-tips", "go-tips")
+	m.rooms = roomPanel{open: true, cursor: 1}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd != nil {
+		t.Fatal("expected the first a to only ask for confirmation")
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd == nil {
+		t.Fatal("expected the second a to archive")
+	}
+	m, _ = m.Update(cmd())
+	if archived != "/api/rooms/go-tips/archive" {
+		t.Errorf("archived %q", archived)
+	}
+	for _, r := range m.roomList {
+		if r.Slug == "go-tips" {
+			t.Error("expected the archived room to leave the list")
+		}
+	}
+	if m.room != "" {
+		t.Errorf("room = %q, want back in the Hall after archiving it", m.room)
+	}
+}
+
+func TestHallRoomsCreateValidatesSlug(t *testing.T) {
+	m := newRoomsTestHall(uuid.New())
+	m.rooms = roomPanel{open: true}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.rooms.mode != roomsCreate {
+		t.Fatal("expected n to open the create form")
+	}
+	m = typeRoomKeys(m, "go")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !strings.HasPrefix(m.rooms.err, "slug:") {
+		t.Errorf("short slug: err = %q, cmd = %v", m.rooms.err, cmd)
+	}
+}
+
+func TestHallRoomsCreateEntersRoom(t *testing.T) {
+	var got client.CreateRoomRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got) //nolint:errcheck
+		room := domain.Room{Slug: got.Slug, Name: got.Slug, RoomType: domain.RoomTypeTopic}
+		json.NewEncoder(w).Encode(room) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	m := newRoomsTestHall(uuid.New())
+	m.client = client.New(srv.URL, "tok")
+	m.rooms = roomPanel{open: true}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = typeRoomKeys(m, "Late Night")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeRoomKeys(m, "for the 2AM crowd")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected a create command, err = %q", m.rooms.err)
+	}
+	m, _ = m.Update(cmd())
+
+	if got.Slug != "late-night" || got.Description != "for the 2AM crowd" {
+		t.Errorf("request = %+v", got)
+	}
+	if m.rooms.open || m.room != "late-night" {
+		t.Errorf("open = %v, room = %q; want panel closed inside the new room", m.rooms.open, m.room)
+	}
+}
+
+func TestHallTopicShownInHeader(t *testing.T) {
+	m := newRoomsTestHall(uuid.New())
+	m.roomList[0].Topic = "ship week: post your demos"
+	if !strings.Contains(m.View(), "topic: ship week") {
+		t.Error("expected the Hall header to show the topic")
+	}
+
+	m, _ = m.Update(roomTopicMsg{room: &domain.Room{Slug: hallSlug, Topic: ""}})
+	if strings.Contains(m.View(), "topic:") {
+		t.Error("expected a cleared topic to drop the header line")
+	}
+}
@@ -0,0 +1,227 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// maxRosterLines is how many magicians the roster lists at once.
+const maxRosterLines = 8
+
+// rosterFollowMsg carries the result of following or unfollowing someone
+// from the roster.
+type rosterFollowMsg struct {
+	login     string
+	following bool // the state asked for
+	err       error
+}
+
+// rosterPanel lists the magicians present in the current room, with quick
+// actions on the one under the cursor.
+type rosterPanel struct {
+	open   bool
+	cursor int
+	err    string // why the last action failed
+	status string // what the last action did
+}
+
+// rosterLogins returns who's present in the room, you first and everyone
+// else in alphabetical order.
+func (m hallModel) rosterLogins() []string {
+	logins := slices.Clone(m.presenceLogins)
+	slices.SortFunc(logins, func(a, b string) int {
+		switch {
+		case a == m.myLogin:
+			return -1
+		case b == m.myLogin:
+			return 1
+		}
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return slices.Compact(logins)
+}
+
+// selectedMember returns the login under the roster cursor.
+func (m hallModel) selectedMember() (string, bool) {
+	logins := m.rosterLogins()
+	if len(logins) == 0 {
+		return "", false
+	}
+	return logins[min(m.roster.cursor, len(logins)-1)], true
+}
+
+// openRoster shows the roster for the current room.
+func (m hallModel) openRoster() hallModel {
+	m.roster = rosterPanel{open: true}
+	return m
+}
+
+// updateRoster handles keys while the roster is open.
+func (m hallModel) updateRoster(msg tea.KeyMsg) (hallModel, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "esc", "q", "m":
+		m.roster = rosterPanel{}
+		return m, nil
+	case "j", "down":
+		if m.roster.cursor < len(m.rosterLogins())-1 {
+			m.roster.cursor++
+		}
+		return m, nil
+	case "k", "up":
+		m.roster.cursor = min(m.roster.cursor, len(m.rosterLogins())-1)
+		if m.roster.cursor > 0 {
+			m.roster.cursor--
+		}
+		return m, nil
+	}
+
+	login, ok := m.selectedMember()
+	if !ok {
+		return m, nil
+	}
+	m.roster.err, m.roster.status = "", ""
+	switch key {
+	case "p":
+		return m, func() tea.Msg { return showPeekMsg{login: login} }
+	case "f", "d", "@":
+		if login == m.myLogin {
+			m.roster.err = "that's you"
+			return m, nil
+		}
+	}
+	switch key {
+	case "f":
+		return m, m.toggleFollow(login)
+	case "d":
+		m.roster = rosterPanel{}
+		return m, func() tea.Msg { return openDMMsg{login: login} }
+	case "@":
+		m.roster = rosterPanel{}
+		m = m.insertMention(login)
+		m.inputFocused = true
+		m.status = ""
+	}
+	return m, nil
+}
+
+// toggleFollow follows login, or unfollows them if you already do.
+func (m hallModel) toggleFollow(login string) tea.Cmd {
+	c := m.client
+	follow := !m.magicians[login].IsFollowing
+	return func() tea.Msg {
+		var err error
+		if follow {
+			err = c.Follow(context.Background(), login)
+		} else {
+			err = c.Unfollow(context.Background(), login)
+		}
+		return rosterFollowMsg{login: login, following: follow, err: err}
+	}
+}
+
+// updateRosterFollow records the result of a roster follow.
+func (m hallModel) updateRosterFollow(msg rosterFollowMsg) hallModel {
+	if msg.err != nil {
+		m.roster.err = errText("could not follow", msg.err)
+		return m
+	}
+	card := m.magicians[msg.login]
+	card.GitHubLogin = msg.login
+	card.IsFollowing = msg.following
+	if m.magicians == nil {
+		m.magicians = make(map[string]domain.MagicianCard)
+	}
+	m.magicians[msg.login] = card
+	if msg.following {
+		m.roster.status = "following @" + msg.login
+	} else {
+		m.roster.status = "unfollowed @" + msg.login
+	}
+	return m
+}
+
+// insertMention puts "@login " into the input at the cursor, spaced off
+// from any word before it.
+func (m hallModel) insertMention(login string) hallModel {
+	before, after := m.inputCursor.split(m.input)
+	mention := "@" + login + " "
+	if before != "" && !strings.HasSuffix(before, " ") && !strings.HasSuffix(before, "\n") {
+		mention = " " + mention
+	}
+	m.input = before + mention + after
+	return m
+}
+
+// rosterPanelHeight returns the number of lines the roster occupies.
+func (m hallModel) rosterPanelHeight() int {
+	if !m.roster.open {
+		return 0
+	}
+	n := 1 + max(min(len(m.rosterLogins()), maxRosterLines), 1)
+	if m.roster.err != "" || m.roster.status != "" {
+		n++
+	}
+	return n
+}
+
+// renderRosterPanel renders the roster above the input line.
+func (m hallModel) renderRosterPanel() string {
+	var b strings.Builder
+	logins := m.rosterLogins()
+	title := "here now · p peek · f follow · d message · @ mention · esc close"
+	b.WriteString(" " + dimStyle.Render(truncStr(title, max(m.width-2, 10))) + "\n")
+	if len(logins) == 0 {
+		b.WriteString("   " + dimStyle.Render("nobody here yet") + "\n")
+	}
+	cursor := min(m.roster.cursor, max(len(logins)-1, 0))
+	start := max(0, cursor-maxRosterLines+1)
+	end := min(len(logins), start+maxRosterLines)
+	for i := start; i < end; i++ {
+		b.WriteString(m.renderRosterLine(logins[i], i == cursor) + "\n")
+	}
+	switch {
+	case m.roster.err != "":
+		b.WriteString(" " + rejectStyle.Render(m.roster.err) + "\n")
+	case m.roster.status != "":
+		b.WriteString(" " + dimStyle.Render(m.roster.status) + "\n")
+	}
+	return b.String()
+}
+
+// renderRosterLine renders one present magician: presence, login in their
+// guild's color, and what you know of them.
+func (m hallModel) renderRosterLine(login string, selected bool) string {
+	card, known := m.magicians[login]
+	marker := "  "
+	if selected {
+		marker = accentStyle.Render("▸ ")
+	}
+	name := GuildStyle(card.GuildID).Render(login)
+	if selected {
+		name = selectedStyle.Render(login)
+	}
+	line := " " + marker + presenceDot(true, card.Away) + " " + name
+	if card.GuildID != "" {
+		line += " " + metaStyle.Render(card.GuildID)
+	}
+	switch {
+	case login == m.myLogin:
+		line += " " + dimStyle.Render("you")
+	case known && card.IsFollowing && card.FollowsYou:
+		line += " " + goldStyle.Render("mutual")
+	case known && card.IsFollowing:
+		line += " " + goldStyle.Render("following")
+	case known && card.FollowsYou:
+		line += " " + dimStyle.Render("follows you")
+	}
+	if card.Away {
+		line += " " + dimStyle.Render("away")
+	}
+	return line
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func keyRune(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// newRosterHall returns a Hall in nav mode with zara, me and ada present.
+func newRosterHall() hallModel {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m.inputFocused = false
+	m.presenceLogins = []string{"zara", "me", "ada"}
+	m, _ = m.Update(hallLoginsMsg{cards: []domain.MagicianCard{
+		{Magician: domain.Magician{GitHubLogin: "ada", GuildID: "nyx"}, IsFollowing: true},
+		{Magician: domain.Magician{GitHubLogin: "zara", GuildID: "cipher"}, Away: true},
+	}})
+	m, _ = m.Update(keyRune('m'))
+	return m
+}
+
+func TestRosterListsPresentMagicians(t *testing.T) {
+	m := newRosterHall()
+	if !m.roster.open {
+		t.Fatal("m should open the roster")
+	}
+	if got := m.rosterLogins(); strings.Join(got, ",") != "me,ada,zara" {
+		t.Errorf("rosterLogins() = %v, want you first then alphabetical", got)
+	}
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"here now", "me you", "ada nyx following", "zara cipher away"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("roster missing %q:\n%s", want, view)
+		}
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.roster.open {
+		t.Error("esc should close the roster")
+	}
+}
+
+func TestRosterActions(t *testing.T) {
+	m := newRosterHall()
+	m, _ = m.Update(keyRune('j')) // ada
+
+	_, cmd := m.Update(keyRune('p'))
+	if peek, ok := cmd().(showPeekMsg); !ok || peek.login != "ada" {
+		t.Errorf("p sent %#v, want a peek at ada", cmd())
+	}
+
+	next, cmd := m.Update(keyRune('d'))
+	if dm, ok := cmd().(openDMMsg); !ok || dm.login != "ada" {
+		t.Errorf("d sent %#v, want a DM with ada", cmd())
+	}
+	if next.roster.open {
+		t.Error("d should close the roster")
+	}
+
+	m.input = "thanks"
+	m, _ = m.Update(keyRune('@'))
+	if m.input != "thanks @ada " || !m.inputFocused || m.roster.open {
+		t.Errorf("@ left input %q, focused %v, roster open %v", m.input, m.inputFocused, m.roster.open)
+	}
+}
+
+func TestRosterFollowToggles(t *testing.T) {
+	f := &clienttest.Fake{}
+	m := newRosterHall()
+	m.client = f
+	m, _ = m.Update(keyRune('j'))
+	m, _ = m.Update(keyRune('j')) // zara
+
+	m, cmd := m.Update(keyRune('f'))
+	m, _ = m.Update(cmd())
+	if !m.magicians["zara"].IsFollowing || m.roster.status != "following @zara" || m.roster.err != "" {
+		t.Errorf("after f: following %v, status %q", m.magicians["zara"].IsFollowing, m.roster.status)
+	}
+
+	m, cmd = m.Update(keyRune('f'))
+	m, _ = m.Update(cmd())
+	if m.magicians["zara"].IsFollowing || m.roster.status != "unfollowed @zara" {
+		t.Errorf("after second f: following %v, status %q", m.magicians["zara"].IsFollowing, m.roster.status)
+	}
+	if f.Count("Follow") != 1 || f.Count("Unfollow") != 1 {
+		t.Errorf("API calls = %v, want one Follow and one Unfollow", f.Calls())
+	}
+}
+
+func TestRosterRefusesActionsOnYourself(t *testing.T) {
+	m := newRosterHall()
+	for _, r := range "fd@" {
+		var cmd tea.Cmd
+		m, cmd = m.Update(keyRune(r))
+		if cmd != nil || m.roster.err != "that's you" || !m.roster.open {
+			t.Errorf("%c on yourself: status %q, cmd %v", r, m.roster.err, cmd != nil)
+		}
+	}
+}
+
+func TestRosterEmptyRoom(t *testing.T) {
+	m := newTestHallModel()
+	m.inputFocused = false
+	m, _ = m.Update(keyRune('m'))
+	m, cmd := m.Update(keyRune('p'))
+	if cmd != nil {
+		t.Error("p in an empty roster should do nothing")
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "nobody here yet") {
+		t.Errorf("expected the empty note:\n%s", view)
+	}
+}
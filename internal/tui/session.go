@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/state"
+)
+
+// resumableViews are the tabs a session can reopen on. The new spell form
+// and notifications are passing visits, so a session left there resumes on
+// the Hall.
+var resumableViews = []view{viewHall, viewGrimoire, viewThreads, viewBoard, viewYou, viewGuild}
+
+// Session returns where the app is, to be saved on quit and handed to
+// WithSession on the next launch.
+func (a App) Session() state.Session {
+	s := state.Session{
+		GrimoireSort: a.grimoire.sortBy,
+		GrimoireTag:  strings.Join(a.grimoire.tagFilters, ","),
+		BoardGuild:   a.board.guildFilter,
+		BoardCity:    a.board.cityFilter,
+	}
+	if slices.Contains(resumableViews, a.view) {
+		s.Tab = a.view.String()
+	}
+	if !a.hall.practicing() {
+		s.Room, s.RoomName = a.hall.room, a.hall.roomName
+		s.HallAnchor = a.hall.scrollAnchor()
+	}
+	if a.grimoire.mode == grimoireModeSpells && a.grimoire.cursor < len(a.grimoire.spells) {
+		s.GrimoireSpell = a.grimoire.spells[a.grimoire.cursor].ID.String()
+	}
+	return s
+}
+
+// WithSession reopens the tab, room and filters of a saved session.
+func (a App) WithSession(s state.Session) App {
+	for _, v := range resumableViews {
+		if v.String() == s.Tab {
+			a.view = v
+		}
+	}
+	if s.Room != "" {
+		a.hall = a.hall.enterRoom(s.Room, s.RoomName)
+	}
+	a.hall.resumeID = s.HallAnchor
+	a.grimoire.resumeID = s.GrimoireSpell
+	if s.GrimoireSort != "" {
+		a.grimoire.sortBy = s.GrimoireSort
+	}
+	a.grimoire.tagFilters = nil
+	if s.GrimoireTag != "" {
+		a.grimoire.tagFilters = strings.Split(s.GrimoireTag, ",")
+	}
+	if i := slices.Index(guildOrder, s.BoardGuild); i >= 0 {
+		a.board.guildFilter, a.board.guildCycle = s.BoardGuild, i
+	}
+	if s.BoardCity != "" {
+		// The full city list comes with the unfiltered board; until then,
+		// c cycles back to all cities.
+		a.board.cityFilter = s.BoardCity
+		a.board.cityOrder = []string{"", s.BoardCity}
+		a.board.cityCycle = 1
+	}
+	return a
+}
+
+// scrollAnchor returns the ID of the newest message on screen while the
+// Hall is scrolled up, or "" when it shows the latest.
+func (m hallModel) scrollAnchor() string {
+	if m.scroll == 0 {
+		return ""
+	}
+	ids := m.visibleMessageIDs()
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[len(ids)-1]
+}
+
+// resumeScroll scrolls the first page of a resumed room so the message the
+// session was saved at sits at the bottom again, with what came after it
+// counted as new. If it has scrolled out of the latest page the room opens
+// at the latest, as usual.
+func (m hallModel) resumeScroll() hallModel {
+	id := m.resumeID
+	m.resumeID = ""
+	idx := slices.IndexFunc(m.messages, func(msg chatMessage) bool { return msg.ID == id })
+	if idx < 0 {
+		return m
+	}
+	lines, starts := m.messageLines()
+	end := len(lines)
+	if idx+1 < len(starts) {
+		end = starts[idx+1]
+	}
+	m.scroll = max(len(lines)-end, 0)
+	m.newBelow = 0
+	for _, msg := range m.messages[idx+1:] {
+		if !msg.IsSelf && !msg.IsSystem {
+			m.newBelow++
+		}
+	}
+	return m
+}
+
+// viewInit returns the loading command for the active tab when it isn't
+// the Hall, which Init always loads.
+func (a App) viewInit() tea.Cmd {
+	return a.initFor(a.view)
+}
+
+// initFor returns the loading command for tab v, or nil for the Hall.
+func (a App) initFor(v view) tea.Cmd {
+	switch v {
+	case viewGrimoire:
+		return a.grimoire.Init()
+	case viewThreads:
+		return a.threads.Init()
+	case viewBoard:
+		return a.board.Init()
+	case viewYou:
+		return a.you.Init()
+	case viewGuild:
+		return a.guild.Init()
+	}
+	return nil
+}
+
+// TabNames lists the tabs StartView can open on.
+func TabNames() []string {
+	names := make([]string, len(resumableViews))
+	for i, v := range resumableViews {
+		names[i] = v.String()
+	}
+	return names
+}
+
+// StartView is a tab to open on, with its filters, as asked for on the
+// command line with grimora --view.
+type StartView struct {
+	Tab    string // one of TabNames
+	Tag    string // Grimoire tag filters, comma-separated
+	Search string // Grimoire search query
+}
+
+// WithStartView opens on the tab in s instead of the saved session's. The
+// Grimoire shows exactly the filters given, none meaning every spell.
+func (a App) WithStartView(s StartView) App {
+	for _, v := range resumableViews {
+		if v.String() == s.Tab {
+			a.view = v
+		}
+	}
+	if a.view == viewGrimoire {
+		a.grimoire.tagFilters = nil
+		if s.Tag != "" {
+			a.grimoire.tagFilters = strings.Split(s.Tag, ",")
+		}
+		a.grimoire.search = s.Search
+		a.grimoire.detail = false
+	}
+	return a
+}
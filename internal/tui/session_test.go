@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestSessionRoundTrip(t *testing.T) {
+	a := newTestApp()
+	a.view = viewBoard
+	a.hall = a.hall.enterRoom("go-help", "Go help")
+	a.grimoire.sortBy, a.grimoire.tagFilters = "top", []string{"debugging", "rust"}
+	a.board.guildFilter, a.board.cityFilter = "nyx", "Berlin"
+
+	want := state.Session{Tab: "board", Room: "go-help", RoomName: "Go help", GrimoireSort: "top", GrimoireTag: "debugging,rust", BoardGuild: "nyx", BoardCity: "Berlin"}
+	if got := a.Session(); got != want {
+		t.Fatalf("Session() = %+v, want %+v", got, want)
+	}
+
+	b := newTestApp().WithSession(want)
+	if b.view != viewBoard || b.hall.room != "go-help" || b.hall.roomName != "Go help" {
+		t.Errorf("restored view %v in room %q", b.view, b.hall.room)
+	}
+	if b.grimoire.sortBy != "top" || !slices.Equal(b.grimoire.tagFilters, []string{"debugging", "rust"}) {
+		t.Errorf("restored grimoire sort %q, tags %q", b.grimoire.sortBy, b.grimoire.tagFilters)
+	}
+	if b.board.guildFilter != "nyx" || guildOrder[b.board.guildCycle] != "nyx" || b.board.cityFilter != "Berlin" {
+		t.Errorf("restored board guild %q (cycle %d), city %q", b.board.guildFilter, b.board.guildCycle, b.board.cityFilter)
+	}
+
+	// c clears a restored city even before the full city list has loaded.
+	b.board, _ = b.board.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if b.board.cityFilter != "" {
+		t.Errorf("city after c = %q, want all cities", b.board.cityFilter)
+	}
+}
+
+func TestSessionResumesScroll(t *testing.T) {
+	var msgs []domain.RoomMessage
+	for i := range 40 {
+		msg := makeTestRoomMessage("alice", "loomari", fmt.Sprintf("message %d", i))
+		msg.CreatedAt = time.Now().Add(time.Duration(i-40) * time.Minute)
+		msgs = append(msgs, msg)
+	}
+	spells := []domain.Spell{makeTestSpell("one", "go"), makeTestSpell("two", "go"), makeTestSpell("three", "go")}
+
+	a := newTestApp()
+	a.hall = newTestHallModel()
+	a.hall, _ = a.hall.Update(hallMessagesMsg{messages: msgs})
+	a.hall.scroll = 12
+	a.grimoire.spells, a.grimoire.cursor = spells, 1
+	s := a.Session()
+	if s.HallAnchor == "" || s.HallAnchor == msgs[len(msgs)-1].ID.String() {
+		t.Fatalf("HallAnchor = %q, want a message above the latest", s.HallAnchor)
+	}
+	if s.GrimoireSpell != spells[1].ID.String() {
+		t.Errorf("GrimoireSpell = %q, want the spell under the cursor", s.GrimoireSpell)
+	}
+
+	b := newTestApp().WithSession(s)
+	b.hall.width, b.hall.height = a.hall.width, a.hall.height
+	// A message that arrived since still counts as new below the old place.
+	later := append(msgs, makeTestRoomMessage("bob", "nyx", "since you left"))
+	b.hall, _ = b.hall.Update(hallMessagesMsg{messages: later})
+	if got := b.hall.scrollAnchor(); got != s.HallAnchor {
+		t.Errorf("resumed at %q, want %q", got, s.HallAnchor)
+	}
+	if b.hall.newBelow == 0 {
+		t.Error("want the messages below the resumed place counted as new")
+	}
+	b.grimoire, _ = b.grimoire.Update(spellsLoadedMsg{spells: []domain.Spell{spells[2], spells[0], spells[1]}})
+	if b.grimoire.cursor != 2 {
+		t.Errorf("cursor = %d, want it back on the saved spell", b.grimoire.cursor)
+	}
+
+	// At the latest message there is no place to keep.
+	a.hall.scroll = 0
+	if got := a.Session().HallAnchor; got != "" {
+		t.Errorf("HallAnchor = %q at the bottom, want none", got)
+	}
+}
+
+func TestSessionSkipsPassingState(t *testing.T) {
+	a := newTestApp().WithTour()
+	a.view = viewNotifications
+	s := a.Session()
+	if s.Tab != "" || s.Room != "" {
+		t.Errorf("Session() = %+v; notifications and the practice room shouldn't be resumed", s)
+	}
+
+	b := newTestApp().WithSession(state.Session{Tab: "create", GrimoireSort: ""})
+	if b.view != viewHall || b.grimoire.sortBy != "new" {
+		t.Errorf("view %v, sort %q; want the Hall and the default sort", b.view, b.grimoire.sortBy)
+	}
+}
+
+func TestStartViewOverridesSession(t *testing.T) {
+	saved := state.Session{Tab: "board", GrimoireTag: "rust"}
+	a := newTestApp().WithSession(saved).WithStartView(StartView{Tab: "grimoire", Tag: "debugging,testing", Search: "flaky"})
+	if a.view != viewGrimoire || !slices.Equal(a.grimoire.tagFilters, []string{"debugging", "testing"}) || a.grimoire.search != "flaky" {
+		t.Errorf("opened %v with tags %q, search %q", a.view, a.grimoire.tagFilters, a.grimoire.search)
+	}
+
+	// Asking for the Grimoire without filters shows every spell.
+	b := newTestApp().WithSession(saved).WithStartView(StartView{Tab: "grimoire"})
+	if len(b.grimoire.tagFilters) != 0 {
+		t.Errorf("tags = %q, want none", b.grimoire.tagFilters)
+	}
+	if c := newTestApp().WithSession(saved).WithStartView(StartView{Tab: "hall"}); c.view != viewHall {
+		t.Errorf("opened %v, want the Hall", c.view)
+	}
+}
+
+func TestHallGoneRoomFallsBack(t *testing.T) {
+	m := newTestHallModel().enterRoom("archived", "Archived")
+	m, cmd := m.Update(hallMessagesMsg{room: "archived", err: &client.HTTPError{StatusCode: 404}})
+	if m.room != "" || cmd == nil {
+		t.Fatalf("room = %q after a 404; want the Hall and a reload", m.room)
+	}
+	if m.status == "" || m.err != "" {
+		t.Errorf("status %q, err %q; want a note and no error", m.status, m.err)
+	}
+}
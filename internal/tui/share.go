@@ -0,0 +1,189 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// createShareKey remembers the shared draft behind the spell form, so its
+// link and suggestions survive a restart.
+const createShareKey = "create:share"
+
+// draftSharedMsg carries the result of sharing or re-syncing the spell form.
+type draftSharedMsg struct {
+	draft  *domain.SpellDraft
+	copied bool // share link is on the clipboard
+	err    error
+}
+
+// draftLoadedMsg carries a shared draft fetched to check for suggestions.
+type draftLoadedMsg struct {
+	draft *domain.SpellDraft
+	err   error
+}
+
+// suggestionResolvedMsg carries the result of accepting or rejecting a suggestion.
+type suggestionResolvedMsg struct {
+	err error
+}
+
+// request builds the spell payload from the form.
+func (m createModel) request() client.CreateSpellRequest {
+	return client.CreateSpellRequest{
+		Text:    strings.TrimSpace(m.fields[fieldText]),
+		Tag:     m.fields[fieldTag],
+		Model:   m.fields[fieldModel],
+		Context: m.fields[fieldContext],
+	}
+}
+
+// shareDraft uploads the form as a shared draft (or pushes the latest text
+// to an existing one) and copies the share link.
+func (m createModel) shareDraft() (createModel, tea.Cmd) {
+	if strings.TrimSpace(m.fields[fieldText]) == "" {
+		m.statusMsg = "write something before sharing"
+		return m, nil
+	}
+	c, id, req := m.client, m.draftID, m.request()
+	m.statusMsg = "sharing draft..."
+	return m, func() tea.Msg {
+		var draft *domain.SpellDraft
+		var err error
+		if id == "" {
+			draft, err = c.ShareSpellDraft(context.Background(), req)
+		} else {
+			draft, err = c.UpdateSpellDraft(context.Background(), id, req)
+		}
+		if err != nil {
+			return draftSharedMsg{err: err}
+		}
+		copied := clipboard.WriteAll(draft.ShareURL) == nil
+		return draftSharedMsg{draft: draft, copied: copied}
+	}
+}
+
+// checkSuggestions fetches the shared draft to pick up new suggestions.
+func (m createModel) checkSuggestions() (createModel, tea.Cmd) {
+	if m.draftID == "" {
+		m.statusMsg = "share the draft first (ctrl+l)"
+		return m, nil
+	}
+	c, id := m.client, m.draftID
+	m.statusMsg = "checking suggestions..."
+	return m, func() tea.Msg {
+		draft, err := c.GetSpellDraft(context.Background(), id)
+		return draftLoadedMsg{draft: draft, err: err}
+	}
+}
+
+// updateReview handles keys while stepping through pending suggestions.
+func (m createModel) updateReview(msg tea.KeyMsg) (createModel, tea.Cmd) {
+	if len(m.suggestions) == 0 {
+		m.reviewing = false
+		return m, nil
+	}
+	s := m.suggestions[m.sugCursor]
+	switch msg.String() {
+	case "j", "down", "tab":
+		m.sugCursor = (m.sugCursor + 1) % len(m.suggestions)
+	case "k", "up", "shift+tab":
+		m.sugCursor = (m.sugCursor - 1 + len(m.suggestions)) % len(m.suggestions)
+	case "a", "enter":
+		f := suggestionField(s)
+		updated, ok := s.Apply(m.fields[f])
+		if !ok {
+			m.statusMsg = "that text has changed since the suggestion · x to dismiss"
+			return m, nil
+		}
+		m.fields[f] = updated
+		m.statusMsg = "accepted @" + s.AuthorLogin + "'s edit"
+		return m.resolveSuggestion(true)
+	case "x", "d":
+		m.statusMsg = "dismissed @" + s.AuthorLogin + "'s edit"
+		return m.resolveSuggestion(false)
+	case "esc":
+		m.reviewing = false
+	}
+	return m, nil
+}
+
+// resolveSuggestion drops the current suggestion from the queue and reports
+// the decision to the API.
+func (m createModel) resolveSuggestion(accept bool) (createModel, tea.Cmd) {
+	s := m.suggestions[m.sugCursor]
+	m.suggestions = append(m.suggestions[:m.sugCursor:m.sugCursor], m.suggestions[m.sugCursor+1:]...)
+	if m.sugCursor >= len(m.suggestions) {
+		m.sugCursor = 0
+	}
+	if len(m.suggestions) == 0 {
+		m.reviewing = false
+	}
+	c, draftID, id := m.client, m.draftID, s.ID.String()
+	return m, func() tea.Msg {
+		return suggestionResolvedMsg{err: c.ResolveDraftSuggestion(context.Background(), draftID, id, accept)}
+	}
+}
+
+// suggestionField maps a suggestion onto the form field it edits.
+func suggestionField(s domain.DraftSuggestion) createField {
+	if s.Field == "context" {
+		return fieldContext
+	}
+	return fieldText
+}
+
+// renderSuggestionInline shows value with the suggestion's span struck out
+// and the replacement beside it. If the span is gone the value is unchanged.
+func renderSuggestionInline(value string, s domain.DraftSuggestion) string {
+	i := strings.Index(value, s.Original)
+	if s.Original == "" || i < 0 {
+		return value
+	}
+	return value[:i] +
+		rejectStyle.Strikethrough(true).Render(s.Original) +
+		upvoteStyle.Render(s.Replacement) +
+		value[i+len(s.Original):]
+}
+
+// viewShareStatus renders the share link line and, while reviewing, the
+// current suggestion's details.
+func (m createModel) viewShareStatus() string {
+	if m.draftID == "" {
+		return ""
+	}
+	var b strings.Builder
+	link := m.shareURL
+	if link == "" {
+		link = "shared draft"
+	}
+	line := metaStyle.Render("shared · ") + accentStyle.Render(link)
+	if n := len(m.suggestions); n > 0 && !m.reviewing {
+		line += metaStyle.Render(fmt.Sprintf(" · %d suggestion%s (ctrl+r to review)", n, plural(n)))
+	}
+	b.WriteString(line + "\n")
+
+	if m.reviewing && len(m.suggestions) > 0 {
+		s := m.suggestions[m.sugCursor]
+		head := fmt.Sprintf("suggestion %d/%d from @%s", m.sugCursor+1, len(m.suggestions), s.AuthorLogin)
+		b.WriteString(selectedStyle.Render(head))
+		if s.Note != "" {
+			b.WriteString(dimStyle.Render(" · " + s.Note))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func newTestReviewModel() createModel {
+	m := newCreateModel(client.New("http://unused.invalid", ""))
+	m.fields[fieldText] = "always bisect before guessing"
+	m.fields[fieldContext] = "go services"
+	m, _ = m.Update(draftLoadedMsg{draft: &domain.SpellDraft{
+		ID:       uuid.New(),
+		ShareURL: "https://grimora.ai/d/abc",
+		Suggestions: []domain.DraftSuggestion{
+			{ID: uuid.New(), AuthorLogin: "bob", Field: "text", Original: "always", Replacement: "usually", Status: domain.SuggestionPending},
+			{ID: uuid.New(), AuthorLogin: "eve", Field: "context", Original: "go", Replacement: "Go", Status: domain.SuggestionPending},
+			{ID: uuid.New(), AuthorLogin: "old", Field: "text", Original: "x", Replacement: "y", Status: domain.SuggestionRejected},
+		},
+	}})
+	m.draftID = "d1"
+	return m
+}
+
+func TestCreateReviewShowsPendingSuggestions(t *testing.T) {
+	m := newTestReviewModel()
+	if !m.reviewing || len(m.suggestions) != 2 {
+		t.Fatalf("expected review of 2 pending suggestions, got reviewing=%v n=%d", m.reviewing, len(m.suggestions))
+	}
+	view := m.View()
+	for _, want := range []string{"suggestion 1/2 from @bob", "alwaysusually bisect", "https://grimora.ai/d/abc"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+}
+
+func TestCreateReviewAcceptAndDismiss(t *testing.T) {
+	m := newTestReviewModel()
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd == nil {
+		t.Error("expected accept to report the decision")
+	}
+	if m.fields[fieldText] != "usually bisect before guessing" {
+		t.Errorf("text = %q, want suggestion applied", m.fields[fieldText])
+	}
+	if len(m.suggestions) != 1 || m.suggestions[0].AuthorLogin != "eve" {
+		t.Fatalf("expected eve's suggestion next, got %+v", m.suggestions)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.fields[fieldContext] != "go services" {
+		t.Errorf("dismissed suggestion should not change context, got %q", m.fields[fieldContext])
+	}
+	if m.reviewing {
+		t.Error("expected review to end once every suggestion is handled")
+	}
+}
+
+func TestCreateReviewStaleSuggestion(t *testing.T) {
+	m := newTestReviewModel()
+	m.fields[fieldText] = "never guess"
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cmd != nil || len(m.suggestions) != 2 {
+		t.Error("a stale suggestion should stay queued and not be reported")
+	}
+	if !strings.Contains(m.statusMsg, "changed") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+}
+
+func TestCreateShareRequiresText(t *testing.T) {
+	m := newCreateModel(nil)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if cmd != nil || !strings.Contains(m.statusMsg, "before sharing") {
+		t.Errorf("expected share refused on empty form, status %q", m.statusMsg)
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if cmd != nil || !strings.Contains(m.statusMsg, "share the draft first") {
+		t.Errorf("expected suggestions check refused before sharing, status %q", m.statusMsg)
+	}
+}
+
+func TestAppEscInReviewStaysInCreate(t *testing.T) {
+	a := newTestApp()
+	a.view = viewCreate
+	a.create = newTestReviewModel()
+	m, _ := a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	a = m.(App)
+	if a.view != viewCreate || a.create.reviewing {
+		t.Errorf("expected esc to end review but stay in create, view=%v reviewing=%v", a.view, a.create.reviewing)
+	}
+}
+
+func TestCreateShareIDPersistsInDrafts(t *testing.T) {
+	store := newTestDraftStore(t)
+	m := newCreateModel(nil)
+	m.drafts = store
+	m.fields[fieldText] = "spell"
+	m, _ = m.Update(draftSharedMsg{draft: &domain.SpellDraft{ID: uuid.MustParse("6f1c1b8e-8d1f-4a43-9a4e-0d3c1f2b6a10")}})
+	if store.Get(createShareKey) != "6f1c1b8e-8d1f-4a43-9a4e-0d3c1f2b6a10" {
+		t.Fatalf("share key = %q", store.Get(createShareKey))
+	}
+
+	restored := newCreateModel(nil)
+	restored.drafts = store
+	restored = restored.restoreDraft()
+	if restored.draftID != m.draftID {
+		t.Errorf("restored draftID = %q, want %q", restored.draftID, m.draftID)
+	}
+}
@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// slashArgs says what a slash command takes after its name.
+type slashArgs int
+
+const (
+	slashNoArgs       slashArgs = iota // "/clear"
+	slashOptionalWord                  // "/help" or "/help dm"
+	slashWord                          // exactly one word: "/dm <user>"
+	slashText                          // any text, but not none: "/seek <question>"
+)
+
+// slashCommand is one command the Hall input understands.
+type slashCommand struct {
+	name  string // without the slash
+	usage string // argument placeholder, e.g. "<user>"
+	desc  string // one line, for the hint popup
+	help  string // what /help <name> says
+	args  slashArgs
+	// run handles the command in the client with its validated argument.
+	// Nil sends the command to the room for the server to handle.
+	run func(m hallModel, arg string) (hallModel, tea.Cmd)
+}
+
+// line returns the command as typed, e.g. "/dm <user>".
+func (c slashCommand) line() string {
+	if c.usage == "" {
+		return "/" + c.name
+	}
+	return "/" + c.name + " " + c.usage
+}
+
+// validate checks arg against what the command takes.
+func (c slashCommand) validate(arg string) error {
+	var ok bool
+	switch c.args {
+	case slashNoArgs:
+		ok = arg == ""
+	case slashOptionalWord:
+		ok = !strings.ContainsAny(arg, " \t\n")
+	case slashWord:
+		ok = arg != "" && !strings.ContainsAny(arg, " \t\n")
+	case slashText:
+		ok = arg != ""
+	}
+	if !ok {
+		return fmt.Errorf("usage: %s", c.line())
+	}
+	return nil
+}
+
+// slashRegistry holds the Hall's slash commands in the order the hint popup
+// lists them.
+type slashRegistry struct {
+	cmds   []slashCommand
+	byName map[string]int
+}
+
+// register adds c. Names must be unique.
+func (r *slashRegistry) register(c slashCommand) {
+	if _, dup := r.byName[c.name]; dup {
+		panic("tui: slash command registered twice: /" + c.name)
+	}
+	if r.byName == nil {
+		r.byName = make(map[string]int)
+	}
+	r.byName[c.name] = len(r.cmds)
+	r.cmds = append(r.cmds, c)
+}
+
+// lookup returns the command called name.
+func (r *slashRegistry) lookup(name string) (slashCommand, bool) {
+	i, ok := r.byName[name]
+	if !ok {
+		return slashCommand{}, false
+	}
+	return r.cmds[i], true
+}
+
+// hints returns the commands to suggest for input: those whose name starts
+// with what's typed, or once arguments are being typed, the command itself.
+func (r *slashRegistry) hints(input string) []slashCommand {
+	name, _, typingArgs := strings.Cut(strings.TrimPrefix(input, "/"), " ")
+	if typingArgs {
+		if c, ok := r.lookup(name); ok {
+			return []slashCommand{c}
+		}
+		return nil
+	}
+	var out []slashCommand
+	for _, c := range r.cmds {
+		if strings.HasPrefix(c.name, name) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// slashCommands is the Hall's command registry. Add a command by
+// registering it in init; the input handler and hints pick it up.
+var slashCommands slashRegistry
+
+func init() {
+	for _, c := range []slashCommand{
+		{name: "build", usage: "<title>", desc: "start a build", args: slashText,
+			help: "announces a new build as a card people can follow"},
+		{name: "b", usage: "<update>", desc: "update a build", args: slashText,
+			help: "posts a progress update on your current build"},
+		{name: "ship", usage: "<title>", desc: "ship something", args: slashText,
+			help: "celebrates something you shipped with a gold card"},
+		{name: "seek", usage: "<question>", desc: "ask for help", args: slashText,
+			help: "asks the room for help; others can watch for answers"},
+		{name: "dm", usage: "<user>", desc: "message someone directly", args: slashWord, run: slashDM,
+			help: "opens your DM thread with a magician, starting one if needed"},
+		{name: "peek", usage: "<user>", desc: "look at someone's card", args: slashWord, run: slashPeek,
+			help: "shows a magician's card: stats, recent spells and builds"},
+		{name: "room", usage: "<slug>", desc: "go to a room", args: slashWord, run: slashRoom,
+			help: "joins a topic room by its slug; /room the-hall goes back to the Hall"},
+		{name: "rooms", desc: "join, create or manage topic rooms", args: slashNoArgs, run: slashRooms,
+			help: "opens the room browser"},
+		{name: "city", desc: "join your city's chapter room", args: slashNoArgs, run: slashCity,
+			help: "joins the room for magicians in the city on your profile, opening it if nobody has yet"},
+		{name: "mute", usage: "[mentions|off]", desc: "quiet this room", args: slashOptionalWord, run: slashMute,
+			help: "hides this room's unread count and silences its bell; /mute mentions still rings for @mentions, /mute off undoes it"},
+		{name: "clear", desc: "clear the chat from your screen", args: slashNoArgs, run: slashClear,
+			help: "hides the messages on screen; nothing is deleted for anyone else"},
+		{name: "dnd", desc: "silence alerts, or turn them back on", args: slashNoArgs, run: slashDND,
+			help: "toggles do not disturb: no bell or flash, but mentions still collect in Notifications (Z does the same anywhere)"},
+		{name: "tour", desc: "practice in a private sandbox room", args: slashNoArgs, run: slashTour,
+			help: "opens the practice room, where nothing you type leaves your terminal"},
+		{name: "help", usage: "[command]", desc: "list commands or explain one", args: slashOptionalWord, run: slashHelp,
+			help: "lists the commands, or explains the one you name"},
+	} {
+		slashCommands.register(c)
+	}
+}
+
+// slashEscape starts a message that begins with a slash but isn't a
+// command, like "//usr/bin is broken"; one slash is dropped before sending.
+const slashEscape = "//"
+
+// unescapeSlash undoes slashEscape, reporting whether body used it.
+func unescapeSlash(body string) (string, bool) {
+	text, ok := strings.CutPrefix(body, slashEscape)
+	if !ok {
+		return body, false
+	}
+	return "/" + text, true
+}
+
+// parseSlash splits "/name arg text" into its name and trimmed argument.
+func parseSlash(body string) (name, arg string, ok bool) {
+	if !strings.HasPrefix(body, "/") {
+		return "", "", false
+	}
+	name, arg, _ = strings.Cut(body[1:], " ")
+	return name, strings.TrimSpace(arg), name != ""
+}
+
+// runSlash handles body if it is a slash command the client should act on.
+// It reports false for plain messages and for commands the server handles,
+// which are sent as they are.
+func (m hallModel) runSlash(body string) (hallModel, tea.Cmd, bool) {
+	name, arg, ok := parseSlash(body)
+	if !ok {
+		return m, nil, false
+	}
+	c, known := slashCommands.lookup(name)
+	if m.practicing() && (!known || c.run == nil) {
+		// The practice room scripts its own answers to these.
+		return m, nil, false
+	}
+	if !known {
+		m.status = "unknown command /" + name + " · /help lists them, or start with // to send it as text"
+		return m, nil, true
+	}
+	if err := c.validate(arg); err != nil {
+		m.status = err.Error()
+		return m, nil, true
+	}
+	if c.run == nil {
+		return m, nil, false
+	}
+	m.input, m.inputCursor = "", editCursor{}
+	m.status = ""
+	m, cmd := c.run(m, arg)
+	return m, cmd, true
+}
+
+// slashLogin strips the @ people habitually type before a login.
+func slashLogin(arg string) string {
+	return strings.TrimPrefix(arg, "@")
+}
+
+func slashDM(m hallModel, arg string) (hallModel, tea.Cmd) {
+	login := slashLogin(arg)
+	if login == m.myLogin {
+		m.status = "that's you"
+		return m, nil
+	}
+	return m, func() tea.Msg { return openDMMsg{login: login} }
+}
+
+func slashPeek(m hallModel, arg string) (hallModel, tea.Cmd) {
+	login := slashLogin(arg)
+	return m, func() tea.Msg { return showPeekMsg{login: login} }
+}
+
+func slashRoom(m hallModel, arg string) (hallModel, tea.Cmd) {
+	slug := strings.TrimPrefix(arg, "#")
+	room := domain.Room{Slug: slug, Name: slug}
+	for _, r := range m.roomList {
+		if r.Slug == slug {
+			room = r
+		}
+	}
+	return m, m.joinRoom(room)
+}
+
+func slashRooms(m hallModel, _ string) (hallModel, tea.Cmd) {
+	return m.openRooms()
+}
+
+func slashClear(m hallModel, _ string) (hallModel, tea.Cmd) {
+	// seenIDs stays, so polling only brings back what's new.
+	m.exitSelect()
+	m.messages = nil
+	m.scroll, m.newBelow = 0, 0
+	return m, nil
+}
+
+func slashTour(m hallModel, _ string) (hallModel, tea.Cmd) {
+	return m.enterTour(), nil
+}
+
+func slashHelp(m hallModel, arg string) (hallModel, tea.Cmd) {
+	if arg == "" {
+		names := make([]string, 0, len(slashCommands.cmds))
+		for _, c := range slashCommands.cmds {
+			names = append(names, "/"+c.name)
+		}
+		sort.Strings(names)
+		m.status = "commands: " + strings.Join(names, " ") + " · /help <command> for more"
+		return m, nil
+	}
+	c, ok := slashCommands.lookup(strings.TrimPrefix(arg, "/"))
+	if !ok {
+		m.status = "unknown command /" + strings.TrimPrefix(arg, "/")
+		return m, nil
+	}
+	m.status = c.line() + " · " + c.help
+	return m, nil
+}
+
+// countSlashHints returns the number of slash hint lines that will be rendered.
+func (m hallModel) countSlashHints() int {
+	return len(slashCommands.hints(m.input))
+}
+
+// renderSlashHints renders slash command hints above the input when typing "/".
+func (m hallModel) renderSlashHints() string {
+	var b strings.Builder
+	for _, c := range slashCommands.hints(m.input) {
+		b.WriteString("   " + accentStyle.Render(c.line()) + "  " + dimStyle.Render(c.desc) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// submitSlash types input into a signed-in Hall and presses enter.
+func submitSlash(t *testing.T, input string) (hallModel, tea.Cmd) {
+	t.Helper()
+	m := newTestHallModel()
+	m.myLogin = "me"
+	m.inputFocused = true
+	m.input = input
+	return m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestParseSlash(t *testing.T) {
+	tests := []struct {
+		body, name, arg string
+		ok              bool
+	}{
+		{"/dm octo", "dm", "octo", true},
+		{"/seek how do I   mock time? ", "seek", "how do I   mock time?", true},
+		{"/clear", "clear", "", true},
+		{"/", "", "", false},
+		{"hello /dm", "", "", false},
+	}
+	for _, tt := range tests {
+		name, arg, ok := parseSlash(tt.body)
+		if name != tt.name || arg != tt.arg || ok != tt.ok {
+			t.Errorf("parseSlash(%q) = %q, %q, %v; want %q, %q, %v", tt.body, name, arg, ok, tt.name, tt.arg, tt.ok)
+		}
+	}
+}
+
+func TestSlashValidate(t *testing.T) {
+	dm, _ := slashCommands.lookup("dm")
+	clearCmd, _ := slashCommands.lookup("clear")
+	seek, _ := slashCommands.lookup("seek")
+	help, _ := slashCommands.lookup("help")
+	tests := []struct {
+		cmd   slashCommand
+		arg   string
+		valid bool
+	}{
+		{dm, "octo", true},
+		{dm, "", false},
+		{dm, "octo mona", false},
+		{clearCmd, "", true},
+		{clearCmd, "all", false},
+		{seek, "why is CI red", true},
+		{seek, "", false},
+		{help, "", true},
+		{help, "dm", true},
+	}
+	for _, tt := range tests {
+		err := tt.cmd.validate(tt.arg)
+		if (err == nil) != tt.valid {
+			t.Errorf("/%s %q: err = %v, want valid %v", tt.cmd.name, tt.arg, err, tt.valid)
+		}
+		if err != nil && !strings.Contains(err.Error(), tt.cmd.line()) {
+			t.Errorf("/%s error %q should show its usage", tt.cmd.name, err)
+		}
+	}
+}
+
+func TestSlashRegistry(t *testing.T) {
+	var r slashRegistry
+	r.register(slashCommand{name: "rooms"})
+	r.register(slashCommand{name: "room", usage: "<slug>"})
+	r.register(slashCommand{name: "dm", usage: "<user>"})
+
+	if got := r.hints("/ro"); len(got) != 2 {
+		t.Errorf("hints(/ro) = %d commands, want 2", len(got))
+	}
+	if got := r.hints("/room lob"); len(got) != 1 || got[0].name != "room" {
+		t.Errorf("hints while typing args = %v, want just /room", got)
+	}
+	if got := r.hints("/nope x"); got != nil {
+		t.Errorf("hints for an unknown command = %v, want none", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a duplicate name should panic")
+		}
+	}()
+	r.register(slashCommand{name: "dm"})
+}
+
+func TestSlashClientCommands(t *testing.T) {
+	m, cmd := submitSlash(t, "/dm @octo")
+	if msg, ok := cmd().(openDMMsg); !ok || msg.login != "octo" || m.input != "" {
+		t.Errorf("/dm sent %#v, input %q; want openDMMsg for octo and a cleared input", cmd(), m.input)
+	}
+
+	_, cmd = submitSlash(t, "/peek octo")
+	if msg, ok := cmd().(showPeekMsg); !ok || msg.login != "octo" {
+		t.Errorf("/peek sent %#v, want showPeekMsg for octo", cmd())
+	}
+
+	m, cmd = submitSlash(t, "/dm me")
+	if cmd != nil || m.status != "that's you" {
+		t.Errorf("/dm yourself: status %q, cmd %v", m.status, cmd)
+	}
+
+	m, _ = submitSlash(t, "/help dm")
+	if !strings.Contains(m.status, "/dm <user>") {
+		t.Errorf("/help dm status = %q", m.status)
+	}
+}
+
+func TestSlashInvalidKeepsInput(t *testing.T) {
+	for _, input := range []string{"/dm", "/dmm octo", "/seek"} {
+		m, cmd := submitSlash(t, input)
+		if cmd != nil || m.input != input || m.status == "" {
+			t.Errorf("%q: input %q, status %q, cmd %v; want the input kept and a usage hint", input, m.input, m.status, cmd)
+		}
+	}
+}
+
+func TestSlashServerCommandsAreSent(t *testing.T) {
+	f := &clienttest.Fake{}
+	m := newHallModel(f)
+	m.myLogin = "me"
+	m.inputFocused = true
+	m.input = "/seek why is CI red"
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.input != "" {
+		t.Fatal("expected /seek to be sent")
+	}
+	cmd()
+	if f.Count("SendRoomMessage") != 1 {
+		t.Errorf("SendRoomMessage called %d times, want 1", f.Count("SendRoomMessage"))
+	}
+}
+
+func TestSlashEscapeSendsText(t *testing.T) {
+	f := &clienttest.Fake{}
+	m := newHallModel(f)
+	m.myLogin = "me"
+	m.inputFocused = true
+	m.input = "/usr/bin is broken"
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !strings.Contains(m.status, "//") {
+		t.Fatalf("status %q; want an unknown command pointing at //", m.status)
+	}
+
+	m.input = "//usr/bin is broken"
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.input != "" {
+		t.Fatal("expected the escaped message to be sent")
+	}
+	cmd()
+	if calls := f.Calls(); len(calls) != 1 || calls[0].Args[1] != "/usr/bin is broken" {
+		t.Errorf("calls = %v, want the text sent with one slash", calls)
+	}
+}
+
+func TestSlashClear(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "me"
+	msg := makeTestRoomMessage("alice", "loomari", "old news")
+	m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{msg}})
+
+	m.inputFocused = true
+	m.input = "/clear"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.messages) != 0 {
+		t.Fatalf("messages after /clear = %d, want 0", len(m.messages))
+	}
+
+	// The next poll doesn't bring back what was cleared.
+	m, _ = m.Update(hallMessagesMsg{messages: []domain.RoomMessage{msg, makeTestRoomMessage("bob", "cipher", "fresh")}})
+	if len(m.messages) != 1 || m.messages[0].Body != "fresh" {
+		t.Errorf("messages after poll = %+v, want just the new one", m.messages)
+	}
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// dupQueryWords is how many leading words of a new spell are searched for
+// when looking for duplicates.
+const dupQueryWords = 8
+
+// spellCheckedMsg carries the remote half of the pre-submit checks for the
+// form as it was when they started. Either result may be missing; the
+// checks are advice, so a failure just skips that one.
+type spellCheckedMsg struct {
+	fields     [numFields]string
+	dup        *domain.Spell
+	verdict    *domain.ForgeVerdict
+	previewErr error
+}
+
+// checkSpell looks for an existing spell with the same text and asks the
+// Grimoire for a dry-run verdict, unless the server has no previews.
+func (m createModel) checkSpell() tea.Cmd {
+	c, fields, req, preview := m.client, m.fields, m.request(), !m.noPreview
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := spellCheckedMsg{fields: fields}
+		if found, err := c.SearchSpells(ctx, dupQuery(req.Text)); err == nil {
+			msg.dup = findDuplicate(req.Text, found)
+		}
+		if preview {
+			msg.verdict, msg.previewErr = c.PreviewSpell(ctx, req)
+		}
+		return msg
+	}
+}
+
+// dupQuery is the search used to find possible duplicates of text.
+func dupQuery(text string) string {
+	words := strings.Fields(text)
+	return strings.Join(words[:min(len(words), dupQueryWords)], " ")
+}
+
+// findDuplicate returns the spell among candidates whose text matches text,
+// ignoring case and spacing.
+func findDuplicate(text string, candidates []domain.Spell) *domain.Spell {
+	want := normalizeSpellText(text)
+	for i := range candidates {
+		if normalizeSpellText(candidates[i].Text) == want {
+			return &candidates[i]
+		}
+	}
+	return nil
+}
+
+func normalizeSpellText(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// spellChecked turns the check results into inline warnings. With none the
+// spell goes straight out; otherwise the next ctrl+s sends it anyway.
+func (m createModel) spellChecked(msg spellCheckedMsg) (createModel, tea.Cmd) {
+	if msg.fields != m.fields {
+		return m, nil // edited while checking; the next ctrl+s checks again
+	}
+	m.checking = false
+	if client.IsNotFound(msg.previewErr) {
+		m.noPreview = true
+	}
+
+	m.fieldWarns = [numFields]string{}
+	var text []string
+	if msg.dup != nil {
+		dup := "looks like a duplicate of " + msg.dup.ID.String()[:8]
+		if msg.dup.Author != nil {
+			dup += " by @" + msg.dup.Author.Login
+		}
+		text = append(text, dup)
+	}
+	if v := msg.verdict; v != nil && v.Verdict == "REJECT" {
+		reason := "the Grimoire would likely reject this"
+		if v.Reason != "" {
+			reason += ": " + v.Reason
+		}
+		text = append(text, reason)
+	}
+	text = append(text, domain.LintSpellMarkdown(m.fields[fieldText])...)
+	m.fieldWarns[fieldText] = strings.Join(text, "; ")
+	m.checked = m.fields
+
+	if m.fieldWarns == ([numFields]string{}) {
+		return m.submit()
+	}
+	m.focus = fieldText
+	m.statusMsg = "check the warnings, then ctrl+s to submit anyway"
+	return m, nil
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+)
+
+// degradedRetryInterval is how often a degraded app retries signing in.
+var degradedRetryInterval = 15 * time.Second
+
+// startupAuthMsg delivers the sign-in check that was still running when the
+// app opened.
+type startupAuthMsg struct {
+	err error
+}
+
+// degradedRetryMsg asks a degraded app to try signing in again.
+type degradedRetryMsg struct{}
+
+// Banners for the ways a startup sign-in check can end.
+const (
+	signedOutBanner   = "signed out · quit and run grimora login"
+	unreachableBanner = "can't reach Grimora · retrying in the background"
+)
+
+// WithDegradedStart opens the app in degraded mode: reason is shown in the
+// header until signing in succeeds. pending, if non-nil, delivers the result
+// of the sign-in check still running from startup.
+func (a App) WithDegradedStart(reason string, pending <-chan error) App {
+	a.degraded = reason
+	a.startupPending = pending
+	return a
+}
+
+// waitStartupCmd waits for the startup sign-in check to finish.
+func waitStartupCmd(pending <-chan error) tea.Cmd {
+	if pending == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return startupAuthMsg{err: <-pending}
+	}
+}
+
+func degradedRetryCmd() tea.Cmd {
+	return tea.Tick(degradedRetryInterval, func(time.Time) tea.Msg {
+		return degradedRetryMsg{}
+	})
+}
+
+// finishStartup applies the background sign-in result. Success clears the
+// banner; the profile itself comes from loadMe, which Init already started.
+func (a App) finishStartup(msg startupAuthMsg) (App, tea.Cmd) {
+	a.startupPending = nil
+	switch {
+	case msg.err == nil:
+		a.degraded = ""
+		if a.me == nil {
+			return a, a.loadMe()
+		}
+	case client.IsUnauthorized(msg.err) && a.relogin != nil:
+		a.degraded = sessionExpiredBanner
+	case client.IsUnauthorized(msg.err):
+		a.degraded = signedOutBanner
+	default:
+		a.degraded = unreachableBanner
+		return a, degradedRetryCmd()
+	}
+	return a, nil
+}
+
+// degradedBanner renders the degraded-mode notice for the header.
+func (a App) degradedBanner() string {
+	switch a.degraded {
+	case signedOutBanner, unreachableBanner, sessionExpiredBanner, reloginFailedBanner:
+		return rejectStyle.Render("⚠ " + a.degraded)
+	}
+	return goldStyle.Render("⚠ " + a.degraded)
+}
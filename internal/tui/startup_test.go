@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestDegradedStartBanner(t *testing.T) {
+	pending := make(chan error, 1)
+	a := NewApp(nil, "dev").WithDegradedStart("signing in · the API is slow right now", pending)
+	model, _ := a.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	a = model.(App)
+	if !strings.Contains(a.View(), "the API is slow") {
+		t.Fatal("expected the degraded banner in the header")
+	}
+
+	pending <- nil
+	msg := waitStartupCmd(pending)()
+	model, cmd := a.Update(msg)
+	a = model.(App)
+	if a.degraded != "" {
+		t.Errorf("degraded = %q, want cleared after signing in", a.degraded)
+	}
+	if cmd == nil {
+		t.Error("expected the profile to load once signed in")
+	}
+}
+
+func TestFinishStartupFailures(t *testing.T) {
+	a := NewApp(nil, "dev").WithDegradedStart("signing in", make(chan error))
+
+	unauthorized := fmt.Errorf("client.GetMe: %w", &client.HTTPError{StatusCode: 401})
+	got, cmd := a.finishStartup(startupAuthMsg{err: unauthorized})
+	if got.degraded != signedOutBanner || cmd != nil {
+		t.Errorf("401: degraded = %q, cmd = %v; want signed out, no retry", got.degraded, cmd)
+	}
+
+	got, cmd = a.finishStartup(startupAuthMsg{err: errors.New("connection refused")})
+	if got.degraded != unreachableBanner || cmd == nil {
+		t.Errorf("network error: degraded = %q, cmd = %v; want a retry", got.degraded, cmd)
+	}
+	if got.startupPending != nil {
+		t.Error("expected the startup check to be marked done")
+	}
+}
+
+func TestMeLoadedClearsDegraded(t *testing.T) {
+	a := NewApp(nil, "dev").WithDegradedStart(unreachableBanner, nil)
+	model, _ := a.Update(meLoadedMsg{me: &domain.Magician{GitHubLogin: "ada"}})
+	if got := model.(App).degraded; got != "" {
+		t.Errorf("degraded = %q, want cleared", got)
+	}
+
+	// A failed retry keeps retrying.
+	_, cmd := a.Update(meLoadedMsg{err: errors.New("timeout")})
+	if cmd == nil {
+		t.Error("expected another retry while degraded")
+	}
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// forgeHistoryMsg carries the breakdown behind the forge analytics screen.
+type forgeHistoryMsg struct {
+	history *domain.ForgeHistory
+	err     error
+}
+
+// sparkBlocks are the eight levels of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as a row of block characters scaled between the
+// smallest and largest value. A flat series sits on the middle level.
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2
+		if hi > lo {
+			level = int(math.Round((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// hbar renders value as a horizontal bar width cells wide at full scale.
+func hbar(value, full, width int) string {
+	if full <= 0 || width <= 0 {
+		return ""
+	}
+	n := value * width / full
+	if value > 0 && n == 0 {
+		n = 1 // never hide a non-zero count
+	}
+	return strings.Repeat("█", n) + dimStyle.Render(strings.Repeat("░", width-n))
+}
+
+// openStats switches to the forge analytics screen and loads its data.
+func (m youModel) openStats() (youModel, tea.Cmd) {
+	m.statsOpen = true
+	if m.client == nil {
+		return m, nil
+	}
+	m.statsLoading = m.history == nil
+	return m, m.loadForgeHistory()
+}
+
+func (m youModel) loadForgeHistory() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		h, err := c.GetForgeHistory(context.Background())
+		return forgeHistoryMsg{history: h, err: err}
+	}
+}
+
+func (m youModel) handleKeyStats(msg tea.KeyMsg) (youModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "backspace", "f":
+		m.statsOpen = false
+	case "r":
+		if m.client != nil {
+			return m, m.loadForgeHistory()
+		}
+	}
+	return m, nil
+}
+
+// statsBarWidth is the width of the bars on the analytics screen.
+func (m youModel) statsBarWidth() int {
+	return min(max(m.width-36, 8), 30)
+}
+
+// viewStats renders the forge analytics screen: per-tag counts, potency
+// distribution, weekly acceptance rate and rank history.
+func (m youModel) viewStats() string {
+	var sb strings.Builder
+	sb.WriteString("\n " + sectionHeaderStyle.Render("── FORGE ANALYTICS ──") + "\n")
+
+	switch {
+	case m.statsLoading:
+		sb.WriteString("   " + dimStyle.Render("consulting the Forge records...") + "\n")
+		return sb.String()
+	case m.statsErr != nil && m.history == nil:
+		sb.WriteString("   " + dimStyle.Render("could not load forge history · r to retry") + "\n")
+		return sb.String()
+	case m.history == nil:
+		return sb.String()
+	}
+	h := m.history
+	barW := m.statsBarWidth()
+
+	// Per-tag forge counts
+	sb.WriteString("\n " + sectionHeaderStyle.Render("── BY TAG ──") + "\n")
+	if len(h.ByTag) == 0 {
+		sb.WriteString("   " + dimStyle.Render("nothing forged yet") + "\n")
+	}
+	most := 0
+	for _, t := range h.ByTag {
+		most = max(most, t.Forged)
+	}
+	for _, t := range h.ByTag {
+		line := fmt.Sprintf("   %s %s %s", TagStyle(t.Tag).Render(padRight(truncStr(t.Tag, 14), 14)), TagStyle(t.Tag).Render(hbar(t.Forged, most, barW)), normalStyle.Render(fmt.Sprintf("%3d", t.Forged)))
+		if t.Rejected > 0 {
+			line += " " + metaStyle.Render(fmt.Sprintf("(%d rejected)", t.Rejected))
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	// Potency distribution
+	if len(h.Potency) > 0 {
+		counts := make([]float64, len(h.Potency))
+		top := 0
+		for i, p := range h.Potency {
+			counts[i] = float64(p.Count)
+			top = max(top, p.Count)
+		}
+		sb.WriteString("\n " + sectionHeaderStyle.Render("── POTENCY ──") + "  " + goldStyle.Render(sparkline(counts)) + "\n")
+		for _, p := range h.Potency {
+			style := potencyStyle(p.Potency)
+			sb.WriteString(fmt.Sprintf("   %s %s %s\n", style.Render(fmt.Sprintf("%-14s", fmt.Sprintf("P%d", p.Potency))), style.Render(hbar(p.Count, top, barW)), normalStyle.Render(fmt.Sprintf("%3d", p.Count))))
+		}
+	}
+
+	// Acceptance rate over time
+	if len(h.Periods) > 0 {
+		rates := make([]float64, len(h.Periods))
+		for i, p := range h.Periods {
+			rates[i] = p.AcceptanceRate()
+		}
+		last := h.Periods[len(h.Periods)-1]
+		sb.WriteString("\n " + sectionHeaderStyle.Render("── ACCEPTANCE ──") + "\n")
+		sb.WriteString("   " + accentStyle.Render(sparkline(rates)) + "  " +
+			normalStyle.Render(fmt.Sprintf("%.0f%% this week", last.AcceptanceRate()*100)) +
+			metaStyle.Render(fmt.Sprintf(" · %d weeks since %s", len(h.Periods), h.Periods[0].Start.Format("Jan 2"))) + "\n")
+	}
+
+	// Rank history; lower ranks are better, so plot them inverted.
+	if len(h.RankHistory) > 0 {
+		inv := make([]float64, len(h.RankHistory))
+		for i, r := range h.RankHistory {
+			inv[i] = -float64(r.Rank)
+		}
+		first, last := h.RankHistory[0], h.RankHistory[len(h.RankHistory)-1]
+		trend := metaStyle.Render(fmt.Sprintf("#%d → ", first.Rank))
+		switch {
+		case last.Rank < first.Rank:
+			trend += upvoteStyle.Render(fmt.Sprintf("#%d ▲", last.Rank))
+		case last.Rank > first.Rank:
+			trend += rejectStyle.Render(fmt.Sprintf("#%d ▼", last.Rank))
+		default:
+			trend += normalStyle.Render(fmt.Sprintf("#%d", last.Rank))
+		}
+		if last.TotalRanked > 0 {
+			trend += metaStyle.Render(fmt.Sprintf(" of %d", last.TotalRanked))
+		}
+		sb.WriteString("\n " + sectionHeaderStyle.Render("── RANK ──") + "\n")
+		sb.WriteString("   " + goldStyle.Render(sparkline(inv)) + "  " + trend + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline(nil); got != "" {
+		t.Errorf("expected empty sparkline, got %q", got)
+	}
+	if got := sparkline([]float64{0, 7}); got != "▁█" {
+		t.Errorf("expected ▁█, got %q", got)
+	}
+	if got := sparkline([]float64{3, 3, 3}); got != "▅▅▅" {
+		t.Errorf("expected a flat series on the middle level, got %q", got)
+	}
+}
+
+func TestHbar(t *testing.T) {
+	if got := hbar(5, 10, 10); strings.Count(got, "█") != 5 {
+		t.Errorf("expected 5 filled cells, got %q", got)
+	}
+	if got := hbar(1, 100, 10); strings.Count(got, "█") != 1 {
+		t.Errorf("expected a non-zero value to show at least one cell, got %q", got)
+	}
+	if got := hbar(3, 0, 10); got != "" {
+		t.Errorf("expected no bar without a scale, got %q", got)
+	}
+}
+
+func TestYouStatsKeyOpensAndCloses(t *testing.T) {
+	m := newTestYouModel()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if !m.statsOpen {
+		t.Fatal("expected f to open the analytics screen")
+	}
+	if !strings.Contains(m.helpKeys(), "refresh") {
+		t.Errorf("expected stats help keys, got %q", m.helpKeys())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.statsOpen {
+		t.Error("expected esc to close the analytics screen")
+	}
+}
+
+func TestYouStatsView(t *testing.T) {
+	m := newTestYouModel()
+	m.statsOpen = true
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	m, _ = m.Update(forgeHistoryMsg{history: &domain.ForgeHistory{
+		ByTag:   []domain.TagForgeCount{{Tag: "debugging", Forged: 8, Rejected: 2}, {Tag: "testing", Forged: 3}},
+		Potency: []domain.PotencyCount{{Potency: 1, Count: 4}, {Potency: 2, Count: 5}, {Potency: 3, Count: 2}},
+		Periods: []domain.ForgePeriod{
+			{Start: start, Submitted: 4, Accepted: 2},
+			{Start: start.AddDate(0, 0, 7), Submitted: 4, Accepted: 3},
+		},
+		RankHistory: []domain.RankPoint{{Date: start, Rank: 40}, {Date: start.AddDate(0, 0, 7), Rank: 12, TotalRanked: 300}},
+	}})
+
+	view := m.View()
+	for _, want := range []string{"FORGE ANALYTICS", "debugging", "(2 rejected)", "P2", "75% this week", "2 weeks since Mar 2", "#40 → #12 ▲", "of 300"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in stats view, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "BUILD JOURNAL") {
+		t.Error("expected the analytics screen to replace the profile sections")
+	}
+}
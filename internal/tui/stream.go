@@ -0,0 +1,350 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// streamLoadedMsg carries the activity feed.
+type streamLoadedMsg struct {
+	events []domain.StreamEvent
+	err    error
+}
+
+// streamJumpMsg asks the App to open what a stream event is about.
+type streamJumpMsg struct {
+	e domain.StreamEvent
+}
+
+// streamSpellMsg carries the spell a stream event points at.
+type streamSpellMsg struct {
+	spell *domain.Spell
+	err   error
+}
+
+// streamWeaponMsg carries the weapon a stream event points at.
+type streamWeaponMsg struct {
+	weapon *domain.Weapon
+	err    error
+}
+
+// streamModel is the activity stream: spells forged, weapons added and
+// magicians joining, newest first. f narrows it to one kind of event, F to
+// magicians the caller follows, and enter opens the selected event. The
+// spell of the day sits above the events, and d opens it.
+type streamModel struct {
+	client        client.API
+	events        []domain.StreamEvent
+	daily         *domain.Spell // spell of the day; nil until loaded or when the server has none
+	dailySeed     string        // the seed daily was picked with
+	kind          string        // only events of this kind; "" shows every kind
+	followingOnly bool
+	cursor        int // index into visible()
+	loading       bool
+	err           string
+	status        string
+	width         int
+	height        int
+}
+
+func newStreamModel(c client.API) streamModel {
+	return streamModel{client: c}
+}
+
+func (m streamModel) Init() tea.Cmd {
+	return tea.Batch(m.load(), m.loadDaily())
+}
+
+func (m streamModel) load() tea.Cmd {
+	c, following := m.client, m.followingOnly
+	return func() tea.Msg {
+		events, err := c.GetStream(context.Background(), following, pageSize, 0)
+		return streamLoadedMsg{events: events, err: err}
+	}
+}
+
+func (m streamModel) Update(msg tea.Msg) (streamModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case streamLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = errReason(msg.err)
+			return m, nil
+		}
+		m.err = ""
+		m.events = msg.events
+		m.cursor = min(m.cursor, max(len(m.visible())-1, 0))
+
+	case dailySpellMsg:
+		// Without one, the stream simply has no card.
+		if msg.err == nil {
+			m.daily, m.dailySeed = msg.spell, msg.seed
+		}
+
+	case tea.KeyMsg:
+		return m.updateKeys(msg)
+	}
+	return m, nil
+}
+
+func (m streamModel) updateKeys(msg tea.KeyMsg) (streamModel, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.cursor < len(m.visible())-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "enter":
+		events := m.visible()
+		if m.cursor >= len(events) {
+			return m, nil
+		}
+		e := events[m.cursor]
+		m.status = ""
+		return m, func() tea.Msg { return streamJumpMsg{e: e} }
+	case "d":
+		if m.daily == nil {
+			return m, nil
+		}
+		spell := m.daily
+		m.status = ""
+		return m, func() tea.Msg { return streamSpellMsg{spell: spell} }
+	case "f":
+		m.kind = nextStreamKind(m.kind)
+		m.cursor = 0
+		m.status = ""
+	case "F":
+		// The API filters by follows, so the feed has to be fetched again.
+		m.followingOnly = !m.followingOnly
+		m.cursor = 0
+		m.status = ""
+		m.loading = true
+		return m, m.load()
+	case "r":
+		m.loading = true
+		return m, tea.Batch(m.load(), m.loadDaily())
+	}
+	return m, nil
+}
+
+// nextStreamKind returns the kind filter after kind: every kind, then each
+// of domain.StreamKinds in turn, then every kind again.
+func nextStreamKind(kind string) string {
+	if kind == "" {
+		return domain.StreamKinds[0]
+	}
+	for i, k := range domain.StreamKinds {
+		if k == kind && i+1 < len(domain.StreamKinds) {
+			return domain.StreamKinds[i+1]
+		}
+	}
+	return ""
+}
+
+// visible returns the loaded events that pass the kind filter.
+func (m streamModel) visible() []domain.StreamEvent {
+	if m.kind == "" {
+		return m.events
+	}
+	var out []domain.StreamEvent
+	for _, e := range m.events {
+		if e.Kind == m.kind {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (m streamModel) helpKeys() string {
+	return helpEntry("j/k", "nav") + "  " + helpEntry("enter", "open") + "  " + m.dailyHelp() + helpEntry("f", "kind") + "  " + helpEntry("F", "following") + "  " + helpEntry("r", "refresh") + "  " + helpEntry("esc", "back")
+}
+
+// filterLabel describes the active filters, e.g. "spell · following".
+func (m streamModel) filterLabel() string {
+	kind, who := "all", "everyone"
+	if m.kind != "" {
+		kind = m.kind
+	}
+	if m.followingOnly {
+		who = "following"
+	}
+	return kind + " · " + who
+}
+
+func (m streamModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(" " + presenceTitleStyle.Render("Stream") + "  " + dimStyle.Render(m.filterLabel()) + "\n")
+	sep := strings.Repeat("─", max(m.width-2, 4))
+	b.WriteString(" " + metaStyle.Render(sep) + "\n")
+	if m.daily != nil {
+		b.WriteString(m.renderDailyCard())
+	}
+
+	if m.loading && len(m.events) == 0 {
+		b.WriteString(" " + dimStyle.Render("loading...") + "\n")
+		return b.String()
+	}
+	if m.err != "" {
+		b.WriteString(" " + dimStyle.Render("error: "+m.err+" · r to retry") + "\n")
+		return b.String()
+	}
+	events := m.visible()
+	if len(events) == 0 {
+		empty := "nothing yet · new spells, weapons and magicians land here"
+		if m.kind != "" || m.followingOnly {
+			empty = "nothing matches · f and F change the filters"
+		}
+		b.WriteString("\n " + dimStyle.Render(empty) + "\n")
+		return b.String()
+	}
+
+	// Keep the cursor in view; title, separator and status take 3 lines.
+	rows := max(m.height-3-m.dailyCardLines(), 1)
+	start := max(0, m.cursor-rows+1)
+	end := min(len(events), start+rows)
+	for i := start; i < end; i++ {
+		b.WriteString(m.renderEvent(events[i], i == m.cursor) + "\n")
+	}
+
+	if m.status != "" {
+		b.WriteString(" " + dimStyle.Render(m.status) + "\n")
+	}
+	return b.String()
+}
+
+// renderEvent renders one stream line: icon, magician, what happened, when.
+func (m streamModel) renderEvent(e domain.StreamEvent, selected bool) string {
+	cursor := "  "
+	if selected {
+		cursor = accentStyle.Render("▸") + " "
+	}
+	who := ""
+	if e.MagicianLogin != "" {
+		who = GuildStyle(e.GuildID).Render("@"+e.MagicianLogin) + " "
+	}
+	text := truncStr(streamEventText(e), max(m.width-textWidth(who)-20, 20))
+	if selected {
+		text = selectedStyle.Render(text)
+	} else {
+		text = chatTextStyle.Render(text)
+	}
+	return fmt.Sprintf(" %s%s %s%s  %s", cursor, goldStyle.Render(streamIcon(e.Kind)), who, text, metaStyle.Render(formatTime(e.CreatedAt)))
+}
+
+// streamIcon returns the glyph for a stream event kind.
+func streamIcon(kind string) string {
+	switch kind {
+	case "spell":
+		return "✦"
+	case "weapon":
+		return "◆"
+	case "member":
+		return "+"
+	case "muse":
+		return "✎"
+	case "reject":
+		return "✗"
+	case "featured":
+		return "★"
+	case "convo":
+		return "✉"
+	case "reforge":
+		return "↻"
+	}
+	return "·"
+}
+
+// streamEventText describes a stream event in one line.
+func streamEventText(e domain.StreamEvent) string {
+	what := cleanTitle(e.Title)
+	switch e.Kind {
+	case "member":
+		what = "joined " + e.GuildID
+		if info, ok := domain.Guilds[e.GuildID]; ok {
+			what = "joined " + info.Name
+		}
+		if e.City != "" {
+			what += " from " + e.City
+		}
+	case "muse":
+		if e.Voice != "" {
+			what = cleanTitle(e.Voice)
+		}
+	case "reforge":
+		what = fmt.Sprintf("%s re-forged to P%d", what, e.Potency)
+	}
+	if what == "" && !e.KnownKind() {
+		// A kind from a newer server with no title to fall back on.
+		what = e.Kind
+	}
+	if e.Tag != "" {
+		what += " #" + e.Tag
+	}
+	return what
+}
+
+// openStream switches to the activity stream and refreshes it.
+func (a App) openStream() (App, tea.Cmd) {
+	a.view = viewStream
+	a.stream.loading = true
+	a.stream.status = ""
+	return a, a.stream.Init()
+}
+
+// jumpToStreamEvent opens the spell, weapon or magician e is about.
+func (a App) jumpToStreamEvent(e domain.StreamEvent) (App, tea.Cmd) {
+	c, id := a.client, e.ID.String()
+	switch e.Kind {
+	case "spell", "featured", "muse", "reject", "reforge":
+		return a, func() tea.Msg {
+			spell, err := c.GetSpell(context.Background(), id)
+			return streamSpellMsg{spell: spell, err: err}
+		}
+	case "weapon":
+		return a, func() tea.Msg {
+			weapon, err := c.GetWeapon(context.Background(), id)
+			return streamWeaponMsg{weapon: weapon, err: err}
+		}
+	}
+	if e.MagicianLogin == "" {
+		return a, nil
+	}
+	login := e.MagicianLogin
+	return a, func() tea.Msg { return showPeekMsg{login: login} }
+}
+
+// showStreamSpell opens the fetched spell in the Grimoire.
+func (a App) showStreamSpell(msg streamSpellMsg) (App, tea.Cmd) {
+	if msg.err != nil {
+		a.stream.status = errText("could not open spell", msg.err)
+		return a, nil
+	}
+	a.grimoire = a.grimoire.showSpell(*msg.spell)
+	a.view = viewGrimoire
+	return a, nil
+}
+
+// showStreamWeapon opens the fetched weapon in the Grimoire.
+func (a App) showStreamWeapon(msg streamWeaponMsg) (App, tea.Cmd) {
+	if msg.err != nil {
+		a.stream.status = errText("could not open weapon", msg.err)
+		return a, nil
+	}
+	a.grimoire = a.grimoire.showWeapon(*msg.weapon)
+	a.view = viewGrimoire
+	return a, nil
+}
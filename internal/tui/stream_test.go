@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestStreamKindFilterCycles(t *testing.T) {
+	f := &clienttest.Fake{Stream: []domain.StreamEvent{
+		{Kind: "spell", ID: uuid.New(), MagicianLogin: "ada", Title: "flaky test triage"},
+		{Kind: "member", MagicianLogin: "linus", GuildID: "loomari", City: "Helsinki"},
+		{Kind: "weapon", ID: uuid.New(), MagicianLogin: "grace", Title: "cobol-lsp"},
+	}}
+	a := openViewTestApp(t, f, "S", viewStream)
+	if got := len(a.stream.visible()); got != 3 {
+		t.Fatalf("visible = %d, want every event", got)
+	}
+
+	a = pressAppKey(t, a, "f")
+	if a.stream.kind != "spell" || len(a.stream.visible()) != 1 {
+		t.Errorf("after f: kind %q, %d visible; want spell, 1", a.stream.kind, len(a.stream.visible()))
+	}
+	if !strings.Contains(a.View(), "spell · everyone") {
+		t.Error("expected the header to name the kind filter")
+	}
+	for range domain.StreamKinds {
+		a = pressAppKey(t, a, "f")
+	}
+	if a.stream.kind != "" {
+		t.Errorf("kind = %q, want the filter to wrap back to every kind", a.stream.kind)
+	}
+}
+
+func TestStreamFollowingOnlyRefetches(t *testing.T) {
+	f := &clienttest.Fake{
+		Magicians: []domain.MagicianCard{
+			{Magician: domain.Magician{GitHubLogin: "ada"}, IsFollowing: true},
+			{Magician: domain.Magician{GitHubLogin: "linus"}},
+		},
+		Stream: []domain.StreamEvent{
+			{Kind: "spell", ID: uuid.New(), MagicianLogin: "ada", Title: "a"},
+			{Kind: "spell", ID: uuid.New(), MagicianLogin: "linus", Title: "b"},
+		},
+	}
+	a := openViewTestApp(t, f, "S", viewStream)
+	a = pressAppKey(t, a, "F")
+	if !a.stream.followingOnly {
+		t.Fatal("expected F to turn on following-only")
+	}
+	calls := f.Calls()
+	last := calls[len(calls)-1]
+	if last.Method != "GetStream" || last.Args[0] != true {
+		t.Errorf("last call = %v, want GetStream(following=true)", last)
+	}
+	if got := a.stream.visible(); len(got) != 1 || got[0].MagicianLogin != "ada" {
+		t.Errorf("visible = %v, want only ada's event", got)
+	}
+}
+
+func TestStreamEnterOpensSpell(t *testing.T) {
+	id := uuid.New()
+	f := &clienttest.Fake{
+		Spells: []domain.Spell{{ID: id, Text: "triage flaky tests"}},
+		Stream: []domain.StreamEvent{
+			{Kind: "member", MagicianLogin: "linus"},
+			{Kind: "spell", ID: id, MagicianLogin: "ada", Title: "triage flaky tests"},
+		},
+	}
+	a := openViewTestApp(t, f, "S", viewStream)
+	a = pressAppKey(t, a, "j")
+	a = pressAppKey(t, a, "enter")
+	if a.view != viewGrimoire || !a.grimoire.detail {
+		t.Fatalf("view = %v (detail %v), want the spell's detail in the Grimoire", a.view, a.grimoire.detail)
+	}
+	if a.grimoire.spells[a.grimoire.cursor].ID != id {
+		t.Error("expected the stream event's spell to be selected")
+	}
+}
+
+func TestStreamEnterOpensWeapon(t *testing.T) {
+	id := uuid.New()
+	f := &clienttest.Fake{
+		Weapons: []domain.Weapon{{ID: id, Name: "cobol-lsp"}},
+		Stream:  []domain.StreamEvent{{Kind: "weapon", ID: id, MagicianLogin: "grace", Title: "cobol-lsp"}},
+	}
+	a := openViewTestApp(t, f, "S", viewStream)
+	a = pressAppKey(t, a, "enter")
+	if a.view != viewGrimoire || a.grimoire.mode != grimoireModeWeapons || !a.grimoire.detail {
+		t.Fatalf("view = %v, want the weapon's detail in the Grimoire", a.view)
+	}
+	if a.grimoire.weapons[a.grimoire.cursor].ID != id {
+		t.Error("expected the stream event's weapon to be selected")
+	}
+}
+
+func TestStreamEnterPeeksNewMagician(t *testing.T) {
+	f := &clienttest.Fake{Stream: []domain.StreamEvent{{Kind: "member", MagicianLogin: "linus"}}}
+	a := openViewTestApp(t, f, "S", viewStream)
+	a = pressAppKey(t, a, "enter")
+	if !a.peekOpen {
+		t.Error("expected enter on a join to peek the magician")
+	}
+}
+
+func TestStreamMissingSpellKeepsStream(t *testing.T) {
+	f := &clienttest.Fake{Stream: []domain.StreamEvent{{Kind: "reject", ID: uuid.New(), MagicianLogin: "ada"}}}
+	a := openViewTestApp(t, f, "S", viewStream)
+	a = pressAppKey(t, a, "enter")
+	if a.view != viewStream {
+		t.Fatalf("view = %v, want to stay on the stream", a.view)
+	}
+	if !strings.Contains(a.stream.status, "could not open spell") {
+		t.Errorf("status = %q, want an error", a.stream.status)
+	}
+}
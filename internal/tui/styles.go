@@ -14,6 +14,9 @@ import (
 type shimmerTickMsg time.Time
 
 func shimmerTickCmd() tea.Cmd {
+	if !animated() {
+		return nil
+	}
 	return tea.Tick(80*time.Millisecond, func(t time.Time) tea.Msg {
 		return shimmerTickMsg(t)
 	})
@@ -22,13 +25,15 @@ func shimmerTickCmd() tea.Cmd {
 // renderShimmerLogo renders "G R I M O R A" as an endless flowing wave of green light.
 // Deep forest green (#1a3a24) -> bright emerald (#4ade80). No hue drift.
 // Letters are spaced apart (letter-spacing) and rendered without a background box.
+// Terminals without truecolor get a stepped ramp; see shimmerColor.
 func renderShimmerLogo(frame int) string {
 	const text = "GRIMORA"
 	n := len(text)
 
 	var out string
 
-	t := float64(frame)
+	t := float64(still(frame))
+	profile := lipgloss.ColorProfile()
 
 	for i := 0; i < n; i++ {
 		x := float64(i) / float64(n-1)
@@ -55,18 +60,9 @@ func renderShimmerLogo(frame int) string {
 			b = 0.05
 		}
 
-		// Continuous RGB interpolation: deep forest green -> bright emerald
-		// Deep:   (26, 58, 36)   #1a3a24
-		// Bright: (74, 222, 128) #4ade80
-		r := clampByte(26 + b*(74-26))
-		g := clampByte(58 + b*(222-58))
-		bl := clampByte(36 + b*(128-36))
-
-		color := fmt.Sprintf("#%02X%02X%02X", r, g, bl)
-
 		s := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color(color))
+			Foreground(shimmerColor(profile, b))
 		out += s.Render(string(text[i]))
 
 		// Letter spacing — two spaces between each letter
@@ -91,169 +87,178 @@ func clampByte(v float64) int {
 var (
 	// Base styles — grimora neutral palette
 	dimStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#8890a0"))
+			Foreground(paletteColor("#8890a0"))
 
 	selectedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#e4e4ec")).
+			Foreground(paletteColor("#e4e4ec")).
 			Bold(true)
 
 	normalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#c0c4d0"))
+			Foreground(paletteColor("#c0c4d0"))
 
 	metaStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#505868"))
+			Foreground(paletteColor("#505868"))
 
 	// Help bar
 	helpKeyStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#8890a0"))
+			Foreground(paletteColor("#8890a0"))
 
 	helpLabelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#505868"))
+			Foreground(paletteColor("#505868"))
 
 	// Search / accent
 	searchStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#4ade80")).
+			Foreground(paletteColor("#4ade80")).
 			Bold(true)
 
 	upvoteStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#4ade80"))
+			Foreground(paletteColor("#4ade80"))
 
 	// Accent / action styles
 	accentStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#34d474"))
+			Foreground(paletteColor("#34d474"))
 
 	// Grimoire voice styles
 	grimVoiceStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#c8a84c")).
+			Foreground(paletteColor("#c8a84c")).
 			Italic(true)
 
 	grimLabelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#d4a844")).
+			Foreground(paletteColor("#d4a844")).
 			Bold(true)
 
 	// Event type styles
 	forgeStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#f59e0b"))
+			Foreground(paletteColor("#f59e0b"))
 
 	castStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#22d3ee"))
+			Foreground(paletteColor("#22d3ee"))
 
 	rejectStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#b45555"))
+			Foreground(paletteColor("#b45555"))
 
 	goldStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#d4a844"))
+			Foreground(paletteColor("#d4a844"))
 
 	// Surface colors
-	borderColor  = lipgloss.Color("#1e1e2a")
-	surfaceColor = lipgloss.Color("#111118")
+	borderColor  = paletteColor("#1e1e2a")
+	surfaceColor = paletteColor("#111118")
+
+	// Markdown code, inline and fenced, on a subtle background.
+	codeStyle = lipgloss.NewStyle().
+			Foreground(paletteColor("#c0c4d0")).
+			Background(paletteColor("#1e1e2a"))
 
 	// Selected row background (matches mockup .grim-row.selected)
-	selectedRowBg = lipgloss.NewStyle().Background(lipgloss.Color("#1e1e2a"))
+	selectedRowBg = lipgloss.NewStyle().Background(paletteColor("#1e1e2a"))
 
 	// Tag colors — from mockup CSS
-	tagColors = map[string]lipgloss.Color{
-		"debugging":     lipgloss.Color("#e06060"),
-		"architecture":  lipgloss.Color("#b080d0"),
-		"performance":   lipgloss.Color("#f0944a"),
-		"code-review":   lipgloss.Color("#d4a844"),
-		"testing":       lipgloss.Color("#60a0e0"),
-		"security":      lipgloss.Color("#d05050"),
-		"database":      lipgloss.Color("#3ecce4"),
-		"ai-prompts":    lipgloss.Color("#c084e0"),
-		"observability": lipgloss.Color("#8890a0"),
+	tagColors = map[string]lipgloss.TerminalColor{
+		"debugging":     paletteColor("#e06060"),
+		"architecture":  paletteColor("#b080d0"),
+		"performance":   paletteColor("#f0944a"),
+		"code-review":   paletteColor("#d4a844"),
+		"testing":       paletteColor("#60a0e0"),
+		"security":      paletteColor("#d05050"),
+		"database":      paletteColor("#3ecce4"),
+		"ai-prompts":    paletteColor("#c084e0"),
+		"observability": paletteColor("#8890a0"),
 		// Carry forward tags not in mockup with distinct colors
-		"refactoring":   lipgloss.Color("#b080d0"),
-		"devops":        lipgloss.Color("#f0944a"),
-		"data":          lipgloss.Color("#3ecce4"),
-		"frontend":      lipgloss.Color("#d4a844"),
-		"backend":       lipgloss.Color("#60a0e0"),
-		"image-gen":     lipgloss.Color("#c084e0"),
-		"writing":       lipgloss.Color("#f0944a"),
-		"business":      lipgloss.Color("#b080d0"),
-		"productivity":  lipgloss.Color("#8890a0"),
-		"analysis":      lipgloss.Color("#3ecce4"),
-		"system-prompt": lipgloss.Color("#d4a844"),
-		"education":     lipgloss.Color("#c084e0"),
-		"coding":        lipgloss.Color("#e4e4ec"),
-		"conversation":  lipgloss.Color("#8890a0"),
-		"general":       lipgloss.Color("#606878"),
+		"refactoring":   paletteColor("#b080d0"),
+		"devops":        paletteColor("#f0944a"),
+		"data":          paletteColor("#3ecce4"),
+		"frontend":      paletteColor("#d4a844"),
+		"backend":       paletteColor("#60a0e0"),
+		"image-gen":     paletteColor("#c084e0"),
+		"writing":       paletteColor("#f0944a"),
+		"business":      paletteColor("#b080d0"),
+		"productivity":  paletteColor("#8890a0"),
+		"analysis":      paletteColor("#3ecce4"),
+		"system-prompt": paletteColor("#d4a844"),
+		"education":     paletteColor("#c084e0"),
+		"coding":        paletteColor("#e4e4ec"),
+		"conversation":  paletteColor("#8890a0"),
+		"general":       paletteColor("#606878"),
 	}
 
 	sectionHeaderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#606878"))
+				Foreground(paletteColor("#606878"))
 
 	commentTextStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#606878"))
+				Foreground(paletteColor("#606878"))
 
 	commentTimeStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#505868"))
+				Foreground(paletteColor("#505868"))
 
 	inputPromptStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#34d474")).
+				Foreground(paletteColor("#34d474")).
 				Bold(true)
 
 	inputPlaceholderStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#343c4a"))
+				Foreground(paletteColor("#343c4a"))
 
 	// Mention styles (Hall)
 	mentionStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#4ade80")).
+			Foreground(paletteColor("#4ade80")).
 			Bold(true)
 
 	mentionSelfStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#86efac")).
+				Foreground(paletteColor("#86efac")).
 				Bold(true)
 
 	// Chat styles (Hall)
 	chatSelfNameStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#e4e4ec"))
+				Foreground(paletteColor("#e4e4ec"))
 
 	chatSelfTextStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#c0c4d0"))
+				Foreground(paletteColor("#c0c4d0"))
 
 	// Composing text — brighter than sent messages so the draft stands out.
 	chatComposingStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#e4e4ec"))
+				Foreground(paletteColor("#e4e4ec"))
 
 	chatTextStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#8890a0"))
+			Foreground(paletteColor("#8890a0"))
 
 	chatSepStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#404858"))
+			Foreground(paletteColor("#404858"))
 
 	chatSysStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#404858"))
+			Foreground(paletteColor("#404858"))
+
+	// Bot badge — muted blue so automated posts read as distinct from people.
+	botBadgeStyle = lipgloss.NewStyle().
+			Foreground(paletteColor("#7aa2f7"))
 
 	// Join announcement styles
 	joinLabelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffd700")).
+			Foreground(paletteColor("#ffd700")).
 			Bold(true)
 
 	joinDetailStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#606878")).
+			Foreground(paletteColor("#606878")).
 			Italic(true)
 
 	// Leave announcement style — dimmer mirror of join
 	leaveLabelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#606878")).
+			Foreground(paletteColor("#606878")).
 			Italic(true)
 
 	presenceTitleStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#8890a0")).
+				Foreground(paletteColor("#8890a0")).
 				Bold(true)
 
 	presenceDotStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#34d474"))
+				Foreground(paletteColor("#34d474"))
 
 	// Guild colors — from mockup .g-* classes
-	guildColors = map[string]lipgloss.Color{
-		"loomari":  lipgloss.Color("#43e88c"),
-		"ashborne": lipgloss.Color("#f0944a"),
-		"amarok":   lipgloss.Color("#b8ccdf"),
-		"nyx":      lipgloss.Color("#c084e0"),
-		"cipher":   lipgloss.Color("#34d474"),
-		"fathom":   lipgloss.Color("#3ecce4"),
+	guildColors = map[string]lipgloss.TerminalColor{
+		"loomari":  paletteColor("#43e88c"),
+		"ashborne": paletteColor("#f0944a"),
+		"amarok":   paletteColor("#b8ccdf"),
+		"nyx":      paletteColor("#c084e0"),
+		"cipher":   paletteColor("#34d474"),
+		"fathom":   paletteColor("#3ecce4"),
 	}
 )
 
@@ -267,8 +272,12 @@ var guildEmblems = map[string]string{
 	"fathom":   "\U0001f419", // octopus
 }
 
-// GuildEmblem returns the emoji emblem for a guild ID.
+// GuildEmblem returns the emoji emblem for a guild ID, or its ASCII stand-in
+// on terminals that can't draw emoji.
 func GuildEmblem(guildID string) string {
+	if !emojiEmblems {
+		return asciiEmblems[guildID]
+	}
 	if e, ok := guildEmblems[guildID]; ok {
 		return e
 	}
@@ -280,7 +289,7 @@ func TagStyle(tag string) lipgloss.Style {
 	if c, ok := tagColors[tag]; ok {
 		return lipgloss.NewStyle().Foreground(c).Bold(true)
 	}
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("#606878")).Bold(true)
+	return lipgloss.NewStyle().Foreground(paletteColor("#606878")).Bold(true)
 }
 
 // GuildStyle returns a bold style colored for the given guild ID.
@@ -288,7 +297,7 @@ func GuildStyle(guildID string) lipgloss.Style {
 	if c, ok := guildColors[guildID]; ok {
 		return lipgloss.NewStyle().Foreground(c).Bold(true)
 	}
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("#8890a0")).Bold(true)
+	return lipgloss.NewStyle().Foreground(paletteColor("#8890a0")).Bold(true)
 }
 
 // GuildBadge returns a short colored badge string for a guild, e.g. "[NYX]".
@@ -304,13 +313,13 @@ func GuildBadge(guildID string) string {
 func rankStyle(rank int) lipgloss.Style {
 	switch rank {
 	case 1:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#60a5fa")) // blue
+		return lipgloss.NewStyle().Foreground(paletteColor("#60a5fa")) // blue
 	case 2:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#f87171")) // red
+		return lipgloss.NewStyle().Foreground(paletteColor("#f87171")) // red
 	case 3:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#facc15")) // yellow
+		return lipgloss.NewStyle().Foreground(paletteColor("#facc15")) // yellow
 	default:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#8891a5")) // soft slate
+		return lipgloss.NewStyle().Foreground(paletteColor("#8891a5")) // soft slate
 	}
 }
 
@@ -318,11 +327,11 @@ func rankStyle(rank int) lipgloss.Style {
 func potencyStyle(potency int) lipgloss.Style {
 	switch {
 	case potency >= 3:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#fff")).Bold(true)
+		return lipgloss.NewStyle().Foreground(paletteColor("#fff")).Bold(true)
 	case potency == 2:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#fbbf24")).Bold(true)
+		return lipgloss.NewStyle().Foreground(paletteColor("#fbbf24")).Bold(true)
 	default:
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("#92711a"))
+		return lipgloss.NewStyle().Foreground(paletteColor("#92711a"))
 	}
 }
 
@@ -330,6 +339,7 @@ func potencyStyle(potency int) lipgloss.Style {
 // pos: "top" or "bottom". label: optional header text (top only).
 // baseColor: hex color. frame: 0=static, 1-20=animating. width: terminal width.
 func cardBorder(pos, label, baseColor string, frame, width int) string {
+	frame = still(frame)
 	w := width - 4
 	if w < 10 {
 		w = 10
@@ -432,6 +442,7 @@ func renderAnimatedName(name string, frame int) string {
 		name = "you"
 	}
 	n := len(name)
+	frame = still(frame)
 
 	type rgb = [3]int
 	bright := rgb{74, 222, 128}
@@ -474,7 +485,12 @@ type helpItem struct {
 	url   string
 }
 
+// whatsNewLabel is the help item that opens the release notes overlay
+// instead of a URL.
+const whatsNewLabel = "What's new"
+
 var helpItems = []helpItem{
+	{whatsNewLabel, "release notes for this version", ""},
 	{"Terms of Service", "grimora.ai/terms", "https://grimora.ai/terms"},
 	{"Privacy Policy", "grimora.ai/privacy", "https://grimora.ai/privacy"},
 	{"FAQ", "grimora.ai/faq", "https://grimora.ai/faq"},
@@ -484,7 +500,7 @@ var helpItems = []helpItem{
 // helpView renders the interactive help overlay with a cursor.
 func helpView(cursor int) string {
 	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#4ade80")).
+		Foreground(paletteColor("#4ade80")).
 		Bold(true).
 		Render("G R I M O R A")
 
@@ -494,13 +510,13 @@ func helpView(cursor int) string {
 		Render(`"The Grimoire sees all. Here is what it permits."`)
 
 	attrib := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#D4A017")).
+		Foreground(paletteColor("#D4A017")).
 		Render("— The Grimoire")
 
 	cmdStyle := lipgloss.NewStyle().Bold(true)
 	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	sectionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Bold(true)
-	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#4ade80"))
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(paletteColor("#4ade80"))
 	linkDescStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Italic(true)
 
 	commands := []struct{ cmd, desc string }{
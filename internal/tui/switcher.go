@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// maxSwitcherRows caps how many conversations the quick switcher lists.
+const maxSwitcherRows = 12
+
+// switcherLoadedMsg carries fresh rooms and threads for the quick switcher.
+type switcherLoadedMsg struct {
+	rooms   []domain.Room
+	threads []domain.Thread
+	err     error
+}
+
+// switchEntry is one conversation in the quick switcher: a room or a DM
+// thread.
+type switchEntry struct {
+	label  string // "#go-tips" or "@ada"
+	detail string // room name or last message
+	unread int
+	alert  string // a room's alert level; see state.RoomAlerts
+	last   time.Time
+	room   *domain.Room
+	thread *domain.Thread
+}
+
+// switcherModel is the ctrl+t quick switcher overlay.
+type switcherModel struct {
+	query   string
+	entries []switchEntry // every conversation, most recently active first
+	matches []switchEntry // entries matching query, best first
+	cursor  int
+	err     string
+	width   int
+	alerts  map[string]string // room alert levels, for rooms that load later
+	blocked map[string]bool   // logins whose last messages aren't previewed
+}
+
+// newSwitcher returns a switcher seeded with what the Hall and Threads
+// already know, so it opens instantly; loadSwitcher refreshes it.
+func newSwitcher(rooms []domain.Room, threads []domain.Thread, alerts map[string]string, blocked map[string]bool, width int) switcherModel {
+	s := switcherModel{width: width, alerts: alerts, blocked: blocked}
+	s.entries = switchEntries(rooms, threads, alerts, blocked)
+	s.filter()
+	return s
+}
+
+func loadSwitcher(c client.API) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		rooms, err := c.ListRooms(ctx)
+		if err != nil {
+			return switcherLoadedMsg{err: err}
+		}
+		threads, err := c.ListThreads(ctx)
+		return switcherLoadedMsg{rooms: rooms, threads: threads, err: err}
+	}
+}
+
+// switchEntries merges rooms and threads into one list: unread
+// conversations first, then by last activity. The main Hall is always
+// there even before the room list loads. Rooms with an alert level in
+// alerts keep their unread count to themselves, and threads with someone
+// in blocked don't preview their last message.
+func switchEntries(rooms []domain.Room, threads []domain.Thread, alerts map[string]string, blocked map[string]bool) []switchEntry {
+	var out []switchEntry
+	hasHall := false
+	for i := range rooms {
+		r := &rooms[i]
+		hasHall = hasHall || r.Slug == hallSlug
+		e := switchEntry{label: "#" + r.Slug, detail: r.Name, unread: r.Unread, alert: alerts[r.Slug], last: r.LastMessageAt, room: r}
+		if e.alert != "" {
+			e.unread = 0
+		}
+		out = append(out, e)
+	}
+	if !hasHall {
+		out = append(out, switchEntry{label: "#" + hallSlug, detail: "The Hall", alert: alerts[hallSlug], room: &domain.Room{Slug: hallSlug, Name: "The Hall"}})
+	}
+	for i := range threads {
+		t := &threads[i]
+		last := t.LastMessageAt
+		if last.IsZero() {
+			last = t.CreatedAt
+		}
+		out = append(out, switchEntry{label: "@" + strings.Join(t.Logins(), ", @"), detail: threadPreview(*t, blocked), unread: t.Unread, last: last, thread: t})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if (out[i].unread > 0) != (out[j].unread > 0) {
+			return out[i].unread > 0
+		}
+		return out[i].last.After(out[j].last)
+	})
+	return out
+}
+
+// filter recomputes matches for the current query. Ties keep recency order.
+func (s *switcherModel) filter() {
+	type scored struct {
+		e     switchEntry
+		score int
+	}
+	var hits []scored
+	for _, e := range s.entries {
+		if score, ok := fuzzyScore(e.label, s.query); ok {
+			hits = append(hits, scored{e, score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	s.matches = nil
+	for _, h := range hits {
+		s.matches = append(s.matches, h.e)
+	}
+	s.cursor = 0
+}
+
+// fuzzyScore reports whether the runes of query appear in s in order,
+// ignoring case, and how good the match is: runs of consecutive runes and
+// runes at the start of a word score higher. An empty query matches
+// everything equally.
+func fuzzyScore(s, query string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, true
+	}
+	score, qi, prevMatch := 0, 0, -2
+	prev := ' '
+	for i, r := range []rune(strings.ToLower(s)) {
+		if qi < len(q) && r == q[qi] {
+			score++
+			if i == prevMatch+1 {
+				score += 2
+			}
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += 3
+			}
+			prevMatch = i
+			qi++
+		}
+		prev = r
+	}
+	return score, qi == len(q)
+}
+
+// selected returns the highlighted entry.
+func (s switcherModel) selected() (switchEntry, bool) {
+	if s.cursor < 0 || s.cursor >= len(s.matches) {
+		return switchEntry{}, false
+	}
+	return s.matches[s.cursor], true
+}
+
+// Update handles keys typed into the switcher. It reports the chosen
+// entry, if any, and whether the switcher should close.
+func (s switcherModel) Update(msg tea.KeyMsg) (switcherModel, *switchEntry, bool) {
+	switch msg.String() {
+	case "esc", "ctrl+t", "ctrl+c":
+		return s, nil, true
+	case "enter":
+		e, ok := s.selected()
+		if !ok {
+			return s, nil, false
+		}
+		return s, &e, true
+	case "down", "ctrl+n", "tab":
+		if s.cursor < min(len(s.matches), maxSwitcherRows)-1 {
+			s.cursor++
+		}
+	case "up", "ctrl+p", "shift+tab":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	default:
+		q := editRune(s.query, msg.String())
+		if q != s.query {
+			s.query = q
+			s.filter()
+		}
+	}
+	return s, nil, false
+}
+
+// loaded replaces the seeded entries with a fresh list, keeping the query.
+func (s switcherModel) loaded(msg switcherLoadedMsg) switcherModel {
+	if msg.err != nil {
+		s.err = errText("could not refresh", msg.err)
+		return s
+	}
+	s.entries = switchEntries(msg.rooms, msg.threads, s.alerts, s.blocked)
+	s.filter()
+	return s
+}
+
+func (s switcherModel) View() string {
+	var b strings.Builder
+	b.WriteString(" " + presenceTitleStyle.Render("Switch to") + "\n")
+	b.WriteString(" " + accentStyle.Render("›") + " " + s.query + accentStyle.Render("▏") + "\n")
+	sep := strings.Repeat("─", max(s.width-2, 4))
+	b.WriteString(" " + metaStyle.Render(sep) + "\n")
+
+	if len(s.matches) == 0 {
+		b.WriteString(" " + dimStyle.Render("no conversations match") + "\n")
+	}
+	for i, e := range s.matches {
+		if i == maxSwitcherRows {
+			break
+		}
+		cursor := "  "
+		label := dimStyle.Render(e.label)
+		if i == s.cursor {
+			cursor = accentStyle.Render("▸") + " "
+			label = selectedStyle.Render(e.label)
+		} else if e.thread != nil {
+			label = GuildStyle(e.thread.OtherGuildID).Render(e.label)
+		}
+		line := " " + cursor + label
+		if e.unread > 0 {
+			line += " " + goldStyle.Render(fmt.Sprintf("%d new", e.unread))
+		}
+		if badge := roomAlertBadge(e.alert); badge != "" {
+			line += " " + badge
+		}
+		if e.detail != "" {
+			line += "  " + dimStyle.Render(truncStr(e.detail, max(s.width-len(e.label)-24, 10)))
+		}
+		if !e.last.IsZero() {
+			line += "  " + metaStyle.Render(formatTime(e.last))
+		}
+		b.WriteString(line + "\n")
+	}
+	if s.err != "" {
+		b.WriteString(" " + dimStyle.Render(s.err) + "\n")
+	}
+	return b.String()
+}
+
+// openSwitcher shows the quick switcher over the current view.
+func (a App) openSwitcher() (App, tea.Cmd) {
+	a.switcherOpen = true
+	a.switcher = newSwitcher(a.hall.roomList, a.threads.threads, a.hall.roomAlerts, a.blocked, a.width)
+	return a, loadSwitcher(a.client)
+}
+
+// switchTo jumps straight into the conversation e names.
+func (a App) switchTo(e switchEntry) (App, tea.Cmd) {
+	if e.thread != nil {
+		a.view = viewThreads
+		var cmd tea.Cmd
+		a.threads, cmd = a.threads.openThread(*e.thread)
+		return a, cmd
+	}
+	a.view = viewHall
+	a.hall.inputFocused = true
+	return a, a.hall.joinRoom(*e.room)
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("#go-tips", "gtp"); !ok {
+		t.Error("expected an in-order subsequence to match")
+	}
+	if _, ok := fuzzyScore("#go-tips", "pg"); ok {
+		t.Error("expected out-of-order runes not to match")
+	}
+	if _, ok := fuzzyScore("@Ada", "ADA"); !ok {
+		t.Error("expected matching to ignore case")
+	}
+	prefix, _ := fuzzyScore("@ada", "ad")
+	scattered, _ := fuzzyScore("@xaxd", "ad")
+	if prefix <= scattered {
+		t.Errorf("word-start run scored %d, scattered %d; want the run higher", prefix, scattered)
+	}
+}
+
+func TestSwitchEntriesOrder(t *testing.T) {
+	now := time.Now()
+	rooms := []domain.Room{
+		{Slug: "go-tips", LastMessageAt: now.Add(-time.Hour)},
+		{Slug: "rustaceans", LastMessageAt: now.Add(-time.Minute)},
+	}
+	threads := []domain.Thread{
+		{ID: uuid.New(), OtherLogin: "ada", LastMessageAt: now.Add(-2 * time.Hour), Unread: 2},
+		{ID: uuid.New(), OtherLogin: "linus", CreatedAt: now.Add(-30 * time.Minute)},
+	}
+	got := switchEntries(rooms, threads, nil, nil)
+	var labels []string
+	for _, e := range got {
+		labels = append(labels, e.label)
+	}
+	// Unread first, then most recent; the main Hall is added with no activity.
+	want := []string{"@ada", "#rustaceans", "@linus", "#go-tips", "#" + hallSlug}
+	if len(labels) != len(want) {
+		t.Fatalf("labels = %v, want %v", labels, want)
+	}
+	for i := range want {
+		if labels[i] != want[i] {
+			t.Fatalf("labels = %v, want %v", labels, want)
+		}
+	}
+}
+
+func TestSwitcherFilterAndJump(t *testing.T) {
+	thread := domain.Thread{ID: uuid.New(), OtherLogin: "ada", OtherGuildID: "nyx"}
+	a := NewApp(nil, "dev")
+	a.width, a.height = 100, 30
+	a.hall.roomList = []domain.Room{{Slug: hallSlug}, {Slug: "go-tips"}}
+	a.threads.threads = []domain.Thread{thread}
+	a.hall.inputFocused = true
+	a.hall.input = "half a thought"
+
+	// ctrl+t opens even while typing in the Hall.
+	a, _ = a.openSwitcher()
+	if len(a.switcher.matches) != 3 {
+		t.Fatalf("matches = %d, want all 3 conversations", len(a.switcher.matches))
+	}
+	for _, r := range "ada" {
+		model, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		a = model.(App)
+	}
+	if len(a.switcher.matches) != 1 || a.switcher.matches[0].label != "@ada" {
+		t.Fatalf("matches = %+v, want just @ada", a.switcher.matches)
+	}
+
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	a = model.(App)
+	if a.switcherOpen {
+		t.Error("expected enter to close the switcher")
+	}
+	if a.view != viewThreads || a.threads.openThreadID != thread.ID.String() {
+		t.Errorf("view = %v, thread = %q; want the DM with ada", a.view, a.threads.openThreadID)
+	}
+}
+
+func TestSwitcherCtrlTWhileEditing(t *testing.T) {
+	a := NewApp(nil, "dev")
+	a.hall.inputFocused = true
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	a = model.(App)
+	if !a.switcherOpen || cmd == nil {
+		t.Fatal("expected ctrl+t to open the switcher and refresh it")
+	}
+	if a.hall.input != "" {
+		t.Errorf("input = %q, want ctrl+t not typed", a.hall.input)
+	}
+	model, _ = a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(App).switcherOpen {
+		t.Error("expected esc to close the switcher")
+	}
+}
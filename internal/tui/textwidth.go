@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Text in the TUI is measured in terminal cells, not runes or bytes. CJK
+// ideographs and most emoji take two cells, combining marks and joiners take
+// none, and a flag or a ZWJ emoji sequence is a single grapheme cluster however
+// many runes it spans. These helpers use the same width tables as
+// lipgloss.Width, so a prefix measured with one lines up with a body wrapped
+// or padded with the other.
+
+// textWidth returns how many cells s occupies. ANSI escapes are ignored.
+func textWidth(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// truncStr truncates s to at most maxLen cells, ending it with "…" when
+// anything was cut. A wide character that would straddle the limit is
+// dropped whole.
+func truncStr(s string, maxLen int) string {
+	if textWidth(s) <= maxLen {
+		return s
+	}
+	if maxLen <= 1 {
+		return "…"
+	}
+	return ansi.Truncate(s, maxLen, "…")
+}
+
+// clipWidth truncates a rendered line to a terminal width cells wide. It
+// leaves the line whole while width is still zero, before the first
+// WindowSizeMsg.
+func clipWidth(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return truncStr(s, width)
+}
+
+// padRight pads s with spaces to width cells. Unlike fmt's %-*s, which counts
+// runes, it keeps columns aligned when s holds wide characters.
+func padRight(s string, width int) string {
+	if gap := width - textWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// hardWrap hard-breaks every line of s that is wider than width cells. It
+// handles long tokens (like URLs) that lipgloss word-wrap can't break, never
+// splits a grapheme cluster, and passes ANSI escapes (such as OSC 8 links)
+// through without counting them.
+func hardWrap(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if textWidth(line) > width {
+			lines[i] = ansi.Hardwrap(line, width, true)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// span is a run of text drawn one way, for wrapSpans.
+type span struct {
+	text   string
+	render func(string) string // nil draws the text as is
+}
+
+// styled returns text as a span drawn in style.
+func styled(text string, style lipgloss.Style) span {
+	return span{text: text, render: func(s string) string { return style.Render(s) }}
+}
+
+// wrapSpans wraps the text of spans to width cells and only then styles it,
+// rendering each piece of each line with its span's style. Wrapping text that
+// is already styled can break a line inside an escape sequence or between a
+// style and its reset, so a continuation line loses its colour or carries
+// half a hyperlink; styling afterwards means every line is whole.
+func wrapSpans(spans []span, width int) []string {
+	var plain strings.Builder
+	ends := make([]int, len(spans))
+	for i, sp := range spans {
+		plain.WriteString(sp.text)
+		ends[i] = plain.Len()
+	}
+	text := plain.String()
+
+	var lines []string
+	for _, r := range wrapRanges(text, width) {
+		var b strings.Builder
+		start := 0
+		for i, sp := range spans {
+			from, to := max(start, r[0]), min(ends[i], r[1])
+			start = ends[i]
+			if from >= to {
+				continue
+			}
+			if sp.render == nil {
+				b.WriteString(text[from:to])
+			} else {
+				b.WriteString(sp.render(text[from:to]))
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// wrapRanges word-wraps s to width cells and returns each line as a byte
+// range of s. Lines break at spaces, which are dropped at the break, and
+// inside a word (between grapheme clusters) only when the word alone is
+// wider than a line. Existing line breaks are kept, as is the indentation of
+// a paragraph's first line. A width of zero or less only splits on line
+// breaks.
+func wrapRanges(s string, width int) [][2]int {
+	var lines [][2]int
+	for from := 0; ; {
+		to := strings.IndexByte(s[from:], '\n')
+		if to < 0 {
+			return wrapParagraph(lines, s, from, len(s), width)
+		}
+		lines = wrapParagraph(lines, s, from, from+to, width)
+		from += to + 1
+	}
+}
+
+// wrapParagraph appends the lines of s[from:to], which holds no line breaks.
+func wrapParagraph(lines [][2]int, s string, from, to, width int) [][2]int {
+	if width <= 0 {
+		return append(lines, [2]int{from, to})
+	}
+	start, end, used := from, from, 0 // the line so far, and its width
+	for i := from; i < to; {
+		j := i
+		for j < to && s[j] == ' ' {
+			j++
+		}
+		if j == to {
+			break // trailing spaces
+		}
+		k := j
+		for k < to && s[k] != ' ' {
+			k++
+		}
+		gap, w := j-i, textWidth(s[j:k])
+		if used+gap+w <= width {
+			end, used, i = k, used+gap+w, k
+			continue
+		}
+		if end > start {
+			lines = append(lines, [2]int{start, end})
+		}
+		start, end, used, i = j, j, 0, k
+		if w <= width {
+			end, used = k, w
+			continue
+		}
+		// Too wide for any line: break between grapheme clusters.
+		for p := j; p < k; {
+			cluster, cw := ansi.FirstGraphemeCluster(s[p:k], ansi.GraphemeWidth)
+			if used+cw > width && used > 0 {
+				lines = append(lines, [2]int{start, p})
+				start, used = p, 0
+			}
+			p += len(cluster)
+			used += cw
+		}
+		end = k
+	}
+	return append(lines, [2]int{start, end})
+}
+
+// trimLastGrapheme removes the last grapheme cluster from s, so backspace
+// deletes an accented letter, a flag or a family emoji as one character.
+func trimLastGrapheme(s string) string {
+	last := 0
+	for rest := s; rest != ""; {
+		cluster, _ := ansi.FirstGraphemeCluster(rest, ansi.GraphemeWidth)
+		if cluster == "" {
+			break
+		}
+		last = len(s) - len(rest)
+		rest = rest[len(cluster):]
+	}
+	return s[:last]
+}
+
+// clampGraphemes returns the longest prefix of s holding at most n runes
+// that does not end inside a grapheme cluster.
+func clampGraphemes(s string, n int) string {
+	end, runes := 0, 0
+	for rest := s; rest != ""; {
+		cluster, _ := ansi.FirstGraphemeCluster(rest, ansi.GraphemeWidth)
+		if cluster == "" {
+			break
+		}
+		if runes += len([]rune(cluster)); runes > n {
+			break
+		}
+		end += len(cluster)
+		rest = rest[len(cluster):]
+	}
+	return s[:end]
+}
@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextWidthWideCharacters(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"hello", 5},
+		{"你好", 4},
+		{"😀", 2},
+		{"👨‍👩‍👧", 2}, // ZWJ family is one cluster
+		{"🇯🇵", 2},
+		{"e\u0301", 1}, // combining accent adds no width
+		{"\033]8;;https://x.dev\ax.dev\033]8;;\a", 5},
+	}
+	for _, tt := range tests {
+		if got := textWidth(tt.s); got != tt.want {
+			t.Errorf("textWidth(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestHardWrapCJK(t *testing.T) {
+	got := hardWrap("你好世界你好世界", 5)
+	for _, line := range strings.Split(got, "\n") {
+		if w := textWidth(line); w > 5 {
+			t.Errorf("line %q is %d cells wide, want <= 5", line, w)
+		}
+	}
+	if joined := strings.ReplaceAll(got, "\n", ""); joined != "你好世界你好世界" {
+		t.Errorf("hardWrap lost content: %q", joined)
+	}
+}
+
+func TestHardWrapKeepsGraphemeClusters(t *testing.T) {
+	family := "👨‍👩‍👧"
+	got := hardWrap("ab"+family+family, 3)
+	for _, line := range strings.Split(got, "\n") {
+		if strings.Count(line, "‍")%2 != 0 {
+			t.Errorf("hardWrap split a ZWJ sequence: %q", got)
+		}
+	}
+}
+
+func TestPadRightWide(t *testing.T) {
+	if got := padRight("東京", 8); textWidth(got) != 8 || got != "東京    " {
+		t.Errorf("padRight(東京, 8) = %q", got)
+	}
+	if got := padRight("toolong", 3); got != "toolong" {
+		t.Errorf("padRight should not cut, got %q", got)
+	}
+}
+
+func TestTrimLastGrapheme(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"", ""},
+		{"abc", "ab"},
+		{"hi🇯🇵", "hi"},
+		{"hi👨‍👩‍👧", "hi"},
+		{"cafe\u0301", "caf"},
+		{"你好", "你"},
+	}
+	for _, tt := range tests {
+		if got := trimLastGrapheme(tt.s); got != tt.want {
+			t.Errorf("trimLastGrapheme(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestClampGraphemes(t *testing.T) {
+	// The flag is two runes; a limit of 3 must not keep half of it.
+	if got := clampGraphemes("ab🇯🇵", 3); got != "ab" {
+		t.Errorf("clampGraphemes = %q, want %q", got, "ab")
+	}
+	if got := clampGraphemes("ab🇯🇵", 4); got != "ab🇯🇵" {
+		t.Errorf("clampGraphemes = %q, want whole string", got)
+	}
+}
+
+func TestRenderChatInputWideCharsFitWidth(t *testing.T) {
+	const width = 40
+	out := renderChatInput("mage", strings.Repeat("漢字", 30), editCursor{}, "", true, 0, width)
+	for _, line := range strings.Split(out, "\n") {
+		if w := textWidth(line); w > width {
+			t.Errorf("input line is %d cells wide, want <= %d: %q", w, width, line)
+		}
+	}
+}
+
+// tagged is a span whose render wraps each piece in brackets, standing in
+// for a style so tests can see where styling starts and stops.
+func tagged(text string) span {
+	return span{text: text, render: func(s string) string { return "[" + s + "]" }}
+}
+
+func TestWrapSpansStylesEachLine(t *testing.T) {
+	spans := []span{{text: "hey "}, tagged("@someone with a long"), {text: " reply to you"}}
+	got := wrapSpans(spans, 12)
+	want := []string{"hey [@someone]", "[with a long]", "reply to you"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrapSpans() = %q, want %q", got, want)
+	}
+}
+
+func TestWrapSpansBreaksAtSpaces(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{"fits", "short line", 40, []string{"short line"}},
+		{"words", "the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"spaces at a break are dropped", "one   two", 4, []string{"one", "two"}},
+		{"line breaks kept", "one\n\ntwo", 40, []string{"one", "", "two"}},
+		{"indent kept", "  indented", 40, []string{"  indented"}},
+		{"long word hard-broken", "see abcdefghij", 4, []string{"see", "abcd", "efgh", "ij"}},
+		{"wide characters", "你好世界", 5, []string{"你好", "世界"}},
+		{"clusters kept whole", "🇯🇵🇯🇵🇯🇵", 3, []string{"🇯🇵", "🇯🇵", "🇯🇵"}},
+		{"no width", "no wrapping at all", 0, []string{"no wrapping at all"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapSpans([]span{{text: tt.text}}, tt.width)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("wrapSpans(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapSpansKeepsLinksWhole(t *testing.T) {
+	// A link the wrapper splits still opens and closes on every line.
+	link := span{text: "abcdefghij", render: func(s string) string {
+		return "\033]8;;https://x.dev\a" + s + "\033]8;;\a"
+	}}
+	for _, line := range wrapSpans([]span{link}, 4) {
+		if !strings.HasPrefix(line, "\033]8;;https://x.dev\a") || !strings.HasSuffix(line, "\033]8;;\a") {
+			t.Errorf("line %q is not a whole link", line)
+		}
+		if w := textWidth(line); w > 4 {
+			t.Errorf("line %q is %d cells wide, want <= 4", line, w)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// threadTitle names a thread after everyone in it but you.
+func threadTitle(t domain.Thread) string {
+	return strings.Join(t.Logins(), ", ")
+}
+
+// renderThreadTitle is threadTitle with each magician in their guild's
+// colour.
+func renderThreadTitle(t domain.Thread) string {
+	if !t.IsGroup() {
+		return GuildStyle(t.OtherGuildID).Render(t.OtherLogin)
+	}
+	names := make([]string, len(t.Participants))
+	for i, p := range t.Participants {
+		names[i] = GuildStyle(p.GuildID).Render(p.Login)
+	}
+	return strings.Join(names, metaStyle.Render(", "))
+}
+
+// anyOnline reports whether anyone in t is online.
+func anyOnline(t domain.Thread, online map[string]bool) bool {
+	for _, login := range t.Logins() {
+		if online[login] {
+			return true
+		}
+	}
+	return false
+}
+
+// senderGuild returns the guild of login in the open thread, so every
+// voice in a group thread keeps its own colour.
+func (m threadsModel) senderGuild(login string) string {
+	for _, p := range m.openParticipants {
+		if p.Login == login {
+			return p.GuildID
+		}
+	}
+	return m.openThreadGuild
+}
+
+// groupLogins reads the logins typed into the new group prompt: separated
+// by spaces or commas, with or without @. Duplicates and your own login
+// are dropped.
+func groupLogins(input, myLogin string) []string {
+	var logins []string
+	for _, f := range strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' }) {
+		login := strings.TrimPrefix(f, "@")
+		if login == "" || strings.EqualFold(login, myLogin) || slices.ContainsFunc(logins, func(l string) bool { return strings.EqualFold(l, login) }) {
+			continue
+		}
+		logins = append(logins, login)
+	}
+	return logins
+}
+
+// startGroupThread opens the thread with everyone in logins, asking the API
+// to start one when there is none yet. A single login is an ordinary DM.
+func (m threadsModel) startGroupThread(logins []string) (threadsModel, tea.Cmd) {
+	if len(logins) == 1 {
+		return m.startThread(logins[0])
+	}
+	want := slices.Sorted(slices.Values(logins))
+	for _, t := range m.threads {
+		if t.IsGroup() && slices.Equal(slices.Sorted(slices.Values(t.Logins())), want) {
+			return m.openThread(t)
+		}
+	}
+	c := m.client
+	return m, func() tea.Msg {
+		thread, err := c.StartGroupThread(context.Background(), logins)
+		return threadsStartedMsg{thread: thread, err: err}
+	}
+}
+
+// updateStart handles keys while the new group prompt is open.
+func (m threadsModel) updateStart(msg tea.KeyMsg) (threadsModel, tea.Cmd) {
+	switch key := msg.String(); key {
+	case "esc":
+		m.starting, m.startInput = false, ""
+	case "enter":
+		logins := groupLogins(m.startInput, m.myLogin)
+		if len(logins) == 0 {
+			m.status = "type the logins to message, e.g. @ada @alan"
+			return m, nil
+		}
+		m.starting, m.status = false, ""
+		return m.startGroupThread(logins)
+	default:
+		if msg.Paste {
+			m.startInput += string(msg.Runes)
+		} else {
+			m.startInput = editRune(m.startInput, key)
+		}
+	}
+	return m, nil
+}
+
+// threadMention is @mention autocomplete in a group thread. It offers only
+// the thread's participants.
+type threadMention struct {
+	query   string
+	matches []string
+	cursor  int
+}
+
+// mentionMatches returns the open thread's participants whose logins start
+// with query.
+func (m threadsModel) mentionMatches(query string) []string {
+	var matches []string
+	for _, p := range m.openParticipants {
+		if strings.HasPrefix(strings.ToLower(p.Login), strings.ToLower(query)) {
+			matches = append(matches, p.Login)
+		}
+	}
+	return matches
+}
+
+// startMention types an @ and, in a group thread with the cursor at the end
+// of the input, offers the participants to complete it.
+func (m threadsModel) startMention() threadsModel {
+	if len(m.openParticipants) == 0 || !m.inputCursor.atEnd(m.input) || utf8.RuneCountInString(m.input) >= maxInputLen {
+		m.input = m.inputCursor.edit(m.input, "@")
+		return m
+	}
+	m.input += "@"
+	m.mention = &threadMention{matches: m.mentionMatches("")}
+	return m
+}
+
+// updateMention handles a key while mention suggestions are showing. It
+// reports false for keys the input should handle as usual.
+func (m threadsModel) updateMention(key string) (threadsModel, bool) {
+	mention := *m.mention
+	switch key {
+	case "tab", "enter":
+		if len(mention.matches) == 0 {
+			m.mention = nil
+			return m, key == "tab"
+		}
+		m.input = strings.TrimSuffix(m.input, "@"+mention.query) + "@" + mention.matches[mention.cursor] + " "
+		m.mention = nil
+	case "up":
+		mention.cursor = max(mention.cursor-1, 0)
+		m.mention = &mention
+	case "down":
+		mention.cursor = min(mention.cursor+1, len(mention.matches)-1)
+		m.mention = &mention
+	case "esc":
+		m.mention = nil
+	case "backspace":
+		m.input = editRune(m.input, "backspace")
+		if mention.query == "" {
+			m.mention = nil
+			return m, true
+		}
+		mention.query = editRune(mention.query, "backspace")
+		mention.matches, mention.cursor = m.mentionMatches(mention.query), 0
+		m.mention = &mention
+	default:
+		if key == " " || utf8.RuneCountInString(key) != 1 {
+			m.mention = nil
+			return m, false
+		}
+		m.input += key
+		mention.query += key
+		mention.matches, mention.cursor = m.mentionMatches(mention.query), 0
+		m.mention = &mention
+		if len(mention.matches) == 0 {
+			m.mention = nil
+		}
+	}
+	return m, true
+}
+
+// renderGroupHeader lists everyone in the open group thread, with how many
+// of them are online.
+func (m threadsModel) renderGroupHeader() string {
+	t := domain.Thread{Participants: m.openParticipants}
+	header := " " + presenceTitleStyle.Render("Group with ") + renderThreadTitle(t)
+	online := 0
+	for _, p := range m.openParticipants {
+		if m.online[p.Login] {
+			online++
+		}
+	}
+	if online > 0 {
+		header += "  " + metaStyle.Render(fmt.Sprintf("%d online", online))
+	}
+	return header
+}
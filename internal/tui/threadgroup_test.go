@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func makeTestGroupThread(logins ...string) domain.Thread {
+	t := domain.Thread{ID: uuid.New(), LastMessage: "parser rewrite", CreatedAt: time.Now()}
+	for _, login := range logins {
+		t.Participants = append(t.Participants, domain.ThreadParticipant{Login: login, GuildID: "guild-" + login})
+	}
+	return t
+}
+
+func TestGroupThreadView(t *testing.T) {
+	m := newTestThreadsModel()
+	group := makeTestGroupThread("ada", "grace")
+	m.threads = []domain.Thread{makeTestThread("linus", "cipher", "thanks!"), group}
+	m.online = map[string]bool{"grace": true}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "ada, grace") {
+		t.Fatalf("expected the group listed by its participants:\n%s", view)
+	}
+
+	// Opened from a notification, the thread still shows everyone in it.
+	m, _ = m.openThread(domain.Thread{ID: group.ID, OtherLogin: "grace"})
+	if m.openThreadLogin != "ada, grace" || len(m.openParticipants) != 2 {
+		t.Fatalf("opened %q with %v, want the listed group", m.openThreadLogin, m.openParticipants)
+	}
+	m.messages = []domain.Message{
+		{ID: uuid.New(), SenderLogin: "ada", Body: "grammar's done", CreatedAt: time.Now()},
+		{ID: uuid.New(), SenderLogin: "grace", Body: "errors next", CreatedAt: time.Now()},
+	}
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "Group with ada, grace  1 online") {
+		t.Errorf("expected the participants in the header:\n%s", view)
+	}
+	if m.senderGuild("ada") != "guild-ada" || m.senderGuild("grace") != "guild-grace" {
+		t.Errorf("senderGuild = %q, %q, want each sender's own guild", m.senderGuild("ada"), m.senderGuild("grace"))
+	}
+}
+
+func TestGroupThreadMentions(t *testing.T) {
+	m := newTestThreadsModel()
+	m, _ = m.openThread(makeTestGroupThread("ada", "alan", "grace"))
+	m, _ = m.Update(keyRune('@'))
+	if m.mention == nil || len(m.mention.matches) != 3 {
+		t.Fatalf("@ offered %+v, want the three participants", m.mention)
+	}
+	m, _ = m.Update(keyRune('a'))
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if !slices.Equal(m.mention.matches, []string{"ada", "alan"}) || m.mention.cursor != 1 {
+		t.Fatalf("matches = %v (cursor %d)", m.mention.matches, m.mention.cursor)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "▸ alan") {
+		t.Errorf("expected the suggestions above the input:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.input != "@alan " || m.mention != nil {
+		t.Errorf("input = %q, want the mention completed", m.input)
+	}
+
+	// Only participants are offered.
+	m, _ = m.Update(keyRune('@'))
+	m, _ = m.Update(keyRune('z'))
+	if m.mention != nil || m.input != "@alan @z" {
+		t.Errorf("input = %q, mention %+v, want no one to complete", m.input, m.mention)
+	}
+
+	// A one-to-one thread types @ as it is.
+	m, _ = m.openThread(makeTestThread("linus", "cipher", ""))
+	m, _ = m.Update(keyRune('@'))
+	if m.mention != nil || m.input != "@" {
+		t.Errorf("in a DM: input = %q, mention %+v", m.input, m.mention)
+	}
+}
+
+func TestStartGroupThread(t *testing.T) {
+	fake := &clienttest.Fake{}
+	m := newTestThreadsModel()
+	m.client = fake
+	m, _ = m.Update(keyRune('g'))
+	if !m.starting {
+		t.Fatal("g should ask who to message")
+	}
+	for _, r := range "@ada, alan testuser" {
+		m, _ = m.Update(keyRune(r))
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.starting {
+		t.Fatalf("enter should start the group, status %q", m.status)
+	}
+	m, _ = m.Update(cmd())
+	if m.state != threadsConvoState || m.openThreadLogin != "ada, alan" {
+		t.Errorf("opened %q, want the new group", m.openThreadLogin)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || calls[0].Method != "StartGroupThread" {
+		t.Errorf("calls = %v", calls)
+	}
+
+	// Starting it again opens the one already listed.
+	m.state, m.threads = threadsListState, slices.Clone(fake.Threads)
+	m, _ = m.startGroupThread([]string{"alan", "ada"})
+	if m.state != threadsConvoState || fake.Count("StartGroupThread") != 1 {
+		t.Errorf("calls = %v, want the listed group reopened", fake.Calls())
+	}
+}
+
+func TestGroupLogins(t *testing.T) {
+	got := groupLogins(" @ada,alan  @Ada me,, ", "me")
+	if !slices.Equal(got, []string{"ada", "alan"}) {
+		t.Errorf("groupLogins() = %q", got)
+	}
+}
@@ -3,12 +3,18 @@ package tui
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/naveenspark/grimora/internal/config"
+	"github.com/naveenspark/grimora/internal/drafts"
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/internal/metrics"
+	"github.com/naveenspark/grimora/internal/outbox"
 	"github.com/naveenspark/grimora/pkg/client"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
@@ -21,8 +27,12 @@ const (
 	threadsConvoState              // viewing a single thread
 )
 
-// threadsPollInterval is how often the open conversation polls for new messages.
-const threadsPollInterval = 5 * time.Second
+// threadsPollInterval is how often the open conversation polls for new
+// messages. Set from config by ApplyConfig.
+var threadsPollInterval = config.DefaultPollIntervals[config.PollThreads]
+
+// threadsPageSize is how many messages are fetched per poll or backfill request.
+const threadsPageSize = 50
 
 // -- messages --
 
@@ -37,8 +47,17 @@ type threadsMessagesLoadedMsg struct {
 	err      error
 }
 
-type threadsSendMsg struct {
-	err error
+// threadsOlderLoadedMsg carries a page of backfilled history older than the oldest loaded message.
+type threadsOlderLoadedMsg struct {
+	threadID string
+	messages []domain.Message
+	err      error
+}
+
+// threadsReadMsg reports whether marking a thread read went through.
+type threadsReadMsg struct {
+	threadID string
+	err      error
 }
 
 type threadsStartedMsg struct {
@@ -48,8 +67,34 @@ type threadsStartedMsg struct {
 
 type threadsPollTickMsg time.Time
 
-func threadsPollCmd() tea.Cmd {
-	return tea.Tick(threadsPollInterval, func(t time.Time) tea.Msg {
+// threadsPresenceMsg carries online status for thread participants, keyed by login.
+type threadsPresenceMsg struct {
+	online map[string]bool
+	err    error
+}
+
+// threadsCardMsg carries the open thread's other participant, for the
+// availability hint in the header.
+type threadsCardMsg struct {
+	login string
+	card  *domain.MagicianCard
+	err   error
+}
+
+// threadsPresenceTickMsg fires when the list view should refresh presence.
+// gen guards against stale ticks from an earlier visit to the list.
+type threadsPresenceTickMsg struct {
+	gen int
+}
+
+func threadsPresenceTickCmd(gen int, d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return threadsPresenceTickMsg{gen: gen}
+	})
+}
+
+func threadsPollCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
 		return threadsPollTickMsg(t)
 	})
 }
@@ -57,7 +102,10 @@ func threadsPollCmd() tea.Cmd {
 // -- model --
 
 type threadsModel struct {
-	client  *client.Client
+	client  client.API
+	drafts  *drafts.Store
+	journal *journal.Journal // copy of everything sent; nil keeps none
+	outbox  *outbox.Store    // messages on their way out; shared with the Hall
 	state   threadsState
 	threads []domain.Thread
 	cursor  int
@@ -66,22 +114,42 @@ type threadsModel struct {
 	height  int
 	myLogin string
 	loading bool
+	online  map[string]bool // participant login -> online
+	presGen int             // incremented each time a presence refresh is scheduled
 
 	// convo state
-	openThreadID    string
-	openThreadLogin string
-	openThreadGuild string
-	messages        []domain.Message
-	input           string
-	inputFocused    bool
-	animFrame       int
-	status          string
-
-	// new thread
+	openThreadID     string
+	openThreadLogin  string // the other magician, or a group thread's title
+	openThreadGuild  string
+	openParticipants []domain.ThreadParticipant // everyone else in a group thread; nil in a DM
+	openThreadCard   *domain.MagicianCard       // nil until loaded
+	messages         []domain.Message
+	input            string
+	inputCursor      editCursor
+	history          inputHistory // sent messages, for ↑ and ↓
+	inputFocused     bool
+	animFrame        int
+	status           string
+	scroll           int             // lines scrolled up from bottom (0 = at bottom)
+	loadingOlder     bool            // backfill request in flight
+	historyDone      bool            // no older messages remain on the server
+	selecting        bool            // message selection mode (nav only)
+	selectedID       string          // ID of the selected message
+	picker           linkPicker      // numbered link chooser for the selected message
+	report           *reportPrompt   // reason being chosen for a report; nil when not reporting
+	mention          *threadMention  // @mention suggestions in a group thread; nil when not completing
+	blocked          map[string]bool // logins whose messages are collapsed; shared, see App.withBlocked
+	revealed         map[string]bool // IDs of collapsed messages x has shown
+	readUpTo         string          // ID of the newest incoming message already marked read
+
+	// new group thread
+	starting   bool // the prompt for who to message is open
 	startInput string
+
+	notified notifiedMessages // messages already alerted on; shared with the Hall and the App
 }
 
-func newThreadsModel(c *client.Client) threadsModel {
+func newThreadsModel(c client.API) threadsModel {
 	return threadsModel{client: c}
 }
 
@@ -97,22 +165,127 @@ func (m threadsModel) loadThreads() tea.Cmd {
 	}
 }
 
+// loadPresence fetches online status for everyone the user has a thread with.
+func (m threadsModel) loadPresence() tea.Cmd {
+	c := m.client
+	logins := make([]string, 0, len(m.threads))
+	for _, t := range m.threads {
+		logins = append(logins, t.Logins()...)
+	}
+	return func() tea.Msg {
+		online, err := c.GetPresence(context.Background(), logins)
+		return threadsPresenceMsg{online: online, err: err}
+	}
+}
+
+// loadCard fetches the open thread's other participant.
+func (m threadsModel) loadCard() tea.Cmd {
+	c, login := m.client, m.openThreadLogin
+	return func() tea.Msg {
+		card, err := c.GetMagician(context.Background(), login)
+		return threadsCardMsg{login: login, card: card, err: err}
+	}
+}
+
 func (m threadsModel) loadMessages() tea.Cmd {
 	c := m.client
 	threadID := m.openThreadID
 	return func() tea.Msg {
-		msgs, err := c.GetMessages(context.Background(), threadID, 50, 0)
+		msgs, err := c.GetMessages(context.Background(), threadID, threadsPageSize, 0)
 		return threadsMessagesLoadedMsg{threadID: threadID, messages: msgs, err: err}
 	}
 }
 
-func (m threadsModel) sendMessage(body string) tea.Cmd {
+// loadOlder fetches the page of messages preceding the oldest loaded message.
+func (m threadsModel) loadOlder() tea.Cmd {
 	c := m.client
 	threadID := m.openThreadID
+	var before time.Time
+	if len(m.messages) > 0 {
+		before = m.messages[0].CreatedAt
+	}
 	return func() tea.Msg {
-		_, err := c.SendMessage(context.Background(), threadID, body)
-		return threadsSendMsg{err: err}
+		msgs, err := c.GetMessagesBefore(context.Background(), threadID, before, threadsPageSize)
+		return threadsOlderLoadedMsg{threadID: threadID, messages: msgs, err: err}
+	}
+}
+
+// markRead marks the open thread read if the other party has sent anything
+// since the last time, and clears its unread count in the list.
+func (m threadsModel) markRead() (threadsModel, tea.Cmd) {
+	newest := ""
+	for _, msg := range m.messages {
+		if msg.SenderLogin != m.myLogin {
+			newest = msg.ID.String()
+		}
+	}
+	if newest == "" || newest == m.readUpTo {
+		return m, nil
+	}
+	m.readUpTo = newest
+	for i := range m.threads {
+		if m.threads[i].ID.String() == m.openThreadID {
+			m.threads[i].Unread = 0
+		}
+	}
+	c, threadID := m.client, m.openThreadID
+	return m, func() tea.Msg {
+		return threadsReadMsg{threadID: threadID, err: c.MarkThreadRead(context.Background(), threadID)}
+	}
+}
+
+// mergeThreadMessages de-duplicates incoming messages by ID against existing
+// ones and returns the union sorted oldest first. An incoming copy replaces
+// the existing one, so read receipts picked up by a poll show.
+func mergeThreadMessages(existing, incoming []domain.Message) []domain.Message {
+	index := make(map[string]int, len(existing)+len(incoming))
+	merged := make([]domain.Message, 0, len(existing)+len(incoming))
+	for _, list := range [][]domain.Message{existing, incoming} {
+		for _, msg := range list {
+			id := msg.ID.String()
+			if i, ok := index[id]; ok {
+				merged[i] = msg
+				continue
+			}
+			index[id] = len(merged)
+			merged = append(merged, msg)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].CreatedAt.Before(merged[j].CreatedAt)
+	})
+	return merged
+}
+
+// incomingAlert returns an alert for messages from the other party that are
+// new since the last poll, and runs the on_dm hook for each of them. The
+// first load of a conversation never alerts, and nor does a magician you've
+// blocked.
+func (m threadsModel) incomingAlert(incoming []domain.Message) tea.Cmd {
+	if len(m.messages) == 0 {
+		return nil
+	}
+	known := make(map[string]bool, len(m.messages))
+	for _, msg := range m.messages {
+		known[msg.ID.String()] = true
 	}
+	var cmds []tea.Cmd
+	for _, msg := range incoming {
+		if !known[msg.ID.String()] && msg.SenderLogin != m.myLogin && !m.blocked[msg.SenderLogin] && m.notified.first(msg.ID.String()) {
+			if len(cmds) == 0 {
+				cmds = append(cmds, alertCmd("new message from "+msg.SenderLogin))
+			}
+			cmds = append(cmds, hookCmd(dmHookEvent(msg)))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// sendMessage queues a message for the open thread and sends it. It shows as
+// pending until the API takes it, and is retried if that fails.
+func (m threadsModel) sendMessage(body string) tea.Cmd {
+	msg := m.outbox.Add(outbox.Message{Kind: outbox.KindDM, Target: m.openThreadID, Login: m.openThreadLogin, Body: body})
+	return sendQueuedCmd(m.client, m.outbox, m.journal, msg)
 }
 
 func (m threadsModel) Update(msg tea.Msg) (threadsModel, tea.Cmd) {
@@ -129,10 +302,34 @@ func (m threadsModel) Update(msg tea.Msg) (threadsModel, tea.Cmd) {
 	case threadsListLoadedMsg:
 		m.loading = false
 		if msg.err != nil {
-			m.err = msg.err.Error()
+			m.err = errReason(msg.err)
 		} else {
 			m.threads = msg.threads
 			m.err = ""
+			if len(m.threads) > 0 {
+				return m, m.loadPresence()
+			}
+		}
+
+	case threadsPresenceMsg:
+		// Keep the last known status on error; presence is best-effort.
+		if msg.err == nil {
+			m.online = msg.online
+		}
+		if m.state == threadsListState {
+			m.presGen++
+			return m, threadsPresenceTickCmd(m.presGen, pollDelay(m.client, threadsPollInterval))
+		}
+
+	case threadsCardMsg:
+		// The hint is best-effort; without the card the header just omits it.
+		if msg.err == nil && msg.login == m.openThreadLogin {
+			m.openThreadCard = msg.card
+		}
+
+	case threadsPresenceTickMsg:
+		if msg.gen == m.presGen && m.state == threadsListState && len(m.threads) > 0 {
+			return m, m.loadPresence()
 		}
 
 	case threadsMessagesLoadedMsg:
@@ -140,46 +337,78 @@ func (m threadsModel) Update(msg tea.Msg) (threadsModel, tea.Cmd) {
 			if msg.err != nil {
 				m.status = "error loading messages"
 			} else {
-				m.messages = msg.messages
+				alert := m.incomingAlert(msg.messages)
+				m.messages = mergeThreadMessages(m.messages, msg.messages)
+				if m.state == threadsConvoState {
+					var read tea.Cmd
+					m, read = m.markRead()
+					return m, tea.Batch(threadsPollCmd(pollDelay(m.client, threadsPollInterval)), alert, read)
+				}
 			}
 		}
 		if m.state == threadsConvoState {
-			return m, threadsPollCmd()
+			return m, threadsPollCmd(pollDelay(m.client, threadsPollInterval))
+		}
+
+	case threadsReadMsg:
+		// Receipts are best-effort; forget the mark so the next poll retries.
+		if msg.err != nil && msg.threadID == m.openThreadID {
+			m.readUpTo = ""
+		}
+
+	case threadsOlderLoadedMsg:
+		if msg.threadID != m.openThreadID {
+			return m, nil
+		}
+		m.loadingOlder = false
+		m.status = ""
+		if msg.err != nil {
+			m.status = "error loading older messages"
+			return m, nil
+		}
+		before := len(m.messages)
+		m.messages = mergeThreadMessages(m.messages, msg.messages)
+		if len(msg.messages) < threadsPageSize || len(m.messages) == before {
+			m.historyDone = true
 		}
 
-	case threadsSendMsg:
+	case outboxSentMsg:
+		if msg.msg.Kind != outbox.KindDM || msg.msg.Target != m.openThreadID {
+			return m, nil
+		}
 		if msg.err != nil {
-			m.status = "send failed: " + msg.err.Error()
+			m.status = errText("send failed", msg.err)
 		} else {
 			m.status = ""
+			m.scroll = 0
 			return m, m.loadMessages()
 		}
 
 	case threadsStartedMsg:
 		if msg.err != nil {
-			m.status = "failed: " + msg.err.Error()
+			m.status = errText("could not start thread", msg.err)
 		} else if msg.thread != nil {
-			m.state = threadsConvoState
-			m.openThreadID = msg.thread.ID.String()
-			m.openThreadLogin = msg.thread.OtherLogin
-			m.openThreadGuild = msg.thread.OtherGuildID
-			m.inputFocused = true
-			m.animFrame = 0
-			m.input = ""
 			m.startInput = ""
-			return m, tea.Batch(m.loadMessages(), cursorBlinkCmd())
+			return m.openThread(*msg.thread)
 		}
 
 	case threadsPollTickMsg:
 		if m.state == threadsConvoState {
+			metrics.ObservePoll("threads")
 			return m, m.loadMessages()
 		}
 
+	case linkOpenedMsg:
+		m.status = linkStatus(msg)
+
+	case reportedMsg:
+		m.status = reportStatus(msg)
+
 	case cursorBlinkMsg:
 		if m.inputFocused {
 			m.animFrame++
 		}
-		return m, cursorBlinkCmd()
+		return m, nil
 
 	case tea.KeyMsg:
 		m.animFrame = 0
@@ -187,13 +416,72 @@ func (m threadsModel) Update(msg tea.Msg) (threadsModel, tea.Cmd) {
 		case threadsListState:
 			return m.updateList(msg)
 		case threadsConvoState:
-			return m.updateConvo(msg)
+			threadID := m.openThreadID
+			var cmd tea.Cmd
+			m, cmd = m.updateConvo(msg)
+			// Leaving the thread clears the input but keeps its saved draft.
+			if m.openThreadID == threadID {
+				m.drafts.Set(threadDraftKey(threadID), m.input)
+			}
+			return m, cmd
 		}
 	}
 	return m, nil
 }
 
+// startThread opens the DM thread with login, asking the API to start one
+// when there is none yet.
+func (m threadsModel) startThread(login string) (threadsModel, tea.Cmd) {
+	for _, t := range m.threads {
+		if !t.IsGroup() && t.OtherLogin == login {
+			return m.openThread(t)
+		}
+	}
+	c := m.client
+	return m, func() tea.Msg {
+		thread, err := c.StartThread(context.Background(), login)
+		return threadsStartedMsg{thread: thread, err: err}
+	}
+}
+
+// openThread switches to the conversation with t's other participants,
+// restoring any saved draft. A thread the list already has is opened as
+// listed, so a notification's bare thread still shows everyone in it.
+func (m threadsModel) openThread(t domain.Thread) (threadsModel, tea.Cmd) {
+	for _, known := range m.threads {
+		if known.ID == t.ID {
+			t = known
+		}
+	}
+	m.state = threadsConvoState
+	m.openThreadID = t.ID.String()
+	m.openThreadLogin = t.OtherLogin
+	m.openThreadGuild = t.OtherGuildID
+	m.openParticipants = nil
+	if t.IsGroup() {
+		m.openThreadLogin = threadTitle(t)
+		m.openParticipants = t.Participants
+	}
+	m.openThreadCard = nil
+	m.messages = nil
+	m.readUpTo = ""
+	m.resetHistory()
+	m.inputFocused = true
+	m.animFrame = 0
+	m.input, m.inputCursor = "", editCursor{}
+	m.mention = nil
+	m = m.restoreDraft()
+	if t.IsGroup() {
+		// Availability hints are for one-to-one threads.
+		return m, m.loadMessages()
+	}
+	return m, tea.Batch(m.loadMessages(), m.loadCard())
+}
+
 func (m threadsModel) updateList(msg tea.KeyMsg) (threadsModel, tea.Cmd) {
+	if m.starting {
+		return m.updateStart(msg)
+	}
 	switch msg.String() {
 	case "j", "down":
 		if m.cursor < len(m.threads)-1 {
@@ -205,21 +493,19 @@ func (m threadsModel) updateList(msg tea.KeyMsg) (threadsModel, tea.Cmd) {
 		}
 	case "enter":
 		if len(m.threads) > 0 && m.cursor < len(m.threads) {
-			thread := m.threads[m.cursor]
-			m.state = threadsConvoState
-			m.openThreadID = thread.ID.String()
-			m.openThreadLogin = thread.OtherLogin
-			m.openThreadGuild = thread.OtherGuildID
-			m.inputFocused = true
-			m.animFrame = 0
-			m.input = ""
-			return m, tea.Batch(m.loadMessages(), cursorBlinkCmd())
+			return m.openThread(m.threads[m.cursor])
 		}
 	case "p":
 		if len(m.threads) > 0 && m.cursor < len(m.threads) {
+			if m.threads[m.cursor].IsGroup() {
+				m.status = "open the group and select a message to see who sent it"
+				return m, nil
+			}
 			login := m.threads[m.cursor].OtherLogin
 			return m, func() tea.Msg { return showPeekMsg{login: login} }
 		}
+	case "g":
+		m.starting, m.startInput, m.status = true, "", ""
 	case "r":
 		return m, m.loadThreads()
 	}
@@ -229,8 +515,34 @@ func (m threadsModel) updateList(msg tea.KeyMsg) (threadsModel, tea.Cmd) {
 func (m threadsModel) updateConvo(msg tea.KeyMsg) (threadsModel, tea.Cmd) {
 	key := msg.String()
 
+	// Failed messages can be retried or discarded whether or not the
+	// input is focused.
+	switch key {
+	case "ctrl+r":
+		cmd := retryFailedCmd(m.client, m.outbox, m.journal, outbox.KindDM, m.openThreadID)
+		if cmd == nil {
+			m.status = "nothing to retry"
+		}
+		return m, cmd
+	case "ctrl+x":
+		if discardFailed(m.outbox, outbox.KindDM, m.openThreadID) == "" {
+			m.status = "nothing to discard"
+		} else {
+			m.status = "unsent message discarded"
+		}
+		return m, nil
+	}
+
 	if m.inputFocused {
+		if m.mention != nil && !msg.Paste {
+			var handled bool
+			if m, handled = m.updateMention(key); handled {
+				return m, nil
+			}
+		}
 		switch key {
+		case "@":
+			return m.startMention(), nil
 		case "esc":
 			m.inputFocused = false
 			return m, nil
@@ -239,30 +551,263 @@ func (m threadsModel) updateConvo(msg tea.KeyMsg) (threadsModel, tea.Cmd) {
 			if body == "" {
 				return m, nil
 			}
-			m.input = ""
+			m.history = m.history.add(body)
+			m.input, m.inputCursor = "", editCursor{}
 			return m, m.sendMessage(body)
+		case "shift+enter", "alt+enter":
+			m.input = m.inputCursor.edit(m.input, "\n")
+			return m, nil
 		default:
-			m.input = editRune(m.input, key)
+			if h, text, ok := m.history.recall(m.input, key); ok {
+				m.history, m.input, m.inputCursor = h, text, editCursor{}
+				return m, nil
+			}
+			m.input = m.inputCursor.edit(m.input, key)
 			return m, nil
 		}
 	}
 
+	if m.selecting {
+		return m.updateSelect(key)
+	}
+
 	// Nav mode
 	switch key {
+	case "v":
+		if n := len(m.messages); n > 0 {
+			m.selecting = true
+			m.selectedID = m.messages[n-1].ID.String()
+			m.status = ""
+			m.ensureSelectedVisible()
+		}
 	case "esc":
 		m.state = threadsListState
 		m.openThreadID = ""
+		m.openParticipants = nil
 		m.messages = nil
-		m.input = ""
+		m.input, m.inputCursor = "", editCursor{}
+		m.resetHistory()
 		return m, m.loadThreads()
 	case "enter", "i":
 		m.inputFocused = true
 		m.animFrame = 0
 		return m, nil
+	case "j", "down":
+		// Scroll down (toward newest).
+		if m.scroll > 0 {
+			m.scroll--
+		}
+	case "k", "up":
+		// Scroll up (toward oldest); backfill once the top is reached.
+		maxScroll := m.maxConvoScroll()
+		if m.scroll < maxScroll {
+			m.scroll++
+		}
+		if m.scroll >= maxScroll && !m.loadingOlder && !m.historyDone && len(m.messages) > 0 {
+			m.loadingOlder = true
+			m.status = "loading older messages..."
+			return m, m.loadOlder()
+		}
+	}
+	return m, nil
+}
+
+// updateSelect handles keys while a message is selected in the conversation.
+func (m threadsModel) updateSelect(key string) (threadsModel, tea.Cmd) {
+	if m.picker.active() {
+		url, done := m.picker.handleKey(key)
+		if done {
+			m.picker = linkPicker{}
+		}
+		if url != "" {
+			return m, openURLCmd(url)
+		}
+		return m, nil
+	}
+	if m.report != nil {
+		p := *m.report
+		reason, done := p.handleKey(key)
+		if !done {
+			return m, nil
+		}
+		m.report, m.status = nil, ""
+		if reason == "" {
+			return m, nil
+		}
+		m.status = "reporting..."
+		return m, p.send(m.client, reason)
+	}
+
+	idx := m.selectedIndex()
+	if idx < 0 {
+		m.exitSelect()
+		return m, nil
+	}
+	switch key {
+	case "j", "down":
+		if idx < len(m.messages)-1 {
+			m.selectedID = m.messages[idx+1].ID.String()
+		}
+		m.ensureSelectedVisible()
+	case "k", "up":
+		if idx > 0 {
+			m.selectedID = m.messages[idx-1].ID.String()
+		}
+		m.ensureSelectedVisible()
+	case "o":
+		urls := extractURLs(m.messages[idx].Body)
+		switch len(urls) {
+		case 0:
+			m.status = "no links in this message"
+		case 1:
+			return m, openURLCmd(urls[0])
+		default:
+			m.picker = newLinkPicker(urls)
+		}
+	case "!":
+		if m.messages[idx].SenderLogin == m.myLogin {
+			m.status = "that's your own message"
+			return m, nil
+		}
+		m.report = &reportPrompt{targetType: domain.ReportTargetMessage, targetID: m.messages[idx].ID.String()}
+		m.status = m.report.question()
+	case "x":
+		target := m.messages[idx]
+		if !m.collapsible(target) {
+			m.status = "x reveals messages from magicians you've blocked"
+			return m, nil
+		}
+		m.revealed = toggleReveal(m.revealed, target.ID.String())
+		m.ensureSelectedVisible()
+	case "esc", "v":
+		m.exitSelect()
+	case "enter", "i":
+		m.exitSelect()
+		m.inputFocused = true
+		m.animFrame = 0
 	}
 	return m, nil
 }
 
+// exitSelect leaves message selection mode.
+func (m *threadsModel) exitSelect() {
+	m.selecting = false
+	m.selectedID = ""
+	m.picker = linkPicker{}
+	m.report = nil
+}
+
+// collapsible reports whether msg is from a magician you've blocked.
+func (m threadsModel) collapsible(msg domain.Message) bool {
+	return msg.SenderLogin != m.myLogin && m.blocked[msg.SenderLogin]
+}
+
+// selectedIndex returns the index of the selected message, or -1 if none.
+func (m threadsModel) selectedIndex() int {
+	if m.selectedID == "" {
+		return -1
+	}
+	for i, msg := range m.messages {
+		if msg.ID.String() == m.selectedID {
+			return i
+		}
+	}
+	return -1
+}
+
+// ensureSelectedVisible adjusts scroll so the selected message is inside the viewport.
+func (m *threadsModel) ensureSelectedVisible() {
+	idx := m.selectedIndex()
+	if idx < 0 {
+		return
+	}
+	lines, starts := m.convoLines()
+	total := len(lines)
+	start := starts[idx]
+	end := total
+	if idx+1 < len(starts) {
+		end = starts[idx+1]
+	}
+	vh := m.convoViewportHeight()
+	if end > total-m.scroll {
+		m.scroll = total - end
+	}
+	if start < total-m.scroll-vh {
+		m.scroll = total - vh - start
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+}
+
+// resetHistory clears scroll, selection and backfill state when switching conversations.
+func (m *threadsModel) resetHistory() {
+	m.scroll = 0
+	m.loadingOlder = false
+	m.historyDone = false
+	m.status = ""
+	m.exitSelect()
+}
+
+// convoViewportHeight returns the number of lines available for messages.
+func (m threadsModel) convoViewportHeight() int {
+	threadBodyWidth := m.width - inputPrefixWidth(m.myLogin) - 1 // -1 for cursor
+	if threadBodyWidth < 10 {
+		threadBodyWidth = 10
+	}
+	chrome := 3 + countInputVisualLines(markCursor(m.input, m.inputCursor, m.inputFocused), threadBodyWidth) // header + sep + status + visual input lines
+	if m.status != "" {
+		chrome++
+	}
+	chrome += m.picker.height()
+	if m.mention != nil {
+		chrome += min(len(m.mention.matches), mentionPopupRows)
+	}
+	viewportHeight := m.height - chrome
+	if viewportHeight < 2 {
+		viewportHeight = 2
+	}
+	return viewportHeight
+}
+
+// convoLines renders every loaded message into visual lines, oldest first, and
+// returns them with the index of each message's first line. When the newest
+// message is mine, a receipt line under it says whether it has been seen.
+// Messages still on their way out follow the rest.
+func (m threadsModel) convoLines() ([]string, []int) {
+	var allLines []string
+	starts := make([]int, len(m.messages))
+	for i, msg := range m.messages {
+		starts[i] = len(allLines)
+		line := m.renderThreadMessage(msg)
+		allLines = append(allLines, strings.Split(line, "\n")...)
+	}
+	if n := len(m.messages); n > 0 && m.messages[n-1].SenderLogin == m.myLogin {
+		allLines = append(allLines, m.renderReceipt(m.messages[n-1]))
+	}
+	allLines = append(allLines, renderPending(m.outbox, outbox.KindDM, m.openThreadID, m.myLogin, m.width, clock())...)
+	return allLines, starts
+}
+
+// renderReceipt is the delivery line under my newest message.
+func (m threadsModel) renderReceipt(msg domain.Message) string {
+	indent := strings.Repeat(" ", 1+8+2+lipgloss.Width(msg.SenderLogin)+3)
+	if msg.ReadAt != nil {
+		return indent + metaStyle.Render("✓ seen")
+	}
+	return indent + metaStyle.Render("sent")
+}
+
+// maxConvoScroll returns the largest scroll offset that still fills the viewport.
+func (m threadsModel) maxConvoScroll() int {
+	lines, _ := m.convoLines()
+	maxScroll := len(lines) - m.convoViewportHeight()
+	if maxScroll < 0 {
+		return 0
+	}
+	return maxScroll
+}
+
 func (m threadsModel) View() string {
 	switch m.state {
 	case threadsConvoState:
@@ -300,26 +845,42 @@ func (m threadsModel) viewList() string {
 			cursor = accentStyle.Render("▸") + " "
 		}
 
-		loginStyled := GuildStyle(thread.OtherGuildID).Render(thread.OtherLogin)
+		loginStyled := renderThreadTitle(thread)
 		if isActive {
-			loginStyled = selectedStyle.Render(thread.OtherLogin)
+			loginStyled = selectedStyle.Render(threadTitle(thread))
 		}
+		if thread.Unread > 0 {
+			loginStyled += " " + accentStyle.Render(fmt.Sprintf("%d new", thread.Unread))
+		}
+		dot := " "
+		if anyOnline(thread, m.online) {
+			dot = presenceDotStyle.Render("●")
+		}
+
+		timeStr := formatTime(thread.CreatedAt)
 
-		preview := truncStr(thread.LastMessage, 40)
+		// Leave the preview whatever the row's other columns don't need.
+		previewMax := 40
+		if m.width > 0 {
+			previewMax = min(previewMax, max(m.width-9-textWidth(loginStyled)-textWidth(timeStr), 1))
+		}
+		preview := truncStr(threadPreview(thread, m.blocked), previewMax)
 		if preview == "" {
 			preview = "no messages"
 		}
 
-		timeStr := formatTime(thread.CreatedAt)
-
-		fmt.Fprintf(&b, " %s%s  %s  %s\n",
+		fmt.Fprintf(&b, " %s%s %s  %s  %s\n",
 			cursor,
+			dot,
 			loginStyled,
 			dimStyle.Render(preview),
 			metaStyle.Render(timeStr),
 		)
 	}
 
+	if m.starting {
+		b.WriteString("\n " + searchStyle.Render("group with: "+m.startInput+"\u2588") + "\n")
+	}
 	if m.status != "" {
 		b.WriteString("\n " + dimStyle.Render(m.status) + "\n")
 	}
@@ -332,42 +893,50 @@ func (m threadsModel) viewConvo() string {
 
 	// Header
 	loginStyled := GuildStyle(m.openThreadGuild).Render(m.openThreadLogin)
-	b.WriteString(" " + presenceTitleStyle.Render("Thread with ") + loginStyled + "\n")
+	header := " " + presenceTitleStyle.Render("Thread with ") + loginStyled
+	if len(m.openParticipants) > 0 {
+		header = m.renderGroupHeader()
+	} else if m.online[m.openThreadLogin] {
+		away := m.openThreadCard != nil && m.openThreadCard.Away
+		header += " " + presenceLabel(true, away)
+	}
+	if m.openThreadCard != nil {
+		if hint := availabilityHint(m.openThreadCard.Magician, clock()); hint != "" {
+			header += "  " + hint
+		}
+	}
+	b.WriteString(header + "\n")
 
 	sep := strings.Repeat("─", max(m.width-2, 4))
 	b.WriteString(" " + metaStyle.Render(sep) + "\n")
 
 	// Messages
-	threadBodyWidth := m.width - inputPrefixWidth(m.myLogin) - 1 // -1 for cursor
-	if threadBodyWidth < 10 {
-		threadBodyWidth = 10
-	}
-	chrome := 3 + countInputVisualLines(m.input, threadBodyWidth) // header + sep + status + visual input lines
-	if m.status != "" {
-		chrome++
-	}
-	viewportHeight := m.height - chrome
-	if viewportHeight < 2 {
-		viewportHeight = 2
-	}
+	viewportHeight := m.convoViewportHeight()
 
-	if len(m.messages) == 0 {
+	if len(m.messages) == 0 && m.outbox.For(outbox.KindDM, m.openThreadID) == nil {
 		padLines(viewportHeight, &b)
 		b.WriteString(" " + dimStyle.Render("no messages yet") + "\n")
 	} else {
-		var allLines []string
-		for _, msg := range m.messages {
-			line := m.renderThreadMessage(msg)
-			allLines = append(allLines, strings.Split(line, "\n")...)
+		allLines, starts := m.convoLines()
+		if idx := m.selectedIndex(); m.selecting && idx >= 0 {
+			allLines[starts[idx]] = markSelectedLine(allLines[starts[idx]])
 		}
 
-		// Show last N lines
+		// Window ends scroll lines above the bottom, clamped to the top.
 		total := len(allLines)
-		start := total - viewportHeight
+		scroll := m.scroll
+		if maxScroll := total - viewportHeight; scroll > maxScroll {
+			scroll = max(maxScroll, 0)
+		}
+		end := total - scroll
+		start := end - viewportHeight
 		if start < 0 {
 			start = 0
 		}
-		visible := allLines[start:]
+		visible := allLines[start:end]
+		if start == 0 && m.historyDone && len(visible) < viewportHeight {
+			visible = append([]string{" " + chatSysStyle.Render("— beginning of conversation —")}, visible...)
+		}
 
 		// Pad top
 		for i := len(visible); i < viewportHeight; i++ {
@@ -379,6 +948,13 @@ func (m threadsModel) viewConvo() string {
 		}
 	}
 
+	if m.picker.active() {
+		b.WriteString(m.picker.View(m.width))
+	}
+	if m.mention != nil {
+		b.WriteString(renderLoginPopup(m.mention.matches, m.mention.cursor))
+	}
+
 	// Input
 	b.WriteString(m.renderConvoInput())
 	b.WriteByte('\n')
@@ -391,6 +967,9 @@ func (m threadsModel) viewConvo() string {
 }
 
 func (m threadsModel) renderThreadMessage(msg domain.Message) string {
+	if m.collapsible(msg) && !m.revealed[msg.ID.String()] {
+		return renderBlockedMessage(msg.CreatedAt)
+	}
 	timeStr := fmt.Sprintf("%8s", formatChatTime(msg.CreatedAt))
 	timePart := metaStyle.Render(timeStr)
 	sep := chatSepStyle.Render(" · ")
@@ -400,7 +979,7 @@ func (m threadsModel) renderThreadMessage(msg domain.Message) string {
 	if isSelf {
 		namePart = chatSelfNameStyle.Render(msg.SenderLogin)
 	} else {
-		namePart = GuildStyle(m.openThreadGuild).Render(msg.SenderLogin)
+		namePart = GuildStyle(m.senderGuild(msg.SenderLogin)).Render(msg.SenderLogin)
 	}
 
 	// Prefix: " " + time(8) + "  " + name + " · "
@@ -409,36 +988,49 @@ func (m threadsModel) renderThreadMessage(msg domain.Message) string {
 	if bodyWidth < 20 {
 		bodyWidth = 20
 	}
-	wrapped := lipgloss.NewStyle().Width(bodyWidth).Render(msg.Body)
-	lines := strings.Split(wrapped, "\n")
-
 	bodyStyle := chatTextStyle
 	if isSelf {
 		bodyStyle = chatSelfTextStyle
 	}
+	lines := renderMarkdown(msg.Body, bodyStyle, bodyWidth, nil, nil)
 
-	result := " " + timePart + "  " + namePart + sep + bodyStyle.Render(lines[0])
+	result := " " + timePart + "  " + namePart + sep + lines[0]
 	if len(lines) > 1 {
 		indent := strings.Repeat(" ", prefixWidth)
 		for _, line := range lines[1:] {
-			result += "\n" + indent + bodyStyle.Render(line)
+			result += "\n" + indent + line
 		}
 	}
 	return result
 }
 
 func (m threadsModel) renderConvoInput() string {
-	return renderChatInput(m.myLogin, m.input, "type a message...", m.inputFocused, m.animFrame, m.width)
+	return renderChatInput(m.myLogin, m.input, m.inputCursor, "type a message...", m.inputFocused, m.animFrame, m.width)
 }
 
 func (m threadsModel) helpKeys() string {
 	switch m.state {
 	case threadsConvoState:
+		if m.mention != nil {
+			return helpEntry("tab", "complete") + "  " + helpEntry("↑/↓", "choose") + "  " + helpEntry("esc", "cancel")
+		}
 		if m.inputFocused {
 			return helpEntry("enter", "send") + "  " + helpEntry("esc", "nav")
 		}
-		return helpEntry("enter", "type") + "  " + helpEntry("esc", "back")
+		if m.picker.active() {
+			return helpEntry("1-9", "open link") + "  " + helpEntry("esc", "cancel")
+		}
+		if m.report != nil {
+			return m.report.helpKeys()
+		}
+		if m.selecting {
+			return helpEntry("j/k", "select") + "  " + helpEntry("o", "open link") + "  " + helpEntry("!", "report") + "  " + helpEntry("enter", "type") + "  " + helpEntry("esc", "done")
+		}
+		return helpEntry("j/k", "scroll") + "  " + helpEntry("v", "select") + "  " + helpEntry("enter", "type") + "  " + helpEntry("esc", "back")
 	default:
-		return helpEntry("j/k", "nav") + "  " + helpEntry("enter", "open") + "  " + helpEntry("p", "peek") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+		if m.starting {
+			return helpEntry("enter", "start") + "  " + helpEntry("esc", "cancel")
+		}
+		return helpEntry("j/k", "nav") + "  " + helpEntry("enter", "open") + "  " + helpEntry("p", "peek") + "  " + helpEntry("g", "group") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
 	}
 }
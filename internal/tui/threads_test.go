@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -8,11 +10,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
 
 func newTestThreadsModel() threadsModel {
-	m := newThreadsModel(nil)
+	m := newThreadsModel(&clienttest.Fake{})
 	m.width = 80
 	m.height = 24
 	m.myLogin = "testuser"
@@ -143,3 +146,323 @@ func TestThreadsConvoRendersMessages(t *testing.T) {
 		t.Errorf("expected own message in convo view, got:\n%s", view)
 	}
 }
+
+func makeTestMessages(n int, start time.Time) []domain.Message {
+	msgs := make([]domain.Message, n)
+	for i := range msgs {
+		msgs[i] = domain.Message{
+			ID:          uuid.New(),
+			SenderLogin: "alice",
+			Body:        fmt.Sprintf("message %d", i),
+			CreatedAt:   start.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return msgs
+}
+
+func TestThreadsPollMergesWithBackfilledHistory(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = uuid.New().String()
+
+	base := time.Now().Add(-time.Hour)
+	older := makeTestMessages(3, base)
+	newer := makeTestMessages(2, base.Add(10*time.Minute))
+	m.messages = older
+
+	// A poll returning only the latest page must not drop older history.
+	m, _ = m.Update(threadsMessagesLoadedMsg{threadID: m.openThreadID, messages: append([]domain.Message{older[2]}, newer...)})
+	if len(m.messages) != 5 {
+		t.Fatalf("expected 5 merged messages, got %d", len(m.messages))
+	}
+	for i := 1; i < len(m.messages); i++ {
+		if m.messages[i].CreatedAt.Before(m.messages[i-1].CreatedAt) {
+			t.Errorf("messages not sorted oldest first at index %d", i)
+		}
+	}
+}
+
+func TestThreadsScrollKeys(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = uuid.New().String()
+	m.messages = makeTestMessages(40, time.Now().Add(-time.Hour))
+	m.inputFocused = false
+	m.historyDone = true
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if m.scroll != 1 {
+		t.Errorf("expected scroll=1 after k, got %d", m.scroll)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.scroll != 0 {
+		t.Errorf("expected scroll=0 after j, got %d", m.scroll)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.scroll != 0 {
+		t.Errorf("expected scroll to stay at 0, got %d", m.scroll)
+	}
+}
+
+func TestThreadsScrollShowsOlderMessages(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = uuid.New().String()
+	m.messages = makeTestMessages(40, time.Now().Add(-time.Hour))
+	m.inputFocused = false
+
+	if strings.Contains(m.View(), "message 0") {
+		t.Fatal("expected oldest message hidden at bottom scroll")
+	}
+	m.scroll = m.maxConvoScroll()
+	if !strings.Contains(m.View(), "message 0") {
+		t.Errorf("expected oldest message visible when scrolled to top, got:\n%s", m.View())
+	}
+}
+
+func TestThreadsScrollToTopTriggersBackfill(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = uuid.New().String()
+	m.messages = makeTestMessages(3, time.Now().Add(-time.Hour))
+	m.inputFocused = false
+
+	// Too few messages to scroll: the first k is already at the top.
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if !m.loadingOlder {
+		t.Error("expected loadingOlder=true after reaching the top")
+	}
+	if cmd == nil {
+		t.Error("expected backfill command at top of history")
+	}
+}
+
+func TestThreadsOlderLoadedPrependsAndMarksDone(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = uuid.New().String()
+	base := time.Now().Add(-time.Hour)
+	m.messages = makeTestMessages(2, base)
+	m.loadingOlder = true
+
+	older := makeTestMessages(2, base.Add(-30*time.Minute))
+	m, _ = m.Update(threadsOlderLoadedMsg{threadID: m.openThreadID, messages: older})
+	if m.loadingOlder {
+		t.Error("expected loadingOlder cleared")
+	}
+	if len(m.messages) != 4 {
+		t.Fatalf("expected 4 messages after backfill, got %d", len(m.messages))
+	}
+	if m.messages[0].ID != older[0].ID {
+		t.Error("expected backfilled messages first")
+	}
+	if !m.historyDone {
+		t.Error("expected historyDone when a short page is returned")
+	}
+	if !strings.Contains(m.View(), "beginning of conversation") {
+		t.Errorf("expected beginning marker once history is exhausted, got:\n%s", m.View())
+	}
+}
+
+func TestThreadsOlderLoadedIgnoresStaleThread(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = uuid.New().String()
+	m.loadingOlder = true
+
+	m, _ = m.Update(threadsOlderLoadedMsg{threadID: "other", messages: makeTestMessages(2, time.Now())})
+	if len(m.messages) != 0 {
+		t.Errorf("expected stale backfill ignored, got %d messages", len(m.messages))
+	}
+}
+
+func TestThreadsSelectAndOpenLink(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = "t1"
+	m.messages = makeTestMessages(3, time.Now())
+	m.messages[1].Body = "docs at https://grimora.ai/docs"
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if !m.selecting || m.selectedIndex() != 2 {
+		t.Fatalf("expected newest message selected, got index %d", m.selectedIndex())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if cmd == nil {
+		t.Error("expected open command for link in selected message")
+	}
+}
+
+func TestThreadsSelectEscKeepsConvoOpen(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = "t1"
+	m.messages = makeTestMessages(2, time.Now())
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.selecting {
+		t.Error("expected esc to leave selection mode")
+	}
+	if m.state != threadsConvoState {
+		t.Error("esc in selection mode should not close the conversation")
+	}
+}
+
+func TestThreadsListLoadedFetchesPresence(t *testing.T) {
+	m := newTestThreadsModel()
+	_, cmd := m.Update(threadsListLoadedMsg{threads: []domain.Thread{makeTestThread("alice", "nyx", "hi")}})
+	if cmd == nil {
+		t.Error("expected presence fetch after threads load")
+	}
+}
+
+func TestThreadsPresenceDotRendered(t *testing.T) {
+	m := newTestThreadsModel()
+	m.threads = []domain.Thread{
+		makeTestThread("alice", "nyx", "hi"),
+		makeTestThread("bob", "cipher", "yo"),
+	}
+	m, cmd := m.Update(threadsPresenceMsg{online: map[string]bool{"alice": true}})
+	if cmd == nil {
+		t.Error("expected presence refresh to be scheduled in list view")
+	}
+
+	for _, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, "alice") && !strings.Contains(line, "●") {
+			t.Errorf("expected presence dot next to online alice, got %q", line)
+		}
+		if strings.Contains(line, "bob") && strings.Contains(line, "●") {
+			t.Errorf("expected no presence dot next to offline bob, got %q", line)
+		}
+	}
+}
+
+func TestThreadsPresenceErrorKeepsLastStatus(t *testing.T) {
+	m := newTestThreadsModel()
+	m.online = map[string]bool{"alice": true}
+	m, _ = m.Update(threadsPresenceMsg{err: fmt.Errorf("boom")})
+	if !m.online["alice"] {
+		t.Error("presence error should keep last known status")
+	}
+}
+
+func TestThreadsPresenceTickIgnoresStale(t *testing.T) {
+	m := newTestThreadsModel()
+	m.threads = []domain.Thread{makeTestThread("alice", "nyx", "hi")}
+	m, _ = m.Update(threadsPresenceMsg{})
+	if _, cmd := m.Update(threadsPresenceTickMsg{gen: m.presGen - 1}); cmd != nil {
+		t.Error("stale presence tick should be ignored")
+	}
+	if _, cmd := m.Update(threadsPresenceTickMsg{gen: m.presGen}); cmd == nil {
+		t.Error("current presence tick should refresh presence")
+	}
+}
+
+func TestThreadsConvoHeaderShowsOnline(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadLogin = "alice"
+	m.online = map[string]bool{"alice": true}
+	if !strings.Contains(m.View(), "online") {
+		t.Errorf("expected online marker in convo header, got:\n%s", m.View())
+	}
+}
+
+func TestThreadsConvoHeaderShowsAvailability(t *testing.T) {
+	m := newTestThreadsModel()
+	m.threads = []domain.Thread{makeTestThread("owl", "nyx", "hi")}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != threadsConvoState {
+		t.Fatal("expected convo state")
+	}
+
+	card := &domain.MagicianCard{Magician: domain.Magician{GitHubLogin: "owl", Timezone: "UTC", ActiveHours: "0-24"}}
+	m, _ = m.Update(threadsCardMsg{login: "someone-else", card: card})
+	if m.openThreadCard != nil {
+		t.Fatal("expected card for another login to be ignored")
+	}
+	m, _ = m.Update(threadsCardMsg{login: "owl", card: card})
+	header := strings.SplitN(m.View(), "\n", 2)[0]
+	if !strings.Contains(header, "active now") {
+		t.Errorf("expected availability in header, got %q", header)
+	}
+}
+
+func TestThreadsListShowsUnreadCount(t *testing.T) {
+	m := newTestThreadsModel()
+	thread := makeTestThread("alice", "loomari", "ping")
+	thread.Unread = 3
+	m, _ = m.Update(threadsListLoadedMsg{threads: []domain.Thread{thread, makeTestThread("bob", "cipher", "hi")}})
+
+	view := m.View()
+	if !strings.Contains(view, "3 new") {
+		t.Errorf("expected unread count in list, got:\n%s", view)
+	}
+	if strings.Count(view, " new") != 1 {
+		t.Errorf("expected only alice's row to show unread, got:\n%s", view)
+	}
+}
+
+func TestThreadsViewingMarksRead(t *testing.T) {
+	fake := &clienttest.Fake{}
+	m := newTestThreadsModel()
+	m.client = fake
+	thread := makeTestThread("alice", "loomari", "ping")
+	thread.Unread = 2
+	m.threads = []domain.Thread{thread}
+	m, _ = m.openThread(thread)
+
+	msgs := makeTestMessages(2, time.Now().Add(-time.Minute))
+	m, cmd := m.Update(threadsMessagesLoadedMsg{threadID: m.openThreadID, messages: msgs})
+	drainCmd(cmd)
+	if got := fake.Count("MarkThreadRead"); got != 1 {
+		t.Fatalf("MarkThreadRead calls = %d, want 1", got)
+	}
+	if m.threads[0].Unread != 0 {
+		t.Errorf("unread = %d, want 0 after viewing", m.threads[0].Unread)
+	}
+
+	// A poll with nothing new from alice doesn't mark again.
+	m, cmd = m.Update(threadsMessagesLoadedMsg{threadID: m.openThreadID, messages: msgs})
+	drainCmd(cmd)
+	if got := fake.Count("MarkThreadRead"); got != 1 {
+		t.Errorf("MarkThreadRead calls = %d after an idle poll, want 1", got)
+	}
+
+	// A failed mark is retried on the next poll.
+	m, _ = m.Update(threadsReadMsg{threadID: m.openThreadID, err: errors.New("offline")})
+	_, cmd = m.Update(threadsMessagesLoadedMsg{threadID: m.openThreadID, messages: msgs})
+	drainCmd(cmd)
+	if got := fake.Count("MarkThreadRead"); got != 2 {
+		t.Errorf("MarkThreadRead calls = %d after a failure, want 2", got)
+	}
+}
+
+func TestThreadsSeenReceipt(t *testing.T) {
+	m := newTestThreadsModel()
+	m.state = threadsConvoState
+	m.openThreadID = uuid.New().String()
+	m.openThreadLogin = "alice"
+	mine := domain.Message{ID: uuid.New(), SenderLogin: "testuser", Body: "you there?", CreatedAt: time.Now()}
+	m.messages = []domain.Message{mine}
+	if view := m.View(); !strings.Contains(view, "sent") || strings.Contains(view, "seen") {
+		t.Errorf("expected an unread receipt, got:\n%s", view)
+	}
+
+	// A poll carrying the read time replaces the copy already loaded.
+	read := mine
+	now := time.Now()
+	read.ReadAt = &now
+	m, _ = m.Update(threadsMessagesLoadedMsg{threadID: m.openThreadID, messages: []domain.Message{read}})
+	if view := m.View(); !strings.Contains(view, "✓ seen") {
+		t.Errorf("expected a seen receipt, got:\n%s", view)
+	}
+
+	// Once alice replies the receipt goes away.
+	m.messages = append(m.messages, domain.Message{ID: uuid.New(), SenderLogin: "alice", Body: "yes", CreatedAt: now.Add(time.Second)})
+	if view := m.View(); strings.Contains(view, "seen") {
+		t.Errorf("expected no receipt under a reply, got:\n%s", view)
+	}
+}
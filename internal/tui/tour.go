@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tourSlug is the practice room. It exists only on this machine: nothing
+// typed there is sent, and the other magicians in it are scripted.
+const tourSlug = "practice"
+
+const tourRoomName = "practice room"
+
+// tourReplyDelay is how long the scripted magicians "type" before answering.
+var tourReplyDelay = 900 * time.Millisecond
+
+// tourLogin stands in for the user's login when the tour runs logged out.
+const tourLogin = "you"
+
+// Tour steps, in order.
+const (
+	tourHello = iota
+	tourMention
+	tourSlash
+	tourReact
+	tourDone
+)
+
+// tourState tracks progress through the practice room script.
+type tourState struct {
+	step int
+	seq  int // for local message IDs
+}
+
+// tourReplyMsg delivers scripted replies after tourReplyDelay.
+type tourReplyMsg struct {
+	msgs []chatMessage
+}
+
+// practicing reports whether the Hall is showing the practice room.
+func (m hallModel) practicing() bool {
+	return m.room == tourSlug
+}
+
+// enterTour switches the Hall to the practice room and starts the script
+// from the top.
+func (m hallModel) enterTour() hallModel {
+	m = m.enterRoom(tourSlug, tourRoomName)
+	m.tour = tourState{}
+	m.connected = true
+	m.inputFocused = true
+	m.messages = []chatMessage{
+		{IsSystem: true, Body: "nothing here leaves your terminal"},
+		m.tourMessage("ada", "nyx", "anyone have a good prompt for untangling flaky tests?"),
+		m.tourMessage("linus", "cipher", "ask the Grimoire, it's weirdly good at that"),
+		m.tourGrimoire("Welcome, apprentice. This room is yours alone. Say something: type a message and press enter."),
+	}
+	return m
+}
+
+// tourMessage returns a scripted message from login.
+func (m *hallModel) tourMessage(login, guild, body string) chatMessage {
+	m.tour.seq++
+	return chatMessage{
+		ID:          fmt.Sprintf("tour-%d", m.tour.seq),
+		SenderLogin: login,
+		SenderGuild: guild,
+		Body:        body,
+		Kind:        "message",
+		CreatedAt:   time.Now(),
+	}
+}
+
+// tourGrimoire returns a line from the Grimoire guiding the next step.
+func (m *hallModel) tourGrimoire(body string) chatMessage {
+	msg := m.tourMessage("", "", body)
+	msg.Kind = "cast"
+	msg.IsGrimoire = true
+	return msg
+}
+
+// tourSend posts body in the practice room, turning slash commands into the
+// rich messages the server would make, and queues the script's answer.
+func (m hallModel) tourSend(body string) (hallModel, tea.Cmd) {
+	login := m.myLogin
+	if login == "" {
+		login = tourLogin
+	}
+	msg := m.tourMessage(login, "", body)
+	msg.IsSelf = true
+
+	var replies []chatMessage
+	cmd, arg, _ := strings.Cut(body, " ")
+	if strings.HasPrefix(body, "/") {
+		switch cmd {
+		case "/seek":
+			msg.Kind, msg.Body = "seek", arg
+		case "/build":
+			msg.Kind, msg.Metadata = "build-start", map[string]string{"title": arg}
+		case "/b":
+			msg.Kind, msg.Body = "build-update", arg
+		case "/ship":
+			msg.Kind, msg.Metadata = "ship", map[string]string{"title": arg}
+		default:
+			replies = append(replies, m.tourGrimoire(fmt.Sprintf("I don't know %s. Try /seek, /build, /b or /ship.", cmd)))
+			return m, tourReplyCmd(replies)
+		}
+		if arg == "" {
+			replies = append(replies, m.tourGrimoire(fmt.Sprintf("%s needs some text after it, e.g. %s how do I mock time in Go?", cmd, cmd)))
+			return m, tourReplyCmd(replies)
+		}
+	}
+	m.messages = append(m.messages, msg)
+	m.scroll = 0
+
+	switch {
+	case m.tour.step == tourHello:
+		m.tour.step = tourMention
+		replies = append(replies, m.tourGrimoire("Heard. Now call someone by name: type @, pick ada from the list with tab, and send."))
+	case m.tour.step == tourMention && msg.Kind == "message":
+		if mentionsLogin(body, "ada") || mentionsLogin(body, "linus") {
+			m.tour.step = tourSlash
+			replies = append(replies,
+				m.tourMessage("ada", "nyx", "hi! mentions ping people even when they're on another tab"),
+				m.tourGrimoire("Slash commands make rich messages. Ask the room something with /seek <question>."))
+		} else {
+			replies = append(replies, m.tourGrimoire("Start with @ and pick a name from the list."))
+		}
+	case m.tour.step == tourSlash && msg.Kind != "message":
+		m.tour.step = tourReact
+		replies = append(replies,
+			m.tourMessage("linus", "cipher", "good one, I'd like to know too"),
+			m.tourGrimoire("/build announces what you're working on, /b posts an update and /ship celebrates. Last thing: press esc, then v to select a message and + to react."))
+	case m.tour.step == tourSlash:
+		replies = append(replies, m.tourGrimoire("Try a slash command: /seek <question>."))
+	}
+	return m, tourReplyCmd(replies)
+}
+
+// tourReact adds a reaction to a practice room message.
+func (m hallModel) tourReact(idx int) (hallModel, tea.Cmd) {
+	msg := &m.messages[idx]
+	found := false
+	for i := range msg.Reactions {
+		if msg.Reactions[i].Emoji == defaultReaction {
+			msg.Reactions[i].Count++
+			found = true
+		}
+	}
+	if !found {
+		msg.Reactions = append(msg.Reactions, reactionCount{Emoji: defaultReaction, Count: 1})
+	}
+	if m.tour.step != tourReact {
+		return m, nil
+	}
+	m.tour.step = tourDone
+	return m, tourReplyCmd([]chatMessage{m.tourGrimoire("That's all there is to it. Press esc twice to leave the practice room; the real Hall is waiting.")})
+}
+
+func tourReplyCmd(msgs []chatMessage) tea.Cmd {
+	if len(msgs) == 0 {
+		return nil
+	}
+	return tea.Tick(tourReplyDelay, func(t time.Time) tea.Msg {
+		for i := range msgs {
+			msgs[i].CreatedAt = t
+		}
+		return tourReplyMsg{msgs: msgs}
+	})
+}
+
+// tourProgram runs the practice room on its own for `grimora tour`, without
+// the rest of the app or an API client.
+type tourProgram struct {
+	hall hallModel
+}
+
+// NewTour returns a standalone practice room for `grimora tour`. It needs no
+// login or network.
+func NewTour() tea.Model {
+	return tourProgram{hall: newHallModel(nil).enterTour()}
+}
+
+func (t tourProgram) Init() tea.Cmd {
+	return tea.Batch(hallAnimTickCmd(), cursorBlinkCmd())
+}
+
+func (t tourProgram) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		// Leave a line for the help bar.
+		msg = tea.WindowSizeMsg{Width: size.Width, Height: size.Height - 1}
+	}
+	if key, ok := msg.(tea.KeyMsg); ok {
+		nav := !t.hall.inputFocused && !t.hall.selecting
+		switch {
+		case key.String() == "ctrl+c", nav && (key.String() == "esc" || key.String() == "q"):
+			return t, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	t.hall, cmd = t.hall.Update(msg)
+	if _, ok := msg.(cursorBlinkMsg); ok {
+		cmd = tea.Batch(cmd, cursorBlinkCmd())
+	}
+	return t, cmd
+}
+
+func (t tourProgram) View() string {
+	help := " " + helpEntry("enter", "send") + "  " + helpEntry("esc", "nav") + "  " + helpEntry("v", "select") + "  " + helpEntry("+", "react") + "  " + helpEntry("esc esc", "quit")
+	return withGlyphs(t.hall.View() + "\n" + help)
+}
+
+// WithTour opens the app in the practice room, for a magician's first run.
+func (a App) WithTour() App {
+	a.hall = a.hall.enterTour()
+	a.view = viewHall
+	return a
+}
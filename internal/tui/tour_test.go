@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tourSay types body into the practice room, sends it and delivers the
+// scripted replies.
+func tourSay(t *testing.T, m hallModel, body string) hallModel {
+	t.Helper()
+	m.input = body
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("%q: expected a scripted reply", body)
+	}
+	reply, ok := cmd().(tourReplyMsg)
+	if !ok {
+		t.Fatalf("%q: expected tourReplyMsg", body)
+	}
+	m, _ = m.Update(reply)
+	return m
+}
+
+func lastMessage(m hallModel) chatMessage {
+	return m.messages[len(m.messages)-1]
+}
+
+func TestTourScript(t *testing.T) {
+	defer func(d time.Duration) { tourReplyDelay = d }(tourReplyDelay)
+	tourReplyDelay = time.Millisecond
+
+	m := newTestHallModel().enterTour()
+	if !m.practicing() || !m.inputFocused || len(m.messages) == 0 {
+		t.Fatal("expected the practice room with its intro")
+	}
+
+	m = tourSay(t, m, "hello there")
+	if m.tour.step != tourMention || !strings.Contains(lastMessage(m).Body, "@") {
+		t.Fatalf("after hello: step %d, last %q", m.tour.step, lastMessage(m).Body)
+	}
+
+	m = tourSay(t, m, "no mention")
+	if m.tour.step != tourMention {
+		t.Error("a message without a mention should not advance")
+	}
+	m = tourSay(t, m, "hi @ada")
+	if m.tour.step != tourSlash {
+		t.Fatalf("after mention: step %d", m.tour.step)
+	}
+
+	n := len(m.messages)
+	m = tourSay(t, m, "/nope")
+	if m.messages[n].Body == "/nope" || m.tour.step != tourSlash {
+		t.Error("unknown slash command should only get a hint")
+	}
+	m = tourSay(t, m, "/seek how do I mock time?")
+	seek := m.messages[len(m.messages)-3]
+	if seek.Kind != "seek" || seek.Body != "how do I mock time?" || !seek.IsSelf {
+		t.Errorf("seek = %+v", seek)
+	}
+	if m.tour.step != tourReact {
+		t.Fatalf("after seek: step %d", m.tour.step)
+	}
+
+	// React to the newest message from select mode.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if r := lastMessage(m).Reactions; len(r) != 1 || r[0].Count != 1 {
+		t.Errorf("reactions = %+v", r)
+	}
+	if m.tour.step != tourDone || cmd == nil {
+		t.Errorf("reacting should finish the tour, step %d", m.tour.step)
+	}
+}
+
+func TestTourNeedsNoLoginOrClient(t *testing.T) {
+	m := newTestHallModel().enterTour()
+	if cmd := m.loadMessages(); cmd != nil {
+		t.Error("the practice room must not poll the API")
+	}
+	m.input = "hi"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := lastMessage(m); got.SenderLogin != tourLogin || got.Body != "hi" {
+		t.Errorf("logged-out message = %+v", got)
+	}
+}
+
+func TestHallSlashTourEntersPracticeRoom(t *testing.T) {
+	m := newTestHallModel()
+	m.myLogin = "user"
+	m.inputFocused = true
+	m.input = "/tour"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.practicing() {
+		t.Fatal("/tour should open the practice room")
+	}
+	m.inputFocused = false
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.practicing() || cmd == nil {
+		t.Error("esc should return to the Hall and start polling it")
+	}
+}
+
+func TestTourReplyDroppedAfterLeaving(t *testing.T) {
+	m := newTestHallModel()
+	n := len(m.messages)
+	m, _ = m.Update(tourReplyMsg{msgs: []chatMessage{{ID: "tour-9", Body: "late"}}})
+	if len(m.messages) != n {
+		t.Error("scripted replies should not land in a real room")
+	}
+}
+
+func TestTourProgramQuitsFromNav(t *testing.T) {
+	var p tea.Model = NewTour()
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyEsc}) // input -> nav
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("expected quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("esc in nav mode should quit the standalone tour")
+	}
+}
+
+func TestTourSeekCannotBeWatched(t *testing.T) {
+	m := newTestHallModel().enterTour()
+	m.messages = append(m.messages, chatMessage{ID: "tour-seek", Kind: "seek", Body: "how do I mock time?", SenderLogin: tourLogin, IsSelf: true})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	if cmd != nil || !strings.Contains(m.status, "can't be watched") {
+		t.Errorf("status = %q, want practice seeks kept off the API", m.status)
+	}
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/internal/journal"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// undoWindow is how long a deleted project can be brought back with "u".
+const undoWindow = 5 * time.Second
+
+// projectUndo remembers a just-deleted project so it can be restored, and
+// where it sat in the list.
+type projectUndo struct {
+	project domain.WorkshopProject
+	index   int
+	expires time.Time
+	gen     int
+}
+
+// undoExpiredMsg closes the undo window opened by the delete numbered gen.
+type undoExpiredMsg struct {
+	gen int
+}
+
+// workshopRestoredMsg carries a restored project. recreated means the
+// server couldn't restore it, so it was created again from its fields and
+// its timeline is gone.
+type workshopRestoredMsg struct {
+	project   *domain.WorkshopProject
+	index     int
+	recreated bool
+	err       error
+}
+
+// offerUndo opens the undo window for p, deleted from position index.
+func (m youModel) offerUndo(p domain.WorkshopProject, index int) (youModel, tea.Cmd) {
+	gen := 1
+	if m.undo != nil {
+		gen = m.undo.gen + 1
+	}
+	m.undo = &projectUndo{project: p, index: index, expires: time.Now().Add(undoWindow), gen: gen}
+	return m, tea.Tick(undoWindow, func(time.Time) tea.Msg { return undoExpiredMsg{gen: gen} })
+}
+
+// canUndo reports whether a deleted project can still be brought back.
+func (m youModel) canUndo() bool {
+	return m.undo != nil && time.Now().Before(m.undo.expires)
+}
+
+// undoDelete restores the last deleted project.
+func (m youModel) undoDelete() (youModel, tea.Cmd) {
+	u := *m.undo
+	m.undo = nil
+	m.statusMsg = "restoring " + u.project.Name + "..."
+	c, j := m.client, m.journal
+	return m, func() tea.Msg {
+		p, recreated, err := restoreProject(context.Background(), c, u.project)
+		if err == nil && recreated {
+			// A project made again has a new ID; a restored one is already
+			// in the journal.
+			record(j, journal.Entry{Kind: journal.KindProject, ID: p.ID.String(), Text: projectJournalText(p.Name, p.Insight)})
+		}
+		return workshopRestoredMsg{project: p, index: u.index, recreated: recreated, err: err}
+	}
+}
+
+// restoreProject asks the server to restore p and, if it can't, creates
+// the project again with the same name, insight and repo link.
+func restoreProject(ctx context.Context, c client.API, p domain.WorkshopProject) (*domain.WorkshopProject, bool, error) {
+	restored, err := c.RestoreWorkshopProject(ctx, p.ID.String())
+	if err == nil || !client.IsNotFound(err) {
+		return restored, false, err
+	}
+	created, err := c.CreateWorkshopProject(ctx, p.Name, p.Insight)
+	if err != nil {
+		return nil, true, err
+	}
+	if p.URL != "" {
+		if err := c.SetWorkshopProjectURL(ctx, created.ID.String(), p.URL); err == nil {
+			created.URL = p.URL
+		}
+	}
+	return created, true, nil
+}
+
+// projectRestored puts a restored project back where it was.
+func (m youModel) projectRestored(msg workshopRestoredMsg) (youModel, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = errText("undo failed", msg.err)
+		return m, nil
+	}
+	i := min(max(msg.index, 0), len(m.projects))
+	m.projects = append(m.projects[:i:i], append([]domain.WorkshopProject{*msg.project}, m.projects[i:]...)...)
+	m.section = youSectionWorkshop
+	m.wsCursor = i
+	if msg.recreated {
+		m.statusMsg = "project restored, but its timeline couldn't be recovered"
+		return m, nil
+	}
+	m.statusMsg = "project restored"
+	return m, m.loadProjectUpdates(msg.project.ID.String())
+}
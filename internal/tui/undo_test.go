@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// deleteFirstProject deletes the first project in m through the fake and
+// returns the model with the undo window open.
+func deleteFirstProject(t *testing.T, m youModel) youModel {
+	t.Helper()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m, tick := m.Update(cmd())
+	if tick == nil || !m.canUndo() {
+		t.Fatal("expected a delete to open the undo window")
+	}
+	return m
+}
+
+func TestUndoRestoresDeletedProject(t *testing.T) {
+	first, second := makeTestProject("grimora", "a TUI"), makeTestProject("lantern", "a lamp")
+	f := &clienttest.Fake{Projects: []domain.WorkshopProject{first, second}}
+	m := newTestYouModel()
+	m.client = f
+	m.projects = []domain.WorkshopProject{first, second}
+
+	m = deleteFirstProject(t, m)
+	if len(m.projects) != 1 || !strings.Contains(m.View(), "grimora removed — press u to undo") {
+		t.Fatalf("expected the undo prompt after delete:\n%s", m.View())
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if cmd == nil {
+		t.Fatal("expected u to restore")
+	}
+	m, _ = m.Update(cmd())
+	if len(m.projects) != 2 || m.projects[0].ID != first.ID || m.wsCursor != 0 {
+		t.Errorf("projects = %+v, cursor %d; want grimora back in first place", m.projects, m.wsCursor)
+	}
+	if m.statusMsg != "project restored" || f.Count("CreateWorkshopProject") != 0 {
+		t.Errorf("status = %q, creates = %d", m.statusMsg, f.Count("CreateWorkshopProject"))
+	}
+}
+
+func TestUndoRecreatesWhenServerCannotRestore(t *testing.T) {
+	p := makeTestProject("grimora", "a TUI")
+	p.URL = "https://github.com/naveenspark/grimora"
+	f := &clienttest.Fake{Projects: []domain.WorkshopProject{p}}
+	m := newTestYouModel()
+	m.client = f
+	m.projects = []domain.WorkshopProject{p}
+
+	m = deleteFirstProject(t, m)
+	f.Deleted = nil // the server kept nothing to restore
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m, _ = m.Update(cmd())
+	if len(m.projects) != 1 || m.projects[0].Name != "grimora" || m.projects[0].URL != p.URL {
+		t.Fatalf("projects = %+v, want grimora recreated with its link", m.projects)
+	}
+	if !strings.Contains(m.statusMsg, "timeline couldn't be recovered") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+}
+
+func TestUndoWindowCloses(t *testing.T) {
+	p := makeTestProject("grimora", "a TUI")
+	f := &clienttest.Fake{Projects: []domain.WorkshopProject{p}}
+	m := newTestYouModel()
+	m.client = f
+	m.projects = []domain.WorkshopProject{p}
+	m = deleteFirstProject(t, m)
+
+	// A tick from an earlier delete leaves the window open.
+	m, _ = m.Update(undoExpiredMsg{gen: m.undo.gen - 1})
+	if !m.canUndo() {
+		t.Fatal("expected a stale tick to be ignored")
+	}
+	m, _ = m.Update(undoExpiredMsg{gen: m.undo.gen})
+	if m.canUndo() || strings.Contains(m.View(), "press u to undo") {
+		t.Error("expected the undo prompt to go once the window closes")
+	}
+
+	// A window that ran out while the tick went elsewhere is closed too.
+	m.undo = &projectUndo{project: p, expires: time.Now().Add(-time.Second)}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")}); cmd != nil {
+		t.Error("expected u to do nothing after the window")
+	}
+}
@@ -1,30 +1,52 @@
 package tui
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	glog "github.com/naveenspark/grimora/internal/log"
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/internal/version"
+	"github.com/naveenspark/grimora/pkg/client"
 )
 
+// latestReleaseURL is GitHub's endpoint for the newest stable release.
+const latestReleaseURL = version.ReleasesURL + "/latest"
+
+// updateCheckInterval is how often a running TUI looks at whether the daily
+// update check is due.
+const updateCheckInterval = time.Hour
+
 // versionCheckMsg carries the result of a background GitHub release check.
+// ok is false when GitHub couldn't be reached, so the check is retried.
 type versionCheckMsg struct {
 	latestVersion string
 	hasUpdate     bool
+	ok            bool
 }
 
+// updateCheckTickMsg wakes the app to see if the daily update check is due.
+type updateCheckTickMsg struct{}
+
 // checkVersion fires a non-blocking HTTP request to GitHub to see if a newer
 // CLI release exists. Returns a no-op message when version is "dev".
 func checkVersion(current string) tea.Cmd {
 	if current == "" || current == "dev" {
 		return nil
 	}
+	return checkVersionAt(latestReleaseURL, current)
+}
+
+// checkVersionAt is checkVersion against the release endpoint at url.
+func checkVersionAt(url, current string) tea.Cmd {
 	return func() tea.Msg {
 		client := &http.Client{Timeout: 5 * time.Second}
-		resp, err := client.Get("https://api.github.com/repos/naveenspark/grimora/releases/latest")
+		resp, err := client.Get(url)
 		if err != nil {
 			return versionCheckMsg{}
 		}
@@ -35,46 +57,119 @@ func checkVersion(current string) tea.Cmd {
 		var release struct {
 			TagName string `json:"tag_name"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		if err := json.NewDecoder(resp.Body).Decode(&release); err != nil || release.TagName == "" {
 			return versionCheckMsg{}
 		}
 		latest := strings.TrimPrefix(release.TagName, "v")
-		if isNewerVersion(latest, current) {
-			return versionCheckMsg{latestVersion: "v" + latest, hasUpdate: true}
-		}
-		return versionCheckMsg{}
+		return versionCheckMsg{latestVersion: "v" + latest, hasUpdate: version.Newer(latest, current), ok: true}
 	}
 }
 
-// isNewerVersion returns true if latest is a newer semver than current.
-// Copied from cmd/grimora/main.go (can't import main package).
-func isNewerVersion(latest, current string) bool {
-	parse := func(v string) (int, int, int) {
-		v = strings.TrimPrefix(v, "v")
-		parts := strings.SplitN(v, ".", 3)
-		atoi := func(s string) int {
-			n, _ := strconv.Atoi(s) //nolint:errcheck
-			return n
-		}
-		var maj, min, patch int
-		if len(parts) > 0 {
-			maj = atoi(parts[0])
-		}
-		if len(parts) > 1 {
-			min = atoi(parts[1])
-		}
-		if len(parts) > 2 {
-			patch = atoi(parts[2])
+func updateCheckTickCmd() tea.Cmd {
+	return tea.Tick(updateCheckInterval, func(time.Time) tea.Msg { return updateCheckTickMsg{} })
+}
+
+// WithUpdateCheck turns on the daily update check, remembering when it last
+// ran in the state file at path. A newer release found by an earlier check
+// shows straight away unless it was dismissed.
+func (a App) WithUpdateCheck(path string, st state.State) App {
+	a.updateCheck = true
+	a.statePath = path
+	a.state = st
+	if st.LatestVersion != st.DismissedVersion && version.Newer(st.LatestVersion, a.currentVersion) {
+		a.latestVersion = st.LatestVersion
+		a.updateAvailable = true
+	}
+	return a
+}
+
+// updateCheckDue returns the version check if the daily check is on and a
+// day has passed since the last one, or nil.
+func (a App) updateCheckDue() tea.Cmd {
+	if !a.updateCheck || !a.state.UpdateCheckDue(time.Now()) {
+		return nil
+	}
+	return checkVersion(a.currentVersion)
+}
+
+// recordVersionCheck remembers a successful check and shows the banner if
+// the release is newer and hasn't been dismissed.
+func (a App) recordVersionCheck(msg versionCheckMsg) (App, tea.Cmd) {
+	if !msg.ok {
+		return a, nil
+	}
+	a.state.UpdateCheckedAt = time.Now()
+	a.state.LatestVersion = msg.latestVersion
+	if msg.hasUpdate && msg.latestVersion != a.state.DismissedVersion {
+		a.latestVersion = msg.latestVersion
+		a.updateAvailable = true
+	}
+	return a, saveStateCmd(a.statePath, a.state)
+}
+
+// dismissUpdate hides the update banner until a newer release comes out.
+func (a App) dismissUpdate() (App, tea.Cmd) {
+	a.updateAvailable = false
+	a.state.DismissedVersion = a.latestVersion
+	return a, saveStateCmd(a.statePath, a.state)
+}
+
+// updateBanner is the header notice for an available release.
+func (a App) updateBanner() string {
+	return accentStyle.Render("↑ "+a.latestVersion+" available — run grimora update") + dimStyle.Render(" · U dismiss")
+}
+
+// saveStateCmd writes st to path in the background. Failures only cost a
+// repeated check or banner, so they are logged and otherwise ignored.
+func saveStateCmd(path string, st state.State) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := state.Save(path, st); err != nil {
+			glog.Warn("save state failed", "err", err)
 		}
-		return maj, min, patch
+		return nil
+	}
+}
+
+// serverInfoMsg carries the server's side of the version handshake.
+type serverInfoMsg struct {
+	info *client.ServerInfo
+	err  error
+}
+
+// checkServer asks the server which API version it speaks.
+func checkServer(c client.API) tea.Cmd {
+	return func() tea.Msg {
+		info, err := c.GetServerInfo(context.Background())
+		return serverInfoMsg{info: info, err: err}
 	}
-	lMaj, lMin, lPatch := parse(latest)
-	cMaj, cMin, cPatch := parse(current)
-	if lMaj != cMaj {
-		return lMaj > cMaj
+}
+
+// serverNewer reports whether the server is ahead of this CLI: it speaks a
+// newer API, or wants a newer release than current.
+func serverNewer(info *client.ServerInfo, current string) bool {
+	if info == nil {
+		return false
 	}
-	if lMin != cMin {
-		return lMin > cMin
+	return info.Newer() || (info.MinClient != "" && version.Newer(info.MinClient, current))
+}
+
+// recordServerInfo shows the server banner when the server is ahead.
+// Servers from before the handshake don't answer, and are never ahead.
+func (a App) recordServerInfo(msg serverInfoMsg) App {
+	if msg.err != nil {
+		if !client.IsNotFound(msg.err) {
+			glog.Warn("server version check failed", "err", msg.err)
+		}
+		return a
 	}
-	return lPatch > cPatch
+	a.serverAhead = serverNewer(msg.info, a.currentVersion)
+	return a
+}
+
+// serverBanner is the header notice for a server that is ahead of the CLI.
+func (a App) serverBanner() string {
+	return goldStyle.Render("⚠ the server is newer than this grimora — some things may not show · run grimora update")
 }
@@ -4,40 +4,17 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-)
 
-func TestIsNewerVersion(t *testing.T) {
-	tests := []struct {
-		latest  string
-		current string
-		want    bool
-	}{
-		{"1.0.1", "1.0.0", true},
-		{"1.1.0", "1.0.0", true},
-		{"2.0.0", "1.9.9", true},
-		{"v1.0.1", "v1.0.0", true},
-		{"1.0.0", "1.0.0", false},
-		{"1.0.0", "1.0.1", false},
-		{"0.9.0", "1.0.0", false},
-		{"dev", "dev", false},
-		{"abc", "def", false},
-		{"v0.5.0", "0.4.2", true},
-		{"0.4.2", "v0.5.0", false},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.latest+"_vs_"+tc.current, func(t *testing.T) {
-			got := isNewerVersion(tc.latest, tc.current)
-			if got != tc.want {
-				t.Errorf("isNewerVersion(%q, %q) = %v, want %v", tc.latest, tc.current, got, tc.want)
-			}
-		})
-	}
-}
+	"github.com/naveenspark/grimora/internal/state"
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/client/clienttest"
+)
 
 func TestCheckVersion(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -46,10 +23,8 @@ func TestCheckVersion(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	// We can't easily override the URL in checkVersion, so test the
-	// components individually. checkVersion is a thin wrapper around
-	// isNewerVersion + HTTP fetch — the HTTP fetch is tested via
-	// TestCheckVersionIntegration below using a custom command.
+	// checkVersion is checkVersionAt pointed at GitHub; the HTTP side is
+	// tested against a mock server below.
 
 	// Test that checkVersion returns nil for dev builds.
 	cmd := checkVersion("dev")
@@ -72,7 +47,7 @@ func TestCheckVersionMockServer(t *testing.T) {
 	defer srv.Close()
 
 	// Build a command that hits the mock server instead of GitHub.
-	cmd := checkVersionFromURL(srv.URL, "0.4.0")
+	cmd := checkVersionAt(srv.URL, "0.4.0")
 	msg := cmd().(versionCheckMsg)
 	if !msg.hasUpdate {
 		t.Error("expected hasUpdate=true for 0.5.0 > 0.4.0")
@@ -89,7 +64,7 @@ func TestCheckVersionMockServerNoUpdate(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cmd := checkVersionFromURL(srv.URL, "0.4.0")
+	cmd := checkVersionAt(srv.URL, "0.4.0")
 	msg := cmd().(versionCheckMsg)
 	if msg.hasUpdate {
 		t.Error("expected hasUpdate=false for same version")
@@ -102,35 +77,144 @@ func TestCheckVersionMockServer404(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	cmd := checkVersionFromURL(srv.URL, "0.4.0")
+	cmd := checkVersionAt(srv.URL, "0.4.0")
 	msg := cmd().(versionCheckMsg)
 	if msg.hasUpdate {
 		t.Error("expected hasUpdate=false on 404")
 	}
 }
 
-// checkVersionFromURL is a test helper that lets us point at a mock server.
-func checkVersionFromURL(url, current string) tea.Cmd {
-	return func() tea.Msg {
-		client := &http.Client{}
-		resp, err := client.Get(url)
-		if err != nil {
-			return versionCheckMsg{}
-		}
-		defer resp.Body.Close() //nolint:errcheck
-		if resp.StatusCode != http.StatusOK {
-			return versionCheckMsg{}
-		}
-		var release struct {
-			TagName string `json:"tag_name"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-			return versionCheckMsg{}
-		}
-		latest := strings.TrimPrefix(release.TagName, "v")
-		if isNewerVersion(latest, current) {
-			return versionCheckMsg{latestVersion: "v" + latest, hasUpdate: true}
+func TestCheckVersionMockServerOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"tag_name": "v0.4.0"}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	msg := checkVersionAt(srv.URL, "0.4.0")().(versionCheckMsg)
+	if !msg.ok || msg.latestVersion != "v0.4.0" {
+		t.Errorf("got %+v, want ok with latestVersion v0.4.0", msg)
+	}
+
+	srv.Close()
+	if msg := checkVersionAt(srv.URL, "0.4.0")().(versionCheckMsg); msg.ok {
+		t.Error("expected ok=false when GitHub is unreachable")
+	}
+}
+
+func TestUpdateCheckDue(t *testing.T) {
+	a := NewApp(nil, "v0.4.0")
+	if a.updateCheckDue() != nil {
+		t.Error("expected no update check unless WithUpdateCheck turned it on")
+	}
+	a = a.WithUpdateCheck("", state.State{})
+	if a.updateCheckDue() == nil {
+		t.Error("expected an update check when never checked before")
+	}
+	a = a.WithUpdateCheck("", state.State{UpdateCheckedAt: time.Now().Add(-time.Hour)})
+	if a.updateCheckDue() != nil {
+		t.Error("expected no update check within a day of the last one")
+	}
+}
+
+func TestWithUpdateCheckShowsCachedRelease(t *testing.T) {
+	a := NewApp(nil, "v0.4.0").WithUpdateCheck("", state.State{LatestVersion: "v0.5.0"})
+	if !a.updateAvailable || a.latestVersion != "v0.5.0" {
+		t.Errorf("expected cached v0.5.0 banner, got %v %q", a.updateAvailable, a.latestVersion)
+	}
+	a = NewApp(nil, "v0.4.0").WithUpdateCheck("", state.State{LatestVersion: "v0.5.0", DismissedVersion: "v0.5.0"})
+	if a.updateAvailable {
+		t.Error("expected dismissed release to stay hidden")
+	}
+	a = NewApp(nil, "v0.5.0").WithUpdateCheck("", state.State{LatestVersion: "v0.5.0"})
+	if a.updateAvailable {
+		t.Error("expected no banner once up to date")
+	}
+}
+
+func TestRecordVersionCheckSavesState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	a := newTestApp().WithUpdateCheck(path, state.State{})
+	a, cmd := a.recordVersionCheck(versionCheckMsg{latestVersion: "v0.5.0", hasUpdate: true, ok: true})
+	if !a.updateAvailable {
+		t.Fatal("expected banner after finding a newer release")
+	}
+	if cmd == nil {
+		t.Fatal("expected a state save")
+	}
+	cmd()
+	st, err := state.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if st.LatestVersion != "v0.5.0" || st.UpdateCheckDue(time.Now()) {
+		t.Errorf("saved state = %+v", st)
+	}
+
+	// A failed check leaves the state alone so it's retried.
+	b := newTestApp().WithUpdateCheck(path, state.State{})
+	if _, cmd := b.recordVersionCheck(versionCheckMsg{}); cmd != nil {
+		t.Error("expected no save after a failed check")
+	}
+}
+
+func TestUpdateBannerDismiss(t *testing.T) {
+	a := newTestApp().WithUpdateCheck("", state.State{})
+	a, _ = a.recordVersionCheck(versionCheckMsg{latestVersion: "v0.5.0", hasUpdate: true, ok: true})
+	if !strings.Contains(a.View(), "v0.5.0 available — run grimora update") {
+		t.Fatal("expected update banner in header")
+	}
+
+	a.hall.inputFocused = false
+	m, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	a = m.(App)
+	if a.updateAvailable {
+		t.Error("expected U to dismiss the banner")
+	}
+	if a.state.DismissedVersion != "v0.5.0" {
+		t.Errorf("DismissedVersion = %q, want v0.5.0", a.state.DismissedVersion)
+	}
+
+	// The same release found again stays dismissed.
+	a, _ = a.recordVersionCheck(versionCheckMsg{latestVersion: "v0.5.0", hasUpdate: true, ok: true})
+	if a.updateAvailable {
+		t.Error("expected dismissed release to stay hidden")
+	}
+}
+
+func TestServerNewer(t *testing.T) {
+	tests := []struct {
+		name string
+		info *client.ServerInfo
+		want bool
+	}{
+		{"no handshake", nil, false},
+		{"same API", &client.ServerInfo{APIVersion: client.APIVersion}, false},
+		{"newer API", &client.ServerInfo{APIVersion: client.APIVersion + 1}, true},
+		{"wants newer CLI", &client.ServerInfo{APIVersion: client.APIVersion, MinClient: "0.9.0"}, true},
+		{"CLI new enough", &client.ServerInfo{APIVersion: client.APIVersion, MinClient: "0.3.0"}, false},
+	}
+	for _, tt := range tests {
+		if got := serverNewer(tt.info, "0.5.0"); got != tt.want {
+			t.Errorf("%s: serverNewer = %v, want %v", tt.name, got, tt.want)
 		}
-		return versionCheckMsg{}
+	}
+}
+
+func TestServerBannerShown(t *testing.T) {
+	f := &clienttest.Fake{Server: &client.ServerInfo{APIVersion: client.APIVersion + 1}}
+	a := NewApp(f, "0.5.0")
+	a.width, a.height = 160, 30
+	a = a.recordServerInfo(checkServer(f)().(serverInfoMsg))
+	if !a.serverAhead {
+		t.Fatal("expected a newer server to be flagged")
+	}
+	if !strings.Contains(a.View(), "the server is newer than this grimora") {
+		t.Error("expected the server banner in the header")
+	}
+
+	// Servers without the handshake are never ahead.
+	old := NewApp(&clienttest.Fake{}, "0.5.0")
+	if old = old.recordServerInfo(checkServer(old.client)().(serverInfoMsg)); old.serverAhead {
+		t.Error("a server without /api/version should not be flagged")
 	}
 }
@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// watchPollInterval is how often watched spells and seeks are checked for
+// new comments, variants and answers.
+const watchPollInterval = 60 * time.Second
+
+type watchTickMsg struct{}
+
+func watchTickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg { return watchTickMsg{} })
+}
+
+// subscriptionsLoadedMsg carries the caller's watched items.
+type subscriptionsLoadedMsg struct {
+	subs []domain.Subscription
+	err  error
+}
+
+// watchResultMsg carries the result of watching or unwatching a spell or
+// seek. The App hands it to every model that shows watch state.
+type watchResultMsg struct {
+	targetType string
+	targetID   string
+	watching   bool
+	err        error
+}
+
+// subscriptionReadMsg carries the result of marking a watched item read.
+type subscriptionReadMsg struct {
+	err error
+}
+
+func loadSubscriptions(c client.API) tea.Cmd {
+	return func() tea.Msg {
+		subs, err := c.ListSubscriptions(context.Background())
+		return subscriptionsLoadedMsg{subs: subs, err: err}
+	}
+}
+
+// watchCmd subscribes to (or, with watch false, unsubscribes from) a target.
+func watchCmd(c client.API, targetType, targetID string, watch bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if watch {
+			_, err = c.Watch(context.Background(), targetType, targetID)
+		} else {
+			err = c.Unwatch(context.Background(), targetType, targetID)
+		}
+		return watchResultMsg{targetType: targetType, targetID: targetID, watching: watch, err: err}
+	}
+}
+
+// newWatchActivity returns the subscriptions in next whose unread count grew
+// since prev.
+func newWatchActivity(prev, next []domain.Subscription) []domain.Subscription {
+	seen := make(map[string]int, len(prev))
+	for _, s := range prev {
+		seen[s.TargetType+"/"+s.TargetID] = s.Unread
+	}
+	var fresh []domain.Subscription
+	for _, s := range next {
+		if s.Unread > seen[s.TargetType+"/"+s.TargetID] {
+			fresh = append(fresh, s)
+		}
+	}
+	return fresh
+}
+
+// watchUnread totals the unread activity across subscriptions.
+func watchUnread(subs []domain.Subscription) int {
+	n := 0
+	for _, s := range subs {
+		n += s.Unread
+	}
+	return n
+}
+
+// watchAlertReason describes fresh activity for the alert flash.
+func watchAlertReason(fresh []domain.Subscription) string {
+	if len(fresh) == 1 {
+		return fmt.Sprintf("new activity on %s: %s", fresh[0].TargetType, truncStr(fresh[0].Title, 40))
+	}
+	return fmt.Sprintf("new activity on %d watched items", len(fresh))
+}
+
+// handleSubscriptions updates the watched list and alerts on new activity.
+// The first load only establishes the baseline.
+func (a App) handleSubscriptions(msg subscriptionsLoadedMsg) (App, tea.Cmd) {
+	if msg.err != nil {
+		return a, nil
+	}
+	var fresh []domain.Subscription
+	if a.you.subsLoaded {
+		fresh = newWatchActivity(a.you.subs, msg.subs)
+	}
+	a.you, _ = a.you.Update(msg)
+	if len(fresh) == 0 {
+		return a, nil
+	}
+	return a, alertCmd(watchAlertReason(fresh))
+}
+
+// handleKeyWatching handles keys on the You tab's watched list.
+func (m youModel) handleKeyWatching(msg tea.KeyMsg) (youModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "backspace", "w":
+		m.watchOpen = false
+	case "j", "down":
+		if m.watchCursor < len(m.subs)-1 {
+			m.watchCursor++
+		}
+	case "k", "up":
+		if m.watchCursor > 0 {
+			m.watchCursor--
+		}
+	case "enter":
+		if m.watchCursor < len(m.subs) && m.subs[m.watchCursor].Unread > 0 {
+			sub := m.subs[m.watchCursor]
+			m.subs[m.watchCursor].Unread = 0
+			c := m.client
+			return m, func() tea.Msg {
+				err := c.MarkSubscriptionRead(context.Background(), sub.TargetType, sub.TargetID)
+				return subscriptionReadMsg{err: err}
+			}
+		}
+	case "x":
+		if m.watchCursor < len(m.subs) {
+			sub := m.subs[m.watchCursor]
+			m.unwatchPending = sub.TargetID
+			return m, watchCmd(m.client, sub.TargetType, sub.TargetID, false)
+		}
+	case "r":
+		if m.client != nil {
+			return m, loadSubscriptions(m.client)
+		}
+	}
+	return m, nil
+}
+
+// viewWatching renders the watched spells and seeks, unread first in the
+// order the server sends them.
+func (m youModel) viewWatching() string {
+	var sb strings.Builder
+	sb.WriteString("\n " + sectionHeaderStyle.Render(fmt.Sprintf("── WATCHING %d ──", len(m.subs))) + "\n")
+	if len(m.subs) == 0 {
+		sb.WriteString("   " + dimStyle.Render("nothing watched · W on a spell or seek to follow it") + "\n")
+		return sb.String()
+	}
+	titleW := max(m.width-24, 20)
+	for i, s := range m.subs {
+		cursor := "  "
+		titleStyle := dimStyle
+		if i == m.watchCursor {
+			cursor = accentStyle.Render("▸") + " "
+			titleStyle = normalStyle.Bold(true)
+		}
+		line := " " + cursor + metaStyle.Render(fmt.Sprintf("%-5s", s.TargetType)) + " " + titleStyle.Render(truncStr(s.Title, titleW))
+		if s.Unread > 0 {
+			line += "  " + goldStyle.Render(fmt.Sprintf("%d new", s.Unread))
+		}
+		sb.WriteString(line + "\n")
+		if s.LastActivity != "" {
+			activity := "     " + dimStyle.Render("└ "+truncStr(s.LastActivity, titleW))
+			if s.LastActivityAt != nil {
+				activity += metaStyle.Render(" · " + formatTime(*s.LastActivityAt))
+			}
+			sb.WriteString(activity + "\n")
+		}
+	}
+	return sb.String()
+}
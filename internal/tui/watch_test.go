@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func makeTestSubscription(kind, id, title string, unread int) domain.Subscription {
+	return domain.Subscription{TargetType: kind, TargetID: id, Title: title, Unread: unread}
+}
+
+func TestNewWatchActivity(t *testing.T) {
+	prev := []domain.Subscription{
+		makeTestSubscription("spell", "s1", "parsers", 1),
+		makeTestSubscription("seek", "m1", "tui tests?", 0),
+	}
+	next := []domain.Subscription{
+		makeTestSubscription("spell", "s1", "parsers", 1),
+		makeTestSubscription("seek", "m1", "tui tests?", 2),
+		makeTestSubscription("spell", "s2", "new watch", 1),
+	}
+	fresh := newWatchActivity(prev, next)
+	if len(fresh) != 2 || fresh[0].TargetID != "m1" || fresh[1].TargetID != "s2" {
+		t.Errorf("fresh = %+v", fresh)
+	}
+	if got := watchUnread(next); got != 4 {
+		t.Errorf("watchUnread = %d, want 4", got)
+	}
+}
+
+func TestAppSubscriptionsAlertOnlyAfterBaseline(t *testing.T) {
+	a := newTestApp()
+	subs := []domain.Subscription{makeTestSubscription("seek", "m1", "tui tests?", 1)}
+
+	a, cmd := a.handleSubscriptions(subscriptionsLoadedMsg{subs: subs})
+	if cmd != nil {
+		t.Error("expected no alert on the first load")
+	}
+	if !a.you.subsLoaded || len(a.you.subs) != 1 {
+		t.Fatalf("expected subscriptions handed to You, got %+v", a.you.subs)
+	}
+
+	subs = []domain.Subscription{makeTestSubscription("seek", "m1", "tui tests?", 3)}
+	_, cmd = a.handleSubscriptions(subscriptionsLoadedMsg{subs: subs})
+	if cmd == nil {
+		t.Fatal("expected an alert for new activity")
+	}
+	if am, ok := cmd().(alertMsg); !ok || !strings.Contains(am.reason, "tui tests?") {
+		t.Errorf("alert = %+v", am)
+	}
+}
+
+func TestAppTabBarShowsWatchUnread(t *testing.T) {
+	a := newTestApp()
+	a, _ = a.handleSubscriptions(subscriptionsLoadedMsg{subs: []domain.Subscription{
+		makeTestSubscription("spell", "s1", "parsers", 2),
+	}})
+	if !strings.Contains(a.View(), "✦2") {
+		t.Error("expected the You tab to show the unread count")
+	}
+}
+
+func TestYouWatchingList(t *testing.T) {
+	m := newTestYouModel()
+	m, _ = m.Update(subscriptionsLoadedMsg{subs: []domain.Subscription{
+		makeTestSubscription("spell", "s1", "parsers", 2),
+		makeTestSubscription("seek", "m1", "tui tests?", 0),
+	}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if !m.watchOpen {
+		t.Fatal("expected w to open the watched list")
+	}
+	view := m.View()
+	for _, want := range []string{"WATCHING 2", "parsers", "2 new", "tui tests?"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in watched list, got:\n%s", want, view)
+		}
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.subs[0].Unread != 0 {
+		t.Errorf("expected enter to mark the item read, unread = %d", m.subs[0].Unread)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected x to unwatch")
+	}
+	m, _ = m.Update(watchResultMsg{targetType: "seek", targetID: "m1"})
+	if len(m.subs) != 1 || m.watchCursor != 0 {
+		t.Errorf("expected the seek removed and cursor clamped, got %+v cursor %d", m.subs, m.watchCursor)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.watchOpen {
+		t.Error("expected esc to close the watched list")
+	}
+}
+
+func TestWatchFailuresReportedWhereMade(t *testing.T) {
+	fail := errors.New("boom")
+	y := newTestYouModel()
+	y, _ = y.Update(subscriptionsLoadedMsg{subs: []domain.Subscription{makeTestSubscription("spell", "s1", "parsers", 0)}})
+
+	// A watch made in the Grimoire failing says nothing in You.
+	y, _ = y.Update(watchResultMsg{targetType: "spell", targetID: "s2", watching: true, err: fail})
+	if y.statusMsg != "" {
+		t.Errorf("You status = %q after a Grimoire watch failed", y.statusMsg)
+	}
+	y.watchOpen = true
+	y, _ = y.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	y, _ = y.Update(watchResultMsg{targetType: "spell", targetID: "s1", err: fail})
+	if !strings.Contains(y.statusMsg, "unwatch failed") || len(y.subs) != 1 {
+		t.Errorf("status = %q, subs = %d", y.statusMsg, len(y.subs))
+	}
+
+	// An unwatch from You failing says nothing in the Grimoire.
+	g := newTestGrimoireModel()
+	g, _ = g.Update(watchResultMsg{targetType: domain.WatchTargetSpell, targetID: "s1", err: fail})
+	if g.statusMsg != "" {
+		t.Errorf("Grimoire status = %q after an unwatch in You failed", g.statusMsg)
+	}
+}
+
+func TestGrimoireWatchToggle(t *testing.T) {
+	m := newTestGrimoireModel()
+	spell := makeTestSpell("watch me", "testing")
+	m.spells = []domain.Spell{spell}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	if cmd == nil {
+		t.Fatal("expected W to watch the selected spell")
+	}
+	m, _ = m.Update(watchResultMsg{targetType: domain.WatchTargetSpell, targetID: spell.ID.String(), watching: true})
+	if !m.spells[0].Watching {
+		t.Error("expected spell marked as watching")
+	}
+	if !strings.Contains(m.statusMsg, "watching") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	// An unwatch from the You tab updates state without a Grimoire status.
+	m.statusMsg = ""
+	m, _ = m.Update(watchResultMsg{targetType: domain.WatchTargetSpell, targetID: spell.ID.String()})
+	if m.spells[0].Watching || m.statusMsg != "" {
+		t.Errorf("watching = %v, status = %q", m.spells[0].Watching, m.statusMsg)
+	}
+}
+
+func TestHallWatchOnlySeeks(t *testing.T) {
+	m := newTestHallSelectModel("plain", "how do I test TUIs?")
+	m.messages[1].Kind = "seek"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	if cmd == nil {
+		t.Fatal("expected W on a seek to watch it")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	if cmd != nil || !strings.Contains(m.status, "only seeks") {
+		t.Errorf("expected plain messages to be rejected, status = %q", m.status)
+	}
+}
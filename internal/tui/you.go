@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/naveenspark/grimora/internal/journal"
 	"github.com/naveenspark/grimora/pkg/client"
 	"github.com/naveenspark/grimora/pkg/domain"
 )
@@ -21,6 +22,9 @@ const (
 	wsEditing                // editing insight of selected project
 	wsAdding                 // adding new project (name + insight fields)
 	wsDeleting               // delete confirmation
+	wsDetail                 // project detail screen
+	wsPosting                // writing an update or ship note from the detail screen
+	wsLinking                // editing the repo URL from the detail screen
 )
 
 // -- messages --
@@ -32,6 +36,11 @@ type youInvitesLoadedMsg struct {
 
 type youCopyMsg struct{ err error }
 
+type youInviteProgressMsg struct {
+	progress *domain.InviteProgress
+	err      error
+}
+
 type workshopLoadedMsg struct {
 	projects []domain.WorkshopProject
 	err      error
@@ -66,10 +75,15 @@ const (
 )
 
 // inviteSpellThreshold is the number of forged spells required for the next invite code.
+// Used only when the server doesn't report invite progress.
 const inviteSpellThreshold = 10
 
+// inviteBarWidth is the number of cells in the invite progress bar.
+const inviteBarWidth = 20
+
 type youModel struct {
-	client     *client.Client
+	client     client.API
+	journal    *journal.Journal // copy of workshop edits; nil keeps none
 	invites    []domain.Invite
 	me         *domain.Magician
 	forgeStats *domain.ForgeStats
@@ -83,20 +97,43 @@ type youModel struct {
 	projectUpdates map[string][]domain.ProjectUpdate
 	wsCursor       int
 	wsState        workshopState
-	wsAddName      string // name field when adding/editing
-	wsAddInsight   string // insight field when adding/editing
-	wsAddFocus     int    // 0=name, 1=insight
+	wsAddName      string       // name field when adding/editing
+	wsAddInsight   string       // insight field when adding/editing
+	wsAddFocus     int          // 0=name, 1=insight
+	wsAddCursor    editCursor   // in the focused field
+	wsDraft        string       // update body or repo URL on the detail screen
+	postKind       string       // "update" or "ship" while posting
+	detailScroll   int          // first visible timeline line on the detail screen
+	undo           *projectUndo // last deleted project, while it can be restored
 
 	// invites
-	inviteCursor int
+	inviteCursor   int
+	inviteProgress *domain.InviteProgress
+
+	// forge analytics screen
+	statsOpen    bool
+	statsLoading bool
+	statsErr     error
+	history      *domain.ForgeHistory
+
+	// profile editing
+	profileOpen bool
+	profile     profileForm
+
+	// watched spells and seeks
+	watchOpen      bool
+	watchCursor    int
+	subs           []domain.Subscription
+	subsLoaded     bool
+	unwatchPending string // target ID of an in-flight unwatch from this list
 }
 
-func newYouModel(c *client.Client) youModel {
+func newYouModel(c client.API) youModel {
 	return youModel{client: c, projectUpdates: make(map[string][]domain.ProjectUpdate)}
 }
 
 func (m youModel) Init() tea.Cmd {
-	return tea.Batch(m.loadInvites(), m.loadWorkshop())
+	return tea.Batch(m.loadInvites(), m.loadInviteProgress(), m.loadWorkshop())
 }
 
 func (m youModel) loadInvites() tea.Cmd {
@@ -107,6 +144,14 @@ func (m youModel) loadInvites() tea.Cmd {
 	}
 }
 
+func (m youModel) loadInviteProgress() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		p, err := c.GetInviteProgress(context.Background())
+		return youInviteProgressMsg{progress: p, err: err}
+	}
+}
+
 func (m youModel) loadWorkshop() tea.Cmd {
 	c := m.client
 	return func() tea.Msg {
@@ -131,6 +176,13 @@ func (m youModel) Update(msg tea.Msg) (youModel, tea.Cmd) {
 		}
 		return m, nil
 
+	case youInviteProgressMsg:
+		// On error keep whatever we had; the view falls back to forge stats.
+		if msg.err == nil && msg.progress != nil {
+			m.inviteProgress = msg.progress
+		}
+		return m, nil
+
 	case meLoadedMsg:
 		if msg.err == nil && msg.me != nil {
 			m.me = msg.me
@@ -138,6 +190,56 @@ func (m youModel) Update(msg tea.Msg) (youModel, tea.Cmd) {
 		m.forgeStats = msg.stats
 		return m, nil
 
+	case forgeHistoryMsg:
+		m.statsLoading = false
+		m.statsErr = msg.err
+		if msg.err == nil && msg.history != nil {
+			m.history = msg.history
+		}
+		return m, nil
+
+	case subscriptionsLoadedMsg:
+		if msg.err == nil {
+			m.subs = msg.subs
+			m.subsLoaded = true
+			if m.watchCursor >= len(m.subs) {
+				m.watchCursor = max(len(m.subs)-1, 0)
+			}
+		}
+		return m, nil
+
+	case subscriptionReadMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("mark read failed", msg.err)
+		}
+		return m, nil
+
+	case watchResultMsg:
+		// Only report failures of unwatches made here; the Grimoire and the
+		// Hall report their own.
+		mine := !msg.watching && m.unwatchPending == msg.targetID
+		if mine {
+			m.unwatchPending = ""
+		}
+		if msg.err != nil {
+			if mine {
+				m.statusMsg = errText("unwatch failed", msg.err)
+			}
+			return m, nil
+		}
+		if !msg.watching {
+			for i, s := range m.subs {
+				if s.TargetType == msg.targetType && s.TargetID == msg.targetID {
+					m.subs = append(m.subs[:i], m.subs[i+1:]...)
+					break
+				}
+			}
+			if m.watchCursor >= len(m.subs) && m.watchCursor > 0 {
+				m.watchCursor--
+			}
+		}
+		return m, nil
+
 	case workshopLoadedMsg:
 		if msg.err == nil {
 			m.projects = msg.projects
@@ -160,12 +262,57 @@ func (m youModel) Update(msg tea.Msg) (youModel, tea.Cmd) {
 	case projectUpdatesMsg:
 		if msg.err == nil {
 			m.projectUpdates[msg.projectID] = msg.updates
+			// A refreshed timeline on the detail screen lands on the latest entry.
+			if proj, ok := m.selectedProject(); ok && m.wsState == wsDetail && proj.ID.String() == msg.projectID {
+				m.detailScroll = m.maxDetailScroll()
+			}
+		}
+		return m, nil
+
+	case projectUpdateCreatedMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("post failed", msg.err)
+			return m, nil
+		}
+		if msg.update != nil {
+			m.projectUpdates[msg.projectID] = append(m.projectUpdates[msg.projectID], *msg.update)
+			if msg.update.Kind == "ship" {
+				m.statusMsg = "shipped!"
+			} else {
+				m.statusMsg = "update posted"
+			}
 		}
+		m.wsState = wsDetail
+		m.wsDraft = ""
+		m.detailScroll = m.maxDetailScroll()
+		return m, nil
+
+	case workshopURLSetMsg:
+		if msg.err != nil {
+			m.statusMsg = errText("link failed", msg.err)
+			return m, nil
+		}
+		for i := range m.projects {
+			if m.projects[i].ID.String() == msg.id {
+				m.projects[i].URL = msg.url
+			}
+		}
+		if msg.url == "" {
+			m.statusMsg = "repo unlinked"
+		} else {
+			m.statusMsg = "repo linked"
+		}
+		m.wsState = wsDetail
+		m.wsDraft = ""
+		return m, nil
+
+	case linkOpenedMsg:
+		m.statusMsg = linkStatus(msg)
 		return m, nil
 
 	case workshopCreatedMsg:
 		if msg.err != nil {
-			m.statusMsg = fmt.Sprintf("create failed: %v", msg.err)
+			m.statusMsg = errText("create failed", msg.err)
 		} else {
 			if msg.project != nil {
 				m.projects = append(m.projects, *msg.project)
@@ -177,11 +324,12 @@ func (m youModel) Update(msg tea.Msg) (youModel, tea.Cmd) {
 		m.wsAddName = ""
 		m.wsAddInsight = ""
 		m.wsAddFocus = 0
+		m.wsAddCursor = editCursor{}
 		return m, nil
 
 	case workshopUpdatedMsg:
 		if msg.err != nil {
-			m.statusMsg = fmt.Sprintf("update failed: %v", msg.err)
+			m.statusMsg = errText("update failed", msg.err)
 		} else {
 			m.statusMsg = "saved"
 		}
@@ -189,27 +337,41 @@ func (m youModel) Update(msg tea.Msg) (youModel, tea.Cmd) {
 		m.wsAddName = ""
 		m.wsAddInsight = ""
 		m.wsAddFocus = 0
+		m.wsAddCursor = editCursor{}
 		return m, nil
 
 	case workshopDeletedMsg:
+		m.wsState = wsNormal
 		if msg.err != nil {
-			m.statusMsg = fmt.Sprintf("delete failed: %v", msg.err)
-		} else {
-			// Remove from local slice
-			for i, p := range m.projects {
-				if p.ID.String() == msg.id {
-					m.projects = append(m.projects[:i], m.projects[i+1:]...)
-					break
-				}
-			}
-			if m.wsCursor >= len(m.projects) && m.wsCursor > 0 {
-				m.wsCursor = len(m.projects) - 1
+			m.statusMsg = errText("delete failed", msg.err)
+			return m, nil
+		}
+		// Remove from local slice
+		var cmd tea.Cmd
+		for i, p := range m.projects {
+			if p.ID.String() == msg.id {
+				m.projects = append(m.projects[:i], m.projects[i+1:]...)
+				m, cmd = m.offerUndo(p, i)
+				break
 			}
-			m.statusMsg = "project removed"
 		}
-		m.wsState = wsNormal
+		if m.wsCursor >= len(m.projects) && m.wsCursor > 0 {
+			m.wsCursor = len(m.projects) - 1
+		}
+		return m, cmd
+
+	case undoExpiredMsg:
+		if m.undo != nil && m.undo.gen == msg.gen {
+			m.undo = nil
+		}
 		return m, nil
 
+	case workshopRestoredMsg:
+		return m.projectRestored(msg)
+
+	case profileSavedMsg:
+		return m.profileSaved(msg), nil
+
 	case youCopyMsg:
 		if msg.err != nil {
 			m.statusMsg = fmt.Sprintf("copy failed: %v", msg.err)
@@ -231,6 +393,9 @@ func (m youModel) Update(msg tea.Msg) (youModel, tea.Cmd) {
 }
 
 func (m youModel) handleKey(msg tea.KeyMsg) (youModel, tea.Cmd) {
+	if m.profileOpen {
+		return m.handleKeyProfile(msg)
+	}
 	// Workshop state machine intercepts keys first when active
 	switch m.wsState {
 	case wsEditing:
@@ -239,6 +404,18 @@ func (m youModel) handleKey(msg tea.KeyMsg) (youModel, tea.Cmd) {
 		return m.handleKeyAdding(msg)
 	case wsDeleting:
 		return m.handleKeyDeleting(msg)
+	case wsDetail:
+		return m.handleKeyDetail(msg)
+	case wsPosting:
+		return m.handleKeyPosting(msg)
+	case wsLinking:
+		return m.handleKeyLinking(msg)
+	}
+	if m.statsOpen {
+		return m.handleKeyStats(msg)
+	}
+	if m.watchOpen {
+		return m.handleKeyWatching(msg)
 	}
 
 	// Normal mode
@@ -249,6 +426,12 @@ func (m youModel) handleKey(msg tea.KeyMsg) (youModel, tea.Cmd) {
 	case "k", "up":
 		m.navUp()
 
+	case "enter":
+		// Open the detail screen for the selected workshop project
+		if m.section == youSectionWorkshop {
+			return m.openProjectDetail()
+		}
+
 	case "e":
 		// Edit selected workshop project (name + insight)
 		if m.section == youSectionWorkshop && len(m.projects) > 0 && m.wsCursor < len(m.projects) {
@@ -256,6 +439,7 @@ func (m youModel) handleKey(msg tea.KeyMsg) (youModel, tea.Cmd) {
 			m.wsAddName = m.projects[m.wsCursor].Name
 			m.wsAddInsight = m.projects[m.wsCursor].Insight
 			m.wsAddFocus = 0
+			m.wsAddCursor = editCursor{}
 		}
 
 	case "a":
@@ -264,6 +448,12 @@ func (m youModel) handleKey(msg tea.KeyMsg) (youModel, tea.Cmd) {
 		m.wsAddName = ""
 		m.wsAddInsight = ""
 		m.wsAddFocus = 0
+		m.wsAddCursor = editCursor{}
+
+	case "u":
+		if m.canUndo() {
+			return m.undoDelete()
+		}
 
 	case "d":
 		// Delete selected workshop project
@@ -279,15 +469,27 @@ func (m youModel) handleKey(msg tea.KeyMsg) (youModel, tea.Cmd) {
 			idx = m.inviteCursor
 		}
 		if idx < len(avail) {
-			code := avail[idx].Code
+			link := avail[idx].JoinURL()
 			return m, func() tea.Msg {
-				err := clipboard.WriteAll("grimora.ai/join/" + code)
+				err := clipboard.WriteAll(link)
 				return youCopyMsg{err: err}
 			}
 		}
 
+	case "E":
+		return m.openProfile(), nil
+
+	case "f":
+		return m.openStats()
+
+	case "w":
+		m.watchOpen = true
+		if m.client != nil {
+			return m, loadSubscriptions(m.client)
+		}
+
 	case "r":
-		return m, tea.Batch(m.loadInvites(), m.loadWorkshop())
+		return m, tea.Batch(m.loadInvites(), m.loadInviteProgress(), m.loadWorkshop())
 	}
 	return m, nil
 }
@@ -296,6 +498,7 @@ func (m youModel) handleKeyEditing(msg tea.KeyMsg) (youModel, tea.Cmd) {
 	switch msg.String() {
 	case "tab":
 		m.wsAddFocus = 1 - m.wsAddFocus
+		m.wsAddCursor = editCursor{}
 	case "enter":
 		name := strings.TrimSpace(m.wsAddName)
 		if name == "" {
@@ -307,9 +510,12 @@ func (m youModel) handleKeyEditing(msg tea.KeyMsg) (youModel, tea.Cmd) {
 			insight := strings.TrimSpace(m.wsAddInsight)
 			m.projects[m.wsCursor].Name = name
 			m.projects[m.wsCursor].Insight = insight
-			c := m.client
+			c, j := m.client, m.journal
 			return m, func() tea.Msg {
 				err := c.UpdateWorkshopProject(context.Background(), id, name, insight)
+				if err == nil {
+					record(j, journal.Entry{Kind: journal.KindProject, ID: id, Text: projectJournalText(name, insight)})
+				}
 				return workshopUpdatedMsg{err: err}
 			}
 		}
@@ -319,11 +525,12 @@ func (m youModel) handleKeyEditing(msg tea.KeyMsg) (youModel, tea.Cmd) {
 		m.wsAddName = ""
 		m.wsAddInsight = ""
 		m.wsAddFocus = 0
+		m.wsAddCursor = editCursor{}
 	default:
 		if m.wsAddFocus == 0 {
-			m.wsAddName = editRune(m.wsAddName, msg.String())
+			m.wsAddName = m.wsAddCursor.edit(m.wsAddName, msg.String())
 		} else {
-			m.wsAddInsight = editRune(m.wsAddInsight, msg.String())
+			m.wsAddInsight = m.wsAddCursor.edit(m.wsAddInsight, msg.String())
 		}
 	}
 	return m, nil
@@ -333,6 +540,7 @@ func (m youModel) handleKeyAdding(msg tea.KeyMsg) (youModel, tea.Cmd) {
 	switch msg.String() {
 	case "tab":
 		m.wsAddFocus = 1 - m.wsAddFocus
+		m.wsAddCursor = editCursor{}
 	case "enter":
 		name := strings.TrimSpace(m.wsAddName)
 		insight := strings.TrimSpace(m.wsAddInsight)
@@ -340,9 +548,12 @@ func (m youModel) handleKeyAdding(msg tea.KeyMsg) (youModel, tea.Cmd) {
 			m.statusMsg = "name required"
 			return m, nil
 		}
-		c := m.client
+		c, j := m.client, m.journal
 		return m, func() tea.Msg {
 			project, err := c.CreateWorkshopProject(context.Background(), name, insight)
+			if err == nil {
+				record(j, journal.Entry{Kind: journal.KindProject, ID: project.ID.String(), Text: projectJournalText(name, insight)})
+			}
 			return workshopCreatedMsg{project: project, err: err}
 		}
 	case "esc":
@@ -350,11 +561,12 @@ func (m youModel) handleKeyAdding(msg tea.KeyMsg) (youModel, tea.Cmd) {
 		m.wsAddName = ""
 		m.wsAddInsight = ""
 		m.wsAddFocus = 0
+		m.wsAddCursor = editCursor{}
 	default:
 		if m.wsAddFocus == 0 {
-			m.wsAddName = editRune(m.wsAddName, msg.String())
+			m.wsAddName = m.wsAddCursor.edit(m.wsAddName, msg.String())
 		} else {
-			m.wsAddInsight = editRune(m.wsAddInsight, msg.String())
+			m.wsAddInsight = m.wsAddCursor.edit(m.wsAddInsight, msg.String())
 		}
 	}
 	return m, nil
@@ -428,17 +640,35 @@ func (m *youModel) navUp() {
 
 // helpKeys returns context-sensitive help text based on the current state.
 func (m youModel) helpKeys() string {
+	if m.profileOpen {
+		return helpEntry("tab", "next") + "  " + helpEntry("←/→", "archetype") + "  " + helpEntry("enter", "save") + "  " + helpEntry("esc", "cancel")
+	}
 	switch m.wsState {
 	case wsEditing, wsAdding:
 		return helpEntry("tab", "next") + "  " + helpEntry("enter", "save") + "  " + helpEntry("esc", "cancel")
 	case wsDeleting:
 		return helpEntry("y", "confirm") + "  " + helpEntry("n", "cancel")
+	case wsDetail:
+		return helpEntry("j/k", "scroll") + "  " + helpEntry("u", "post update") + "  " + helpEntry("s", "ship") + "  " + helpEntry("l", "link repo") + "  " + helpEntry("o", "open repo") + "  " + helpEntry("esc", "back")
+	case wsPosting:
+		return helpEntry("enter", "post") + "  " + helpEntry("esc", "cancel")
+	case wsLinking:
+		return helpEntry("enter", "save") + "  " + helpEntry("esc", "cancel")
 	default:
+		if m.statsOpen {
+			return helpEntry("r", "refresh") + "  " + helpEntry("esc", "back") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+		}
+		if m.watchOpen {
+			return helpEntry("j/k", "nav") + "  " + helpEntry("enter", "mark read") + "  " + helpEntry("x", "unwatch") + "  " + helpEntry("esc", "back")
+		}
 		switch m.section {
 		case youSectionInvites:
-			return helpEntry("j/k", "nav") + "  " + helpEntry("c", "copy link") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+			return helpEntry("j/k", "nav") + "  " + helpEntry("c", "copy link") + "  " + helpEntry("E", "profile") + "  " + helpEntry("f", "stats") + "  " + helpEntry("w", "watching") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
 		default:
-			return helpEntry("j/k", "nav") + "  " + helpEntry("e", "edit") + "  " + helpEntry("a", "add") + "  " + helpEntry("d", "remove") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+			if m.canUndo() {
+				return helpEntry("u", "undo remove") + "  " + helpEntry("j/k", "nav") + "  " + helpEntry("enter", "open") + "  " + helpEntry("e", "edit") + "  " + helpEntry("a", "add") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
+			}
+			return helpEntry("j/k", "nav") + "  " + helpEntry("enter", "open") + "  " + helpEntry("e", "edit") + "  " + helpEntry("a", "add") + "  " + helpEntry("d", "remove") + "  " + helpEntry("E", "profile") + "  " + helpEntry("f", "stats") + "  " + helpEntry("w", "watching") + "  " + helpEntry("h", "help") + "  " + helpEntry("q", "quit")
 		}
 	}
 }
@@ -451,6 +681,9 @@ func (m youModel) View() string {
 		// Emblem + login
 		emblem := GuildEmblem(m.me.GuildID)
 		nameStr := selectedStyle.Render(m.me.GitHubLogin)
+		if m.me.DisplayName != "" {
+			nameStr = selectedStyle.Render(m.me.DisplayName) + " " + metaStyle.Render("@"+m.me.GitHubLogin)
+		}
 		if emblem != "" {
 			sb.WriteString(" " + emblem + " " + nameStr + "\n")
 		} else {
@@ -468,9 +701,18 @@ func (m youModel) View() string {
 		if m.me.Edition != "" {
 			parts = append(parts, metaStyle.Render(m.me.Edition))
 		}
+		if m.me.Archetype != "" {
+			parts = append(parts, metaStyle.Render(m.me.Archetype))
+		}
+		if m.me.City != "" {
+			parts = append(parts, metaStyle.Render(m.me.City))
+		}
 		if len(parts) > 0 {
 			sb.WriteString("   " + strings.Join(parts, dimStyle.Render(" · ")) + "\n")
 		}
+		if m.me.Bio != "" {
+			sb.WriteString("   " + dimStyle.Render(m.me.Bio) + "\n")
+		}
 
 		// Grimoire quip in italic gold
 		quip := grimoireQuip(m.me)
@@ -481,6 +723,25 @@ func (m youModel) View() string {
 
 	if m.statusMsg != "" {
 		sb.WriteString("\n " + upvoteStyle.Render(m.statusMsg) + "\n")
+	} else if m.canUndo() {
+		sb.WriteString("\n " + upvoteStyle.Render(m.undo.project.Name+" removed — press u to undo") + "\n")
+	}
+
+	if m.profileOpen {
+		sb.WriteString(m.viewProfileForm())
+		return sb.String()
+	}
+	if m.inDetail() {
+		sb.WriteString(m.viewProjectDetail())
+		return sb.String()
+	}
+	if m.statsOpen {
+		sb.WriteString(m.viewStats())
+		return sb.String()
+	}
+	if m.watchOpen {
+		sb.WriteString(m.viewWatching())
+		return sb.String()
 	}
 
 	sb.WriteString(m.viewStatsBar())
@@ -537,8 +798,11 @@ func (m youModel) viewStatsBar() string {
 			accentStyle.Render(fmt.Sprintf("#%d", m.forgeStats.Rank)),
 		)
 	}
+	if m.forgeStats != nil && m.forgeStats.SpellsCast > 0 {
+		parts = append(parts, dimStyle.Render(fmt.Sprintf("%d", m.forgeStats.SpellsCast))+" "+dimStyle.Render("cast"))
+	}
 
-	sb.WriteString("   " + strings.Join(parts, dimStyle.Render("   ")) + "\n")
+	sb.WriteString(clipWidth("   "+strings.Join(parts, dimStyle.Render("   ")), m.width) + "\n")
 	return sb.String()
 }
 
@@ -676,20 +940,20 @@ func (m youModel) renderProjectCard(proj domain.WorkshopProject, updates []domai
 	return sb.String()
 }
 
-// viewInvitesSection renders the invites section.
+// viewInvitesSection renders the invites section: open codes, who claimed
+// the rest, and progress toward the next code.
 func (m youModel) viewInvitesSection() string {
 	if len(m.invites) == 0 {
 		return ""
 	}
 
 	var sb strings.Builder
-	var available []domain.Invite
-	claimed := 0
+	var available, claimed []domain.Invite
 	for _, inv := range m.invites {
-		if inv.UsedBy == nil {
-			available = append(available, inv)
+		if inv.Claimed() {
+			claimed = append(claimed, inv)
 		} else {
-			claimed++
+			available = append(available, inv)
 		}
 	}
 	sb.WriteString("\n " + sectionHeaderStyle.Render(fmt.Sprintf("── INVITES %d available ──", len(available))) + "\n")
@@ -699,35 +963,74 @@ func (m youModel) viewInvitesSection() string {
 		if isActive {
 			cursor = accentStyle.Render("▸") + " "
 		}
-		sb.WriteString(" " + cursor + accentStyle.Render("grimora.ai/join/"+inv.Code) + "\n")
+		sb.WriteString(" " + cursor + accentStyle.Render(inv.JoinURL()) + "\n")
 	}
-	sb.WriteString("   " + metaStyle.Render(fmt.Sprintf("%d claimed · %d more forged spells until next invite", claimed, inviteSpellThreshold)) + "\n")
+	for _, inv := range claimed {
+		sb.WriteString("   " + dimStyle.Render("✓ "+inv.Code) + "  " + metaStyle.Render(claimedByLabel(inv)) + "\n")
+	}
+	sb.WriteString("   " + m.viewInviteProgress() + "\n")
 
 	return sb.String()
 }
 
+// claimedByLabel describes who redeemed an invite and when.
+func claimedByLabel(inv domain.Invite) string {
+	label := "claimed"
+	if inv.UsedByLogin != "" {
+		label += " by @" + inv.UsedByLogin
+	}
+	if inv.UsedAt != nil {
+		label += " · " + inv.UsedAt.Format("Jan 2, 2006")
+	}
+	return label
+}
+
+// currentInviteProgress returns server-reported progress, falling back to
+// forged spells against the default threshold for older servers.
+func (m youModel) currentInviteProgress() domain.InviteProgress {
+	if m.inviteProgress != nil && m.inviteProgress.SpellsRequired > 0 {
+		return *m.inviteProgress
+	}
+	p := domain.InviteProgress{SpellsRequired: inviteSpellThreshold}
+	if m.forgeStats != nil {
+		p.SpellsForged = m.forgeStats.SpellsForged % inviteSpellThreshold
+	}
+	return p
+}
+
+// viewInviteProgress renders a bar showing progress toward the next invite.
+func (m youModel) viewInviteProgress() string {
+	p := m.currentInviteProgress()
+	filled := p.SpellsForged * inviteBarWidth / p.SpellsRequired
+	if filled > inviteBarWidth {
+		filled = inviteBarWidth
+	}
+	bar := accentStyle.Render(strings.Repeat("█", filled)) + metaStyle.Render(strings.Repeat("░", inviteBarWidth-filled))
+	label := fmt.Sprintf("%d/%d · %d more forged spells until next invite", p.SpellsForged, p.SpellsRequired, p.Remaining())
+	if p.Remaining() == 0 {
+		label = fmt.Sprintf("%d/%d · next invite unlocked", p.SpellsForged, p.SpellsRequired)
+	}
+	return bar + " " + metaStyle.Render(label)
+}
+
 func (m youModel) renderEditForm() string {
 	var sb strings.Builder
 	sb.WriteString("\n")
 
-	nameCursor := ""
-	insightCursor := ""
-	if m.wsAddFocus == 0 {
-		nameCursor = accentStyle.Render("_")
-	} else {
-		insightCursor = accentStyle.Render("_")
-	}
+	caret := accentStyle.Render("_")
 
 	nameLabel := inputPromptStyle.Render("name:")
 	insightLabel := inputPromptStyle.Render("insight:")
 
 	var nameLine, insightLine string
 	if m.wsAddFocus == 0 {
-		nameLine = "   " + accentStyle.Render(">") + " " + nameLabel + " " + m.wsAddName + nameCursor
+		before, after := m.wsAddCursor.split(m.wsAddName)
+		nameLine = "   " + accentStyle.Render(">") + " " + nameLabel + " " + before + caret + after
 		insightLine = "     " + insightLabel + " " + dimStyle.Render(m.wsAddInsight)
 	} else {
+		before, after := m.wsAddCursor.split(m.wsAddInsight)
 		nameLine = "     " + nameLabel + " " + dimStyle.Render(m.wsAddName)
-		insightLine = "   " + accentStyle.Render(">") + " " + insightLabel + " " + m.wsAddInsight + insightCursor
+		insightLine = "   " + accentStyle.Render(">") + " " + insightLabel + " " + before + caret + after
 	}
 
 	sb.WriteString(nameLine + "\n")
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -424,3 +425,187 @@ func TestYouTimelineMaxUpdates(t *testing.T) {
 		t.Errorf("expected '3 earlier' in view, got:\n%s", view)
 	}
 }
+
+func TestYouInvitesShowsClaimedBy(t *testing.T) {
+	m := newTestYouModel()
+	used := uuid.New()
+	at := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	m, _ = m.Update(youInvitesLoadedMsg{invites: []domain.Invite{
+		{ID: uuid.New(), Code: "OPEN1"},
+		{ID: uuid.New(), Code: "TAKEN1", UsedBy: &used, UsedByLogin: "alice", UsedAt: &at},
+	}})
+
+	view := m.View()
+	if !strings.Contains(view, "claimed by @alice · Mar 14, 2026") {
+		t.Errorf("expected claimed-by line in view, got:\n%s", view)
+	}
+	if strings.Contains(view, "grimora.ai/join/TAKEN1") {
+		t.Error("claimed invite should not be listed as a join link")
+	}
+}
+
+func TestYouInviteProgressFromServer(t *testing.T) {
+	m := newTestYouModel()
+	m, _ = m.Update(youInvitesLoadedMsg{invites: []domain.Invite{{ID: uuid.New(), Code: "OPEN1"}}})
+	m, _ = m.Update(youInviteProgressMsg{progress: &domain.InviteProgress{SpellsForged: 6, SpellsRequired: 8}})
+
+	view := m.View()
+	if !strings.Contains(view, "6/8 · 2 more forged spells") {
+		t.Errorf("expected server progress in view, got:\n%s", view)
+	}
+	if !strings.Contains(view, strings.Repeat("█", 15)) {
+		t.Errorf("expected a 75%% progress bar, got:\n%s", view)
+	}
+}
+
+func TestYouInviteProgressFallsBackToForgeStats(t *testing.T) {
+	m := newTestYouModel()
+	m.forgeStats = &domain.ForgeStats{SpellsForged: 13}
+	m, _ = m.Update(youInviteProgressMsg{err: fmt.Errorf("not found")})
+
+	p := m.currentInviteProgress()
+	if p.SpellsForged != 3 || p.SpellsRequired != inviteSpellThreshold {
+		t.Errorf("expected fallback 3/%d, got %d/%d", inviteSpellThreshold, p.SpellsForged, p.SpellsRequired)
+	}
+}
+
+func TestYouEnterOpensProjectDetail(t *testing.T) {
+	m := newTestYouModel()
+	proj := makeTestProject("Detail Project", "deep dive")
+	proj.URL = "https://github.com/me/detail"
+	m, _ = m.Update(workshopLoadedMsg{projects: []domain.WorkshopProject{proj}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.wsState != wsDetail {
+		t.Fatalf("expected wsState=wsDetail after enter, got %d", m.wsState)
+	}
+	view := m.View()
+	if !strings.Contains(view, "TIMELINE") || !strings.Contains(view, "https://github.com/me/detail") {
+		t.Errorf("expected timeline header and repo URL in detail view, got:\n%s", view)
+	}
+	if strings.Contains(view, "BUILD JOURNAL") {
+		t.Error("detail view should replace the build journal")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.wsState != wsNormal {
+		t.Errorf("expected wsState=wsNormal after esc, got %d", m.wsState)
+	}
+}
+
+func TestYouDetailPostUpdateAppendsToTimeline(t *testing.T) {
+	m := newTestYouModel()
+	proj := makeTestProject("Posting", "")
+	m, _ = m.Update(workshopLoadedMsg{projects: []domain.WorkshopProject{proj}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if m.wsState != wsPosting || m.postKind != "update" {
+		t.Fatalf("expected posting an update after 'u', got state=%d kind=%q", m.wsState, m.postKind)
+	}
+	// Empty updates are rejected without a request.
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("expected no command for an empty update")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("wired up auth")})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command to post the update")
+	}
+
+	m, _ = m.Update(projectUpdateCreatedMsg{
+		projectID: proj.ID.String(),
+		update:    &domain.ProjectUpdate{Kind: "update", Body: "wired up auth", CreatedAt: time.Now()},
+	})
+	if m.wsState != wsDetail {
+		t.Errorf("expected back on detail screen, got %d", m.wsState)
+	}
+	if !strings.Contains(m.View(), "wired up auth") {
+		t.Errorf("expected posted update in timeline, got:\n%s", m.View())
+	}
+}
+
+func TestYouDetailShipMarksShipped(t *testing.T) {
+	m := newTestYouModel()
+	proj := makeTestProject("Shipping", "")
+	m, _ = m.Update(workshopLoadedMsg{projects: []domain.WorkshopProject{proj}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.wsState != wsPosting || m.postKind != "ship" {
+		t.Fatalf("expected posting a ship event after 's', got state=%d kind=%q", m.wsState, m.postKind)
+	}
+	// A ship note is optional.
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Fatal("expected a command to post the ship event")
+	}
+	m, _ = m.Update(projectUpdateCreatedMsg{
+		projectID: proj.ID.String(),
+		update:    &domain.ProjectUpdate{Kind: "ship", CreatedAt: time.Now()},
+	})
+	if projectStatus(m.projectUpdates[proj.ID.String()]) != "shipped" {
+		t.Error("expected project to be shipped")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.wsState != wsDetail {
+		t.Errorf("shipping twice should stay on detail screen, got %d", m.wsState)
+	}
+}
+
+func TestYouDetailLinkRepoURL(t *testing.T) {
+	m := newTestYouModel()
+	proj := makeTestProject("Linking", "")
+	m, _ = m.Update(workshopLoadedMsg{projects: []domain.WorkshopProject{proj}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if m.wsState != wsLinking {
+		t.Fatalf("expected wsState=wsLinking after 'l', got %d", m.wsState)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("not a url")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("expected invalid URL to be rejected")
+	}
+
+	m.wsDraft = "https://github.com/me/linking"
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Fatal("expected a command to save the repo URL")
+	}
+	m, _ = m.Update(workshopURLSetMsg{id: proj.ID.String(), url: "https://github.com/me/linking"})
+	if m.projects[0].URL != "https://github.com/me/linking" {
+		t.Errorf("URL = %q, want linked repo", m.projects[0].URL)
+	}
+	if m.wsState != wsDetail {
+		t.Errorf("expected back on detail screen, got %d", m.wsState)
+	}
+}
+
+func TestYouDetailTimelineScrolls(t *testing.T) {
+	m := newTestYouModel()
+	m.height = 20 // five timeline rows
+	proj := makeTestProject("Long Timeline", "")
+	m, _ = m.Update(workshopLoadedMsg{projects: []domain.WorkshopProject{proj}})
+
+	updates := make([]domain.ProjectUpdate, 10)
+	for i := range updates {
+		updates[i] = domain.ProjectUpdate{Kind: "update", Body: fmt.Sprintf("entry %02d", i), CreatedAt: time.Now()}
+	}
+	m, _ = m.Update(projectUpdatesMsg{projectID: proj.ID.String(), updates: updates})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	view := m.View()
+	if !strings.Contains(view, "entry 09") || strings.Contains(view, "entry 00") {
+		t.Errorf("expected detail to open on the latest entries, got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	view = m.View()
+	if !strings.Contains(view, "entry 00") || strings.Contains(view, "entry 09") {
+		t.Errorf("expected top of timeline after 'g', got:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.detailScroll != 1 {
+		t.Errorf("detailScroll = %d after 'j', want 1", m.detailScroll)
+	}
+}
@@ -0,0 +1,158 @@
+// Package version parses and orders semantic versions such as the release
+// tags grimora updates between (v1.4.0, v1.5.0-rc.1, v1.5.0+darwin).
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReleasesURL is the GitHub API listing grimora's releases, newest first.
+const ReleasesURL = "https://api.github.com/repos/naveenspark/grimora/releases"
+
+// Version is a parsed semantic version.
+type Version struct {
+	Major, Minor, Patch uint64
+	// Prerelease holds the dot-separated identifiers after "-", e.g.
+	// ["rc", "1"]. Empty is a final release.
+	Prerelease []string
+	// Build is the metadata after "+". It never affects ordering.
+	Build string
+}
+
+// Parse reads a version with an optional leading "v". Minor and patch may
+// be left out ("v2", "1.4") and count as 0. Numbers too big for a uint64
+// are an error, not a silent zero.
+func Parse(s string) (Version, error) {
+	var v Version
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	rest, v.Build, _ = strings.Cut(rest, "+")
+	core, pre, hasPre := strings.Cut(rest, "-")
+	if hasPre {
+		if pre == "" {
+			return Version{}, fmt.Errorf("version %q: empty prerelease", s)
+		}
+		v.Prerelease = strings.Split(pre, ".")
+		for _, id := range v.Prerelease {
+			if id == "" {
+				return Version{}, fmt.Errorf("version %q: empty prerelease identifier", s)
+			}
+		}
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("version %q: want at most major.minor.patch", s)
+	}
+	nums := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("version %q: bad number %q", s, p)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// String formats v as "MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]", without
+// a "v".
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 as v sorts before, with or after w, by
+// semver precedence: prereleases sort before their final release
+// (1.2.0-beta.1 < 1.2.0) and build metadata is ignored.
+func (v Version) Compare(w Version) int {
+	for _, c := range [][2]uint64{{v.Major, w.Major}, {v.Minor, w.Minor}, {v.Patch, w.Patch}} {
+		if c[0] != c[1] {
+			if c[0] < c[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return comparePrerelease(v.Prerelease, w.Prerelease)
+}
+
+// Newer reports whether latest is a newer version than current. A string
+// that doesn't parse, such as "dev", is never newer and nothing is newer
+// than it.
+func Newer(latest, current string) bool {
+	l, err := Parse(latest)
+	if err != nil {
+		return false
+	}
+	c, err := Parse(current)
+	if err != nil {
+		return false
+	}
+	return l.Compare(c) > 0
+}
+
+// comparePrerelease orders prerelease identifiers ("beta.2" < "beta.10" <
+// "rc.1"). No identifiers is a final release and sorts last.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, bn := isNumeric(a[i]), isNumeric(b[i])
+		var c int
+		switch {
+		case an && bn:
+			c = compareNumeric(a[i], b[i])
+		case an:
+			c = -1 // numeric identifiers sort before alphanumeric ones
+		case bn:
+			c = 1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// compareNumeric compares two digit strings by value without converting
+// them, so identifiers of any length (date stamps, build numbers) are safe.
+func compareNumeric(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,58 @@
+package version
+
+import "testing"
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest  string
+		current string
+		want    bool
+	}{
+		{"1.0.0", "1.0.0", false},
+		{"1.1.0", "1.0.0", true},
+		{"1.0.0", "1.1.0", false},
+		{"1.10.0", "1.9.0", true},
+		{"2.0.0", "1.99.99", true},
+		{"v1.2.3", "1.2.3", false},
+		{"v0.5.0", "0.4.2", true},
+		{"1.2.0", "1.2.0-beta.1", true},
+		{"1.2.0-beta.1", "1.2.0", false},
+		{"1.2.0-beta.10", "1.2.0-beta.2", true},
+		{"1.2.0-rc.1", "1.2.0-beta.3", true},
+		{"1.2.0-rc.1", "1.2.0-rc", true},
+		{"1.2.0-1", "1.2.0-alpha", false},
+		{"1.2.1-beta.1", "1.2.0", true},
+		{"v1.2.0-nightly.20261016", "v1.2.0-nightly.20261015", true},
+		{"1.2.0-build.100000000000000000000", "1.2.0-build.99999999999999999999", true},
+		{"1.2.0+darwin", "1.2.0", false},
+		{"1.2.1+abc", "1.2.0+xyz", true},
+		{"v2", "1.9.9", true},
+		{"dev", "dev", false},
+		{"abc", "def", false},
+		{"1.0.0", "dev", false},
+		{"99999999999999999999.0.0", "1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.5.0-rc.1+linux.arm64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Major != 1 || v.Minor != 5 || v.Patch != 0 || len(v.Prerelease) != 2 || v.Build != "linux.arm64" {
+		t.Errorf("Parse() = %+v", v)
+	}
+	if got := v.String(); got != "1.5.0-rc.1+linux.arm64" {
+		t.Errorf("String() = %q", got)
+	}
+	for _, bad := range []string{"", "dev", "1.2.3.4", "1.x.0", "1.2.0-", "1.2.0-rc..1", "-1.0.0"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q): expected an error", bad)
+		}
+	}
+}
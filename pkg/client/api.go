@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// API is the part of Client the TUI uses. Views depend on it rather than on
+// *Client so tests can hand them a fake such as clienttest.Fake.
+type API interface {
+	RateLimit() RateLimit
+	GetServerInfo(ctx context.Context) (*ServerInfo, error)
+
+	// Profile and stats
+	GetMe(ctx context.Context) (*domain.Magician, error)
+	UpdateProfile(ctx context.Context, u domain.ProfileUpdate) (*domain.Magician, error)
+	GetForgeStats(ctx context.Context) (*domain.ForgeStats, error)
+	GetForgeHistory(ctx context.Context) (*domain.ForgeHistory, error)
+
+	// Spells and weapons
+	ListSpells(ctx context.Context, tags []string, sort string, limit, offset int) ([]domain.Spell, error)
+	TagStats(ctx context.Context) ([]domain.TagStat, error)
+	SearchSpells(ctx context.Context, query string) ([]domain.Spell, error)
+	GetSpell(ctx context.Context, id string) (*domain.Spell, error)
+	GetRandomSpell(ctx context.Context, minPotency int, seed string) (*domain.Spell, error)
+	CreateSpell(ctx context.Context, spell CreateSpellRequest) (*domain.Spell, error)
+	PreviewSpell(ctx context.Context, spell CreateSpellRequest) (*domain.ForgeVerdict, error)
+	UpdateSpell(ctx context.Context, id string, spell CreateSpellRequest) (*domain.Spell, error)
+	SetSpellContext(ctx context.Context, id, spellContext string) (*domain.Spell, error)
+	UpvoteSpell(ctx context.Context, id string) error
+	CastSpell(ctx context.Context, id string) (*domain.SpellCast, error)
+	ForkSpell(ctx context.Context, id string) (*domain.Spell, error)
+	RequestReevaluation(ctx context.Context, id string) (*domain.Reevaluation, error)
+	SaveSpell(ctx context.Context, id string) error
+	UnsaveSpell(ctx context.Context, id string) error
+	ListSavedSpells(ctx context.Context, limit, offset int) ([]domain.Spell, error)
+	ListWeapons(ctx context.Context, categories []string, limit, offset int) ([]domain.Weapon, error)
+	GetWeapon(ctx context.Context, id string) (*domain.Weapon, error)
+	SearchWeapons(ctx context.Context, query string) ([]domain.Weapon, error)
+	SaveWeapon(ctx context.Context, id string) error
+
+	// Spell drafts
+	ShareSpellDraft(ctx context.Context, req CreateSpellRequest) (*domain.SpellDraft, error)
+	UpdateSpellDraft(ctx context.Context, id string, req CreateSpellRequest) (*domain.SpellDraft, error)
+	GetSpellDraft(ctx context.Context, id string) (*domain.SpellDraft, error)
+	SuggestDraftEdit(ctx context.Context, draftID, field, original, replacement, note string) (*domain.DraftSuggestion, error)
+	ResolveDraftSuggestion(ctx context.Context, draftID, suggestionID string, accept bool) error
+
+	// Magicians
+	ListMagicians(ctx context.Context, limit, offset int) ([]domain.MagicianCard, error)
+	GetMagician(ctx context.Context, login string) (*domain.MagicianCard, error)
+	GetMagicianWorkshop(ctx context.Context, login string) ([]domain.WorkshopProject, error)
+	ListMagicianSpells(ctx context.Context, login string, limit int) ([]domain.Spell, error)
+	GetLeaderboard(ctx context.Context, guild, city string, limit, offset int) ([]domain.LeaderboardEntry, error)
+	GetLeaderboardRank(ctx context.Context, login string) (*domain.LeaderboardEntry, error)
+	GetGuildLeaderboard(ctx context.Context) ([]domain.GuildStanding, error)
+	GetPresence(ctx context.Context, logins []string) (map[string]bool, error)
+	Heartbeat(ctx context.Context, status string) error
+	Follow(ctx context.Context, login string) error
+	Unfollow(ctx context.Context, login string) error
+	GetStream(ctx context.Context, followingOnly bool, limit, offset int) ([]domain.StreamEvent, error)
+
+	// DM threads
+	ListThreads(ctx context.Context) ([]domain.Thread, error)
+	StartThread(ctx context.Context, login string) (*domain.Thread, error)
+	StartGroupThread(ctx context.Context, logins []string) (*domain.Thread, error)
+	GetMessages(ctx context.Context, threadID string, limit, offset int) ([]domain.Message, error)
+	GetMessagesBefore(ctx context.Context, threadID string, before time.Time, limit int) ([]domain.Message, error)
+	SendMessage(ctx context.Context, threadID, body string) (*domain.Message, error)
+	MarkThreadRead(ctx context.Context, threadID string) error
+
+	// Rooms
+	ListRooms(ctx context.Context) ([]domain.Room, error)
+	JoinRoom(ctx context.Context, slug string) error
+	CreateRoom(ctx context.Context, req CreateRoomRequest) (*domain.Room, error)
+	ArchiveRoom(ctx context.Context, slug string) error
+	SetRoomTopic(ctx context.Context, slug, topic string) (*domain.Room, error)
+	GetRoomMessages(ctx context.Context, slug string, before time.Time, limit int) ([]domain.RoomMessage, error)
+	GetRoomPresence(ctx context.Context, slug string) (*RoomPresence, error)
+	SendRoomMessage(ctx context.Context, slug, body string, metadata map[string]string) (*domain.RoomMessage, error)
+	GetReactionCounts(ctx context.Context, slug string, msgIDs []string) (map[string][]ReactionCount, error)
+	AddReaction(ctx context.Context, slug, msgID, emoji string) error
+
+	// Guild chests
+	ListGuildChests(ctx context.Context, guildID string) (*domain.GuildChests, error)
+	GetGuildChest(ctx context.Context, guildID, chestID string) (*domain.GuildChest, error)
+	AddChestSpell(ctx context.Context, guildID, chestID, spellID string) error
+	RemoveChestSpell(ctx context.Context, guildID, chestID, spellID string) error
+
+	// Invites
+	ListInvites(ctx context.Context) ([]domain.Invite, error)
+	GetInviteProgress(ctx context.Context) (*domain.InviteProgress, error)
+
+	// Workshop
+	ListWorkshopProjects(ctx context.Context) ([]domain.WorkshopProject, error)
+	CreateWorkshopProject(ctx context.Context, name, insight string) (*domain.WorkshopProject, error)
+	UpdateWorkshopProject(ctx context.Context, id, name, insight string) error
+	SetWorkshopProjectURL(ctx context.Context, id, repoURL string) error
+	DeleteWorkshopProject(ctx context.Context, id string) error
+	RestoreWorkshopProject(ctx context.Context, id string) (*domain.WorkshopProject, error)
+	ListProjectUpdates(ctx context.Context, projectID string) ([]domain.ProjectUpdate, error)
+	CreateProjectUpdate(ctx context.Context, projectID, kind, body string) (*domain.ProjectUpdate, error)
+
+	// Watching and notifications
+	Watch(ctx context.Context, targetType, targetID string) (*domain.Subscription, error)
+	Unwatch(ctx context.Context, targetType, targetID string) error
+	ListSubscriptions(ctx context.Context) ([]domain.Subscription, error)
+	MarkSubscriptionRead(ctx context.Context, targetType, targetID string) error
+	ListNotifications(ctx context.Context, limit int) ([]domain.GroupedNotification, error)
+	MarkNotificationRead(ctx context.Context, id string) error
+	MarkAllNotificationsRead(ctx context.Context) error
+	GetTelemetry(ctx context.Context) (*TelemetryResponse, error)
+
+	// Moderation
+	ReportMessage(ctx context.Context, msgID, reason string) error
+	ReportSpell(ctx context.Context, spellID, reason string) error
+	BlockMagician(ctx context.Context, login string) error
+	UnblockMagician(ctx context.Context, login string) error
+	ListBlocked(ctx context.Context) ([]string, error)
+}
+
+var _ API = (*Client)(nil)
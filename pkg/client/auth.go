@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Tokens is a signed-in session: the access token sent with every request
+// and the refresh token that renews it once it expires.
+type Tokens struct {
+	Access  string `json:"token"`
+	Refresh string `json:"refresh_token,omitempty"`
+}
+
+// TokenSaver persists renewed tokens, so the next run starts with them
+// rather than with the expired pair.
+type TokenSaver func(Tokens) error
+
+// WithRefreshToken lets the client renew an expired session with refresh.
+// A request answered 401 renews it and is sent once more; save, if non-nil,
+// is handed every renewed pair.
+func WithRefreshToken(refresh string, save TokenSaver) Option {
+	return func(c *Client) {
+		c.tokens.Refresh = refresh
+		c.saveTokens = save
+	}
+}
+
+// Tokens returns the session the client is signed in with.
+func (c *Client) Tokens() Tokens {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.tokens
+}
+
+// SetTokens signs the client in with t, e.g. after logging in again.
+// Requests already in flight finish with the old token.
+func (c *Client) SetTokens(t Tokens) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.tokens = t
+}
+
+// SetSessionExpiredHandler installs fn to be called whenever a request is
+// refused as unauthenticated and the session couldn't be renewed, so the
+// caller can offer to sign in again. Pass nil to remove it.
+func (c *Client) SetSessionExpiredHandler(fn func()) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.onExpired = fn
+}
+
+// sessionExpired calls the handler SetSessionExpiredHandler installed.
+func (c *Client) sessionExpired() {
+	c.authMu.Lock()
+	fn := c.onExpired
+	c.authMu.Unlock()
+	if fn != nil {
+		fn()
+	}
+}
+
+// RefreshSession trades the refresh token for a new session and saves it.
+// It fails with ErrUnauthorized when there's no refresh token or the
+// server no longer accepts it.
+func (c *Client) RefreshSession(ctx context.Context) error {
+	refresh := c.Tokens().Refresh
+	if refresh == "" {
+		return fmt.Errorf("client.RefreshSession: %w", &HTTPError{StatusCode: http.StatusUnauthorized, Message: "no refresh token"})
+	}
+	var t Tokens
+	if err := c.do(ctx, http.MethodPost, "/auth/refresh", map[string]string{"refresh_token": refresh}, &t, false); err != nil {
+		return fmt.Errorf("client.RefreshSession: %w", err)
+	}
+	if t.Access == "" {
+		return fmt.Errorf("client.RefreshSession: no token in response")
+	}
+	if t.Refresh == "" {
+		// Servers that don't rotate refresh tokens keep the one we have.
+		t.Refresh = refresh
+	}
+	c.SetTokens(t)
+	if c.saveTokens != nil {
+		if err := c.saveTokens(t); err != nil {
+			return fmt.Errorf("client.RefreshSession: save tokens: %w", err)
+		}
+	}
+	return nil
+}
+
+// renew refreshes the session after a request sent with stale came back
+// 401, and reports whether there's a new token to retry with. Requests
+// that fail together renew once: the rest find the token already changed.
+func (c *Client) renew(ctx context.Context, stale string) bool {
+	c.renewMu.Lock()
+	defer c.renewMu.Unlock()
+	t := c.Tokens()
+	if t.Access != stale {
+		return true
+	}
+	if t.Refresh == "" {
+		return false
+	}
+	// Tokens that couldn't be saved still work for the rest of this run.
+	return c.RefreshSession(ctx) == nil || c.Tokens().Access != stale
+}
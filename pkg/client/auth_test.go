@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// refreshServer serves /api/me to "fresh" only and renews the session at
+// /auth/refresh for refresh token "r1", counting renewals.
+func refreshServer(t *testing.T, renewals *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/refresh":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+			if body["refresh_token"] != "r1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			renewals.Add(1)
+			json.NewEncoder(w).Encode(Tokens{Access: "fresh", Refresh: "r2"}) //nolint:errcheck
+		case "/api/me":
+			if r.Header.Get("Authorization") != "Bearer fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(domain.Magician{GitHubLogin: "ada"}) //nolint:errcheck
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRefreshOnUnauthorized(t *testing.T) {
+	var renewals atomic.Int32
+	srv := refreshServer(t, &renewals)
+	var saved Tokens
+	expired := false
+	c := New(srv.URL, "stale", WithRefreshToken("r1", func(tk Tokens) error {
+		saved = tk
+		return nil
+	}))
+	c.SetSessionExpiredHandler(func() { expired = true })
+
+	me, err := c.GetMe(context.Background())
+	if err != nil || me.GitHubLogin != "ada" {
+		t.Fatalf("GetMe() = %v, %v; want a retry with the renewed token", me, err)
+	}
+	if want := (Tokens{Access: "fresh", Refresh: "r2"}); c.Tokens() != want || saved != want {
+		t.Errorf("Tokens() = %+v, saved %+v; want %+v", c.Tokens(), saved, want)
+	}
+	if expired {
+		t.Error("session reported expired after a successful renewal")
+	}
+}
+
+func TestRefreshRetriesWhenSavingFails(t *testing.T) {
+	var renewals atomic.Int32
+	srv := refreshServer(t, &renewals)
+	c := New(srv.URL, "stale", WithRefreshToken("r1", func(Tokens) error {
+		return errors.New("disk full")
+	}))
+
+	if me, err := c.GetMe(context.Background()); err != nil || me.GitHubLogin != "ada" {
+		t.Fatalf("GetMe() = %v, %v; want a retry with the renewed token though it wasn't saved", me, err)
+	}
+}
+
+func TestRefreshOnceForConcurrentRequests(t *testing.T) {
+	var renewals atomic.Int32
+	srv := refreshServer(t, &renewals)
+	c := New(srv.URL, "stale", WithRefreshToken("r1", nil))
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetMe(context.Background()); err != nil {
+				t.Errorf("GetMe() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := renewals.Load(); n != 1 {
+		t.Errorf("renewed %d times, want once", n)
+	}
+}
+
+func TestSessionExpiredWhenRefreshFails(t *testing.T) {
+	var renewals atomic.Int32
+	srv := refreshServer(t, &renewals)
+	expired := 0
+	c := New(srv.URL, "stale", WithRefreshToken("revoked", nil))
+	c.SetSessionExpiredHandler(func() { expired++ })
+
+	_, err := c.GetMe(context.Background())
+	if !IsUnauthorized(err) {
+		t.Fatalf("GetMe() error = %v, want unauthorized", err)
+	}
+	if expired != 1 {
+		t.Errorf("expired handler called %d times, want 1", expired)
+	}
+	if c.Tokens().Access != "stale" {
+		t.Errorf("Tokens() = %+v, want the old session kept", c.Tokens())
+	}
+
+	// Without a refresh token there's nothing to renew with.
+	c = New(srv.URL, "stale")
+	c.SetSessionExpiredHandler(func() { expired++ })
+	if _, err := c.GetMe(context.Background()); !IsUnauthorized(err) || expired != 2 {
+		t.Errorf("GetMe() without refresh = %v (expired %d)", err, expired)
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// MaxBatchKeys is the most keys BatchGet puts in one request. It keeps
+// query strings well under common URL length limits.
+const MaxBatchKeys = 50
+
+// BatchGet fetches a JSON object keyed by the values of a comma-separated
+// list parameter, such as the ids of GetReactionCounts, and merges the
+// responses. Repeated and empty keys are dropped, and the rest go out at
+// most MaxBatchKeys to a request, so callers can hand over whatever keys
+// they have pending without building huge URLs. No keys means no request.
+func BatchGet[V any](ctx context.Context, c *Client, path, param string, keys []string) (map[string]V, error) {
+	keys = batchKeys(keys)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	result := make(map[string]V, len(keys))
+	for chunk := range slices.Chunk(keys, MaxBatchKeys) {
+		var part map[string]V
+		q := url.Values{param: {strings.Join(chunk, ",")}}
+		if err := c.get(ctx, path+sep+q.Encode(), &part); err != nil {
+			return nil, err
+		}
+		maps.Copy(result, part)
+	}
+	return result, nil
+}
+
+// batchKeys returns keys without empties and repeats, in first-seen order.
+func batchKeys(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if k != "" && !seen[k] {
+			seen[k] = true
+			out = append(out, k)
+		}
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBatchGetChunksAndMerges(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/rooms/the-hall/messages/reactions" {
+			http.NotFound(w, r)
+			return
+		}
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		mu.Lock()
+		batches = append(batches, ids)
+		mu.Unlock()
+		out := make(map[string][]ReactionCount, len(ids))
+		for _, id := range ids {
+			out[id] = []ReactionCount{{Emoji: "🔥", Count: 1}}
+		}
+		json.NewEncoder(w).Encode(out) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	ids := make([]string, 0, MaxBatchKeys+11)
+	for i := range MaxBatchKeys + 10 {
+		ids = append(ids, fmt.Sprintf("m%d", i))
+	}
+	ids = append(ids, "m0", "") // a repeat and an empty key
+
+	c := New(srv.URL, "tok")
+	got, err := c.GetReactionCounts(context.Background(), "the-hall", ids)
+	if err != nil {
+		t.Fatalf("GetReactionCounts() error: %v", err)
+	}
+	if len(got) != MaxBatchKeys+10 {
+		t.Errorf("merged %d messages, want %d", len(got), MaxBatchKeys+10)
+	}
+	if len(batches) != 2 || len(batches[0]) != MaxBatchKeys || len(batches[1]) != 10 {
+		t.Errorf("batch sizes = %d requests %v", len(batches), batches)
+	}
+}
+
+func TestBatchGetNoKeysSkipsRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("unexpected request")
+	}))
+	defer srv.Close()
+
+	got, err := BatchGet[bool](context.Background(), New(srv.URL, "tok"), "/api/magicians/presence", "logins", []string{"", ""})
+	if err != nil || got != nil {
+		t.Errorf("BatchGet(no keys) = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestBatchGetKeepsExistingQuery(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"a":true}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	if _, err := BatchGet[bool](context.Background(), New(srv.URL, "tok"), "/api/x?scope=room", "ids", []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	if query != "scope=room&ids=a" {
+		t.Errorf("query = %q", query)
+	}
+}
+
+func TestBatchGetError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if _, err := New(srv.URL, "tok").GetPresence(context.Background(), []string{"alice"}); err == nil || !strings.Contains(err.Error(), "client.GetPresence") {
+		t.Errorf("GetPresence() error = %v", err)
+	}
+}
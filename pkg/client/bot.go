@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// DefaultBotInterval is the minimum gap between bot posts unless
+// BotOptions.MinInterval says otherwise.
+const DefaultBotInterval = 2 * time.Second
+
+// ErrDMsNotAllowed is returned by Bot.SendDM unless the bot was created with
+// BotOptions.AllowDMs and its token carries the threads scope.
+var ErrDMsNotAllowed = errors.New("direct messages not allowed for this bot")
+
+// Announcement is a formatted bot post. The Hall renders it as a card with a
+// [bot] badge; Body is also folded into a plain-text fallback.
+type Announcement struct {
+	Kind   string            // message kind; empty means domain.MessageKindAnnounce
+	Title  string            // headline, required
+	Body   string            // optional detail
+	URL    string            // optional link, e.g. a release or CI run
+	Fields map[string]string // extra metadata for richer cards, e.g. "status"
+}
+
+// text returns the plain-text rendering used as the message body.
+func (a Announcement) text() string {
+	parts := []string{a.Title}
+	if a.Body != "" {
+		parts = append(parts, a.Body)
+	}
+	if a.URL != "" {
+		parts = append(parts, a.URL)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// metadata returns the card metadata sent alongside the body.
+func (a Announcement) metadata() map[string]string {
+	meta := make(map[string]string, len(a.Fields)+3)
+	for k, v := range a.Fields {
+		meta[k] = v
+	}
+	meta["title"] = a.Title
+	if a.Body != "" {
+		meta["body"] = a.Body
+	}
+	if a.URL != "" {
+		meta["url"] = a.URL
+	}
+	return meta
+}
+
+// PostAnnouncement posts a structured announcement to a room.
+func (c *Client) PostAnnouncement(ctx context.Context, slug string, a Announcement) (*domain.RoomMessage, error) {
+	if strings.TrimSpace(a.Title) == "" {
+		return nil, fmt.Errorf("client.PostAnnouncement: title required")
+	}
+	kind := a.Kind
+	if kind == "" {
+		kind = domain.MessageKindAnnounce
+	}
+	payload := map[string]any{
+		"body":     a.text(),
+		"kind":     kind,
+		"metadata": a.metadata(),
+	}
+	var msg domain.RoomMessage
+	if err := c.post(ctx, "/api/rooms/"+url.PathEscape(slug)+"/messages", payload, &msg); err != nil {
+		return nil, fmt.Errorf("client.PostAnnouncement: %w", err)
+	}
+	return &msg, nil
+}
+
+// GetTokenInfo returns the account and scopes behind the client's token.
+func (c *Client) GetTokenInfo(ctx context.Context) (*domain.TokenInfo, error) {
+	var info domain.TokenInfo
+	if err := c.get(ctx, "/api/me/token", &info); err != nil {
+		return nil, fmt.Errorf("client.GetTokenInfo: %w", err)
+	}
+	return &info, nil
+}
+
+// BotOptions configures a Bot's guardrails.
+type BotOptions struct {
+	// MinInterval spaces out posts. Zero uses DefaultBotInterval.
+	MinInterval time.Duration
+	// AllowDMs lets the bot send direct messages. Off by default so a
+	// misconfigured bot can't spam people's inboxes.
+	AllowDMs bool
+}
+
+// Bot wraps a Client for automated posting with guardrails: posts are paced
+// at least MinInterval apart, a server Retry-After is waited out before the
+// next post, and direct messages are refused unless explicitly allowed.
+type Bot struct {
+	client *Client
+	opts   BotOptions
+
+	mu   sync.Mutex
+	next time.Time         // earliest time the next post may go out
+	info *domain.TokenInfo // set by Verify
+}
+
+// NewBot returns a Bot that authenticates with a bot token.
+func NewBot(baseURL, token string, opts BotOptions) *Bot {
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = DefaultBotInterval
+	}
+	return &Bot{client: New(baseURL, token), opts: opts}
+}
+
+// Client returns the underlying API client, e.g. to install a request
+// observer. Calls made through it bypass the bot's guardrails.
+func (b *Bot) Client() *Client {
+	return b.client
+}
+
+// Verify checks that the token belongs to a bot account allowed to post to
+// rooms, and remembers its scopes for later guardrail checks.
+func (b *Bot) Verify(ctx context.Context) (*domain.TokenInfo, error) {
+	info, err := b.client.GetTokenInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client.Bot.Verify: %w", err)
+	}
+	if !info.IsBot {
+		return nil, fmt.Errorf("client.Bot.Verify: token for @%s is a personal token, not a bot token", info.Login)
+	}
+	if !info.HasScope(domain.ScopeRoomsPost) {
+		return nil, fmt.Errorf("client.Bot.Verify: token lacks the %s scope", domain.ScopeRoomsPost)
+	}
+	b.mu.Lock()
+	b.info = info
+	b.mu.Unlock()
+	return info, nil
+}
+
+// Say posts a plain message to a room.
+func (b *Bot) Say(ctx context.Context, slug, body string) (*domain.RoomMessage, error) {
+	if err := b.wait(ctx); err != nil {
+		return nil, fmt.Errorf("client.Bot.Say: %w", err)
+	}
+	msg, err := b.client.SendRoomMessage(ctx, slug, body, nil)
+	if err != nil {
+		return nil, fmt.Errorf("client.Bot.Say: %w", err)
+	}
+	return msg, nil
+}
+
+// Announce posts a structured announcement card to a room.
+func (b *Bot) Announce(ctx context.Context, slug string, a Announcement) (*domain.RoomMessage, error) {
+	if err := b.wait(ctx); err != nil {
+		return nil, fmt.Errorf("client.Bot.Announce: %w", err)
+	}
+	msg, err := b.client.PostAnnouncement(ctx, slug, a)
+	if err != nil {
+		return nil, fmt.Errorf("client.Bot.Announce: %w", err)
+	}
+	return msg, nil
+}
+
+// SendDM sends a direct message to login. It fails with ErrDMsNotAllowed
+// unless AllowDMs is set and, once verified, the token has the threads scope.
+func (b *Bot) SendDM(ctx context.Context, login, body string) (*domain.Message, error) {
+	b.mu.Lock()
+	info := b.info
+	b.mu.Unlock()
+	if !b.opts.AllowDMs || (info != nil && !info.HasScope(domain.ScopeThreads)) {
+		return nil, fmt.Errorf("client.Bot.SendDM: %w", ErrDMsNotAllowed)
+	}
+	if err := b.wait(ctx); err != nil {
+		return nil, fmt.Errorf("client.Bot.SendDM: %w", err)
+	}
+	thread, err := b.client.StartThread(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("client.Bot.SendDM: %w", err)
+	}
+	msg, err := b.client.SendMessage(ctx, thread.ID.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("client.Bot.SendDM: %w", err)
+	}
+	return msg, nil
+}
+
+// wait blocks until the bot may post again, reserving the slot after it.
+func (b *Bot) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	at := b.next
+	if ra := b.client.RateLimit().RetryAfter; ra.After(at) {
+		at = ra
+	}
+	if at.Before(now) {
+		at = now
+	}
+	b.next = at.Add(b.opts.MinInterval)
+	b.mu.Unlock()
+
+	d := at.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
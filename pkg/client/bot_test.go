@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestPostAnnouncement(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/rooms/build-club/messages" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Body     string            `json:"body"`
+			Kind     string            `json:"kind"`
+			Metadata map[string]string `json:"metadata"`
+		}
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		if body.Kind != domain.MessageKindAnnounce {
+			t.Errorf("kind = %q, want announce", body.Kind)
+		}
+		if body.Body != "v1.2 shipped · https://example.com/r" {
+			t.Errorf("fallback body = %q", body.Body)
+		}
+		if body.Metadata["title"] != "v1.2 shipped" || body.Metadata["url"] != "https://example.com/r" || body.Metadata["env"] != "prod" {
+			t.Errorf("metadata = %v", body.Metadata)
+		}
+		json.NewEncoder(w).Encode(domain.RoomMessage{Kind: body.Kind}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	_, err := c.PostAnnouncement(context.Background(), "build-club", Announcement{
+		Title:  "v1.2 shipped",
+		URL:    "https://example.com/r",
+		Fields: map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("PostAnnouncement() error: %v", err)
+	}
+}
+
+func TestPostAnnouncementRequiresTitle(t *testing.T) {
+	c := New("http://unused", "tok")
+	if _, err := c.PostAnnouncement(context.Background(), "hall", Announcement{Body: "no title"}); err == nil {
+		t.Error("expected error for empty title")
+	}
+}
+
+func TestBotVerifyRejectsPersonalToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(domain.TokenInfo{Login: "human"}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	b := NewBot(srv.URL, "tok", BotOptions{})
+	if _, err := b.Verify(context.Background()); err == nil {
+		t.Error("expected personal token to be rejected")
+	}
+}
+
+func TestBotSendDMGuardrail(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		json.NewEncoder(w).Encode(map[string]string{}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	b := NewBot(srv.URL, "tok", BotOptions{})
+	_, err := b.SendDM(context.Background(), "alice", "hi")
+	if !errors.Is(err, ErrDMsNotAllowed) {
+		t.Errorf("err = %v, want ErrDMsNotAllowed", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("expected no requests, got %d", calls.Load())
+	}
+}
+
+func TestBotPacesPosts(t *testing.T) {
+	var stamps []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stamps = append(stamps, time.Now())
+		json.NewEncoder(w).Encode(domain.RoomMessage{}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	b := NewBot(srv.URL, "tok", BotOptions{MinInterval: 50 * time.Millisecond})
+	for range 2 {
+		if _, err := b.Say(context.Background(), "hall", "tick"); err != nil {
+			t.Fatalf("Say() error: %v", err)
+		}
+	}
+	if gap := stamps[1].Sub(stamps[0]); gap < 45*time.Millisecond {
+		t.Errorf("posts %v apart, want >= 50ms", gap)
+	}
+}
+
+func TestBotWaitHonorsContext(t *testing.T) {
+	b := NewBot("http://unused", "tok", BotOptions{MinInterval: time.Hour})
+	b.next = time.Now().Add(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := b.Say(ctx, "hall", "late"); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
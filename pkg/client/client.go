@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/naveenspark/grimora/pkg/domain"
@@ -24,22 +25,76 @@ type CreateSpellRequest struct {
 	Context string   `json:"context,omitempty"`
 }
 
+// RequestObserver is notified after every API request with the method, the
+// request path, the response status (0 if no response arrived) and the
+// elapsed time.
+type RequestObserver func(method, path string, status int, elapsed time.Duration)
+
+// ChainObservers returns an observer that calls each non-nil fn in order, or
+// nil if there are none.
+func ChainObservers(fns ...RequestObserver) RequestObserver {
+	var live []RequestObserver
+	for _, fn := range fns {
+		if fn != nil {
+			live = append(live, fn)
+		}
+	}
+	switch len(live) {
+	case 0:
+		return nil
+	case 1:
+		return live[0]
+	}
+	return func(method, path string, status int, elapsed time.Duration) {
+		for _, fn := range live {
+			fn(method, path, status, elapsed)
+		}
+	}
+}
+
 // Client is the Grimora API client.
 type Client struct {
 	baseURL    string
-	token      string
 	httpClient *http.Client
-}
-
-// New creates a new API client.
-func New(baseURL, token string) *Client {
-	return &Client{
-		baseURL: baseURL,
-		token:   token,
+	userAgent  string
+	observer   RequestObserver
+	base       context.Context // cancels every request along with its own context; nil for none
+
+	rlMu      sync.Mutex
+	rateLimit RateLimit
+
+	authMu     sync.Mutex // guards tokens and onExpired
+	tokens     Tokens
+	saveTokens TokenSaver
+	renewMu    sync.Mutex // one renewal at a time
+	onExpired  func()
+}
+
+// New creates a new API client. Without options it uses the shared tuned
+// transport and DefaultTimeout.
+func New(baseURL, token string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:   baseURL,
+		tokens:    Tokens{Access: token},
+		userAgent: DefaultUserAgent,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Transport: sharedTransport,
+			Timeout:   DefaultTimeout,
 		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Health pings the API. It is cheap and needs no auth, so a short deadline
+// on it tells a slow API from one that is down.
+func (c *Client) Health(ctx context.Context) error {
+	if err := c.get(ctx, "/api/health", nil); err != nil {
+		return fmt.Errorf("client.Health: %w", err)
+	}
+	return nil
 }
 
 // GetMe returns the authenticated magician's profile.
@@ -51,6 +106,16 @@ func (c *Client) GetMe(ctx context.Context) (*domain.Magician, error) {
 	return &m, nil
 }
 
+// UpdateProfile changes the authenticated magician's profile settings and
+// returns the updated profile.
+func (c *Client) UpdateProfile(ctx context.Context, u domain.ProfileUpdate) (*domain.Magician, error) {
+	var m domain.Magician
+	if err := c.doRequest(ctx, http.MethodPatch, "/api/me", u, &m); err != nil {
+		return nil, fmt.Errorf("client.UpdateProfile: %w", err)
+	}
+	return &m, nil
+}
+
 // GetForgeStats returns the authenticated magician's forge stats.
 func (c *Client) GetForgeStats(ctx context.Context) (*domain.ForgeStats, error) {
 	var stats domain.ForgeStats
@@ -60,11 +125,22 @@ func (c *Client) GetForgeStats(ctx context.Context) (*domain.ForgeStats, error)
 	return &stats, nil
 }
 
-// ListSpells fetches spells with optional tag filter and sort.
-func (c *Client) ListSpells(ctx context.Context, tag, sort string, limit, offset int) ([]domain.Spell, error) {
+// GetForgeHistory returns the authenticated magician's forge record broken
+// down by tag, potency and week, plus their rank over time.
+func (c *Client) GetForgeHistory(ctx context.Context) (*domain.ForgeHistory, error) {
+	var history domain.ForgeHistory
+	if err := c.get(ctx, "/api/me/forge-history", &history); err != nil {
+		return nil, fmt.Errorf("client.GetForgeHistory: %w", err)
+	}
+	return &history, nil
+}
+
+// ListSpells fetches spells with optional tag filter and sort. A spell
+// matches when it carries any of tags; no tags means all spells.
+func (c *Client) ListSpells(ctx context.Context, tags []string, sort string, limit, offset int) ([]domain.Spell, error) {
 	params := url.Values{}
-	if tag != "" {
-		params.Set("tag", tag)
+	if len(tags) > 0 {
+		params.Set("tag", strings.Join(tags, ","))
 	}
 	if sort != "" {
 		params.Set("sort", sort)
@@ -79,6 +155,35 @@ func (c *Client) ListSpells(ctx context.Context, tag, sort string, limit, offset
 	return spells, nil
 }
 
+// SaveSpell bookmarks a spell into the caller's saved library.
+func (c *Client) SaveSpell(ctx context.Context, id string) error {
+	if err := c.doRequest(ctx, http.MethodPost, "/api/spells/"+url.PathEscape(id)+"/save", nil, nil); err != nil {
+		return fmt.Errorf("client.SaveSpell: %w", err)
+	}
+	return nil
+}
+
+// UnsaveSpell removes a spell from the caller's saved library.
+func (c *Client) UnsaveSpell(ctx context.Context, id string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/api/spells/"+url.PathEscape(id)+"/save", nil, nil); err != nil {
+		return fmt.Errorf("client.UnsaveSpell: %w", err)
+	}
+	return nil
+}
+
+// ListSavedSpells fetches the caller's saved spells, most recently saved first.
+func (c *Client) ListSavedSpells(ctx context.Context, limit, offset int) ([]domain.Spell, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+
+	var spells []domain.Spell
+	if err := c.get(ctx, "/api/me/saved-spells?"+params.Encode(), &spells); err != nil {
+		return nil, fmt.Errorf("client.ListSavedSpells: %w", err)
+	}
+	return spells, nil
+}
+
 // TagStats returns spell counts and upvote totals per tag.
 func (c *Client) TagStats(ctx context.Context) ([]domain.TagStat, error) {
 	var stats []domain.TagStat
@@ -109,6 +214,29 @@ func (c *Client) GetSpell(ctx context.Context, id string) (*domain.Spell, error)
 	return &spell, nil
 }
 
+// GetRandomSpell fetches a published spell of at least minPotency picked at
+// random. A seed makes the pick repeatable: everyone asking with the same
+// seed gets the same spell, which is how the spell of the day works. An
+// empty seed picks afresh each time.
+func (c *Client) GetRandomSpell(ctx context.Context, minPotency int, seed string) (*domain.Spell, error) {
+	params := url.Values{}
+	if minPotency > 0 {
+		params.Set("min_potency", strconv.Itoa(minPotency))
+	}
+	if seed != "" {
+		params.Set("seed", seed)
+	}
+	path := "/api/spells/random"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	var spell domain.Spell
+	if err := c.get(ctx, path, &spell); err != nil {
+		return nil, fmt.Errorf("client.GetRandomSpell: %w", err)
+	}
+	return &spell, nil
+}
+
 // CreateSpell creates a new spell.
 func (c *Client) CreateSpell(ctx context.Context, spell CreateSpellRequest) (*domain.Spell, error) {
 	var created domain.Spell
@@ -118,6 +246,39 @@ func (c *Client) CreateSpell(ctx context.Context, spell CreateSpellRequest) (*do
 	return &created, nil
 }
 
+// PreviewSpell asks the Grimoire for the verdict it would give spell
+// without creating it. Servers that don't offer previews answer with a
+// not-found error (see IsNotFound).
+func (c *Client) PreviewSpell(ctx context.Context, spell CreateSpellRequest) (*domain.ForgeVerdict, error) {
+	var verdict domain.ForgeVerdict
+	if err := c.post(ctx, "/api/spells/preview", spell, &verdict); err != nil {
+		return nil, fmt.Errorf("client.PreviewSpell: %w", err)
+	}
+	return &verdict, nil
+}
+
+// UpdateSpell replaces the text, tag, model, stack and context of one of
+// the caller's spells and returns it. The Grimoire weighs the new text
+// afresh, so the spell goes back to pending. Only the author may edit
+// (IsForbidden).
+func (c *Client) UpdateSpell(ctx context.Context, id string, spell CreateSpellRequest) (*domain.Spell, error) {
+	var updated domain.Spell
+	if err := c.doRequest(ctx, http.MethodPut, "/api/spells/"+url.PathEscape(id), spell, &updated); err != nil {
+		return nil, fmt.Errorf("client.UpdateSpell: %w", err)
+	}
+	return &updated, nil
+}
+
+// SetSpellContext replaces the context note on one of the caller's spells
+// and returns the updated spell.
+func (c *Client) SetSpellContext(ctx context.Context, id, spellContext string) (*domain.Spell, error) {
+	var spell domain.Spell
+	if err := c.doRequest(ctx, http.MethodPatch, "/api/spells/"+url.PathEscape(id), map[string]string{"context": spellContext}, &spell); err != nil {
+		return nil, fmt.Errorf("client.SetSpellContext: %w", err)
+	}
+	return &spell, nil
+}
+
 // UpvoteSpell upvotes a spell by ID.
 func (c *Client) UpvoteSpell(ctx context.Context, id string) error {
 	if err := c.doRequest(ctx, http.MethodPost, "/api/spells/"+url.PathEscape(id)+"/upvote", nil, nil); err != nil {
@@ -126,6 +287,39 @@ func (c *Client) UpvoteSpell(ctx context.Context, id string) error {
 	return nil
 }
 
+// CastSpell records that the caller used a spell. Unlike an upvote it can
+// be recorded any number of times.
+func (c *Client) CastSpell(ctx context.Context, id string) (*domain.SpellCast, error) {
+	var cast domain.SpellCast
+	if err := c.post(ctx, "/api/spells/"+url.PathEscape(id)+"/cast", nil, &cast); err != nil {
+		return nil, fmt.Errorf("client.CastSpell: %w", err)
+	}
+	return &cast, nil
+}
+
+// ForkSpell copies someone else's spell into the caller's grimoire. The
+// copy's ForkedFrom names the spell it came from and that spell's own
+// origins, and the original's Forks count goes up.
+func (c *Client) ForkSpell(ctx context.Context, id string) (*domain.Spell, error) {
+	var spell domain.Spell
+	if err := c.post(ctx, "/api/spells/"+url.PathEscape(id)+"/fork", nil, &spell); err != nil {
+		return nil, fmt.Errorf("client.ForkSpell: %w", err)
+	}
+	return &spell, nil
+}
+
+// RequestReevaluation asks the Grimoire to weigh one of the caller's spells
+// again. The request stays pending until it rules; GetSpell reports the
+// outcome in the spell's Reforge. Only the author may ask (IsForbidden), and
+// only once at a time (IsConflict).
+func (c *Client) RequestReevaluation(ctx context.Context, id string) (*domain.Reevaluation, error) {
+	var r domain.Reevaluation
+	if err := c.post(ctx, "/api/spells/"+url.PathEscape(id)+"/reevaluate", nil, &r); err != nil {
+		return nil, fmt.Errorf("client.RequestReevaluation: %w", err)
+	}
+	return &r, nil
+}
+
 // RemoveUpvote removes an upvote from a spell.
 func (c *Client) RemoveUpvote(ctx context.Context, id string) error {
 	if err := c.doRequest(ctx, http.MethodDelete, "/api/spells/"+url.PathEscape(id)+"/upvote", nil, nil); err != nil {
@@ -138,18 +332,23 @@ func (c *Client) RemoveUpvote(ctx context.Context, id string) error {
 
 // CreateWeaponRequest is the payload for creating a new weapon.
 type CreateWeaponRequest struct {
-	Name           string `json:"name"`
-	Description    string `json:"description,omitempty"`
-	RepositoryURL  string `json:"repository_url"`
-	GitHubStars    int    `json:"github_stars,omitempty"`
-	GitHubForks    int    `json:"github_forks,omitempty"`
-	GitHubLanguage string `json:"github_language,omitempty"`
-	License        string `json:"license,omitempty"`
-}
-
-// ListWeapons fetches weapons.
-func (c *Client) ListWeapons(ctx context.Context, limit, offset int) ([]domain.Weapon, error) {
+	Name           string   `json:"name"`
+	Description    string   `json:"description,omitempty"`
+	RepositoryURL  string   `json:"repository_url"`
+	GitHubStars    int      `json:"github_stars,omitempty"`
+	GitHubForks    int      `json:"github_forks,omitempty"`
+	GitHubLanguage string   `json:"github_language,omitempty"`
+	License        string   `json:"license,omitempty"`
+	Categories     []string `json:"categories,omitempty"` // from domain.WeaponCategories
+}
+
+// ListWeapons fetches weapons, only those in any of categories when
+// there are some.
+func (c *Client) ListWeapons(ctx context.Context, categories []string, limit, offset int) ([]domain.Weapon, error) {
 	params := url.Values{}
+	if len(categories) > 0 {
+		params.Set("category", strings.Join(categories, ","))
+	}
 	params.Set("limit", strconv.Itoa(limit))
 	params.Set("offset", strconv.Itoa(offset))
 
@@ -274,6 +473,16 @@ func (c *Client) StartThread(ctx context.Context, login string) (*domain.Thread,
 	return &thread, nil
 }
 
+// StartGroupThread creates or retrieves the group thread between the caller
+// and every magician in logins.
+func (c *Client) StartGroupThread(ctx context.Context, logins []string) (*domain.Thread, error) {
+	var thread domain.Thread
+	if err := c.post(ctx, "/api/threads", map[string][]string{"logins": logins}, &thread); err != nil {
+		return nil, fmt.Errorf("client.StartGroupThread: %w", err)
+	}
+	return &thread, nil
+}
+
 // GetMessages returns messages in a thread.
 func (c *Client) GetMessages(ctx context.Context, threadID string, limit, offset int) ([]domain.Message, error) {
 	params := url.Values{}
@@ -287,6 +496,22 @@ func (c *Client) GetMessages(ctx context.Context, threadID string, limit, offset
 	return msgs, nil
 }
 
+// GetMessagesBefore returns up to limit thread messages created before the given
+// cursor, for backfilling history. A zero before returns the most recent messages.
+func (c *Client) GetMessagesBefore(ctx context.Context, threadID string, before time.Time, limit int) ([]domain.Message, error) {
+	params := url.Values{}
+	if !before.IsZero() {
+		params.Set("before", before.Format(time.RFC3339Nano))
+	}
+	params.Set("limit", strconv.Itoa(limit))
+
+	var msgs []domain.Message
+	if err := c.get(ctx, "/api/threads/"+url.PathEscape(threadID)+"/messages?"+params.Encode(), &msgs); err != nil {
+		return nil, fmt.Errorf("client.GetMessagesBefore: %w", err)
+	}
+	return msgs, nil
+}
+
 // SendMessage sends a message to a thread.
 func (c *Client) SendMessage(ctx context.Context, threadID, body string) (*domain.Message, error) {
 	var msg domain.Message
@@ -296,6 +521,15 @@ func (c *Client) SendMessage(ctx context.Context, threadID, body string) (*domai
 	return &msg, nil
 }
 
+// MarkThreadRead tells the server the caller has read every message in the
+// thread, clearing its unread count and letting the sender see a receipt.
+func (c *Client) MarkThreadRead(ctx context.Context, threadID string) error {
+	if err := c.doRequest(ctx, http.MethodPost, "/api/threads/"+url.PathEscape(threadID)+"/read", nil, nil); err != nil {
+		return fmt.Errorf("client.MarkThreadRead: %w", err)
+	}
+	return nil
+}
+
 // --- Invites ---
 
 // ListInvites returns the authenticated magician's invite codes.
@@ -307,6 +541,23 @@ func (c *Client) ListInvites(ctx context.Context) ([]domain.Invite, error) {
 	return invites, nil
 }
 
+// GetInviteProgress returns progress toward the authenticated magician's next invite code.
+func (c *Client) GetInviteProgress(ctx context.Context) (*domain.InviteProgress, error) {
+	var p domain.InviteProgress
+	if err := c.get(ctx, "/api/invites/progress", &p); err != nil {
+		return nil, fmt.Errorf("client.GetInviteProgress: %w", err)
+	}
+	return &p, nil
+}
+
+// RevokeInvite invalidates an unclaimed invite code.
+func (c *Client) RevokeInvite(ctx context.Context, code string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, "/api/invites/"+url.PathEscape(code), nil, nil); err != nil {
+		return fmt.Errorf("client.RevokeInvite: %w", err)
+	}
+	return nil
+}
+
 // --- Workshop ---
 
 // ListWorkshopProjects returns the current magician's workshop projects.
@@ -335,6 +586,14 @@ func (c *Client) UpdateWorkshopProject(ctx context.Context, id, name, insight st
 	return nil
 }
 
+// SetWorkshopProjectURL links a workshop project to a repo URL. An empty URL unlinks it.
+func (c *Client) SetWorkshopProjectURL(ctx context.Context, id, repoURL string) error {
+	if err := c.doRequest(ctx, http.MethodPatch, "/api/workshop/"+url.PathEscape(id), map[string]string{"url": repoURL}, nil); err != nil {
+		return fmt.Errorf("client.SetWorkshopProjectURL: %w", err)
+	}
+	return nil
+}
+
 // DeleteWorkshopProject deletes a workshop project.
 func (c *Client) DeleteWorkshopProject(ctx context.Context, id string) error {
 	if err := c.doRequest(ctx, http.MethodDelete, "/api/workshop/"+url.PathEscape(id), nil, nil); err != nil {
@@ -343,6 +602,17 @@ func (c *Client) DeleteWorkshopProject(ctx context.Context, id string) error {
 	return nil
 }
 
+// RestoreWorkshopProject brings back a project deleted moments ago, with
+// its ID and timeline intact. Servers that don't keep deleted projects, or
+// no longer have this one, answer with a not-found error (see IsNotFound).
+func (c *Client) RestoreWorkshopProject(ctx context.Context, id string) (*domain.WorkshopProject, error) {
+	var project domain.WorkshopProject
+	if err := c.post(ctx, "/api/workshop/"+url.PathEscape(id)+"/restore", nil, &project); err != nil {
+		return nil, fmt.Errorf("client.RestoreWorkshopProject: %w", err)
+	}
+	return &project, nil
+}
+
 // GetLeaderboard returns ranked magicians with optional guild/city filters.
 func (c *Client) GetLeaderboard(ctx context.Context, guild, city string, limit, offset int) ([]domain.LeaderboardEntry, error) {
 	params := url.Values{}
@@ -362,6 +632,28 @@ func (c *Client) GetLeaderboard(ctx context.Context, guild, city string, limit,
 	return entries, nil
 }
 
+// GetLeaderboardRank returns a magician's place on the overall leaderboard,
+// however far down it is. A magician who isn't ranked is a not-found error.
+func (c *Client) GetLeaderboardRank(ctx context.Context, login string) (*domain.LeaderboardEntry, error) {
+	var entry domain.LeaderboardEntry
+	if err := c.get(ctx, "/api/leaderboard/"+url.PathEscape(login), &entry); err != nil {
+		return nil, fmt.Errorf("client.GetLeaderboardRank: %w", err)
+	}
+	return &entry, nil
+}
+
+// GetGuildLeaderboard returns the six guilds ranked against each other, with
+// how each moved over the last week. Servers that predate guild standings
+// answer with a not-found error; domain.RankGuilds over ListMagicians is the
+// fallback.
+func (c *Client) GetGuildLeaderboard(ctx context.Context) ([]domain.GuildStanding, error) {
+	var standings []domain.GuildStanding
+	if err := c.get(ctx, "/api/guilds/standings", &standings); err != nil {
+		return nil, fmt.Errorf("client.GetGuildLeaderboard: %w", err)
+	}
+	return standings, nil
+}
+
 // ListProjectUpdates returns timeline entries for a workshop project.
 func (c *Client) ListProjectUpdates(ctx context.Context, projectID string) ([]domain.ProjectUpdate, error) {
 	var updates []domain.ProjectUpdate
@@ -371,6 +663,16 @@ func (c *Client) ListProjectUpdates(ctx context.Context, projectID string) ([]do
 	return updates, nil
 }
 
+// CreateProjectUpdate posts a timeline entry on a workshop project.
+// kind is "update" for progress notes or "ship" to mark the project shipped.
+func (c *Client) CreateProjectUpdate(ctx context.Context, projectID, kind, body string) (*domain.ProjectUpdate, error) {
+	var update domain.ProjectUpdate
+	if err := c.post(ctx, "/api/workshop/"+url.PathEscape(projectID)+"/updates", map[string]string{"kind": kind, "body": body}, &update); err != nil {
+		return nil, fmt.Errorf("client.CreateProjectUpdate: %w", err)
+	}
+	return &update, nil
+}
+
 // GetMagicianWorkshop returns a magician's workshop projects (public view).
 func (c *Client) GetMagicianWorkshop(ctx context.Context, login string) ([]domain.WorkshopProject, error) {
 	var projects []domain.WorkshopProject
@@ -380,6 +682,18 @@ func (c *Client) GetMagicianWorkshop(ctx context.Context, login string) ([]domai
 	return projects, nil
 }
 
+// ListMagicianSpells returns a magician's most recent spells, newest first.
+func (c *Client) ListMagicianSpells(ctx context.Context, login string, limit int) ([]domain.Spell, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
+
+	var spells []domain.Spell
+	if err := c.get(ctx, "/api/magicians/"+url.PathEscape(login)+"/spells?"+params.Encode(), &spells); err != nil {
+		return nil, fmt.Errorf("client.ListMagicianSpells: %w", err)
+	}
+	return spells, nil
+}
+
 // GetMagician fetches a single magician card by login.
 func (c *Client) GetMagician(ctx context.Context, login string) (*domain.MagicianCard, error) {
 	var card domain.MagicianCard
@@ -389,6 +703,25 @@ func (c *Client) GetMagician(ctx context.Context, login string) (*domain.Magicia
 	return &card, nil
 }
 
+// GetPresence reports which of the given magicians are currently online,
+// keyed by login. Logins absent from the result should be treated as offline.
+func (c *Client) GetPresence(ctx context.Context, logins []string) (map[string]bool, error) {
+	result, err := BatchGet[bool](ctx, c, "/api/magicians/presence", "logins", logins)
+	if err != nil {
+		return nil, fmt.Errorf("client.GetPresence: %w", err)
+	}
+	return result, nil
+}
+
+// Heartbeat tells the server the caller is still here: status is
+// domain.PresenceOnline, or domain.PresenceAway once they've gone idle.
+func (c *Client) Heartbeat(ctx context.Context, status string) error {
+	if err := c.post(ctx, "/api/presence/heartbeat", map[string]string{"status": status}, nil); err != nil {
+		return fmt.Errorf("client.Heartbeat: %w", err)
+	}
+	return nil
+}
+
 // --- Rooms ---
 
 // ListRooms returns all non-archived chat rooms.
@@ -438,22 +771,33 @@ type ReactionCount struct {
 }
 
 // GetReactionCounts fetches batch reaction counts for a set of message IDs.
+// Messages without reactions are absent from the result.
 func (c *Client) GetReactionCounts(ctx context.Context, slug string, msgIDs []string) (map[string][]ReactionCount, error) {
-	if len(msgIDs) == 0 {
-		return nil, nil
-	}
-	ids := strings.Join(msgIDs, ",")
-	var result map[string][]ReactionCount
-	if err := c.get(ctx, "/api/rooms/"+url.PathEscape(slug)+"/messages/reactions?ids="+url.QueryEscape(ids), &result); err != nil {
+	result, err := BatchGet[[]ReactionCount](ctx, c, "/api/rooms/"+url.PathEscape(slug)+"/messages/reactions", "ids", msgIDs)
+	if err != nil {
 		return nil, fmt.Errorf("client.GetReactionCounts: %w", err)
 	}
 	return result, nil
 }
 
-// SendRoomMessage posts a message to a chat room.
-func (c *Client) SendRoomMessage(ctx context.Context, slug, body string) (*domain.RoomMessage, error) {
+// AddReaction reacts to a room message with an emoji.
+func (c *Client) AddReaction(ctx context.Context, slug, msgID, emoji string) error {
+	path := "/api/rooms/" + url.PathEscape(slug) + "/messages/" + url.PathEscape(msgID) + "/reactions"
+	if err := c.post(ctx, path, map[string]string{"emoji": emoji}, nil); err != nil {
+		return fmt.Errorf("client.AddReaction: %w", err)
+	}
+	return nil
+}
+
+// SendRoomMessage posts a message to a chat room. Metadata is optional and
+// carries structured fields alongside the body, e.g. a reply reference.
+func (c *Client) SendRoomMessage(ctx context.Context, slug, body string, metadata map[string]string) (*domain.RoomMessage, error) {
+	payload := map[string]any{"body": body}
+	if len(metadata) > 0 {
+		payload["metadata"] = metadata
+	}
 	var msg domain.RoomMessage
-	if err := c.post(ctx, "/api/rooms/"+url.PathEscape(slug)+"/messages", map[string]string{"body": body}, &msg); err != nil {
+	if err := c.post(ctx, "/api/rooms/"+url.PathEscape(slug)+"/messages", payload, &msg); err != nil {
 		return nil, fmt.Errorf("client.SendRoomMessage: %w", err)
 	}
 	return &msg, nil
@@ -471,8 +815,10 @@ func (c *Client) JoinRoom(ctx context.Context, slug string) error {
 
 // TelemetryResponse is the shape of the telemetry endpoint response.
 type TelemetryResponse struct {
-	Total  int              `json:"total"`
-	Cities []CityCountEntry `json:"cities"`
+	Total  int               `json:"total"`
+	Cities []CityCountEntry  `json:"cities"`
+	Joins  []DayCountEntry   `json:"joins,omitempty"`  // magicians joined per day, oldest first
+	Guilds []GuildCountEntry `json:"guilds,omitempty"` // members per guild
 }
 
 // CityCountEntry is a city + count pair in the telemetry response.
@@ -481,7 +827,22 @@ type CityCountEntry struct {
 	Count int    `json:"count"`
 }
 
-// GetTelemetry returns platform-level telemetry (magician count + top cities).
+// DayCountEntry is a day (YYYY-MM-DD, UTC) + count pair in the telemetry
+// response.
+type DayCountEntry struct {
+	Day   string `json:"day"`
+	Count int    `json:"count"`
+}
+
+// GuildCountEntry is a guild + member count pair in the telemetry response.
+type GuildCountEntry struct {
+	GuildID string `json:"guild_id"`
+	Count   int    `json:"count"`
+}
+
+// GetTelemetry returns platform-level telemetry: the magician count, top
+// cities, daily joins and guild sizes. Older servers send only the first
+// two.
 func (c *Client) GetTelemetry(ctx context.Context) (*TelemetryResponse, error) {
 	var t TelemetryResponse
 	if err := c.get(ctx, "/api/telemetry", &t); err != nil {
@@ -495,29 +856,40 @@ func (c *Client) post(ctx context.Context, path string, body any, out any) error
 }
 
 func (c *Client) doRequest(ctx context.Context, method, path string, body any, out any) error {
-	var reqBody io.Reader
-	if body != nil {
-		data, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("marshal body: %w", err)
-		}
-		reqBody = bytes.NewReader(data)
-	}
+	return c.do(ctx, method, path, body, out, true)
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+// do sends a request and decodes its response into out. With renew, a 401
+// renews the session if it can and sends the request once more.
+func (c *Client) do(ctx context.Context, method, path string, body any, out any, renew bool) error {
+	if c.base != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = withBase(ctx, c.base)
+		defer cancel()
 	}
+
+	var data []byte
 	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("marshal body: %w", err)
+		}
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, sent, err := c.send(ctx, method, path, data)
 	if err != nil {
-		return fmt.Errorf("do request: %w", err)
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && renew {
+		if c.renew(ctx, sent) {
+			resp.Body.Close() //nolint:errcheck // best-effort close
+			if resp, _, err = c.send(ctx, method, path, data); err != nil {
+				return err
+			}
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.sessionExpired()
+		}
 	}
 	defer resp.Body.Close() //nolint:errcheck // best-effort close
 
@@ -527,22 +899,82 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, o
 			return &HTTPError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("failed to read body: %v", readErr)}
 		}
 		var apiErr struct {
-			Error string `json:"error"`
+			Error  string       `json:"error"`
+			Errors []FieldError `json:"errors"`
+		}
+		httpErr := &HTTPError{StatusCode: resp.StatusCode, Message: string(respBody)}
+		if json.Unmarshal(respBody, &apiErr) == nil {
+			if apiErr.Error != "" {
+				httpErr.Message = apiErr.Error
+			}
+			httpErr.Fields = apiErr.Errors
 		}
-		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error != "" {
-			return &HTTPError{StatusCode: resp.StatusCode, Message: apiErr.Error}
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			httpErr.RetryAfter = d
 		}
-		return &HTTPError{StatusCode: resp.StatusCode, Message: string(respBody)}
+		return httpErr
 	}
 
 	if out != nil {
-		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
-			return fmt.Errorf("decode response: %w", err)
-		}
+		return decodeBody(resp.Body, out)
 	}
 	return nil
 }
 
+// send makes one round trip with the current access token, which it
+// returns along with the response so a 401 can tell whether the token has
+// been renewed since.
+func (c *Client) send(ctx context.Context, method, path string, data []byte) (*http.Response, string, error) {
+	var reqBody io.Reader
+	if data != nil {
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return nil, "", fmt.Errorf("create request: %w", err)
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	token := c.Tokens().Access
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.observe(method, path, 0, start)
+		if ctx.Err() == nil {
+			// A cancelled request was abandoned, not lost on the network.
+			err = &NetworkError{Err: err}
+		}
+		return nil, token, fmt.Errorf("do request: %w", err)
+	}
+	c.observe(method, path, resp.StatusCode, start)
+	c.recordRateLimit(resp, time.Now())
+	return resp, token, nil
+}
+
+// withBase returns a copy of ctx that is also cancelled when base is.
+func withBase(ctx, base context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(base, func() { cancel(context.Cause(base)) })
+	return ctx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+func (c *Client) observe(method, path string, status int, start time.Time) {
+	if c.observer != nil {
+		c.observer(method, path, status, time.Since(start))
+	}
+}
+
 func (c *Client) get(ctx context.Context, path string, out any) error {
 	return c.doRequest(ctx, http.MethodGet, path, nil, out)
 }
@@ -3,9 +3,12 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -44,6 +47,35 @@ func TestGetMe(t *testing.T) {
 	}
 }
 
+func TestUpdateProfile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/me" {
+			http.NotFound(w, r)
+			return
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		if body["timezone"] != "Europe/Berlin" {
+			t.Errorf("timezone = %v, want Europe/Berlin", body["timezone"])
+		}
+		if _, ok := body["active_hours"]; ok {
+			t.Error("expected unset active_hours to be omitted")
+		}
+		json.NewEncoder(w).Encode(domain.Magician{GitHubLogin: "testmage", Timezone: "Europe/Berlin"}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	tz := "Europe/Berlin"
+	c := New(srv.URL, "test-token")
+	me, err := c.UpdateProfile(context.Background(), domain.ProfileUpdate{Timezone: &tz})
+	if err != nil {
+		t.Fatalf("UpdateProfile() error: %v", err)
+	}
+	if me.Timezone != "Europe/Berlin" {
+		t.Errorf("Timezone = %q, want Europe/Berlin", me.Timezone)
+	}
+}
+
 func TestGetMe_Unauthorized(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusUnauthorized)
@@ -61,6 +93,36 @@ func TestGetMe_Unauthorized(t *testing.T) {
 	}
 }
 
+func TestHealth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/health" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := New(srv.URL, "").Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+}
+
+func TestHealth_Deadline(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := New(srv.URL, "tok").Health(ctx); err == nil {
+		t.Fatal("expected a slow API to miss the deadline")
+	}
+}
+
 func TestListSpells(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/spells" {
@@ -76,7 +138,7 @@ func TestListSpells(t *testing.T) {
 	defer srv.Close()
 
 	c := New(srv.URL, "tok")
-	spells, err := c.ListSpells(context.Background(), "", "new", 50, 0)
+	spells, err := c.ListSpells(context.Background(), nil, "new", 50, 0)
 	if err != nil {
 		t.Fatalf("ListSpells() error: %v", err)
 	}
@@ -95,7 +157,7 @@ func TestListSpells_Empty(t *testing.T) {
 	defer srv.Close()
 
 	c := New(srv.URL, "tok")
-	spells, err := c.ListSpells(context.Background(), "", "", 50, 0)
+	spells, err := c.ListSpells(context.Background(), nil, "", 50, 0)
 	if err != nil {
 		t.Fatalf("ListSpells() error: %v", err)
 	}
@@ -104,6 +166,71 @@ func TestListSpells_Empty(t *testing.T) {
 	}
 }
 
+func TestListSpells_Tags(t *testing.T) {
+	var gotTag string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTag = r.URL.Query().Get("tag")
+		json.NewEncoder(w).Encode([]domain.Spell{}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if _, err := c.ListSpells(context.Background(), []string{"debugging", "rust"}, "top", 50, 0); err != nil {
+		t.Fatalf("ListSpells() error: %v", err)
+	}
+	if gotTag != "debugging,rust" {
+		t.Errorf("tag = %q, want %q", gotTag, "debugging,rust")
+	}
+}
+
+func TestListWeapons_Categories(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		json.NewEncoder(w).Encode([]domain.Weapon{{Name: "jq", Categories: []string{"cli", "data"}}}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	weapons, err := c.ListWeapons(context.Background(), []string{"cli", "data"}, 20, 0)
+	if err != nil {
+		t.Fatalf("ListWeapons() error: %v", err)
+	}
+	if got.Get("category") != "cli,data" || got.Get("limit") != "20" {
+		t.Errorf("query = %v, want category=cli,data", got)
+	}
+	if len(weapons) != 1 || !slices.Equal(weapons[0].Categories, []string{"cli", "data"}) {
+		t.Errorf("weapons = %+v", weapons)
+	}
+
+	if _, err := c.ListWeapons(context.Background(), nil, 20, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got.Has("category") {
+		t.Errorf("query = %v, want no category without filters", got)
+	}
+}
+
+func TestTagStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/spells/tags" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode([]domain.TagStat{{Tag: "debugging", SpellCount: 12, TotalUpvotes: 40}}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	stats, err := c.TagStats(context.Background())
+	if err != nil {
+		t.Fatalf("TagStats() error: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Tag != "debugging" || stats[0].SpellCount != 12 {
+		t.Errorf("TagStats() = %+v", stats)
+	}
+}
+
 func TestCreateSpell(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -153,6 +280,33 @@ func TestHTTPError(t *testing.T) {
 	}
 }
 
+func TestHTTPErrorFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error":"validation failed","errors":[{"field":"text","code":"too_short","message":"at least 20 characters"},{"field":"tag","code":"invalid","message":"unknown tag"}]}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	_, err := c.CreateSpell(context.Background(), CreateSpellRequest{Text: "hi", Tag: "nope"})
+	if !IsStatus(err, http.StatusUnprocessableEntity) {
+		t.Fatalf("expected 422, got %v", err)
+	}
+	fields := FieldErrors(err)
+	if len(fields) != 2 {
+		t.Fatalf("FieldErrors() = %+v, want 2", fields)
+	}
+	if fields[0] != (FieldError{Field: "text", Code: CodeTooShort, Message: "at least 20 characters"}) {
+		t.Errorf("fields[0] = %+v", fields[0])
+	}
+	if fields[1].Field != "tag" || fields[1].Code != CodeInvalid {
+		t.Errorf("fields[1] = %+v", fields[1])
+	}
+	if FieldErrors(fmt.Errorf("plain")) != nil {
+		t.Error("expected no field errors for a non-HTTP error")
+	}
+}
+
 func TestIsStatus(t *testing.T) {
 	tests := []struct {
 		name string
@@ -194,4 +348,620 @@ func TestDoRequest_CancelledContext(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for canceled context")
 	}
+	if IsNetwork(err) {
+		t.Errorf("a cancelled request is not a network error: %v", err)
+	}
+}
+
+func TestWithBaseContext_CancelsInFlight(t *testing.T) {
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done() // hang until the client gives up
+	}))
+	defer srv.Close()
+
+	base, cancel := context.WithCancel(context.Background())
+	c := New(srv.URL, "tok", WithBaseContext(base))
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err := c.GetMe(context.Background())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetMe() error = %v, want context.Canceled", err)
+	}
+	if IsNetwork(err) {
+		t.Errorf("an abandoned request is not a network error: %v", err)
+	}
+}
+
+func TestErrorKinds(t *testing.T) {
+	wrap := func(code int) error {
+		return fmt.Errorf("client.GetMe: %w", &HTTPError{StatusCode: code})
+	}
+	tests := []struct {
+		name string
+		is   func(error) bool
+		code int
+	}{
+		{"IsUnauthorized", IsUnauthorized, 401},
+		{"IsForbidden", IsForbidden, 403},
+		{"IsNotFound", IsNotFound, 404},
+		{"IsConflict", IsConflict, 409},
+		{"IsRateLimited", IsRateLimited, 429},
+	}
+	for _, tt := range tests {
+		if !tt.is(wrap(tt.code)) {
+			t.Errorf("%s(HTTP %d) = false, want true", tt.name, tt.code)
+		}
+		if tt.is(wrap(500)) || tt.is(nil) || tt.is(errors.New("HTTP 401")) {
+			t.Errorf("%s matched an unrelated error", tt.name)
+		}
+	}
+	if !errors.Is(wrap(401), ErrUnauthorized) {
+		t.Error("errors.Is(HTTP 401, ErrUnauthorized) = false")
+	}
+}
+
+func TestNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close() // nothing listening any more
+
+	_, err := New(srv.URL, "tok").GetMe(context.Background())
+	if !IsNetwork(err) {
+		t.Fatalf("IsNetwork(%v) = false, want true", err)
+	}
+	if IsUnauthorized(err) || IsNotFound(err) {
+		t.Errorf("a network error matched an HTTP status: %v", err)
+	}
+	if !IsUnsent(err) {
+		t.Errorf("IsUnsent(%v) = false, want a refused connection to count as never sent", err)
+	}
+	if IsUnsent(&NetworkError{Err: context.DeadlineExceeded}) {
+		t.Error("a timeout may have reached the server, so it isn't unsent")
+	}
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) || netErr.Err == nil {
+		t.Errorf("errors.As(%v, *NetworkError) failed", err)
+	}
+}
+
+func TestGetMessagesBefore(t *testing.T) {
+	before := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/threads/t1/messages" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("before"); got != before.Format(time.RFC3339Nano) {
+			t.Errorf("before = %q, want %q", got, before.Format(time.RFC3339Nano))
+		}
+		if got := r.URL.Query().Get("limit"); got != "50" {
+			t.Errorf("limit = %q, want 50", got)
+		}
+		json.NewEncoder(w).Encode([]domain.Message{{Body: "old"}}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	msgs, err := c.GetMessagesBefore(context.Background(), "t1", before, 50)
+	if err != nil {
+		t.Fatalf("GetMessagesBefore() error: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Body != "old" {
+		t.Errorf("unexpected messages: %+v", msgs)
+	}
+}
+
+func TestPreviewSpell(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/spells/preview" {
+			http.NotFound(w, r)
+			return
+		}
+		var req CreateSpellRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Tag != "testing" {
+			t.Errorf("request = %+v, %v", req, err)
+		}
+		json.NewEncoder(w).Encode(domain.ForgeVerdict{Verdict: "REJECT", Reason: "generic"}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	v, err := c.PreviewSpell(context.Background(), CreateSpellRequest{Text: "x", Tag: "testing"})
+	if err != nil {
+		t.Fatalf("PreviewSpell() error: %v", err)
+	}
+	if v.Verdict != "REJECT" || v.Reason != "generic" {
+		t.Errorf("verdict = %+v", v)
+	}
+}
+
+func TestSetSpellContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/spells/s1" {
+			http.NotFound(w, r)
+			return
+		}
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		json.NewEncoder(w).Encode(domain.Spell{Context: req["context"]}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	s, err := c.SetSpellContext(context.Background(), "s1", "Published: https://gist.github.com/x")
+	if err != nil || s.Context != "Published: https://gist.github.com/x" {
+		t.Fatalf("SetSpellContext() = %+v, %v", s, err)
+	}
+}
+
+func TestUpdateSpell(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/spells/s1" {
+			http.NotFound(w, r)
+			return
+		}
+		var req CreateSpellRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		json.NewEncoder(w).Encode(domain.Spell{Text: req.Text, Tag: req.Tag, Status: "pending"}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	s, err := New(srv.URL, "tok").UpdateSpell(context.Background(), "s1", CreateSpellRequest{Text: "check the race detector first", Tag: "debugging"})
+	if err != nil || s.Text != "check the race detector first" || s.Tag != "debugging" {
+		t.Fatalf("UpdateSpell() = %+v, %v", s, err)
+	}
+}
+
+func TestGetRandomSpell(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/spells/random" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+		json.NewEncoder(w).Encode(domain.Spell{Text: "bisect before you guess", Potency: 3}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	s, err := c.GetRandomSpell(context.Background(), 3, "2026-10-16")
+	if err != nil || s.Potency != 3 {
+		t.Fatalf("GetRandomSpell() = %+v, %v", s, err)
+	}
+	if query != "min_potency=3&seed=2026-10-16" {
+		t.Errorf("query = %q", query)
+	}
+	if _, err := c.GetRandomSpell(context.Background(), 0, ""); err != nil || query != "" {
+		t.Errorf("query = %q, %v, want no parameters", query, err)
+	}
+}
+
+func TestCastSpell(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/spells/s1/cast" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"spell_id":"00000000-0000-0000-0000-000000000001","casts":8,"spells_cast":3}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	cast, err := New(srv.URL, "tok").CastSpell(context.Background(), "s1")
+	if err != nil || cast.Casts != 8 || cast.SpellsCast != 3 {
+		t.Fatalf("CastSpell() = %+v, %v", cast, err)
+	}
+}
+
+func TestForkSpell(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/spells/s1/fork" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"id":"00000000-0000-0000-0000-000000000002","text":"Rerun it","forked_from":[{"id":"00000000-0000-0000-0000-000000000001","login":"alice","preview":"Rerun it"}]}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	fork, err := New(srv.URL, "tok").ForkSpell(context.Background(), "s1")
+	if err != nil || len(fork.ForkedFrom) != 1 || fork.ForkedFrom[0].Login != "alice" {
+		t.Fatalf("ForkSpell() = %+v, %v", fork, err)
+	}
+}
+
+func TestRequestReevaluation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/spells/s1/reevaluate" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"status":"pending","old_potency":1,"requested_at":"2026-05-01T10:00:00Z"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	r, err := New(srv.URL, "tok").RequestReevaluation(context.Background(), "s1")
+	if err != nil || !r.Pending() || r.OldPotency != 1 {
+		t.Fatalf("RequestReevaluation() = %+v, %v", r, err)
+	}
+}
+
+func TestRestoreWorkshopProject(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/workshop/p1/restore" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(domain.WorkshopProject{Name: "grimora"}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	p, err := c.RestoreWorkshopProject(context.Background(), "p1")
+	if err != nil || p.Name != "grimora" {
+		t.Fatalf("RestoreWorkshopProject() = %+v, %v", p, err)
+	}
+	if _, err := c.RestoreWorkshopProject(context.Background(), "gone/x"); !IsNotFound(err) {
+		t.Errorf("missing project error = %v, want not found", err)
+	}
+}
+
+func TestStartGroupThread(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Logins []string `json:"logins"`
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/api/threads" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		th := domain.Thread{}
+		for _, login := range body.Logins {
+			th.Participants = append(th.Participants, domain.ThreadParticipant{Login: login})
+		}
+		json.NewEncoder(w).Encode(th) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	th, err := c.StartGroupThread(context.Background(), []string{"ada", "alan"})
+	if err != nil {
+		t.Fatalf("StartGroupThread() error: %v", err)
+	}
+	if !th.IsGroup() || !slices.Equal(th.Logins(), []string{"ada", "alan"}) {
+		t.Errorf("thread = %+v, want a group with ada and alan", th)
+	}
+}
+
+func TestMarkThreadRead(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Method + " " + r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if err := c.MarkThreadRead(context.Background(), "t1"); err != nil {
+		t.Fatalf("MarkThreadRead() error: %v", err)
+	}
+	if got != "POST /api/threads/t1/read" {
+		t.Errorf("request = %q, want POST /api/threads/t1/read", got)
+	}
+}
+
+func TestGetLeaderboardRank(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/leaderboard/octocat" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(domain.LeaderboardEntry{Rank: 412, Login: "octocat"}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	entry, err := c.GetLeaderboardRank(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetLeaderboardRank() error: %v", err)
+	}
+	if entry.Rank != 412 || entry.Login != "octocat" {
+		t.Errorf("entry = %+v, want octocat at 412", entry)
+	}
+	if _, err := c.GetLeaderboardRank(context.Background(), "nobody"); !IsNotFound(err) {
+		t.Errorf("expected not-found for an unranked login, got %v", err)
+	}
+}
+
+func TestGetGuildLeaderboard(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/guilds/standings" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"guild_id":"nyx","rank":1,"members":12,"spells":80,"potency":240,"week":{"spells":6,"potency":20,"last_rank":3}}]`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	standings, err := New(srv.URL, "tok").GetGuildLeaderboard(context.Background())
+	if err != nil {
+		t.Fatalf("GetGuildLeaderboard() error: %v", err)
+	}
+	if len(standings) != 1 || standings[0].GuildID != "nyx" || standings[0].Week == nil || standings[0].RankChange() != 2 {
+		t.Errorf("standings = %+v", standings)
+	}
+}
+
+func TestGetPresence(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/magicians/presence" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("logins"); got != "alice,bob" {
+			t.Errorf("logins = %q, want alice,bob", got)
+		}
+		json.NewEncoder(w).Encode(map[string]bool{"alice": true}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	online, err := c.GetPresence(context.Background(), []string{"alice", "bob"})
+	if err != nil {
+		t.Fatalf("GetPresence() error: %v", err)
+	}
+	if !online["alice"] || online["bob"] {
+		t.Errorf("unexpected presence: %v", online)
+	}
+}
+
+func TestGetPresence_Empty(t *testing.T) {
+	c := New("http://unused.invalid", "tok")
+	online, err := c.GetPresence(context.Background(), nil)
+	if err != nil || online != nil {
+		t.Errorf("expected nil result for no logins, got %v, %v", online, err)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	var got, status string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Method + " " + r.URL.Path
+		var body struct {
+			Status string `json:"status"`
+		}
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		status = body.Status
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := New(srv.URL, "tok").Heartbeat(context.Background(), domain.PresenceAway); err != nil {
+		t.Fatalf("Heartbeat() error: %v", err)
+	}
+	if got != "POST /api/presence/heartbeat" || status != "away" {
+		t.Errorf("request = %q with status %q, want POST /api/presence/heartbeat with away", got, status)
+	}
+}
+
+func TestRevokeInvite(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/invites/ABC123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if err := c.RevokeInvite(context.Background(), "ABC123"); err != nil {
+		t.Fatalf("RevokeInvite() error: %v", err)
+	}
+}
+
+func TestCreateProjectUpdate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/workshop/p1/updates" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		if body["kind"] != "ship" || body["body"] != "v1 is out" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		json.NewEncoder(w).Encode(domain.ProjectUpdate{Kind: body["kind"], Body: body["body"]}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	u, err := c.CreateProjectUpdate(context.Background(), "p1", "ship", "v1 is out")
+	if err != nil {
+		t.Fatalf("CreateProjectUpdate() error: %v", err)
+	}
+	if u.Kind != "ship" {
+		t.Errorf("kind = %q, want ship", u.Kind)
+	}
+}
+
+func TestSetWorkshopProjectURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/workshop/p1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		if body["url"] != "https://github.com/me/proj" {
+			t.Errorf("url = %q", body["url"])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if err := c.SetWorkshopProjectURL(context.Background(), "p1", "https://github.com/me/proj"); err != nil {
+		t.Fatalf("SetWorkshopProjectURL() error: %v", err)
+	}
+}
+
+func TestRequestObserver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	var gotMethod, gotPath string
+	var gotStatus int
+	c := New(srv.URL, "tok", WithRequestObserver(func(method, path string, status int, _ time.Duration) {
+		gotMethod, gotPath, gotStatus = method, path, status
+	}))
+	c.GetMe(context.Background()) //nolint:errcheck // 404 expected
+
+	if gotMethod != http.MethodGet || gotPath != "/api/me" || gotStatus != http.StatusNotFound {
+		t.Errorf("observed %s %s %d, want GET /api/me 404", gotMethod, gotPath, gotStatus)
+	}
+}
+
+func TestChainObservers(t *testing.T) {
+	if ChainObservers(nil, nil) != nil {
+		t.Error("expected nil when no observers are set")
+	}
+	var calls []string
+	a := func(string, string, int, time.Duration) { calls = append(calls, "a") }
+	b := func(string, string, int, time.Duration) { calls = append(calls, "b") }
+	ChainObservers(a, nil, b)("GET", "/api/me", 200, 0)
+	if strings.Join(calls, ",") != "a,b" {
+		t.Errorf("calls = %v, want [a b]", calls)
+	}
+}
+
+func TestSendRoomMessageMetadata(t *testing.T) {
+	var got []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		got = append(got, body)
+		json.NewEncoder(w).Encode(domain.RoomMessage{}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if _, err := c.SendRoomMessage(context.Background(), "hall", "plain", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SendRoomMessage(context.Background(), "hall", "agreed", map[string]string{"reply_to": "abc"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got[0]["metadata"]; ok {
+		t.Errorf("plain message should not send metadata, got %v", got[0])
+	}
+	meta, _ := got[1]["metadata"].(map[string]any)
+	if meta["reply_to"] != "abc" {
+		t.Errorf("reply metadata = %v", got[1])
+	}
+}
+
+func TestSpellSaves(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/api/me/saved-spells" {
+			if r.URL.Query().Get("limit") != "20" {
+				t.Errorf("limit = %q, want 20", r.URL.Query().Get("limit"))
+			}
+			json.NewEncoder(w).Encode([]domain.Spell{{Text: "bisect it", Saved: true}}) //nolint:errcheck
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	ctx := context.Background()
+	if err := c.SaveSpell(ctx, "abc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UnsaveSpell(ctx, "abc"); err != nil {
+		t.Fatal(err)
+	}
+	spells, err := c.ListSavedSpells(ctx, 20, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spells) != 1 || !spells[0].Saved {
+		t.Errorf("saved spells = %+v", spells)
+	}
+	want := []string{"POST /api/spells/abc/save", "DELETE /api/spells/abc/save", "GET /api/me/saved-spells"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestGetForgeHistory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/me/forge-history" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"by_tag":[{"tag":"debugging","forged":3,"rejected":1}],"potency":[{"potency":2,"count":3}],"periods":[{"submitted":4,"accepted":3}],"rank_history":[{"rank":12,"total_ranked":80}]}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	h, err := New(srv.URL, "tok").GetForgeHistory(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.ByTag) != 1 || h.ByTag[0].Rejected != 1 || h.Potency[0].Count != 3 || h.Periods[0].Accepted != 3 || h.RankHistory[0].Rank != 12 {
+		t.Errorf("history = %+v", h)
+	}
+}
+
+func TestListMagicianSpells(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/magicians/octo/spells" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("limit"); got != "3" {
+			t.Errorf("limit = %q, want 3", got)
+		}
+		w.Write([]byte(`[{"text":"bisect the regression","potency":4}]`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	spells, err := New(srv.URL, "tok").ListMagicianSpells(context.Background(), "octo", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spells) != 1 || spells[0].Potency != 4 {
+		t.Errorf("spells = %+v", spells)
+	}
+}
+
+func TestAddReaction(t *testing.T) {
+	var gotPath string
+	var body map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if err := c.AddReaction(context.Background(), "the-hall", "m1", "🔥"); err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "POST /api/rooms/the-hall/messages/m1/reactions" {
+		t.Errorf("request = %q", gotPath)
+	}
+	if body["emoji"] != "🔥" {
+		t.Errorf("body = %v, want emoji 🔥", body)
+	}
 }
@@ -0,0 +1,1635 @@
+// Package clienttest provides an in-memory client.API for tests.
+//
+// A Fake serves whatever data a test puts in its fields, applies writes to
+// that data the way the API would (sending a message appends it, upvoting
+// bumps the count) and records every call:
+//
+//	f := &clienttest.Fake{Spells: []domain.Spell{{ID: id, Text: "..."}}}
+//	f.Fail = map[string]error{"UpvoteSpell": errors.New("boom")}
+//	// ... drive a view with f ...
+//	if f.Count("UpvoteSpell") != 1 { ... }
+package clienttest
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// Call is one recorded method call on a Fake.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Fake is an in-memory client.API. The zero value is ready to use and
+// behaves like an empty account. Set the fields before handing the Fake to
+// the code under test; after that, read them back only once that code is
+// done, since the Fake updates them from its own goroutines' calls.
+type Fake struct {
+	Me             *domain.Magician
+	ForgeStats     *domain.ForgeStats
+	ForgeHistory   *domain.ForgeHistory
+	Spells         []domain.Spell
+	Weapons        []domain.Weapon
+	Drafts         []domain.SpellDraft
+	Magicians      []domain.MagicianCard
+	Leaderboard    []domain.LeaderboardEntry
+	Online         map[string]bool // login → online
+	Presence       string          // status of the last Heartbeat
+	Threads        []domain.Thread
+	Messages       map[string][]domain.Message // thread ID → messages, oldest first
+	Rooms          []domain.Room
+	RoomMessages   map[string][]domain.RoomMessage // room slug → messages, oldest first
+	RoomPresence   map[string]*client.RoomPresence // room slug → presence
+	Reactions      map[string][]client.ReactionCount
+	GuildChests    map[string]*domain.GuildChests // guild ID → chests
+	Invites        []domain.Invite
+	InviteProgress *domain.InviteProgress
+	Projects       []domain.WorkshopProject            // the caller's workshop
+	Deleted        []domain.WorkshopProject            // removed projects RestoreWorkshopProject can bring back
+	Workshops      map[string][]domain.WorkshopProject // other magicians' workshops by login
+	ProjectUpdates map[string][]domain.ProjectUpdate   // project ID → timeline
+	Subscriptions  []domain.Subscription
+	Notifications  []domain.GroupedNotification
+	Reports        []domain.Report      // filed by ReportMessage and ReportSpell
+	Blocked        []string             // logins the caller has blocked
+	Stream         []domain.StreamEvent // activity feed, newest first
+	Limit          client.RateLimit
+	Verdict        *domain.ForgeVerdict      // returned by PreviewSpell; nil accepts
+	Telemetry      *client.TelemetryResponse // returned by GetTelemetry; nil tallies Magicians
+	GuildStandings []domain.GuildStanding    // returned by GetGuildLeaderboard; nil ranks Magicians
+	Server         *client.ServerInfo        // returned by GetServerInfo; nil answers like a server without the handshake
+
+	// Fail makes the named method (e.g. "ListSpells") return the error
+	// instead of doing anything. The call is still recorded.
+	Fail map[string]error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ client.API = (*Fake)(nil)
+
+// Calls returns every call made so far, in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.calls)
+}
+
+// Count returns how many times method was called.
+func (f *Fake) Count(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, c := range f.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// call records a call and returns the error Fail holds for it. f.mu must be
+// held.
+func (f *Fake) call(method string, args ...any) error {
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+	return f.Fail[method]
+}
+
+// notFound returns the error the API gives for a missing resource.
+func notFound(what, id string) error {
+	return &client.HTTPError{StatusCode: 404, Message: fmt.Sprintf("%s %s not found", what, id)}
+}
+
+// page returns the limit items of s starting at offset; limit <= 0 means
+// all of them.
+func page[T any](s []T, limit, offset int) []T {
+	if offset >= len(s) {
+		return nil
+	}
+	s = s[max(offset, 0):]
+	if limit > 0 && limit < len(s) {
+		s = s[:limit]
+	}
+	return slices.Clone(s)
+}
+
+// last returns the final limit items of s; limit <= 0 means all of them.
+func last[T any](s []T, limit int) []T {
+	if limit > 0 && limit < len(s) {
+		s = s[len(s)-limit:]
+	}
+	return slices.Clone(s)
+}
+
+func (f *Fake) login() string {
+	if f.Me == nil {
+		return ""
+	}
+	return f.Me.GitHubLogin
+}
+
+func (f *Fake) myID() uuid.UUID {
+	if f.Me == nil {
+		return uuid.Nil
+	}
+	return f.Me.ID
+}
+
+func (f *Fake) RateLimit() client.RateLimit {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.Limit
+}
+
+// GetServerInfo returns Server, or a not-found error when it is nil.
+func (f *Fake) GetServerInfo(ctx context.Context) (*client.ServerInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetServerInfo"); err != nil {
+		return nil, err
+	}
+	if f.Server == nil {
+		return nil, notFound("route", "/api/version")
+	}
+	info := *f.Server
+	info.Capabilities = slices.Clone(f.Server.Capabilities)
+	return &info, nil
+}
+
+// --- Profile and stats ---
+
+func (f *Fake) GetMe(ctx context.Context) (*domain.Magician, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetMe"); err != nil {
+		return nil, err
+	}
+	if f.Me == nil {
+		return nil, &client.HTTPError{StatusCode: 401, Message: "not signed in"}
+	}
+	me := *f.Me
+	return &me, nil
+}
+
+// UpdateProfile applies the set fields of u to Me.
+func (f *Fake) UpdateProfile(ctx context.Context, u domain.ProfileUpdate) (*domain.Magician, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("UpdateProfile", u); err != nil {
+		return nil, err
+	}
+	if f.Me == nil {
+		return nil, &client.HTTPError{StatusCode: 401, Message: "not signed in"}
+	}
+	if u.Timezone != nil {
+		f.Me.Timezone = *u.Timezone
+	}
+	if u.ActiveHours != nil {
+		f.Me.ActiveHours = *u.ActiveHours
+	}
+	if u.City != nil {
+		f.Me.City = *u.City
+	}
+	if u.DisplayName != nil {
+		f.Me.DisplayName = *u.DisplayName
+	}
+	if u.Bio != nil {
+		f.Me.Bio = *u.Bio
+	}
+	if u.Archetype != nil {
+		f.Me.Archetype = *u.Archetype
+	}
+	me := *f.Me
+	return &me, nil
+}
+
+func (f *Fake) GetForgeStats(ctx context.Context) (*domain.ForgeStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetForgeStats"); err != nil {
+		return nil, err
+	}
+	if f.ForgeStats == nil {
+		return &domain.ForgeStats{}, nil
+	}
+	s := *f.ForgeStats
+	return &s, nil
+}
+
+func (f *Fake) GetForgeHistory(ctx context.Context) (*domain.ForgeHistory, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetForgeHistory"); err != nil {
+		return nil, err
+	}
+	if f.ForgeHistory == nil {
+		return &domain.ForgeHistory{}, nil
+	}
+	h := *f.ForgeHistory
+	return &h, nil
+}
+
+// --- Spells and weapons ---
+
+// ListSpells filters by tags and pages; sort is recorded but the spells keep
+// the order they have in Spells.
+func (f *Fake) ListSpells(ctx context.Context, tags []string, sort string, limit, offset int) ([]domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListSpells", tags, sort, limit, offset); err != nil {
+		return nil, err
+	}
+	var out []domain.Spell
+	for _, s := range f.Spells {
+		if len(tags) == 0 || slices.Contains(tags, s.Tag) {
+			out = append(out, s)
+		}
+	}
+	return page(out, limit, offset), nil
+}
+
+// TagStats counts Spells by tag, most used first.
+func (f *Fake) TagStats(ctx context.Context) ([]domain.TagStat, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("TagStats"); err != nil {
+		return nil, err
+	}
+	var out []domain.TagStat
+	for _, s := range f.Spells {
+		i := slices.IndexFunc(out, func(t domain.TagStat) bool { return t.Tag == s.Tag })
+		if i < 0 {
+			out = append(out, domain.TagStat{Tag: s.Tag})
+			i = len(out) - 1
+		}
+		out[i].SpellCount++
+		out[i].TotalUpvotes += s.Upvotes
+	}
+	slices.SortStableFunc(out, func(a, b domain.TagStat) int { return b.SpellCount - a.SpellCount })
+	return out, nil
+}
+
+func (f *Fake) SearchSpells(ctx context.Context, query string) ([]domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SearchSpells", query); err != nil {
+		return nil, err
+	}
+	q := strings.ToLower(query)
+	var out []domain.Spell
+	for _, s := range f.Spells {
+		if strings.Contains(strings.ToLower(s.Text), q) || strings.Contains(strings.ToLower(s.Situations), q) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (f *Fake) spell(id string) *domain.Spell {
+	for i := range f.Spells {
+		if f.Spells[i].ID.String() == id {
+			return &f.Spells[i]
+		}
+	}
+	return nil
+}
+
+func (f *Fake) GetSpell(ctx context.Context, id string) (*domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetSpell", id); err != nil {
+		return nil, err
+	}
+	s := f.spell(id)
+	if s == nil {
+		return nil, notFound("spell", id)
+	}
+	out := *s
+	return &out, nil
+}
+
+// GetRandomSpell picks one of Spells with at least minPotency that isn't
+// pending or removed. The same seed always picks the same spell; an empty
+// one picks at random.
+func (f *Fake) GetRandomSpell(ctx context.Context, minPotency int, seed string) (*domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetRandomSpell", minPotency, seed); err != nil {
+		return nil, err
+	}
+	var pool []domain.Spell
+	for _, s := range f.Spells {
+		if s.Potency >= minPotency && s.Status != "pending" && s.Status != "removed" {
+			pool = append(pool, s)
+		}
+	}
+	if len(pool) == 0 {
+		return nil, notFound("spell", "random")
+	}
+	i := rand.IntN(len(pool))
+	if seed != "" {
+		h := fnv.New32a()
+		h.Write([]byte(seed)) //nolint:errcheck // hash writes never fail
+		i = int(h.Sum32() % uint32(len(pool)))
+	}
+	out := pool[i]
+	return &out, nil
+}
+
+// CreateSpell adds a pending spell owned by Me.
+func (f *Fake) CreateSpell(ctx context.Context, req client.CreateSpellRequest) (*domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("CreateSpell", req); err != nil {
+		return nil, err
+	}
+	s := domain.Spell{
+		ID:         uuid.New(),
+		MagicianID: f.myID(),
+		Text:       req.Text,
+		Tag:        req.Tag,
+		Model:      req.Model,
+		Stack:      req.Stack,
+		Context:    req.Context,
+		Status:     "pending",
+		CreatedAt:  time.Now(),
+	}
+	f.Spells = append(f.Spells, s)
+	return &s, nil
+}
+
+func (f *Fake) PreviewSpell(ctx context.Context, req client.CreateSpellRequest) (*domain.ForgeVerdict, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("PreviewSpell", req); err != nil {
+		return nil, err
+	}
+	if f.Verdict != nil {
+		v := *f.Verdict
+		return &v, nil
+	}
+	return &domain.ForgeVerdict{Verdict: "ACCEPT", Potency: 2}, nil
+}
+
+// UpdateSpell replaces one of Me's spells and sends it back to pending.
+func (f *Fake) UpdateSpell(ctx context.Context, id string, req client.CreateSpellRequest) (*domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("UpdateSpell", id, req); err != nil {
+		return nil, err
+	}
+	s := f.spell(id)
+	if s == nil {
+		return nil, notFound("spell", id)
+	}
+	if s.MagicianID != f.myID() {
+		return nil, &client.HTTPError{StatusCode: 403, Message: "only the author can edit a spell"}
+	}
+	s.Text, s.Tag, s.Model, s.Stack, s.Context = req.Text, req.Tag, req.Model, req.Stack, req.Context
+	s.Status = "pending"
+	out := *s
+	return &out, nil
+}
+
+func (f *Fake) SetSpellContext(ctx context.Context, id, spellContext string) (*domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SetSpellContext", id, spellContext); err != nil {
+		return nil, err
+	}
+	s := f.spell(id)
+	if s == nil {
+		return nil, notFound("spell", id)
+	}
+	s.Context = spellContext
+	out := *s
+	return &out, nil
+}
+
+func (f *Fake) UpvoteSpell(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("UpvoteSpell", id); err != nil {
+		return err
+	}
+	s := f.spell(id)
+	if s == nil {
+		return notFound("spell", id)
+	}
+	s.Upvotes++
+	return nil
+}
+
+// CastSpell counts a use of the spell and, in ForgeStats, by the caller.
+func (f *Fake) CastSpell(ctx context.Context, id string) (*domain.SpellCast, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("CastSpell", id); err != nil {
+		return nil, err
+	}
+	s := f.spell(id)
+	if s == nil {
+		return nil, notFound("spell", id)
+	}
+	s.Casts++
+	if f.ForgeStats == nil {
+		f.ForgeStats = &domain.ForgeStats{}
+	}
+	f.ForgeStats.SpellsCast++
+	return &domain.SpellCast{SpellID: s.ID, Casts: s.Casts, SpellsCast: f.ForgeStats.SpellsCast}, nil
+}
+
+// ForkSpell copies the spell as the caller's, extending its attribution
+// chain, and counts the fork on the original.
+func (f *Fake) ForkSpell(ctx context.Context, id string) (*domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ForkSpell", id); err != nil {
+		return nil, err
+	}
+	s := f.spell(id)
+	if s == nil {
+		return nil, notFound("spell", id)
+	}
+	s.Forks++
+	origin := domain.SpellOrigin{ID: s.ID, Preview: s.Preview}
+	if s.Author != nil {
+		origin.Login = s.Author.Login
+	}
+	if origin.Preview == "" {
+		origin.Preview = strings.SplitN(s.Text, "\n", 2)[0]
+	}
+	fork := domain.Spell{
+		ID:         uuid.New(),
+		MagicianID: f.myID(),
+		Text:       s.Text,
+		Tag:        s.Tag,
+		Model:      s.Model,
+		Stack:      s.Stack,
+		Context:    s.Context,
+		Potency:    s.Potency,
+		Status:     "published",
+		Preview:    s.Preview,
+		ForkedFrom: append([]domain.SpellOrigin{origin}, s.ForkedFrom...),
+		CreatedAt:  time.Now(),
+	}
+	if f.Me != nil {
+		fork.Author = &domain.Author{Login: f.Me.GitHubLogin, GuildID: f.Me.GuildID, Archetype: f.Me.Archetype}
+	}
+	f.Spells = append(f.Spells, fork)
+	return &fork, nil
+}
+
+// RequestReevaluation marks the caller's spell as waiting on the Grimoire.
+// Tests rule on it by filling in the spell's Reforge.
+func (f *Fake) RequestReevaluation(ctx context.Context, id string) (*domain.Reevaluation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("RequestReevaluation", id); err != nil {
+		return nil, err
+	}
+	s := f.spell(id)
+	if s == nil {
+		return nil, notFound("spell", id)
+	}
+	if s.MagicianID != f.myID() {
+		return nil, &client.HTTPError{StatusCode: 403, Message: "only the author can ask for a re-forge"}
+	}
+	if s.Reforge.Pending() {
+		return nil, &client.HTTPError{StatusCode: 409, Message: "a re-forge is already pending"}
+	}
+	s.Reforge = &domain.Reevaluation{Status: domain.ReevaluationPending, OldPotency: s.Potency, RequestedAt: time.Now()}
+	r := *s.Reforge
+	return &r, nil
+}
+
+func (f *Fake) setSaved(method, id string, saved bool) error {
+	if err := f.call(method, id); err != nil {
+		return err
+	}
+	s := f.spell(id)
+	if s == nil {
+		return notFound("spell", id)
+	}
+	s.Saved = saved
+	return nil
+}
+
+func (f *Fake) SaveSpell(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.setSaved("SaveSpell", id, true)
+}
+
+func (f *Fake) UnsaveSpell(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.setSaved("UnsaveSpell", id, false)
+}
+
+// ListSavedSpells returns the spells in Spells marked Saved.
+func (f *Fake) ListSavedSpells(ctx context.Context, limit, offset int) ([]domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListSavedSpells", limit, offset); err != nil {
+		return nil, err
+	}
+	var out []domain.Spell
+	for _, s := range f.Spells {
+		if s.Saved {
+			out = append(out, s)
+		}
+	}
+	return page(out, limit, offset), nil
+}
+
+func (f *Fake) ListWeapons(ctx context.Context, categories []string, limit, offset int) ([]domain.Weapon, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListWeapons", categories, limit, offset); err != nil {
+		return nil, err
+	}
+	var out []domain.Weapon
+	for _, w := range f.Weapons {
+		if len(categories) == 0 || slices.ContainsFunc(w.Categories, func(c string) bool { return slices.Contains(categories, c) }) {
+			out = append(out, w)
+		}
+	}
+	return page(out, limit, offset), nil
+}
+
+func (f *Fake) GetWeapon(ctx context.Context, id string) (*domain.Weapon, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetWeapon", id); err != nil {
+		return nil, err
+	}
+	for _, w := range f.Weapons {
+		if w.ID.String() == id {
+			return &w, nil
+		}
+	}
+	return nil, notFound("weapon", id)
+}
+
+func (f *Fake) SearchWeapons(ctx context.Context, query string) ([]domain.Weapon, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SearchWeapons", query); err != nil {
+		return nil, err
+	}
+	q := strings.ToLower(query)
+	var out []domain.Weapon
+	for _, w := range f.Weapons {
+		if strings.Contains(strings.ToLower(w.Name), q) || strings.Contains(strings.ToLower(w.Description), q) {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+func (f *Fake) SaveWeapon(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SaveWeapon", id); err != nil {
+		return err
+	}
+	for i := range f.Weapons {
+		if f.Weapons[i].ID.String() == id {
+			f.Weapons[i].SaveCount++
+			return nil
+		}
+	}
+	return notFound("weapon", id)
+}
+
+// --- Spell drafts ---
+
+func (f *Fake) draft(id string) *domain.SpellDraft {
+	for i := range f.Drafts {
+		if f.Drafts[i].ID.String() == id {
+			return &f.Drafts[i]
+		}
+	}
+	return nil
+}
+
+func (f *Fake) ShareSpellDraft(ctx context.Context, req client.CreateSpellRequest) (*domain.SpellDraft, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ShareSpellDraft", req); err != nil {
+		return nil, err
+	}
+	id := uuid.New()
+	d := domain.SpellDraft{
+		ID:         id,
+		MagicianID: f.myID(),
+		Text:       req.Text,
+		Tag:        req.Tag,
+		Model:      req.Model,
+		Context:    req.Context,
+		ShareURL:   "grimora.ai/drafts/" + id.String(),
+		UpdatedAt:  time.Now(),
+	}
+	f.Drafts = append(f.Drafts, d)
+	return &d, nil
+}
+
+func (f *Fake) UpdateSpellDraft(ctx context.Context, id string, req client.CreateSpellRequest) (*domain.SpellDraft, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("UpdateSpellDraft", id, req); err != nil {
+		return nil, err
+	}
+	d := f.draft(id)
+	if d == nil {
+		return nil, notFound("draft", id)
+	}
+	d.Text, d.Tag, d.Model, d.Context = req.Text, req.Tag, req.Model, req.Context
+	d.UpdatedAt = time.Now()
+	out := *d
+	return &out, nil
+}
+
+func (f *Fake) GetSpellDraft(ctx context.Context, id string) (*domain.SpellDraft, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetSpellDraft", id); err != nil {
+		return nil, err
+	}
+	d := f.draft(id)
+	if d == nil {
+		return nil, notFound("draft", id)
+	}
+	out := *d
+	out.Suggestions = slices.Clone(d.Suggestions)
+	return &out, nil
+}
+
+func (f *Fake) SuggestDraftEdit(ctx context.Context, draftID, field, original, replacement, note string) (*domain.DraftSuggestion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SuggestDraftEdit", draftID, field, original, replacement, note); err != nil {
+		return nil, err
+	}
+	d := f.draft(draftID)
+	if d == nil {
+		return nil, notFound("draft", draftID)
+	}
+	s := domain.DraftSuggestion{
+		ID:          uuid.New(),
+		DraftID:     d.ID,
+		AuthorLogin: f.login(),
+		Field:       field,
+		Original:    original,
+		Replacement: replacement,
+		Note:        note,
+		Status:      domain.SuggestionPending,
+		CreatedAt:   time.Now(),
+	}
+	d.Suggestions = append(d.Suggestions, s)
+	return &s, nil
+}
+
+func (f *Fake) ResolveDraftSuggestion(ctx context.Context, draftID, suggestionID string, accept bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ResolveDraftSuggestion", draftID, suggestionID, accept); err != nil {
+		return err
+	}
+	d := f.draft(draftID)
+	if d == nil {
+		return notFound("draft", draftID)
+	}
+	for i := range d.Suggestions {
+		s := &d.Suggestions[i]
+		if s.ID.String() != suggestionID {
+			continue
+		}
+		s.Status = domain.SuggestionRejected
+		if accept {
+			s.Status = domain.SuggestionAccepted
+			if s.Field == "context" {
+				d.Context, _ = s.Apply(d.Context)
+			} else {
+				d.Text, _ = s.Apply(d.Text)
+			}
+		}
+		return nil
+	}
+	return notFound("suggestion", suggestionID)
+}
+
+// --- Magicians ---
+
+func (f *Fake) magician(login string) *domain.MagicianCard {
+	for i := range f.Magicians {
+		if f.Magicians[i].GitHubLogin == login {
+			return &f.Magicians[i]
+		}
+	}
+	return nil
+}
+
+func (f *Fake) ListMagicians(ctx context.Context, limit, offset int) ([]domain.MagicianCard, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListMagicians", limit, offset); err != nil {
+		return nil, err
+	}
+	return page(f.Magicians, limit, offset), nil
+}
+
+func (f *Fake) GetMagician(ctx context.Context, login string) (*domain.MagicianCard, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetMagician", login); err != nil {
+		return nil, err
+	}
+	m := f.magician(login)
+	if m == nil {
+		return nil, notFound("magician", login)
+	}
+	out := *m
+	return &out, nil
+}
+
+func (f *Fake) GetMagicianWorkshop(ctx context.Context, login string) ([]domain.WorkshopProject, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetMagicianWorkshop", login); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.Workshops[login]), nil
+}
+
+// ListMagicianSpells returns login's spells, newest first.
+func (f *Fake) ListMagicianSpells(ctx context.Context, login string, limit int) ([]domain.Spell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListMagicianSpells", login, limit); err != nil {
+		return nil, err
+	}
+	var out []domain.Spell
+	for _, s := range f.Spells {
+		if s.Author != nil && s.Author.Login == login {
+			out = append(out, s)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b domain.Spell) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return page(out, limit, 0), nil
+}
+
+func (f *Fake) GetLeaderboard(ctx context.Context, guild, city string, limit, offset int) ([]domain.LeaderboardEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetLeaderboard", guild, city, limit, offset); err != nil {
+		return nil, err
+	}
+	var out []domain.LeaderboardEntry
+	for _, e := range f.Leaderboard {
+		if (guild == "" || e.GuildID == guild) && (city == "" || strings.EqualFold(e.City, city)) {
+			out = append(out, e)
+		}
+	}
+	return page(out, limit, offset), nil
+}
+
+// GetLeaderboardRank finds login in Leaderboard, ignoring case.
+func (f *Fake) GetLeaderboardRank(ctx context.Context, login string) (*domain.LeaderboardEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetLeaderboardRank", login); err != nil {
+		return nil, err
+	}
+	for _, e := range f.Leaderboard {
+		if strings.EqualFold(e.Login, login) {
+			return &e, nil
+		}
+	}
+	return nil, notFound("magician", login)
+}
+
+// GetGuildLeaderboard returns GuildStandings, or Magicians ranked by
+// domain.RankGuilds when it's nil.
+func (f *Fake) GetGuildLeaderboard(ctx context.Context) ([]domain.GuildStanding, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetGuildLeaderboard"); err != nil {
+		return nil, err
+	}
+	if f.GuildStandings != nil {
+		return slices.Clone(f.GuildStandings), nil
+	}
+	return domain.RankGuilds(f.Magicians), nil
+}
+
+// GetPresence reports Online for each login; unknown logins are offline.
+func (f *Fake) GetPresence(ctx context.Context, logins []string) (map[string]bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetPresence", logins); err != nil {
+		return nil, err
+	}
+	out := make(map[string]bool, len(logins))
+	for _, l := range logins {
+		out[l] = f.Online[l]
+	}
+	return out, nil
+}
+
+// Heartbeat records status as Me's presence.
+func (f *Fake) Heartbeat(ctx context.Context, status string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("Heartbeat", status); err != nil {
+		return err
+	}
+	f.Presence = status
+	return nil
+}
+
+func (f *Fake) setFollowing(method, login string, following bool) error {
+	if err := f.call(method, login); err != nil {
+		return err
+	}
+	if m := f.magician(login); m != nil {
+		m.IsFollowing = following
+	}
+	return nil
+}
+
+func (f *Fake) Follow(ctx context.Context, login string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.setFollowing("Follow", login, true)
+}
+
+func (f *Fake) Unfollow(ctx context.Context, login string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.setFollowing("Unfollow", login, false)
+}
+
+// GetStream pages through Stream. followingOnly keeps the events of
+// magicians in Magicians the caller follows.
+func (f *Fake) GetStream(ctx context.Context, followingOnly bool, limit, offset int) ([]domain.StreamEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetStream", followingOnly, limit, offset); err != nil {
+		return nil, err
+	}
+	events := f.Stream
+	if followingOnly {
+		events = nil
+		for _, e := range f.Stream {
+			if m := f.magician(e.MagicianLogin); m != nil && m.IsFollowing {
+				events = append(events, e)
+			}
+		}
+	}
+	return page(events, limit, offset), nil
+}
+
+// --- DM threads ---
+
+func (f *Fake) ListThreads(ctx context.Context) ([]domain.Thread, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListThreads"); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.Threads), nil
+}
+
+// StartThread returns the thread with login, creating it if there is none.
+func (f *Fake) StartThread(ctx context.Context, login string) (*domain.Thread, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("StartThread", login); err != nil {
+		return nil, err
+	}
+	for _, t := range f.Threads {
+		if !t.IsGroup() && t.OtherLogin == login {
+			return &t, nil
+		}
+	}
+	t := domain.Thread{ID: uuid.New(), OtherLogin: login, CreatedAt: time.Now()}
+	f.Threads = append(f.Threads, t)
+	return &t, nil
+}
+
+// StartGroupThread returns the group thread with exactly logins, creating
+// it if there is none.
+func (f *Fake) StartGroupThread(ctx context.Context, logins []string) (*domain.Thread, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("StartGroupThread", logins); err != nil {
+		return nil, err
+	}
+	want := slices.Sorted(slices.Values(logins))
+	for _, t := range f.Threads {
+		if t.IsGroup() && slices.Equal(slices.Sorted(slices.Values(t.Logins())), want) {
+			return &t, nil
+		}
+	}
+	t := domain.Thread{ID: uuid.New(), CreatedAt: time.Now()}
+	for _, login := range logins {
+		t.Participants = append(t.Participants, domain.ThreadParticipant{Login: login})
+	}
+	f.Threads = append(f.Threads, t)
+	return &t, nil
+}
+
+// GetMessages pages from the newest message backwards, like the API.
+func (f *Fake) GetMessages(ctx context.Context, threadID string, limit, offset int) ([]domain.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetMessages", threadID, limit, offset); err != nil {
+		return nil, err
+	}
+	msgs := f.Messages[threadID]
+	return last(msgs[:max(len(msgs)-offset, 0)], limit), nil
+}
+
+// GetMessagesBefore returns the newest limit messages older than before, or
+// the newest limit overall when before is zero.
+func (f *Fake) GetMessagesBefore(ctx context.Context, threadID string, before time.Time, limit int) ([]domain.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetMessagesBefore", threadID, before, limit); err != nil {
+		return nil, err
+	}
+	var out []domain.Message
+	for _, m := range f.Messages[threadID] {
+		if before.IsZero() || m.CreatedAt.Before(before) {
+			out = append(out, m)
+		}
+	}
+	return last(out, limit), nil
+}
+
+func (f *Fake) SendMessage(ctx context.Context, threadID, body string) (*domain.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SendMessage", threadID, body); err != nil {
+		return nil, err
+	}
+	tid, err := uuid.Parse(threadID)
+	if err != nil {
+		return nil, notFound("thread", threadID)
+	}
+	m := domain.Message{
+		ID:          uuid.New(),
+		ThreadID:    tid,
+		SenderID:    f.myID(),
+		SenderLogin: f.login(),
+		Body:        body,
+		CreatedAt:   time.Now(),
+	}
+	if f.Messages == nil {
+		f.Messages = make(map[string][]domain.Message)
+	}
+	f.Messages[threadID] = append(f.Messages[threadID], m)
+	for i := range f.Threads {
+		if f.Threads[i].ID == tid {
+			f.Threads[i].LastMessage = body
+			f.Threads[i].LastMessageAt = m.CreatedAt
+		}
+	}
+	return &m, nil
+}
+
+// MarkThreadRead zeroes the thread's unread count and stamps ReadAt on the
+// messages the other party sent.
+func (f *Fake) MarkThreadRead(ctx context.Context, threadID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("MarkThreadRead", threadID); err != nil {
+		return err
+	}
+	now := time.Now()
+	me := f.myID()
+	msgs := f.Messages[threadID]
+	for i := range msgs {
+		if msgs[i].SenderID != me && msgs[i].ReadAt == nil {
+			msgs[i].ReadAt = &now
+		}
+	}
+	for i := range f.Threads {
+		if f.Threads[i].ID.String() == threadID {
+			f.Threads[i].Unread = 0
+		}
+	}
+	return nil
+}
+
+// --- Rooms ---
+
+func (f *Fake) room(slug string) *domain.Room {
+	for i := range f.Rooms {
+		if f.Rooms[i].Slug == slug {
+			return &f.Rooms[i]
+		}
+	}
+	return nil
+}
+
+func (f *Fake) ListRooms(ctx context.Context) ([]domain.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListRooms"); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.Rooms), nil
+}
+
+func (f *Fake) JoinRoom(ctx context.Context, slug string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("JoinRoom", slug); err != nil {
+		return err
+	}
+	if f.room(slug) == nil {
+		return notFound("room", slug)
+	}
+	return nil
+}
+
+// CreateRoom adds a topic room owned by Me. Taken slugs fail with a 409.
+func (f *Fake) CreateRoom(ctx context.Context, req client.CreateRoomRequest) (*domain.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("CreateRoom", req); err != nil {
+		return nil, err
+	}
+	if f.room(req.Slug) != nil {
+		return nil, &client.HTTPError{StatusCode: 409, Message: "room already exists", Fields: []client.FieldError{
+			{Field: "slug", Code: client.CodeDuplicate, Message: "that name is taken"},
+		}}
+	}
+	name := req.Name
+	if name == "" {
+		name = req.Slug
+	}
+	me := f.myID()
+	r := domain.Room{
+		ID:          uuid.New(),
+		Slug:        req.Slug,
+		Name:        name,
+		RoomType:    domain.RoomTypeTopic,
+		CreatedBy:   &me,
+		Description: req.Description,
+		CreatedAt:   time.Now(),
+	}
+	f.Rooms = append(f.Rooms, r)
+	return &r, nil
+}
+
+func (f *Fake) ArchiveRoom(ctx context.Context, slug string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ArchiveRoom", slug); err != nil {
+		return err
+	}
+	r := f.room(slug)
+	if r == nil {
+		return notFound("room", slug)
+	}
+	r.Archived = true
+	return nil
+}
+
+func (f *Fake) SetRoomTopic(ctx context.Context, slug, topic string) (*domain.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SetRoomTopic", slug, topic); err != nil {
+		return nil, err
+	}
+	r := f.room(slug)
+	if r == nil {
+		return nil, notFound("room", slug)
+	}
+	r.Topic = topic
+	out := *r
+	return &out, nil
+}
+
+// GetRoomMessages returns the newest limit messages older than before, or
+// the newest limit overall when before is zero.
+func (f *Fake) GetRoomMessages(ctx context.Context, slug string, before time.Time, limit int) ([]domain.RoomMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetRoomMessages", slug, before, limit); err != nil {
+		return nil, err
+	}
+	var out []domain.RoomMessage
+	for _, m := range f.RoomMessages[slug] {
+		if before.IsZero() || m.CreatedAt.Before(before) {
+			out = append(out, m)
+		}
+	}
+	return last(out, limit), nil
+}
+
+func (f *Fake) GetRoomPresence(ctx context.Context, slug string) (*client.RoomPresence, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetRoomPresence", slug); err != nil {
+		return nil, err
+	}
+	if p := f.RoomPresence[slug]; p != nil {
+		out := *p
+		out.Magicians = slices.Clone(p.Magicians)
+		return &out, nil
+	}
+	return &client.RoomPresence{RoomSlug: slug}, nil
+}
+
+func (f *Fake) SendRoomMessage(ctx context.Context, slug, body string, metadata map[string]string) (*domain.RoomMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SendRoomMessage", slug, body, metadata); err != nil {
+		return nil, err
+	}
+	m := domain.RoomMessage{
+		ID:          uuid.New(),
+		SenderID:    f.myID(),
+		SenderLogin: f.login(),
+		Body:        body,
+		Kind:        "text",
+		CreatedAt:   time.Now(),
+	}
+	if f.Me != nil {
+		m.SenderGuild = f.Me.GuildID
+	}
+	if r := f.room(slug); r != nil {
+		m.RoomID = r.ID
+		r.LastMessageAt = m.CreatedAt
+	}
+	if len(metadata) > 0 {
+		m.Metadata, _ = json.Marshal(metadata)
+	}
+	if f.RoomMessages == nil {
+		f.RoomMessages = make(map[string][]domain.RoomMessage)
+	}
+	f.RoomMessages[slug] = append(f.RoomMessages[slug], m)
+	return &m, nil
+}
+
+func (f *Fake) GetReactionCounts(ctx context.Context, slug string, msgIDs []string) (map[string][]client.ReactionCount, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetReactionCounts", slug, msgIDs); err != nil {
+		return nil, err
+	}
+	out := make(map[string][]client.ReactionCount)
+	for _, id := range msgIDs {
+		if rc := f.Reactions[id]; len(rc) > 0 {
+			out[id] = slices.Clone(rc)
+		}
+	}
+	return out, nil
+}
+
+func (f *Fake) AddReaction(ctx context.Context, slug, msgID, emoji string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("AddReaction", slug, msgID, emoji); err != nil {
+		return err
+	}
+	if f.Reactions == nil {
+		f.Reactions = make(map[string][]client.ReactionCount)
+	}
+	rc := f.Reactions[msgID]
+	for i := range rc {
+		if rc[i].Emoji == emoji {
+			rc[i].Count++
+			return nil
+		}
+	}
+	f.Reactions[msgID] = append(rc, client.ReactionCount{Emoji: emoji, Count: 1})
+	return nil
+}
+
+// --- Guild chests ---
+
+func (f *Fake) chest(guildID, chestID string) *domain.GuildChest {
+	g := f.GuildChests[guildID]
+	if g == nil {
+		return nil
+	}
+	for i := range g.Chests {
+		if g.Chests[i].ID.String() == chestID {
+			return &g.Chests[i]
+		}
+	}
+	return nil
+}
+
+func (f *Fake) ListGuildChests(ctx context.Context, guildID string) (*domain.GuildChests, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListGuildChests", guildID); err != nil {
+		return nil, err
+	}
+	g := f.GuildChests[guildID]
+	if g == nil {
+		return &domain.GuildChests{}, nil
+	}
+	out := *g
+	out.Chests = slices.Clone(g.Chests)
+	for i := range out.Chests {
+		out.Chests[i].Spells = nil
+	}
+	out.Contributions = slices.Clone(g.Contributions)
+	return &out, nil
+}
+
+func (f *Fake) GetGuildChest(ctx context.Context, guildID, chestID string) (*domain.GuildChest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetGuildChest", guildID, chestID); err != nil {
+		return nil, err
+	}
+	c := f.chest(guildID, chestID)
+	if c == nil {
+		return nil, notFound("chest", chestID)
+	}
+	out := *c
+	out.Spells = slices.Clone(c.Spells)
+	return &out, nil
+}
+
+// AddChestSpell copies the spell from Spells into the chest.
+func (f *Fake) AddChestSpell(ctx context.Context, guildID, chestID, spellID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("AddChestSpell", guildID, chestID, spellID); err != nil {
+		return err
+	}
+	c := f.chest(guildID, chestID)
+	if c == nil {
+		return notFound("chest", chestID)
+	}
+	s := f.spell(spellID)
+	if s == nil {
+		return notFound("spell", spellID)
+	}
+	c.Spells = append(c.Spells, domain.ChestSpell{Spell: *s, AddedBy: f.login(), AddedAt: time.Now()})
+	c.SpellCount = len(c.Spells)
+	return nil
+}
+
+func (f *Fake) RemoveChestSpell(ctx context.Context, guildID, chestID, spellID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("RemoveChestSpell", guildID, chestID, spellID); err != nil {
+		return err
+	}
+	c := f.chest(guildID, chestID)
+	if c == nil {
+		return notFound("chest", chestID)
+	}
+	c.Spells = slices.DeleteFunc(c.Spells, func(s domain.ChestSpell) bool { return s.ID.String() == spellID })
+	c.SpellCount = len(c.Spells)
+	return nil
+}
+
+// --- Invites ---
+
+func (f *Fake) ListInvites(ctx context.Context) ([]domain.Invite, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListInvites"); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.Invites), nil
+}
+
+func (f *Fake) GetInviteProgress(ctx context.Context) (*domain.InviteProgress, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetInviteProgress"); err != nil {
+		return nil, err
+	}
+	if f.InviteProgress == nil {
+		return &domain.InviteProgress{}, nil
+	}
+	p := *f.InviteProgress
+	return &p, nil
+}
+
+// --- Workshop ---
+
+func (f *Fake) project(id string) *domain.WorkshopProject {
+	for i := range f.Projects {
+		if f.Projects[i].ID.String() == id {
+			return &f.Projects[i]
+		}
+	}
+	return nil
+}
+
+func (f *Fake) ListWorkshopProjects(ctx context.Context) ([]domain.WorkshopProject, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListWorkshopProjects"); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.Projects), nil
+}
+
+func (f *Fake) CreateWorkshopProject(ctx context.Context, name, insight string) (*domain.WorkshopProject, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("CreateWorkshopProject", name, insight); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	p := domain.WorkshopProject{ID: uuid.New(), MagicianID: f.myID(), Name: name, Insight: insight, CreatedAt: now, UpdatedAt: now}
+	f.Projects = append(f.Projects, p)
+	return &p, nil
+}
+
+func (f *Fake) UpdateWorkshopProject(ctx context.Context, id, name, insight string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("UpdateWorkshopProject", id, name, insight); err != nil {
+		return err
+	}
+	p := f.project(id)
+	if p == nil {
+		return notFound("project", id)
+	}
+	p.Name, p.Insight, p.UpdatedAt = name, insight, time.Now()
+	return nil
+}
+
+func (f *Fake) SetWorkshopProjectURL(ctx context.Context, id, repoURL string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("SetWorkshopProjectURL", id, repoURL); err != nil {
+		return err
+	}
+	p := f.project(id)
+	if p == nil {
+		return notFound("project", id)
+	}
+	p.URL, p.UpdatedAt = repoURL, time.Now()
+	return nil
+}
+
+func (f *Fake) DeleteWorkshopProject(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("DeleteWorkshopProject", id); err != nil {
+		return err
+	}
+	p := f.project(id)
+	if p == nil {
+		return notFound("project", id)
+	}
+	f.Deleted = append(f.Deleted, *p)
+	f.Projects = slices.DeleteFunc(f.Projects, func(p domain.WorkshopProject) bool { return p.ID.String() == id })
+	return nil
+}
+
+func (f *Fake) RestoreWorkshopProject(ctx context.Context, id string) (*domain.WorkshopProject, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("RestoreWorkshopProject", id); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(f.Deleted, func(p domain.WorkshopProject) bool { return p.ID.String() == id })
+	if i < 0 {
+		return nil, notFound("project", id)
+	}
+	p := f.Deleted[i]
+	f.Deleted = slices.Delete(f.Deleted, i, i+1)
+	f.Projects = append(f.Projects, p)
+	return &p, nil
+}
+
+func (f *Fake) ListProjectUpdates(ctx context.Context, projectID string) ([]domain.ProjectUpdate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListProjectUpdates", projectID); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.ProjectUpdates[projectID]), nil
+}
+
+func (f *Fake) CreateProjectUpdate(ctx context.Context, projectID, kind, body string) (*domain.ProjectUpdate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("CreateProjectUpdate", projectID, kind, body); err != nil {
+		return nil, err
+	}
+	p := f.project(projectID)
+	if p == nil {
+		return nil, notFound("project", projectID)
+	}
+	u := domain.ProjectUpdate{ID: uuid.New(), ProjectID: p.ID, Kind: kind, Body: body, CreatedAt: time.Now()}
+	if f.ProjectUpdates == nil {
+		f.ProjectUpdates = make(map[string][]domain.ProjectUpdate)
+	}
+	f.ProjectUpdates[projectID] = append(f.ProjectUpdates[projectID], u)
+	return &u, nil
+}
+
+// --- Watching and notifications ---
+
+func (f *Fake) Watch(ctx context.Context, targetType, targetID string) (*domain.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("Watch", targetType, targetID); err != nil {
+		return nil, err
+	}
+	for _, s := range f.Subscriptions {
+		if s.TargetType == targetType && s.TargetID == targetID {
+			return &s, nil
+		}
+	}
+	s := domain.Subscription{ID: uuid.New(), TargetType: targetType, TargetID: targetID, CreatedAt: time.Now()}
+	f.Subscriptions = append(f.Subscriptions, s)
+	return &s, nil
+}
+
+func (f *Fake) Unwatch(ctx context.Context, targetType, targetID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("Unwatch", targetType, targetID); err != nil {
+		return err
+	}
+	f.Subscriptions = slices.DeleteFunc(f.Subscriptions, func(s domain.Subscription) bool {
+		return s.TargetType == targetType && s.TargetID == targetID
+	})
+	return nil
+}
+
+func (f *Fake) ListSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListSubscriptions"); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.Subscriptions), nil
+}
+
+func (f *Fake) MarkSubscriptionRead(ctx context.Context, targetType, targetID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("MarkSubscriptionRead", targetType, targetID); err != nil {
+		return err
+	}
+	for i := range f.Subscriptions {
+		if f.Subscriptions[i].TargetType == targetType && f.Subscriptions[i].TargetID == targetID {
+			f.Subscriptions[i].Unread = 0
+		}
+	}
+	return nil
+}
+
+func (f *Fake) ListNotifications(ctx context.Context, limit int) ([]domain.GroupedNotification, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListNotifications", limit); err != nil {
+		return nil, err
+	}
+	return page(f.Notifications, limit, 0), nil
+}
+
+func (f *Fake) MarkNotificationRead(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("MarkNotificationRead", id); err != nil {
+		return err
+	}
+	for i := range f.Notifications {
+		if f.Notifications[i].ID.String() == id {
+			f.Notifications[i].Read = true
+			return nil
+		}
+	}
+	return notFound("notification", id)
+}
+
+func (f *Fake) MarkAllNotificationsRead(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("MarkAllNotificationsRead"); err != nil {
+		return err
+	}
+	for i := range f.Notifications {
+		f.Notifications[i].Read = true
+	}
+	return nil
+}
+
+// GetTelemetry returns Telemetry when set; otherwise it tallies Magicians.
+func (f *Fake) GetTelemetry(ctx context.Context) (*client.TelemetryResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetTelemetry"); err != nil {
+		return nil, err
+	}
+	if f.Telemetry != nil {
+		t := *f.Telemetry
+		return &t, nil
+	}
+	t := client.TelemetryResponse{Total: len(f.Magicians)}
+	cities := make(map[string]int)
+	guilds := make(map[string]int)
+	joins := make(map[string]int)
+	for _, m := range f.Magicians {
+		if m.City != "" {
+			cities[m.City]++
+		}
+		guilds[m.GuildID]++
+		if !m.CreatedAt.IsZero() {
+			joins[m.CreatedAt.UTC().Format(time.DateOnly)]++
+		}
+	}
+	for city, n := range cities {
+		t.Cities = append(t.Cities, client.CityCountEntry{City: city, Count: n})
+	}
+	slices.SortFunc(t.Cities, func(a, b client.CityCountEntry) int { return cmp.Or(b.Count-a.Count, strings.Compare(a.City, b.City)) })
+	for id, n := range guilds {
+		t.Guilds = append(t.Guilds, client.GuildCountEntry{GuildID: id, Count: n})
+	}
+	slices.SortFunc(t.Guilds, func(a, b client.GuildCountEntry) int { return strings.Compare(a.GuildID, b.GuildID) })
+	for day, n := range joins {
+		t.Joins = append(t.Joins, client.DayCountEntry{Day: day, Count: n})
+	}
+	slices.SortFunc(t.Joins, func(a, b client.DayCountEntry) int { return strings.Compare(a.Day, b.Day) })
+	return &t, nil
+}
+
+// --- Moderation ---
+
+func (f *Fake) ReportMessage(ctx context.Context, msgID, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ReportMessage", msgID, reason); err != nil {
+		return err
+	}
+	f.Reports = append(f.Reports, domain.Report{TargetType: domain.ReportTargetMessage, TargetID: msgID, Reason: reason})
+	return nil
+}
+
+func (f *Fake) ReportSpell(ctx context.Context, spellID, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ReportSpell", spellID, reason); err != nil {
+		return err
+	}
+	f.Reports = append(f.Reports, domain.Report{TargetType: domain.ReportTargetSpell, TargetID: spellID, Reason: reason})
+	return nil
+}
+
+func (f *Fake) BlockMagician(ctx context.Context, login string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("BlockMagician", login); err != nil {
+		return err
+	}
+	if !slices.Contains(f.Blocked, login) {
+		f.Blocked = append(f.Blocked, login)
+	}
+	return nil
+}
+
+func (f *Fake) UnblockMagician(ctx context.Context, login string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("UnblockMagician", login); err != nil {
+		return err
+	}
+	f.Blocked = slices.DeleteFunc(f.Blocked, func(l string) bool { return l == login })
+	return nil
+}
+
+func (f *Fake) ListBlocked(ctx context.Context) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListBlocked"); err != nil {
+		return nil, err
+	}
+	return slices.Clone(f.Blocked), nil
+}
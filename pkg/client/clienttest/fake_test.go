@@ -0,0 +1,267 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/client"
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestFakeSpells(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	f := &Fake{Spells: []domain.Spell{
+		{ID: id, Text: "triage flaky tests", Tag: "testing"},
+		{ID: uuid.New(), Text: "review a diff", Tag: "review"},
+	}}
+
+	got, err := f.ListSpells(ctx, []string{"testing"}, "new", 10, 0)
+	if err != nil || len(got) != 1 || got[0].ID != id {
+		t.Fatalf("ListSpells(testing) = %v, %v", got, err)
+	}
+	if got, _ := f.ListSpells(ctx, []string{"testing", "review"}, "new", 10, 0); len(got) != 2 {
+		t.Errorf("ListSpells(testing, review) returned %d spells, want 2", len(got))
+	}
+	if stats, _ := f.TagStats(ctx); len(stats) != 2 || stats[0].SpellCount != 1 {
+		t.Errorf("TagStats() = %+v", stats)
+	}
+	if got, _ := f.SearchSpells(ctx, "DIFF"); len(got) != 1 {
+		t.Errorf("SearchSpells matched %d spells, want 1", len(got))
+	}
+
+	if err := f.UpvoteSpell(ctx, id.String()); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SaveSpell(ctx, id.String()); err != nil {
+		t.Fatal(err)
+	}
+	s, _ := f.GetSpell(ctx, id.String())
+	if s.Upvotes != 1 || !s.Saved {
+		t.Errorf("spell after upvote and save = %+v", s)
+	}
+	if saved, _ := f.ListSavedSpells(ctx, 10, 0); len(saved) != 1 {
+		t.Errorf("ListSavedSpells returned %d spells, want 1", len(saved))
+	}
+
+	if _, err := f.GetSpell(ctx, uuid.NewString()); !client.IsStatus(err, 404) {
+		t.Errorf("GetSpell(missing) err = %v, want a 404", err)
+	}
+}
+
+func TestFakeGetRandomSpell(t *testing.T) {
+	ctx := context.Background()
+	f := &Fake{Spells: []domain.Spell{
+		{ID: uuid.New(), Potency: 3, Status: "published"},
+		{ID: uuid.New(), Potency: 3, Status: "pending"},
+		{ID: uuid.New(), Potency: 1, Status: "published"},
+		{ID: uuid.New(), Potency: 3},
+	}}
+	first, err := f.GetRandomSpell(ctx, 3, "2026-10-16")
+	if err != nil || first.Potency != 3 || first.Status == "pending" {
+		t.Fatalf("GetRandomSpell() = %+v, %v", first, err)
+	}
+	for range 5 {
+		if s, _ := f.GetRandomSpell(ctx, 3, "2026-10-16"); s.ID != first.ID {
+			t.Fatalf("same seed picked %s, then %s", first.ID, s.ID)
+		}
+	}
+	if _, err := f.GetRandomSpell(ctx, 4, ""); !client.IsNotFound(err) {
+		t.Errorf("err = %v, want not found when nothing is potent enough", err)
+	}
+}
+
+func TestFakeForkSpell(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	f := &Fake{
+		Me:     &domain.Magician{ID: uuid.New(), GitHubLogin: "ada"},
+		Spells: []domain.Spell{{ID: id, Text: "triage flaky tests\nrerun them first", Author: &domain.Author{Login: "alice"}}},
+	}
+
+	fork, err := f.ForkSpell(ctx, id.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fork.Author.Login != "ada" || len(fork.ForkedFrom) != 1 || fork.ForkedFrom[0].Login != "alice" || fork.ForkedFrom[0].Preview != "triage flaky tests" {
+		t.Errorf("fork = %+v", fork)
+	}
+	again, _ := f.ForkSpell(ctx, fork.ID.String())
+	if len(again.ForkedFrom) != 2 || again.ForkedFrom[0].ID != fork.ID || again.ForkedFrom[1].ID != id {
+		t.Errorf("fork of a fork has chain %+v", again.ForkedFrom)
+	}
+	if s, _ := f.GetSpell(ctx, id.String()); s.Forks != 1 {
+		t.Errorf("original Forks = %d, want 1", s.Forks)
+	}
+}
+
+func TestFakeRequestReevaluation(t *testing.T) {
+	ctx := context.Background()
+	me := &domain.Magician{ID: uuid.New()}
+	mine, theirs := uuid.New(), uuid.New()
+	f := &Fake{Me: me, Spells: []domain.Spell{
+		{ID: mine, MagicianID: me.ID, Potency: 1},
+		{ID: theirs, MagicianID: uuid.New()},
+	}}
+
+	r, err := f.RequestReevaluation(ctx, mine.String())
+	if err != nil || !r.Pending() || r.OldPotency != 1 {
+		t.Fatalf("RequestReevaluation() = %+v, %v", r, err)
+	}
+	if s, _ := f.GetSpell(ctx, mine.String()); !s.Reforge.Pending() {
+		t.Errorf("spell Reforge = %+v, want pending", s.Reforge)
+	}
+	if _, err := f.RequestReevaluation(ctx, mine.String()); !client.IsConflict(err) {
+		t.Errorf("second request err = %v, want a conflict", err)
+	}
+	if _, err := f.RequestReevaluation(ctx, theirs.String()); !client.IsForbidden(err) {
+		t.Errorf("someone else's spell err = %v, want forbidden", err)
+	}
+}
+
+func TestFakeMagicianSpellsAndThreads(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	octo := &domain.Author{Login: "octo"}
+	f := &Fake{Spells: []domain.Spell{
+		{Text: "old", Author: octo, CreatedAt: now.Add(-time.Hour)},
+		{Text: "someone else's", Author: &domain.Author{Login: "mona"}, CreatedAt: now},
+		{Text: "new", Author: octo, CreatedAt: now},
+	}}
+
+	got, err := f.ListMagicianSpells(ctx, "octo", 3)
+	if err != nil || len(got) != 2 || got[0].Text != "new" {
+		t.Fatalf("ListMagicianSpells(octo) = %v, %v", got, err)
+	}
+
+	first, err := f.StartThread(ctx, "octo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := f.StartThread(ctx, "octo")
+	if again.ID != first.ID || len(f.Threads) != 1 {
+		t.Errorf("StartThread twice made %d threads, want 1", len(f.Threads))
+	}
+}
+
+func TestFakeFailAndCalls(t *testing.T) {
+	ctx := context.Background()
+	boom := errors.New("boom")
+	f := &Fake{Fail: map[string]error{"ListRooms": boom}}
+
+	if _, err := f.ListRooms(ctx); !errors.Is(err, boom) {
+		t.Errorf("ListRooms err = %v, want boom", err)
+	}
+	f.JoinRoom(ctx, "go-tips") //nolint:errcheck
+
+	calls := f.Calls()
+	if len(calls) != 2 || calls[0].Method != "ListRooms" || calls[1].Method != "JoinRoom" {
+		t.Fatalf("Calls() = %+v", calls)
+	}
+	if calls[1].Args[0] != "go-tips" {
+		t.Errorf("JoinRoom args = %v", calls[1].Args)
+	}
+	if f.Count("JoinRoom") != 1 {
+		t.Errorf("Count(JoinRoom) = %d, want 1", f.Count("JoinRoom"))
+	}
+}
+
+func TestFakeRooms(t *testing.T) {
+	ctx := context.Background()
+	f := &Fake{Me: &domain.Magician{ID: uuid.New(), GitHubLogin: "ada", GuildID: "loomari"}}
+
+	r, err := f.CreateRoom(ctx, client.CreateRoomRequest{Slug: "go-tips"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.OwnedBy(f.Me.ID) || r.Name != "go-tips" {
+		t.Errorf("created room = %+v", r)
+	}
+	if _, err := f.CreateRoom(ctx, client.CreateRoomRequest{Slug: "go-tips"}); !client.IsStatus(err, 409) {
+		t.Errorf("duplicate CreateRoom err = %v, want a 409", err)
+	}
+
+	if _, err := f.SendRoomMessage(ctx, "go-tips", "hi", map[string]string{"spell_id": "x"}); err != nil {
+		t.Fatal(err)
+	}
+	msgs, _ := f.GetRoomMessages(ctx, "go-tips", time.Time{}, 50)
+	if len(msgs) != 1 || msgs[0].SenderLogin != "ada" || msgs[0].RoomID != r.ID {
+		t.Fatalf("room messages = %+v", msgs)
+	}
+	if old, _ := f.GetRoomMessages(ctx, "go-tips", msgs[0].CreatedAt, 50); len(old) != 0 {
+		t.Errorf("messages before the first = %d, want 0", len(old))
+	}
+
+	f.AddReaction(ctx, "go-tips", msgs[0].ID.String(), "🔥") //nolint:errcheck
+	f.AddReaction(ctx, "go-tips", msgs[0].ID.String(), "🔥") //nolint:errcheck
+	counts, _ := f.GetReactionCounts(ctx, "go-tips", []string{msgs[0].ID.String()})
+	if rc := counts[msgs[0].ID.String()]; len(rc) != 1 || rc[0].Count != 2 {
+		t.Errorf("reactions = %+v", rc)
+	}
+}
+
+func TestFakeMessagesPaging(t *testing.T) {
+	ctx := context.Background()
+	thread := uuid.New()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var msgs []domain.Message
+	for i := range 5 {
+		msgs = append(msgs, domain.Message{ID: uuid.New(), ThreadID: thread, Body: string(rune('a' + i)), CreatedAt: base.Add(time.Duration(i) * time.Minute)})
+	}
+	f := &Fake{Messages: map[string][]domain.Message{thread.String(): msgs}}
+
+	got, _ := f.GetMessages(ctx, thread.String(), 2, 0)
+	if len(got) != 2 || got[0].Body != "d" || got[1].Body != "e" {
+		t.Errorf("newest page = %v", got)
+	}
+	got, _ = f.GetMessagesBefore(ctx, thread.String(), msgs[3].CreatedAt, 2)
+	if len(got) != 2 || got[0].Body != "b" || got[1].Body != "c" {
+		t.Errorf("page before d = %v", got)
+	}
+}
+
+func TestFakeTelemetryTalliesMagicians(t *testing.T) {
+	day := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	card := func(city, guild string, joined time.Time) domain.MagicianCard {
+		return domain.MagicianCard{Magician: domain.Magician{City: city, GuildID: guild, CreatedAt: joined}}
+	}
+	f := &Fake{Magicians: []domain.MagicianCard{
+		card("Lagos", "nyx", day),
+		card("Berlin", "nyx", day),
+		card("Berlin", "cipher", day.AddDate(0, 0, -1)),
+	}}
+	got, err := f.GetTelemetry(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != 3 || got.Cities[0] != (client.CityCountEntry{City: "Berlin", Count: 2}) {
+		t.Errorf("total/cities = %d %+v", got.Total, got.Cities)
+	}
+	if len(got.Joins) != 2 || got.Joins[1] != (client.DayCountEntry{Day: "2026-10-15", Count: 2}) {
+		t.Errorf("joins = %+v", got.Joins)
+	}
+	if len(got.Guilds) != 2 || got.Guilds[1] != (client.GuildCountEntry{GuildID: "nyx", Count: 2}) {
+		t.Errorf("guilds = %+v", got.Guilds)
+	}
+}
+
+func TestFakeGetGuildLeaderboard(t *testing.T) {
+	ctx := context.Background()
+	f := &Fake{Magicians: []domain.MagicianCard{
+		{Magician: domain.Magician{GuildID: "cipher"}, SpellCount: 2, TotalPotency: 9},
+		{Magician: domain.Magician{GuildID: "nyx"}, SpellCount: 1, TotalPotency: 3},
+	}}
+	got, err := f.GetGuildLeaderboard(ctx)
+	if err != nil || len(got) != len(domain.Guilds) || got[0].GuildID != "cipher" || got[0].Week != nil {
+		t.Fatalf("GetGuildLeaderboard() = %+v, %v; want Magicians ranked", got, err)
+	}
+
+	f.GuildStandings = []domain.GuildStanding{{GuildID: "nyx", Rank: 1, Week: &domain.GuildWeek{LastRank: 3}}}
+	if got, _ := f.GetGuildLeaderboard(ctx); len(got) != 1 || got[0].RankChange() != 2 {
+		t.Errorf("GetGuildLeaderboard() = %+v, want GuildStandings", got)
+	}
+}
@@ -3,18 +3,108 @@ package client
 import (
 	"errors"
 	"fmt"
+	"net"
+	"time"
 )
 
 // HTTPError represents a non-2xx HTTP response from the API.
 type HTTPError struct {
 	StatusCode int
 	Message    string
+	RetryAfter time.Duration // from the Retry-After header, if the API sent one
+	Fields     []FieldError  // per-field validation failures, if the API sent any
 }
 
+// Validation error codes the API reports in FieldError.Code.
+const (
+	CodeRequired  = "required"
+	CodeTooShort  = "too_short"
+	CodeTooLong   = "too_long"
+	CodeInvalid   = "invalid"
+	CodeDuplicate = "duplicate"
+)
+
+// FieldError is one validation failure from the API: which request field
+// (by its JSON name) was rejected, a machine-readable code and a message
+// meant for people.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors the API's failures can be matched against with errors.Is or the
+// Is helpers below. An HTTPError matches the one for its status code.
+var (
+	ErrUnauthorized = errors.New("not authenticated")   // 401: missing or expired token
+	ErrForbidden    = errors.New("forbidden")           // 403
+	ErrNotFound     = errors.New("not found")           // 404
+	ErrConflict     = errors.New("conflict")            // 409: e.g. already exists
+	ErrRateLimited  = errors.New("rate limited")        // 429
+	ErrNetwork      = errors.New("network unreachable") // no response at all
+)
+
 func (e *HTTPError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 }
 
+// Is matches the sentinel error for e's status code.
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == 401
+	case ErrForbidden:
+		return e.StatusCode == 403
+	case ErrNotFound:
+		return e.StatusCode == 404
+	case ErrConflict:
+		return e.StatusCode == 409
+	case ErrRateLimited:
+		return e.StatusCode == 429
+	}
+	return false
+}
+
+// NetworkError is a request that got no response: DNS, dial, TLS or a
+// timeout. It matches ErrNetwork.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string { return e.Err.Error() }
+
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+// Is matches ErrNetwork.
+func (e *NetworkError) Is(target error) bool { return target == ErrNetwork }
+
+// IsUnauthorized reports whether err means the token is missing or expired,
+// so the user needs to run grimora login.
+func IsUnauthorized(err error) bool { return errors.Is(err, ErrUnauthorized) }
+
+// IsForbidden reports whether err is the API refusing a signed-in user.
+func IsForbidden(err error) bool { return errors.Is(err, ErrForbidden) }
+
+// IsNotFound reports whether err is a 404.
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsConflict reports whether err is a 409.
+func IsConflict(err error) bool { return errors.Is(err, ErrConflict) }
+
+// IsRateLimited reports whether err is a 429.
+func IsRateLimited(err error) bool { return errors.Is(err, ErrRateLimited) }
+
+// IsNetwork reports whether err is a request that never got a response.
+func IsNetwork(err error) bool { return errors.Is(err, ErrNetwork) }
+
+// IsUnsent reports whether err is a request that never left: the connection
+// to the API couldn't be made. Unlike a timeout or a dropped connection, the
+// server can't have acted on it, so it's safe to send again.
+func IsUnsent(err error) bool {
+	var opErr *net.OpError
+	return IsNetwork(err) && errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 // IsStatus returns true if err (or any wrapped error) is an HTTPError with the given status code.
 func IsStatus(err error, code int) bool {
 	var httpErr *HTTPError
@@ -23,3 +113,13 @@ func IsStatus(err error, code int) bool {
 	}
 	return false
 }
+
+// FieldErrors returns the validation failures carried by err, if it (or any
+// error it wraps) is an HTTPError that has them.
+func FieldErrors(err error) []FieldError {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Fields
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// chestPath is the endpoint for a guild's chests, or one chest when chestID
+// is set.
+func chestPath(guildID, chestID string) string {
+	p := "/api/guilds/" + url.PathEscape(guildID) + "/chests"
+	if chestID != "" {
+		p += "/" + url.PathEscape(chestID)
+	}
+	return p
+}
+
+// ListGuildChests returns a guild's chests along with the caller's role and
+// per-member contribution stats.
+func (c *Client) ListGuildChests(ctx context.Context, guildID string) (*domain.GuildChests, error) {
+	var chests domain.GuildChests
+	if err := c.get(ctx, chestPath(guildID, ""), &chests); err != nil {
+		return nil, fmt.Errorf("client.ListGuildChests: %w", err)
+	}
+	return &chests, nil
+}
+
+// GetGuildChest fetches a single chest with its spells.
+func (c *Client) GetGuildChest(ctx context.Context, guildID, chestID string) (*domain.GuildChest, error) {
+	var chest domain.GuildChest
+	if err := c.get(ctx, chestPath(guildID, chestID), &chest); err != nil {
+		return nil, fmt.Errorf("client.GetGuildChest: %w", err)
+	}
+	return &chest, nil
+}
+
+// AddChestSpell adds a spell to a guild chest. Only curators may do this.
+func (c *Client) AddChestSpell(ctx context.Context, guildID, chestID, spellID string) error {
+	if err := c.post(ctx, chestPath(guildID, chestID)+"/spells", map[string]string{"spell_id": spellID}, nil); err != nil {
+		return fmt.Errorf("client.AddChestSpell: %w", err)
+	}
+	return nil
+}
+
+// RemoveChestSpell removes a spell from a guild chest. Only curators may do
+// this.
+func (c *Client) RemoveChestSpell(ctx context.Context, guildID, chestID, spellID string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, chestPath(guildID, chestID)+"/spells/"+url.PathEscape(spellID), nil, nil); err != nil {
+		return fmt.Errorf("client.RemoveChestSpell: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListGuildChests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/guilds/amarok/chests" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"role":"curator","chests":[{"name":"Debugging","spell_count":4}],"contributions":[{"login":"wolf","role":"curator","added":3,"casts":12}]}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	got, err := c.ListGuildChests(context.Background(), "amarok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.CanCurate() || len(got.Chests) != 1 || got.Chests[0].SpellCount != 4 {
+		t.Errorf("chests = %+v", got)
+	}
+	if len(got.Contributions) != 1 || got.Contributions[0].Casts != 12 {
+		t.Errorf("contributions = %+v", got.Contributions)
+	}
+}
+
+func TestGetGuildChestFlattensSpells(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"Debugging","spells":[{"text":"bisect first","tag":"debugging","added_by":"wolf"}]}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	chest, err := c.GetGuildChest(context.Background(), "amarok", "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chest.Spells) != 1 || chest.Spells[0].Text != "bisect first" || chest.Spells[0].AddedBy != "wolf" {
+		t.Errorf("spells = %+v", chest.Spells)
+	}
+}
+
+func TestChestSpellAddRemove(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost {
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+			if body["spell_id"] != "s1" {
+				t.Errorf("spell_id = %q", body["spell_id"])
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if err := c.AddChestSpell(context.Background(), "amarok", "c1", "s1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RemoveChestSpell(context.Background(), "amarok", "c1", "s1"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"POST /api/guilds/amarok/chests/c1/spells", "DELETE /api/guilds/amarok/chests/c1/spells/s1"}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+}
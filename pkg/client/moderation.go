@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// blockPath is the endpoint for blocking one magician.
+func blockPath(login string) string {
+	return "/api/magicians/" + url.PathEscape(login) + "/block"
+}
+
+// report files a report with the moderators.
+func (c *Client) report(ctx context.Context, targetType, targetID, reason string) error {
+	body := domain.Report{TargetType: targetType, TargetID: targetID, Reason: reason}
+	return c.doRequest(ctx, http.MethodPost, "/api/reports", body, nil)
+}
+
+// ReportMessage flags a Hall or DM message as spam, abuse or something
+// else the moderators should see.
+func (c *Client) ReportMessage(ctx context.Context, msgID, reason string) error {
+	if err := c.report(ctx, domain.ReportTargetMessage, msgID, reason); err != nil {
+		return fmt.Errorf("client.ReportMessage: %w", err)
+	}
+	return nil
+}
+
+// ReportSpell flags a spell for the moderators.
+func (c *Client) ReportSpell(ctx context.Context, spellID, reason string) error {
+	if err := c.report(ctx, domain.ReportTargetSpell, spellID, reason); err != nil {
+		return fmt.Errorf("client.ReportSpell: %w", err)
+	}
+	return nil
+}
+
+// BlockMagician hides a magician's messages from the caller and stops them
+// sending the caller DMs. Blocking someone already blocked is a no-op.
+func (c *Client) BlockMagician(ctx context.Context, login string) error {
+	if err := c.doRequest(ctx, http.MethodPost, blockPath(login), nil, nil); err != nil {
+		return fmt.Errorf("client.BlockMagician: %w", err)
+	}
+	return nil
+}
+
+// UnblockMagician lifts a block.
+func (c *Client) UnblockMagician(ctx context.Context, login string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, blockPath(login), nil, nil); err != nil {
+		return fmt.Errorf("client.UnblockMagician: %w", err)
+	}
+	return nil
+}
+
+// ListBlocked returns the logins the caller has blocked.
+func (c *Client) ListBlocked(ctx context.Context) ([]string, error) {
+	var logins []string
+	if err := c.get(ctx, "/api/me/blocks", &logins); err != nil {
+		return nil, fmt.Errorf("client.ListBlocked: %w", err)
+	}
+	return logins, nil
+}
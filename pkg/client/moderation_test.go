@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestReportAndBlock(t *testing.T) {
+	var got []string
+	var reports []domain.Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/reports":
+			var rep domain.Report
+			json.NewDecoder(r.Body).Decode(&rep) //nolint:errcheck
+			reports = append(reports, rep)
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /api/me/blocks":
+			json.NewEncoder(w).Encode([]string{"spammer"}) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	ctx := context.Background()
+	if err := c.ReportMessage(ctx, "m1", domain.ReportSpam); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ReportSpell(ctx, "s1", domain.ReportAbuse); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.BlockMagician(ctx, "spammer"); err != nil {
+		t.Fatal(err)
+	}
+	blocked, err := c.ListBlocked(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocked) != 1 || blocked[0] != "spammer" {
+		t.Errorf("blocked = %v", blocked)
+	}
+	if err := c.UnblockMagician(ctx, "spammer"); err != nil {
+		t.Fatal(err)
+	}
+
+	wantReports := []domain.Report{
+		{TargetType: domain.ReportTargetMessage, TargetID: "m1", Reason: domain.ReportSpam},
+		{TargetType: domain.ReportTargetSpell, TargetID: "s1", Reason: domain.ReportAbuse},
+	}
+	if len(reports) != len(wantReports) {
+		t.Fatalf("reports = %+v", reports)
+	}
+	for i := range wantReports {
+		if reports[i] != wantReports[i] {
+			t.Errorf("report %d = %+v, want %+v", i, reports[i], wantReports[i])
+		}
+	}
+	want := []string{
+		"POST /api/reports",
+		"POST /api/reports",
+		"POST /api/magicians/spammer/block",
+		"GET /api/me/blocks",
+		"DELETE /api/magicians/spammer/block",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("requests = %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
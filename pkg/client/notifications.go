@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// ListNotifications returns the caller's most recent notifications, newest
+// first: @mentions, new followers, upvotes and comments on their spells, and
+// DMs. Repeats of the same event are grouped server-side.
+func (c *Client) ListNotifications(ctx context.Context, limit int) ([]domain.GroupedNotification, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
+
+	var notifs []domain.GroupedNotification
+	if err := c.get(ctx, "/api/notifications?"+params.Encode(), &notifs); err != nil {
+		return nil, fmt.Errorf("client.ListNotifications: %w", err)
+	}
+	return notifs, nil
+}
+
+// MarkNotificationRead marks one notification, and the group it heads, read.
+func (c *Client) MarkNotificationRead(ctx context.Context, id string) error {
+	if err := c.doRequest(ctx, http.MethodPost, "/api/notifications/"+url.PathEscape(id)+"/read", nil, nil); err != nil {
+		return fmt.Errorf("client.MarkNotificationRead: %w", err)
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks every notification read.
+func (c *Client) MarkAllNotificationsRead(ctx context.Context) error {
+	if err := c.doRequest(ctx, http.MethodPost, "/api/notifications/read", nil, nil); err != nil {
+		return fmt.Errorf("client.MarkAllNotificationsRead: %w", err)
+	}
+	return nil
+}
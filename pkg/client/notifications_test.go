@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestNotificationsLifecycle(t *testing.T) {
+	id := uuid.New()
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.RequestURI())
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode([]domain.GroupedNotification{{ //nolint:errcheck
+				Notification: domain.Notification{ID: id, Type: domain.NotifMention, ActorLogin: "ada", RefSlug: "the-hall"},
+				ActorCount:   1,
+			}})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	ctx := context.Background()
+	notifs, err := c.ListNotifications(ctx, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifs) != 1 || notifs[0].Type != domain.NotifMention || notifs[0].RefSlug != "the-hall" {
+		t.Errorf("notifications = %+v", notifs)
+	}
+	if err := c.MarkNotificationRead(ctx, id.String()); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.MarkAllNotificationsRead(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /api/notifications?limit=50",
+		"POST /api/notifications/" + id.String() + "/read",
+		"POST /api/notifications/read",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("requests = %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// DefaultUserAgent identifies requests unless WithUserAgent says otherwise.
+const DefaultUserAgent = "grimora-client"
+
+// Option configures a Client in New. Options apply in order, so a
+// WithTimeout after WithHTTPClient adjusts the client it supplied.
+type Option func(*Client)
+
+// WithTransport sends requests through rt instead of the shared transport,
+// e.g. to add TLS settings or stub the network in tests.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = rt
+	}
+}
+
+// WithTimeout bounds each request to d instead of DefaultTimeout. Zero
+// means no limit beyond the request's context.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
+}
+
+// WithHTTPClient sends requests through a copy of hc, for callers that
+// already manage their own client.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		cp := *hc
+		c.httpClient = &cp
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithBaseContext ties every request to ctx as well as to its own context,
+// so cancelling ctx abandons whatever is in flight, e.g. when the app shuts
+// down.
+func WithBaseContext(ctx context.Context) Option {
+	return func(c *Client) {
+		c.base = ctx
+	}
+}
+
+// WithRequestObserver calls fn after every request, e.g. to export metrics.
+func WithRequestObserver(fn RequestObserver) Option {
+	return func(c *Client) {
+		c.observer = fn
+	}
+}
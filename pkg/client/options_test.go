@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingTransport struct{ n atomic.Int32 }
+
+func (ct *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ct.n.Add(1)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	rt := &countingTransport{}
+	c := New(srv.URL, "tok", WithTransport(rt), WithTimeout(5*time.Second))
+	if c.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", c.httpClient.Timeout)
+	}
+	if err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if rt.n.Load() != 1 {
+		t.Errorf("custom transport saw %d requests, want 1", rt.n.Load())
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	rt := &countingTransport{}
+	hc := &http.Client{Transport: rt, Timeout: time.Minute}
+	c := New(srv.URL, "tok", WithHTTPClient(hc), WithTimeout(time.Second))
+	if hc.Timeout != time.Minute {
+		t.Error("WithTimeout should not modify the caller's http.Client")
+	}
+	if c.httpClient.Timeout != time.Second {
+		t.Errorf("Timeout = %v, want 1s", c.httpClient.Timeout)
+	}
+	if err := c.Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if rt.n.Load() != 1 {
+		t.Errorf("supplied client's transport saw %d requests, want 1", rt.n.Load())
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var got atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Store(r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := New(srv.URL, "tok").Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if ua := got.Load(); ua != DefaultUserAgent {
+		t.Errorf("User-Agent = %v, want %q", ua, DefaultUserAgent)
+	}
+
+	if err := New(srv.URL, "tok", WithUserAgent("grimora/1.2.3")).Health(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if ua := got.Load(); ua != "grimora/1.2.3" {
+		t.Errorf("User-Agent = %v, want grimora/1.2.3", ua)
+	}
+}
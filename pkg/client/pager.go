@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// DefaultPageSize is how many items a Pager asks for at a time when it isn't
+// told otherwise.
+const DefaultPageSize = 50
+
+// Page describes one page of a list to fetch.
+type Page struct {
+	Limit  int    // most items to return
+	Offset int    // items already returned, for endpoints paged by offset
+	Cursor string // the previous page's cursor, for endpoints paged by cursor; "" for the first page
+}
+
+// PageFunc fetches the page p describes. It returns the page's items and,
+// from endpoints paged by cursor, the cursor of the page after it; "" from
+// the others.
+type PageFunc[T any] func(ctx context.Context, p Page) (items []T, next string, err error)
+
+// Pager walks a paginated list an item at a time, fetching pages as they're
+// needed:
+//
+//	it := client.SpellsIter(ctx, c, client.SpellsOptions{Tags: []string{"go"}})
+//	for it.Next() {
+//		use(it.Item())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// A list ends at a page shorter than asked for, or, when pages come with
+// cursors, at the first page without one. Either way an empty page ends it,
+// so a misbehaving server can't keep a Pager going forever.
+type Pager[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+	page  Page
+	buf   []T
+	item  T
+	last  bool // the page in buf is the final one
+	err   error
+}
+
+// NewPager returns a Pager over the pages fetch returns, pageSize items at a
+// time; pageSize <= 0 means DefaultPageSize.
+func NewPager[T any](ctx context.Context, pageSize int, fetch PageFunc[T]) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Pager[T]{ctx: ctx, fetch: fetch, page: Page{Limit: pageSize}}
+}
+
+// Next advances to the next item, fetching the next page if it has to. It
+// returns false at the end of the list or on an error; Err tells which.
+func (p *Pager[T]) Next() bool {
+	for len(p.buf) == 0 {
+		if p.last || p.err != nil {
+			return false
+		}
+		items, next, err := p.fetch(p.ctx, p.page)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		byCursor := p.page.Cursor != "" || next != ""
+		p.buf = items
+		p.page.Offset += len(items)
+		p.page.Cursor = next
+		if byCursor {
+			p.last = len(items) == 0 || next == ""
+		} else {
+			p.last = len(items) < p.page.Limit
+		}
+	}
+	p.item, p.buf = p.buf[0], p.buf[1:]
+	return true
+}
+
+// Item returns the item Next advanced to.
+func (p *Pager[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error that stopped the Pager, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Collect returns the rest of the list. On an error it returns the items
+// gathered before it along with the error.
+func (p *Pager[T]) Collect() ([]T, error) {
+	var all []T
+	for p.Next() {
+		all = append(all, p.Item())
+	}
+	return all, p.Err()
+}
+
+// offsetPages adapts a list method paged by limit and offset to a PageFunc.
+func offsetPages[T any](list func(ctx context.Context, limit, offset int) ([]T, error)) PageFunc[T] {
+	return func(ctx context.Context, p Page) ([]T, string, error) {
+		items, err := list(ctx, p.Limit, p.Offset)
+		return items, "", err
+	}
+}
+
+// SpellsOptions narrows and orders the spells SpellsIter walks.
+type SpellsOptions struct {
+	Tags     []string // spells carrying any of these; none means all
+	Sort     string   // "new", "top" or "casts"; "" is the server's default
+	PageSize int      // spells per request; 0 means DefaultPageSize
+}
+
+// SpellsIter walks the spells ListSpells returns for opts.
+func SpellsIter(ctx context.Context, c API, opts SpellsOptions) *Pager[domain.Spell] {
+	return NewPager(ctx, opts.PageSize, offsetPages(func(ctx context.Context, limit, offset int) ([]domain.Spell, error) {
+		return c.ListSpells(ctx, opts.Tags, opts.Sort, limit, offset)
+	}))
+}
+
+// SavedSpellsIter walks the caller's bookmarked spells, pageSize at a time.
+func SavedSpellsIter(ctx context.Context, c API, pageSize int) *Pager[domain.Spell] {
+	return NewPager(ctx, pageSize, offsetPages(c.ListSavedSpells))
+}
+
+// WeaponsIter walks every weapon, pageSize at a time.
+func WeaponsIter(ctx context.Context, c API, pageSize int) *Pager[domain.Weapon] {
+	return NewPager(ctx, pageSize, offsetPages(func(ctx context.Context, limit, offset int) ([]domain.Weapon, error) {
+		return c.ListWeapons(ctx, nil, limit, offset)
+	}))
+}
+
+// MagiciansIter walks every magician, with the caller's follow state,
+// pageSize at a time.
+func MagiciansIter(ctx context.Context, c API, pageSize int) *Pager[domain.MagicianCard] {
+	return NewPager(ctx, pageSize, offsetPages(c.ListMagicians))
+}
+
+// LeaderboardOptions narrows the entries LeaderboardIter walks.
+type LeaderboardOptions struct {
+	Guild    string // one guild's magicians; "" means every guild
+	City     string // one city's magicians; "" means everywhere
+	PageSize int    // entries per request; 0 means DefaultPageSize
+}
+
+// LeaderboardIter walks the leaderboard from the top.
+func LeaderboardIter(ctx context.Context, c API, opts LeaderboardOptions) *Pager[domain.LeaderboardEntry] {
+	return NewPager(ctx, opts.PageSize, offsetPages(func(ctx context.Context, limit, offset int) ([]domain.LeaderboardEntry, error) {
+		return c.GetLeaderboard(ctx, opts.Guild, opts.City, limit, offset)
+	}))
+}
+
+// StreamIter walks the activity stream back from the newest event, only
+// through magicians the caller follows when followingOnly is set.
+func StreamIter(ctx context.Context, c API, followingOnly bool, pageSize int) *Pager[domain.StreamEvent] {
+	return NewPager(ctx, pageSize, offsetPages(func(ctx context.Context, limit, offset int) ([]domain.StreamEvent, error) {
+		return c.GetStream(ctx, followingOnly, limit, offset)
+	}))
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestSpellsIterPagesByOffset(t *testing.T) {
+	const total = 7
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		requests = append(requests, q.Get("limit")+"@"+q.Get("offset"))
+		if q.Get("tag") != "go" || q.Get("sort") != "top" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		spells := []domain.Spell{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			spells = append(spells, domain.Spell{ID: uuid.New(), Potency: i})
+		}
+		json.NewEncoder(w).Encode(spells) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	it := SpellsIter(context.Background(), New(srv.URL, "tok"), SpellsOptions{Tags: []string{"go"}, Sort: "top", PageSize: 3})
+	var got []int
+	for it.Next() {
+		got = append(got, it.Item().Potency)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if len(got) != total || got[0] != 0 || got[total-1] != total-1 {
+		t.Errorf("walked %v, want 0..%d in order", got, total-1)
+	}
+	if want := []string{"3@0", "3@3", "3@6"}; len(requests) != len(want) || requests[2] != want[2] {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestPagerFollowsCursors(t *testing.T) {
+	pages := map[string][]int{"": {1, 2}, "b": {3}, "c": {4, 5}}
+	next := map[string]string{"": "b", "b": "c"}
+	it := NewPager(context.Background(), 2, func(_ context.Context, p Page) ([]int, string, error) {
+		return pages[p.Cursor], next[p.Cursor], nil
+	})
+	// The short page at cursor b doesn't end the list while it has a cursor.
+	got, err := it.Collect()
+	if err != nil || len(got) != 5 || got[4] != 5 {
+		t.Errorf("Collect() = %v, %v; want 1..5", got, err)
+	}
+}
+
+func TestPagerStopsAtError(t *testing.T) {
+	boom := errors.New("boom")
+	calls := 0
+	it := NewPager(context.Background(), 2, func(_ context.Context, p Page) ([]int, string, error) {
+		calls++
+		if p.Offset > 0 {
+			return nil, "", boom
+		}
+		return []int{1, 2}, "", nil
+	})
+	got, err := it.Collect()
+	if !errors.Is(err, boom) || len(got) != 2 {
+		t.Errorf("Collect() = %v, %v; want the first page and boom", got, err)
+	}
+	if it.Next() || calls != 2 {
+		t.Errorf("Next() after an error fetched again (%d calls)", calls)
+	}
+}
+
+func TestPagerEmptyPageEnds(t *testing.T) {
+	calls := 0
+	it := NewPager(context.Background(), 0, func(_ context.Context, p Page) ([]int, string, error) {
+		calls++
+		if p.Limit != DefaultPageSize {
+			t.Errorf("Limit = %d, want DefaultPageSize", p.Limit)
+		}
+		return nil, "always-more", nil
+	})
+	if it.Next() || it.Err() != nil || calls != 1 {
+		t.Errorf("empty page: Next() kept going (%d calls, err %v)", calls, it.Err())
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultRetryAfter is assumed when a 429 arrives without a Retry-After header.
+const defaultRetryAfter = 30 * time.Second
+
+// RateLimit is the request quota most recently reported by the API through
+// the X-RateLimit-* and Retry-After response headers.
+type RateLimit struct {
+	Limit      int       // requests allowed per window; 0 if never reported
+	Remaining  int       // requests left in the current window
+	Reset      time.Time // when the window refills; zero if unknown
+	RetryAfter time.Time // earliest time to retry after a 429/503; zero if none
+}
+
+// Exhausted reports whether the current window has no requests left.
+func (r RateLimit) Exhausted(now time.Time) bool {
+	return r.Limit > 0 && r.Remaining <= 0 && r.Reset.After(now)
+}
+
+// Fraction returns the share of the window's quota still available, or 1
+// when the quota is unknown or the window has already reset.
+func (r RateLimit) Fraction(now time.Time) float64 {
+	if r.Limit <= 0 || (!r.Reset.IsZero() && !r.Reset.After(now)) {
+		return 1
+	}
+	return float64(r.Remaining) / float64(r.Limit)
+}
+
+// RateLimit returns the most recently reported quota. It is safe to call
+// while requests are in flight.
+func (c *Client) RateLimit() RateLimit {
+	c.rlMu.Lock()
+	defer c.rlMu.Unlock()
+	return c.rateLimit
+}
+
+// recordRateLimit updates the tracked quota from a response.
+func (c *Client) recordRateLimit(resp *http.Response, now time.Time) {
+	rl, ok := parseRateLimit(resp.Header, now)
+	throttled := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+	if throttled {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			rl.RetryAfter = now.Add(d)
+		} else if resp.StatusCode == http.StatusTooManyRequests {
+			rl.RetryAfter = now.Add(defaultRetryAfter)
+		}
+	}
+	if !ok && rl.RetryAfter.IsZero() {
+		return
+	}
+	c.rlMu.Lock()
+	defer c.rlMu.Unlock()
+	if !ok {
+		// A bare Retry-After keeps the last known quota.
+		rl.Limit, rl.Remaining, rl.Reset = c.rateLimit.Limit, c.rateLimit.Remaining, c.rateLimit.Reset
+	}
+	c.rateLimit = rl
+}
+
+// parseRateLimit reads X-RateLimit-Limit, -Remaining and -Reset. Reset may be
+// a Unix timestamp or, as some proxies send it, seconds until the reset.
+func parseRateLimit(h http.Header, now time.Time) (RateLimit, bool) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return RateLimit{}, false
+	}
+	rl := RateLimit{Limit: limit, Remaining: limit}
+	if rem, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		rl.Remaining = rem
+	}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+		if reset < 1e9 {
+			rl.Reset = now.Add(time.Duration(reset) * time.Second)
+		} else {
+			rl.Reset = time.Unix(reset, 0)
+		}
+	}
+	return rl, true
+}
+
+// parseRetryAfter reads a Retry-After value in either delay-seconds or
+// HTTP-date form.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
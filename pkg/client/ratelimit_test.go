@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitFromHeaders(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "7")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.Write([]byte(`{}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if c.RateLimit().Limit != 0 {
+		t.Fatal("expected unknown quota before any request")
+	}
+	if _, err := c.GetMe(context.Background()); err != nil {
+		t.Fatalf("GetMe() error: %v", err)
+	}
+	rl := c.RateLimit()
+	if rl.Limit != 100 || rl.Remaining != 7 {
+		t.Errorf("quota = %d/%d, want 7/100", rl.Remaining, rl.Limit)
+	}
+	if rl.Reset.Unix() != reset {
+		t.Errorf("reset = %v, want unix %d", rl.Reset, reset)
+	}
+	if f := rl.Fraction(time.Now()); f != 0.07 {
+		t.Errorf("Fraction() = %v, want 0.07", f)
+	}
+}
+
+func TestRateLimit429RetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "12")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"slow down"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	_, err := c.GetMe(context.Background())
+	if !IsStatus(err, http.StatusTooManyRequests) {
+		t.Fatalf("expected 429, got %v", err)
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.RetryAfter != 12*time.Second {
+		t.Errorf("RetryAfter = %v, want 12s", httpErr)
+	}
+	wait := time.Until(c.RateLimit().RetryAfter)
+	if wait < 10*time.Second || wait > 12*time.Second {
+		t.Errorf("RetryAfter in %v, want ~12s", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"", 0, false},
+		{"30", 30 * time.Second, true},
+		{"-1", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.in, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRateLimitFractionAfterReset(t *testing.T) {
+	now := time.Now()
+	rl := RateLimit{Limit: 10, Remaining: 0, Reset: now.Add(-time.Second)}
+	if rl.Exhausted(now) {
+		t.Error("a window that already reset is not exhausted")
+	}
+	if f := rl.Fraction(now); f != 1 {
+		t.Errorf("Fraction() = %v after reset, want 1", f)
+	}
+}
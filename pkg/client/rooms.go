@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// CreateRoomRequest is the body for creating a topic room.
+type CreateRoomRequest struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name,omitempty"` // defaults to the slug
+	Description string `json:"description,omitempty"`
+}
+
+// CreateRoom creates a topic room owned by the caller.
+func (c *Client) CreateRoom(ctx context.Context, req CreateRoomRequest) (*domain.Room, error) {
+	var room domain.Room
+	if err := c.post(ctx, "/api/rooms", req, &room); err != nil {
+		return nil, fmt.Errorf("client.CreateRoom: %w", err)
+	}
+	return &room, nil
+}
+
+// ArchiveRoom archives a room the caller owns. Archived rooms drop out of
+// ListRooms but keep their history.
+func (c *Client) ArchiveRoom(ctx context.Context, slug string) error {
+	if err := c.doRequest(ctx, http.MethodPost, "/api/rooms/"+url.PathEscape(slug)+"/archive", nil, nil); err != nil {
+		return fmt.Errorf("client.ArchiveRoom: %w", err)
+	}
+	return nil
+}
+
+// SetRoomTopic sets the topic shown in a room's header. An empty topic
+// clears it.
+func (c *Client) SetRoomTopic(ctx context.Context, slug, topic string) (*domain.Room, error) {
+	var room domain.Room
+	if err := c.doRequest(ctx, http.MethodPatch, "/api/rooms/"+url.PathEscape(slug), map[string]string{"topic": topic}, &room); err != nil {
+		return nil, fmt.Errorf("client.SetRoomTopic: %w", err)
+	}
+	return &room, nil
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateRoom(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/rooms" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body CreateRoomRequest
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		if body.Slug != "go-tips" || body.Description != "small Go tricks" {
+			t.Errorf("body = %+v", body)
+		}
+		w.Write([]byte(`{"slug":"go-tips","name":"go-tips","room_type":"topic"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	room, err := c.CreateRoom(context.Background(), CreateRoomRequest{Slug: "go-tips", Description: "small Go tricks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if room.Slug != "go-tips" || room.RoomType != "topic" {
+		t.Errorf("room = %+v", room)
+	}
+}
+
+func TestArchiveRoom(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/rooms/go-tips/archive" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := New(srv.URL, "tok").ArchiveRoom(context.Background(), "go-tips"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetRoomTopic(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/rooms/go-tips" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)                                 //nolint:errcheck
+		w.Write([]byte(`{"slug":"go-tips","topic":"` + body["topic"] + `"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	room, err := New(srv.URL, "tok").SetRoomTopic(context.Background(), "go-tips", "generics week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if room.Topic != "generics week" {
+		t.Errorf("Topic = %q", room.Topic)
+	}
+}
+
+func TestArchiveRoomForbidden(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	err := New(srv.URL, "tok").ArchiveRoom(context.Background(), "the-hall")
+	if !IsStatus(err, http.StatusForbidden) {
+		t.Errorf("err = %v, want 403", err)
+	}
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// ErrSchema matches responses whose shape the client can't use.
+var ErrSchema = errors.New("unexpected response shape")
+
+// SchemaError is a response object that failed validation.
+type SchemaError struct {
+	Type string // Go type of the object, e.g. "domain.StreamEvent"
+	Err  error
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Type, e.Err)
+}
+
+func (e *SchemaError) Unwrap() error { return e.Err }
+
+// Is matches ErrSchema.
+func (e *SchemaError) Is(target error) bool { return target == ErrSchema }
+
+// IsSchema reports whether err is a response the client couldn't use.
+func IsSchema(err error) bool { return errors.Is(err, ErrSchema) }
+
+// validator is implemented by domain types that can tell a response the
+// client can't use from one it merely doesn't fully understand. Unknown
+// kinds and types are not invalid: views show them with a fallback.
+type validator interface {
+	Validate() error
+}
+
+// decodeBody decodes a response into out, keeps fields the domain types
+// don't declare in their Extra maps, and validates what came back. In a
+// list, invalid items are dropped so one bad row doesn't blank a view; a
+// single invalid object is a *SchemaError.
+func decodeBody(r io.Reader, out any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	v := reflect.ValueOf(out)
+	if hasExtra(v.Type()) {
+		fillExtra(data, v)
+	}
+	return validate(v)
+}
+
+// validate checks out, a pointer to what was decoded.
+func validate(v reflect.Value) error {
+	v = v.Elem()
+	switch v.Kind() {
+	case reflect.Slice:
+		if !v.Type().Elem().Implements(reflect.TypeFor[validator]()) {
+			return nil
+		}
+		kept := 0
+		for i := range v.Len() {
+			item := v.Index(i)
+			if item.Kind() == reflect.Pointer && item.IsNil() {
+				continue
+			}
+			if item.Interface().(validator).Validate() != nil {
+				continue
+			}
+			v.Index(kept).Set(v.Index(i))
+			kept++
+		}
+		v.SetLen(kept)
+	case reflect.Struct:
+		if val, ok := v.Interface().(validator); ok {
+			if err := val.Validate(); err != nil {
+				return &SchemaError{Type: v.Type().String(), Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	extraType  = reflect.TypeFor[domain.Extra]()
+	extraCache sync.Map // reflect.Type → bool
+)
+
+// hasExtra reports whether t holds a domain.Extra field anywhere worth
+// walking into, so responses without one are decoded only once.
+func hasExtra(t reflect.Type) bool {
+	if v, ok := extraCache.Load(t); ok {
+		return v.(bool)
+	}
+	extraCache.Store(t, false) // breaks cycles in recursive types
+	found := false
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		found = hasExtra(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if f.Type == extraType || (f.IsExported() && hasExtra(f.Type)) {
+				found = true
+				break
+			}
+		}
+	}
+	extraCache.Store(t, found)
+	return found
+}
+
+// fillExtra walks raw alongside v, storing the keys of each JSON object
+// that its struct doesn't declare in the struct's Extra field.
+func fillExtra(raw json.RawMessage, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			fillExtra(raw, v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return
+		}
+		for i := range min(len(items), v.Len()) {
+			fillExtra(items[i], v.Index(i))
+		}
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(raw, &fields) != nil {
+			return
+		}
+		fillStruct(fields, v, jsonNames(v.Type()))
+	}
+}
+
+// fillStruct is fillExtra for a struct decoded from the object fields.
+// known holds every key the outermost struct declares, so an embedded
+// struct's Extra doesn't claim its parent's fields.
+func fillStruct(fields map[string]json.RawMessage, v reflect.Value, known map[string]bool) {
+	t := v.Type()
+	var extra reflect.Value
+	for i := range t.NumField() {
+		f := t.Field(i)
+		switch {
+		case f.Type == extraType:
+			extra = v.Field(i)
+		case f.Anonymous && f.Type.Kind() == reflect.Struct:
+			// Embedded fields live in the same object.
+			fillStruct(fields, v.Field(i), known)
+		default:
+			name, ok := jsonName(f)
+			if !ok || !hasExtra(f.Type) {
+				continue
+			}
+			if sub, ok := fields[name]; ok {
+				fillExtra(sub, v.Field(i))
+			}
+		}
+	}
+	if !extra.IsValid() || !extra.CanSet() {
+		return
+	}
+	unknown := domain.Extra{}
+	for name, value := range fields {
+		if !known[name] {
+			unknown[name] = value
+		}
+	}
+	if len(unknown) > 0 {
+		extra.Set(reflect.ValueOf(unknown))
+	}
+}
+
+// jsonName returns the key encoding/json uses for f, and false for fields
+// it skips.
+func jsonName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return f.Name, true
+}
+
+// jsonNames returns the keys of a struct type, embedded structs included.
+func jsonNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			for name := range jsonNames(f.Type) {
+				names[name] = true
+			}
+			continue
+		}
+		if name, ok := jsonName(f); ok {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// serveJSON answers every request with body.
+func serveJSON(t *testing.T, body string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body)) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+	return New(srv.URL, "tok")
+}
+
+func TestDecodeKeepsUnknownFields(t *testing.T) {
+	c := serveJSON(t, `[
+		{"kind": "spell", "magician_login": "ada", "title": "triage", "created_at": "2026-10-01T00:00:00Z"},
+		{"kind": "duel", "magician_login": "linus", "arena": "rust", "round": 3, "created_at": "2026-10-01T00:00:00Z"}
+	]`)
+	events, err := c.GetStream(context.Background(), false, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %d, want 2", len(events))
+	}
+	if events[0].Extra != nil {
+		t.Errorf("known event got extra fields %v", events[0].Extra)
+	}
+	duel := events[1]
+	if duel.KnownKind() {
+		t.Error("duel should be an unknown kind")
+	}
+	if string(duel.Extra["arena"]) != `"rust"` || string(duel.Extra["round"]) != "3" {
+		t.Errorf("extra = %v, want arena and round", duel.Extra)
+	}
+	if _, ok := duel.Extra["magician_login"]; ok {
+		t.Error("declared fields must not be copied into Extra")
+	}
+}
+
+func TestDecodeEmbeddedExtraSkipsParentFields(t *testing.T) {
+	c := serveJSON(t, `[{"id": "00000000-0000-0000-0000-000000000001", "type": "badge", "actor_login": "ada", "actor_count": 2, "badge": "first-ship"}]`)
+	notifs, err := c.ListNotifications(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifs) != 1 {
+		t.Fatalf("notifications = %d, want 1", len(notifs))
+	}
+	extra := notifs[0].Extra
+	if string(extra["badge"]) != `"first-ship"` {
+		t.Errorf("extra = %v, want badge", extra)
+	}
+	if _, ok := extra["actor_count"]; ok {
+		t.Error("GroupedNotification's own fields must not land in Notification.Extra")
+	}
+}
+
+func TestDecodeDropsInvalidListItems(t *testing.T) {
+	c := serveJSON(t, `[{"kind": "spell", "title": "a"}, {"title": "no kind"}, {"kind": "member"}]`)
+	events, err := c.GetStream(context.Background(), false, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Kind != "spell" || events[1].Kind != "member" {
+		t.Errorf("events = %+v, want the two with a kind", events)
+	}
+}
+
+func TestDecodeInvalidObjectIsSchemaError(t *testing.T) {
+	c := serveJSON(t, `{"title": "no kind"}`)
+	var e domain.StreamEvent
+	err := c.get(context.Background(), "/api/stream/1", &e)
+	if !IsSchema(err) {
+		t.Fatalf("err = %v, want a schema error", err)
+	}
+	if IsNotFound(err) {
+		t.Error("a schema error is not a not-found")
+	}
+}
+
+func TestDecodeWithoutExtraFields(t *testing.T) {
+	c := serveJSON(t, `{"id": "00000000-0000-0000-0000-000000000001", "text": "x", "shiny_new_field": true}`)
+	spell, err := c.GetSpell(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spell.Text != "x" {
+		t.Errorf("spell = %+v", spell)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// ShareSpellDraft saves an unpublished spell as a shareable draft and returns
+// it with its share link.
+func (c *Client) ShareSpellDraft(ctx context.Context, req CreateSpellRequest) (*domain.SpellDraft, error) {
+	var draft domain.SpellDraft
+	if err := c.post(ctx, "/api/spell-drafts", req, &draft); err != nil {
+		return nil, fmt.Errorf("client.ShareSpellDraft: %w", err)
+	}
+	return &draft, nil
+}
+
+// UpdateSpellDraft replaces a shared draft's contents so collaborators see
+// the latest version. The share link stays the same.
+func (c *Client) UpdateSpellDraft(ctx context.Context, id string, req CreateSpellRequest) (*domain.SpellDraft, error) {
+	var draft domain.SpellDraft
+	if err := c.doRequest(ctx, http.MethodPut, "/api/spell-drafts/"+url.PathEscape(id), req, &draft); err != nil {
+		return nil, fmt.Errorf("client.UpdateSpellDraft: %w", err)
+	}
+	return &draft, nil
+}
+
+// GetSpellDraft fetches a shared draft along with its suggestions.
+func (c *Client) GetSpellDraft(ctx context.Context, id string) (*domain.SpellDraft, error) {
+	var draft domain.SpellDraft
+	if err := c.get(ctx, "/api/spell-drafts/"+url.PathEscape(id), &draft); err != nil {
+		return nil, fmt.Errorf("client.GetSpellDraft: %w", err)
+	}
+	return &draft, nil
+}
+
+// SuggestDraftEdit proposes replacing original with replacement in one field
+// ("text" or "context") of someone else's shared draft.
+func (c *Client) SuggestDraftEdit(ctx context.Context, draftID, field, original, replacement, note string) (*domain.DraftSuggestion, error) {
+	payload := map[string]string{
+		"field":       field,
+		"original":    original,
+		"replacement": replacement,
+	}
+	if note != "" {
+		payload["note"] = note
+	}
+	var s domain.DraftSuggestion
+	if err := c.post(ctx, "/api/spell-drafts/"+url.PathEscape(draftID)+"/suggestions", payload, &s); err != nil {
+		return nil, fmt.Errorf("client.SuggestDraftEdit: %w", err)
+	}
+	return &s, nil
+}
+
+// ResolveDraftSuggestion marks a suggestion on the caller's draft as accepted
+// or rejected.
+func (c *Client) ResolveDraftSuggestion(ctx context.Context, draftID, suggestionID string, accept bool) error {
+	status := domain.SuggestionRejected
+	if accept {
+		status = domain.SuggestionAccepted
+	}
+	path := "/api/spell-drafts/" + url.PathEscape(draftID) + "/suggestions/" + url.PathEscape(suggestionID)
+	if err := c.doRequest(ctx, http.MethodPatch, path, map[string]string{"status": status}, nil); err != nil {
+		return fmt.Errorf("client.ResolveDraftSuggestion: %w", err)
+	}
+	return nil
+}
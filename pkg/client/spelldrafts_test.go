@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestShareAndUpdateSpellDraft(t *testing.T) {
+	id := uuid.New()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CreateSpellRequest
+		json.NewDecoder(r.Body).Decode(&req) //nolint:errcheck
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/spell-drafts", "PUT /api/spell-drafts/" + id.String():
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(domain.SpellDraft{ //nolint:errcheck
+			ID:       id,
+			Text:     req.Text,
+			ShareURL: "https://grimora.ai/d/" + id.String(),
+		})
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	d, err := c.ShareSpellDraft(context.Background(), CreateSpellRequest{Text: "v1", Tag: "testing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.ShareURL == "" || d.Text != "v1" {
+		t.Errorf("draft = %+v", d)
+	}
+	d, err = c.UpdateSpellDraft(context.Background(), id.String(), CreateSpellRequest{Text: "v2", Tag: "testing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Text != "v2" {
+		t.Errorf("updated text = %q", d.Text)
+	}
+}
+
+func TestSuggestDraftEdit(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/spell-drafts/d1/suggestions" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)              //nolint:errcheck
+		json.NewEncoder(w).Encode(domain.DraftSuggestion{ //nolint:errcheck
+			Field: got["field"], Original: got["original"], Replacement: got["replacement"], Status: domain.SuggestionPending,
+		})
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	s, err := c.SuggestDraftEdit(context.Background(), "d1", "text", "always", "usually", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Replacement != "usually" || s.Status != domain.SuggestionPending {
+		t.Errorf("suggestion = %+v", s)
+	}
+	if _, ok := got["note"]; ok {
+		t.Errorf("payload = %v, want no note when it's empty", got)
+	}
+}
+
+func TestResolveDraftSuggestion(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/spell-drafts/d1/suggestions/s1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		got = append(got, body["status"])
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	if err := c.ResolveDraftSuggestion(context.Background(), "d1", "s1", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ResolveDraftSuggestion(context.Background(), "d1", "s1", false); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != domain.SuggestionAccepted || got[1] != domain.SuggestionRejected {
+		t.Errorf("statuses = %v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+// subscriptionPath is the endpoint for one watched target, e.g.
+// /api/subscriptions/spell/<id>.
+func subscriptionPath(targetType, targetID string) string {
+	return "/api/subscriptions/" + url.PathEscape(targetType) + "/" + url.PathEscape(targetID)
+}
+
+// Watch subscribes the caller to new comments, variants and answers on a
+// spell or seek. Watching something already watched is a no-op.
+func (c *Client) Watch(ctx context.Context, targetType, targetID string) (*domain.Subscription, error) {
+	var sub domain.Subscription
+	if err := c.doRequest(ctx, http.MethodPut, subscriptionPath(targetType, targetID), nil, &sub); err != nil {
+		return nil, fmt.Errorf("client.Watch: %w", err)
+	}
+	return &sub, nil
+}
+
+// Unwatch removes the caller's subscription to a spell or seek.
+func (c *Client) Unwatch(ctx context.Context, targetType, targetID string) error {
+	if err := c.doRequest(ctx, http.MethodDelete, subscriptionPath(targetType, targetID), nil, nil); err != nil {
+		return fmt.Errorf("client.Unwatch: %w", err)
+	}
+	return nil
+}
+
+// ListSubscriptions returns everything the caller watches, most recently
+// active first.
+func (c *Client) ListSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	var subs []domain.Subscription
+	if err := c.get(ctx, "/api/me/subscriptions", &subs); err != nil {
+		return nil, fmt.Errorf("client.ListSubscriptions: %w", err)
+	}
+	return subs, nil
+}
+
+// MarkSubscriptionRead clears the unread count on a watched target.
+func (c *Client) MarkSubscriptionRead(ctx context.Context, targetType, targetID string) error {
+	if err := c.doRequest(ctx, http.MethodPost, subscriptionPath(targetType, targetID)+"/read", nil, nil); err != nil {
+		return fmt.Errorf("client.MarkSubscriptionRead: %w", err)
+	}
+	return nil
+}
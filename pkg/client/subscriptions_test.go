@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/naveenspark/grimora/pkg/domain"
+)
+
+func TestWatchLifecycle(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /api/subscriptions/seek/m1":
+			json.NewEncoder(w).Encode(domain.Subscription{TargetType: "seek", TargetID: "m1", Title: "how do I test TUIs?"}) //nolint:errcheck
+		case "GET /api/me/subscriptions":
+			json.NewEncoder(w).Encode([]domain.Subscription{{TargetType: "seek", TargetID: "m1", Unread: 2}}) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, "tok")
+	ctx := context.Background()
+	sub, err := c.Watch(ctx, domain.WatchTargetSeek, "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Title != "how do I test TUIs?" {
+		t.Errorf("subscription = %+v", sub)
+	}
+	subs, err := c.ListSubscriptions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || subs[0].Unread != 2 {
+		t.Errorf("subscriptions = %+v", subs)
+	}
+	if err := c.MarkSubscriptionRead(ctx, domain.WatchTargetSeek, "m1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Unwatch(ctx, domain.WatchTargetSeek, "m1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"PUT /api/subscriptions/seek/m1",
+		"GET /api/me/subscriptions",
+		"POST /api/subscriptions/seek/m1/read",
+		"DELETE /api/subscriptions/seek/m1",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("requests = %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds each request unless WithTimeout says otherwise.
+const DefaultTimeout = 30 * time.Second
+
+// ProxyEnv names a proxy for Grimora traffic only. It takes precedence over
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY, which are honored otherwise.
+const ProxyEnv = "GRIMORA_PROXY"
+
+// sharedTransport carries every Client not given its own, so the TUI's
+// pollers share one pool of keep-alive (and, over TLS, HTTP/2) connections
+// instead of each dialing afresh.
+var sharedTransport = newTransport(os.Getenv)
+
+func newTransport(getenv func(string) string) *http.Transport {
+	return &http.Transport{
+		Proxy: proxyFunc(getenv),
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          32,
+		MaxIdleConnsPerHost:   16, // nearly all traffic goes to one API host
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// proxyFunc picks the proxy for a request: ProxyEnv if set, else the
+// standard environment variables. A ProxyEnv without a scheme is taken as
+// http://.
+func proxyFunc(getenv func(string) string) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		p := getenv(ProxyEnv)
+		if p == "" {
+			return http.ProxyFromEnvironment(req)
+		}
+		if !strings.Contains(p, "://") {
+			p = "http://" + p
+		}
+		u, err := url.Parse(p)
+		if err != nil {
+			return nil, fmt.Errorf("client: invalid %s: %w", ProxyEnv, err)
+		}
+		return u, nil
+	}
+}
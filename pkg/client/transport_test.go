@@ -0,0 +1,43 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewSharesTransport(t *testing.T) {
+	a, b := New("http://x", "t"), New("http://y", "t")
+	if a.httpClient.Transport != b.httpClient.Transport {
+		t.Error("expected clients to share one transport")
+	}
+	if a.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("Timeout = %v, want %v", a.httpClient.Timeout, DefaultTimeout)
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://api.grimora.ai/api/me", nil)
+	env := map[string]string{ProxyEnv: "proxy.corp:3128"}
+	u, err := proxyFunc(func(k string) string { return env[k] })(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u == nil || u.String() != "http://proxy.corp:3128" {
+		t.Errorf("proxy = %v, want http://proxy.corp:3128", u)
+	}
+
+	env[ProxyEnv] = "http://%zz"
+	if _, err := proxyFunc(func(k string) string { return env[k] })(req); err == nil {
+		t.Error("expected an unparseable proxy to fail")
+	}
+}
+
+func TestTransportTuning(t *testing.T) {
+	tr := newTransport(func(string) string { return "" })
+	if !tr.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be attempted")
+	}
+	if tr.MaxIdleConnsPerHost < 2 {
+		t.Errorf("MaxIdleConnsPerHost = %d; pollers would churn connections", tr.MaxIdleConnsPerHost)
+	}
+}
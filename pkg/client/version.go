@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// APIVersion is the version of the Grimora API this client was written
+// against. Servers report theirs from /api/version; a higher one means the
+// server may send kinds of messages and events this client can only show
+// with a fallback.
+const APIVersion = 1
+
+// ServerInfo is the server's side of the version handshake.
+type ServerInfo struct {
+	Version      string   `json:"version"`              // server release, e.g. "2026.10.1"
+	APIVersion   int      `json:"api_version"`          // see APIVersion
+	Capabilities []string `json:"capabilities"`         // optional features, e.g. "spell_preview"
+	MinClient    string   `json:"min_client,omitempty"` // oldest CLI release the server fully supports
+}
+
+// Supports reports whether the server advertises capability.
+func (s ServerInfo) Supports(capability string) bool {
+	return slices.Contains(s.Capabilities, capability)
+}
+
+// Newer reports whether the server speaks a newer API than this client.
+func (s ServerInfo) Newer() bool {
+	return s.APIVersion > APIVersion
+}
+
+// GetServerInfo asks the server which API version and capabilities it
+// has. Servers from before the handshake answer with a not-found error
+// (see IsNotFound); treat those as APIVersion 1 with no capabilities.
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	var info ServerInfo
+	if err := c.get(ctx, "/api/version", &info); err != nil {
+		return nil, fmt.Errorf("client.GetServerInfo: %w", err)
+	}
+	return &info, nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetServerInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"version": "2026.10.1", "api_version": 2, "capabilities": ["spell_preview"], "min_client": "0.9.0"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	info, err := New(srv.URL, "").GetServerInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.APIVersion != 2 || info.MinClient != "0.9.0" || !info.Supports("spell_preview") || info.Supports("duels") {
+		t.Errorf("info = %+v", info)
+	}
+	if !info.Newer() {
+		t.Errorf("API version %d should be newer than %d", info.APIVersion, APIVersion)
+	}
+}
+
+func TestGetServerInfoOldServer(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	if _, err := New(srv.URL, "").GetServerInfo(context.Background()); !IsNotFound(err) {
+		t.Errorf("err = %v, want not found from a server without the handshake", err)
+	}
+}
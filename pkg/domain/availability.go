@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Availability is a guess at whether a magician is around, from their time
+// zone and active hours. It says nothing about whether they're online.
+type Availability int
+
+const (
+	AvailabilityUnknown Availability = iota // no time zone set
+	AvailabilityAsleep                      // outside their active hours
+	AvailabilityActive                      // inside their active hours
+)
+
+// DefaultActiveHours is assumed for magicians who set a time zone but no
+// active hours.
+const DefaultActiveHours = "8-23"
+
+// ParseActiveHours parses "start-end" in whole local hours (0-24). The range
+// may wrap past midnight, as in "22-6".
+func ParseActiveHours(s string) (start, end int, err error) {
+	a, b, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("active hours %q: want start-end, e.g. 9-18", s)
+	}
+	start, errA := strconv.Atoi(strings.TrimSpace(a))
+	end, errB := strconv.Atoi(strings.TrimSpace(b))
+	if errA != nil || errB != nil || start < 0 || start > 23 || end < 0 || end > 24 || start == end {
+		return 0, 0, fmt.Errorf("active hours %q: want hours between 0 and 24, e.g. 9-18", s)
+	}
+	return start, end, nil
+}
+
+// LocalTime returns now in the magician's time zone. ok is false when they
+// haven't set one or it isn't a known zone.
+func (m Magician) LocalTime(now time.Time) (local time.Time, ok bool) {
+	if m.Timezone == "" {
+		return now, false
+	}
+	loc, err := time.LoadLocation(m.Timezone)
+	if err != nil {
+		return now, false
+	}
+	return now.In(loc), true
+}
+
+// AvailabilityAt guesses whether the magician is around at now.
+func (m Magician) AvailabilityAt(now time.Time) Availability {
+	local, ok := m.LocalTime(now)
+	if !ok {
+		return AvailabilityUnknown
+	}
+	hours := m.ActiveHours
+	if hours == "" {
+		hours = DefaultActiveHours
+	}
+	start, end, err := ParseActiveHours(hours)
+	if err != nil {
+		start, end, _ = ParseActiveHours(DefaultActiveHours) //nolint:errcheck // constant
+	}
+	h := local.Hour()
+	active := h >= start && h < end
+	if start > end {
+		active = h >= start || h < end
+	}
+	if active {
+		return AvailabilityActive
+	}
+	return AvailabilityAsleep
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseActiveHours(t *testing.T) {
+	tests := []struct {
+		in         string
+		start, end int
+		wantErr    bool
+	}{
+		{"9-18", 9, 18, false},
+		{" 08 - 17 ", 8, 17, false},
+		{"22-6", 22, 6, false},
+		{"0-24", 0, 24, false},
+		{"9", 0, 0, true},
+		{"9-9", 0, 0, true},
+		{"24-8", 0, 0, true},
+		{"9-25", 0, 0, true},
+		{"nine-five", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			start, end, err := ParseActiveHours(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseActiveHours(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if start != tt.start || end != tt.end {
+				t.Errorf("ParseActiveHours(%q) = %d, %d, want %d, %d", tt.in, start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestAvailabilityAt(t *testing.T) {
+	// 12:00 UTC is 14:00 in Berlin (summer) and 21:00 in Tokyo.
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		m    Magician
+		want Availability
+	}{
+		{"no time zone", Magician{}, AvailabilityUnknown},
+		{"unknown zone", Magician{Timezone: "Mars/Olympus"}, AvailabilityUnknown},
+		{"inside hours", Magician{Timezone: "Europe/Berlin", ActiveHours: "9-18"}, AvailabilityActive},
+		{"outside hours", Magician{Timezone: "Asia/Tokyo", ActiveHours: "9-18"}, AvailabilityAsleep},
+		{"wrapping hours", Magician{Timezone: "Asia/Tokyo", ActiveHours: "20-2"}, AvailabilityActive},
+		{"default hours", Magician{Timezone: "Asia/Tokyo"}, AvailabilityActive},
+		{"default hours at night", Magician{Timezone: "Pacific/Honolulu"}, AvailabilityAsleep},
+		{"bad hours fall back", Magician{Timezone: "Europe/Berlin", ActiveHours: "soon"}, AvailabilityActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.AvailabilityAt(now); got != tt.want {
+				t.Errorf("AvailabilityAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
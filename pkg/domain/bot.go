@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Token scopes granted to bot accounts. Human sessions carry no scopes and
+// may do anything their account can.
+const (
+	ScopeRoomsPost = "rooms:post" // post messages and announcements to rooms
+	ScopeThreads   = "threads"    // start and send direct messages
+)
+
+// MessageKindAnnounce is the room message kind for bot announcements.
+const MessageKindAnnounce = "announce"
+
+// TokenInfo describes the account and permissions behind an API token.
+type TokenInfo struct {
+	MagicianID uuid.UUID  `json:"magician_id"`
+	Login      string     `json:"login"`
+	IsBot      bool       `json:"is_bot"`
+	Scopes     []string   `json:"scopes,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// HasScope reports whether the token may perform actions under scope.
+// Non-bot tokens are unscoped and always allowed.
+func (t TokenInfo) HasScope(scope string) bool {
+	return !t.IsBot || slices.Contains(t.Scopes, scope)
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "testing"
+
+func TestTokenInfoHasScope(t *testing.T) {
+	human := TokenInfo{Login: "alice"}
+	if !human.HasScope(ScopeThreads) {
+		t.Error("personal tokens should be unscoped")
+	}
+	bot := TokenInfo{Login: "shipbot", IsBot: true, Scopes: []string{ScopeRoomsPost}}
+	if !bot.HasScope(ScopeRoomsPost) {
+		t.Error("expected bot to have rooms:post")
+	}
+	if bot.HasScope(ScopeThreads) {
+		t.Error("bot without threads scope must not DM")
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Guild roles. Curators add and remove spells in the guild's chests; every
+// other member can browse them.
+const (
+	GuildRoleMember  = "member"
+	GuildRoleCurator = "curator"
+)
+
+// GuildChest is a guild's shared spell collection.
+type GuildChest struct {
+	ID          uuid.UUID    `json:"id"`
+	GuildID     string       `json:"guild_id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	SpellCount  int          `json:"spell_count"`
+	Spells      []ChestSpell `json:"spells,omitempty"` // only when fetching a single chest
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// ChestSpell is a spell in a chest along with the curator who added it.
+type ChestSpell struct {
+	Spell
+	AddedBy string    `json:"added_by"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// ChestContribution counts one member's additions to the guild's chests and
+// the casts those spells have earned.
+type ChestContribution struct {
+	Login string `json:"login"`
+	Role  string `json:"role"`
+	Added int    `json:"added"`
+	Casts int    `json:"casts"`
+}
+
+// GuildChests is the overview of a guild's chests: the chests themselves,
+// the caller's role and per-member contribution stats, most active first.
+type GuildChests struct {
+	Role          string              `json:"role"`
+	Chests        []GuildChest        `json:"chests"`
+	Contributions []ChestContribution `json:"contributions"`
+}
+
+// CanCurate reports whether the caller may add and remove chest spells.
+func (g GuildChests) CanCurate() bool {
+	return g.Role == GuildRoleCurator
+}
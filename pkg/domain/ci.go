@@ -0,0 +1,22 @@
+package domain
+
+// MessageKindCI is the room message kind for build results posted by
+// `grimora ci notify`.
+const MessageKindCI = "ci"
+
+// Build result statuses carried in a CI message's "status" metadata. They
+// match the values GitHub Actions reports for job.status.
+const (
+	CIStatusSuccess   = "success"
+	CIStatusFailure   = "failure"
+	CIStatusCancelled = "cancelled"
+)
+
+// ValidCIStatus reports whether s is a known build result status.
+func ValidCIStatus(s string) bool {
+	switch s {
+	case CIStatusSuccess, CIStatusFailure, CIStatusCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+)
+
+// Extra holds the fields of an API object this version of grimora doesn't
+// know about, keyed by their JSON name. The client fills it in as it decodes
+// responses, so a field added by a newer server survives being passed on,
+// e.g. by grimora stream --json.
+type Extra map[string]json.RawMessage
+
+// withExtra adds the fields in extra that aren't already set to the JSON
+// object data.
+func withExtra(data []byte, extra Extra) ([]byte, error) {
+	if len(extra) == 0 || !bytes.HasPrefix(data, []byte("{")) {
+		return data, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	merged := maps.Clone(extra)
+	maps.Copy(merged, fields)
+	return json.Marshal(merged)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// ForgeHistory is a magician's forging record broken down by tag, potency
+// and time, for the analytics view.
+type ForgeHistory struct {
+	ByTag       []TagForgeCount `json:"by_tag"`
+	Potency     []PotencyCount  `json:"potency"`
+	Periods     []ForgePeriod   `json:"periods"`      // oldest first
+	RankHistory []RankPoint     `json:"rank_history"` // oldest first
+}
+
+// TagForgeCount is how many spells under one tag the Forge accepted and rejected.
+type TagForgeCount struct {
+	Tag      string `json:"tag"`
+	Forged   int    `json:"forged"`
+	Rejected int    `json:"rejected"`
+}
+
+// PotencyCount is how many forged spells earned a potency rating.
+type PotencyCount struct {
+	Potency int `json:"potency"`
+	Count   int `json:"count"`
+}
+
+// ForgePeriod summarizes submissions over one period (a week).
+type ForgePeriod struct {
+	Start     time.Time `json:"start"`
+	Submitted int       `json:"submitted"`
+	Accepted  int       `json:"accepted"`
+}
+
+// AcceptanceRate returns the share of submissions accepted in the period,
+// or 0 if nothing was submitted.
+func (p ForgePeriod) AcceptanceRate() float64 {
+	if p.Submitted == 0 {
+		return 0
+	}
+	return float64(p.Accepted) / float64(p.Submitted)
+}
+
+// RankPoint is the magician's leaderboard rank on a given day.
+type RankPoint struct {
+	Date        time.Time `json:"date"`
+	Rank        int       `json:"rank"`
+	TotalRanked int       `json:"total_ranked"`
+}
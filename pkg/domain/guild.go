@@ -1,5 +1,7 @@
 package domain
 
+import "sort"
+
 // Guild represents one of the six Grimora guilds.
 type Guild struct {
 	ID       string
@@ -24,3 +26,63 @@ func ValidGuildID(id string) bool {
 	_, ok := Guilds[id]
 	return ok
 }
+
+// GuildStanding is one guild's totals in the guild rankings.
+type GuildStanding struct {
+	GuildID string     `json:"guild_id"`
+	Rank    int        `json:"rank"`
+	Members int        `json:"members"`
+	Spells  int        `json:"spells"`
+	Potency int        `json:"potency"`
+	Week    *GuildWeek `json:"week,omitempty"` // nil when there's no history, as from RankGuilds
+}
+
+// GuildWeek is how a guild's standing moved over the last seven days.
+type GuildWeek struct {
+	Members  int `json:"members"`   // magicians who joined
+	Spells   int `json:"spells"`    // spells forged
+	Potency  int `json:"potency"`   // potency those spells earned
+	LastRank int `json:"last_rank"` // rank a week ago; 0 if it wasn't ranked
+}
+
+// RankChange is how many places the guild climbed over the week; negative
+// when it fell. It's 0 when that isn't known.
+func (s GuildStanding) RankChange() int {
+	if s.Week == nil || s.Week.LastRank == 0 {
+		return 0
+	}
+	return s.Week.LastRank - s.Rank
+}
+
+// RankGuilds totals members, spells and potency per guild from cards and
+// ranks all six guilds by potency, then spells. Ties keep ID order.
+func RankGuilds(cards []MagicianCard) []GuildStanding {
+	totals := make(map[string]*GuildStanding, len(Guilds))
+	standings := make([]GuildStanding, 0, len(Guilds))
+	for id := range Guilds {
+		standings = append(standings, GuildStanding{GuildID: id})
+	}
+	sort.Slice(standings, func(i, j int) bool { return standings[i].GuildID < standings[j].GuildID })
+	for i := range standings {
+		totals[standings[i].GuildID] = &standings[i]
+	}
+	for _, c := range cards {
+		s, ok := totals[c.GuildID]
+		if !ok {
+			continue
+		}
+		s.Members++
+		s.Spells += c.SpellCount
+		s.Potency += c.TotalPotency
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Potency != standings[j].Potency {
+			return standings[i].Potency > standings[j].Potency
+		}
+		return standings[i].Spells > standings[j].Spells
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
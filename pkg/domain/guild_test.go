@@ -33,3 +33,50 @@ func TestGuildsCount(t *testing.T) {
 		t.Errorf("len(Guilds) = %d, want 6", got)
 	}
 }
+
+func TestRankGuilds(t *testing.T) {
+	card := func(guild string, spells, potency int) MagicianCard {
+		return MagicianCard{Magician: Magician{GuildID: guild}, SpellCount: spells, TotalPotency: potency}
+	}
+	got := RankGuilds([]MagicianCard{
+		card("amarok", 4, 10),
+		card("amarok", 2, 5),
+		card("nyx", 9, 20),
+		card("cipher", 3, 15),
+		card("fathom", 5, 15),
+		card("unknown", 50, 500),
+	})
+
+	if len(got) != len(Guilds) {
+		t.Fatalf("expected all %d guilds ranked, got %d", len(Guilds), len(got))
+	}
+	want := []string{"nyx", "amarok", "fathom", "cipher", "ashborne", "loomari"}
+	for i, id := range want {
+		if got[i].GuildID != id || got[i].Rank != i+1 {
+			t.Errorf("rank %d = %s (#%d), want %s", i+1, got[i].GuildID, got[i].Rank, id)
+		}
+	}
+	if got[1].Members != 2 || got[1].Spells != 6 || got[1].Potency != 15 {
+		t.Errorf("amarok totals = %+v", got[1])
+	}
+	if got[0].Week != nil || got[0].RankChange() != 0 {
+		t.Error("RankGuilds has no history, so no weekly movement")
+	}
+}
+
+func TestGuildStandingRankChange(t *testing.T) {
+	tests := []struct {
+		s    GuildStanding
+		want int
+	}{
+		{GuildStanding{Rank: 2, Week: &GuildWeek{LastRank: 5}}, 3},
+		{GuildStanding{Rank: 4, Week: &GuildWeek{LastRank: 1}}, -3},
+		{GuildStanding{Rank: 3, Week: &GuildWeek{}}, 0},
+		{GuildStanding{Rank: 3}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.s.RankChange(); got != tt.want {
+			t.Errorf("RankChange(%+v) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
@@ -15,6 +15,32 @@ type Invite struct {
 	UsedBy    *uuid.UUID `json:"used_by,omitempty"`
 	CreatedAt time.Time  `json:"created_at"`
 	UsedAt    *time.Time `json:"used_at,omitempty"`
+	// UsedByLogin is the login of the magician who claimed the code, if any.
+	UsedByLogin string `json:"used_by_login,omitempty"`
+}
+
+// Claimed reports whether the invite has been redeemed.
+func (i Invite) Claimed() bool {
+	return i.UsedBy != nil
+}
+
+// JoinURL returns the shareable signup link for the invite.
+func (i Invite) JoinURL() string {
+	return "grimora.ai/join/" + i.Code
+}
+
+// InviteProgress tracks how close a magician is to earning their next invite code.
+type InviteProgress struct {
+	SpellsForged   int `json:"spells_forged"`   // spells counted toward the next invite
+	SpellsRequired int `json:"spells_required"` // spells needed to unlock it
+}
+
+// Remaining returns how many more spells are needed for the next invite.
+func (p InviteProgress) Remaining() int {
+	if p.SpellsForged >= p.SpellsRequired {
+		return 0
+	}
+	return p.SpellsRequired - p.SpellsForged
 }
 
 // InvitesPerMagician is how many invite codes each new magician receives.
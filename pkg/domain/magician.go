@@ -1,7 +1,11 @@
 package domain
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -17,13 +21,62 @@ type Magician struct {
 	Email       string     `json:"email,omitempty"`
 	City        string     `json:"city,omitempty"`
 	DisplayName string     `json:"display_name,omitempty"`
+	Bio         string     `json:"bio,omitempty"`
 	Stack       []string   `json:"stack,omitempty"`
 	Edition     string     `json:"edition,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	LastSeenAt  *time.Time `json:"last_seen_at,omitempty"`
+	IsBot       bool       `json:"is_bot,omitempty"`
+	// Availability hints: an IANA time zone such as "Europe/Berlin" and the
+	// local hours they're usually around, e.g. "9-18".
+	Timezone    string `json:"timezone,omitempty"`
+	ActiveHours string `json:"active_hours,omitempty"`
 	// Ceremony fields (migration 005)
 	CardURL     string   `json:"card_url,omitempty"`
 	CardStatus  string   `json:"card_status,omitempty"`
 	TopLanguage string   `json:"top_language,omitempty"`
 	Fragments   []string `json:"fragments,omitempty"`
 }
+
+// Archetypes a magician can claim on their profile.
+var Archetypes = []string{"architect", "alchemist", "oracle", "tinkerer", "sentinel", "chronicler"}
+
+// Profile text limits, in characters.
+const (
+	MaxDisplayNameLen = 40
+	MaxCityLen        = 60
+	MaxBioLen         = 160
+)
+
+// ProfileUpdate is the body of a profile update. Nil fields are left as
+// they are; an empty string clears the setting.
+type ProfileUpdate struct {
+	Timezone    *string `json:"timezone,omitempty"`
+	ActiveHours *string `json:"active_hours,omitempty"`
+	City        *string `json:"city,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+	Bio         *string `json:"bio,omitempty"`
+	Archetype   *string `json:"archetype,omitempty"`
+}
+
+// TextProblems checks the free-text and archetype fields being changed and
+// returns what's wrong with each, keyed by JSON field name, the way the
+// API reports validation failures. Nil means they're fine.
+func (u ProfileUpdate) TextProblems() map[string]string {
+	problems := make(map[string]string)
+	checkLen := func(field string, v *string, limit int) {
+		if v != nil && utf8.RuneCountInString(strings.TrimSpace(*v)) > limit {
+			problems[field] = fmt.Sprintf("at most %d characters", limit)
+		}
+	}
+	checkLen("display_name", u.DisplayName, MaxDisplayNameLen)
+	checkLen("city", u.City, MaxCityLen)
+	checkLen("bio", u.Bio, MaxBioLen)
+	if u.Archetype != nil && *u.Archetype != "" && !slices.Contains(Archetypes, *u.Archetype) {
+		problems["archetype"] = "one of " + strings.Join(Archetypes, ", ")
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProfileUpdateTextProblems(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	if p := (ProfileUpdate{City: ptr("Lisbon"), Archetype: ptr("oracle"), Bio: ptr("")}).TextProblems(); p != nil {
+		t.Errorf("valid update flagged: %v", p)
+	}
+	p := ProfileUpdate{
+		DisplayName: ptr(strings.Repeat("n", MaxDisplayNameLen+1)),
+		Bio:         ptr(strings.Repeat("é", MaxBioLen)),
+		Archetype:   ptr("wizard"),
+	}.TextProblems()
+	if p["display_name"] == "" || p["archetype"] == "" {
+		t.Errorf("problems = %v, want display_name and archetype", p)
+	}
+	if _, ok := p["bio"]; ok {
+		t.Error("a bio at the limit counted in runes should pass")
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +22,36 @@ type Notification struct {
 	RefSlug     string     `json:"ref_slug,omitempty"`
 	Read        bool       `json:"read"`
 	CreatedAt   time.Time  `json:"created_at"`
+	Extra       Extra      `json:"-"` // fields from a newer server
+}
+
+// Notification types. RefID and RefSlug point at the source: the room
+// message (RefSlug is the room) for mentions, the spell for upvotes and
+// comments, and the thread for DMs. Follows carry no ref; the actor is the
+// source.
+const (
+	NotifMention = "mention"
+	NotifFollow  = "follow"
+	NotifUpvote  = "upvote"
+	NotifComment = "comment"
+	NotifDM      = "dm"
+)
+
+// NotificationTypes are the notification types this version knows how to
+// show.
+var NotificationTypes = []string{NotifMention, NotifFollow, NotifUpvote, NotifComment, NotifDM}
+
+// KnownType reports whether n's type is one of NotificationTypes.
+func (n Notification) KnownType() bool {
+	return slices.Contains(NotificationTypes, n.Type)
+}
+
+// Validate reports a notification the center can't place at all.
+func (n Notification) Validate() error {
+	if n.Type == "" {
+		return errors.New("notification has no type")
+	}
+	return nil
 }
 
 // GroupedNotification extends Notification with multi-actor info for display.
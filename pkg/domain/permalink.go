@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// WebURL is the Grimora website. Permalinks point into it.
+const WebURL = "https://grimora.ai"
+
+// HallSlug is the slug of the main Hall room.
+const HallSlug = "the-hall"
+
+// Kinds of Link.
+const (
+	LinkMessage  = "message"
+	LinkSpell    = "spell"
+	LinkMagician = "magician"
+)
+
+// Link is a parsed grimora.ai permalink.
+type Link struct {
+	Kind  string // LinkMessage, LinkSpell or LinkMagician
+	Room  string // room slug of a message; "" is the main Hall
+	ID    string // message or spell ID
+	Login string // magician login
+}
+
+// MessagePermalink returns the link to message id in room. Messages in the
+// main Hall link as /hall/<id>, others as /hall/<room>/<id>.
+func MessagePermalink(room, id string) string {
+	if room == "" || room == HallSlug {
+		return WebURL + "/hall/" + id
+	}
+	return WebURL + "/hall/" + url.PathEscape(room) + "/" + id
+}
+
+// SpellPermalink returns the link to spell id.
+func SpellPermalink(id string) string {
+	return WebURL + "/spells/" + id
+}
+
+// MagicianPermalink returns the link to login's card.
+func MagicianPermalink(login string) string {
+	return WebURL + "/@" + login
+}
+
+// ParseLink parses a permalink to a message, spell or magician:
+//
+//	https://grimora.ai/hall/<message-id>
+//	https://grimora.ai/hall/<room>/<message-id>
+//	https://grimora.ai/spells/<spell-id>
+//	https://grimora.ai/@<login>
+//
+// The scheme may be left off.
+func ParseLink(s string) (Link, error) {
+	raw := strings.TrimSpace(s)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Link{}, fmt.Errorf("not a grimora.ai link: %q", s)
+	}
+	if host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."); host != "grimora.ai" {
+		return Link{}, fmt.Errorf("not a grimora.ai link: %q", s)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && strings.HasPrefix(parts[0], "@") && len(parts[0]) > 1:
+		return Link{Kind: LinkMagician, Login: parts[0][1:]}, nil
+	case len(parts) == 2 && parts[0] == "spells" && isUUID(parts[1]):
+		return Link{Kind: LinkSpell, ID: parts[1]}, nil
+	case len(parts) == 2 && parts[0] == "hall" && isUUID(parts[1]):
+		return Link{Kind: LinkMessage, ID: parts[1]}, nil
+	case len(parts) == 3 && parts[0] == "hall" && parts[1] != "" && isUUID(parts[2]):
+		room := parts[1]
+		if room == HallSlug {
+			room = ""
+		}
+		return Link{Kind: LinkMessage, Room: room, ID: parts[2]}, nil
+	}
+	return Link{}, fmt.Errorf("don't know how to open %q: want a message, spell or magician link", s)
+}
+
+func isUUID(s string) bool {
+	_, err := uuid.Parse(s)
+	return err == nil
+}
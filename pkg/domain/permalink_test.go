@@ -0,0 +1,72 @@
+package domain
+
+import "testing"
+
+const testID = "8f14e45f-ceea-467f-a8f5-6a0e2c1b7d3e"
+
+func TestMessagePermalink(t *testing.T) {
+	tests := []struct {
+		room, want string
+	}{
+		{"", "https://grimora.ai/hall/" + testID},
+		{"the-hall", "https://grimora.ai/hall/" + testID},
+		{"guild-loomari", "https://grimora.ai/hall/guild-loomari/" + testID},
+	}
+	for _, tt := range tests {
+		if got := MessagePermalink(tt.room, testID); got != tt.want {
+			t.Errorf("MessagePermalink(%q) = %q, want %q", tt.room, got, tt.want)
+		}
+	}
+}
+
+func TestParseLink(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Link
+	}{
+		{"https://grimora.ai/hall/" + testID, Link{Kind: LinkMessage, ID: testID}},
+		{"grimora.ai/hall/the-hall/" + testID, Link{Kind: LinkMessage, ID: testID}},
+		{"https://www.grimora.ai/hall/guild-loomari/" + testID + "/", Link{Kind: LinkMessage, Room: "guild-loomari", ID: testID}},
+		{"https://grimora.ai/spells/" + testID, Link{Kind: LinkSpell, ID: testID}},
+		{"https://grimora.ai/@octocat", Link{Kind: LinkMagician, Login: "octocat"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseLink(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseLink(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseLinkRejects(t *testing.T) {
+	for _, in := range []string{
+		"https://example.com/hall/" + testID,
+		"https://grimora.ai/hall/not-an-id",
+		"https://grimora.ai/spells",
+		"https://grimora.ai/@",
+		"https://grimora.ai/faq",
+		"",
+	} {
+		if l, err := ParseLink(in); err == nil {
+			t.Errorf("ParseLink(%q) = %+v, want error", in, l)
+		}
+	}
+}
+
+func TestPermalinkRoundTrip(t *testing.T) {
+	for _, room := range []string{"", "guild-loomari"} {
+		l, err := ParseLink(MessagePermalink(room, testID))
+		if err != nil || l.Room != room || l.ID != testID {
+			t.Errorf("round trip of room %q = %+v, %v", room, l, err)
+		}
+	}
+}
+
+func TestPermalinksParse(t *testing.T) {
+	if got, err := ParseLink(SpellPermalink(testID)); err != nil || got != (Link{Kind: LinkSpell, ID: testID}) {
+		t.Errorf("ParseLink(SpellPermalink) = %+v, %v", got, err)
+	}
+	if got, err := ParseLink(MagicianPermalink("ada")); err != nil || got != (Link{Kind: LinkMagician, Login: "ada"}) {
+		t.Errorf("ParseLink(MagicianPermalink) = %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// placeholderRe matches the two ways spells mark text to fill in:
+// {{name}}, and an all-caps <NAME> of at least two characters, so code
+// like <T> or <div> in a spell isn't mistaken for one.
+var placeholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z][\w .-]{0,39}?)\s*\}\}|<([A-Z][A-Z0-9_-]{1,39})>`)
+
+// Placeholder is a blank in a spell's text for the caster to fill in.
+type Placeholder struct {
+	Token string // as written in the spell, e.g. "{{language}}" or "<PROJECT>"
+	Name  string // what it asks for, e.g. "language" or "PROJECT"
+}
+
+// Placeholders returns the blanks in text in the order they first appear.
+// A name used more than once, however it's written, is listed once at its
+// first token; filling it fills every occurrence.
+func Placeholders(text string) []Placeholder {
+	var out []Placeholder
+	seen := make(map[string]bool)
+	for _, m := range placeholderRe.FindAllStringSubmatch(text, -1) {
+		p := Placeholder{Token: m[0], Name: placeholderName(m)}
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// FillPlaceholders replaces each blank in text with its value in values,
+// keyed by Name. Blanks without a value, or with an empty one, are left as
+// written.
+func FillPlaceholders(text string, values map[string]string) string {
+	return placeholderRe.ReplaceAllStringFunc(text, func(token string) string {
+		if v := values[placeholderName(placeholderRe.FindStringSubmatch(token))]; strings.TrimSpace(v) != "" {
+			return v
+		}
+		return token
+	})
+}
+
+// placeholderName picks the name out of a placeholderRe match.
+func placeholderName(m []string) string {
+	if m[1] != "" {
+		return m[1]
+	}
+	return m[2]
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	text := "Port this {{ language }} service to <TARGET_LANG> for <PROJECT>. Keep {{language}} idioms out of {{PROJECT}}.\nfunc Map[T any](xs []T) <div>"
+	want := []Placeholder{
+		{Token: "{{ language }}", Name: "language"},
+		{Token: "<TARGET_LANG>", Name: "TARGET_LANG"},
+		{Token: "<PROJECT>", Name: "PROJECT"},
+	}
+	if got := Placeholders(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("Placeholders() = %+v, want %+v", got, want)
+	}
+	if got := Placeholders("no blanks, just <T> and <b>"); got != nil {
+		t.Errorf("Placeholders() = %+v, want none", got)
+	}
+}
+
+func TestFillPlaceholders(t *testing.T) {
+	text := "Review <PROJECT> in {{ language }}; {{PROJECT}} ships Friday. Ask <OWNER>."
+	got := FillPlaceholders(text, map[string]string{
+		"PROJECT":  "grimora",
+		"language": "Go",
+		"OWNER":    "  ",
+	})
+	if want := "Review grimora in Go; grimora ships Friday. Ask <OWNER>."; got != want {
+		t.Errorf("FillPlaceholders() = %q, want %q", got, want)
+	}
+}
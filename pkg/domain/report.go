@@ -0,0 +1,21 @@
+package domain
+
+// Report reasons.
+const (
+	ReportSpam  = "spam"
+	ReportAbuse = "abuse"
+	ReportOther = "other"
+)
+
+// Reportable target kinds.
+const (
+	ReportTargetMessage = "message"
+	ReportTargetSpell   = "spell"
+)
+
+// Report flags a message or spell for the moderators to look at.
+type Report struct {
+	TargetType string `json:"target_type"` // "message" or "spell"
+	TargetID   string `json:"target_id"`
+	Reason     string `json:"reason"` // ReportSpam, ReportAbuse or ReportOther
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"encoding/json"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,9 +21,87 @@ type Room struct {
 	SlowmodeSeconds int        `json:"slowmode_seconds,omitempty"`
 	MaxMembers      int        `json:"max_members,omitempty"` // 0 = unlimited
 	Archived        bool       `json:"archived,omitempty"`
+	Topic           string     `json:"topic,omitempty"` // shown in the room's header
+	LastMessageAt   time.Time  `json:"last_message_at,omitzero"`
+	Unread          int        `json:"unread,omitempty"` // messages since the caller last looked
 	CreatedAt       time.Time  `json:"created_at"`
 }
 
+// Room types.
+const (
+	RoomTypeHall  = "hall"
+	RoomTypeGuild = "guild"
+	RoomTypeTopic = "topic"
+)
+
+// OwnedBy reports whether the magician with id created the room. Only topic
+// rooms have owners.
+func (r Room) OwnedBy(id uuid.UUID) bool {
+	return r.CreatedBy != nil && id != uuid.Nil && *r.CreatedBy == id
+}
+
+// Room slug limits.
+const (
+	MinRoomSlugLen = 3
+	MaxRoomSlugLen = 32
+)
+
+// ValidRoomSlug reports whether slug can name a new room: lowercase letters,
+// digits and single dashes, not starting or ending with a dash.
+func ValidRoomSlug(slug string) bool {
+	if len(slug) < MinRoomSlugLen || len(slug) > MaxRoomSlugLen {
+		return false
+	}
+	if slug[0] == '-' || slug[len(slug)-1] == '-' || strings.Contains(slug, "--") {
+		return false
+	}
+	for _, r := range slug {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// cityRoomPrefix starts the slug of every city chapter room.
+const cityRoomPrefix = "city-"
+
+// cityFolds spells common accented letters the way a slug can.
+var cityFolds = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a",
+	"ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ý", "y", "ÿ", "y", "ß", "ss", "ł", "l",
+)
+
+// CityRoomSlug returns the slug of the chapter room for magicians in city,
+// e.g. "city-sao-paulo" for "São Paulo", or "" when nothing of the name
+// survives as a slug.
+func CityRoomSlug(city string) string {
+	var b strings.Builder
+	b.WriteString(cityRoomPrefix)
+	dash := true // no dash straight after the prefix
+	for _, r := range cityFolds.Replace(strings.ToLower(strings.TrimSpace(city))) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() == MaxRoomSlugLen {
+			break
+		}
+	}
+	slug := strings.TrimRight(b.String(), "-")
+	if !ValidRoomSlug(slug) || slug == strings.TrimSuffix(cityRoomPrefix, "-") {
+		return ""
+	}
+	return slug
+}
+
 // RoomMessage is a single message in a room.
 type RoomMessage struct {
 	ID          uuid.UUID       `json:"id"`
@@ -29,10 +109,34 @@ type RoomMessage struct {
 	SenderID    uuid.UUID       `json:"sender_id"`
 	SenderLogin string          `json:"sender_login"`
 	SenderGuild string          `json:"sender_guild,omitempty"`
+	SenderIsBot bool            `json:"sender_is_bot,omitempty"`
 	Body        string          `json:"body"`
 	Kind        string          `json:"kind"`
 	Metadata    json.RawMessage `json:"metadata,omitempty"`
 	CreatedAt   time.Time       `json:"created_at"`
+	Extra       Extra           `json:"-"` // fields from a newer server
+}
+
+// MessageKinds are the kinds of RoomMessage this version knows how to show.
+var MessageKinds = []string{
+	"message", "build-start", "build-update", "ship", "seek", "forge-verdict",
+	"cast", "join", "leave", MessageKindAnnounce, MessageKindCI,
+}
+
+// KnownKind reports whether m's kind is one of MessageKinds. An empty kind
+// is a plain message.
+func (m RoomMessage) KnownKind() bool {
+	return m.Kind == "" || slices.Contains(MessageKinds, m.Kind)
+}
+
+// MarshalJSON encodes m with its Extra fields, so they round-trip.
+func (m RoomMessage) MarshalJSON() ([]byte, error) {
+	type plain RoomMessage
+	data, err := json.Marshal(plain(m))
+	if err != nil {
+		return nil, err
+	}
+	return withExtra(data, m.Extra)
 }
 
 // Reaction represents a mash reaction on a room message.
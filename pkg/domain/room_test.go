@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidRoomSlug(t *testing.T) {
+	tests := []struct {
+		slug  string
+		valid bool
+	}{
+		{"go-tips", true},
+		{"rust2026", true},
+		{"abc", true},
+		{"ab", false},
+		{"Go-Tips", false},
+		{"-go", false},
+		{"go-", false},
+		{"go--tips", false},
+		{"go tips", false},
+		{"gö-tips", false},
+		{"a-very-long-room-slug-that-goes-on", false},
+	}
+	for _, tt := range tests {
+		if got := ValidRoomSlug(tt.slug); got != tt.valid {
+			t.Errorf("ValidRoomSlug(%q) = %v, want %v", tt.slug, got, tt.valid)
+		}
+	}
+}
+
+func TestCityRoomSlug(t *testing.T) {
+	tests := map[string]string{
+		"Lisbon":         "city-lisbon",
+		"  New York ":    "city-new-york",
+		"São Paulo":      "city-sao-paulo",
+		"Washington, DC": "city-washington-dc",
+		"Łódź":           "city-lod",
+		"東京":             "",
+		"":               "",
+		"Llanfairpwllgwyngyll-gogerychwyrndrobwll": "city-llanfairpwllgwyngyll-gogery",
+	}
+	for city, want := range tests {
+		got := CityRoomSlug(city)
+		if got != want {
+			t.Errorf("CityRoomSlug(%q) = %q, want %q", city, got, want)
+		}
+		if got != "" && !ValidRoomSlug(got) {
+			t.Errorf("CityRoomSlug(%q) = %q, not a valid slug", city, got)
+		}
+	}
+}
+
+func TestRoomOwnedBy(t *testing.T) {
+	owner := uuid.New()
+	r := Room{RoomType: RoomTypeTopic, CreatedBy: &owner}
+	if !r.OwnedBy(owner) {
+		t.Error("expected creator to own the room")
+	}
+	if r.OwnedBy(uuid.New()) {
+		t.Error("expected someone else not to own the room")
+	}
+	if (Room{RoomType: RoomTypeHall}).OwnedBy(owner) {
+		t.Error("expected rooms without a creator to have no owner")
+	}
+	if r.OwnedBy(uuid.Nil) {
+		t.Error("expected the zero ID to own nothing")
+	}
+}
+
+func TestRoomMessageExtraRoundTrips(t *testing.T) {
+	m := RoomMessage{Kind: "poll", Body: "lunch?", Extra: Extra{"options": json.RawMessage(`["tacos","ramen"]`)}}
+	if m.KnownKind() {
+		t.Error("poll should be an unknown kind")
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if string(fields["options"]) != `["tacos","ramen"]` || string(fields["body"]) != `"lunch?"` {
+		t.Errorf("encoded = %s, want declared and extra fields", data)
+	}
+	if !(RoomMessage{}).KnownKind() || !(RoomMessage{Kind: MessageKindCI}).KnownKind() {
+		t.Error("plain and CI messages are known kinds")
+	}
+}
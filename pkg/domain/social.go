@@ -1,13 +1,19 @@
 package domain
 
 import (
+	"encoding/json"
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// StreamKinds are the kinds of StreamEvent this version knows how to show.
+var StreamKinds = []string{"spell", "weapon", "member", "muse", "reject", "featured", "convo", "reforge"}
+
 // StreamEvent represents one item in the activity feed.
-// Kind is one of "spell", "weapon", "member", "muse", "reject", "featured", "convo".
+// Kind is one of StreamKinds, or a kind added by a newer server.
 type StreamEvent struct {
 	Kind          string    `json:"kind"`
 	ID            uuid.UUID `json:"id"`
@@ -22,6 +28,30 @@ type StreamEvent struct {
 	Contributions int       `json:"contributions,omitempty"` // For join events
 	TopLanguage   string    `json:"top_language,omitempty"`  // For join events
 	CreatedAt     time.Time `json:"created_at"`
+	Extra         Extra     `json:"-"` // fields from a newer server
+}
+
+// KnownKind reports whether e's kind is one of StreamKinds.
+func (e StreamEvent) KnownKind() bool {
+	return slices.Contains(StreamKinds, e.Kind)
+}
+
+// Validate reports an event the feed can't place at all.
+func (e StreamEvent) Validate() error {
+	if e.Kind == "" {
+		return errors.New("stream event has no kind")
+	}
+	return nil
+}
+
+// MarshalJSON encodes e with its Extra fields, so they round-trip.
+func (e StreamEvent) MarshalJSON() ([]byte, error) {
+	type plain StreamEvent
+	data, err := json.Marshal(plain(e))
+	if err != nil {
+		return nil, err
+	}
+	return withExtra(data, e.Extra)
 }
 
 // MagicianCard is a magician profile enriched for the "who" browse view.
@@ -30,19 +60,54 @@ type MagicianCard struct {
 	SpellCount   int    `json:"spell_count"`
 	WeaponCount  int    `json:"weapon_count"`
 	IsFollowing  bool   `json:"is_following"`
+	FollowsYou   bool   `json:"follows_you,omitempty"`   // They follow the caller
 	Emblem       string `json:"emblem,omitempty"`        // Guild emoji
 	Move         int    `json:"move,omitempty"`          // Leaderboard rank movement
 	TotalPotency int    `json:"total_potency,omitempty"` // Total potency score
 	Online       bool   `json:"online,omitempty"`        // Presence status
+	Away         bool   `json:"away,omitempty"`          // Online but idle
 }
 
-// Thread is a DM conversation between two magicians.
+// Presence statuses a client reports in its heartbeat.
+const (
+	PresenceOnline = "online"
+	PresenceAway   = "away"
+)
+
+// Thread is a DM conversation between two magicians, or a group thread
+// between three or more.
 type Thread struct {
-	ID           uuid.UUID `json:"id"`
-	OtherLogin   string    `json:"other_login"`
-	OtherGuildID string    `json:"other_guild_id"`
-	LastMessage  string    `json:"last_message,omitempty"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID            uuid.UUID           `json:"id"`
+	OtherLogin    string              `json:"other_login"` // the other magician; empty in a group thread
+	OtherGuildID  string              `json:"other_guild_id"`
+	Participants  []ThreadParticipant `json:"participants,omitempty"` // everyone but the caller, in a group thread
+	LastMessage   string              `json:"last_message,omitempty"`
+	LastMessageAt time.Time           `json:"last_message_at,omitzero"`
+	Unread        int                 `json:"unread,omitempty"` // messages the caller hasn't read
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+// ThreadParticipant is one of the magicians in a group thread.
+type ThreadParticipant struct {
+	Login   string `json:"login"`
+	GuildID string `json:"guild_id"`
+}
+
+// IsGroup reports whether t has more than one other participant.
+func (t Thread) IsGroup() bool {
+	return len(t.Participants) > 1
+}
+
+// Logins returns everyone in t but the caller.
+func (t Thread) Logins() []string {
+	if !t.IsGroup() {
+		return []string{t.OtherLogin}
+	}
+	logins := make([]string, len(t.Participants))
+	for i, p := range t.Participants {
+		logins[i] = p.Login
+	}
+	return logins
 }
 
 // LeaderboardEntry is one row in the leaderboard ranking.
@@ -60,10 +125,11 @@ type LeaderboardEntry struct {
 
 // Message is a single direct message.
 type Message struct {
-	ID          uuid.UUID `json:"id"`
-	ThreadID    uuid.UUID `json:"thread_id"`
-	SenderID    uuid.UUID `json:"sender_id"`
-	SenderLogin string    `json:"sender_login"`
-	Body        string    `json:"body"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uuid.UUID  `json:"id"`
+	ThreadID    uuid.UUID  `json:"thread_id"`
+	SenderID    uuid.UUID  `json:"sender_id"`
+	SenderLogin string     `json:"sender_login"`
+	Body        string     `json:"body"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ReadAt      *time.Time `json:"read_at,omitempty"` // when the recipient read it; nil until then
 }
@@ -1,29 +1,46 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
 // Spell represents a shared prompt.
 type Spell struct {
-	ID         uuid.UUID `json:"id"`
-	MagicianID uuid.UUID `json:"magician_id"`
-	Text       string    `json:"text"`
-	Tag        string    `json:"tag"`
-	Model      string    `json:"model,omitempty"`
-	Stack      []string  `json:"stack,omitempty"`
-	Context    string    `json:"context,omitempty"`
-	Potency    int       `json:"potency"`
-	Status     string    `json:"status"` // "pending", "published", "removed"
-	Upvotes    int       `json:"upvotes"`
-	Preview    string    `json:"preview,omitempty"`    // Truncated text for list views
-	Voice      string    `json:"voice,omitempty"`      // Grimoire commentary
-	Situations string    `json:"situations,omitempty"` // LLM-generated search situations
-	Author     *Author   `json:"author,omitempty"`     // Author info for display
-	Comments   []Comment `json:"comments,omitempty"`   // Spell comments
-	CreatedAt  time.Time `json:"created_at"`
+	ID         uuid.UUID     `json:"id"`
+	MagicianID uuid.UUID     `json:"magician_id"`
+	Text       string        `json:"text"`
+	Tag        string        `json:"tag"`
+	Model      string        `json:"model,omitempty"`
+	Stack      []string      `json:"stack,omitempty"`
+	Context    string        `json:"context,omitempty"`
+	Potency    int           `json:"potency"`
+	Status     string        `json:"status"` // "pending", "published", "removed"
+	Upvotes    int           `json:"upvotes"`
+	Casts      int           `json:"casts,omitempty"`       // Times the spell has been used
+	Preview    string        `json:"preview,omitempty"`     // Truncated text for list views
+	Voice      string        `json:"voice,omitempty"`       // Grimoire commentary
+	Situations string        `json:"situations,omitempty"`  // LLM-generated search situations
+	Author     *Author       `json:"author,omitempty"`      // Author info for display
+	Comments   []Comment     `json:"comments,omitempty"`    // Spell comments
+	Saved      bool          `json:"saved,omitempty"`       // Bookmarked by the viewer
+	Watching   bool          `json:"watching,omitempty"`    // Viewer is subscribed to updates
+	ForkedFrom []SpellOrigin `json:"forked_from,omitempty"` // Attribution chain for a fork, nearest first
+	Forks      int           `json:"forks,omitempty"`       // Times the spell has been forked
+	Reforge    *Reevaluation `json:"reforge,omitempty"`     // The author's latest re-forge request
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// SpellOrigin is one link in a fork's attribution chain: a spell it was
+// forked from, directly or by way of other forks.
+type SpellOrigin struct {
+	ID      uuid.UUID `json:"id"`
+	Login   string    `json:"login"`   // the spell's author
+	Preview string    `json:"preview"` // the opening of its text
 }
 
 // Valid spell tags.
@@ -76,6 +93,91 @@ func ValidTag(tag string) bool {
 	return validTagSet[tag]
 }
 
+// MaxTagLen is the longest tag the API accepts.
+const MaxTagLen = 32
+
+// WellFormedTag reports whether tag can be used on a new spell: 2 to
+// MaxTagLen lowercase letters, digits and inner hyphens. ValidTags are the
+// curated ones; any well-formed tag is accepted.
+func WellFormedTag(tag string) bool {
+	if len(tag) < 2 || len(tag) > MaxTagLen || tag[0] == '-' || tag[len(tag)-1] == '-' {
+		return false
+	}
+	for _, r := range tag {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeTag lowercases tag and joins its words with hyphens, so
+// "System Prompt" becomes "system-prompt".
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
+
+// Spell text limits the API enforces, in runes after trimming space. They
+// mirror its validation of POST /api/spells, which rejects text outside them
+// with a too_short or too_long field error ("at least 20 characters"), so
+// the create form and grimora forge can say so before the round trip. The
+// API doesn't publish them; if it ever changes them, its field errors still
+// come back and are shown as they are.
+const (
+	MinSpellLen = 20
+	MaxSpellLen = 2000
+)
+
+// SpellLengthProblem says why text is too short or too long for a spell, or
+// returns "" if its length is fine.
+func SpellLengthProblem(text string) string {
+	n := utf8.RuneCountInString(strings.TrimSpace(text))
+	switch {
+	case n == 0:
+		return "required"
+	case n < MinSpellLen:
+		return fmt.Sprintf("too short (%d/%d characters)", n, MinSpellLen)
+	case n > MaxSpellLen:
+		return fmt.Sprintf("too long (%d/%d characters)", n, MaxSpellLen)
+	}
+	return ""
+}
+
+// LintSpellMarkdown returns warnings about markdown in text that won't
+// render the way it reads: unclosed code fences, stray backticks or bold
+// markers, and empty headings. Nothing here stops a spell being submitted.
+func LintSpellMarkdown(text string) []string {
+	var warnings []string
+	inFence, fenceLine := false, 0
+	bold := 0
+	for i, line := range strings.Split(text, "\n") {
+		n := i + 1
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			fenceLine = n
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if h := strings.TrimLeft(trimmed, "#"); h != trimmed && len(trimmed)-len(h) <= 6 && strings.TrimSpace(h) == "" {
+			warnings = append(warnings, fmt.Sprintf("empty heading on line %d", n))
+		}
+		if strings.Count(line, "`")%2 == 1 {
+			warnings = append(warnings, fmt.Sprintf("unmatched ` on line %d", n))
+		}
+		bold += strings.Count(line, "**")
+	}
+	if inFence {
+		warnings = append(warnings, fmt.Sprintf("code block opened on line %d is never closed", fenceLine))
+	}
+	if bold%2 == 1 {
+		warnings = append(warnings, "unclosed ** bold")
+	}
+	return warnings
+}
+
 // SpellMatch is a spell candidate returned by semantic similarity search.
 type SpellMatch struct {
 	ID         uuid.UUID `json:"id"`
@@ -110,6 +212,28 @@ type ForgeVerdict struct {
 	Stats       *ForgeStats `json:"stats,omitempty"`       // Magician's forge stats
 }
 
+// Re-forge request statuses.
+const (
+	ReevaluationPending = "pending"
+	ReevaluationDone    = "done"
+)
+
+// Reevaluation is an author's request for the Grimoire to weigh one of their
+// spells again, and the new verdict once it has.
+type Reevaluation struct {
+	Status      string    `json:"status"`            // ReevaluationPending or ReevaluationDone
+	OldPotency  int       `json:"old_potency"`       // potency when the request was made
+	Potency     int       `json:"potency,omitempty"` // the new potency, once done
+	Voice       string    `json:"voice,omitempty"`   // the Grimoire's new commentary, once done
+	RequestedAt time.Time `json:"requested_at"`
+	ResolvedAt  time.Time `json:"resolved_at,omitzero"`
+}
+
+// Pending reports whether the Grimoire has yet to rule on r.
+func (r *Reevaluation) Pending() bool {
+	return r != nil && r.Status == ReevaluationPending
+}
+
 // ForgeStats tracks a magician's forging record and competitive rank.
 type ForgeStats struct {
 	SpellsForged   int     `json:"spells_forged"`
@@ -118,6 +242,14 @@ type ForgeStats struct {
 	AcceptanceRate float64 `json:"acceptance_rate"`
 	Rank           int     `json:"rank"`
 	TotalRanked    int     `json:"total_ranked"`
+	SpellsCast     int     `json:"spells_cast,omitempty"` // spells the magician has used
+}
+
+// SpellCast is the receipt for casting (using) a spell.
+type SpellCast struct {
+	SpellID    uuid.UUID `json:"spell_id"`
+	Casts      int       `json:"casts"`       // times the spell has now been cast
+	SpellsCast int       `json:"spells_cast"` // casts the caster has recorded
 }
 
 // Author is the magician who created a spell.
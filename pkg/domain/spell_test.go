@@ -1,6 +1,10 @@
 package domain
 
-import "testing"
+import (
+	"slices"
+	"strings"
+	"testing"
+)
 
 func TestValidTag(t *testing.T) {
 	tests := []struct {
@@ -38,3 +42,79 @@ func TestValidTagsCount(t *testing.T) {
 		t.Errorf("len(ValidTags) = %d, want 20", got)
 	}
 }
+
+func TestWellFormedTag(t *testing.T) {
+	for tag, want := range map[string]bool{
+		"debugging":                           true,
+		"rust":                                true,
+		"k8s":                                 true,
+		"llm-evals":                           true,
+		"":                                    false,
+		"x":                                   false,
+		"Rust":                                false,
+		"-rust":                               false,
+		"rust-":                               false,
+		"two words":                           false,
+		"emoji-✨":                             false,
+		"a-very-long-tag-that-goes-on-and-on": false,
+	} {
+		if got := WellFormedTag(tag); got != want {
+			t.Errorf("WellFormedTag(%q) = %v, want %v", tag, got, want)
+		}
+	}
+}
+
+func TestNormalizeTag(t *testing.T) {
+	if got := NormalizeTag("  System Prompt "); got != "system-prompt" {
+		t.Errorf("NormalizeTag = %q, want %q", got, "system-prompt")
+	}
+}
+
+func TestForgePeriodAcceptanceRate(t *testing.T) {
+	if got := (ForgePeriod{}).AcceptanceRate(); got != 0 {
+		t.Errorf("empty period rate = %v, want 0", got)
+	}
+	if got := (ForgePeriod{Submitted: 4, Accepted: 3}).AcceptanceRate(); got != 0.75 {
+		t.Errorf("rate = %v, want 0.75", got)
+	}
+}
+
+func TestSpellLengthProblem(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"   ", "required"},
+		{"too brief", "too short (9/20 characters)"},
+		{"  long enough to be a real spell  ", ""},
+		{strings.Repeat("é", MaxSpellLen), ""},
+		{strings.Repeat("é", MaxSpellLen+1), "too long (2001/2000 characters)"},
+	}
+	for _, tt := range tests {
+		if got := SpellLengthProblem(tt.text); got != tt.want {
+			t.Errorf("SpellLengthProblem(%.20q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLintSpellMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"clean", "# Review\nRun `go vet` first, then **read** the diff.\n```\n` ** #\n```", nil},
+		{"open fence", "Try this:\n```go\nfmt.Println()", []string{"code block opened on line 2 is never closed"}},
+		{"stray backtick", "Run `go vet first", []string{"unmatched ` on line 1"}},
+		{"empty heading", "##\nsteps", []string{"empty heading on line 1"}},
+		{"hashtag is not a heading", "#golang tips", nil},
+		{"open bold", "**always check errors", []string{"unclosed ** bold"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LintSpellMarkdown(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("LintSpellMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
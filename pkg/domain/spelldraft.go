@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Suggestion statuses.
+const (
+	SuggestionPending  = "pending"
+	SuggestionAccepted = "accepted"
+	SuggestionRejected = "rejected"
+)
+
+// SpellDraft is an unpublished spell shared with other magicians for review.
+// Anyone with the share link can read it and suggest edits; only the owner
+// can accept them or publish the spell.
+type SpellDraft struct {
+	ID          uuid.UUID         `json:"id"`
+	MagicianID  uuid.UUID         `json:"magician_id"`
+	Text        string            `json:"text"`
+	Tag         string            `json:"tag,omitempty"`
+	Model       string            `json:"model,omitempty"`
+	Context     string            `json:"context,omitempty"`
+	ShareURL    string            `json:"share_url"`
+	Suggestions []DraftSuggestion `json:"suggestions,omitempty"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// Pending returns the suggestions still awaiting the owner's review.
+func (d SpellDraft) Pending() []DraftSuggestion {
+	var out []DraftSuggestion
+	for _, s := range d.Suggestions {
+		if s.Status == SuggestionPending {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// DraftSuggestion proposes replacing one span of a draft field with new text.
+type DraftSuggestion struct {
+	ID          uuid.UUID `json:"id"`
+	DraftID     uuid.UUID `json:"draft_id"`
+	AuthorLogin string    `json:"author_login"`
+	Field       string    `json:"field"` // "text" or "context"
+	Original    string    `json:"original"`
+	Replacement string    `json:"replacement"`
+	Note        string    `json:"note,omitempty"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Apply replaces the first occurrence of the suggestion's original span in
+// text. It reports false if the span is no longer there, e.g. because the
+// owner has since edited that part of the draft.
+func (s DraftSuggestion) Apply(text string) (string, bool) {
+	if s.Original == "" || !strings.Contains(text, s.Original) {
+		return text, false
+	}
+	return strings.Replace(text, s.Original, s.Replacement, 1), true
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "testing"
+
+func TestDraftSuggestionApply(t *testing.T) {
+	s := DraftSuggestion{Original: "always", Replacement: "usually"}
+	got, ok := s.Apply("always bisect; always log")
+	if !ok || got != "usually bisect; always log" {
+		t.Errorf("Apply = %q, %v; want first occurrence replaced", got, ok)
+	}
+	if _, ok := s.Apply("never bisect"); ok {
+		t.Error("expected Apply to fail when the span is gone")
+	}
+	if _, ok := (DraftSuggestion{Replacement: "x"}).Apply("text"); ok {
+		t.Error("expected Apply to fail for an empty span")
+	}
+}
+
+func TestSpellDraftPending(t *testing.T) {
+	d := SpellDraft{Suggestions: []DraftSuggestion{
+		{Note: "a", Status: SuggestionPending},
+		{Note: "b", Status: SuggestionAccepted},
+		{Note: "c", Status: SuggestionPending},
+	}}
+	p := d.Pending()
+	if len(p) != 2 || p[0].Note != "a" || p[1].Note != "c" {
+		t.Errorf("Pending = %+v", p)
+	}
+}
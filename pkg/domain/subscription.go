@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Watchable target kinds.
+const (
+	WatchTargetSpell = "spell"
+	WatchTargetSeek  = "seek"
+)
+
+// Subscription is a watched spell or seek. The server counts new comments,
+// variants and answers since the watcher last read it.
+type Subscription struct {
+	ID             uuid.UUID  `json:"id"`
+	TargetType     string     `json:"target_type"` // "spell" or "seek"
+	TargetID       string     `json:"target_id"`
+	Title          string     `json:"title"`                   // spell preview or seek question
+	Unread         int        `json:"unread"`                  // new activity since last read
+	LastActivity   string     `json:"last_activity,omitempty"` // preview of the newest comment, variant or answer
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +18,27 @@ type Weapon struct {
 	GitHubForks    int       `json:"github_forks"`
 	GitHubLanguage string    `json:"github_language,omitempty"`
 	License        string    `json:"license,omitempty"`
+	Categories     []string  `json:"categories,omitempty"` // what kind of tool it is; see WeaponCategories
 	SaveCount      int       `json:"save_count"`
 	CreatedAt      time.Time `json:"created_at"`
 }
+
+// WeaponCategories are the kinds of tool a weapon can be filed under, in
+// the order the Grimoire's category bar shows them.
+var WeaponCategories = []string{
+	"cli",
+	"ai",
+	"devops",
+	"editor",
+	"testing",
+	"data",
+	"web",
+	"security",
+	"observability",
+	"library",
+}
+
+// ValidWeaponCategory reports whether category is one of WeaponCategories.
+func ValidWeaponCategory(category string) bool {
+	return slices.Contains(WeaponCategories, category)
+}